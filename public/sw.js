@@ -1,5 +1,5 @@
 /* Minimal service worker: caches shell and attempts background sync when available */
-const CACHE_VERSION = '2025-12-12-v2'; // Update this on each deploy
+const CACHE_VERSION = '2025-12-12-v3'; // Update this on each deploy
 const CACHE_NAME = `pinseekr-shell-${CACHE_VERSION}`;
 const ASSETS = [
   '/',
@@ -45,3 +45,14 @@ self.addEventListener('sync', (e) => {
     );
   }
 });
+
+self.addEventListener('notificationclick', (e) => {
+  e.notification.close();
+  e.waitUntil(
+    // bring the open round back to the front, or open the app
+    self.clients.matchAll({ type: 'window', includeUncontrolled: true }).then((clients) => {
+      if (clients.length > 0) return clients[0].focus();
+      return self.clients.openWindow('/');
+    })
+  );
+});
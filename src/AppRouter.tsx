@@ -12,6 +12,29 @@ const ScoreEntryPage = lazy(() => import("./pages/ScoreEntryPage"));
 const AchievementsPage = lazy(() => import("./pages/AchievementsPage"));
 const ProfilePage = lazy(() => import("./pages/ProfilePage"));
 const AccountInfoPage = lazy(() => import("./pages/AccountInfoPage"));
+const MarshalPage = lazy(() => import("./pages/MarshalPage"));
+const DisputesPage = lazy(() => import("./pages/DisputesPage"));
+const TvPage = lazy(() => import("./pages/TvPage"));
+const SponsorsPage = lazy(() => import("./pages/SponsorsPage"));
+const AcesPage = lazy(() => import("./pages/AcesPage"));
+const CourseStatusPage = lazy(() => import("./pages/CourseStatusPage"));
+const RentalsPage = lazy(() => import("./pages/RentalsPage"));
+const OccupancyPage = lazy(() => import("./pages/OccupancyPage"));
+const VouchersPage = lazy(() => import("./pages/VouchersPage"));
+const AccountsPage = lazy(() => import("./pages/AccountsPage"));
+const PublicStatsPage = lazy(() => import("./pages/PublicStatsPage"));
+const LessonsPage = lazy(() => import("./pages/LessonsPage"));
+const PracticePage = lazy(() => import("./pages/PracticePage"));
+const FeedPage = lazy(() => import("./pages/FeedPage"));
+const FriendsLeaderboardPage = lazy(() => import("./pages/FriendsLeaderboardPage"));
+const SeasonPage = lazy(() => import("./pages/SeasonPage"));
+const TerminalPage = lazy(() => import("./pages/TerminalPage"));
+const ShotReplayPage = lazy(() => import("./pages/ShotReplayPage"));
+const ScorerDevicesPage = lazy(() => import("./pages/ScorerDevicesPage"));
+const TournamentDrawPage = lazy(() => import("./pages/TournamentDrawPage"));
+const VirtualTournamentPage = lazy(() => import("./pages/VirtualTournamentPage"));
+const FantasyLeaguePage = lazy(() => import("./pages/FantasyLeaguePage"));
+const TeamEventPage = lazy(() => import("./pages/TeamEventPage"));
 
 export function AppRouter() {
   return (
@@ -24,8 +47,33 @@ export function AppRouter() {
           <Route path="/round/new" element={<NewRoundPage />} />
           <Route path="/score-entry" element={<ScoreEntryPage />} />
           <Route path="/join/:roundId" element={<JoinRoundPage />} />
+          <Route path="/rounds/:roundId/replay/:npub" element={<ShotReplayPage />} />
           <Route path="/achievements" element={<AchievementsPage />} />
           <Route path="/account" element={<AccountInfoPage />} />
+          <Route path="/marshal" element={<MarshalPage />} />
+          <Route path="/disputes" element={<DisputesPage />} />
+          <Route path="/tv/:tournamentId" element={<TvPage />} />
+          <Route path="/sponsors" element={<SponsorsPage />} />
+          <Route path="/courses/:courseId/aces" element={<AcesPage />} />
+          <Route path="/courses/:courseId/status" element={<CourseStatusPage />} />
+          <Route path="/courses/:courseId/rentals" element={<RentalsPage />} />
+          <Route path="/courses/:courseId/occupancy" element={<OccupancyPage />} />
+          <Route path="/courses/:courseId/vouchers" element={<VouchersPage />} />
+          <Route path="/courses/:courseId/vouchers/:voucherId" element={<VouchersPage />} />
+          <Route path="/courses/:courseId/accounts" element={<AccountsPage />} />
+          <Route path="/lessons" element={<LessonsPage />} />
+          <Route path="/lessons/:npub" element={<LessonsPage />} />
+          <Route path="/practice" element={<PracticePage />} />
+          <Route path="/feed" element={<FeedPage />} />
+          <Route path="/players/:npub/friends/leaderboard" element={<FriendsLeaderboardPage />} />
+          <Route path="/season" element={<SeasonPage />} />
+          <Route path="/terminal" element={<TerminalPage />} />
+          <Route path="/tournaments/:tournamentId/draw" element={<TournamentDrawPage />} />
+          <Route path="/tournaments/:tournamentId/devices" element={<ScorerDevicesPage />} />
+          <Route path="/tournaments/:tournamentId/virtual" element={<VirtualTournamentPage />} />
+          <Route path="/fantasy/:leagueId" element={<FantasyLeaguePage />} />
+          <Route path="/team-events/:eventId" element={<TeamEventPage />} />
+          <Route path="/stats/public" element={<PublicStatsPage />} />
           <Route path="/profile/:nip19Id" element={<ProfilePage />} />
           {/* NIP-19 route for npub1, note1, naddr1, nevent1, nprofile1 */}
           <Route path="/:nip19" element={<NIP19Page />} />
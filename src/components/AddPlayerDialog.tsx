@@ -16,6 +16,7 @@ import { useToast } from '@/hooks/useToast';
 import { nip19 } from 'nostr-tools';
 import { useGolfProfile } from '@/hooks/useGolfProfile';
 import { useHandicapCalculation } from '@/hooks/useHandicapCalculation';
+import { useHandicapSettings } from '@/hooks/useHandicapSettings';
 
 interface AddPlayerDialogProps {
   open: boolean;
@@ -44,12 +45,13 @@ export const AddPlayerDialog: React.FC<AddPlayerDialogProps> = ({
   // Fetch profile data for the validated pubkey
   const { data: profileData } = useAuthor(validatedPubkey || '');
   const { data: golfProfile } = useGolfProfile(validatedPubkey || '');
-  const { data: handicapResult } = useHandicapCalculation(validatedPubkey || '');
+  const { committeePubkeys, requireAttestation } = useHandicapSettings();
+  const { data: handicapResult } = useHandicapCalculation(validatedPubkey || '', committeePubkeys, { requireAttestation });
 
   // Contact row component that fetches golf data
   const ContactRow: React.FC<{ friend: Contact }> = ({ friend }) => {
     const { data: friendGolfProfile } = useGolfProfile(friend.pubkey);
-    const { data: friendHandicapResult } = useHandicapCalculation(friend.pubkey);
+    const { data: friendHandicapResult } = useHandicapCalculation(friend.pubkey, committeePubkeys, { requireAttestation });
     
     const handicap = friendGolfProfile?.handicap ?? friendHandicapResult?.index ?? 0;
 
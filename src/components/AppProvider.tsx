@@ -17,6 +17,16 @@ interface AppProviderProps {
 const AppConfigSchema: z.ZodType<AppConfig, z.ZodTypeDef, unknown> = z.object({
   theme: z.enum(['dark', 'light', 'system']),
   relayUrl: z.string().url(),
+  contentFilter: z.object({
+    trustedPubkeys: z.array(z.string().regex(/^[a-f0-9]{64}$/)),
+    wotDepth: z.number().int().min(0).max(2),
+    minPow: z.number().int().min(0).max(32),
+    useMuteList: z.boolean(),
+  }).optional(),
+  handicap: z.object({
+    committeePubkeys: z.array(z.string().regex(/^[a-f0-9]{64}$/)),
+    requireAttestation: z.boolean().optional(),
+  }).optional(),
 });
 
 export function AppProvider(props: AppProviderProps) {
@@ -0,0 +1,134 @@
+import React, { useState } from 'react';
+import { nip19 } from 'nostr-tools';
+import { Card, CardContent, CardDescription, CardHeader, CardTitle } from '@/components/ui/card';
+import { Button } from '@/components/ui/button';
+import { Input } from '@/components/ui/input';
+import { Label } from '@/components/ui/label';
+import { Switch } from '@/components/ui/switch';
+import { Textarea } from '@/components/ui/textarea';
+import { Select, SelectContent, SelectItem, SelectTrigger, SelectValue } from '@/components/ui/select';
+import { useAppContext } from '@/hooks/useAppContext';
+import { useAuthor } from '@/hooks/useAuthor';
+import { useCurrentUser } from '@/hooks/useCurrentUser';
+import { useMuteList } from '@/hooks/useModeration';
+import { useToast } from '@/hooks/useToast';
+import { genUserName } from '@/lib/genUserName';
+import { DEFAULT_CONTENT_FILTER, type ContentFilterConfig } from '@/lib/golf/contentFilterEngine';
+
+function toPubkey(value: string): string | null {
+  const trimmed = value.trim();
+  if (/^[a-f0-9]{64}$/i.test(trimmed)) return trimmed.toLowerCase();
+  try {
+    const decoded = nip19.decode(trimmed);
+    return decoded.type === 'npub' ? decoded.data : null;
+  } catch {
+    return null;
+  }
+}
+
+function MutedRow({ pubkey, disabled, onUnmute }: { pubkey: string; disabled: boolean; onUnmute: () => void }) {
+  const author = useAuthor(pubkey);
+  const name = author.data?.metadata?.name ?? genUserName(pubkey);
+  return (
+    <div className="flex items-center justify-between gap-2 rounded border px-3 py-2">
+      <span className="text-sm truncate">{name}</span>
+      <Button size="sm" variant="outline" disabled={disabled} onClick={onUnmute}>Unmute</Button>
+    </div>
+  );
+}
+
+/**
+ * Spam filter settings for the golf feed, round chat and reactions: club
+ * admins and how far to trust their follows, a proof-of-work minimum, and
+ * whether to apply the user's mute list, which can be managed here too
+ */
+export default function ContentFilterSettings() {
+  const { config, updateConfig } = useAppContext();
+  const { toast } = useToast();
+  const { user } = useCurrentUser();
+  const { muted, unmute, isUpdating } = useMuteList();
+  const current = config.contentFilter ?? DEFAULT_CONTENT_FILTER;
+
+  const [admins, setAdmins] = useState(current.trustedPubkeys.map(p => nip19.npubEncode(p)).join('\n'));
+  const [wotDepth, setWotDepth] = useState(String(current.wotDepth));
+  const [minPow, setMinPow] = useState(String(current.minPow));
+  const [useMuteList, setUseMuteList] = useState(current.useMuteList);
+
+  const handleSave = () => {
+    const lines = admins.split(/[\s,]+/).filter(Boolean);
+    const trustedPubkeys = lines.map(toPubkey);
+    if (trustedPubkeys.some(p => p === null)) {
+      toast({ title: 'Invalid admin key', description: 'Enter one npub or hex public key per line.', variant: 'destructive' });
+      return;
+    }
+
+    const contentFilter: ContentFilterConfig = {
+      trustedPubkeys: [...new Set(trustedPubkeys as string[])],
+      wotDepth: Number(wotDepth),
+      minPow: Math.min(32, Math.max(0, Math.floor(Number(minPow) || 0))),
+      useMuteList,
+    };
+    updateConfig(prev => ({ ...prev, contentFilter }));
+    toast({ title: 'Spam filter saved', description: 'The feed and round chat now use these settings.' });
+  };
+
+  return (
+    <Card>
+      <CardHeader>
+        <CardTitle>Spam Filter</CardTitle>
+        <CardDescription>What shows in the golf feed, round chat and reactions</CardDescription>
+      </CardHeader>
+      <CardContent className="space-y-4">
+        <div className="space-y-2">
+          <Label htmlFor="filter-admins">Club admins</Label>
+          <Textarea
+            id="filter-admins"
+            value={admins}
+            onChange={(e) => setAdmins(e.target.value)}
+            placeholder="npub1... (one per line)"
+            rows={3}
+          />
+        </div>
+        <div className="grid grid-cols-2 gap-3">
+          <div className="space-y-2">
+            <Label>Web of trust</Label>
+            <Select value={wotDepth} onValueChange={setWotDepth}>
+              <SelectTrigger>
+                <SelectValue />
+              </SelectTrigger>
+              <SelectContent>
+                <SelectItem value="0">Off</SelectItem>
+                <SelectItem value="1">Admins' follows</SelectItem>
+                <SelectItem value="2">Follows of follows</SelectItem>
+              </SelectContent>
+            </Select>
+          </div>
+          <div className="space-y-2">
+            <Label htmlFor="filter-pow">Minimum proof of work</Label>
+            <Input
+              id="filter-pow"
+              type="number"
+              min={0}
+              max={32}
+              value={minPow}
+              onChange={(e) => setMinPow(e.target.value)}
+            />
+          </div>
+        </div>
+        <div className="flex items-center justify-between">
+          <Label htmlFor="filter-mutes">Hide people I've muted</Label>
+          <Switch id="filter-mutes" checked={useMuteList} onCheckedChange={setUseMuteList} />
+        </div>
+        <Button onClick={handleSave} className="w-full">Save</Button>
+        {user && muted.length > 0 && (
+          <div className="space-y-2">
+            <Label>Muted players</Label>
+            {muted.map(pubkey => (
+              <MutedRow key={pubkey} pubkey={pubkey} disabled={isUpdating} onUnmute={() => unmute(pubkey)} />
+            ))}
+          </div>
+        )}
+      </CardContent>
+    </Card>
+  );
+}
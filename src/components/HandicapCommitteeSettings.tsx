@@ -0,0 +1,78 @@
+import { useState } from 'react';
+import { nip19 } from 'nostr-tools';
+import { Card, CardContent, CardDescription, CardHeader, CardTitle } from '@/components/ui/card';
+import { Button } from '@/components/ui/button';
+import { Label } from '@/components/ui/label';
+import { Switch } from '@/components/ui/switch';
+import { Textarea } from '@/components/ui/textarea';
+import { useAppContext } from '@/hooks/useAppContext';
+import { useHandicapSettings } from '@/hooks/useHandicapSettings';
+import { useToast } from '@/hooks/useToast';
+import type { HandicapSettings } from '@/lib/golf/handicapCalculator';
+
+function toPubkey(value: string): string | null {
+  const trimmed = value.trim();
+  if (/^[a-f0-9]{64}$/i.test(trimmed)) return trimmed.toLowerCase();
+  try {
+    const decoded = nip19.decode(trimmed);
+    return decoded.type === 'npub' ? decoded.data : null;
+  } catch {
+    return null;
+  }
+}
+
+/**
+ * The handicap committees whose penalty scores and dispute rulings count
+ * towards handicap indexes shown in the app, and whether cards need a
+ * marker's countersignature to count
+ */
+export default function HandicapCommitteeSettings() {
+  const { updateConfig } = useAppContext();
+  const current = useHandicapSettings();
+  const { toast } = useToast();
+
+  const [committee, setCommittee] = useState(current.committeePubkeys.map(p => nip19.npubEncode(p)).join('\n'));
+  const [requireAttestation, setRequireAttestation] = useState(current.requireAttestation ?? false);
+
+  const handleSave = () => {
+    const committeePubkeys = committee.split(/[\s,]+/).filter(Boolean).map(toPubkey);
+    if (committeePubkeys.some(p => p === null)) {
+      toast({ title: 'Invalid committee key', description: 'Enter one npub or hex public key per line.', variant: 'destructive' });
+      return;
+    }
+
+    const handicap: HandicapSettings = {
+      ...current,
+      committeePubkeys: [...new Set(committeePubkeys as string[])],
+      requireAttestation,
+    };
+    updateConfig(prev => ({ ...prev, handicap }));
+    toast({ title: 'Handicap settings saved', description: 'Handicaps are now worked out with these settings.' });
+  };
+
+  return (
+    <Card>
+      <CardHeader>
+        <CardTitle>Handicap Committee</CardTitle>
+        <CardDescription>Whose penalty scores and rulings count towards handicaps, and which cards count</CardDescription>
+      </CardHeader>
+      <CardContent className="space-y-4">
+        <div className="space-y-2">
+          <Label htmlFor="handicap-committee">Committee members</Label>
+          <Textarea
+            id="handicap-committee"
+            value={committee}
+            onChange={(e) => setCommittee(e.target.value)}
+            placeholder="npub1... (one per line)"
+            rows={3}
+          />
+        </div>
+        <div className="flex items-center justify-between">
+          <Label htmlFor="handicap-attestation">Only count cards a marker has signed</Label>
+          <Switch id="handicap-attestation" checked={requireAttestation} onCheckedChange={setRequireAttestation} />
+        </div>
+        <Button onClick={handleSave} className="w-full">Save</Button>
+      </CardContent>
+    </Card>
+  );
+}
@@ -3,6 +3,12 @@ import { Link } from 'react-router-dom';
 import { ThemeToggle } from '@/components/ThemeToggle';
 import { LoginArea } from '@/components/auth/LoginArea';
 import { OutboxPanel } from '@/components/OutboxPanel';
+import { useDisputeNotifications } from '@/hooks/useDisputes';
+import { useAchievementNotifications } from '@/hooks/useAchievements';
+import { useLessonNotifications } from '@/hooks/useLessons';
+import { usePrivacyRetention } from '@/hooks/usePrivacy';
+import { useHandicapRevisionJob } from '@/hooks/useHandicapHistory';
+import { useHealthCheckJob } from '@/hooks/useScheduledJobs';
 
 interface LayoutProps {
   children: React.ReactNode;
@@ -10,6 +16,13 @@ interface LayoutProps {
 }
 
 export const Layout: React.FC<LayoutProps> = ({ children, showHeader = true }) => {
+  useDisputeNotifications();
+  useAchievementNotifications();
+  useLessonNotifications();
+  usePrivacyRetention();
+  useHandicapRevisionJob();
+  useHealthCheckJob();
+
   return (
     <>
       {showHeader && (
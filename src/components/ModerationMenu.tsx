@@ -0,0 +1,121 @@
+import { useState } from 'react';
+import type { NostrEvent } from '@nostrify/nostrify';
+import { MoreHorizontal } from 'lucide-react';
+import { Button } from '@/components/ui/button';
+import { Label } from '@/components/ui/label';
+import { Textarea } from '@/components/ui/textarea';
+import { Dialog, DialogContent, DialogDescription, DialogFooter, DialogHeader, DialogTitle } from '@/components/ui/dialog';
+import { DropdownMenu, DropdownMenuContent, DropdownMenuItem, DropdownMenuTrigger } from '@/components/ui/dropdown-menu';
+import { Select, SelectContent, SelectItem, SelectTrigger, SelectValue } from '@/components/ui/select';
+import { useCurrentUser } from '@/hooks/useCurrentUser';
+import { useMuteList, useReport } from '@/hooks/useModeration';
+import { useToast } from '@/hooks/useToast';
+import { REPORT_TYPES, type ReportType } from '@/lib/golf/moderationEngine';
+
+interface ModerationMenuProps {
+  event: NostrEvent;
+  authorName: string;
+}
+
+/**
+ * "More" menu on notes and comments for muting the author or reporting the
+ * content. Hidden for logged-out visitors and on the member's own posts.
+ */
+export function ModerationMenu({ event, authorName }: ModerationMenuProps) {
+  const { user } = useCurrentUser();
+  const { muted, mute, unmute, isUpdating } = useMuteList();
+  const report = useReport();
+  const { toast } = useToast();
+  const [reportOpen, setReportOpen] = useState(false);
+  const [reportType, setReportType] = useState<ReportType>('spam');
+  const [reason, setReason] = useState('');
+
+  if (!user || user.pubkey === event.pubkey) return null;
+
+  const isMuted = muted.includes(event.pubkey);
+
+  const handleMute = async () => {
+    try {
+      if (isMuted) {
+        await unmute(event.pubkey);
+        toast({ title: `Unmuted ${authorName}` });
+      } else {
+        await mute(event.pubkey);
+        toast({ title: `Muted ${authorName}`, description: 'Their posts are hidden from your feed and round chat.' });
+      }
+    } catch (error) {
+      toast({
+        title: 'Could not update your mute list',
+        description: error instanceof Error ? error.message : 'Please try again',
+        variant: 'destructive',
+      });
+    }
+  };
+
+  const handleReport = async () => {
+    try {
+      await report.mutateAsync({ type: reportType, target: { pubkey: event.pubkey, id: event.id }, reason });
+      toast({ title: 'Report sent', description: 'Thanks, club admins will take a look.' });
+      setReportOpen(false);
+      setReason('');
+    } catch (error) {
+      toast({
+        title: 'Report failed',
+        description: error instanceof Error ? error.message : 'Please try again',
+        variant: 'destructive',
+      });
+    }
+  };
+
+  return (
+    <>
+      <DropdownMenu>
+        <DropdownMenuTrigger asChild>
+          <Button variant="ghost" size="sm" className="h-8 px-2 text-xs" aria-label="More options">
+            <MoreHorizontal className="h-3 w-3" />
+          </Button>
+        </DropdownMenuTrigger>
+        <DropdownMenuContent align="end">
+          <DropdownMenuItem disabled={isUpdating} onClick={handleMute}>
+            {isMuted ? `Unmute ${authorName}` : `Mute ${authorName}`}
+          </DropdownMenuItem>
+          <DropdownMenuItem onClick={() => setReportOpen(true)}>Report</DropdownMenuItem>
+        </DropdownMenuContent>
+      </DropdownMenu>
+
+      <Dialog open={reportOpen} onOpenChange={setReportOpen}>
+        <DialogContent>
+          <DialogHeader>
+            <DialogTitle>Report post</DialogTitle>
+            <DialogDescription>Reports are public Nostr events that club admins can act on.</DialogDescription>
+          </DialogHeader>
+          <div className="space-y-4">
+            <div className="space-y-2">
+              <Label>Reason</Label>
+              <Select value={reportType} onValueChange={(value) => setReportType(value as ReportType)}>
+                <SelectTrigger>
+                  <SelectValue />
+                </SelectTrigger>
+                <SelectContent>
+                  {REPORT_TYPES.map(({ type, label }) => (
+                    <SelectItem key={type} value={type}>{label}</SelectItem>
+                  ))}
+                </SelectContent>
+              </Select>
+            </div>
+            <div className="space-y-2">
+              <Label htmlFor="report-details">Details (optional)</Label>
+              <Textarea id="report-details" value={reason} onChange={(e) => setReason(e.target.value)} rows={3} />
+            </div>
+          </div>
+          <DialogFooter>
+            <Button variant="outline" onClick={() => setReportOpen(false)}>Cancel</Button>
+            <Button onClick={handleReport} disabled={report.status === 'pending'}>
+              {report.status === 'pending' ? 'Sending...' : 'Send report'}
+            </Button>
+          </DialogFooter>
+        </DialogContent>
+      </Dialog>
+    </>
+  );
+}
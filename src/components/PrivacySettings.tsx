@@ -0,0 +1,209 @@
+import React, { useEffect, useState } from 'react';
+import { Download, Trash2 } from 'lucide-react';
+import { Card, CardContent, CardDescription, CardHeader, CardTitle } from '@/components/ui/card';
+import { Button } from '@/components/ui/button';
+import { Dialog, DialogContent, DialogDescription, DialogFooter, DialogHeader, DialogTitle } from '@/components/ui/dialog';
+import { Input } from '@/components/ui/input';
+import { Label } from '@/components/ui/label';
+import { Switch } from '@/components/ui/switch';
+import { Select, SelectContent, SelectItem, SelectTrigger, SelectValue } from '@/components/ui/select';
+import { useDataErasure, usePrivacy } from '@/hooks/usePrivacy';
+import { useToast } from '@/hooks/useToast';
+import {
+  CONSENT_PURPOSES,
+  RETENTION_CHOICES,
+  hasConsent,
+  setConsent,
+  type ConsentPurpose,
+} from '@/lib/golf/privacyEngine';
+
+const PURPOSES = Object.keys(CONSENT_PURPOSES) as ConsentPurpose[];
+
+const retentionLabel = (days: number) => (days % 365 === 0 ? `${days / 365} ${days === 365 ? 'year' : 'years'}` : `${days} days`);
+
+function RetentionSelect({ id, value, onChange }: { id: string; value: number | null; onChange: (days: number | null) => void }) {
+  return (
+    <Select value={value === null ? 'keep' : String(value)} onValueChange={(v) => onChange(v === 'keep' ? null : Number(v))}>
+      <SelectTrigger id={id} className="w-32">
+        <SelectValue />
+      </SelectTrigger>
+      <SelectContent>
+        <SelectItem value="keep">Keep</SelectItem>
+        {RETENTION_CHOICES.map(days => (
+          <SelectItem key={days} value={String(days)}>{retentionLabel(days)}</SelectItem>
+        ))}
+      </SelectContent>
+    </Select>
+  );
+}
+
+function EraseDataDialog({ open, onOpenChange }: { open: boolean; onOpenChange: (open: boolean) => void }) {
+  const { eraseData, isErasing } = useDataErasure();
+  const { toast } = useToast();
+  const [confirmation, setConfirmation] = useState('');
+
+  const handleErase = async () => {
+    try {
+      const summary = await eraseData();
+      const failed = summary.mediaFailed > 0 ? ` ${summary.mediaFailed} uploads could not be deleted.` : '';
+      toast({
+        title: 'Your golf data was erased',
+        description: `Asked relays to delete ${summary.events} events and deleted ${summary.media} uploads.${failed}`,
+      });
+      setConfirmation('');
+      onOpenChange(false);
+    } catch (error) {
+      toast({ title: 'Could not erase your data', description: error instanceof Error ? error.message : undefined, variant: 'destructive' });
+    }
+  };
+
+  return (
+    <Dialog open={open} onOpenChange={onOpenChange}>
+      <DialogContent>
+        <DialogHeader>
+          <DialogTitle>Delete my data</DialogTitle>
+          <DialogDescription>
+            Your rounds, scores, shots, bookings, posts and uploaded photos are deleted, and this device forgets your rounds.
+            Relays that honour deletion requests remove your events; some may keep copies. Rounds and reports other people
+            published that mention you stay with them. Your Nostr account itself is not deleted.
+          </DialogDescription>
+        </DialogHeader>
+        <div className="space-y-1">
+          <Label htmlFor="erase-confirmation">Type DELETE to confirm</Label>
+          <Input id="erase-confirmation" value={confirmation} onChange={(e) => setConfirmation(e.target.value)} autoComplete="off" />
+        </div>
+        <DialogFooter>
+          <Button variant="outline" onClick={() => onOpenChange(false)}>Cancel</Button>
+          <Button variant="destructive" disabled={confirmation !== 'DELETE' || isErasing} onClick={handleErase}>
+            {isErasing ? 'Deleting…' : 'Delete everything'}
+          </Button>
+        </DialogFooter>
+      </DialogContent>
+    </Dialog>
+  );
+}
+
+/**
+ * The member's consents and retention periods, a download of everything
+ * stored about them, and erasing it
+ */
+export default function PrivacySettings() {
+  const { settings, isLoading, saveSettings, isSaving, exportData } = usePrivacy();
+  const { toast } = useToast();
+  const [consents, setConsents] = useState<Record<ConsentPurpose, boolean>>(() =>
+    Object.fromEntries(PURPOSES.map(p => [p, hasConsent(settings, p)])) as Record<ConsentPurpose, boolean>
+  );
+  const [gpsDays, setGpsDays] = useState(settings.gpsRetentionDays);
+  const [personalDays, setPersonalDays] = useState(settings.personalRetentionDays);
+  const [isExporting, setIsExporting] = useState(false);
+  const [showErase, setShowErase] = useState(false);
+
+  // Show the saved settings once they load
+  useEffect(() => {
+    setConsents(Object.fromEntries(PURPOSES.map(p => [p, hasConsent(settings, p)])) as Record<ConsentPurpose, boolean>);
+    setGpsDays(settings.gpsRetentionDays);
+    setPersonalDays(settings.personalRetentionDays);
+  }, [settings]);
+
+  const handleSave = async () => {
+    const now = Date.now();
+    // Only a changed consent gets a new date
+    const next = PURPOSES.reduce(
+      (s, p) => (consents[p] === hasConsent(s, p) ? s : setConsent(s, p, consents[p], now)),
+      settings,
+    );
+    try {
+      await saveSettings({ consents: next.consents, gpsRetentionDays: gpsDays, personalRetentionDays: personalDays });
+      toast({ title: 'Privacy settings saved' });
+    } catch (error) {
+      toast({ title: 'Could not save privacy settings', description: error instanceof Error ? error.message : undefined, variant: 'destructive' });
+    }
+  };
+
+  const handleExport = async () => {
+    setIsExporting(true);
+    try {
+      const data = await exportData();
+      const blob = new Blob([JSON.stringify(data, null, 2)], { type: 'application/json' });
+      const url = URL.createObjectURL(blob);
+      const a = document.createElement('a');
+      a.href = url;
+      a.download = `pinseekr-data-${data.npub.slice(0, 16)}.json`;
+      document.body.appendChild(a);
+      a.click();
+      document.body.removeChild(a);
+      URL.revokeObjectURL(url);
+    } catch (error) {
+      toast({ title: 'Could not export your data', description: error instanceof Error ? error.message : undefined, variant: 'destructive' });
+    } finally {
+      setIsExporting(false);
+    }
+  };
+
+  return (
+    <Card>
+      <CardHeader>
+        <CardTitle>Privacy</CardTitle>
+        <CardDescription>How your data is used and how long it is kept</CardDescription>
+      </CardHeader>
+      <CardContent className="space-y-4">
+        {PURPOSES.map(purpose => {
+          const record = settings.consents.find(c => c.purpose === purpose);
+          return (
+            <div key={purpose} className="flex items-center justify-between gap-4">
+              <div>
+                <Label htmlFor={`consent-${purpose}`}>{CONSENT_PURPOSES[purpose].name}</Label>
+                <p className="text-xs text-muted-foreground">
+                  {CONSENT_PURPOSES[purpose].description}
+                  {record && ` · ${record.granted ? 'Agreed' : 'Withdrawn'} ${new Date(record.at).toLocaleDateString()}`}
+                </p>
+              </div>
+              <Switch
+                id={`consent-${purpose}`}
+                checked={consents[purpose]}
+                disabled={isLoading}
+                onCheckedChange={(checked) => setConsents(prev => ({ ...prev, [purpose]: checked }))}
+              />
+            </div>
+          );
+        })}
+
+        <div className="flex items-center justify-between gap-4">
+          <div>
+            <Label htmlFor="retention-gps">Keep shot positions</Label>
+            <p className="text-xs text-muted-foreground">Older shots keep their club and distance</p>
+          </div>
+          <RetentionSelect id="retention-gps" value={gpsDays} onChange={setGpsDays} />
+        </div>
+
+        <div className="flex items-center justify-between gap-4">
+          <div>
+            <Label htmlFor="retention-personal">Keep rounds on this device</Label>
+            <p className="text-xs text-muted-foreground">Finished rounds and scores saved offline</p>
+          </div>
+          <RetentionSelect id="retention-personal" value={personalDays} onChange={setPersonalDays} />
+        </div>
+
+        <div className="flex gap-2">
+          <Button className="flex-1" disabled={isLoading || isSaving} onClick={handleSave}>
+            {isSaving ? 'Saving…' : 'Save'}
+          </Button>
+          <Button variant="outline" className="flex-1" disabled={isExporting} onClick={handleExport}>
+            <Download className="h-4 w-4 mr-2" />
+            {isExporting ? 'Exporting…' : 'My data'}
+          </Button>
+        </div>
+        <p className="text-xs text-muted-foreground">
+          The export has every event you published, events by others that mention you, and what this device keeps.
+          Retention runs while the app is open.
+        </p>
+
+        <Button variant="destructive" className="w-full" onClick={() => setShowErase(true)}>
+          <Trash2 className="h-4 w-4 mr-2" />
+          Delete my data
+        </Button>
+        <EraseDataDialog open={showErase} onOpenChange={setShowErase} />
+      </CardContent>
+    </Card>
+  );
+}
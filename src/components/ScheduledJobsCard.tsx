@@ -0,0 +1,54 @@
+import { Card, CardContent, CardDescription, CardHeader, CardTitle } from '@/components/ui/card';
+import { Badge } from '@/components/ui/badge';
+import { Button } from '@/components/ui/button';
+import { useScheduledJobs } from '@/hooks/useScheduledJobs';
+import { useToast } from '@/hooks/useToast';
+
+/**
+ * The background jobs running while the app is open (outbox sync,
+ * reminders, watches), with their last and next runs
+ */
+export function ScheduledJobsCard() {
+  const { jobs, runNow } = useScheduledJobs();
+  const { toast } = useToast();
+
+  const handleRun = async (id: string) => {
+    try {
+      await runNow(id);
+    } catch (error) {
+      toast({ title: 'Could not run the job', description: error instanceof Error ? error.message : undefined, variant: 'destructive' });
+    }
+  };
+
+  return (
+    <Card>
+      <CardHeader>
+        <CardTitle>Background Jobs</CardTitle>
+        <CardDescription>Work this device does on a schedule while the app is open</CardDescription>
+      </CardHeader>
+      <CardContent className="space-y-2">
+        {jobs.length === 0 ? (
+          <p className="text-sm text-muted-foreground">No jobs are running.</p>
+        ) : (
+          jobs.map(job => (
+            <div key={job.id} className="flex items-center justify-between gap-2 rounded border p-3">
+              <div className="min-w-0">
+                <div className="text-sm font-medium truncate">{job.name}</div>
+                <div className="text-xs text-muted-foreground truncate">
+                  {job.lastRunAt ? `Last run ${new Date(job.lastRunAt).toLocaleTimeString()}` : 'Not run yet'}
+                  {job.lastDurationMs !== undefined && ` (${job.lastDurationMs} ms)`}
+                  {job.nextRunAt && ` · next ${new Date(job.nextRunAt).toLocaleTimeString()}`}
+                </div>
+                {job.lastError && <div className="text-xs text-destructive truncate">{job.lastError}</div>}
+              </div>
+              <div className="flex items-center gap-2">
+                {job.running && <Badge variant="secondary">Running</Badge>}
+                <Button size="sm" variant="outline" disabled={job.running} onClick={() => handleRun(job.id)}>Run</Button>
+              </div>
+            </div>
+          ))
+        )}
+      </CardContent>
+    </Card>
+  );
+}
@@ -18,6 +18,8 @@ import {
 } from "@/components/ui/popover";
 import { useAddGolfCourse, type GolfCourse } from "@/hooks/useGolfCourses";
 import { useCurrentUser } from "@/hooks/useCurrentUser";
+import { useOsmCourse } from "@/hooks/useOsmCourse";
+import { osmToImportedCourse, type OsmArea } from "@/lib/golf/osmImport";
 import { useUploadFile } from "@/hooks/useUploadFile";
 import { useToast } from "@/hooks/useToast";
 
@@ -66,24 +68,66 @@ export function AddCourseDialog({ open, onOpenChange, onCourseAdded, existingCou
   // State for drag mode to show visual zones
   const [isDragging, setIsDragging] = useState<{sectionIndex: number, hole: number} | null>(null);
 
+  // Filling the form from an OpenStreetMap course relation
+  const [osmRelation, setOsmRelation] = useState('');
+  const [osmArea, setOsmArea] = useState<OsmArea | null>(null);
+  const { data: osmData, isFetching: isFetchingOsm, error: osmError } = useOsmCourse(osmArea);
+  const [osmCourse, setOsmCourse] = useState<GolfCourse | null>(null);
+
+  useEffect(() => {
+    if (!osmData) return;
+    if (osmData.holes.length === 0) {
+      toast({ title: "No holes found", description: "That relation has no mapped golf holes.", variant: "destructive" });
+      return;
+    }
+    const imported = osmToImportedCourse(osmData);
+    setOsmCourse({
+      ...imported,
+      id: '',
+      author: '',
+      createdAt: 0,
+      totalPar: Object.values(imported.holes).reduce((sum, par) => sum + par, 0),
+    });
+    if (osmData.warnings.length > 0) {
+      toast({ title: "Check the imported holes", description: osmData.warnings.join(' ') });
+    }
+  }, [osmData, toast]);
+
+  useEffect(() => {
+    if (osmError) {
+      toast({ title: "OpenStreetMap import failed", description: osmError.message, variant: "destructive" });
+    }
+  }, [osmError, toast]);
+
+  // An import not saved is dropped when the dialog closes
+  useEffect(() => {
+    if (!open) {
+      setOsmCourse(null);
+      setOsmArea(null);
+    }
+  }, [open]);
+
+  // The course the form starts from: an import being reviewed, or the course being edited
+  const sourceCourse = osmCourse ?? existingCourse;
+
   const getDefaultValues = useCallback((): CourseFormData => {
     const defaultTees = ['Blue', 'White'];
     
-    if (existingCourse) {
+    if (sourceCourse) {
       // Convert existing course to section format
-      const holes = existingCourse.holes;
+      const holes = sourceCourse.holes;
       const totalHoles = Object.keys(holes).length;
       const sections: NineHoleSection[] = [];
       
       // Get tee names from existing course or use defaults
-      const courseTees = existingCourse.tees && existingCourse.tees.length > 0 
-        ? existingCourse.tees 
+      const courseTees = sourceCourse.tees && sourceCourse.tees.length > 0 
+        ? sourceCourse.tees 
         : defaultTees;
       
       // Detect handicap style from existing handicaps
       let detectedStyle: HandicapStyle = 'combined-18';
-      if (existingCourse.handicaps) {
-        const hcValues = Object.values(existingCourse.handicaps);
+      if (sourceCourse.handicaps) {
+        const hcValues = Object.values(sourceCourse.handicaps);
         const maxHc = Math.max(...hcValues);
         const numSections = Math.ceil(totalHoles / 9);
         
@@ -116,19 +160,19 @@ export function AddCourseDialog({ open, onOpenChange, onCourseAdded, existingCou
         for (let hole = sectionStart; hole <= sectionEnd; hole++) {
           const relativeHole = ((hole - 1) % 9) + 1; // Convert to 1-9 within section
           sectionHoles[relativeHole] = holes[hole] || 4;
-          sectionHandicaps[relativeHole] = existingCourse.handicaps?.[hole] || hole;
+          sectionHandicaps[relativeHole] = sourceCourse.handicaps?.[hole] || hole;
           
           // Get per-tee yardages from existing course
           for (const teeName of courseTees) {
-            const teeYards = existingCourse.teeYardages?.[teeName]?.[hole] 
-              || existingCourse.yardages?.[hole] // fallback to legacy single yardage
+            const teeYards = sourceCourse.teeYardages?.[teeName]?.[hole] 
+              || sourceCourse.yardages?.[hole] // fallback to legacy single yardage
               || 0;
             sectionTeeYardages[teeName][relativeHole] = teeYards;
           }
         }
         
         sections.push({
-          name: existingCourse.sections?.[sectionNumber - 1] || defaultSectionNames[sectionNumber - 1] || `9-Hole ${sectionNumber}`,
+          name: sourceCourse.sections?.[sectionNumber - 1] || defaultSectionNames[sectionNumber - 1] || `9-Hole ${sectionNumber}`,
           holes: sectionHoles,
           handicaps: sectionHandicaps,
           teeYardages: sectionTeeYardages
@@ -136,8 +180,8 @@ export function AddCourseDialog({ open, onOpenChange, onCourseAdded, existingCou
       }
       
       return {
-        name: existingCourse.name,
-        location: existingCourse.location,
+        name: sourceCourse.name,
+        location: sourceCourse.location,
         sections,
         tees: courseTees,
         handicapStyle: detectedStyle,
@@ -173,7 +217,7 @@ export function AddCourseDialog({ open, onOpenChange, onCourseAdded, existingCou
         }
       ],
     };
-  }, [existingCourse]);
+  }, [sourceCourse]);
 
   const { register, handleSubmit, reset, setValue, watch, formState: { errors } } = useForm<CourseFormData>({
     defaultValues: getDefaultValues(),
@@ -184,10 +228,10 @@ export function AddCourseDialog({ open, onOpenChange, onCourseAdded, existingCou
   const handicapStyle = watch('handicapStyle') || 'combined-18';
   // (yardage totals computed on demand where needed)
 
-  // Reset form when existingCourse changes
+  // Reset form when existingCourse changes, or an import arrives
   useEffect(() => {
     reset(getDefaultValues());
-  }, [existingCourse, reset, getDefaultValues]);
+  }, [sourceCourse, reset, getDefaultValues]);
 
   // Get the max handicap value based on handicap style
   const getMaxHandicap = (): number => {
@@ -492,6 +536,9 @@ export function AddCourseDialog({ open, onOpenChange, onCourseAdded, existingCou
         teeYardages,
         sections: sectionNames,
         tees: data.tees,
+        // Mapped greens and tee ratings aren't edited here, so carry them over
+        greens: sourceCourse?.greens,
+        teeRatings: sourceCourse?.teeRatings,
         scorecardImages: uploadedUrls && uploadedUrls.length > 0 ? uploadedUrls : undefined,
         // Pass existing ID to update the same Nostr event instead of creating a new one
         existingId: existingCourse?.id,
@@ -509,12 +556,16 @@ export function AddCourseDialog({ open, onOpenChange, onCourseAdded, existingCou
         teeYardages,
         sections: sectionNames,
         tees: data.tees,
+        greens: sourceCourse?.greens,
+        teeRatings: sourceCourse?.teeRatings,
         totalPar: Object.values(holes).reduce((sum, par) => sum + par, 0),
         author: existingCourse?.author || user.pubkey,
         createdAt: existingCourse?.createdAt || Date.now(),
       };
 
       onCourseAdded(course);
+      setOsmCourse(null);
+      setOsmArea(null);
       reset();
       
       toast({
@@ -565,6 +616,30 @@ export function AddCourseDialog({ open, onOpenChange, onCourseAdded, existingCou
         </DialogHeader>
 
         <form onSubmit={handleSubmit(onSubmit)} className="space-y-6 overflow-hidden">
+          {!existingCourse && (
+            <div className="space-y-2">
+              <Label htmlFor="osm-relation">Fill from OpenStreetMap</Label>
+              <div className="flex gap-2">
+                <Input
+                  id="osm-relation"
+                  value={osmRelation}
+                  onChange={(e) => setOsmRelation(e.target.value)}
+                  placeholder="Course relation id, e.g. 1234567"
+                  inputMode="numeric"
+                />
+                <Button
+                  type="button"
+                  variant="outline"
+                  disabled={!/^\d+$/.test(osmRelation.trim()) || isFetchingOsm}
+                  onClick={() => setOsmArea({ relationId: Number(osmRelation.trim()) })}
+                >
+                  {isFetchingOsm ? <Loader2 className="h-4 w-4 animate-spin" /> : 'Import'}
+                </Button>
+              </div>
+              <p className="text-xs text-muted-foreground">Pars, yardages and greens are taken from the map; review them before saving</p>
+            </div>
+          )}
+
           {/* Basic Info */}
           <div className="space-y-4">
             <div>
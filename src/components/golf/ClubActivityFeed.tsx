@@ -0,0 +1,72 @@
+import React from 'react';
+import { formatDistanceToNow } from 'date-fns';
+import { Flag, Sparkles, Trophy, CheckCircle2 } from 'lucide-react';
+import { Avatar, AvatarFallback, AvatarImage } from '@/components/ui/avatar';
+import { Skeleton } from '@/components/ui/skeleton';
+import { useAuthor } from '@/hooks/useAuthor';
+import { genUserName } from '@/lib/genUserName';
+import { cn } from '@/lib/utils';
+import type { ActivityItem, ActivityType } from '@/lib/golf/activityEngine';
+
+const activityIcons: Record<ActivityType, React.ComponentType<{ className?: string }>> = {
+  'round-started': Flag,
+  'round-finished': CheckCircle2,
+  highlight: Sparkles,
+  'competition-posted': Trophy,
+};
+
+function ActivityRow({ item, large }: { item: ActivityItem; large?: boolean }) {
+  const author = useAuthor(item.pubkey);
+  const metadata = author.data?.metadata;
+  const displayName = metadata?.name ?? genUserName(item.pubkey);
+  const Icon = activityIcons[item.type];
+
+  return (
+    <li className={cn('flex items-center gap-3', large && 'gap-5')}>
+      <Avatar className={large ? 'h-14 w-14' : 'h-8 w-8'}>
+        <AvatarImage src={metadata?.picture} />
+        <AvatarFallback className="text-xs">{displayName.charAt(0)}</AvatarFallback>
+      </Avatar>
+      <div className="flex-1 min-w-0">
+        <p className={cn('truncate', large ? 'text-3xl' : 'text-sm')}>
+          <span className="font-medium">{displayName}</span> {item.text.charAt(0).toLowerCase() + item.text.slice(1)}
+        </p>
+        <p className={cn('text-muted-foreground', large ? 'text-xl' : 'text-xs')}>
+          {formatDistanceToNow(new Date(item.createdAt), { addSuffix: true })}
+        </p>
+      </div>
+      <Icon className={cn(item.type === 'highlight' ? 'text-primary' : 'text-muted-foreground', large ? 'h-10 w-10' : 'h-4 w-4')} />
+    </li>
+  );
+}
+
+interface ClubActivityFeedProps {
+  items: ActivityItem[] | undefined;
+  isLoading?: boolean;
+  large?: boolean; // clubhouse display sizing
+  className?: string;
+}
+
+/**
+ * Activity on a course, newest first
+ */
+export function ClubActivityFeed({ items, isLoading, large, className }: ClubActivityFeedProps) {
+  if (isLoading) {
+    return (
+      <div className={cn('space-y-2', className)}>
+        <Skeleton className="h-8 w-full" />
+        <Skeleton className="h-8 w-full" />
+      </div>
+    );
+  }
+
+  if (!items || items.length === 0) {
+    return <p className={cn('text-muted-foreground', large ? 'text-2xl' : 'text-sm', className)}>No activity on the course today.</p>;
+  }
+
+  return (
+    <ul className={cn(large ? 'space-y-6' : 'space-y-3', className)}>
+      {items.map(item => <ActivityRow key={item.id} item={item} large={large} />)}
+    </ul>
+  );
+}
@@ -0,0 +1,167 @@
+import React, { useState } from 'react';
+import { Badge } from '@/components/ui/badge';
+import { Button } from '@/components/ui/button';
+import { Card, CardContent, CardDescription, CardHeader, CardTitle } from '@/components/ui/card';
+import { Input } from '@/components/ui/input';
+import { Label } from '@/components/ui/label';
+import { Textarea } from '@/components/ui/textarea';
+import { Select, SelectContent, SelectItem, SelectTrigger, SelectValue } from '@/components/ui/select';
+import { useAuthor } from '@/hooks/useAuthor';
+import { useConditionReports } from '@/hooks/useConditionReports';
+import { useCurrentUser } from '@/hooks/useCurrentUser';
+import { useToast } from '@/hooks/useToast';
+import { useUploadFile } from '@/hooks/useUploadFile';
+import { genUserName } from '@/lib/genUserName';
+import { affectedHoles, CONDITION_TYPE_NAMES, type ConditionReview, type ConditionType, type CurrentCondition } from '@/lib/golf/conditionEngine';
+import type { GolfCourse } from '@/hooks/useGolfCourses';
+
+function ConditionRow({ condition, isClub, onReview, disabled }: {
+  condition: CurrentCondition;
+  isClub: boolean;
+  onReview: (review: ConditionReview) => void;
+  disabled: boolean;
+}) {
+  const author = useAuthor(condition.reporter);
+  const name = author.data?.metadata?.name ?? genUserName(condition.reporter);
+
+  return (
+    <div className="space-y-2 rounded border p-3">
+      <div className="flex items-start justify-between gap-2">
+        <div className="min-w-0">
+          <div className="text-sm font-medium">{CONDITION_TYPE_NAMES[condition.type]}</div>
+          <div className="text-xs text-muted-foreground">
+            {name} · {new Date(condition.createdAt).toLocaleDateString()}
+          </div>
+        </div>
+        <Badge variant={condition.status === 'confirmed' ? 'default' : 'secondary'}>
+          {condition.status === 'confirmed' ? 'Confirmed by club' : 'Player report'}
+        </Badge>
+      </div>
+      {condition.description && <p className="text-sm">{condition.description}</p>}
+      {condition.images.length > 0 && (
+        <div className="flex gap-2 overflow-x-auto">
+          {condition.images.map(url => (
+            <a key={url} href={url} target="_blank" rel="noopener noreferrer">
+              <img src={url} alt={CONDITION_TYPE_NAMES[condition.type]} className="h-20 w-20 rounded object-cover" />
+            </a>
+          ))}
+        </div>
+      )}
+      {isClub && (
+        <div className="flex gap-2">
+          {condition.status !== 'confirmed' && (
+            <Button size="sm" variant="outline" disabled={disabled} onClick={() => onReview('confirmed')}>Confirm</Button>
+          )}
+          {condition.status !== 'confirmed' && (
+            <Button size="sm" variant="outline" disabled={disabled} onClick={() => onReview('rejected')}>Reject</Button>
+          )}
+          <Button size="sm" variant="outline" disabled={disabled} onClick={() => onReview('fixed')}>Fixed</Button>
+        </div>
+      )}
+    </div>
+  );
+}
+
+/**
+ * Course conditions reported by players, grouped by hole, with a form to
+ * report one. The club account reviews reports here.
+ */
+export function ConditionReportsCard({ course }: { course: Pick<GolfCourse, 'id' | 'author'> }) {
+  const { user } = useCurrentUser();
+  const { toast } = useToast();
+  const { conditions, submitReport, review, isPublishing } = useConditionReports(course);
+  const { mutateAsync: uploadFile, isPending: isUploading } = useUploadFile();
+  const [hole, setHole] = useState('');
+  const [type, setType] = useState<ConditionType>('gur');
+  const [description, setDescription] = useState('');
+  const [photos, setPhotos] = useState<File[]>([]);
+
+  const isClub = !!user && user.pubkey === course.author;
+
+  const handleSubmit = async () => {
+    const holeNumber = parseInt(hole);
+    if (!(holeNumber > 0)) {
+      toast({ title: 'Enter the hole number', variant: 'destructive' });
+      return;
+    }
+    try {
+      const uploaded = await Promise.all(photos.map(photo => uploadFile(photo)));
+      await submitReport({ hole: holeNumber, type, description: description.trim(), photos: uploaded });
+      toast({ title: 'Thanks, condition reported' });
+      setHole('');
+      setDescription('');
+      setPhotos([]);
+    } catch (error) {
+      toast({ title: 'Could not send the report', description: error instanceof Error ? error.message : undefined, variant: 'destructive' });
+    }
+  };
+
+  const handleReview = async (condition: CurrentCondition, verdict: ConditionReview) => {
+    try {
+      await review(condition, verdict);
+    } catch (error) {
+      toast({ title: 'Could not review the report', description: error instanceof Error ? error.message : undefined, variant: 'destructive' });
+    }
+  };
+
+  return (
+    <Card>
+      <CardHeader>
+        <CardTitle className="text-lg">Conditions</CardTitle>
+        <CardDescription>Reported by players out on the course</CardDescription>
+      </CardHeader>
+      <CardContent className="space-y-3">
+        {conditions.length === 0 ? (
+          <p className="text-sm text-muted-foreground">Nothing reported.</p>
+        ) : (
+          affectedHoles(conditions).map(h => (
+            <div key={h} className="space-y-2">
+              <div className="text-sm font-semibold">Hole {h}</div>
+              {conditions.filter(c => c.hole === h).map(condition => (
+                <ConditionRow
+                  key={`${condition.reporter}:${condition.reportId}`}
+                  condition={condition}
+                  isClub={isClub}
+                  disabled={isPublishing}
+                  onReview={(verdict) => handleReview(condition, verdict)}
+                />
+              ))}
+            </div>
+          ))
+        )}
+
+        {user && (
+          <div className="space-y-3 border-t pt-3">
+            <div className="grid grid-cols-2 gap-3">
+              <div className="space-y-2">
+                <Label htmlFor="condition-hole">Hole</Label>
+                <Input id="condition-hole" type="number" min={1} value={hole} onChange={(e) => setHole(e.target.value)} />
+              </div>
+              <div className="space-y-2">
+                <Label>Condition</Label>
+                <Select value={type} onValueChange={(value) => setType(value as ConditionType)}>
+                  <SelectTrigger>
+                    <SelectValue />
+                  </SelectTrigger>
+                  <SelectContent>
+                    {(Object.keys(CONDITION_TYPE_NAMES) as ConditionType[]).map(t => (
+                      <SelectItem key={t} value={t}>{CONDITION_TYPE_NAMES[t]}</SelectItem>
+                    ))}
+                  </SelectContent>
+                </Select>
+              </div>
+            </div>
+            <Textarea value={description} onChange={(e) => setDescription(e.target.value)} placeholder="Where on the hole, how bad" rows={2} />
+            <div className="space-y-2">
+              <Label htmlFor="condition-photos">Photos</Label>
+              <Input id="condition-photos" type="file" accept="image/*" multiple onChange={(e) => setPhotos(Array.from(e.target.files ?? []))} />
+            </div>
+            <Button className="w-full" onClick={handleSubmit} disabled={isPublishing || isUploading}>
+              {isUploading ? 'Uploading...' : 'Report Condition'}
+            </Button>
+          </div>
+        )}
+      </CardContent>
+    </Card>
+  );
+}
@@ -0,0 +1,54 @@
+import React, { useState } from 'react';
+import { Siren } from 'lucide-react';
+import { Alert, AlertDescription, AlertTitle } from '@/components/ui/alert';
+import { Button } from '@/components/ui/button';
+import { useCourseAlerts } from '@/hooks/useCourseAlerts';
+import { alertMapUrl, alertSummary } from '@/lib/golf/alertEngine';
+import type { GolfCourse } from '@/hooks/useGolfCourses';
+
+interface CourseAlertBannerProps {
+  course: Pick<GolfCourse, 'id' | 'author'> | null | undefined;
+  className?: string;
+}
+
+/**
+ * Urgent club alerts for players out on the course. New alerts also pop up
+ * as notifications once the player allows them.
+ */
+export function CourseAlertBanner({ course, className }: CourseAlertBannerProps) {
+  const { alerts } = useCourseAlerts(course, true);
+  const [permission, setPermission] = useState(() => (typeof Notification === 'undefined' ? 'denied' : Notification.permission));
+
+  const enableNotifications = async () => {
+    setPermission(await Notification.requestPermission());
+  };
+
+  if (!course) return null;
+
+  return (
+    <div className={className}>
+      {alerts.map(alert => {
+        const mapUrl = alertMapUrl(alert);
+        return (
+          <Alert key={alert.alertId} variant="destructive" className="mb-2">
+            <Siren className="h-4 w-4" />
+            <AlertTitle>{alertSummary(alert)}</AlertTitle>
+            <AlertDescription>
+              {alert.message}
+              {mapUrl && (
+                <a href={mapUrl} target="_blank" rel="noopener noreferrer" className="block underline">
+                  Show location
+                </a>
+              )}
+            </AlertDescription>
+          </Alert>
+        );
+      })}
+      {permission === 'default' && (
+        <Button variant="outline" size="sm" onClick={enableNotifications}>
+          Alert me about lightning and emergencies
+        </Button>
+      )}
+    </div>
+  );
+}
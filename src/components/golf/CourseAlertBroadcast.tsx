@@ -0,0 +1,99 @@
+import React, { useState } from 'react';
+import { Button } from '@/components/ui/button';
+import { Card, CardContent, CardDescription, CardHeader, CardTitle } from '@/components/ui/card';
+import { Input } from '@/components/ui/input';
+import { Label } from '@/components/ui/label';
+import { Textarea } from '@/components/ui/textarea';
+import { Select, SelectContent, SelectItem, SelectTrigger, SelectValue } from '@/components/ui/select';
+import { useCourseAlerts } from '@/hooks/useCourseAlerts';
+import { useToast } from '@/hooks/useToast';
+import { ALERT_DURATION_MINUTES, ALERT_TYPE_NAMES, alertSummary, type AlertType, type CourseAlert } from '@/lib/golf/alertEngine';
+import type { GolfCourse } from '@/hooks/useGolfCourses';
+
+/**
+ * Lets the club account broadcast an urgent alert to everyone playing the
+ * course, and give the all clear
+ */
+export function CourseAlertBroadcast({ course }: { course: Pick<GolfCourse, 'id' | 'author'> }) {
+  const { toast } = useToast();
+  const { alerts, broadcast, clear, isPublishing } = useCourseAlerts(course);
+  const [type, setType] = useState<AlertType>('lightning');
+  const [message, setMessage] = useState('');
+  const [hole, setHole] = useState('');
+  const [location, setLocation] = useState<CourseAlert['location']>();
+
+  const useMyLocation = () => {
+    navigator.geolocation.getCurrentPosition(
+      (position) => setLocation({ lat: position.coords.latitude, lon: position.coords.longitude }),
+      () => toast({ title: 'Could not get your location', variant: 'destructive' }),
+      { enableHighAccuracy: true, timeout: 10000 }
+    );
+  };
+
+  const handleBroadcast = async () => {
+    if (!message.trim()) {
+      toast({ title: 'Say what players should do', variant: 'destructive' });
+      return;
+    }
+    const holeNumber = parseInt(hole);
+    try {
+      await broadcast({ type, message: message.trim(), ...(holeNumber > 0 ? { hole: holeNumber } : {}), location });
+      toast({ title: 'Alert sent to players on the course' });
+      setMessage('');
+      setHole('');
+      setLocation(undefined);
+    } catch (error) {
+      toast({ title: 'Could not send the alert', description: error instanceof Error ? error.message : undefined, variant: 'destructive' });
+    }
+  };
+
+  const handleClear = async (alert: CourseAlert) => {
+    try {
+      await clear(alert);
+      toast({ title: 'All clear sent' });
+    } catch (error) {
+      toast({ title: 'Could not clear the alert', description: error instanceof Error ? error.message : undefined, variant: 'destructive' });
+    }
+  };
+
+  return (
+    <Card>
+      <CardHeader>
+        <CardTitle className="text-lg">Broadcast Alert</CardTitle>
+        <CardDescription>Reaches every player with a round open on this course. Alerts lapse after {ALERT_DURATION_MINUTES / 60} hours.</CardDescription>
+      </CardHeader>
+      <CardContent className="space-y-3">
+        {alerts.map(alert => (
+          <div key={alert.alertId} className="flex items-center justify-between gap-2 rounded border border-destructive p-3 text-sm">
+            <span>{alertSummary(alert)}: {alert.message}</span>
+            <Button size="sm" variant="outline" disabled={isPublishing} onClick={() => handleClear(alert)}>All Clear</Button>
+          </div>
+        ))}
+        <div className="grid grid-cols-2 gap-3">
+          <div className="space-y-2">
+            <Label>Type</Label>
+            <Select value={type} onValueChange={(value) => setType(value as AlertType)}>
+              <SelectTrigger>
+                <SelectValue />
+              </SelectTrigger>
+              <SelectContent>
+                {(Object.keys(ALERT_TYPE_NAMES) as AlertType[]).map(t => (
+                  <SelectItem key={t} value={t}>{ALERT_TYPE_NAMES[t]}</SelectItem>
+                ))}
+              </SelectContent>
+            </Select>
+          </div>
+          <div className="space-y-2">
+            <Label htmlFor="alert-hole">Hole (optional)</Label>
+            <Input id="alert-hole" type="number" min={1} value={hole} onChange={(e) => setHole(e.target.value)} />
+          </div>
+        </div>
+        <Textarea value={message} onChange={(e) => setMessage(e.target.value)} placeholder="Leave the course and head to the clubhouse now" rows={2} />
+        <Button variant="outline" className="w-full" onClick={useMyLocation}>
+          {location ? `Location set (${location.lat.toFixed(5)}, ${location.lon.toFixed(5)})` : 'Attach My Location'}
+        </Button>
+        <Button variant="destructive" className="w-full" onClick={handleBroadcast} disabled={isPublishing}>Send Alert</Button>
+      </CardContent>
+    </Card>
+  );
+}
@@ -0,0 +1,65 @@
+import React from 'react';
+import { Link } from 'react-router-dom';
+import { AlertTriangle } from 'lucide-react';
+import { Alert, AlertDescription, AlertTitle } from '@/components/ui/alert';
+import { useConditionReports } from '@/hooks/useConditionReports';
+import { useCourseNotices } from '@/hooks/useCourseNotices';
+import { affectedHoles } from '@/lib/golf/conditionEngine';
+import { courseCentre } from '@/lib/golf/caddieEngine';
+import { finishesInDaylight, sunTimes } from '@/lib/golf/daylightEngine';
+import { NOTICE_TYPE_NAMES, noticesOn, readingDate } from '@/lib/golf/maintenanceEngine';
+import type { GolfCourse } from '@/hooks/useGolfCourses';
+
+interface CourseNoticeAlertProps {
+  course: Pick<GolfCourse, 'id' | 'author' | 'name' | 'holes' | 'greens'> | null | undefined;
+  className?: string;
+}
+
+/**
+ * Warns players about closures and maintenance on the course today, holes
+ * where players have reported poor conditions, and starting too late to
+ * finish before dark
+ */
+export function CourseNoticeAlert({ course, className }: CourseNoticeAlertProps) {
+  const { notices } = useCourseNotices(course);
+  const { conditions } = useConditionReports(course);
+  const today = noticesOn(notices, new Date());
+  const holes = affectedHoles(conditions);
+
+  const now = new Date();
+  const centre = courseCentre(course?.greens);
+  const sun = centre ? sunTimes(readingDate(now), centre) : null;
+  const pars = course ? Object.keys(course.holes).map(Number).sort((a, b) => a - b).map(hole => course.holes[hole]) : [];
+  const tooLate = !!sun && now.getTime() > sun.sunrise && !finishesInDaylight(now.getTime(), sun.sunset, pars);
+
+  if (!course || (today.length === 0 && holes.length === 0 && !tooLate)) return null;
+
+  return (
+    <Alert className={className}>
+      <AlertTriangle className="h-4 w-4" />
+      <AlertTitle>{today.length > 0 ? 'Course notices today' : holes.length > 0 ? 'Course conditions' : 'Daylight'}</AlertTitle>
+      <AlertDescription>
+        <ul className="space-y-1">
+          {today.map(notice => (
+            <li key={notice.noticeId}>
+              {NOTICE_TYPE_NAMES[notice.type]}: {notice.title}
+              {notice.holes.length > 0 && ` (holes ${notice.holes.join(', ')})`}
+            </li>
+          ))}
+          {holes.length > 0 && (
+            <li>Conditions reported on {holes.length === 1 ? 'hole' : 'holes'} {holes.join(', ')}</li>
+          )}
+          {sun && tooLate && (
+            <li>
+              Sunset is at {new Date(sun.sunset).toLocaleTimeString([], { hour: '2-digit', minute: '2-digit' })}; starting
+              now, {pars.length} holes are unlikely to finish in daylight
+            </li>
+          )}
+        </ul>
+        <Link to={`/courses/${course.id}/status`} className="text-sm text-primary hover:underline">
+          Course status
+        </Link>
+      </AlertDescription>
+    </Alert>
+  );
+}
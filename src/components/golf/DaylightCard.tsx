@@ -0,0 +1,46 @@
+import React from 'react';
+import { Card, CardContent, CardHeader, CardTitle } from '@/components/ui/card';
+import { courseCentre } from '@/lib/golf/caddieEngine';
+import { lastTeeTimes, sunTimes } from '@/lib/golf/daylightEngine';
+import { readingDate } from '@/lib/golf/maintenanceEngine';
+import type { GolfCourse } from '@/hooks/useGolfCourses';
+
+const formatTime = (ms: number) => new Date(ms).toLocaleTimeString([], { hour: '2-digit', minute: '2-digit' });
+
+/**
+ * Today's sunrise and sunset at the course, with the last tee times that
+ * still finish in daylight
+ */
+export function DaylightCard({ course }: { course: Pick<GolfCourse, 'holes' | 'greens'> }) {
+  const centre = courseCentre(course.greens);
+  if (!centre) return null;
+
+  const sun = sunTimes(readingDate(new Date()), centre);
+  const pars = Object.keys(course.holes).map(Number).sort((a, b) => a - b).map(hole => course.holes[hole]);
+
+  return (
+    <Card>
+      <CardHeader>
+        <CardTitle className="text-lg">Daylight</CardTitle>
+      </CardHeader>
+      <CardContent>
+        {!sun ? (
+          <p className="text-sm text-muted-foreground">The sun doesn't rise or set here today.</p>
+        ) : (
+          <div className="grid grid-cols-2 gap-2 text-sm">
+            <div className="text-muted-foreground">Sunrise</div>
+            <div>{formatTime(sun.sunrise)}</div>
+            <div className="text-muted-foreground">Sunset</div>
+            <div>{formatTime(sun.sunset)}</div>
+            {lastTeeTimes(sun.sunset, pars).map(last => (
+              <React.Fragment key={last.holes}>
+                <div className="text-muted-foreground">Last tee time, {last.holes} holes</div>
+                <div>{formatTime(last.teeTime)}</div>
+              </React.Fragment>
+            ))}
+          </div>
+        )}
+      </CardContent>
+    </Card>
+  );
+}
@@ -0,0 +1,87 @@
+import React, { useState } from 'react';
+import { Card, CardContent, CardDescription, CardHeader, CardTitle } from '@/components/ui/card';
+import { Input } from '@/components/ui/input';
+import { Label } from '@/components/ui/label';
+import { Select, SelectContent, SelectItem, SelectTrigger, SelectValue } from '@/components/ui/select';
+import { useCourseOccupancy } from '@/hooks/useCourseOccupancy';
+import { useRateCard, useWeatherForecast } from '@/hooks/useGreenFees';
+import { courseCentre } from '@/lib/golf/caddieEngine';
+import { occupancyAt } from '@/lib/golf/occupancyEngine';
+import { WEATHER_NAMES, classifyWeather, forecastAt, quoteGreenFee } from '@/lib/golf/pricingEngine';
+import type { GolfCourse } from '@/hooks/useGolfCourses';
+
+/** The next hour on the hour, as a datetime-local value */
+function nextHour(): string {
+  const date = new Date();
+  date.setHours(date.getHours() + 1, 0, 0, 0);
+  const pad = (n: number) => String(n).padStart(2, '0');
+  return `${date.getFullYear()}-${pad(date.getMonth() + 1)}-${pad(date.getDate())}T${pad(date.getHours())}:00`;
+}
+
+/**
+ * Green fee quote for a tee time, from the club's rate card, the course's
+ * usual occupancy at that hour and the weather forecast
+ */
+export function GreenFeeCard({ course }: { course: Pick<GolfCourse, 'id' | 'author' | 'name' | 'greens'> }) {
+  const { rateCard } = useRateCard(course);
+  const { data: occupancy } = useCourseOccupancy(rateCard ? course.name : undefined, 12);
+  const { data: forecast = [] } = useWeatherForecast(rateCard ? courseCentre(course.greens) : null);
+  const [teeTime, setTeeTime] = useState(nextHour);
+  const [memberClass, setMemberClass] = useState('');
+
+  if (!rateCard) return null;
+
+  const at = new Date(teeTime).getTime();
+  const selectedClass = memberClass || rateCard.memberClasses[rateCard.memberClasses.length - 1] || '';
+  const expected = occupancy && occupancy.rounds > 0 ? occupancyAt(occupancy.grid, at) : undefined;
+  const hour = forecastAt(forecast, at);
+  const weather = hour ? classifyWeather(hour) : undefined;
+  const quote = isNaN(at) ? null : quoteGreenFee(rateCard, { memberClass: selectedClass, teeTime: at, occupancy: expected, weather });
+
+  return (
+    <Card>
+      <CardHeader>
+        <CardTitle className="text-lg">Green Fees</CardTitle>
+        <CardDescription>Standard fee {rateCard.baseFee.toFixed(2)} {rateCard.currency}. Pay at the pro shop.</CardDescription>
+      </CardHeader>
+      <CardContent className="space-y-3">
+        <div className="grid grid-cols-2 gap-3">
+          <div className="space-y-2">
+            <Label htmlFor="fee-tee-time">Tee time</Label>
+            <Input id="fee-tee-time" type="datetime-local" value={teeTime} onChange={(e) => setTeeTime(e.target.value)} />
+          </div>
+          {rateCard.memberClasses.length > 0 && (
+            <div className="space-y-2">
+              <Label>Rate</Label>
+              <Select value={selectedClass} onValueChange={setMemberClass}>
+                <SelectTrigger>
+                  <SelectValue />
+                </SelectTrigger>
+                <SelectContent>
+                  {rateCard.memberClasses.map(c => (
+                    <SelectItem key={c} value={c}>{c}</SelectItem>
+                  ))}
+                </SelectContent>
+              </Select>
+            </div>
+          )}
+        </div>
+
+        {quote && (
+          <div className="rounded border p-3">
+            <div className="text-2xl font-bold">{quote.fee.toFixed(2)} {quote.currency}</div>
+            {quote.applied.length > 0 && (
+              <div className="text-sm">{quote.applied.map(rule => rule.label).join(' · ')}</div>
+            )}
+            <div className="text-xs text-muted-foreground">
+              {[
+                expected !== undefined ? `Usually ${Math.round(expected * 100)}% full` : null,
+                weather ? `${WEATHER_NAMES[weather]} forecast` : null,
+              ].filter(Boolean).join(' · ')}
+            </div>
+          </div>
+        )}
+      </CardContent>
+    </Card>
+  );
+}
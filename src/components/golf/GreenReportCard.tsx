@@ -0,0 +1,110 @@
+import React, { useState } from 'react';
+import { Button } from '@/components/ui/button';
+import { Card, CardContent, CardDescription, CardHeader, CardTitle } from '@/components/ui/card';
+import { Input } from '@/components/ui/input';
+import { Label } from '@/components/ui/label';
+import { Select, SelectContent, SelectItem, SelectTrigger, SelectValue } from '@/components/ui/select';
+import { useCurrentUser } from '@/hooks/useCurrentUser';
+import { useGreenReport } from '@/hooks/useGreenReport';
+import { useToast } from '@/hooks/useToast';
+import { puttingHint, type GreenFirmness } from '@/lib/golf/caddieEngine';
+import { readingDate } from '@/lib/golf/maintenanceEngine';
+import type { GolfCourse } from '@/hooks/useGolfCourses';
+
+// Putt lengths the caddie read is shown for
+const SAMPLE_PUTTS = [6, 15, 30];
+
+/**
+ * Today's green speed and firmness, with caddie putting reads for a few
+ * putt lengths. The club account can post the day's reading.
+ */
+export function GreenReportCard({ course }: { course: Pick<GolfCourse, 'id' | 'author'> }) {
+  const { user } = useCurrentUser();
+  const { toast } = useToast();
+  const { reading, postReading, isPublishing } = useGreenReport(course);
+  const [stimp, setStimp] = useState('');
+  const [firmness, setFirmness] = useState<GreenFirmness>('medium');
+  const [note, setNote] = useState('');
+
+  const isClub = !!user && user.pubkey === course.author;
+  const isToday = reading?.date === readingDate(new Date());
+
+  const handlePost = async () => {
+    const value = parseFloat(stimp);
+    if (!(value > 0 && value < 20)) {
+      toast({ title: 'Enter the stimp reading in feet', variant: 'destructive' });
+      return;
+    }
+    try {
+      await postReading({ stimp: value, firmness, note: note.trim() });
+      toast({ title: 'Green report posted' });
+      setStimp('');
+      setNote('');
+    } catch (error) {
+      toast({ title: 'Could not post the report', description: error instanceof Error ? error.message : undefined, variant: 'destructive' });
+    }
+  };
+
+  return (
+    <Card>
+      <CardHeader>
+        <CardTitle className="text-lg">Greens</CardTitle>
+        <CardDescription>
+          {reading
+            ? `Stimp ${reading.stimp} ft · ${reading.firmness}${isToday ? '' : ` · measured ${reading.date}`}`
+            : 'No green report yet'}
+        </CardDescription>
+      </CardHeader>
+      <CardContent className="space-y-3">
+        {reading?.note && <p className="text-sm">{reading.note}</p>}
+        {reading && (
+          <div className="space-y-2">
+            {SAMPLE_PUTTS.map(feet => {
+              const hint = puttingHint(feet, reading);
+              return (
+                <div key={feet} className="flex justify-between text-sm">
+                  <span>{feet} ft putt</span>
+                  <span className="text-muted-foreground">
+                    plays like {hint.playsLikeFeet} ft · {hint.breakFactor}× break
+                  </span>
+                </div>
+              );
+            })}
+            {puttingHint(SAMPLE_PUTTS[0], reading).tips.map(tip => (
+              <p key={tip} className="text-xs text-muted-foreground">{tip}</p>
+            ))}
+          </div>
+        )}
+
+        {isClub && (
+          <div className="space-y-3 border-t pt-3">
+            <div className="grid grid-cols-2 gap-3">
+              <div className="space-y-2">
+                <Label htmlFor="green-stimp">Stimp (ft)</Label>
+                <Input id="green-stimp" type="number" step="0.1" value={stimp} onChange={(e) => setStimp(e.target.value)} placeholder="10.5" />
+              </div>
+              <div className="space-y-2">
+                <Label>Firmness</Label>
+                <Select value={firmness} onValueChange={(value) => setFirmness(value as GreenFirmness)}>
+                  <SelectTrigger>
+                    <SelectValue />
+                  </SelectTrigger>
+                  <SelectContent>
+                    <SelectItem value="soft">Soft</SelectItem>
+                    <SelectItem value="medium">Medium</SelectItem>
+                    <SelectItem value="firm">Firm</SelectItem>
+                  </SelectContent>
+                </Select>
+              </div>
+            </div>
+            <div className="space-y-2">
+              <Label htmlFor="green-note">Note</Label>
+              <Input id="green-note" value={note} onChange={(e) => setNote(e.target.value)} placeholder="Double cut and rolled" />
+            </div>
+            <Button className="w-full" onClick={handlePost} disabled={isPublishing}>Post Today's Reading</Button>
+          </div>
+        )}
+      </CardContent>
+    </Card>
+  );
+}
@@ -0,0 +1,49 @@
+import { Card, CardContent, CardDescription, CardHeader, CardTitle } from '@/components/ui/card';
+import { Badge } from '@/components/ui/badge';
+import { Skeleton } from '@/components/ui/skeleton';
+import { useHandicapHistory } from '@/hooks/useHandicapHistory';
+import { useHandicapSettings } from '@/hooks/useHandicapSettings';
+
+const SHOWN_REVISIONS = 10;
+
+/**
+ * A player's recent handicap index revisions: the round behind each one and
+ * how far it moved the index
+ */
+export function HandicapHistoryCard({ pubkey }: { pubkey: string | undefined }) {
+  const { committeePubkeys, requireAttestation } = useHandicapSettings();
+  const { data: revisions, isLoading } = useHandicapHistory(pubkey, committeePubkeys, { requireAttestation });
+
+  return (
+    <Card>
+      <CardHeader>
+        <CardTitle>Handicap History</CardTitle>
+        <CardDescription>Each revision of your index and the round that caused it</CardDescription>
+      </CardHeader>
+      <CardContent className="space-y-2">
+        {isLoading ? (
+          <Skeleton className="h-16 w-full" />
+        ) : !revisions?.length ? (
+          <p className="text-sm text-muted-foreground">No rounds count towards a handicap yet.</p>
+        ) : (
+          revisions.slice(0, SHOWN_REVISIONS).map(revision => (
+            <div key={revision.roundId} className="flex items-center justify-between text-sm">
+              <span className="text-muted-foreground">{new Date(revision.date).toLocaleDateString()}</span>
+              <div className="flex items-center gap-2">
+                {revision.exceptionalReduction < 0 && (
+                  <Badge variant="secondary">Exceptional score {revision.exceptionalReduction}</Badge>
+                )}
+                {revision.change !== null && revision.change !== 0 && (
+                  <span className={revision.change < 0 ? 'text-green-600' : 'text-red-600'}>
+                    {revision.change > 0 ? '+' : ''}{revision.change.toFixed(1)}
+                  </span>
+                )}
+                <span className="font-medium w-12 text-right">{revision.index?.toFixed(1) ?? '—'}</span>
+              </div>
+            </div>
+          ))
+        )}
+      </CardContent>
+    </Card>
+  );
+}
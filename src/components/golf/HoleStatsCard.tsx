@@ -0,0 +1,49 @@
+import { Card, CardContent, CardDescription, CardHeader, CardTitle } from '@/components/ui/card';
+import { Skeleton } from '@/components/ui/skeleton';
+import { useHoleStats } from '@/hooks/useHoleStats';
+import type { GolfCourse } from '@/hooks/useGolfCourses';
+
+const percent = (rate: number | null) => (rate === null ? '—' : `${Math.round(rate * 100)}%`);
+
+/**
+ * How each hole plays, from every round submitted at the course: average
+ * to par, birdie and three-putt rates, and the hole's difficulty rank
+ */
+export function HoleStatsCard({ course }: { course: Pick<GolfCourse, 'name' | 'holes'> }) {
+  const { data: stats, isLoading } = useHoleStats(course.name, course.holes);
+
+  return (
+    <Card>
+      <CardHeader>
+        <CardTitle className="text-lg">How the Holes Play</CardTitle>
+        <CardDescription>From the rounds submitted here; holes with only a few rounds are left out</CardDescription>
+      </CardHeader>
+      <CardContent className="space-y-2">
+        {isLoading ? (
+          <Skeleton className="h-24 w-full" />
+        ) : stats.length === 0 ? (
+          <p className="text-sm text-muted-foreground">Not enough rounds here yet.</p>
+        ) : (
+          <>
+            <div className="grid grid-cols-5 gap-2 text-xs font-medium text-muted-foreground">
+              <span>Hole</span>
+              <span>To par</span>
+              <span>Birdies</span>
+              <span>3-putts</span>
+              <span>Rank</span>
+            </div>
+            {stats.map(hole => (
+              <div key={hole.hole} className="grid grid-cols-5 gap-2 text-sm">
+                <span>{hole.hole} · par {hole.par}</span>
+                <span>{hole.averageToPar > 0 ? '+' : ''}{hole.averageToPar.toFixed(2)}</span>
+                <span>{percent(hole.birdieRate)}</span>
+                <span>{percent(hole.threePuttRate)}</span>
+                <span>{hole.difficultyRank}</span>
+              </div>
+            ))}
+          </>
+        )}
+      </CardContent>
+    </Card>
+  );
+}
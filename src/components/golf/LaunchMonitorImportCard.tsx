@@ -0,0 +1,76 @@
+import { useState } from 'react';
+import { Button } from '@/components/ui/button';
+import { Card, CardContent, CardDescription, CardHeader, CardTitle } from '@/components/ui/card';
+import { Input } from '@/components/ui/input';
+import { Label } from '@/components/ui/label';
+import { useLaunchMonitorImport } from '@/hooks/useLaunchMonitorImport';
+import { useToast } from '@/hooks/useToast';
+import type { LaunchMonitorFormat, LaunchMonitorImport } from '@/lib/golf/launchMonitorImport';
+
+const FORMAT_LABELS: Record<LaunchMonitorFormat, string> = {
+  'garmin-r10': 'Garmin R10',
+  mevo: 'Mevo',
+  generic: 'CSV',
+};
+
+/**
+ * Import a launch monitor CSV export as a range session. The export is
+ * previewed against the player's bag before anything is saved.
+ */
+export function LaunchMonitorImportCard() {
+  const { preview, apply, isApplying } = useLaunchMonitorImport();
+  const { toast } = useToast();
+  const [parsed, setParsed] = useState<LaunchMonitorImport | null>(null);
+
+  const handleFile = async (file: File | undefined) => {
+    setParsed(file ? preview(await file.text()) : null);
+  };
+
+  const matched = parsed?.shots.filter(s => s.clubId) ?? [];
+
+  const handleImport = async () => {
+    try {
+      const session = await apply(matched);
+      toast({ title: 'Session imported', description: `${matched.length} shots across ${session.blocks.length} clubs` });
+      setParsed(null);
+    } catch (error) {
+      toast({
+        title: 'Could not import the session',
+        description: error instanceof Error ? error.message : 'Please try again',
+        variant: 'destructive',
+      });
+    }
+  };
+
+  return (
+    <Card>
+      <CardHeader>
+        <CardTitle className="text-lg">Launch Monitor</CardTitle>
+        <CardDescription>Import a CSV export; its carries update your bag distances</CardDescription>
+      </CardHeader>
+      <CardContent className="space-y-3">
+        <div className="space-y-2">
+          <Label htmlFor="launch-monitor-file">Export file</Label>
+          <Input id="launch-monitor-file" type="file" accept=".csv,text/csv" onChange={(e) => handleFile(e.target.files?.[0])} />
+        </div>
+        {parsed && (
+          <>
+            <p className="text-sm">
+              {matched.length} of {parsed.shots.length} shots matched a club in your bag
+              {` · ${FORMAT_LABELS[parsed.format]} export`}
+            </p>
+            {parsed.unmatchedClubs.length > 0 && (
+              <p className="text-xs text-muted-foreground">Not in your bag: {parsed.unmatchedClubs.join(', ')}</p>
+            )}
+            {parsed.errors.map((error, i) => (
+              <p key={i} className="text-xs text-destructive">{error}</p>
+            ))}
+            <Button className="w-full" onClick={handleImport} disabled={isApplying || matched.length === 0}>
+              Import Session
+            </Button>
+          </>
+        )}
+      </CardContent>
+    </Card>
+  );
+}
@@ -0,0 +1,83 @@
+import React, { useEffect, useState } from 'react';
+import { Button } from '@/components/ui/button';
+import { Card, CardContent, CardDescription, CardHeader, CardTitle } from '@/components/ui/card';
+import { Input } from '@/components/ui/input';
+import { Label } from '@/components/ui/label';
+import { Switch } from '@/components/ui/switch';
+import { useLightningWatch } from '@/hooks/useLightningWatch';
+import { useToast } from '@/hooks/useToast';
+import type { GolfCourse } from '@/hooks/useGolfCourses';
+
+/**
+ * Club settings and status for the automatic lightning suspension
+ */
+export function LightningWatchCard({ course }: { course: Pick<GolfCourse, 'id' | 'author' | 'name' | 'greens'> }) {
+  const { toast } = useToast();
+  const { settings, saveSettings, hasCentre, active, lastCheck, error } = useLightningWatch(course);
+  const [form, setForm] = useState(settings);
+
+  useEffect(() => {
+    setForm(settings);
+  }, [settings]);
+
+  const handleSave = () => {
+    const radiusKm = Number(form.radiusKm);
+    if (!(radiusKm > 0)) {
+      toast({ title: 'Enter a radius in km', variant: 'destructive' });
+      return;
+    }
+    saveSettings({ ...form, radiusKm, clientId: form.clientId.trim(), clientSecret: form.clientSecret.trim() });
+    toast({ title: 'Lightning watch saved' });
+  };
+
+  return (
+    <Card>
+      <CardHeader>
+        <CardTitle className="text-lg">Lightning Watch</CardTitle>
+        <CardDescription>
+          Suspends play when lightning strikes inside the radius and gives the all clear 30 minutes after the last
+          strike. Uses an Xweather account; runs while this page is open.
+        </CardDescription>
+      </CardHeader>
+      <CardContent className="space-y-3">
+        {!hasCentre && (
+          <p className="text-sm text-muted-foreground">Add green positions to the course to locate it for the lightning watch.</p>
+        )}
+        <div className="flex items-center justify-between">
+          <Label htmlFor="lightning-enabled">Automatic suspension</Label>
+          <Switch id="lightning-enabled" checked={form.enabled} onCheckedChange={(enabled) => setForm({ ...form, enabled })} />
+        </div>
+        <div className="space-y-2">
+          <Label htmlFor="lightning-radius">Radius (km)</Label>
+          <Input id="lightning-radius" type="number" min={1} value={form.radiusKm} onChange={(e) => setForm({ ...form, radiusKm: Number(e.target.value) })} />
+        </div>
+        <div className="grid grid-cols-2 gap-3">
+          <div className="space-y-2">
+            <Label htmlFor="lightning-client-id">Client ID</Label>
+            <Input id="lightning-client-id" value={form.clientId} onChange={(e) => setForm({ ...form, clientId: e.target.value })} />
+          </div>
+          <div className="space-y-2">
+            <Label htmlFor="lightning-client-secret">Client secret</Label>
+            <Input id="lightning-client-secret" type="password" value={form.clientSecret} onChange={(e) => setForm({ ...form, clientSecret: e.target.value })} />
+          </div>
+        </div>
+        <Button className="w-full" onClick={handleSave}>Save</Button>
+
+        {active && (
+          <p className="text-sm text-muted-foreground">
+            {error
+              ? `Last check failed: ${error}`
+              : lastCheck
+                ? `Checked ${new Date(lastCheck.at).toLocaleTimeString([], { hour: '2-digit', minute: '2-digit' })}. ` +
+                  (lastCheck.resumeAt
+                    ? `Lightning nearby; play resumes at ${new Date(lastCheck.resumeAt).toLocaleTimeString([], { hour: '2-digit', minute: '2-digit' })} at the earliest.`
+                    : lastCheck.nearestKm !== null
+                      ? `Nearest strike ${lastCheck.nearestKm.toFixed(0)} km away.`
+                      : 'No recent strikes.')
+                : 'Checking…'}
+          </p>
+        )}
+      </CardContent>
+    </Card>
+  );
+}
@@ -0,0 +1,75 @@
+import { Card, CardContent, CardDescription, CardHeader, CardTitle } from '@/components/ui/card';
+import { Skeleton } from '@/components/ui/skeleton';
+import { usePlayerTrends } from '@/hooks/usePlayerTrends';
+import type { RoundMetrics } from '@/lib/golf/trendsEngine';
+
+type TrendKey = keyof Omit<RoundMetrics, 'strokesGained'>;
+
+// Shown metrics; `lowerIsBetter` decides which way a change is an improvement
+const METRICS: { key: TrendKey; label: string; percent?: boolean; lowerIsBetter?: boolean }[] = [
+  { key: 'scoringAverage', label: 'Scoring average', lowerIsBetter: true },
+  { key: 'girPercentage', label: 'Greens in regulation', percent: true },
+  { key: 'fairwayPercentage', label: 'Fairways hit', percent: true },
+  { key: 'puttsPerRound', label: 'Putts per round', lowerIsBetter: true },
+  { key: 'scramblingPercentage', label: 'Scrambling', percent: true },
+];
+
+const format = (value: number, percent?: boolean) => (percent ? `${Math.round(value * 100)}%` : value.toFixed(1));
+
+/**
+ * A player's form: their averages over the last few rounds, how they moved
+ * from the few before, and strokes gained against a scratch player
+ */
+export function PlayerTrendsCard({ pubkey }: { pubkey: string | undefined }) {
+  const { data: trends, isLoading } = usePlayerTrends(pubkey);
+  const summary = trends?.summary;
+  const strokesGained = summary?.current?.strokesGained;
+
+  return (
+    <Card>
+      <CardHeader>
+        <CardTitle>Trends</CardTitle>
+        <CardDescription>
+          {summary
+            ? `Averages over your last ${summary.window} rounds, against the ${summary.window} before`
+            : 'Averages over your recent rounds'}
+        </CardDescription>
+      </CardHeader>
+      <CardContent className="space-y-2">
+        {isLoading ? (
+          <Skeleton className="h-24 w-full" />
+        ) : !summary?.current ? (
+          <p className="text-sm text-muted-foreground">Play a round to see your trends.</p>
+        ) : (
+          <>
+            {METRICS.map(({ key, label, percent, lowerIsBetter }) => {
+              const value = summary.current![key];
+              const change = summary.change[key];
+              if (value === null) return null;
+              const improved = change !== undefined && (lowerIsBetter ? change < 0 : change > 0);
+              return (
+                <div key={key} className="flex items-center justify-between text-sm">
+                  <span className="text-muted-foreground">{label}</span>
+                  <div className="flex items-center gap-2">
+                    {change !== undefined && change !== 0 && (
+                      <span className={improved ? 'text-green-600' : 'text-red-600'}>
+                        {change > 0 ? '+' : '-'}{format(Math.abs(change), percent)}
+                      </span>
+                    )}
+                    <span className="font-medium w-12 text-right">{format(value, percent)}</span>
+                  </div>
+                </div>
+              );
+            })}
+            {strokesGained && (
+              <p className="text-xs text-muted-foreground">
+                Strokes gained per round: tee {strokesGained.offTheTee.toFixed(1)} · approach {strokesGained.approach.toFixed(1)} ·
+                short game {strokesGained.shortGame.toFixed(1)} · putting {strokesGained.putting.toFixed(1)}
+              </p>
+            )}
+          </>
+        )}
+      </CardContent>
+    </Card>
+  );
+}
@@ -0,0 +1,110 @@
+import React, { useState } from 'react';
+import { Card, CardContent, CardDescription, CardHeader, CardTitle } from '@/components/ui/card';
+import { Badge } from '@/components/ui/badge';
+import { Button } from '@/components/ui/button';
+import { Input } from '@/components/ui/input';
+import { useAuthor } from '@/hooks/useAuthor';
+import { usePredictionGame } from '@/hooks/usePredictionGame';
+import { useToast } from '@/hooks/useToast';
+import { genUserName } from '@/lib/genUserName';
+import { PREDICTION_GAME_NAMES, type PredictionEntry, type PredictionGame } from '@/lib/golf/predictionEngine';
+import type { GolfRound } from '@/lib/golf/types';
+
+function EntryRow({ entry, isWinner, isFinal }: { entry: PredictionEntry; isWinner: boolean; isFinal: boolean }) {
+  const author = useAuthor(entry.predictor);
+  const name = author.data?.metadata?.name ?? genUserName(entry.predictor);
+
+  return (
+    <div className="flex items-center justify-between gap-2 text-sm">
+      <span className="truncate">{name}</span>
+      <span className="flex items-center gap-2 text-muted-foreground">
+        predicted {entry.value}
+        {isFinal && (isWinner ? <Badge>{entry.miss === 0 ? 'Called it' : 'Closest'}</Badge> : <span>off by {entry.miss}</span>)}
+      </span>
+    </div>
+  );
+}
+
+function PredictionForm({ game, current, onPredict, disabled }: {
+  game: PredictionGame;
+  current?: number;
+  onPredict: (game: PredictionGame, value: number) => Promise<unknown>;
+  disabled: boolean;
+}) {
+  const [value, setValue] = useState(current !== undefined ? String(current) : '');
+
+  return (
+    <div className="flex items-end gap-2">
+      <div className="flex-1 space-y-1">
+        <div className="text-xs text-muted-foreground">{PREDICTION_GAME_NAMES[game]}</div>
+        <Input type="number" min={0} value={value} onChange={(e) => setValue(e.target.value)} placeholder={game === 'own-score' ? '85' : '3'} />
+      </div>
+      <Button variant="outline" disabled={disabled || value === ''} onClick={() => onPredict(game, Number(value))}>
+        {current !== undefined ? 'Update' : 'Predict'}
+      </Button>
+    </div>
+  );
+}
+
+/**
+ * The round's prediction games: the group's predictions before the round,
+ * and who came closest once every card is in. Winners earn badges.
+ */
+export function PredictionsPanel({ round }: { round: Partial<GolfRound> }) {
+  const { results, myPredictions, canPredict, isSettled, predict, isPublishing } = usePredictionGame(round);
+  const { toast } = useToast();
+
+  if (results.length === 0 && !canPredict) return null;
+
+  const handlePredict = async (game: PredictionGame, value: number) => {
+    try {
+      await predict(game, value);
+      toast({ title: 'Prediction saved', description: `${PREDICTION_GAME_NAMES[game]}: ${value}` });
+    } catch (error) {
+      toast({
+        title: 'Could not save the prediction',
+        description: error instanceof Error ? error.message : 'Please try again',
+        variant: 'destructive',
+      });
+    }
+  };
+
+  return (
+    <Card>
+      <CardHeader>
+        <CardTitle className="text-lg">Predictions</CardTitle>
+        <CardDescription>
+          {isSettled
+            ? 'Final results · the closest predictions win a badge'
+            : canPredict
+              ? 'Predict before you tee off · closest wins a badge, no money involved'
+              : 'Judged when every card is complete'}
+        </CardDescription>
+      </CardHeader>
+      <CardContent className="space-y-4">
+        {canPredict && (['own-score', 'group-birdies'] as PredictionGame[]).map(game => (
+          <PredictionForm
+            key={game}
+            game={game}
+            current={myPredictions.find(p => p.game === game)?.value}
+            onPredict={handlePredict}
+            disabled={isPublishing}
+          />
+        ))}
+        {results.map(result => (
+          <div key={result.game} className="space-y-1 rounded border p-3">
+            <div className="text-sm font-medium">{PREDICTION_GAME_NAMES[result.game]}</div>
+            {result.entries.map(entry => (
+              <EntryRow
+                key={entry.predictor}
+                entry={entry}
+                isWinner={result.winners.includes(entry.predictor)}
+                isFinal={result.winners.length > 0}
+              />
+            ))}
+          </div>
+        ))}
+      </CardContent>
+    </Card>
+  );
+}
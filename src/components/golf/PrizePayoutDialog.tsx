@@ -0,0 +1,152 @@
+import React, { useState } from 'react';
+import { Dialog, DialogContent, DialogDescription, DialogFooter, DialogHeader, DialogTitle } from '@/components/ui/dialog';
+import { Badge } from '@/components/ui/badge';
+import { Button } from '@/components/ui/button';
+import { Input } from '@/components/ui/input';
+import { Label } from '@/components/ui/label';
+import { Switch } from '@/components/ui/switch';
+import { useAuthor } from '@/hooks/useAuthor';
+import { usePrizePayouts } from '@/hooks/usePrizePayouts';
+import { useToast } from '@/hooks/useToast';
+import { genUserName } from '@/lib/genUserName';
+import type { Prize } from '@/lib/golf/payoutEngine';
+
+function PrizeRow({ prize, paid, waiting, onApprove, disabled }: {
+  prize: Prize;
+  paid: boolean;
+  waiting: boolean;
+  onApprove: () => void;
+  disabled: boolean;
+}) {
+  const author = useAuthor(prize.recipient);
+  const name = author.data?.metadata?.name ?? genUserName(prize.recipient);
+
+  return (
+    <div className="flex items-center justify-between gap-2 rounded border p-3">
+      <div className="min-w-0">
+        <div className="text-sm font-medium truncate">{name}</div>
+        <div className="text-xs text-muted-foreground">{prize.memo}</div>
+      </div>
+      <div className="flex items-center gap-2">
+        <span className="text-sm font-mono font-bold">{prize.amountSats.toLocaleString()} sats</span>
+        {paid ? (
+          <Badge variant="secondary">Paid</Badge>
+        ) : waiting ? (
+          <Button size="sm" onClick={onApprove} disabled={disabled}>Approve</Button>
+        ) : null}
+      </div>
+    </div>
+  );
+}
+
+interface PrizePayoutDialogProps {
+  open: boolean;
+  onOpenChange: (open: boolean) => void;
+  prizes: Prize[];
+}
+
+/**
+ * Pay a round's prizes from the connected club wallet
+ */
+export function PrizePayoutDialog({ open, onOpenChange, prizes }: PrizePayoutDialogProps) {
+  const { toast } = useToast();
+  const { policy, setPolicy, history, settle, approve, isPaying } = usePrizePayouts();
+  const [waiting, setWaiting] = useState<string[]>([]);
+  const paid = new Set(history.map(r => r.prizeId));
+
+  const handleSettle = async () => {
+    const { paid: paidNow, pending, failed } = await settle(prizes);
+    setWaiting(pending.map(p => p.prizeId));
+    toast({
+      title: paidNow.length > 0 ? `Paid ${paidNow.length} prize${paidNow.length === 1 ? '' : 's'}` : 'Nothing paid automatically',
+      description: failed.length > 0
+        ? `${failed[0].error}${failed.length > 1 ? ` (and ${failed.length - 1} more)` : ''}`
+        : pending.length > 0 ? `${pending.length} waiting for your approval` : undefined,
+      variant: failed.length > 0 ? 'destructive' : 'default',
+    });
+  };
+
+  const handleApprove = async (prize: Prize) => {
+    try {
+      await approve(prize);
+      setWaiting(prev => prev.filter(id => id !== prize.prizeId));
+    } catch (error) {
+      toast({ title: 'Payout failed', description: error instanceof Error ? error.message : undefined, variant: 'destructive' });
+    }
+  };
+
+  const total = prizes.reduce((sum, p) => sum + p.amountSats, 0);
+
+  return (
+    <Dialog open={open} onOpenChange={onOpenChange}>
+      <DialogContent className="sm:max-w-[600px]">
+        <DialogHeader>
+          <DialogTitle>Pay Prizes</DialogTitle>
+          <DialogDescription>
+            Prizes are zapped to the winners from your connected wallet: {total.toLocaleString()} sats in total.
+          </DialogDescription>
+        </DialogHeader>
+
+        <div className="space-y-3">
+          <div className="flex items-center justify-between">
+            <div>
+              <Label htmlFor="payout-automatic">Pay automatically</Label>
+              <p className="text-xs text-muted-foreground">Otherwise every payout waits for your approval</p>
+            </div>
+            <Switch
+              id="payout-automatic"
+              checked={policy.mode === 'automatic'}
+              onCheckedChange={checked => setPolicy({ ...policy, mode: checked ? 'automatic' : 'approval' })}
+            />
+          </div>
+          <div className="grid grid-cols-2 gap-2">
+            <div>
+              <Label htmlFor="payout-max">Max per payout (sats)</Label>
+              <Input
+                id="payout-max"
+                type="number"
+                min={0}
+                value={policy.maxPerPayoutSats}
+                onChange={e => setPolicy({ ...policy, maxPerPayoutSats: parseInt(e.target.value) || 0 })}
+              />
+            </div>
+            <div>
+              <Label htmlFor="payout-daily">Max per day (sats)</Label>
+              <Input
+                id="payout-daily"
+                type="number"
+                min={0}
+                value={policy.maxDailySats}
+                onChange={e => setPolicy({ ...policy, maxDailySats: parseInt(e.target.value) || 0 })}
+              />
+            </div>
+          </div>
+        </div>
+
+        <div className="mt-2 space-y-2 max-h-72 overflow-auto">
+          {prizes.length === 0 ? (
+            <div className="text-sm text-muted-foreground">No prizes won.</div>
+          ) : (
+            prizes.map(prize => (
+              <PrizeRow
+                key={prize.prizeId}
+                prize={prize}
+                paid={paid.has(prize.prizeId)}
+                waiting={waiting.includes(prize.prizeId)}
+                onApprove={() => handleApprove(prize)}
+                disabled={isPaying}
+              />
+            ))
+          )}
+        </div>
+
+        <DialogFooter>
+          <Button variant="ghost" onClick={() => onOpenChange(false)}>Close</Button>
+          <Button onClick={handleSettle} disabled={isPaying || prizes.every(p => paid.has(p.prizeId))}>
+            {isPaying ? 'Paying...' : 'Finalize & Pay'}
+          </Button>
+        </DialogFooter>
+      </DialogContent>
+    </Dialog>
+  );
+}
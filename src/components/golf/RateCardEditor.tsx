@@ -0,0 +1,216 @@
+import React, { useEffect, useState } from 'react';
+import { Button } from '@/components/ui/button';
+import { Card, CardContent, CardDescription, CardHeader, CardTitle } from '@/components/ui/card';
+import { Checkbox } from '@/components/ui/checkbox';
+import { Input } from '@/components/ui/input';
+import { Label } from '@/components/ui/label';
+import { Select, SelectContent, SelectItem, SelectTrigger, SelectValue } from '@/components/ui/select';
+import { useRateCard } from '@/hooks/useGreenFees';
+import { useToast } from '@/hooks/useToast';
+import { DAY_NAMES } from '@/lib/golf/occupancyEngine';
+import {
+  WEATHER_NAMES,
+  describeRule,
+  type PriceAdjustment,
+  type PricingRule,
+  type WeatherCondition,
+} from '@/lib/golf/pricingEngine';
+import type { GolfCourse } from '@/hooks/useGolfCourses';
+import { v4 as uuidv4 } from 'uuid';
+
+const ADJUSTMENT_NAMES: Record<PriceAdjustment, string> = {
+  percent: 'Change by %',
+  amount: 'Add amount',
+  fixed: 'Set fee',
+};
+
+const splitList = (value: string) => value.split(',').map(s => s.trim()).filter(Boolean);
+const optionalNumber = (value: string) => (value.trim() === '' || isNaN(Number(value)) ? undefined : Number(value));
+
+/**
+ * The club's green fee rate card: the standard fee, the rates players can
+ * choose from, and pricing rules applied in order
+ */
+export function RateCardEditor({ course }: { course: Pick<GolfCourse, 'id' | 'author'> }) {
+  const { toast } = useToast();
+  const { rateCard, saveRateCard, isPublishing } = useRateCard(course);
+
+  const [baseFee, setBaseFee] = useState('');
+  const [currency, setCurrency] = useState('USD');
+  const [classes, setClasses] = useState('Member, Visitor');
+  const [rules, setRules] = useState<PricingRule[]>([]);
+
+  const [label, setLabel] = useState('');
+  const [adjustment, setAdjustment] = useState<PriceAdjustment>('percent');
+  const [value, setValue] = useState('');
+  const [ruleClasses, setRuleClasses] = useState('');
+  const [days, setDays] = useState<number[]>([]);
+  const [fromHour, setFromHour] = useState('');
+  const [toHour, setToHour] = useState('');
+  const [minOccupancy, setMinOccupancy] = useState('');
+  const [maxOccupancy, setMaxOccupancy] = useState('');
+  const [weather, setWeather] = useState<WeatherCondition[]>([]);
+
+  useEffect(() => {
+    if (!rateCard) return;
+    setBaseFee(String(rateCard.baseFee));
+    setCurrency(rateCard.currency);
+    setClasses(rateCard.memberClasses.join(', '));
+    setRules(rateCard.rules);
+  }, [rateCard]);
+
+  const addRule = () => {
+    const amount = Number(value);
+    if (!label.trim() || value.trim() === '' || isNaN(amount)) {
+      toast({ title: 'Give the rule a name and an amount', variant: 'destructive' });
+      return;
+    }
+    const min = optionalNumber(minOccupancy);
+    const max = optionalNumber(maxOccupancy);
+    const rule: PricingRule = {
+      id: uuidv4(),
+      label: label.trim(),
+      adjustment,
+      value: amount,
+      ...(splitList(ruleClasses).length > 0 ? { memberClasses: splitList(ruleClasses) } : {}),
+      ...(days.length > 0 ? { days: [...days].sort((a, b) => a - b) } : {}),
+      ...(optionalNumber(fromHour) !== undefined ? { fromHour: optionalNumber(fromHour) } : {}),
+      ...(optionalNumber(toHour) !== undefined ? { toHour: optionalNumber(toHour) } : {}),
+      ...(min !== undefined ? { minOccupancy: min / 100 } : {}),
+      ...(max !== undefined ? { maxOccupancy: max / 100 } : {}),
+      ...(weather.length > 0 ? { weather } : {}),
+    };
+    setRules([...rules, rule]);
+    setLabel('');
+    setValue('');
+    setRuleClasses('');
+    setDays([]);
+    setFromHour('');
+    setToHour('');
+    setMinOccupancy('');
+    setMaxOccupancy('');
+    setWeather([]);
+  };
+
+  const handleSave = async () => {
+    const fee = Number(baseFee);
+    if (baseFee.trim() === '' || isNaN(fee) || fee < 0) {
+      toast({ title: 'Enter the standard green fee', variant: 'destructive' });
+      return;
+    }
+    try {
+      await saveRateCard({ baseFee: fee, currency: currency.trim() || 'USD', memberClasses: splitList(classes), rules });
+      toast({ title: 'Green fees saved' });
+    } catch (error) {
+      toast({ title: 'Could not save green fees', description: error instanceof Error ? error.message : undefined, variant: 'destructive' });
+    }
+  };
+
+  return (
+    <Card>
+      <CardHeader>
+        <CardTitle className="text-lg">Green Fee Rules</CardTitle>
+        <CardDescription>Matching rules apply in order to the standard fee.</CardDescription>
+      </CardHeader>
+      <CardContent className="space-y-3">
+        <div className="grid grid-cols-2 gap-3">
+          <div className="space-y-2">
+            <Label htmlFor="fee-base">Standard fee</Label>
+            <Input id="fee-base" type="number" step="0.01" value={baseFee} onChange={(e) => setBaseFee(e.target.value)} />
+          </div>
+          <div className="space-y-2">
+            <Label htmlFor="fee-currency">Currency</Label>
+            <Input id="fee-currency" value={currency} onChange={(e) => setCurrency(e.target.value)} />
+          </div>
+        </div>
+        <div className="space-y-2">
+          <Label htmlFor="fee-classes">Rates (comma separated)</Label>
+          <Input id="fee-classes" value={classes} onChange={(e) => setClasses(e.target.value)} placeholder="Member, Junior, Visitor" />
+        </div>
+
+        {rules.map(rule => (
+          <div key={rule.id} className="flex items-center justify-between gap-2 text-sm">
+            <div className="min-w-0">
+              <div className="font-medium">{rule.label}</div>
+              <div className="text-xs text-muted-foreground">{describeRule(rule, currency)}</div>
+            </div>
+            <Button size="sm" variant="ghost" onClick={() => setRules(rules.filter(r => r.id !== rule.id))}>
+              Remove
+            </Button>
+          </div>
+        ))}
+
+        <div className="space-y-3 rounded border p-3">
+          <div className="grid grid-cols-2 gap-3">
+            <div className="space-y-2">
+              <Label htmlFor="rule-label">Rule</Label>
+              <Input id="rule-label" value={label} onChange={(e) => setLabel(e.target.value)} placeholder="Twilight" />
+            </div>
+            <div className="space-y-2">
+              <Label htmlFor="rule-classes">Rates (blank for all)</Label>
+              <Input id="rule-classes" value={ruleClasses} onChange={(e) => setRuleClasses(e.target.value)} />
+            </div>
+            <div className="space-y-2">
+              <Label>Adjustment</Label>
+              <Select value={adjustment} onValueChange={(v) => setAdjustment(v as PriceAdjustment)}>
+                <SelectTrigger>
+                  <SelectValue />
+                </SelectTrigger>
+                <SelectContent>
+                  {(Object.keys(ADJUSTMENT_NAMES) as PriceAdjustment[]).map(a => (
+                    <SelectItem key={a} value={a}>{ADJUSTMENT_NAMES[a]}</SelectItem>
+                  ))}
+                </SelectContent>
+              </Select>
+            </div>
+            <div className="space-y-2">
+              <Label htmlFor="rule-value">Amount</Label>
+              <Input id="rule-value" type="number" step="0.01" value={value} onChange={(e) => setValue(e.target.value)} placeholder="-40" />
+            </div>
+            <div className="space-y-2">
+              <Label htmlFor="rule-from">From hour</Label>
+              <Input id="rule-from" type="number" min={0} max={24} step="0.5" value={fromHour} onChange={(e) => setFromHour(e.target.value)} />
+            </div>
+            <div className="space-y-2">
+              <Label htmlFor="rule-to">Until hour</Label>
+              <Input id="rule-to" type="number" min={0} max={24} step="0.5" value={toHour} onChange={(e) => setToHour(e.target.value)} />
+            </div>
+            <div className="space-y-2">
+              <Label htmlFor="rule-min">At least % full</Label>
+              <Input id="rule-min" type="number" min={0} max={100} value={minOccupancy} onChange={(e) => setMinOccupancy(e.target.value)} />
+            </div>
+            <div className="space-y-2">
+              <Label htmlFor="rule-max">Up to % full</Label>
+              <Input id="rule-max" type="number" min={0} max={100} value={maxOccupancy} onChange={(e) => setMaxOccupancy(e.target.value)} />
+            </div>
+          </div>
+          <div className="flex flex-wrap gap-3 text-sm">
+            {DAY_NAMES.map((name, day) => (
+              <label key={name} className="flex items-center gap-1">
+                <Checkbox
+                  checked={days.includes(day)}
+                  onCheckedChange={(checked) => setDays(checked ? [...days, day] : days.filter(d => d !== day))}
+                />
+                {name}
+              </label>
+            ))}
+          </div>
+          <div className="flex flex-wrap gap-3 text-sm">
+            {(Object.keys(WEATHER_NAMES) as WeatherCondition[]).map(w => (
+              <label key={w} className="flex items-center gap-1">
+                <Checkbox
+                  checked={weather.includes(w)}
+                  onCheckedChange={(checked) => setWeather(checked ? [...weather, w] : weather.filter(x => x !== w))}
+                />
+                {WEATHER_NAMES[w]}
+              </label>
+            ))}
+          </div>
+          <Button variant="outline" className="w-full" onClick={addRule}>Add Rule</Button>
+        </div>
+
+        <Button className="w-full" onClick={handleSave} disabled={isPublishing}>Save Green Fees</Button>
+      </CardContent>
+    </Card>
+  );
+}
@@ -0,0 +1,101 @@
+import React from 'react';
+import { Link } from 'react-router-dom';
+import { nip19 } from 'nostr-tools';
+import { Route as RouteIcon } from 'lucide-react';
+import { Card, CardContent } from '@/components/ui/card';
+import { Button } from '@/components/ui/button';
+import { CommentsSection } from '@/components/comments/CommentsSection';
+import { useAuthor } from '@/hooks/useAuthor';
+import { useCurrentUser } from '@/hooks/useCurrentUser';
+import { useReactions } from '@/hooks/useReactions';
+import { useRoundEvent } from '@/hooks/useRoundEvent';
+import { useToast } from '@/hooks/useToast';
+import { genUserName } from '@/lib/genUserName';
+import { QUICK_REACTIONS } from '@/lib/golf/reactionEngine';
+import { cn } from '@/lib/utils';
+
+interface RoundSocialPanelProps {
+  roundId: string;
+  className?: string;
+}
+
+function ReplayLink({ roundId, pubkey }: { roundId: string; pubkey: string }) {
+  const author = useAuthor(pubkey);
+  const name = author.data?.metadata?.name ?? genUserName(pubkey);
+
+  return (
+    <Button size="sm" variant="outline" asChild>
+      <Link to={`/rounds/${roundId}/replay/${nip19.npubEncode(pubkey)}`}>
+        <RouteIcon className="h-4 w-4 mr-1" />
+        {name}
+      </Link>
+    </Button>
+  );
+}
+
+/**
+ * Reactions and chat on a published round. Both are plain Nostr events, so
+ * replies and reactions made from other clients show up here too.
+ */
+export function RoundSocialPanel({ roundId, className }: RoundSocialPanelProps) {
+  const { data: roundEvent } = useRoundEvent(roundId);
+  const { counts, react, isReacting } = useReactions(roundEvent);
+  const { user } = useCurrentUser();
+  const { toast } = useToast();
+
+  // Nothing to hang reactions on until the round has been shared
+  if (!roundEvent) return null;
+
+  const handleReact = async (emoji: string) => {
+    try {
+      await react(emoji);
+    } catch (error) {
+      toast({
+        title: 'Reaction failed',
+        description: error instanceof Error ? error.message : 'Could not publish your reaction',
+        variant: 'destructive',
+      });
+    }
+  };
+
+  const emojis = [...new Set([...counts.map(c => c.emoji), ...QUICK_REACTIONS])];
+  const players = roundEvent.tags.find(([name]) => name === 'players')?.slice(1).filter(Boolean) ?? [roundEvent.pubkey];
+
+  return (
+    <div className={cn('space-y-4', className)}>
+      <Card>
+        <CardContent className="p-3 flex flex-wrap gap-2">
+          {emojis.map(emoji => {
+            const count = counts.find(c => c.emoji === emoji);
+            return (
+              <Button
+                key={emoji}
+                size="sm"
+                variant={count?.mine ? 'secondary' : 'outline'}
+                disabled={!user || isReacting || count?.mine}
+                onClick={() => handleReact(emoji)}
+              >
+                {emoji}
+                {count && <span className="ml-1 text-xs">{count.count}</span>}
+              </Button>
+            );
+          })}
+        </CardContent>
+      </Card>
+      <Card>
+        <CardContent className="p-3 space-y-2">
+          <div className="text-sm text-muted-foreground">Shot tracer replays</div>
+          <div className="flex flex-wrap gap-2">
+            {players.map(pubkey => <ReplayLink key={pubkey} roundId={roundId} pubkey={pubkey} />)}
+          </div>
+        </CardContent>
+      </Card>
+      <CommentsSection
+        root={roundEvent}
+        title="Round chat"
+        emptyStateMessage="No comments yet"
+        emptyStateSubtitle="Replies to this round from any Nostr client appear here."
+      />
+    </div>
+  );
+}
@@ -0,0 +1,92 @@
+import React, { useState } from 'react';
+import { nip19 } from 'nostr-tools';
+import { Card, CardContent, CardDescription, CardHeader, CardTitle } from '@/components/ui/card';
+import { Button } from '@/components/ui/button';
+import { Input } from '@/components/ui/input';
+import { Label } from '@/components/ui/label';
+import { useScoringDelegations } from '@/hooks/useScoringDelegations';
+import { useToast } from '@/hooks/useToast';
+import { DEFAULT_DELEGATION_HOURS } from '@/lib/golf/delegationEngine';
+
+function toPubkey(value: string): string | null {
+  const trimmed = value.trim();
+  if (/^[a-f0-9]{64}$/i.test(trimmed)) return trimmed.toLowerCase();
+  try {
+    const decoded = nip19.decode(trimmed);
+    return decoded.type === 'npub' ? decoded.data : null;
+  } catch {
+    return null;
+  }
+}
+
+/**
+ * Lets a player authorise scoring terminals to post their score cards for a
+ * few hours, and revoke them
+ */
+export function ScoringTerminalsCard() {
+  const { active, grant, revoke, isPublishing } = useScoringDelegations();
+  const { toast } = useToast();
+  const [terminal, setTerminal] = useState('');
+  const [label, setLabel] = useState('');
+  const [hours, setHours] = useState(String(DEFAULT_DELEGATION_HOURS));
+
+  const handleGrant = async () => {
+    const pubkey = toPubkey(terminal);
+    const duration = Number(hours);
+    if (!pubkey || !(duration > 0)) {
+      toast({ title: 'Check the details', description: 'Enter the terminal key shown on its screen and a duration.', variant: 'destructive' });
+      return;
+    }
+    try {
+      await grant(pubkey, duration, label.trim() || 'Scoring terminal');
+      toast({ title: 'Terminal authorised', description: `It can post your score cards for ${duration} hours.` });
+      setTerminal('');
+      setLabel('');
+    } catch (error) {
+      toast({
+        title: 'Could not authorise the terminal',
+        description: error instanceof Error ? error.message : 'Please try again',
+        variant: 'destructive',
+      });
+    }
+  };
+
+  return (
+    <Card>
+      <CardHeader>
+        <CardTitle>Scoring Terminals</CardTitle>
+        <CardDescription>Let a clubhouse or cart terminal post your score cards for you</CardDescription>
+      </CardHeader>
+      <CardContent className="space-y-4">
+        {active.map(delegation => (
+          <div key={delegation.delegatee} className="flex items-center justify-between gap-2 rounded border p-3">
+            <div className="min-w-0">
+              <div className="text-sm font-medium truncate">{delegation.label || 'Scoring terminal'}</div>
+              <div className="text-xs text-muted-foreground">
+                Until {new Date(delegation.until * 1000).toLocaleString()}
+              </div>
+            </div>
+            <Button size="sm" variant="outline" disabled={isPublishing} onClick={() => revoke(delegation)}>
+              Revoke
+            </Button>
+          </div>
+        ))}
+        <div className="space-y-2">
+          <Label htmlFor="terminal-key">Terminal key</Label>
+          <Input id="terminal-key" value={terminal} onChange={(e) => setTerminal(e.target.value)} placeholder="npub1..." />
+        </div>
+        <div className="grid grid-cols-2 gap-3">
+          <div className="space-y-2">
+            <Label htmlFor="terminal-label">Name</Label>
+            <Input id="terminal-label" value={label} onChange={(e) => setLabel(e.target.value)} placeholder="Pro shop" />
+          </div>
+          <div className="space-y-2">
+            <Label htmlFor="terminal-hours">Hours</Label>
+            <Input id="terminal-hours" type="number" min={1} value={hours} onChange={(e) => setHours(e.target.value)} />
+          </div>
+        </div>
+        <Button className="w-full" onClick={handleGrant} disabled={isPublishing}>Authorise Terminal</Button>
+      </CardContent>
+    </Card>
+  );
+}
@@ -0,0 +1,75 @@
+import { useState } from 'react';
+import { Card, CardContent, CardDescription, CardHeader, CardTitle } from '@/components/ui/card';
+import { Input } from '@/components/ui/input';
+import { Label } from '@/components/ui/label';
+import { useSensorIngestion } from '@/hooks/useSensorIngestion';
+import { useToast } from '@/hooks/useToast';
+import type { SensorBatch } from '@/lib/golf/sensorEngine';
+
+/** Batches from a device's JSON export: a list of batches or a single one */
+function parseSensorFile(text: string): SensorBatch[] {
+  const data: unknown = JSON.parse(text);
+  const batches = Array.isArray(data) ? data : [data];
+  return batches.filter((b): b is SensorBatch =>
+    !!b && typeof b === 'object' && Array.isArray((b as SensorBatch).measurements)
+  );
+}
+
+/**
+ * Load shots from a rangefinder, ball tracker or smart grip export into
+ * shot tracking. A file loaded twice doesn't record its shots twice.
+ */
+export function SensorImportCard() {
+  const { ingest, isIngesting } = useSensorIngestion();
+  const { toast } = useToast();
+  const [errors, setErrors] = useState<string[]>([]);
+
+  const handleFile = async (input: HTMLInputElement) => {
+    const file = input.files?.[0];
+    if (!file) return;
+    try {
+      const batches = parseSensorFile(await file.text());
+      if (batches.length === 0) throw new Error('The file has no sensor batches');
+      const result = await ingest(batches);
+      setErrors(result.errors);
+      toast({ title: 'Sensor data loaded', description: `${result.shots.length} shots recorded` });
+    } catch (error) {
+      toast({
+        title: 'Could not load the sensor data',
+        description: error instanceof Error ? error.message : 'Please try again',
+        variant: 'destructive',
+      });
+    } finally {
+      input.value = '';
+    }
+  };
+
+  return (
+    <Card>
+      <CardHeader>
+        <CardTitle className="text-lg">Sensors</CardTitle>
+        <CardDescription>Load shots from a rangefinder, ball tracker or smart grip export</CardDescription>
+      </CardHeader>
+      <CardContent className="space-y-3">
+        <div className="space-y-2">
+          <Label htmlFor="sensor-file">Device export (JSON)</Label>
+          <Input
+            id="sensor-file"
+            type="file"
+            accept=".json,application/json"
+            disabled={isIngesting}
+            onChange={(e) => handleFile(e.target)}
+          />
+        </div>
+        {errors.length > 0 && (
+          <div className="space-y-1">
+            <p className="text-xs text-muted-foreground">Skipped {errors.length} measurement{errors.length === 1 ? '' : 's'}:</p>
+            {errors.slice(0, 5).map((error, i) => (
+              <p key={i} className="text-xs text-destructive">{error}</p>
+            ))}
+          </div>
+        )}
+      </CardContent>
+    </Card>
+  );
+}
@@ -0,0 +1,73 @@
+import React from 'react';
+import { Button } from '@/components/ui/button';
+import { Input } from '@/components/ui/input';
+import { Label } from '@/components/ui/label';
+import { Select, SelectContent, SelectItem, SelectTrigger, SelectValue } from '@/components/ui/select';
+import { SIDE_COMPETITION_NAMES, type SideCompetition, type SideCompetitionType } from '@/lib/golf/sideCompetitionEngine';
+
+interface SideCompetitionsEditorProps {
+  value: SideCompetition[];
+  onChange: (value: SideCompetition[]) => void;
+  holes: number[];
+  pars?: { [hole: number]: number };
+}
+
+/**
+ * Pick the holes that carry a closest-to-the-pin or longest-drive prize
+ */
+export function SideCompetitionsEditor({ value, onChange, holes, pars = {} }: SideCompetitionsEditorProps) {
+  const update = (index: number, patch: Partial<SideCompetition>) =>
+    onChange(value.map((c, i) => (i === index ? { ...c, ...patch } : c)));
+
+  const add = () => {
+    // Closest to the pin suits a par 3, longest drive a par 5
+    const parThree = holes.find(h => pars[h] === 3 && !value.some(c => c.hole === h));
+    const hole = parThree ?? holes.find(h => !value.some(c => c.hole === h)) ?? holes[0] ?? 1;
+    onChange([...value, { hole, type: pars[hole] === 3 ? 'closest-to-pin' : 'longest-drive', prizeSats: 0 }]);
+  };
+
+  return (
+    <div className="space-y-2">
+      <Label>Side Competitions</Label>
+      {value.map((competition, index) => (
+        <div key={index} className="flex items-center gap-2">
+          <Select value={String(competition.hole)} onValueChange={v => update(index, { hole: parseInt(v) })}>
+            <SelectTrigger className="w-24">
+              <SelectValue />
+            </SelectTrigger>
+            <SelectContent>
+              {holes.map(h => (
+                <SelectItem key={h} value={String(h)}>Hole {h}</SelectItem>
+              ))}
+            </SelectContent>
+          </Select>
+          <Select value={competition.type} onValueChange={v => update(index, { type: v as SideCompetitionType })}>
+            <SelectTrigger className="flex-1">
+              <SelectValue />
+            </SelectTrigger>
+            <SelectContent>
+              {(Object.keys(SIDE_COMPETITION_NAMES) as SideCompetitionType[]).map(type => (
+                <SelectItem key={type} value={type}>{SIDE_COMPETITION_NAMES[type]}</SelectItem>
+              ))}
+            </SelectContent>
+          </Select>
+          <Input
+            type="number"
+            min={0}
+            className="w-28"
+            placeholder="Prize (sats)"
+            value={competition.prizeSats || ''}
+            onChange={e => update(index, { prizeSats: Math.max(0, parseInt(e.target.value) || 0) })}
+          />
+          <Button variant="ghost" size="sm" onClick={() => onChange(value.filter((_, i) => i !== index))}>
+            Remove
+          </Button>
+        </div>
+      ))}
+      <Button variant="outline" size="sm" onClick={add}>Add side competition</Button>
+      <p className="text-xs text-muted-foreground">
+        Judged from the players' GPS tee shots. Prizes are paid from your club wallet when the round closes.
+      </p>
+    </div>
+  );
+}
@@ -0,0 +1,77 @@
+import React, { useState } from 'react';
+import { Card, CardContent, CardDescription, CardHeader, CardTitle } from '@/components/ui/card';
+import { Badge } from '@/components/ui/badge';
+import { Button } from '@/components/ui/button';
+import { PrizePayoutDialog } from '@/components/golf/PrizePayoutDialog';
+import { useAuthor } from '@/hooks/useAuthor';
+import { useSideCompetitions } from '@/hooks/useSideCompetitions';
+import { genUserName } from '@/lib/genUserName';
+import { SIDE_COMPETITION_NAMES, type SideStanding } from '@/lib/golf/sideCompetitionEngine';
+import type { GeoPoint } from '@/lib/golf/caddieEngine';
+import type { GolfRound } from '@/lib/golf/types';
+
+function StandingRow({ standing, isFinal }: { standing: SideStanding; isFinal: boolean }) {
+  const { competition, leader } = standing;
+  const author = useAuthor(leader?.playerPubkey);
+  const name = leader ? author.data?.metadata?.name ?? genUserName(leader.playerPubkey) : null;
+
+  return (
+    <div className="flex items-center justify-between gap-2 rounded border p-3">
+      <div className="min-w-0">
+        <div className="text-sm font-medium">
+          {SIDE_COMPETITION_NAMES[competition.type]} · Hole {competition.hole}
+        </div>
+        <div className="text-xs text-muted-foreground truncate">
+          {name
+            ? `${isFinal ? 'Winner' : 'Leader'}: ${name} (${Math.round(leader!.yards)} yds)`
+            : 'No shots recorded yet'}
+        </div>
+      </div>
+      {competition.prizeSats > 0 && (
+        <Badge variant={isFinal && leader ? 'default' : 'secondary'}>{competition.prizeSats.toLocaleString()} sats</Badge>
+      )}
+    </div>
+  );
+}
+
+interface SideCompetitionsPanelProps {
+  round: Partial<GolfRound>;
+  greens?: { [hole: number]: GeoPoint };
+}
+
+/**
+ * Live leaders of the round's side competitions, and the winners' payouts
+ * once the round is closed
+ */
+export function SideCompetitionsPanel({ round, greens }: SideCompetitionsPanelProps) {
+  const { standings, isFinal, prizes } = useSideCompetitions(round, greens);
+  const [payOpen, setPayOpen] = useState(false);
+
+  if (standings.length === 0) return null;
+
+  return (
+    <Card>
+      <CardHeader>
+        <CardTitle className="text-lg">Side Competitions</CardTitle>
+        <CardDescription>
+          {isFinal ? 'Final results' : 'Judged from GPS shots as they are recorded'}
+        </CardDescription>
+      </CardHeader>
+      <CardContent className="space-y-2">
+        {standings.map(standing => (
+          <StandingRow
+            key={`${standing.competition.type}-${standing.competition.hole}`}
+            standing={standing}
+            isFinal={isFinal}
+          />
+        ))}
+        {prizes.length > 0 && (
+          <Button variant="outline" className="w-full" onClick={() => setPayOpen(true)}>
+            Pay Winners from Club Wallet
+          </Button>
+        )}
+      </CardContent>
+      <PrizePayoutDialog open={payOpen} onOpenChange={setPayOpen} prizes={prizes} />
+    </Card>
+  );
+}
@@ -0,0 +1,80 @@
+import { useState } from 'react';
+import { Button } from '@/components/ui/button';
+import { Card, CardContent, CardDescription, CardHeader, CardTitle } from '@/components/ui/card';
+import { Input } from '@/components/ui/input';
+import { Label } from '@/components/ui/label';
+import { useSimulatorImport } from '@/hooks/useSimulatorImport';
+import { useToast } from '@/hooks/useToast';
+import type { SimulatorRound, SimulatorSource } from '@/lib/golf/simulatorImport';
+
+const SOURCE_LABELS: Record<SimulatorSource, string> = {
+  gspro: 'GSPro',
+  trackman: 'TrackMan',
+  generic: 'Simulator',
+};
+
+/**
+ * Import a simulator round export as an indoor round. It only counts
+ * towards the handicap when the player opts in to indoor rounds.
+ */
+export function SimulatorImportCard() {
+  const { preview, apply, isApplying } = useSimulatorImport();
+  const { toast } = useToast();
+  const [round, setRound] = useState<SimulatorRound | null>(null);
+
+  const handleFile = async (file: File | undefined) => {
+    setRound(file ? preview(await file.text()) : null);
+  };
+
+  const handleImport = async () => {
+    if (!round) return;
+    try {
+      await apply(round);
+      toast({ title: 'Round imported', description: `${round.course || 'Simulator round'} on ${new Date(round.date).toLocaleDateString()}` });
+      setRound(null);
+    } catch (error) {
+      toast({
+        title: 'Could not import the round',
+        description: error instanceof Error ? error.message : 'Please try again',
+        variant: 'destructive',
+      });
+    }
+  };
+
+  const gross = round?.holes.reduce((sum, h) => sum + h.strokes, 0) ?? 0;
+
+  return (
+    <Card>
+      <CardHeader>
+        <CardTitle className="text-lg">Simulator Round</CardTitle>
+        <CardDescription>Import a GSPro or TrackMan export as an indoor round</CardDescription>
+      </CardHeader>
+      <CardContent className="space-y-3">
+        <div className="space-y-2">
+          <Label htmlFor="simulator-file">Round export</Label>
+          <Input id="simulator-file" type="file" accept=".csv,text/csv" onChange={(e) => handleFile(e.target.files?.[0])} />
+        </div>
+        {round && (
+          <>
+            <p className="text-sm">
+              {SOURCE_LABELS[round.source]} · {round.course || 'Unknown course'} · {new Date(round.date).toLocaleDateString()}
+            </p>
+            <p className="text-sm text-muted-foreground">
+              {round.holes.length} holes · {gross} strokes
+              {round.shots.length > 0 && ` · ${round.shots.length} shots for your practice stats`}
+            </p>
+            {round.unmatchedClubs.length > 0 && (
+              <p className="text-xs text-muted-foreground">Not in your bag: {round.unmatchedClubs.join(', ')}</p>
+            )}
+            {round.errors.map((error, i) => (
+              <p key={i} className="text-xs text-destructive">{error}</p>
+            ))}
+            <Button className="w-full" onClick={handleImport} disabled={isApplying || round.holes.length === 0}>
+              Import Round
+            </Button>
+          </>
+        )}
+      </CardContent>
+    </Card>
+  );
+}
@@ -0,0 +1,45 @@
+import React, { useEffect, useState } from 'react';
+import { useGolfCourses } from '@/hooks/useGolfCourses';
+import { useSponsorImpressions, useSponsorSlots } from '@/hooks/useSponsorSlots';
+import { activeSlots, sponsorForRotation, type SponsorPlacement } from '@/lib/golf/sponsorEngine';
+import { cn } from '@/lib/utils';
+
+interface SponsorBannerProps {
+  courseId?: string;
+  courseName?: string;
+  placement: SponsorPlacement;
+  className?: string;
+}
+
+/**
+ * The course's current sponsor banner, if it has one running. Banners take
+ * turns by weight across views on this device.
+ */
+export function SponsorBanner({ courseId, courseName, placement, className }: SponsorBannerProps) {
+  const { data: courses = [] } = useGolfCourses();
+  const course = courses.find(c => (courseId && c.id === courseId) || (courseName && c.name === courseName));
+  const { data: slots = [] } = useSponsorSlots(course);
+  const { impressions, recordImpression } = useSponsorImpressions();
+
+  // Impressions so far on this device pick the next banner in the rotation, once per view
+  const [rotation] = useState(() => Object.values(impressions).reduce((sum, i) => sum + i.count, 0));
+  const sponsor = sponsorForRotation(activeSlots(slots, placement, Date.now()), rotation);
+
+  const sponsorId = sponsor?.slotId;
+  useEffect(() => {
+    if (sponsorId) recordImpression(sponsorId);
+  }, [sponsorId, recordImpression]);
+
+  if (!sponsor) return null;
+
+  const banner = <img src={sponsor.imageUrl} alt={sponsor.sponsor} className="w-full max-h-24 object-contain" />;
+
+  return (
+    <div className={cn('rounded-md border bg-card p-2', className)}>
+      <p className="text-[10px] uppercase tracking-wide text-muted-foreground mb-1">Sponsored by {sponsor.sponsor}</p>
+      {sponsor.link ? (
+        <a href={sponsor.link} target="_blank" rel="noreferrer sponsored">{banner}</a>
+      ) : banner}
+    </div>
+  );
+}
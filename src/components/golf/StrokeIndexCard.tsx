@@ -0,0 +1,69 @@
+import { useMemo } from 'react';
+import { Badge } from '@/components/ui/badge';
+import { Card, CardContent, CardDescription, CardHeader, CardTitle } from '@/components/ui/card';
+import { Skeleton } from '@/components/ui/skeleton';
+import { useStrokeIndexRecommendation } from '@/hooks/useStrokeIndexRecommendation';
+import type { GolfCourse } from '@/hooks/useGolfCourses';
+import type { StrokeIndexHole } from '@/lib/golf/strokeIndexEngine';
+
+/**
+ * Stroke index table recommended from the scores played at the course,
+ * next to the current one, for the club to review
+ */
+export function StrokeIndexCard({ course }: { course: Pick<GolfCourse, 'name' | 'holes' | 'handicaps'> }) {
+  const holes = useMemo<StrokeIndexHole[]>(
+    () => Object.entries(course.holes)
+      .map(([hole, par]) => ({ hole: Number(hole), par, currentIndex: course.handicaps?.[Number(hole)] }))
+      .sort((a, b) => a.hole - b.hole),
+    [course.holes, course.handicaps]
+  );
+  const { data: report, isLoading } = useStrokeIndexRecommendation(course.name, holes);
+
+  return (
+    <Card>
+      <CardHeader>
+        <CardTitle className="text-lg">Stroke Index</CardTitle>
+        <CardDescription>
+          {report
+            ? `Recommended from ${report.totalSamples} hole scores · odd indexes on the ${report.oddNine} nine`
+            : 'Recommended from the scores played here'}
+        </CardDescription>
+      </CardHeader>
+      <CardContent className="space-y-2">
+        {isLoading ? (
+          <Skeleton className="h-24 w-full" />
+        ) : holes.length !== 18 ? (
+          <p className="text-sm text-muted-foreground">Stroke indexes can only be allocated on an 18-hole course.</p>
+        ) : !report || report.totalSamples === 0 ? (
+          <p className="text-sm text-muted-foreground">No scores submitted here yet.</p>
+        ) : (
+          <>
+            {report.insufficientHoles.length > 0 && (
+              <p className="text-xs text-muted-foreground">
+                Not enough scores yet on hole{report.insufficientHoles.length > 1 ? 's' : ''} {report.insufficientHoles.join(', ')}
+              </p>
+            )}
+            <div className="grid grid-cols-4 gap-2 text-xs font-medium text-muted-foreground">
+              <span>Hole</span>
+              <span>Average</span>
+              <span>Current</span>
+              <span>Recommended</span>
+            </div>
+            {report.holes.map(hole => (
+              <div key={hole.hole} className="grid grid-cols-4 gap-2 text-sm">
+                <span>{hole.hole} · par {hole.par}</span>
+                <span>{hole.samples > 0 ? hole.averageScore.toFixed(2) : '—'}</span>
+                <span>{hole.currentIndex ?? '—'}</span>
+                <span>
+                  {report.changedHoles.includes(hole.hole)
+                    ? <Badge variant="secondary">{hole.recommendedIndex}</Badge>
+                    : hole.recommendedIndex}
+                </span>
+              </div>
+            ))}
+          </>
+        )}
+      </CardContent>
+    </Card>
+  );
+}
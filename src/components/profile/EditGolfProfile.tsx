@@ -11,6 +11,7 @@ import { Save, User, Home, Settings, Calculator, RefreshCw } from 'lucide-react'
 import { useGolfProfileMutation } from '@/hooks/useGolfProfile';
 import { useCurrentUser } from '@/hooks/useCurrentUser';
 import { useHandicapCalculation } from '@/hooks/useHandicapCalculation';
+import { useHandicapSettings } from '@/hooks/useHandicapSettings';
 import { HandicapInfoDialog } from '@/components/golf/HandicapInfoDialog';
 import type { GolfProfile } from '@/lib/golf/social';
 
@@ -23,7 +24,8 @@ interface EditGolfProfileProps {
 export function EditGolfProfile({ profile, onSave, className }: EditGolfProfileProps) {
   const { user } = useCurrentUser();
   const { createProfile, updateProfile } = useGolfProfileMutation();
-  const { data: handicapResult, isLoading: isCalculatingHandicap } = useHandicapCalculation(user?.pubkey);
+  const { committeePubkeys, requireAttestation } = useHandicapSettings();
+  const { data: handicapResult, isLoading: isCalculatingHandicap } = useHandicapCalculation(user?.pubkey, committeePubkeys, { requireAttestation });
   
   const [formData, setFormData] = useState({
     name: profile?.name || '',
@@ -203,6 +205,12 @@ export function EditGolfProfile({ profile, onSave, className }: EditGolfProfileP
                 ) : (
                   <span>Enter your handicap index manually</span>
                 )}
+                {!!handicapResult?.heldForReview?.length && (
+                  <p className="mt-1 text-amber-600 dark:text-amber-400">
+                    {handicapResult.heldForReview.length} round{handicapResult.heldForReview.length === 1 ? '' : 's'} held for committee review:{' '}
+                    {handicapResult.heldForReview[0].integrityFlags?.find(f => f.severity === 'review')?.message ?? 'card disputed by marker'}
+                  </p>
+                )}
               </div>
             </div>
           </div>
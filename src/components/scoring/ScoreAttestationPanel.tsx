@@ -0,0 +1,85 @@
+import React from 'react';
+import { Card, CardContent, CardDescription, CardHeader, CardTitle } from '@/components/ui/card';
+import { Badge } from '@/components/ui/badge';
+import { Button } from '@/components/ui/button';
+import { useCurrentUser } from '@/hooks/useCurrentUser';
+import { useScoreAttestations } from '@/hooks/useScoreAttestations';
+import { useToast } from '@/hooks/useToast';
+import type { AttestationStatus } from '@/lib/golf/attestationEngine';
+import type { PlayerInRound } from '@/lib/golf/types';
+
+const statusStyles: Record<AttestationStatus, { label: string; variant: 'secondary' | 'destructive' | 'outline' }> = {
+  pending: { label: 'Awaiting marker', variant: 'outline' },
+  attested: { label: 'Attested', variant: 'secondary' },
+  disputed: { label: 'Disputed', variant: 'destructive' },
+};
+
+interface ScoreAttestationPanelProps {
+  roundId: string;
+  players: PlayerInRound[];
+}
+
+/**
+ * Marker sign-off for each card in a round
+ */
+export function ScoreAttestationPanel({ roundId, players }: ScoreAttestationPanelProps) {
+  const { user } = useCurrentUser();
+  const { toast } = useToast();
+  const pubkeys = players.map(p => p.playerId);
+  const { data: cards = {}, attest, isAttesting } = useScoreAttestations(roundId, pubkeys);
+
+  if (players.length < 2) return null;
+
+  const isMarker = !!user && pubkeys.includes(user.pubkey);
+
+  const handleVerdict = async (playerPubkey: string, verdict: 'attest' | 'dispute') => {
+    try {
+      await attest({ playerPubkey, verdict });
+      toast({ title: verdict === 'attest' ? 'Card attested' : 'Card disputed' });
+    } catch (error) {
+      toast({
+        title: 'Could not sign the card',
+        description: error instanceof Error ? error.message : 'Please try again',
+        variant: 'destructive',
+      });
+    }
+  };
+
+  return (
+    <Card>
+      <CardHeader>
+        <CardTitle className="text-lg">Marker sign-off</CardTitle>
+        <CardDescription>Cards count for handicap and competitions once a marker attests them</CardDescription>
+      </CardHeader>
+      <CardContent className="space-y-3">
+        {players.map(player => {
+          const entry = cards[player.playerId];
+          const gross = entry ? Object.values(entry.card.scores).reduce((sum, s) => sum + s, 0) : null;
+          const canMark = isMarker && player.playerId !== user?.pubkey && !!entry;
+
+          return (
+            <div key={player.playerId} className="flex items-center justify-between gap-2">
+              <div className="min-w-0">
+                <div className="font-medium truncate">{player.name}</div>
+                <div className="text-xs text-muted-foreground">{gross !== null ? `Gross ${gross}` : 'No card yet'}</div>
+              </div>
+              <div className="flex items-center gap-2">
+                {entry && <Badge variant={statusStyles[entry.status].variant}>{statusStyles[entry.status].label}</Badge>}
+                {canMark && (
+                  <>
+                    <Button size="sm" variant="outline" disabled={isAttesting} onClick={() => handleVerdict(player.playerId, 'attest')}>
+                      Attest
+                    </Button>
+                    <Button size="sm" variant="ghost" disabled={isAttesting} onClick={() => handleVerdict(player.playerId, 'dispute')}>
+                      Dispute
+                    </Button>
+                  </>
+                )}
+              </div>
+            </div>
+          );
+        })}
+      </CardContent>
+    </Card>
+  );
+}
@@ -31,6 +31,7 @@ interface ScoreCardProps {
   onUpdateRound: (round: GolfRound) => void;
   onSaveRound: () => void;
   onShareRound?: () => void;
+  detectedHole?: number | null; // hole the player's GPS puts them on; the card follows it
 }
 
 export const ScoreCard: React.FC<ScoreCardProps> = ({
@@ -38,9 +39,15 @@ export const ScoreCard: React.FC<ScoreCardProps> = ({
   course,
   onUpdateRound,
   onSaveRound,
-  onShareRound
+  onShareRound,
+  detectedHole
 }) => {
   const [currentHole, setCurrentHole] = useState(0);
+
+  // Walking onto a new hole moves the card there; the player can still page back
+  React.useEffect(() => {
+    if (detectedHole && detectedHole <= round.holes.length) setCurrentHole(detectedHole - 1);
+  }, [detectedHole, round.holes.length]);
   const [currentPlayer, setCurrentPlayer] = useState(0);
   const [activeTab, setActiveTab] = useState<'score' | 'others'>('score');
   const [expandedPutts, setExpandedPutts] = useState(false);
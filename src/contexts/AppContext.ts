@@ -1,4 +1,6 @@
 import { createContext } from "react";
+import type { ContentFilterConfig } from "@/lib/golf/contentFilterEngine";
+import type { HandicapSettings } from "@/lib/golf/handicapCalculator";
 
 export type Theme = "dark" | "light" | "system";
 
@@ -7,6 +9,10 @@ export interface AppConfig {
   theme: Theme;
   /** Selected relay URL */
   relayUrl: string;
+  /** Spam filtering for the feed, round chat and reactions */
+  contentFilter?: ContentFilterConfig;
+  /** Handicap committees the handicap calculation trusts */
+  handicap?: HandicapSettings;
 }
 
 export interface AppContextType {
@@ -0,0 +1,192 @@
+import { useEffect } from 'react';
+import { useQuery, useMutation, useQueryClient } from '@tanstack/react-query';
+import { useNostr } from '@nostrify/react';
+import type { NostrEvent, NostrFilter } from '@nostrify/nostrify';
+import { useCurrentUser } from './useCurrentUser';
+import { useNostrPublish } from './useNostrPublish';
+import { useToast } from './useToast';
+import { fetchPlayerCards, resolveScoreCards } from './useScoringDelegations';
+import { fetchRoundCourses } from './useGolfCourses';
+import { aceCoordinate } from './useCourseAces';
+import { GOLF_KINDS } from '@/lib/golf/types';
+import { BadgeService } from '@/lib/golf/badgeSystem';
+import {
+  createBadgeDefinitionEvent,
+  createNip58AwardEvent,
+  parseAceEvent,
+  parseAceWitnessEvent,
+  parsePlayerScoreEvent,
+  type PlayerScoreRecord,
+} from '@/lib/golf/nostrEvents';
+import { playerCardFilters } from '@/lib/golf/delegationEngine';
+import { evaluateAchievements, mergeAchievements, withCoursePars, type EarnedAchievement } from '@/lib/golf/achievementEngine';
+import { aceWall, type Ace, type AceWitness } from '@/lib/golf/aceEngine';
+
+const badgeService = new BadgeService();
+
+interface NostrLike {
+  query(filters: NostrFilter[], opts?: { signal?: AbortSignal }): Promise<NostrEvent[]>;
+}
+
+const storageKey = (pubkey: string) => `achievements:${pubkey}`;
+
+function loadEarned(pubkey: string): EarnedAchievement[] | null {
+  try {
+    const stored = localStorage.getItem(storageKey(pubkey));
+    return stored ? JSON.parse(stored) : null;
+  } catch {
+    return null;
+  }
+}
+
+function saveEarned(pubkey: string, earned: EarnedAchievement[]) {
+  localStorage.setItem(storageKey(pubkey), JSON.stringify(earned));
+}
+
+/**
+ * Add badges earned outside the player's own cards (prediction games) to
+ * those stored for them on this device. Returns the ones that are new.
+ */
+export function recordAchievements(pubkey: string, achievements: EarnedAchievement[]): EarnedAchievement[] {
+  const { earned, unlocked } = mergeAchievements(loadEarned(pubkey) ?? [], achievements);
+  if (unlocked.length > 0) saveEarned(pubkey, earned);
+  return unlocked;
+}
+
+/** The player's aces that two witnesses have signed */
+async function fetchConfirmedAces(nostr: NostrLike, pubkey: string, signal: AbortSignal): Promise<Ace[]> {
+  const aces = (await nostr.query([{ kinds: [GOLF_KINDS.ACE], authors: [pubkey], limit: 50 }], { signal }))
+    .map(e => parseAceEvent(e))
+    .filter((a): a is Ace => a !== null);
+  if (aces.length === 0) return [];
+
+  const witnesses = (await nostr.query([{ kinds: [GOLF_KINDS.ACE_WITNESS], '#a': aces.map(aceCoordinate) }], { signal }))
+    .map(e => parseAceWitnessEvent(e))
+    .filter((w): w is AceWitness => w !== null);
+  return aceWall(aces, witnesses);
+}
+
+/**
+ * Evaluate a player's cards and merge the result into the badges stored for
+ * them on this device. Pars a card doesn't record come from its round's
+ * course. `unlocked` is empty the first time, so a player's history isn't
+ * announced as new.
+ */
+async function syncAchievements(nostr: NostrLike, pubkey: string, signal: AbortSignal, extra: NostrEvent[] = []) {
+  const [stored, received] = await Promise.all([
+    fetchPlayerCards(nostr, [pubkey], { limit: 500 }, signal),
+    resolveScoreCards(nostr, extra, signal),
+  ]);
+  const cards = [...stored, ...received]
+    .filter(card => card.player === pubkey)
+    .map(({ event, player }) => {
+      const record = parsePlayerScoreEvent(event);
+      return record && { ...record, playerPubkey: player };
+    })
+    .filter((c): c is PlayerScoreRecord => c !== null);
+
+  const [courses, aces] = await Promise.all([
+    fetchRoundCourses(nostr, [...new Set(cards.map(c => c.roundId))], pubkey, signal),
+    fetchConfirmedAces(nostr, pubkey, signal),
+  ]);
+  const withPars = cards.map(card => withCoursePars(card, courses.get(card.roundId)?.course.holes ?? {}));
+
+  const known = loadEarned(pubkey);
+  const { earned, unlocked } = mergeAchievements(known ?? [], evaluateAchievements(withPars, aces));
+  saveEarned(pubkey, earned);
+  return { earned, unlocked: known ? unlocked : [] };
+}
+
+/**
+ * Hook for a player's earned badges. They are worked out from the player's
+ * score cards and kept per player on this device; `publishBadge` awards one
+ * as a NIP-58 badge so other Nostr clients can show it.
+ */
+export function useAchievements(pubkey: string | undefined) {
+  const { nostr } = useNostr();
+  const { user } = useCurrentUser();
+  const { mutateAsync: publishEvent } = useNostrPublish();
+  const queryClient = useQueryClient();
+
+  const query = useQuery<EarnedAchievement[]>({
+    queryKey: ['achievements', pubkey],
+    queryFn: async (c) => {
+      const signal = AbortSignal.any([c.signal, AbortSignal.timeout(5000)]);
+      return (await syncAchievements(nostr, pubkey!, signal)).earned;
+    },
+    enabled: !!pubkey,
+    placeholderData: () => (pubkey ? loadEarned(pubkey) ?? undefined : undefined),
+    staleTime: 5 * 60 * 1000,
+  });
+
+  const publish = useMutation({
+    mutationFn: async (badgeId: string) => {
+      if (!user || user.pubkey !== pubkey) throw new Error('Log in as this player to publish badges');
+      const badge = badgeService.getBadgeDefinition(badgeId);
+      if (!badge) throw new Error(`Unknown badge: ${badgeId}`);
+
+      for (const event of [createBadgeDefinitionEvent(badge, user.pubkey), createNip58AwardEvent(badge, user.pubkey, user.pubkey)]) {
+        await publishEvent({ kind: event.kind, content: event.content, tags: event.tags, created_at: event.created_at });
+      }
+
+      const earned = (loadEarned(user.pubkey) ?? []).map(a => (a.badgeId === badgeId ? { ...a, published: true } : a));
+      saveEarned(user.pubkey, earned);
+      return earned;
+    },
+    onSuccess: (earned) => {
+      queryClient.setQueryData(['achievements', pubkey], earned);
+    },
+  });
+
+  return {
+    ...query,
+    publishBadge: publish.mutateAsync,
+    isPublishing: publish.status === 'pending',
+  };
+}
+
+/**
+ * Congratulate the logged-in player as their new cards, or newly confirmed
+ * aces, earn badges
+ */
+export function useAchievementNotifications() {
+  const { nostr } = useNostr();
+  const { user } = useCurrentUser();
+  const { toast } = useToast();
+  const queryClient = useQueryClient();
+  const pubkey = user?.pubkey;
+
+  useEffect(() => {
+    if (!pubkey) return;
+    const controller = new AbortController();
+
+    (async () => {
+      try {
+        // New cards, and witnesses confirming the player's aces
+        const since = Math.floor(Date.now() / 1000);
+        const subscription = nostr.req(
+          [...playerCardFilters([pubkey], { since }), { kinds: [GOLF_KINDS.ACE_WITNESS], '#p': [pubkey], since }],
+          { signal: controller.signal }
+        );
+
+        for await (const msg of subscription) {
+          if (msg[0] === 'CLOSED') break;
+          if (msg[0] !== 'EVENT') continue;
+
+          const signal = AbortSignal.any([controller.signal, AbortSignal.timeout(5000)]);
+          const received = msg[2].kind === GOLF_KINDS.PLAYER_SCORE ? [msg[2]] : [];
+          const { earned, unlocked } = await syncAchievements(nostr, pubkey, signal, received);
+          for (const achievement of unlocked) {
+            const badge = badgeService.getBadgeDefinition(achievement.badgeId);
+            if (badge) toast({ title: `${badge.icon} Achievement unlocked: ${badge.name}`, description: badge.description });
+          }
+          queryClient.setQueryData(['achievements', pubkey], earned);
+        }
+      } catch (err) {
+        if (!controller.signal.aborted) console.warn('Achievement subscription ended', err);
+      }
+    })();
+
+    return () => controller.abort();
+  }, [nostr, pubkey, toast, queryClient]);
+}
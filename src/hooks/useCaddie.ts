@@ -0,0 +1,35 @@
+import { useMemo } from 'react';
+import { useGolfBag } from './useGolfBag';
+import { useElevationChange } from './useElevation';
+import { recommendShot, type GeoPoint, type ShotConditions } from '@/lib/golf/caddieEngine';
+
+/**
+ * Hook for a caddie recommendation: club and aim point from the player's
+ * position to a target, using their bag and learned dispersion. Elevation
+ * comes from SRTM tiles unless `conditions.elevationChange` is given.
+ */
+export function useCaddie(
+  pubkey: string | undefined,
+  position: GeoPoint | null,
+  target: GeoPoint | null,
+  conditions: ShotConditions = {}
+) {
+  const bag = useGolfBag(pubkey);
+  const { windSpeed, windDirection } = conditions;
+  const elevation = useElevationChange(
+    conditions.elevationChange === undefined ? position : null,
+    conditions.elevationChange === undefined ? target : null
+  );
+  const elevationChange = conditions.elevationChange ?? elevation.data ?? undefined;
+
+  const recommendation = useMemo(() => {
+    if (!bag.data || !position || !target) return null;
+    return recommendShot(position, target, bag.data.distances, { windSpeed, windDirection, elevationChange });
+  }, [bag.data, position, target, windSpeed, windDirection, elevationChange]);
+
+  return {
+    recommendation,
+    isLoading: bag.isLoading,
+    error: bag.error,
+  };
+}
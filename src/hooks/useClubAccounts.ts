@@ -0,0 +1,58 @@
+import { useMemo } from 'react';
+import { useQuery } from '@tanstack/react-query';
+import { useNostr } from '@nostrify/react';
+import { useVouchers } from './useVouchers';
+import { usePrizePayouts } from './usePrizePayouts';
+import { GOLF_KINDS } from '@/lib/golf/types';
+import { parseRentalInventoryEvent, parseRentalReservationEvent } from '@/lib/golf/nostrEvents';
+import { payoutEntries, rentalEntries, voucherEntries, type LedgerEntry } from '@/lib/golf/ledgerEngine';
+import type { RentalInventory, RentalReservation } from '@/lib/golf/rentalEngine';
+import type { GolfCourse } from './useGolfCourses';
+
+/**
+ * Journal entries for the club's accounts: gift vouchers, equipment hire
+ * and prize payouts. Payouts come from this device's payout history, since
+ * that is the only record of what the club wallet paid.
+ */
+export function useClubAccounts(course: Pick<GolfCourse, 'id' | 'author'> | null | undefined) {
+  const { nostr } = useNostr();
+  const { vouchers, redemptions, isLoading: vouchersLoading } = useVouchers(course);
+  const { history } = usePrizePayouts();
+
+  // Every reservation, not just the recent ones the rentals page needs
+  const rentals = useQuery<{ inventory: RentalInventory | null; reservations: RentalReservation[] }>({
+    queryKey: ['rental-history', course?.author, course?.id],
+    queryFn: async (c) => {
+      const signal = AbortSignal.any([c.signal, AbortSignal.timeout(5000)]);
+      const events = await nostr.query([
+        { kinds: [GOLF_KINDS.RENTAL_INVENTORY], authors: [course!.author], '#d': [course!.id] },
+        { kinds: [GOLF_KINDS.RENTAL_RESERVATION], '#a': [`${GOLF_KINDS.COURSE}:${course!.author}:${course!.id}`], limit: 2000 },
+      ], { signal });
+
+      const inventory = events
+        .map(parseRentalInventoryEvent)
+        .filter((i): i is RentalInventory => i !== null && i.courseAuthor === course!.author)
+        .sort((a, b) => b.createdAt - a.createdAt)[0] ?? null;
+
+      const latest = new Map<string, RentalReservation>();
+      for (const reservation of events.map(parseRentalReservationEvent)) {
+        if (!reservation) continue;
+        const key = `${reservation.renter}:${reservation.reservationId}`;
+        const existing = latest.get(key);
+        if (!existing || reservation.createdAt > existing.createdAt) latest.set(key, reservation);
+      }
+
+      return { inventory, reservations: [...latest.values()] };
+    },
+    enabled: !!course?.author && !!course?.id,
+    staleTime: 60 * 1000,
+  });
+
+  const entries = useMemo<LedgerEntry[]>(() => [
+    ...voucherEntries(vouchers, redemptions),
+    ...rentalEntries(rentals.data?.inventory ?? null, rentals.data?.reservations ?? []),
+    ...payoutEntries(history),
+  ], [vouchers, redemptions, rentals.data, history]);
+
+  return { entries, isLoading: vouchersLoading || rentals.isLoading };
+}
@@ -0,0 +1,116 @@
+import { useEffect } from 'react';
+import { useQuery, useQueryClient } from '@tanstack/react-query';
+import { useNostr } from '@nostrify/react';
+import type { NostrEvent } from '@nostrify/nostrify';
+import { resolveScoreCards } from './useScoringDelegations';
+import { GOLF_KINDS } from '@/lib/golf/types';
+import { scoreCardFilters } from '@/lib/golf/delegationEngine';
+import { parsePlayerScoreEvent } from '@/lib/golf/nostrEvents';
+import {
+  competitionActivity,
+  mergeActivity,
+  roundActivity,
+  scoreHighlights,
+  type ActivityItem,
+} from '@/lib/golf/activityEngine';
+
+// How far back the feed reaches
+const ACTIVITY_WINDOW_SECONDS = 24 * 60 * 60;
+
+/**
+ * Live activity on a course, newest first: rounds starting and finishing,
+ * birdies and better, and competitions posted. Loads the last day, then
+ * merges events from a subscription into the cached feed as they arrive,
+ * for clubhouse displays.
+ */
+export function useClubActivity(courseName: string | undefined, pars: number[] = [], limit = 50) {
+  const { nostr } = useNostr();
+  const queryClient = useQueryClient();
+  const parsKey = pars.join(',');
+
+  const query = useQuery<ActivityItem[]>({
+    queryKey: ['club-activity', courseName, parsKey, limit],
+    queryFn: async (c) => {
+      const signal = AbortSignal.any([c.signal, AbortSignal.timeout(5000)]);
+      const since = Math.floor(Date.now() / 1000) - ACTIVITY_WINDOW_SECONDS;
+
+      const rounds = await nostr.query([{
+        kinds: [GOLF_KINDS.ROUND],
+        '#course': [courseName!],
+        since,
+        limit: 100,
+      }], { signal });
+
+      const roundItems = rounds.flatMap(roundActivity);
+      const roundIds = [...new Set(roundItems.map(item => item.roundId))];
+      if (roundIds.length === 0) return [];
+
+      const events = await nostr.query([
+        ...scoreCardFilters(roundIds, { since }),
+        { kinds: [GOLF_KINDS.TOURNAMENT], '#d': roundIds.map(id => `${id}-tournament`), since },
+      ], { signal });
+
+      const cards = await resolveScoreCards(nostr, events, signal);
+      return mergeActivity(roundItems, [
+        ...events.flatMap(competitionItems),
+        ...cards.flatMap(card => cardActivity(card, pars)),
+      ], limit);
+    },
+    enabled: !!courseName,
+    staleTime: 5 * 60 * 1000,
+  });
+
+  // Live updates: new rounds on the course, and scores or competitions for rounds in the feed
+  const roundIdsKey = [...new Set((query.data ?? []).map(item => item.roundId))].sort().join(',');
+  useEffect(() => {
+    if (!courseName) return;
+    const controller = new AbortController();
+    const since = Math.floor(Date.now() / 1000);
+    const roundIds = roundIdsKey ? roundIdsKey.split(',') : [];
+    const coursePars = parsKey ? parsKey.split(',').map(Number) : [];
+    const key = ['club-activity', courseName, parsKey, limit];
+
+    (async () => {
+      try {
+        const subscription = nostr.req([
+          { kinds: [GOLF_KINDS.ROUND], '#course': [courseName], since },
+          ...(roundIds.length > 0 ? [
+            ...scoreCardFilters(roundIds, { since }),
+            { kinds: [GOLF_KINDS.TOURNAMENT], '#d': roundIds.map(id => `${id}-tournament`), since },
+          ] : []),
+        ], { signal: controller.signal });
+
+        for await (const msg of subscription) {
+          if (msg[0] === 'CLOSED') break;
+          if (msg[0] !== 'EVENT') continue;
+
+          const event = msg[2];
+          const items = event.kind === GOLF_KINDS.ROUND ? roundActivity(event)
+            : event.kind === GOLF_KINDS.TOURNAMENT ? competitionItems(event)
+            : (await resolveScoreCards(nostr, [event], AbortSignal.any([controller.signal, AbortSignal.timeout(5000)])))
+              .flatMap(card => cardActivity(card, coursePars));
+          if (items.length > 0) {
+            queryClient.setQueryData<ActivityItem[]>(key, feed => mergeActivity(feed ?? [], items, limit));
+          }
+        }
+      } catch (err) {
+        if (!controller.signal.aborted) console.warn('Club activity subscription ended', err);
+      }
+    })();
+
+    return () => controller.abort();
+  }, [nostr, queryClient, courseName, roundIdsKey, parsKey, limit]);
+
+  return query;
+}
+
+function competitionItems(event: NostrEvent): ActivityItem[] {
+  const item = event.kind === GOLF_KINDS.TOURNAMENT ? competitionActivity(event) : null;
+  return item ? [item] : [];
+}
+
+/** Highlights from a score card, credited to its player */
+function cardActivity({ event, player }: { event: NostrEvent; player: string }, pars: number[]): ActivityItem[] {
+  const record = parsePlayerScoreEvent(event);
+  return record ? scoreHighlights({ ...record, playerPubkey: player }, pars) : [];
+}
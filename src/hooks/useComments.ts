@@ -1,12 +1,14 @@
 import { NKinds, NostrEvent, NostrFilter } from '@nostrify/nostrify';
 import { useNostr } from '@nostrify/react';
 import { useQuery } from '@tanstack/react-query';
+import { useContentFilter } from './useContentFilter';
 
 export function useComments(root: NostrEvent | URL, limit?: number) {
   const { nostr } = useNostr();
+  const contentFilter = useContentFilter();
 
   return useQuery({
-    queryKey: ['comments', root instanceof URL ? root.toString() : root.id, limit],
+    queryKey: ['comments', root instanceof URL ? root.toString() : root.id, limit, contentFilter.key],
     queryFn: async (c) => {
       const filter: NostrFilter = { kinds: [1111] };
 
@@ -25,9 +27,19 @@ export function useComments(root: NostrEvent | URL, limit?: number) {
         filter.limit = limit;
       }
 
-      // Query for all kind 1111 comments that reference this addressable event regardless of depth
+      // Kind 1 replies from other clients reference the root with lowercase tags (NIP-10)
+      const filters: NostrFilter[] = [filter];
+      if (!(root instanceof URL)) {
+        const noteFilter: NostrFilter = { kinds: [1] };
+        if (filter['#A']) noteFilter['#a'] = filter['#A'];
+        else noteFilter['#e'] = [root.id];
+        if (typeof limit === 'number') noteFilter.limit = limit;
+        filters.push(noteFilter);
+      }
+
+      // Query for all comments that reference this event regardless of depth
       const signal = AbortSignal.any([c.signal, AbortSignal.timeout(5000)]);
-      const events = await nostr.query([filter], { signal });
+      const events = contentFilter.filter(await nostr.query(filters, { signal }));
 
       // Helper function to get tag value
       const getTagValue = (event: NostrEvent, tagName: string): string | undefined => {
@@ -37,6 +49,10 @@ export function useComments(root: NostrEvent | URL, limit?: number) {
 
       // Filter top-level comments (those with lowercase tag matching the root)
       const topLevelComments = events.filter(comment => {
+        if (comment.kind === 1) {
+          // A note is top-level unless it replies to one of the comments
+          return !comment.tags.some(([name, id, , marker]) => name === 'e' && marker === 'reply' && events.some(e => e.id === id));
+        }
         if (root instanceof URL) {
           return getTagValue(comment, 'i') === root.toString();
         } else if (NKinds.addressable(root.kind)) {
@@ -93,6 +109,6 @@ export function useComments(root: NostrEvent | URL, limit?: number) {
         }
       };
     },
-    enabled: !!root,
+    enabled: !!root && !contentFilter.isLoading,
   });
 }
\ No newline at end of file
@@ -0,0 +1,104 @@
+import { useMutation, useQuery, useQueryClient } from '@tanstack/react-query';
+import { useNostr } from '@nostrify/react';
+import { useCurrentUser } from './useCurrentUser';
+import { useNostrPublish } from './useNostrPublish';
+import { GOLF_KINDS } from '@/lib/golf/types';
+import { createConditionReportEvent, parseConditionReportEvent } from '@/lib/golf/nostrEvents';
+import {
+  CONDITION_LABEL_NAMESPACE,
+  conditionReviewTags,
+  currentConditions,
+  parseConditionReview,
+  type ConditionReport,
+  type ConditionReview,
+  type ConditionReviewLabel,
+} from '@/lib/golf/conditionEngine';
+import type { GolfCourse } from './useGolfCourses';
+import { v4 as uuidv4 } from 'uuid';
+
+const reportAddress = (report: ConditionReport) => `${GOLF_KINDS.CONDITION_REPORT}:${report.reporter}:${report.reportId}`;
+
+/**
+ * Player-reported conditions on a course and the club's reviews of them.
+ * Any logged-in player can report; the club account confirms, rejects or
+ * marks reports fixed.
+ */
+export function useConditionReports(course: Pick<GolfCourse, 'id' | 'author'> | null | undefined) {
+  const { nostr } = useNostr();
+  const { user } = useCurrentUser();
+  const { mutateAsync: publishEvent } = useNostrPublish();
+  const queryClient = useQueryClient();
+  const queryKey = ['condition-reports', course?.author, course?.id];
+
+  const query = useQuery<{ reports: ConditionReport[]; reviews: ConditionReviewLabel[] }>({
+    queryKey,
+    queryFn: async (c) => {
+      const signal = AbortSignal.any([c.signal, AbortSignal.timeout(5000)]);
+      // Confirmed reports stay up until fixed, so look back further than the expiry
+      const since = Math.floor(Date.now() / 1000) - 90 * 24 * 60 * 60;
+      const events = await nostr.query([
+        { kinds: [GOLF_KINDS.CONDITION_REPORT], '#a': [`${GOLF_KINDS.COURSE}:${course!.author}:${course!.id}`], since, limit: 300 },
+        { kinds: [1985], authors: [course!.author], '#L': [CONDITION_LABEL_NAMESPACE], since, limit: 500 },
+      ], { signal });
+
+      // Keep the latest version of each report
+      const latest = new Map<string, ConditionReport>();
+      for (const report of events.map(parseConditionReportEvent)) {
+        if (!report) continue;
+        const existing = latest.get(reportAddress(report));
+        if (!existing || report.createdAt > existing.createdAt) latest.set(reportAddress(report), report);
+      }
+
+      return {
+        reports: [...latest.values()],
+        reviews: events.map(parseConditionReview).filter((r): r is ConditionReviewLabel => r !== null),
+      };
+    },
+    enabled: !!course?.author && !!course?.id,
+    staleTime: 5 * 60 * 1000,
+  });
+
+  const submit = useMutation({
+    mutationFn: async (params: Pick<ConditionReport, 'hole' | 'type' | 'description'> & { photos: string[][][] }) => {
+      if (!course) throw new Error('Select a course');
+      if (!user) throw new Error('Log in to report course conditions');
+      const event = createConditionReportEvent({
+        reportId: uuidv4(),
+        courseId: course.id,
+        courseAuthor: course.author,
+        reporter: user.pubkey,
+        hole: params.hole,
+        type: params.type,
+        description: params.description,
+      }, params.photos);
+      return publishEvent({ kind: event.kind, content: event.content, tags: event.tags, created_at: event.created_at });
+    },
+    onSuccess: () => {
+      queryClient.invalidateQueries({ queryKey });
+    },
+  });
+
+  const reviewReport = useMutation({
+    mutationFn: async ({ report, review }: { report: ConditionReport; review: ConditionReview }) => {
+      if (!course) throw new Error('Select a course');
+      if (user?.pubkey !== course.author) throw new Error('Only the club account can review condition reports');
+      return publishEvent({
+        kind: 1985,
+        content: '',
+        tags: conditionReviewTags(reportAddress(report), report.reporter, review),
+        created_at: Math.floor(Date.now() / 1000),
+      });
+    },
+    onSuccess: () => {
+      queryClient.invalidateQueries({ queryKey });
+    },
+  });
+
+  return {
+    conditions: currentConditions(query.data?.reports ?? [], query.data?.reviews ?? [], reportAddress, Date.now()),
+    isLoading: query.isLoading,
+    submitReport: submit.mutateAsync,
+    review: (report: ConditionReport, review: ConditionReview) => reviewReport.mutateAsync({ report, review }),
+    isPublishing: submit.status === 'pending' || reviewReport.status === 'pending',
+  };
+}
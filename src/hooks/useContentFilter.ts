@@ -0,0 +1,91 @@
+import { useCallback, useMemo } from 'react';
+import { useQuery } from '@tanstack/react-query';
+import { useNostr } from '@nostrify/react';
+import type { NostrEvent } from '@nostrify/nostrify';
+import { useAppContext } from './useAppContext';
+import { useCurrentUser } from './useCurrentUser';
+import { useMuteList } from './useModeration';
+import {
+  DEFAULT_CONTENT_FILTER,
+  MAX_WOT_DEPTH,
+  passesContentFilter,
+  trustGraph,
+} from '@/lib/golf/contentFilterEngine';
+import { reportedBy } from '@/lib/golf/moderationEngine';
+
+// Contact lists fetched per hop, so a huge follow list can't stall the graph
+const MAX_LISTS_PER_HOP = 500;
+
+/**
+ * The app's spam filter, from the content filter settings: the viewer's mute
+ * list, the club admins' reports and a web of trust grown from the admins'
+ * contact lists. Use `filter` on notes, comments and reactions before
+ * showing them.
+ */
+export function useContentFilter() {
+  const { nostr } = useNostr();
+  const { user } = useCurrentUser();
+  const { config: appConfig } = useAppContext();
+  const config = appConfig.contentFilter ?? DEFAULT_CONTENT_FILTER;
+  const depth = Math.min(config.wotDepth, MAX_WOT_DEPTH);
+
+  const { muted: mutedList, isLoading: mutesLoading } = useMuteList();
+
+  const reports = useQuery<NostrEvent[]>({
+    queryKey: ['admin-reports', config.trustedPubkeys.join(',')],
+    queryFn: async (c) => {
+      const signal = AbortSignal.any([c.signal, AbortSignal.timeout(5000)]);
+      return nostr.query([{ kinds: [1984], authors: config.trustedPubkeys, limit: 1000 }], { signal });
+    },
+    enabled: config.trustedPubkeys.length > 0,
+    staleTime: 10 * 60 * 1000,
+  });
+
+  const trusted = useQuery<Set<string>>({
+    queryKey: ['web-of-trust', config.trustedPubkeys.join(','), depth],
+    queryFn: async (c) => {
+      const contactLists = new Map<string, NostrEvent>();
+      let frontier = config.trustedPubkeys;
+
+      for (let hop = 0; hop < depth && frontier.length > 0; hop++) {
+        const signal = AbortSignal.any([c.signal, AbortSignal.timeout(5000)]);
+        const authors = frontier.slice(0, MAX_LISTS_PER_HOP);
+        const lists = await nostr.query([{ kinds: [3], authors }], { signal });
+        for (const list of lists) {
+          const existing = contactLists.get(list.pubkey);
+          if (!existing || list.created_at > existing.created_at) contactLists.set(list.pubkey, list);
+        }
+        const known = trustGraph(config.trustedPubkeys, contactLists, hop + 1);
+        frontier = [...known].filter(pubkey => !contactLists.has(pubkey));
+      }
+
+      return trustGraph(config.trustedPubkeys, contactLists, depth);
+    },
+    enabled: depth > 0 && config.trustedPubkeys.length > 0,
+    staleTime: 30 * 60 * 1000,
+  });
+
+  const trustedSet = trusted.data;
+  const viewer = user?.pubkey;
+  const mutedKey = mutedList.join(',');
+  const reported = useMemo(
+    () => (reports.data ? reportedBy(reports.data, config.trustedPubkeys) : undefined),
+    [reports.data, config.trustedPubkeys],
+  );
+
+  const filter = useCallback(
+    (events: NostrEvent[]) => events.filter(event =>
+      passesContentFilter(event, config, { muted: mutedKey ? mutedKey.split(',') : [], reported, trusted: trustedSet, viewer })
+    ),
+    [config, mutedKey, reported, trustedSet, viewer],
+  );
+
+  return {
+    config,
+    filter,
+    // Changes whenever the filter does, for query keys of filtered queries
+    key: `${JSON.stringify(config)}:${mutedKey}:${reports.data?.length ?? 0}:${trustedSet?.size ?? 0}`,
+    // Hold back content until the lists it is filtered against have loaded
+    isLoading: (config.useMuteList && mutesLoading) || reports.isLoading || trusted.isLoading,
+  };
+}
@@ -0,0 +1,129 @@
+import { useQuery, useMutation, useQueryClient } from '@tanstack/react-query';
+import { useNostr } from '@nostrify/react';
+import { useCurrentUser } from './useCurrentUser';
+import { useNostrPublish } from './useNostrPublish';
+import { GOLF_KINDS } from '@/lib/golf/types';
+import { createAceEvent, createAceWitnessEvent, parseAceEvent, parseAceWitnessEvent } from '@/lib/golf/nostrEvents';
+import { aceAnnouncement, aceSignatures, aceWall, type Ace, type AceWitness, type ConfirmedAce } from '@/lib/golf/aceEngine';
+import type { GolfCourse } from './useGolfCourses';
+
+interface CourseAces {
+  wall: ConfirmedAce[];
+  pending: (Ace & { signedBy: string[] })[]; // claims still waiting for witnesses
+  announced: string[]; // ace coordinates the club has announced
+}
+
+export const aceCoordinate = (ace: Ace) => `${GOLF_KINDS.ACE}:${ace.playerPubkey}:${ace.aceId}`;
+
+/**
+ * Hook for a course's hole-in-one registry. Players claim an ace and name
+ * their witnesses; once two of them sign it goes on the wall of fame, and
+ * the course author (the club account) can announce it with a public note.
+ */
+export function useCourseAces(course: Pick<GolfCourse, 'id' | 'author' | 'name'> | undefined) {
+  const { nostr } = useNostr();
+  const { user } = useCurrentUser();
+  const { mutateAsync: publishEvent } = useNostrPublish();
+  const queryClient = useQueryClient();
+
+  const query = useQuery<CourseAces>({
+    queryKey: ['course-aces', course?.author, course?.id],
+    queryFn: async (c) => {
+      const signal = AbortSignal.any([c.signal, AbortSignal.timeout(5000)]);
+      const aceEvents = await nostr.query([{
+        kinds: [GOLF_KINDS.ACE],
+        '#a': [`${GOLF_KINDS.COURSE}:${course!.author}:${course!.id}`],
+        limit: 200,
+      }], { signal });
+
+      const aces = aceEvents
+        .map(e => parseAceEvent(e))
+        .filter((a): a is Ace => a !== null);
+      if (aces.length === 0) return { wall: [], pending: [], announced: [] };
+
+      const coordinates = aces.map(aceCoordinate);
+      const related = await nostr.query([
+        { kinds: [GOLF_KINDS.ACE_WITNESS], '#a': coordinates },
+        { kinds: [1], authors: [course!.author], '#a': coordinates },
+      ], { signal });
+
+      const witnesses = related
+        .map(e => parseAceWitnessEvent(e))
+        .filter((w): w is AceWitness => w !== null);
+      const wall = aceWall(aces, witnesses);
+      const confirmed = new Set(wall.map(a => aceCoordinate(a)));
+
+      return {
+        wall,
+        pending: aces
+          .filter(a => !confirmed.has(aceCoordinate(a)))
+          .map(a => ({ ...a, signedBy: aceSignatures(a, witnesses) }))
+          .sort((a, b) => b.createdAt - a.createdAt),
+        announced: related
+          .filter(e => e.kind === 1)
+          .flatMap(e => e.tags.filter(t => t[0] === 'a').map(t => t[1])),
+      };
+    },
+    enabled: !!course?.author && !!course?.id,
+    staleTime: 60 * 1000,
+  });
+
+  const invalidate = () => queryClient.invalidateQueries({ queryKey: ['course-aces', course?.author, course?.id] });
+
+  const claim = useMutation({
+    mutationFn: async (params: { roundId: string; hole: number; yards?: number; club?: string; witnesses: string[]; note: string }) => {
+      if (!user) throw new Error('Must be logged in to record a hole in one');
+      if (!course) throw new Error('Select a course');
+      const witnesses = [...new Set(params.witnesses)].filter(w => w !== user.pubkey);
+      if (witnesses.length < 2) throw new Error('Name two witnesses other than yourself');
+
+      const event = createAceEvent({
+        ...params,
+        witnesses,
+        courseId: course.id,
+        courseAuthor: course.author,
+        courseName: course.name,
+      }, user.pubkey);
+      return publishEvent({ kind: event.kind, content: event.content, tags: event.tags, created_at: event.created_at });
+    },
+    onSuccess: invalidate,
+  });
+
+  const witness = useMutation({
+    mutationFn: async (ace: Ace) => {
+      if (!user) throw new Error('Must be logged in to sign as a witness');
+      if (!ace.witnesses.includes(user.pubkey)) throw new Error('You were not named as a witness');
+
+      const event = createAceWitnessEvent(ace, user.pubkey);
+      return publishEvent({ kind: event.kind, content: event.content, tags: event.tags, created_at: event.created_at });
+    },
+    onSuccess: invalidate,
+  });
+
+  const announce = useMutation({
+    mutationFn: async ({ ace, playerName }: { ace: Ace; playerName: string }) => {
+      if (!user || user.pubkey !== course?.author) throw new Error('Only the club account can announce aces');
+
+      return publishEvent({
+        kind: 1,
+        content: aceAnnouncement(ace, playerName),
+        tags: [
+          ['p', ace.playerPubkey],
+          ['a', aceCoordinate(ace)],
+          ['t', 'golf'],
+          ['t', 'holeinone'],
+        ],
+        created_at: Math.floor(Date.now() / 1000),
+      });
+    },
+    onSuccess: invalidate,
+  });
+
+  return {
+    ...query,
+    claimAce: claim.mutateAsync,
+    witnessAce: witness.mutateAsync,
+    announceAce: announce.mutateAsync,
+    isPublishing: [claim, witness, announce].some(m => m.status === 'pending'),
+  };
+}
@@ -0,0 +1,112 @@
+import { useEffect } from 'react';
+import { useMutation, useQuery, useQueryClient } from '@tanstack/react-query';
+import { useNostr } from '@nostrify/react';
+import { useCurrentUser } from './useCurrentUser';
+import { useNostrPublish } from './useNostrPublish';
+import { useToast } from './useToast';
+import { GOLF_KINDS } from '@/lib/golf/types';
+import { createCourseAlertEvent, parseCourseAlertEvent } from '@/lib/golf/nostrEvents';
+import { ALERT_DURATION_MINUTES, alertSummary, liveAlerts, type CourseAlert } from '@/lib/golf/alertEngine';
+import type { GolfCourse } from './useGolfCourses';
+import { v4 as uuidv4 } from 'uuid';
+
+/** Show a system notification, if the player has allowed them */
+async function notifyDevice(alert: CourseAlert) {
+  if (typeof Notification === 'undefined' || Notification.permission !== 'granted') return;
+  const options = { body: alert.message, tag: alert.alertId, requireInteraction: true };
+  const registration = await navigator.serviceWorker?.getRegistration();
+  if (registration) {
+    await registration.showNotification(alertSummary(alert), options);
+  } else {
+    new Notification(alertSummary(alert), options);
+  }
+}
+
+/**
+ * Urgent alerts for a course from the club account, kept live with a relay
+ * subscription. With `notify`, new alerts also raise a toast and a system
+ * notification; use it where a player is out on the course.
+ */
+export function useCourseAlerts(course: Pick<GolfCourse, 'id' | 'author'> | null | undefined, notify = false) {
+  const { nostr } = useNostr();
+  const { user } = useCurrentUser();
+  const { toast } = useToast();
+  const { mutateAsync: publishEvent } = useNostrPublish();
+  const queryClient = useQueryClient();
+  const queryKey = ['course-alerts', course?.author, course?.id];
+  const coordinate = course ? `${GOLF_KINDS.COURSE}:${course.author}:${course.id}` : '';
+
+  const query = useQuery<CourseAlert[]>({
+    queryKey,
+    queryFn: async (c) => {
+      const signal = AbortSignal.any([c.signal, AbortSignal.timeout(5000)]);
+      const events = await nostr.query([{
+        kinds: [GOLF_KINDS.COURSE_ALERT],
+        authors: [course!.author],
+        '#a': [coordinate],
+        since: Math.floor(Date.now() / 1000) - 24 * 60 * 60,
+      }], { signal });
+      return events.map(parseCourseAlertEvent).filter((a): a is CourseAlert => a !== null);
+    },
+    enabled: !!coordinate,
+    // Expired alerts need to disappear even if nothing new arrives
+    refetchInterval: 60 * 1000,
+  });
+
+  useEffect(() => {
+    if (!course?.author || !coordinate) return;
+    const controller = new AbortController();
+
+    (async () => {
+      try {
+        const subscription = nostr.req([{
+          kinds: [GOLF_KINDS.COURSE_ALERT],
+          authors: [course.author],
+          '#a': [coordinate],
+          since: Math.floor(Date.now() / 1000),
+        }], { signal: controller.signal });
+
+        for await (const msg of subscription) {
+          if (msg[0] === 'CLOSED') break;
+          if (msg[0] !== 'EVENT') continue;
+
+          const alert = parseCourseAlertEvent(msg[2]);
+          if (!alert) continue;
+          queryClient.setQueryData<CourseAlert[]>(['course-alerts', course.author, course.id], (old = []) => [...old, alert]);
+          if (notify && !alert.cleared) {
+            toast({ title: alertSummary(alert), description: alert.message, variant: 'destructive' });
+            notifyDevice(alert).catch((err) => console.warn('Could not show alert notification', err));
+          }
+        }
+      } catch (err) {
+        if (!controller.signal.aborted) console.warn('Course alert subscription ended', err);
+      }
+    })();
+
+    return () => controller.abort();
+  }, [nostr, queryClient, toast, course?.author, course?.id, coordinate, notify]);
+
+  const publish = useMutation({
+    mutationFn: async (alert: Omit<CourseAlert, 'createdAt' | 'courseId' | 'courseAuthor'>) => {
+      if (!course) throw new Error('Select a course');
+      if (user?.pubkey !== course.author) throw new Error('Only the club account can broadcast alerts');
+      const event = createCourseAlertEvent({ ...alert, courseId: course.id, courseAuthor: course.author });
+      return publishEvent({ kind: event.kind, content: event.content, tags: event.tags, created_at: event.created_at });
+    },
+    onSuccess: () => {
+      queryClient.invalidateQueries({ queryKey });
+    },
+  });
+
+  return {
+    alerts: course ? liveAlerts(query.data ?? [], course.author, Date.now()) : [],
+    broadcast: (alert: Pick<CourseAlert, 'type' | 'message' | 'hole' | 'location'> & { alertId?: string }) => publish.mutateAsync({
+      ...alert,
+      alertId: alert.alertId ?? uuidv4(),
+      expiresAt: Date.now() + ALERT_DURATION_MINUTES * 60 * 1000,
+      cleared: false,
+    }),
+    clear: (alert: CourseAlert) => publish.mutateAsync({ ...alert, cleared: true }),
+    isPublishing: publish.status === 'pending',
+  };
+}
@@ -0,0 +1,41 @@
+import { useMemo } from 'react';
+import { useHandicapCalculation } from './useHandicapCalculation';
+import { useHandicapSettings } from './useHandicapSettings';
+import {
+  calculatePlayingHandicapFromIndex,
+  type HandicapAllowanceFormat,
+  type TeeRating,
+} from '@/lib/golf/handicapCalculator';
+import type { TeeBox } from '@/lib/golf/courseTypes';
+
+/**
+ * Hook to get a player's course and playing handicap for a tee.
+ * Uses the calculated handicap index unless an explicit index is given.
+ */
+export function useCourseHandicap(
+  userPubkey: string | undefined,
+  tee: TeeBox | TeeRating | undefined,
+  format: HandicapAllowanceFormat | number = 'individual-stroke',
+  handicapIndex?: number
+) {
+  const { committeePubkeys, requireAttestation } = useHandicapSettings();
+  const { data: handicapResult, isLoading } = useHandicapCalculation(
+    handicapIndex === undefined ? userPubkey : undefined,
+    committeePubkeys,
+    { requireAttestation }
+  );
+
+  const index = handicapIndex ?? handicapResult?.index ?? null;
+
+  const result = useMemo(() => {
+    if (index === null || !tee) return null;
+
+    const rating: TeeRating = 'totalPar' in tee
+      ? { courseRating: tee.courseRating, slopeRating: tee.slopeRating, par: tee.totalPar }
+      : tee;
+
+    return { handicapIndex: index, ...calculatePlayingHandicapFromIndex(index, rating, format) };
+  }, [index, tee, format]);
+
+  return { data: result, isLoading: handicapIndex === undefined && isLoading };
+}
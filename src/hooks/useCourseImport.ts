@@ -0,0 +1,49 @@
+import { useState } from 'react';
+import { useGolfCourses, useAddGolfCourse } from './useGolfCourses';
+import {
+  parseCourseCsv,
+  parseCourseJson,
+  planCourseImport,
+  validateImportedCourse,
+  type CourseImportPlanEntry,
+  type ImportedCourse,
+} from '@/lib/golf/courseImport';
+
+/**
+ * Hook for bulk course import. `preview` parses an export and returns a
+ * dry-run plan against the courses already on relays (`planCourses` does the
+ * same for already-parsed courses); `apply` publishes the creates and updates
+ * from a plan.
+ */
+export function useCourseImport() {
+  const { data: courses = [] } = useGolfCourses();
+  const addCourse = useAddGolfCourse();
+  const [isApplying, setIsApplying] = useState(false);
+
+  const planCourses = (imported: ImportedCourse[], parseErrors: string[] = []) => {
+    const errors = [...parseErrors, ...imported.flatMap(validateImportedCourse)];
+    return { plan: planCourseImport(courses, imported), errors };
+  };
+
+  const preview = (text: string, format: 'csv' | 'json' = 'csv') => {
+    const result = format === 'json' ? parseCourseJson(text) : parseCourseCsv(text);
+    return planCourses(result.courses, result.errors);
+  };
+
+  const apply = async (plan: CourseImportPlanEntry[]) => {
+    setIsApplying(true);
+    try {
+      let published = 0;
+      for (const entry of plan) {
+        if (entry.action === 'unchanged') continue;
+        await addCourse.mutateAsync({ ...entry.course, existingId: entry.existingId });
+        published++;
+      }
+      return published;
+    } finally {
+      setIsApplying(false);
+    }
+  };
+
+  return { preview, planCourses, apply, isApplying };
+}
@@ -0,0 +1,69 @@
+import { useMutation, useQuery, useQueryClient } from '@tanstack/react-query';
+import { useNostr } from '@nostrify/react';
+import { useCurrentUser } from './useCurrentUser';
+import { useNostrPublish } from './useNostrPublish';
+import { GOLF_KINDS } from '@/lib/golf/types';
+import { createCourseNoticeEvent, isExpired, parseCourseNoticeEvent } from '@/lib/golf/nostrEvents';
+import { courseStatus, upcomingNotices, type CourseNotice } from '@/lib/golf/maintenanceEngine';
+import type { GolfCourse } from './useGolfCourses';
+
+/**
+ * Greenkeeping notices for a course. Only notices from the course author
+ * (the club account) count. The club can post and cancel notices.
+ */
+export function useCourseNotices(course: Pick<GolfCourse, 'id' | 'author' | 'name'> | null | undefined) {
+  const { nostr } = useNostr();
+  const { user } = useCurrentUser();
+  const { mutateAsync: publishEvent } = useNostrPublish();
+  const queryClient = useQueryClient();
+  const queryKey = ['course-notices', course?.author, course?.id];
+
+  const query = useQuery<CourseNotice[]>({
+    queryKey,
+    queryFn: async (c) => {
+      const signal = AbortSignal.any([c.signal, AbortSignal.timeout(5000)]);
+      const events = await nostr.query([{
+        kinds: [GOLF_KINDS.COURSE_NOTICE],
+        authors: [course!.author],
+        '#a': [`${GOLF_KINDS.COURSE}:${course!.author}:${course!.id}`],
+        limit: 200,
+      }], { signal });
+
+      // Keep the latest version of each notice
+      const latest = new Map<string, CourseNotice>();
+      for (const notice of events.filter(e => !isExpired(e)).map(parseCourseNoticeEvent)) {
+        if (!notice) continue;
+        const existing = latest.get(notice.noticeId);
+        if (!existing || notice.createdAt > existing.createdAt) latest.set(notice.noticeId, notice);
+      }
+      return [...latest.values()];
+    },
+    enabled: !!course?.author && !!course?.id,
+    staleTime: 5 * 60 * 1000,
+  });
+
+  const publish = useMutation({
+    mutationFn: async (notice: Omit<CourseNotice, 'createdAt' | 'courseId' | 'courseAuthor' | 'courseName'>) => {
+      if (!course) throw new Error('Select a course');
+      if (user?.pubkey !== course.author) throw new Error('Only the club account can post course notices');
+      const event = createCourseNoticeEvent({ ...notice, courseId: course.id, courseAuthor: course.author, courseName: course.name });
+      return publishEvent({ kind: event.kind, content: event.content, tags: event.tags, created_at: event.created_at });
+    },
+    onSuccess: () => {
+      queryClient.invalidateQueries({ queryKey });
+    },
+  });
+
+  const notices = query.data ?? [];
+  const now = Date.now();
+
+  return {
+    notices,
+    upcoming: upcomingNotices(notices, now),
+    status: courseStatus(notices, now),
+    isLoading: query.isLoading,
+    postNotice: publish.mutateAsync,
+    cancelNotice: (notice: CourseNotice) => publish.mutateAsync({ ...notice, cancelled: true }),
+    isPublishing: publish.status === 'pending',
+  };
+}
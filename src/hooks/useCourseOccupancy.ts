@@ -0,0 +1,62 @@
+import { useQuery } from '@tanstack/react-query';
+import { useNostr } from '@nostrify/react';
+import { GOLF_KINDS } from '@/lib/golf/types';
+import { parsePrivacyEvent } from '@/lib/golf/nostrEvents';
+import { occupancyGrid, type OccupancyCell, type TeeTimeRecord } from '@/lib/golf/occupancyEngine';
+import { hasConsent } from '@/lib/golf/privacyEngine';
+
+const WEEK_MS = 7 * 24 * 60 * 60 * 1000;
+
+/**
+ * Hook for tee sheet occupancy at a course over the last `weeks` weeks,
+ * from the rounds played there. Each round counts once at its tee time
+ * (or when it was started) with its number of players. Rounds by players
+ * who withdrew consent to club statistics are left out.
+ */
+export function useCourseOccupancy(courseName: string | undefined, weeks: number) {
+  const { nostr } = useNostr();
+
+  return useQuery<{ grid: OccupancyCell[][]; rounds: number }>({
+    queryKey: ['course-occupancy', courseName, weeks],
+    queryFn: async (c) => {
+      const signal = AbortSignal.any([c.signal, AbortSignal.timeout(10000)]);
+      const since = Date.now() - weeks * WEEK_MS;
+
+      const events = await nostr.query([{
+        kinds: [GOLF_KINDS.ROUND],
+        '#course': [courseName!],
+        since: Math.floor(since / 1000),
+        limit: 2000,
+      }], { signal });
+
+      const authors = [...new Set(events.map(e => e.pubkey))];
+      const privacy = authors.length > 0
+        ? await nostr.query([{ kinds: [GOLF_KINDS.PRIVACY], authors, '#d': ['privacy'] }], { signal })
+        : [];
+      const optedOut = new Set(privacy
+        .map(e => ({ pubkey: e.pubkey, settings: parsePrivacyEvent(e) }))
+        .filter(p => p.settings && !hasConsent(p.settings, 'club-stats'))
+        .map(p => p.pubkey));
+
+      // A round is republished as it progresses; count it once
+      const records = new Map<string, TeeTimeRecord>();
+      for (const event of events) {
+        if (optedOut.has(event.pubkey)) continue;
+        const tag = (name: string) => event.tags.find(([n]) => n === name);
+        const roundId = tag('round-id')?.[1] || tag('d')?.[1];
+        if (!roundId || records.has(roundId)) continue;
+        const teeTime = tag('tee-time')?.[1];
+        records.set(roundId, {
+          roundId,
+          teeTime: teeTime ? parseInt(teeTime) * 1000 : event.created_at * 1000,
+          players: Math.max(1, (tag('players')?.length ?? 1) - 1),
+        });
+      }
+
+      const played = [...records.values()].filter(r => r.teeTime >= since && r.teeTime <= Date.now());
+      return { grid: occupancyGrid(played, weeks), rounds: played.length };
+    },
+    enabled: !!courseName,
+    staleTime: 10 * 60 * 1000,
+  });
+}
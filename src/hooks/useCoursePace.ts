@@ -0,0 +1,124 @@
+import { useEffect, useRef } from 'react';
+import { useQuery, useQueryClient } from '@tanstack/react-query';
+import { useNostr } from '@nostrify/react';
+import { useToast } from './useToast';
+import { resolveScoreCards } from './useScoringDelegations';
+import { GOLF_KINDS } from '@/lib/golf/types';
+import { scoreCardFilters } from '@/lib/golf/delegationEngine';
+import { parsePlayerScoreEvent, type PlayerScoreRecord } from '@/lib/golf/nostrEvents';
+import { calculateCoursePace, groupProgress, type GroupPace, type PaceConfig } from '@/lib/golf/paceEngine';
+
+// Rounds started longer ago than this are no longer on the course
+const ACTIVE_WINDOW_SECONDS = 8 * 60 * 60;
+
+/**
+ * Hook for the pace of every group currently on a course, for the starter or
+ * marshal. Groups are the course's active rounds; progress comes from the
+ * players' scores and is compared with expected hole times from the tee time.
+ * Updates live as rounds start and scores come in.
+ */
+export function useCoursePace(courseName: string | undefined, pars: number[], config: PaceConfig = {}) {
+  const { nostr } = useNostr();
+  const queryClient = useQueryClient();
+
+  const query = useQuery<GroupPace[]>({
+    queryKey: ['course-pace', courseName, pars.join(','), config.behindMinutes, config.openHoles, JSON.stringify(config.suspensions ?? [])],
+    queryFn: async (c) => {
+      const signal = AbortSignal.any([c.signal, AbortSignal.timeout(5000)]);
+      const since = Math.floor(Date.now() / 1000) - ACTIVE_WINDOW_SECONDS;
+
+      const rounds = await nostr.query([{
+        kinds: [GOLF_KINDS.ROUND],
+        '#course': [courseName!],
+        since,
+        limit: 100,
+      }], { signal });
+
+      const active = new Map<string, number>(); // round id -> tee time
+      for (const round of rounds) {
+        const tag = (name: string) => round.tags.find(([n]) => n === name)?.[1];
+        if (tag('status') !== 'active') continue;
+        const roundId = tag('round-id') || tag('d');
+        if (!roundId) continue;
+        const teeTime = tag('tee-time');
+        active.set(roundId, teeTime ? parseInt(teeTime) * 1000 : round.created_at * 1000);
+      }
+      if (active.size === 0) return [];
+
+      const scoreEvents = await nostr.query(scoreCardFilters([...active.keys()], { since }), { signal });
+      const cards = await resolveScoreCards(nostr, scoreEvents, signal);
+
+      // Latest score record per round and player
+      const latest = new Map<string, PlayerScoreRecord>();
+      for (const { event, player } of cards) {
+        const parsed = parsePlayerScoreEvent(event);
+        if (!parsed) continue;
+        const record = { ...parsed, playerPubkey: player };
+        const key = `${record.roundId}:${record.playerPubkey}`;
+        const existing = latest.get(key);
+        if (!existing || record.updatedAt > existing.updatedAt) latest.set(key, record);
+      }
+
+      const playOrder = pars.map((_, i) => i + 1);
+      const groups = [...active.entries()].map(([roundId, teeTime]) =>
+        groupProgress(roundId, teeTime, playOrder, [...latest.values()].filter(r => r.roundId === roundId))
+      );
+
+      return calculateCoursePace(groups, pars, Date.now(), config, playOrder);
+    },
+    enabled: !!courseName && pars.length > 0,
+    refetchInterval: 60 * 1000, // also keeps expected times moving between updates
+  });
+
+  // Live updates: refetch when a round on the course starts or finishes, or a group posts a score
+  const roundIdsKey = (query.data ?? []).map(g => g.groupId).sort().join(',');
+  useEffect(() => {
+    if (!courseName) return;
+    const controller = new AbortController();
+    const since = Math.floor(Date.now() / 1000);
+    const roundIds = roundIdsKey ? roundIdsKey.split(',') : [];
+
+    (async () => {
+      try {
+        const subscription = nostr.req([
+          { kinds: [GOLF_KINDS.ROUND], '#course': [courseName], since },
+          ...(roundIds.length > 0 ? scoreCardFilters(roundIds, { since }) : []),
+        ], { signal: controller.signal });
+        for await (const msg of subscription) {
+          if (msg[0] === 'CLOSED') break;
+          if (msg[0] === 'EVENT') queryClient.invalidateQueries({ queryKey: ['course-pace', courseName] });
+        }
+      } catch (err) {
+        if (!controller.signal.aborted) console.warn('Course pace subscription ended', err);
+      }
+    })();
+
+    return () => controller.abort();
+  }, [nostr, queryClient, courseName, roundIdsKey]);
+
+  return query;
+}
+
+/**
+ * Notify the player when their group falls behind pace
+ */
+export function useSlowPlayAlert(pace: GroupPace[] | undefined, roundId: string | undefined) {
+  const { toast } = useToast();
+  const lastStatus = useRef<string | null>(null);
+
+  const group = pace?.find(g => g.groupId === roundId);
+  const status = group?.status ?? null;
+  const minutesBehind = group?.minutesBehind ?? 0;
+
+  useEffect(() => {
+    const slow = status === 'behind' || status === 'out-of-position';
+    if (slow && lastStatus.current !== status) {
+      toast({
+        title: status === 'out-of-position' ? 'Your group is out of position' : 'Your group is behind pace',
+        description: `About ${minutesBehind} minutes behind schedule. Please pick up the pace.`,
+        variant: status === 'out-of-position' ? 'destructive' : 'default',
+      });
+    }
+    lastStatus.current = status;
+  }, [status, minutesBehind, toast]);
+}
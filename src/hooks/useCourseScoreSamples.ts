@@ -0,0 +1,57 @@
+import { useQuery } from '@tanstack/react-query';
+import { useNostr } from '@nostrify/react';
+import { GOLF_KINDS } from '@/lib/golf/types';
+import { scoreCardFilters } from '@/lib/golf/delegationEngine';
+import { parsePlayerScoreEvent, type PlayerScoreRecord } from '@/lib/golf/nostrEvents';
+import { resolveScoreCards } from './useScoringDelegations';
+
+/**
+ * Hook to gather submitted scores for every round played at a course.
+ * Rounds are found by their `course` tag, then the players' PLAYER_SCORE
+ * events for those rounds are loaded (latest per round and player).
+ */
+export function useCourseScoreSamples(courseName: string | undefined, limit: number = 200) {
+  const { nostr } = useNostr();
+
+  return useQuery<PlayerScoreRecord[]>({
+    queryKey: ['course-score-samples', courseName, limit],
+    queryFn: async (c) => {
+      if (!courseName) return [];
+
+      const signal = AbortSignal.any([c.signal, AbortSignal.timeout(10000)]);
+
+      const rounds = await nostr.query([{
+        kinds: [GOLF_KINDS.ROUND],
+        '#course': [courseName],
+        limit,
+      }], { signal });
+
+      const roundIds = [...new Set(
+        rounds
+          .map(r => r.tags.find(([name]) => name === 'round-id')?.[1] || r.tags.find(([name]) => name === 'd')?.[1])
+          .filter((id): id is string => !!id)
+      )];
+      if (roundIds.length === 0) return [];
+
+      const scoreEvents = await nostr.query(scoreCardFilters(roundIds, { limit: roundIds.length * 8 }), { signal });
+      const unique = [...new Map(scoreEvents.map(e => [e.id, e])).values()];
+
+      // Terminal-signed cards are credited to the player they were kept for
+      const latest = new Map<string, PlayerScoreRecord>();
+      for (const { event, player } of await resolveScoreCards(nostr, unique, signal)) {
+        const parsed = parsePlayerScoreEvent(event);
+        if (!parsed) continue;
+        const record = { ...parsed, playerPubkey: player };
+        const key = `${record.roundId}:${record.playerPubkey}`;
+        const existing = latest.get(key);
+        if (!existing || record.updatedAt > existing.updatedAt) {
+          latest.set(key, record);
+        }
+      }
+
+      return [...latest.values()];
+    },
+    enabled: !!courseName,
+    staleTime: 10 * 60 * 1000, // 10 minutes
+  });
+}
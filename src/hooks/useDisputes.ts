@@ -0,0 +1,155 @@
+import { useEffect } from 'react';
+import { useQuery, useMutation, useQueryClient } from '@tanstack/react-query';
+import { useNostr } from '@nostrify/react';
+import { useCurrentUser } from './useCurrentUser';
+import { useHandicapSettings } from './useHandicapSettings';
+import { useNostrPublish } from './useNostrPublish';
+import { useToast } from './useToast';
+import { GOLF_KINDS } from '@/lib/golf/types';
+import {
+  createDisputeEvent,
+  createRulingEvent,
+  parseDisputeEvent,
+  parseRulingEvent,
+} from '@/lib/golf/nostrEvents';
+import {
+  resolveDisputes,
+  type Dispute,
+  type DisputeWithStatus,
+  type Ruling,
+} from '@/lib/golf/disputeEngine';
+import { v4 as uuidv4 } from 'uuid';
+
+// Results that a ruling can change
+const RECOMPUTED_QUERIES = ['disputes', 'handicap-calculation', 'handicap-history', 'score-attestations'];
+
+/**
+ * Hook for the disputes a user is involved in: raised by them, against their
+ * card, or sent to them as committee member. Rulings count only from the
+ * handicap committee in the settings. `raiseDispute` contests a score or
+ * match result before that committee; `recordRuling` records its decision.
+ */
+export function useDisputes(pubkey: string | undefined) {
+  const { nostr } = useNostr();
+  const { user } = useCurrentUser();
+  const { mutateAsync: publishEvent } = useNostrPublish();
+  const queryClient = useQueryClient();
+  const { committeePubkeys } = useHandicapSettings();
+
+  const query = useQuery<DisputeWithStatus[]>({
+    queryKey: ['disputes', pubkey, committeePubkeys],
+    queryFn: async (c) => {
+      const signal = AbortSignal.any([c.signal, AbortSignal.timeout(5000)]);
+      const disputeEvents = await nostr.query([
+        { kinds: [GOLF_KINDS.DISPUTE], '#p': [pubkey!], limit: 100 },
+        { kinds: [GOLF_KINDS.DISPUTE], authors: [pubkey!], limit: 100 },
+      ], { signal });
+
+      const disputes = new Map<string, Dispute>();
+      for (const event of disputeEvents) {
+        const dispute = parseDisputeEvent(event);
+        if (dispute) disputes.set(`${dispute.raisedBy}:${dispute.disputeId}`, dispute);
+      }
+      if (disputes.size === 0) return [];
+
+      const roundIds = [...new Set([...disputes.values()].map(d => d.roundId))];
+      const rulingEvents = await nostr.query([
+        { kinds: [GOLF_KINDS.RULING], '#round': roundIds },
+      ], { signal });
+
+      const rulings = rulingEvents
+        .map(e => parseRulingEvent(e))
+        .filter((r): r is Ruling => r !== null);
+
+      return resolveDisputes([...disputes.values()], rulings, committeePubkeys);
+    },
+    enabled: !!pubkey,
+    staleTime: 30 * 1000,
+  });
+
+  const invalidate = () => {
+    for (const key of RECOMPUTED_QUERIES) queryClient.invalidateQueries({ queryKey: [key] });
+  };
+
+  const raiseDispute = useMutation({
+    mutationFn: async (params: Omit<Dispute, 'disputeId' | 'raisedBy' | 'committee' | 'createdAt'>) => {
+      if (!user) throw new Error('Must be logged in to raise a dispute');
+      if (!params.reason.trim()) throw new Error('Explain what is being disputed');
+      if (committeePubkeys.length === 0) throw new Error('Set a handicap committee in your account settings first');
+
+      const event = createDisputeEvent({ ...params, committee: committeePubkeys, disputeId: uuidv4() }, user.pubkey);
+      return publishEvent({ kind: event.kind, content: event.content, tags: event.tags, created_at: event.created_at });
+    },
+    onSuccess: invalidate,
+  });
+
+  const recordRuling = useMutation({
+    mutationFn: async (params: Omit<Ruling, 'rulingId' | 'committee' | 'createdAt'>) => {
+      if (!user) throw new Error('Must be logged in to record a ruling');
+      if (params.decision === 'corrected' && params.corrections.length === 0) {
+        throw new Error('A corrected ruling needs at least one corrected score');
+      }
+
+      const rulingId = params.disputeId ?? `review-${params.roundId}:${params.players.join(',')}`;
+      const event = createRulingEvent({ ...params, rulingId }, user.pubkey);
+      return publishEvent({ kind: event.kind, content: event.content, tags: event.tags, created_at: event.created_at });
+    },
+    onSuccess: invalidate,
+  });
+
+  return {
+    ...query,
+    raiseDispute: raiseDispute.mutateAsync,
+    recordRuling: recordRuling.mutateAsync,
+    isPublishing: raiseDispute.status === 'pending' || recordRuling.status === 'pending',
+  };
+}
+
+/**
+ * Notify the logged-in user when a dispute names them or a ruling affects
+ * their card, and refresh anything the ruling changes
+ */
+export function useDisputeNotifications() {
+  const { nostr } = useNostr();
+  const { user } = useCurrentUser();
+  const { toast } = useToast();
+  const queryClient = useQueryClient();
+  const pubkey = user?.pubkey;
+
+  useEffect(() => {
+    if (!pubkey) return;
+    const controller = new AbortController();
+
+    (async () => {
+      try {
+        const subscription = nostr.req([
+          { kinds: [GOLF_KINDS.DISPUTE, GOLF_KINDS.RULING], '#p': [pubkey], since: Math.floor(Date.now() / 1000) },
+        ], { signal: controller.signal });
+
+        for await (const msg of subscription) {
+          if (msg[0] === 'CLOSED') break;
+          if (msg[0] !== 'EVENT') continue;
+
+          const event = msg[2];
+          if (event.pubkey === pubkey) continue;
+
+          const dispute = parseDisputeEvent(event);
+          const ruling = parseRulingEvent(event);
+          if (dispute) {
+            toast({
+              title: dispute.committee.includes(pubkey) ? 'New dispute to rule on' : 'A score of yours was disputed',
+              description: dispute.reason,
+            });
+          } else if (ruling) {
+            toast({ title: `Committee ruling: ${ruling.decision}`, description: ruling.explanation });
+          }
+          for (const key of RECOMPUTED_QUERIES) queryClient.invalidateQueries({ queryKey: [key] });
+        }
+      } catch (err) {
+        if (!controller.signal.aborted) console.warn('Dispute subscription ended', err);
+      }
+    })();
+
+    return () => controller.abort();
+  }, [nostr, pubkey, toast, queryClient]);
+}
@@ -0,0 +1,52 @@
+import { useQuery } from '@tanstack/react-query';
+import {
+  elevationChangeYards,
+  hgtTileName,
+  hgtTileUrl,
+  parseHgt,
+  type HgtTile,
+} from '@/lib/golf/elevation';
+import type { GeoPoint } from '@/lib/golf/caddieEngine';
+import { UPSTREAM_SERVICES, upstreamFetch } from '@/lib/upstream/upstream';
+
+// Tiles are ~2.8 MB each (3 arc-second); keep them for the session
+const tileCache = new Map<string, Promise<HgtTile>>();
+
+async function loadTile(name: string): Promise<HgtTile> {
+  let tile = tileCache.get(name);
+  if (!tile) {
+    tile = (async () => {
+      const response = await upstreamFetch(UPSTREAM_SERVICES.elevation, hgtTileUrl(name));
+      if (!response.ok || !response.body) {
+        throw new Error(`Failed to load elevation tile ${name}`);
+      }
+      const stream = response.body.pipeThrough(new DecompressionStream('gzip'));
+      return parseHgt(name, await new Response(stream).arrayBuffer());
+    })();
+    tileCache.set(name, tile);
+    // Allow a retry after a failed download
+    tile.catch(() => tileCache.delete(name));
+  }
+  return tile;
+}
+
+/**
+ * Hook for the elevation change between two points (yards, positive = uphill),
+ * from SRTM tiles. Null while loading or where there is no elevation data.
+ */
+export function useElevationChange(from: GeoPoint | null, to: GeoPoint | null) {
+  return useQuery({
+    queryKey: ['elevation-change', from?.lat, from?.lon, to?.lat, to?.lon],
+    queryFn: async () => {
+      const names = [...new Set([hgtTileName(from!), hgtTileName(to!)])];
+      const tiles = new Map<string, HgtTile>();
+      for (const tile of await Promise.all(names.map(loadTile))) {
+        tiles.set(tile.name, tile);
+      }
+      return elevationChangeYards(tiles, from!, to!);
+    },
+    enabled: !!from && !!to,
+    staleTime: Infinity,
+    retry: 1,
+  });
+}
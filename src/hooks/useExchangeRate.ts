@@ -1,5 +1,6 @@
 import { useState, useEffect, useCallback } from 'react';
 import type { Currency } from '@/lib/golf/expenseTypes';
+import { UPSTREAM_SERVICES, upstreamFetch } from '@/lib/upstream/upstream';
 
 interface ExchangeRates {
   btcToUsd: number;
@@ -60,9 +61,9 @@ export function useExchangeRate(): UseExchangeRateReturn {
 
     try {
       // Fetch BTC price from CoinGecko (free, no API key required)
-      const btcResponse = await fetch(
-        'https://api.coingecko.com/api/v3/simple/price?ids=bitcoin&vs_currencies=usd,cad,eur,gbp,aud,mxn',
-        { signal: AbortSignal.timeout(5000) }
+      const btcResponse = await upstreamFetch(
+        UPSTREAM_SERVICES.rates,
+        'https://api.coingecko.com/api/v3/simple/price?ids=bitcoin&vs_currencies=usd,cad,eur,gbp,aud,mxn'
       );
 
       if (!btcResponse.ok) {
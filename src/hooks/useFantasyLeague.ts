@@ -0,0 +1,172 @@
+import { useMutation, useQuery, useQueryClient } from '@tanstack/react-query';
+import { useNostr } from '@nostrify/react';
+import type { NostrEvent } from '@nostrify/nostrify';
+import { useCurrentUser } from './useCurrentUser';
+import { useNostrPublish } from './useNostrPublish';
+import { fetchZapProvider } from './useZaps';
+import { GOLF_KINDS } from '@/lib/golf/types';
+import {
+  createFantasyLeagueEvent,
+  createFantasyPicksEvent,
+  parseFantasyLeagueEvent,
+  parseFantasyPicksEvent,
+} from '@/lib/golf/nostrEvents';
+import {
+  fantasyPrizes,
+  fantasySource,
+  fantasyStandings,
+  paidMembers,
+  parseResultsCsv,
+  type EntryPayment,
+  type FantasyLeague,
+  type FantasyMajor,
+  type FantasyPicks,
+} from '@/lib/golf/fantasyEngine';
+import { verifyZapReceipt } from '@/lib/golf/zapReceiptEngine';
+import { UPSTREAM_SERVICES, upstreamFetch } from '@/lib/upstream/upstream';
+
+// Entry fees are zaps on the league event; the zap request names the payer.
+// Only receipts from the commissioner's zap provider count, for the amount
+// of the paid invoice.
+async function entryPayment(receipt: NostrEvent, commissioner: string, providerPubkey: string): Promise<EntryPayment | null> {
+  const verified = await verifyZapReceipt(receipt, providerPubkey);
+  if (!verified || verified.recipient !== commissioner) return null;
+  return { sender: verified.sender, amountSats: verified.amountSats };
+}
+
+/**
+ * A fantasy league: its majors, members' picks, standings and entry pot.
+ * Members `pick` pros before each major starts and pay the entry fee by
+ * zapping the league. The commissioner `create`s the league, adds majors,
+ * loads each major's results from the league's data source, and pays out
+ * the `prizes` from their wallet once the season is over.
+ */
+export function useFantasyLeague(leagueId: string | undefined) {
+  const { nostr } = useNostr();
+  const { user } = useCurrentUser();
+  const { mutateAsync: publishEvent } = useNostrPublish();
+  const queryClient = useQueryClient();
+  const queryKey = ['fantasy-league', leagueId];
+
+  const query = useQuery<{ event: NostrEvent | null; league: FantasyLeague | null; picks: FantasyPicks[]; payments: EntryPayment[] }>({
+    queryKey,
+    queryFn: async (c) => {
+      const signal = AbortSignal.any([c.signal, AbortSignal.timeout(5000)]);
+      const versions = await nostr.query([{
+        kinds: [GOLF_KINDS.FANTASY_LEAGUE],
+        '#d': [leagueId!],
+        limit: 10,
+      }], { signal });
+
+      // The commissioner is the league id's only author; a contested id has no league
+      const commissioners = new Set(versions.map(e => e.pubkey));
+      const [latest] = commissioners.size === 1 ? versions.sort((a, b) => b.created_at - a.created_at) : [];
+
+      const league = latest ? parseFantasyLeagueEvent(latest) : null;
+      if (!latest || !league) return { event: null, league: null, picks: [], payments: [] };
+
+      const address = `${GOLF_KINDS.FANTASY_LEAGUE}:${league.commissioner}:${league.leagueId}`;
+      const events = await nostr.query([
+        { kinds: [GOLF_KINDS.FANTASY_PICKS], '#a': [address], limit: 1000 },
+        { kinds: [9735], '#a': [address], limit: 500 },
+        { kinds: [0], authors: [league.commissioner], limit: 1 },
+      ], { signal });
+
+      const profile = events.find(e => e.kind === 0);
+      let provider: Awaited<ReturnType<typeof fetchZapProvider>> = null;
+      try {
+        provider = profile ? await fetchZapProvider(JSON.parse(profile.content)) : null;
+      } catch {
+        // The commissioner's profile has invalid metadata, so no entry fee can be verified
+      }
+
+      const payments = provider
+        ? await Promise.all(events.filter(e => e.kind === 9735).map(e => entryPayment(e, league.commissioner, provider!.nostrPubkey)))
+        : [];
+
+      return {
+        event: latest,
+        league,
+        picks: events.map(parseFantasyPicksEvent).filter((p): p is FantasyPicks => p !== null),
+        payments: payments.filter((p): p is EntryPayment => p !== null),
+      };
+    },
+    enabled: !!leagueId,
+  });
+
+  const league = query.data?.league ?? null;
+  const picks = query.data?.picks ?? [];
+  const isCommissioner = !!user && !!league && league.commissioner === user.pubkey;
+
+  const publish = useMutation({
+    mutationFn: async (event: NostrEvent) => {
+      return publishEvent({ kind: event.kind, content: event.content, tags: event.tags, created_at: event.created_at });
+    },
+    onSuccess: () => {
+      queryClient.invalidateQueries({ queryKey });
+    },
+  });
+
+  const updateLeague = (changes: Partial<FantasyLeague>) => {
+    if (!league || !isCommissioner) throw new Error('Only the commissioner can change the league');
+    return publish.mutateAsync(createFantasyLeagueEvent({ ...league, ...changes }));
+  };
+
+  const standings = league ? fantasyStandings(league, picks) : [];
+  const paid = league ? paidMembers(league, query.data?.payments ?? []) : new Set<string>();
+
+  return {
+    event: query.data?.event ?? null,
+    league,
+    standings,
+    paid,
+    prizes: league ? fantasyPrizes(league, standings, paid) : [],
+    isFinished: !!league && league.majors.length > 0 && league.majors.every(m => league.results[m.eventId]),
+    myPicks: (eventId: string) => picks
+      .filter(p => p.member === user?.pubkey && p.eventId === eventId)
+      .sort((a, b) => b.pickedAt - a.pickedAt)[0],
+    isLoading: query.isLoading,
+    isCommissioner,
+    create: async (details: Pick<FantasyLeague, 'name' | 'entryFeeSats' | 'picksPerMajor' | 'payout' | 'source'>) => {
+      if (!user) throw new Error('Log in to start a fantasy league');
+      if (league) throw new Error('A league with this id already exists');
+      return publish.mutateAsync(createFantasyLeagueEvent({
+        ...details,
+        leagueId: leagueId!,
+        commissioner: user.pubkey,
+        majors: [],
+        results: {},
+      }));
+    },
+    addMajor: async (major: FantasyMajor) => {
+      if (league?.majors.some(m => m.eventId === major.eventId)) throw new Error('That major is already in the league');
+      return updateLeague({ majors: [...(league?.majors ?? []), major].sort((a, b) => a.startsAt - b.startsAt) });
+    },
+    /** Load a major's results from the league's data source, or from pasted text */
+    loadResults: async (eventId: string, pasted?: string) => {
+      if (!league) throw new Error('League not found');
+      const source = fantasySource(league.source);
+      let results;
+      if (pasted) {
+        results = parseResultsCsv(pasted);
+      } else {
+        if (!source.url) throw new Error('Paste the results for this major');
+        const response = await upstreamFetch(UPSTREAM_SERVICES.results, source.url(eventId));
+        if (!response.ok) throw new Error(`${source.name} returned ${response.status}`);
+        results = source.parse(await response.text());
+      }
+      if (results.length === 0) throw new Error('No results found');
+      return updateLeague({ results: { ...league.results, [eventId]: results } });
+    },
+    pick: async (eventId: string, pros: string[]) => {
+      if (!user) throw new Error('Log in to make your picks');
+      if (!league) throw new Error('League not found');
+      const major = league.majors.find(m => m.eventId === eventId);
+      if (!major) throw new Error('That major is not in the league');
+      if (Date.now() >= major.startsAt) throw new Error('Picks for this major are locked');
+      if (pros.length === 0 || pros.length > league.picksPerMajor) throw new Error(`Pick up to ${league.picksPerMajor} pros`);
+      return publish.mutateAsync(createFantasyPicksEvent({ leagueId: league.leagueId, eventId, member: user.pubkey, pros }, league.commissioner));
+    },
+    isPublishing: publish.status === 'pending',
+  };
+}
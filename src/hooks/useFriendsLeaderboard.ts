@@ -0,0 +1,38 @@
+import { useQuery } from '@tanstack/react-query';
+import { useNostr } from '@nostrify/react';
+import { fetchPlayerCards } from './useScoringDelegations';
+import { parsePlayerScoreEvent, type PlayerScoreRecord } from '@/lib/golf/nostrEvents';
+import { followedPubkeys, friendsLeaderboard, monthStart, type FriendStanding } from '@/lib/golf/friendsEngine';
+
+/**
+ * "Friends this month" leaderboard for a player: the people they follow
+ * (kind 3) who have posted Pinseekr score cards this calendar month, plus
+ * the player themselves.
+ */
+export function useFriendsLeaderboard(pubkey: string | undefined) {
+  const { nostr } = useNostr();
+
+  return useQuery<{ standings: FriendStanding[]; follows: number }>({
+    queryKey: ['friends-leaderboard', pubkey],
+    queryFn: async (c) => {
+      const signal = AbortSignal.any([c.signal, AbortSignal.timeout(5000)]);
+      const lists = await nostr.query([{ kinds: [3], authors: [pubkey!], limit: 1 }], { signal });
+      const latestList = lists.sort((a, b) => b.created_at - a.created_at)[0];
+      const follows = followedPubkeys(latestList);
+
+      const since = Math.floor(monthStart(new Date()) / 1000);
+      const cards = await fetchPlayerCards(nostr, [pubkey!, ...follows.filter(p => p !== pubkey)], { since, limit: 2000 }, signal);
+
+      const records = cards
+        .map(({ event, player }) => {
+          const record = parsePlayerScoreEvent(event);
+          return record && { ...record, playerPubkey: player };
+        })
+        .filter((r): r is PlayerScoreRecord => r !== null);
+
+      return { standings: friendsLeaderboard(records), follows: follows.length };
+    },
+    enabled: !!pubkey,
+    staleTime: 5 * 60 * 1000,
+  });
+}
@@ -0,0 +1,121 @@
+import { useQuery, useMutation, useQueryClient } from '@tanstack/react-query';
+import { useNostr } from '@nostrify/react';
+import { useCurrentUser } from './useCurrentUser';
+import { useNostrPublish } from './useNostrPublish';
+import { usePrivacySettings } from './usePrivacy';
+import { GOLF_KINDS } from '@/lib/golf/types';
+import {
+  createBagEvent,
+  createShotEvent,
+  parseBagEvent,
+  parsePracticeSessionEvent,
+  parseShotEvent,
+  type ShotDetails,
+} from '@/lib/golf/nostrEvents';
+import {
+  calculateClubDistances,
+  validateBag,
+  DEFAULT_BAG,
+  type BagClub,
+  type BagConfig,
+  type ShotSample,
+} from '@/lib/golf/bagEngine';
+import { practiceShotSamples, type PracticeSession } from '@/lib/golf/practiceEngine';
+import { DEFAULT_PRIVACY_SETTINGS, anonymizeShot, hasConsent } from '@/lib/golf/privacyEngine';
+import { v4 as uuidv4 } from 'uuid';
+
+/**
+ * Hook for a player's bag: registered clubs plus carry distances learned
+ * from their recorded shots and launch monitor practice. Falls back to a
+ * standard set when no bag has been published.
+ */
+export function useGolfBag(pubkey: string | undefined, config: BagConfig = {}) {
+  const { nostr } = useNostr();
+  const { user } = useCurrentUser();
+  const { mutateAsync: publishEvent } = useNostrPublish();
+  const queryClient = useQueryClient();
+  const { data: privacy = DEFAULT_PRIVACY_SETTINGS } = usePrivacySettings(user?.pubkey);
+
+  const query = useQuery({
+    queryKey: ['golf-bag', pubkey],
+    queryFn: async (c) => {
+      const signal = AbortSignal.any([c.signal, AbortSignal.timeout(5000)]);
+      const events = await nostr.query([
+        { kinds: [GOLF_KINDS.GOLF_BAG], authors: [pubkey!], '#d': ['bag'], limit: 1 },
+        { kinds: [GOLF_KINDS.SHOT], authors: [pubkey!], limit: 500 },
+        { kinds: [GOLF_KINDS.PRACTICE_SESSION], authors: [pubkey!], limit: 50 },
+      ], { signal });
+
+      const bagEvent = events
+        .filter(e => e.kind === GOLF_KINDS.GOLF_BAG)
+        .sort((a, b) => b.created_at - a.created_at)[0];
+      const registered = bagEvent ? parseBagEvent(bagEvent) : null;
+      const clubs = registered && registered.length > 0 ? registered : DEFAULT_BAG;
+
+      const shots: ShotSample[] = events
+        .filter(e => e.kind === GOLF_KINDS.SHOT)
+        .map(e => parseShotEvent(e))
+        .filter((s): s is NonNullable<typeof s> => s !== null);
+
+      // Latest version of each practice session
+      const practice = new Map<string, { createdAt: number; session: PracticeSession }>();
+      for (const event of events.filter(e => e.kind === GOLF_KINDS.PRACTICE_SESSION)) {
+        const session = parsePracticeSessionEvent(event);
+        const existing = session && practice.get(session.sessionId);
+        if (session && (!existing || event.created_at > existing.createdAt)) {
+          practice.set(session.sessionId, { createdAt: event.created_at, session });
+        }
+      }
+      const practiceShots = practiceShotSamples([...practice.values()].map(p => p.session));
+
+      return {
+        clubs,
+        isDefault: !registered || registered.length === 0,
+        shots,
+        distances: calculateClubDistances(clubs, [...shots, ...practiceShots], config),
+      };
+    },
+    enabled: !!pubkey,
+    staleTime: 60 * 1000,
+  });
+
+  const saveBag = useMutation({
+    mutationFn: async (clubs: BagClub[]) => {
+      if (!user) throw new Error('Must be logged in to save a bag');
+
+      const errors = validateBag(clubs);
+      if (errors.length > 0) throw new Error(errors[0]);
+
+      const event = createBagEvent(clubs, user.pubkey);
+      return publishEvent({ kind: event.kind, content: event.content, tags: event.tags, created_at: event.created_at });
+    },
+    onSuccess: () => {
+      queryClient.invalidateQueries({ queryKey: ['golf-bag', user?.pubkey] });
+    },
+  });
+
+  const recordShot = useMutation({
+    mutationFn: async (params: { clubId: string; distance: number; shotId?: string; timestamp?: number } & ShotDetails) => {
+      if (!user) throw new Error('Must be logged in to record shots');
+
+      // Sensors pass their own id and time, so a resent reading replaces the shot
+      const { clubId, distance, shotId = uuidv4(), timestamp = Date.now(), ...details } = params;
+      const shot: ShotSample = { shotId, clubId, distance, timestamp };
+      // Positions are only published with the player's consent
+      const event = createShotEvent(shot, user.pubkey, hasConsent(privacy, 'gps') ? details : anonymizeShot(details));
+      await publishEvent({ kind: event.kind, content: event.content, tags: event.tags, created_at: event.created_at });
+
+      return shot;
+    },
+    onSuccess: () => {
+      queryClient.invalidateQueries({ queryKey: ['golf-bag', user?.pubkey] });
+    },
+  });
+
+  return {
+    ...query,
+    saveBag: saveBag.mutateAsync,
+    recordShot: recordShot.mutateAsync,
+    isSaving: saveBag.status === 'pending' || recordShot.status === 'pending',
+  };
+}
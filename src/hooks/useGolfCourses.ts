@@ -1,5 +1,6 @@
 import React from 'react';
 import { useNostr } from '@nostrify/react';
+import type { NostrEvent, NostrFilter } from '@nostrify/nostrify';
 import { GOLF_KINDS } from '@/lib/golf/types';
 import { useQuery, useMutation, useQueryClient } from '@tanstack/react-query';
 import { useNostrPublish } from '@/hooks/useNostrPublish';
@@ -15,6 +16,8 @@ export interface GolfCourse {
   teeYardages?: { [teeName: string]: { [hole: number]: number } }; // per-tee per-hole yardages
   sections?: { [sectionIndex: number]: string }; // section names (e.g., "Granite", "Slate")
   tees?: string[]; // tee names (e.g., ["Black", "Blue", "White"])
+  teeRatings?: { [teeName: string]: { courseRating: number; slopeRating: number } }; // per-tee course/slope rating
+  greens?: { [hole: number]: { lat: number; lon: number } }; // green centre positions
   totalPar: number;
   author: string;
   createdAt: number;
@@ -22,6 +25,217 @@ export interface GolfCourse {
   eventId?: string; // Nostr event ID
 }
 
+/**
+ * Parse a course event, from its JSON content or, for older events, its tags
+ */
+export function parseCourseEvent(event: NostrEvent): GolfCourse {
+  // Try to parse JSON content first (preferred)
+  try {
+    if (event.content && event.content.trim().startsWith('{')) {
+      const parsedRaw = JSON.parse(event.content) as unknown;
+      const parsed = parsedRaw as {
+        id?: string;
+        name?: string;
+        title?: string;
+        courseName?: string;
+        location?: string;
+        courseLocation?: string;
+        holes?: Record<string, number>;
+        handicaps?: Record<string, number>;
+        sections?: Record<string, string>;
+        tees?: string[] | { name: string; yardage: number }[]; // support both formats
+        teeYardages?: Record<string, Record<string, number>>;
+        teeRatings?: Record<string, { courseRating: number; slopeRating: number }>;
+        greens?: Record<string, { lat: number; lon: number }>;
+        yardages?: Record<string, number>;
+        totalPar?: number;
+        createdAt?: number;
+      };
+
+      const parsedHoles = parsed.holes || {};
+      const computedTotalPar = typeof parsed.totalPar === 'number'
+        ? parsed.totalPar
+        : Object.values(parsedHoles).map(v => Number(v) || 0).reduce((s, n) => s + n, 0);
+
+      // Convert tees from old format { name, yardage }[] to new format string[]
+      let teeNames: string[] | undefined;
+      if (parsed.tees) {
+        if (Array.isArray(parsed.tees) && parsed.tees.length > 0) {
+          if (typeof parsed.tees[0] === 'string') {
+            teeNames = parsed.tees as string[];
+          } else {
+            // Old format - extract just the names
+            teeNames = (parsed.tees as { name: string; yardage: number }[]).map(t => t.name);
+          }
+        }
+      }
+
+      return {
+        id: parsed.id || event.tags.find(t => t[0] === 'd')?.[1] || event.id,
+        name: parsed.name || parsed.title || parsed.courseName || 'Unknown Course',
+        location: parsed.location || parsed.courseLocation || '',
+        holes: parsed.holes || {},
+        handicaps: parsed.handicaps || undefined,
+        sections: parsed.sections || undefined,
+        tees: teeNames,
+        teeYardages: parsed.teeYardages || undefined,
+        teeRatings: parsed.teeRatings || undefined,
+        greens: parsed.greens || undefined,
+        yardages: parsed.yardages || undefined,
+        totalPar: computedTotalPar,
+        author: event.pubkey,
+        createdAt: (parsed.createdAt && typeof parsed.createdAt === 'number') ? parsed.createdAt : event.created_at * 1000,
+      } as GolfCourse;
+    }
+  } catch {
+    // Fall back to tag parsing below if JSON parse fails
+  }
+
+  // Fallback: parse hole pars, yardages and sections from tags (back-compat)
+  const getTag = (name: string) => event.tags.find(tag => tag[0] === name)?.[1];
+  const holes: { [hole: number]: number } = {};
+  const handicaps: { [hole: number]: number } = {};
+  const sections: { [sectionIndex: number]: string } = {};
+  const teeNames: string[] = [];
+  const teeYardages: { [teeName: string]: { [hole: number]: number } } = {};
+  const teeRatings: { [teeName: string]: { courseRating: number; slopeRating: number } } = {};
+  const yardages: { [hole: number]: number } = {};
+  let totalPar = 0;
+
+  event.tags.forEach(tag => {
+    if (tag[0].startsWith('hole') && tag[0] !== 'hole') {
+      const holeNumber = parseInt(tag[0].replace('hole', ''));
+      if (!isNaN(holeNumber) && holeNumber > 0) {
+        const par = parseInt(tag[1] || '4');
+        holes[holeNumber] = par;
+        totalPar += par;
+      }
+    } else if (tag[0] === 'hc' && tag[1] && tag[2]) {
+      // Handicap tag: ['hc', '<holeNumber>', '<handicap>']
+      const holeNum = parseInt(tag[1]);
+      const hc = parseInt(tag[2]);
+      if (!isNaN(holeNum) && !isNaN(hc)) handicaps[holeNum] = hc;
+    } else if (tag[0].startsWith('section') && tag[0] !== 'section') {
+      const sectionIndex = parseInt(tag[0].replace('section', ''));
+      if (!isNaN(sectionIndex) && sectionIndex >= 0) {
+        sections[sectionIndex] = tag[1] || '';
+      }
+    } else if (tag[0] === 'tee') {
+      // New format: ['tee', '<name>'] (no yardage - yardages are per-hole)
+      // Old format: ['tee', '<name>', '<totalYardage>']
+      const name = tag[1] || '';
+      if (name && !teeNames.includes(name)) {
+        teeNames.push(name);
+        teeYardages[name] = {};
+      }
+    } else if (tag[0] === 'rating' && tag[1]) {
+      // Rating tag: ['rating', '<teeName>', '<courseRating>', '<slopeRating>']
+      const courseRating = parseFloat(tag[2]);
+      const slopeRating = parseInt(tag[3]);
+      if (!isNaN(courseRating) && !isNaN(slopeRating)) teeRatings[tag[1]] = { courseRating, slopeRating };
+    } else if (tag[0] === 'yard') {
+      // New format: ['yard', '<teeName>', '<holeNumber>', '<yards>']
+      // Old format: ['yard', '<holeNumber>', '<yards>']
+      if (tag.length === 4) {
+        // New format with tee name
+        const teeName = tag[1];
+        const holeNum = parseInt(tag[2]);
+        const yards = parseInt(tag[3]) || 0;
+        if (teeName && !isNaN(holeNum) && holeNum > 0) {
+          if (!teeYardages[teeName]) teeYardages[teeName] = {};
+          teeYardages[teeName][holeNum] = yards;
+        }
+      } else if (tag.length === 3) {
+        // Old format without tee name
+        const holeNum = parseInt(tag[1]);
+        const yards = parseInt(tag[2]) || 0;
+        if (!isNaN(holeNum) && holeNum > 0) yardages[holeNum] = yards;
+      }
+    }
+  });
+
+  return {
+    id: getTag('d') || event.id,
+    name: getTag('name') || 'Unknown Course',
+    location: getTag('location') || '',
+    holes,
+    handicaps: Object.keys(handicaps).length > 0 ? handicaps : undefined,
+    sections: Object.keys(sections).length > 0 ? sections : undefined,
+    tees: teeNames.length > 0 ? teeNames : undefined,
+    teeYardages: Object.keys(teeYardages).length > 0 ? teeYardages : undefined,
+    teeRatings: Object.keys(teeRatings).length > 0 ? teeRatings : undefined,
+    yardages: Object.keys(yardages).length > 0 ? yardages : undefined,
+    totalPar,
+    author: event.pubkey,
+    createdAt: event.created_at * 1000,
+  };
+}
+
+/**
+ * Course and slope rating for the tee played. Without a recorded tee, a
+ * course with a single rated tee uses that one.
+ */
+export function teeRating(course: GolfCourse, tee?: string): { courseRating: number; slopeRating: number } | undefined {
+  const ratings = course.teeRatings ?? {};
+  if (tee && ratings[tee]) return ratings[tee];
+  const rated = Object.values(ratings);
+  return !tee && rated.length === 1 ? rated[0] : undefined;
+}
+
+interface NostrLike {
+  query(filters: NostrFilter[], opts?: { signal?: AbortSignal }): Promise<NostrEvent[]>;
+}
+
+export interface RoundCourse {
+  course: GolfCourse;
+  tee?: string; // the tee box played, when the round recorded it
+  holes?: number; // holes in the round (9 or 18), from its `holes` tag
+}
+
+/**
+ * The course each round was played on, from the latest version of the
+ * round's event among those the player hosted or is listed in. Rounds on a
+ * course with no course event are left out.
+ */
+export async function fetchRoundCourses(
+  nostr: NostrLike,
+  roundIds: string[],
+  player: string,
+  signal: AbortSignal,
+): Promise<Map<string, RoundCourse>> {
+  if (roundIds.length === 0) return new Map();
+
+  const events = await nostr.query([
+    { kinds: [GOLF_KINDS.ROUND], '#round-id': roundIds },
+    { kinds: [GOLF_KINDS.COURSE], '#t': ['golf-course'], limit: 500 },
+  ], { signal });
+
+  const courses = new Map<string, GolfCourse>();
+  for (const course of events.filter(e => e.kind === GOLF_KINDS.COURSE).map(parseCourseEvent)) {
+    const key = course.name.toLowerCase().trim();
+    const existing = courses.get(key);
+    if (!existing || course.createdAt > existing.createdAt) courses.set(key, course);
+  }
+
+  const rounds = new Map<string, NostrEvent>();
+  for (const event of events) {
+    if (event.kind !== GOLF_KINDS.ROUND) continue;
+    const roundId = event.tags.find(t => t[0] === 'round-id')?.[1];
+    const players = event.tags.find(t => t[0] === 'players')?.slice(1) ?? [];
+    if (!roundId || (event.pubkey !== player && !players.includes(player))) continue;
+    const existing = rounds.get(roundId);
+    if (!existing || event.created_at > existing.created_at) rounds.set(roundId, event);
+  }
+
+  const result = new Map<string, RoundCourse>();
+  for (const [roundId, event] of rounds) {
+    const course = courses.get(event.tags.find(t => t[0] === 'course')?.[1]?.toLowerCase().trim() ?? '');
+    const holes = parseInt(event.tags.find(t => t[0] === 'holes')?.[1] ?? '');
+    if (course) result.set(roundId, { course, tee: event.tags.find(t => t[0] === 'tee-box')?.[1], holes: isNaN(holes) ? undefined : holes });
+  }
+  return result;
+}
+
 /**
  * Hook to fetch all golf courses from Nostr
  */
@@ -40,137 +254,7 @@ export function useGolfCourses() {
         }
       ], { signal });
 
-      const courses: GolfCourse[] = events.map(event => {
-        // Try to parse JSON content first (preferred)
-        try {
-          if (event.content && event.content.trim().startsWith('{')) {
-            const parsedRaw = JSON.parse(event.content) as unknown;
-            const parsed = parsedRaw as {
-              id?: string;
-              name?: string;
-              title?: string;
-              courseName?: string;
-              location?: string;
-              courseLocation?: string;
-              holes?: Record<string, number>;
-              handicaps?: Record<string, number>;
-              sections?: Record<string, string>;
-              tees?: string[] | { name: string; yardage: number }[]; // support both formats
-              teeYardages?: Record<string, Record<string, number>>;
-              yardages?: Record<string, number>;
-              totalPar?: number;
-              createdAt?: number;
-            };
-
-            const parsedHoles = parsed.holes || {};
-            const computedTotalPar = typeof parsed.totalPar === 'number'
-              ? parsed.totalPar
-              : Object.values(parsedHoles).map(v => Number(v) || 0).reduce((s, n) => s + n, 0);
-
-            // Convert tees from old format { name, yardage }[] to new format string[]
-            let teeNames: string[] | undefined;
-            if (parsed.tees) {
-              if (Array.isArray(parsed.tees) && parsed.tees.length > 0) {
-                if (typeof parsed.tees[0] === 'string') {
-                  teeNames = parsed.tees as string[];
-                } else {
-                  // Old format - extract just the names
-                  teeNames = (parsed.tees as { name: string; yardage: number }[]).map(t => t.name);
-                }
-              }
-            }
-
-            return {
-              id: parsed.id || event.tags.find(t => t[0] === 'd')?.[1] || event.id,
-              name: parsed.name || parsed.title || parsed.courseName || 'Unknown Course',
-              location: parsed.location || parsed.courseLocation || '',
-              holes: parsed.holes || {},
-              handicaps: parsed.handicaps || undefined,
-              sections: parsed.sections || undefined,
-              tees: teeNames,
-              teeYardages: parsed.teeYardages || undefined,
-              yardages: parsed.yardages || undefined,
-              totalPar: computedTotalPar,
-              author: event.pubkey,
-              createdAt: (parsed.createdAt && typeof parsed.createdAt === 'number') ? parsed.createdAt : event.created_at * 1000,
-            } as GolfCourse;
-          }
-        } catch {
-          // Fall back to tag parsing below if JSON parse fails
-        }
-
-        // Fallback: parse hole pars, yardages and sections from tags (back-compat)
-        const getTag = (name: string) => event.tags.find(tag => tag[0] === name)?.[1];
-        const holes: { [hole: number]: number } = {};
-        const handicaps: { [hole: number]: number } = {};
-        const sections: { [sectionIndex: number]: string } = {};
-        const teeNames: string[] = [];
-        const teeYardages: { [teeName: string]: { [hole: number]: number } } = {};
-        const yardages: { [hole: number]: number } = {};
-        let totalPar = 0;
-
-        event.tags.forEach(tag => {
-          if (tag[0].startsWith('hole') && tag[0] !== 'hole') {
-            const holeNumber = parseInt(tag[0].replace('hole', ''));
-            if (!isNaN(holeNumber) && holeNumber > 0) {
-              const par = parseInt(tag[1] || '4');
-              holes[holeNumber] = par;
-              totalPar += par;
-            }
-          } else if (tag[0] === 'hc' && tag[1] && tag[2]) {
-            // Handicap tag: ['hc', '<holeNumber>', '<handicap>']
-            const holeNum = parseInt(tag[1]);
-            const hc = parseInt(tag[2]);
-            if (!isNaN(holeNum) && !isNaN(hc)) handicaps[holeNum] = hc;
-          } else if (tag[0].startsWith('section') && tag[0] !== 'section') {
-            const sectionIndex = parseInt(tag[0].replace('section', ''));
-            if (!isNaN(sectionIndex) && sectionIndex >= 0) {
-              sections[sectionIndex] = tag[1] || '';
-            }
-          } else if (tag[0] === 'tee') {
-            // New format: ['tee', '<name>'] (no yardage - yardages are per-hole)
-            // Old format: ['tee', '<name>', '<totalYardage>']
-            const name = tag[1] || '';
-            if (name && !teeNames.includes(name)) {
-              teeNames.push(name);
-              teeYardages[name] = {};
-            }
-          } else if (tag[0] === 'yard') {
-            // New format: ['yard', '<teeName>', '<holeNumber>', '<yards>']
-            // Old format: ['yard', '<holeNumber>', '<yards>']
-            if (tag.length === 4) {
-              // New format with tee name
-              const teeName = tag[1];
-              const holeNum = parseInt(tag[2]);
-              const yards = parseInt(tag[3]) || 0;
-              if (teeName && !isNaN(holeNum) && holeNum > 0) {
-                if (!teeYardages[teeName]) teeYardages[teeName] = {};
-                teeYardages[teeName][holeNum] = yards;
-              }
-            } else if (tag.length === 3) {
-              // Old format without tee name
-              const holeNum = parseInt(tag[1]);
-              const yards = parseInt(tag[2]) || 0;
-              if (!isNaN(holeNum) && holeNum > 0) yardages[holeNum] = yards;
-            }
-          }
-        });
-
-        return {
-          id: getTag('d') || event.id,
-          name: getTag('name') || 'Unknown Course',
-          location: getTag('location') || '',
-          holes,
-          handicaps: Object.keys(handicaps).length > 0 ? handicaps : undefined,
-          sections: Object.keys(sections).length > 0 ? sections : undefined,
-          tees: teeNames.length > 0 ? teeNames : undefined,
-          teeYardages: Object.keys(teeYardages).length > 0 ? teeYardages : undefined,
-          yardages: Object.keys(yardages).length > 0 ? yardages : undefined,
-          totalPar,
-          author: event.pubkey,
-          createdAt: event.created_at * 1000,
-        };
-      });
+      const courses = events.map(parseCourseEvent);
 
       // Deduplicate courses by name - keep only the most recent version
       const coursesByName = new Map<string, GolfCourse>();
@@ -246,6 +330,13 @@ export function useAddGolfCourse() {
         }
       }
 
+      // Add per-tee ratings as tags: ['rating', '<teeName>', '<courseRating>', '<slopeRating>']
+      if (course.teeRatings) {
+        for (const [teeName, rating] of Object.entries(course.teeRatings)) {
+          tags.push(['rating', teeName, String(rating.courseRating), String(rating.slopeRating)]);
+        }
+      }
+
       // Legacy: Add single per-hole yardages if no teeYardages (backwards compatibility)
       if (!course.teeYardages && course.yardages) {
         const holeNums = Object.keys(course.yardages).map(Number).sort((a, b) => a - b);
@@ -281,6 +372,8 @@ export function useAddGolfCourse() {
         handicaps: course.handicaps || undefined,
         tees: course.tees || undefined,
         teeYardages: course.teeYardages || undefined,
+        teeRatings: course.teeRatings || undefined,
+        greens: course.greens || undefined,
         yardages: course.yardages || undefined, // legacy
         sections: course.sections || undefined,
         scorecardImages: course.scorecardImages || undefined,
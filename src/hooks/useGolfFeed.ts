@@ -0,0 +1,45 @@
+import { useInfiniteQuery } from '@tanstack/react-query';
+import { useNostr } from '@nostrify/react';
+import type { NostrEvent } from '@nostrify/nostrify';
+import { useContentFilter } from './useContentFilter';
+import { buildFeed, FEED_HASHTAGS } from '@/lib/golf/feedEngine';
+
+const PAGE_SIZE = 50;
+
+/**
+ * Golf notes from the app's relays for the social feed, newest first. Relays
+ * can't match references by kind, so notes are fetched by #golf-style
+ * hashtags and then filtered with `buildFeed`. Spam is dropped with the
+ * app's content filter (mute list, web of trust, proof of work). Older pages
+ * load with `fetchNextPage`.
+ */
+export function useGolfFeed() {
+  const { nostr } = useNostr();
+  const contentFilter = useContentFilter();
+
+  const feed = useInfiniteQuery({
+    queryKey: ['golf-feed'],
+    queryFn: async ({ pageParam, signal: querySignal }) => {
+      const signal = AbortSignal.any([querySignal, AbortSignal.timeout(5000)]);
+      const events = await nostr.query([
+        { kinds: [1], '#t': FEED_HASHTAGS, until: pageParam, limit: PAGE_SIZE },
+      ], { signal });
+
+      return {
+        notes: buildFeed(events, [], PAGE_SIZE),
+        // Continue from the oldest event seen, even if it was filtered out
+        oldest: events.length > 0 ? Math.min(...events.map(e => e.created_at)) : undefined,
+      };
+    },
+    initialPageParam: undefined as number | undefined,
+    getNextPageParam: (last) => (last.oldest !== undefined ? last.oldest - 1 : undefined),
+    staleTime: 60 * 1000,
+  });
+
+  // Pages can overlap when relays disagree; dedupe across them
+  const notes: NostrEvent[] = contentFilter.isLoading
+    ? []
+    : buildFeed(contentFilter.filter(feed.data?.pages.flatMap(p => p.notes) ?? []), [], Infinity);
+
+  return { ...feed, isLoading: feed.isLoading || contentFilter.isLoading, notes };
+}
@@ -0,0 +1,85 @@
+import { useMutation, useQuery, useQueryClient } from '@tanstack/react-query';
+import { useNostr } from '@nostrify/react';
+import { useCurrentUser } from './useCurrentUser';
+import { useNostrPublish } from './useNostrPublish';
+import { GOLF_KINDS } from '@/lib/golf/types';
+import { createRateCardEvent, parseRateCardEvent } from '@/lib/golf/nostrEvents';
+import { parseOpenMeteoHourly, type HourlyForecast, type RateCard } from '@/lib/golf/pricingEngine';
+import type { GeoPoint } from '@/lib/golf/caddieEngine';
+import { UPSTREAM_SERVICES, upstreamFetch } from '@/lib/upstream/upstream';
+import type { GolfCourse } from './useGolfCourses';
+
+const OPEN_METEO_URL = 'https://api.open-meteo.com/v1/forecast';
+
+/**
+ * The club's green fee rate card for a course. Only the club account can
+ * publish it.
+ */
+export function useRateCard(course: Pick<GolfCourse, 'id' | 'author'> | null | undefined) {
+  const { nostr } = useNostr();
+  const { user } = useCurrentUser();
+  const { mutateAsync: publishEvent } = useNostrPublish();
+  const queryClient = useQueryClient();
+  const queryKey = ['rate-card', course?.author, course?.id];
+
+  const query = useQuery<RateCard | null>({
+    queryKey,
+    queryFn: async (c) => {
+      const signal = AbortSignal.any([c.signal, AbortSignal.timeout(5000)]);
+      const events = await nostr.query([
+        { kinds: [GOLF_KINDS.RATE_CARD], authors: [course!.author], '#d': [course!.id] },
+      ], { signal });
+
+      return events
+        .map(parseRateCardEvent)
+        .filter((card): card is RateCard => card !== null && card.courseAuthor === course!.author)
+        .sort((a, b) => b.createdAt - a.createdAt)[0] ?? null;
+    },
+    enabled: !!course?.author && !!course?.id,
+    staleTime: 5 * 60 * 1000,
+  });
+
+  const save = useMutation({
+    mutationFn: async (card: Pick<RateCard, 'currency' | 'baseFee' | 'memberClasses' | 'rules'>) => {
+      if (!course) throw new Error('Select a course');
+      if (user?.pubkey !== course.author) throw new Error('Only the club account can set green fees');
+      const event = createRateCardEvent({ ...card, courseId: course.id, courseAuthor: course.author });
+      return publishEvent({ kind: event.kind, content: event.content, tags: event.tags, created_at: event.created_at });
+    },
+    onSuccess: () => {
+      queryClient.invalidateQueries({ queryKey });
+    },
+  });
+
+  return {
+    rateCard: query.data ?? null,
+    isLoading: query.isLoading,
+    saveRateCard: save.mutateAsync,
+    isPublishing: save.status === 'pending',
+  };
+}
+
+/**
+ * Hook for the hourly weather forecast at a point for the next week, from
+ * Open-Meteo
+ */
+export function useWeatherForecast(point: GeoPoint | null) {
+  return useQuery<HourlyForecast[]>({
+    queryKey: ['weather-forecast', point?.lat.toFixed(2), point?.lon.toFixed(2)],
+    queryFn: async (c) => {
+      const params = new URLSearchParams({
+        latitude: point!.lat.toFixed(4),
+        longitude: point!.lon.toFixed(4),
+        hourly: 'temperature_2m,precipitation_probability,wind_speed_10m',
+        timeformat: 'unixtime',
+        forecast_days: '7',
+      });
+      const response = await upstreamFetch(UPSTREAM_SERVICES.weather, `${OPEN_METEO_URL}?${params}`, { signal: c.signal });
+      if (!response.ok) throw new Error('Failed to load the weather forecast');
+      return parseOpenMeteoHourly(await response.json());
+    },
+    enabled: !!point,
+    staleTime: 30 * 60 * 1000,
+    retry: 1,
+  });
+}
@@ -0,0 +1,69 @@
+import { useMutation, useQuery, useQueryClient } from '@tanstack/react-query';
+import { useNostr } from '@nostrify/react';
+import { useCurrentUser } from './useCurrentUser';
+import { useNostrPublish } from './useNostrPublish';
+import { GOLF_KINDS } from '@/lib/golf/types';
+import { createGreenReportEvent, isExpired, parseGreenReportEvent } from '@/lib/golf/nostrEvents';
+import {
+  currentGreenReading,
+  GREEN_READING_MAX_AGE_DAYS,
+  readingDate,
+  type GreenReading,
+} from '@/lib/golf/maintenanceEngine';
+import type { GolfCourse } from './useGolfCourses';
+
+/**
+ * The course's current green speed and firmness, posted daily by the club
+ * account. The club can post today's reading with `postReading`.
+ */
+export function useGreenReport(course: Pick<GolfCourse, 'id' | 'author'> | null | undefined) {
+  const { nostr } = useNostr();
+  const { user } = useCurrentUser();
+  const { mutateAsync: publishEvent } = useNostrPublish();
+  const queryClient = useQueryClient();
+  const queryKey = ['green-report', course?.author, course?.id];
+
+  const query = useQuery<GreenReading[]>({
+    queryKey,
+    queryFn: async (c) => {
+      const signal = AbortSignal.any([c.signal, AbortSignal.timeout(5000)]);
+      const since = Math.floor(Date.now() / 1000) - (GREEN_READING_MAX_AGE_DAYS + 1) * 24 * 60 * 60;
+      const events = await nostr.query([{
+        kinds: [GOLF_KINDS.GREEN_REPORT],
+        authors: [course!.author],
+        '#a': [`${GOLF_KINDS.COURSE}:${course!.author}:${course!.id}`],
+        since,
+      }], { signal });
+      return events
+        .filter(e => !isExpired(e))
+        .map(parseGreenReportEvent)
+        .filter((r): r is GreenReading => r !== null);
+    },
+    enabled: !!course?.author && !!course?.id,
+    staleTime: 15 * 60 * 1000,
+  });
+
+  const publish = useMutation({
+    mutationFn: async (reading: Pick<GreenReading, 'stimp' | 'firmness' | 'note'>) => {
+      if (!course) throw new Error('Select a course');
+      if (user?.pubkey !== course.author) throw new Error('Only the club account can post green reports');
+      const event = createGreenReportEvent({
+        ...reading,
+        courseId: course.id,
+        courseAuthor: course.author,
+        date: readingDate(new Date()),
+      });
+      return publishEvent({ kind: event.kind, content: event.content, tags: event.tags, created_at: event.created_at });
+    },
+    onSuccess: () => {
+      queryClient.invalidateQueries({ queryKey });
+    },
+  });
+
+  return {
+    reading: currentGreenReading(query.data ?? [], new Date()),
+    isLoading: query.isLoading,
+    postReading: publish.mutateAsync,
+    isPublishing: publish.status === 'pending',
+  };
+}
@@ -1,20 +1,390 @@
 import { useNostr } from '@nostrify/react';
+import type { NostrEvent, NostrFilter } from '@nostrify/nostrify';
 import { useQuery } from '@tanstack/react-query';
 import { GOLF_KINDS } from '@/lib/golf/types';
+import { fetchPlayerCards } from './useScoringDelegations';
+import { fetchRoundCourses, parseCourseEvent, teeRating, type GolfCourse } from './useGolfCourses';
 import {
+  parseAttestationEvent,
+  parseHandicapPenaltyEvent,
+  parsePlayerScoreEvent,
+  parseRulingEvent,
+  parseShotEvent,
+} from '@/lib/golf/nostrEvents';
+import { checkRoundEvidence, holdForReview, type RoundEvidence } from '@/lib/golf/integrityEngine';
+import { countsForPlay, hostedRounds, resolveAttestation, type ScoreAttestation } from '@/lib/golf/attestationEngine';
+import { applyRuling, latestRulings, type Ruling } from '@/lib/golf/disputeEngine';
+import type { GeoPoint } from '@/lib/golf/caddieEngine';
+import { scoreCardRound } from '@/lib/golf/delegationEngine';
+import {
+  calculateAdjustedGrossScore,
+  calculateCourseHandicap,
   calculateDifferential,
   calculateHandicapIndex,
+  applyExceptionalScoreReductions,
   DEFAULT_COURSE_RATING,
   DEFAULT_SLOPE,
+  type AdjustedHoleInput,
   type RoundDifferential,
   type HandicapResult,
 } from '@/lib/golf/handicapCalculator';
 
+interface NostrLike {
+  query(filters: NostrFilter[], opts?: { signal?: AbortSignal }): Promise<NostrEvent[]>;
+}
+
 interface PlayerScoreContent {
   holes?: { hole: number; strokes: number; putts?: number }[];
   gross?: number;
   net?: number;
   thru?: number;
+  updatedAt?: number;
+}
+
+/**
+ * Load a player's PLAYER_SCORE events and convert them to handicap differentials,
+ * using course ratings/slopes from COURSE events where available. Hole scores
+ * are capped at net double bogey and holes not played count as net par.
+ * Penalty scores are only accepted from the given committee pubkeys.
+ * Each round is checked for plausibility against its tee time and GPS track,
+ * and carries the attestation status of the player's card. Committee rulings
+ * correct or void cards.
+ */
+export async function fetchRoundDifferentials(
+  nostr: NostrLike,
+  userPubkey: string,
+  signal: AbortSignal,
+  limit: number,
+  committeePubkeys: string[] = []
+): Promise<RoundDifferential[]> {
+  // Query user's PLAYER_SCORE events (their scorecards, and cards terminals signed for them)
+  const scoreCards = await fetchPlayerCards(nostr, [userPubkey], { limit }, AbortSignal.any([signal, AbortSignal.timeout(10000)]));
+
+  // Also query for the courses to get ratings/slopes
+  const courseEvents = await nostr.query([{
+    kinds: [GOLF_KINDS.COURSE],
+    '#t': ['golf-course'],
+    limit: 100,
+  }], { signal: AbortSignal.any([signal, AbortSignal.timeout(10000)]) });
+
+  // Build course lookup by name/id
+  const courseMap = new Map<string, GolfCourse>();
+  for (const course of courseEvents.map(parseCourseEvent)) {
+    courseMap.set(course.name.toLowerCase(), course);
+    courseMap.set(course.id, course);
+  }
+
+  // The course and tee each round was played from, for cards without a course tag
+  const roundCourses = await fetchRoundCourses(
+    nostr,
+    [...new Set(scoreCards.map(({ event }) => scoreCardRound(event)).filter((id): id is string => !!id))],
+    userPubkey,
+    AbortSignal.any([signal, AbortSignal.timeout(10000)])
+  );
+
+  // Convert score events to differentials
+  const differentials: RoundDifferential[] = [];
+  const evidence = new Map<string, RoundInfo>();
+  const roundHoles = new Map<string, AdjustedHoleInput[]>();
+
+  for (const { event } of scoreCards) {
+    try {
+      const content: PlayerScoreContent = JSON.parse(event.content || '{}');
+      // Hole scores from either card layout (a `holes` list or a `scores` map)
+      const scores = parsePlayerScoreEvent(event)?.scores ?? {};
+
+      // Look up course by various means, and the rating of the tee played
+      const roundKey = scoreCardRound(event);
+      const played = roundKey ? roundCourses.get(roundKey) : undefined;
+      const courseTag = event.tags?.find(t => t[0] === 'course')?.[1];
+      const courseInfo = (courseTag && (courseMap.get(courseTag.toLowerCase()) || courseMap.get(courseTag))) || played?.course;
+
+      // Skip incomplete rounds (need gross score, or 14+ holes / 7+ holes when the round was a nine)
+      const holesPlayed = Object.keys(scores).length;
+      const isNineHole = played?.holes === 9;
+      if (!content.gross && holesPlayed < (isNineHole ? 7 : 14)) {
+        continue;
+      }
+
+      // The round's holes with the course pars and stroke indexes (par 4 and the
+      // hole number when the course doesn't say); a back nine starts at hole 10
+      const firstHole = isNineHole && Object.keys(scores).some(h => Number(h) > 9) ? 10 : 1;
+      const holes: AdjustedHoleInput[] = Array.from({ length: isNineHole ? 9 : 18 }, (_, i) => ({
+        hole: firstHole + i,
+        par: courseInfo?.holes[firstHole + i] ?? 4,
+        strokeIndex: courseInfo?.handicaps?.[firstHole + i] ?? firstHole + i,
+        strokes: scores[firstHole + i],
+      }));
+
+      // Until there's an index, holes are capped at the maximum course handicap
+      const gross = holesPlayed > 0
+        ? calculateAdjustedGrossScore(holes, isNineHole ? 27 : 54).adjustedGross
+        : content.gross!;
+      if (holesPlayed > 0) roundHoles.set(event.id, holes);
+
+      if (gross < (isNineHole ? 20 : 40)) continue; // Invalid score
+
+      // Try to find course info from the round
+      let courseRating = DEFAULT_COURSE_RATING;
+      let slope = DEFAULT_SLOPE;
+      let courseName = 'Unknown Course';
+      let greens: GeoPoint[] = [];
+
+      if (courseInfo) {
+        const rating = teeRating(courseInfo, played?.course.id === courseInfo.id ? played.tee : undefined);
+        courseRating = rating?.courseRating ?? DEFAULT_COURSE_RATING;
+        slope = rating?.slopeRating ?? DEFAULT_SLOPE;
+        courseName = courseInfo.name;
+        greens = Object.values(courseInfo.greens ?? {});
+      }
+
+      if (roundKey) {
+        evidence.set(event.id, {
+          roundKey,
+          holesPlayed: holesPlayed || (isNineHole ? 9 : 18),
+          finishedAt: content.updatedAt || event.created_at * 1000,
+          course: greens,
+          scores,
+        });
+      }
+
+      // Simulator rounds only count when the player opts in
+      const indoor = event.tags?.some(t => t[0] === 'origin' && t[1] === 'simulator') || undefined;
+
+      if (isNineHole) {
+        // Nine-hole rating is half the 18-hole rating; combined with an expected score on replay
+        const nineHoleDifferential = calculateDifferential(gross, courseRating / 2, slope);
+        differentials.push({
+          roundId: event.id,
+          date: event.created_at * 1000,
+          gross,
+          courseRating: courseRating / 2,
+          slope,
+          differential: nineHoleDifferential * 2,
+          courseName,
+          holes: 9,
+          nineHoleDifferential,
+          indoor,
+        });
+        continue;
+      }
+
+      const differential = calculateDifferential(gross, courseRating, slope);
+
+      differentials.push({
+        roundId: event.id,
+        date: event.created_at * 1000,
+        gross,
+        courseRating,
+        slope,
+        differential,
+        courseName,
+        indoor,
+      });
+    } catch {
+      // Skip invalid events
+    }
+  }
+
+  capAtNetDoubleBogey(differentials, roundHoles);
+
+  await attachRoundChecks(nostr, userPubkey, signal, differentials, evidence, committeePubkeys);
+
+  if (committeePubkeys.length > 0) {
+    const penaltyEvents = await nostr.query([{
+      kinds: [GOLF_KINDS.HANDICAP_PENALTY],
+      authors: committeePubkeys,
+      '#p': [userPubkey],
+      limit: 50,
+    }], { signal: AbortSignal.any([signal, AbortSignal.timeout(10000)]) });
+
+    for (const event of penaltyEvents) {
+      const penalty = parseHandicapPenaltyEvent(event);
+      if (penalty) differentials.push(penalty);
+    }
+  }
+
+  return differentials;
+}
+
+/**
+ * Re-cap each round's hole scores at net double bogey using the course
+ * handicap from the index the provisionally capped rounds give
+ */
+function capAtNetDoubleBogey(differentials: RoundDifferential[], roundHoles: Map<string, AdjustedHoleInput[]>): void {
+  const recent = [...differentials].sort((a, b) => b.date - a.date).slice(0, 20);
+  const index = calculateHandicapIndex(recent).index;
+  if (index === null) return;
+
+  for (const d of differentials) {
+    const holes = roundHoles.get(d.roundId);
+    if (!holes) continue;
+
+    // Nine-hole rounds play off half the index against the nine's rating and par
+    const par = holes.reduce((sum, h) => sum + h.par, 0);
+    const courseHandicap = calculateCourseHandicap(d.holes === 9 ? index / 2 : index, {
+      courseRating: d.courseRating,
+      slopeRating: d.slope,
+      par,
+    });
+    d.gross = calculateAdjustedGrossScore(holes, courseHandicap).adjustedGross;
+
+    if (d.holes === 9) {
+      d.nineHoleDifferential = calculateDifferential(d.gross, d.courseRating, d.slope);
+      d.differential = d.nineHoleDifferential * 2;
+    } else {
+      d.differential = calculateDifferential(d.gross, d.courseRating, d.slope);
+    }
+  }
+}
+
+interface RoundInfo {
+  roundKey: string; // the round id the score event is addressed by
+  holesPlayed: number;
+  finishedAt: number;
+  course: GeoPoint[];
+  scores: { [hole: number]: number };
+}
+
+/**
+ * Gather each round's tee time and players (from the host's latest round event), GPS track (from recorded shots),
+ * marker attestations and committee rulings, then run the plausibility checks,
+ * resolve the attestation status and apply any ruling
+ */
+async function attachRoundChecks(
+  nostr: NostrLike,
+  userPubkey: string,
+  signal: AbortSignal,
+  differentials: RoundDifferential[],
+  evidence: Map<string, RoundInfo>,
+  committeePubkeys: string[]
+): Promise<void> {
+  const roundKeys = [...new Set([...evidence.values()].map(e => e.roundKey))];
+  if (roundKeys.length === 0) return;
+
+  const events = await nostr.query([
+    { kinds: [GOLF_KINDS.ROUND], '#d': roundKeys },
+    { kinds: [GOLF_KINDS.ROUND], '#round-id': roundKeys },
+    { kinds: [GOLF_KINDS.SHOT], authors: [userPubkey], '#round': roundKeys, limit: 2000 },
+    { kinds: [GOLF_KINDS.SCORE_ATTESTATION], '#p': [userPubkey], '#round': roundKeys },
+    ...(committeePubkeys.length > 0
+      ? [{ kinds: [GOLF_KINDS.RULING], authors: committeePubkeys, '#p': [userPubkey] }]
+      : []),
+  ], { signal: AbortSignal.any([signal, AbortSignal.timeout(10000)]) });
+
+  const teeTimes = new Map<string, number>();
+  const players = new Map<string, string[]>();
+  const tracks = new Map<string, { track: GeoPoint[]; firstShot: number }>();
+  const attestations: ScoreAttestation[] = [];
+  const rulingList: Ruling[] = [];
+  for (const [roundKey, event] of hostedRounds(events.filter(e => e.kind === GOLF_KINDS.ROUND))) {
+    const teeTime = parseInt(event.tags.find(t => t[0] === 'tee-time')?.[1] || '');
+    if (!isNaN(teeTime)) teeTimes.set(roundKey, teeTime * 1000);
+    players.set(roundKey, event.tags.find(t => t[0] === 'players')?.slice(1) ?? []);
+  }
+
+  for (const event of events) {
+    if (event.kind === GOLF_KINDS.ROUND) continue;
+
+    if (event.kind === GOLF_KINDS.SCORE_ATTESTATION) {
+      const attestation = parseAttestationEvent(event);
+      if (attestation) attestations.push(attestation);
+      continue;
+    }
+
+    if (event.kind === GOLF_KINDS.RULING) {
+      const ruling = parseRulingEvent(event);
+      if (ruling) rulingList.push(ruling);
+      continue;
+    }
+
+    const shot = parseShotEvent(event);
+    if (!shot?.roundId) continue;
+    const entry = tracks.get(shot.roundId) ?? { track: [], firstShot: shot.timestamp };
+    if (shot.start) entry.track.push(shot.start);
+    if (shot.end) entry.track.push(shot.end);
+    entry.firstShot = Math.min(entry.firstShot, shot.timestamp);
+    tracks.set(shot.roundId, entry);
+  }
+
+  const rulings = latestRulings(rulingList, committeePubkeys);
+
+  for (const round of differentials) {
+    const info = evidence.get(round.roundId);
+    if (!info) continue;
+
+    const recorded = tracks.get(info.roundKey);
+    const roundEvidence: RoundEvidence = {
+      holesPlayed: info.holesPlayed,
+      startedAt: teeTimes.get(info.roundKey) ?? recorded?.firstShot,
+      finishedAt: info.finishedAt,
+      track: recorded?.track ?? [],
+      course: info.course,
+    };
+    const flags = checkRoundEvidence(roundEvidence);
+    if (flags.length > 0) round.integrityFlags = flags;
+
+    round.attestation = resolveAttestation(
+      { roundId: info.roundKey, playerPubkey: userPubkey, scores: info.scores },
+      attestations,
+      players.get(info.roundKey) ?? []
+    ).status;
+
+    const ruling = rulings.get(`${info.roundKey}:${userPubkey}`);
+    if (ruling) {
+      round.ruling = ruling.decision;
+      const total = (scores: { [hole: number]: number }) => Object.values(scores).reduce((sum, s) => sum + s, 0);
+      const delta = total(applyRuling(userPubkey, info.scores, ruling)) - total(info.scores);
+      if (ruling.decision === 'corrected' && delta !== 0) {
+        round.gross += delta;
+        if (round.holes === 9) {
+          round.nineHoleDifferential = calculateDifferential(round.gross, round.courseRating, round.slope);
+          round.differential = round.nineHoleDifferential * 2;
+        } else {
+          round.differential = calculateDifferential(round.gross, round.courseRating, round.slope);
+        }
+      }
+    }
+  }
+}
+
+/**
+ * Rounds whose attestation status lets them count (penalty scores always do).
+ * A committee ruling overrides the attestation: voided cards never count, and
+ * dismissed or corrected ones do. Simulator rounds count only with `includeIndoor`.
+ */
+export function countedDifferentials(
+  differentials: RoundDifferential[],
+  requireAttestation: boolean,
+  includeIndoor = false
+): RoundDifferential[] {
+  return differentials.filter(d => {
+    if (d.source === 'penalty') return true;
+    if (d.indoor && !includeIndoor) return false;
+    if (d.ruling) return d.ruling !== 'void';
+    return countsForPlay(d.attestation ?? 'pending', requireAttestation);
+  });
+}
+
+/**
+ * A handicap index from a player's differentials: only counted rounds, less
+ * those held for review, with exceptional score reductions over the latest 20
+ */
+export function handicapFromDifferentials(
+  differentials: RoundDifferential[],
+  requireAttestation: boolean,
+  includeIndoor = false
+): HandicapResult {
+  const { accepted, held } = holdForReview(countedDifferentials(differentials, requireAttestation, includeIndoor));
+
+  // Sorted most recent first; take last 20
+  const recent20 = applyExceptionalScoreReductions(accepted).slice(0, 20);
+
+  return {
+    ...calculateHandicapIndex(recent20),
+    heldForReview: [...held, ...differentials.filter(d => d.attestation === 'disputed' && !d.ruling)],
+    awaitingAttestation: differentials.filter(d => d.attestation === 'pending' && requireAttestation && !d.ruling),
+  };
 }
 
 /**
@@ -22,12 +392,23 @@ interface PlayerScoreContent {
  * 
  * Queries the user's last 20 completed rounds and calculates their handicap index
  * using progressive thresholds (best 2 of 5, best 3 of 10, best 8 of 20).
+ * Exceptional score reductions are applied, along with penalty scores from
+ * any trusted handicap committees. Implausible rounds are held for committee
+ * review instead of counting. Disputed cards never count; with
+ * `requireAttestation`, only cards countersigned by a marker do. Simulator
+ * rounds are left out unless `includeIndoor` is set.
  */
-export function useHandicapCalculation(userPubkey: string | undefined) {
+export function useHandicapCalculation(
+  userPubkey: string | undefined,
+  committeePubkeys: string[] = [],
+  options: { requireAttestation?: boolean; includeIndoor?: boolean } = {}
+) {
   const { nostr } = useNostr();
+  const requireAttestation = options.requireAttestation ?? false;
+  const includeIndoor = options.includeIndoor ?? false;
 
   return useQuery<HandicapResult>({
-    queryKey: ['handicap-calculation', userPubkey],
+    queryKey: ['handicap-calculation', userPubkey, committeePubkeys, requireAttestation, includeIndoor],
     queryFn: async ({ signal }) => {
       if (!userPubkey) {
         return {
@@ -41,92 +422,8 @@ export function useHandicapCalculation(userPubkey: string | undefined) {
         };
       }
 
-      // Query user's PLAYER_SCORE events (their scorecards)
-      const scoreEvents = await nostr.query([{
-        kinds: [GOLF_KINDS.PLAYER_SCORE],
-        authors: [userPubkey],
-        limit: 20,
-      }], { signal: AbortSignal.any([signal, AbortSignal.timeout(10000)]) });
-
-      // Also query for the courses to get ratings/slopes
-      const courseEvents = await nostr.query([{
-        kinds: [GOLF_KINDS.COURSE],
-        '#t': ['golf-course'],
-        limit: 100,
-      }], { signal: AbortSignal.any([signal, AbortSignal.timeout(10000)]) });
-
-      // Build course lookup by name/id
-      const courseMap = new Map<string, { rating: number; slope: number; name: string }>();
-      for (const ce of courseEvents) {
-        try {
-          const content = JSON.parse(ce.content || '{}');
-          const name = content.name || ce.tags?.find(t => t[0] === 'name')?.[1] || '';
-          const rating = parseFloat(ce.tags?.find(t => t[0] === 'rating')?.[1] || '') || DEFAULT_COURSE_RATING;
-          const slope = parseInt(ce.tags?.find(t => t[0] === 'slope')?.[1] || '') || DEFAULT_SLOPE;
-          const id = ce.tags?.find(t => t[0] === 'd')?.[1] || ce.id;
-          
-          if (name) courseMap.set(name.toLowerCase(), { rating, slope, name });
-          if (id) courseMap.set(id, { rating, slope, name });
-        } catch {
-          // Skip invalid course events
-        }
-      }
-
-      // Convert score events to differentials
-      const differentials: RoundDifferential[] = [];
-
-      for (const event of scoreEvents) {
-        try {
-          const content: PlayerScoreContent = JSON.parse(event.content || '{}');
-          
-          // Skip incomplete rounds (need gross score)
-          if (!content.gross && (!content.holes || content.holes.length < 9)) {
-            continue;
-          }
-
-          // Calculate gross from holes if not provided
-          const gross = content.gross || 
-            (content.holes?.reduce((sum, h) => sum + (h.strokes || 0), 0) || 0);
-
-          if (gross < 40) continue; // Invalid score
-
-          // Try to find course info from the round
-          let courseRating = DEFAULT_COURSE_RATING;
-          let slope = DEFAULT_SLOPE;
-          let courseName = 'Unknown Course';
-
-          // Look up course by various means
-          const courseTag = event.tags?.find(t => t[0] === 'course')?.[1];
-          if (courseTag) {
-            const courseInfo = courseMap.get(courseTag.toLowerCase()) || courseMap.get(courseTag);
-            if (courseInfo) {
-              courseRating = courseInfo.rating;
-              slope = courseInfo.slope;
-              courseName = courseInfo.name;
-            }
-          }
-
-          const differential = calculateDifferential(gross, courseRating, slope);
-
-          differentials.push({
-            roundId: event.id,
-            date: event.created_at * 1000,
-            gross,
-            courseRating,
-            slope,
-            differential,
-            courseName,
-          });
-        } catch {
-          // Skip invalid events
-        }
-      }
-
-      // Sort by date (most recent first) and take last 20
-      differentials.sort((a, b) => b.date - a.date);
-      const recent20 = differentials.slice(0, 20);
-
-      return calculateHandicapIndex(recent20);
+      const differentials = await fetchRoundDifferentials(nostr, userPubkey, signal, 20, committeePubkeys);
+      return handicapFromDifferentials(differentials, requireAttestation, includeIndoor);
     },
     enabled: !!userPubkey,
     staleTime: 5 * 60 * 1000, // 5 minutes
@@ -0,0 +1,65 @@
+import { useNostr } from '@nostrify/react';
+import { useEffect } from 'react';
+import { useQuery, useQueryClient } from '@tanstack/react-query';
+import { buildHandicapHistory, type HandicapRevision } from '@/lib/golf/handicapCalculator';
+import { holdForReview } from '@/lib/golf/integrityEngine';
+import { scheduler } from '@/lib/scheduler/scheduler';
+import { useCurrentUser } from './useCurrentUser';
+import { countedDifferentials, fetchRoundDifferentials } from './useHandicapCalculation';
+
+/**
+ * Hook to rebuild a player's handicap index revision history.
+ *
+ * Every revision is replayed from the player's published PLAYER_SCORE events,
+ * so anyone (e.g. a handicap committee) can audit which differentials produced
+ * each index. Rounds held for committee review, and disputed cards, are left
+ * out, as are unattested cards with `requireAttestation`. Returned newest first.
+ */
+export function useHandicapHistory(
+  userPubkey: string | undefined,
+  committeePubkeys: string[] = [],
+  options: { requireAttestation?: boolean } = {}
+) {
+  const { nostr } = useNostr();
+  const requireAttestation = options.requireAttestation ?? false;
+
+  return useQuery<HandicapRevision[]>({
+    queryKey: ['handicap-history', userPubkey, committeePubkeys, requireAttestation],
+    queryFn: async ({ signal }) => {
+      if (!userPubkey) return [];
+
+      const differentials = await fetchRoundDifferentials(nostr, userPubkey, signal, 500, committeePubkeys);
+      return buildHandicapHistory(holdForReview(countedDifferentials(differentials, requireAttestation)).accepted).reverse();
+    },
+    enabled: !!userPubkey,
+    staleTime: 5 * 60 * 1000, // 5 minutes
+    gcTime: 30 * 60 * 1000, // 30 minutes
+  });
+}
+
+/**
+ * Registers the nightly handicap revision job: the logged-in player's index
+ * and revision history are recomputed from their latest rounds, as the WHS
+ * revises indexes once a day
+ */
+export function useHandicapRevisionJob() {
+  const queryClient = useQueryClient();
+  const { user } = useCurrentUser();
+  const pubkey = user?.pubkey;
+
+  useEffect(() => {
+    if (!pubkey) return;
+
+    return scheduler.register({
+      id: 'handicap-revision',
+      name: 'Handicap revision',
+      schedule: '0 0 * * *',
+      run: async () => {
+        await Promise.all([
+          queryClient.invalidateQueries({ queryKey: ['handicap-calculation', pubkey] }),
+          queryClient.invalidateQueries({ queryKey: ['handicap-history', pubkey] }),
+        ]);
+      },
+    });
+  }, [queryClient, pubkey]);
+}
@@ -0,0 +1,12 @@
+import { useAppContext } from './useAppContext';
+import { DEFAULT_HANDICAP_SETTINGS, type HandicapSettings } from '@/lib/golf/handicapCalculator';
+
+/**
+ * The handicap settings: which handicap committees' penalty scores and
+ * rulings count towards the player's index, and whether cards need a
+ * marker's countersignature to count
+ */
+export function useHandicapSettings(): HandicapSettings {
+  const { config } = useAppContext();
+  return config.handicap ?? DEFAULT_HANDICAP_SETTINGS;
+}
@@ -0,0 +1,78 @@
+import { useEffect, useRef, useState } from 'react';
+import type { GeoPoint } from '@/lib/golf/caddieEngine';
+import {
+  createHoleTracker,
+  updateHoleTracker,
+  type DetectionHole,
+  type HoleDetectionConfig,
+  type HoleTransition,
+} from '@/lib/golf/holeDetection';
+
+const YARDS_PER_METRE = 1.09361;
+
+interface UseHoleDetectionOptions {
+  enabled?: boolean;
+  startingHole?: number | null;
+  config?: HoleDetectionConfig;
+  onTransition?: (transition: HoleTransition) => void; // e.g. advance the scorecard
+}
+
+/**
+ * Hook that watches the device's GPS and tracks which hole the player is on.
+ * Greens (and tees, when known) come from the course record.
+ */
+export function useHoleDetection(holes: DetectionHole[], options: UseHoleDetectionOptions = {}) {
+  const { enabled = true, startingHole = null, config } = options;
+  const [currentHole, setCurrentHole] = useState<number | null>(startingHole);
+  const [position, setPosition] = useState<GeoPoint | null>(null);
+  const [error, setError] = useState<string | null>(null);
+
+  // Keep the latest inputs in refs so the GPS watch isn't restarted on every render
+  const stateRef = useRef(createHoleTracker(startingHole));
+  const holesRef = useRef(holes);
+  const configRef = useRef(config);
+  const onTransitionRef = useRef(options.onTransition);
+  holesRef.current = holes;
+  configRef.current = config;
+  onTransitionRef.current = options.onTransition;
+
+  useEffect(() => {
+    if (!enabled || typeof navigator === 'undefined' || !navigator.geolocation) return;
+
+    const watchId = navigator.geolocation.watchPosition(
+      (position) => {
+        const { state, transition } = updateHoleTracker(stateRef.current, holesRef.current, {
+          lat: position.coords.latitude,
+          lon: position.coords.longitude,
+          accuracy: position.coords.accuracy * YARDS_PER_METRE,
+          timestamp: position.timestamp,
+        }, configRef.current);
+
+        stateRef.current = state;
+        setPosition({ lat: position.coords.latitude, lon: position.coords.longitude });
+        if (transition) {
+          setCurrentHole(transition.to);
+          onTransitionRef.current?.(transition);
+        }
+      },
+      (err) => setError(err.message),
+      { enableHighAccuracy: true, maximumAge: 5000 }
+    );
+
+    return () => navigator.geolocation.clearWatch(watchId);
+  }, [enabled]);
+
+  // Manual override, e.g. when the player corrects the scorecard
+  const setHole = (hole: number) => {
+    stateRef.current = { ...createHoleTracker(hole), transitions: stateRef.current.transitions };
+    setCurrentHole(hole);
+  };
+
+  return {
+    currentHole,
+    position, // latest fix, e.g. for distances to the green
+    transitions: stateRef.current.transitions,
+    setHole,
+    error,
+  };
+}
@@ -0,0 +1,28 @@
+import { useMemo } from 'react';
+import { useCourseScoreSamples } from './useCourseScoreSamples';
+import { calculateHoleStats, type HoleStatsConfig } from '@/lib/golf/holeStatsEngine';
+
+/**
+ * Hook for per-hole statistics at a course (average score, birdie rate,
+ * three-putt rate), aggregated from every round submitted there.
+ * Pass `hole` to get the stats for a single hole.
+ */
+export function useHoleStats(
+  courseName: string | undefined,
+  pars: { [hole: number]: number },
+  config: HoleStatsConfig = {},
+  hole?: number
+) {
+  const { data: records, isLoading, error } = useCourseScoreSamples(courseName);
+
+  const stats = useMemo(() => {
+    if (!records) return [];
+    return calculateHoleStats(records, pars, config);
+  }, [records, pars, config]);
+
+  return {
+    data: hole === undefined ? stats : stats.filter(s => s.hole === hole),
+    isLoading,
+    error,
+  };
+}
@@ -0,0 +1,32 @@
+import { useCurrentUser } from './useCurrentUser';
+import { useGolfBag } from './useGolfBag';
+import { usePracticeSessions } from './usePracticeSessions';
+import {
+  launchMonitorSession,
+  parseLaunchMonitorCsv,
+  type LaunchMonitorShot,
+} from '@/lib/golf/launchMonitorImport';
+import type { PracticeType } from '@/lib/golf/practiceEngine';
+import { DEFAULT_BAG } from '@/lib/golf/bagEngine';
+import { v4 as uuidv4 } from 'uuid';
+
+/**
+ * Hook for importing launch monitor CSV exports. `preview` parses an export
+ * against the player's bag; `apply` saves the matched shots as a practice
+ * session, whose carries then feed the bag's distance learning.
+ */
+export function useLaunchMonitorImport() {
+  const { user } = useCurrentUser();
+  const { data: bag } = useGolfBag(user?.pubkey);
+  const { saveSession, isSaving } = usePracticeSessions(user?.pubkey);
+
+  const preview = (text: string) => parseLaunchMonitorCsv(text, bag?.clubs ?? DEFAULT_BAG);
+
+  const apply = (shots: LaunchMonitorShot[], type: PracticeType = 'range') => {
+    const session = launchMonitorSession(shots, uuidv4(), type);
+    if (session.blocks.length === 0) throw new Error('No shots matched a club in your bag');
+    return saveSession(session);
+  };
+
+  return { preview, apply, isApplying: isSaving };
+}
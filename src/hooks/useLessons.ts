@@ -0,0 +1,260 @@
+import { useEffect, useRef } from 'react';
+import { useMutation, useQuery, useQueryClient } from '@tanstack/react-query';
+import { useNostr } from '@nostrify/react';
+import type { NostrEvent } from '@nostrify/nostrify';
+import { useCurrentUser } from './useCurrentUser';
+import { useNostrPublish } from './useNostrPublish';
+import { useToast } from './useToast';
+import { GOLF_KINDS } from '@/lib/golf/types';
+import {
+  createLessonBookingEvent,
+  createLessonSlotEvent,
+  isExpired,
+  parseLessonBookingEvent,
+  parseLessonSlotEvent,
+} from '@/lib/golf/nostrEvents';
+import {
+  dueReminders,
+  openSlots,
+  upcomingLessons,
+  type LessonBooking,
+  type LessonSlot,
+} from '@/lib/golf/lessonEngine';
+import { scheduler } from '@/lib/scheduler/scheduler';
+import { v4 as uuidv4 } from 'uuid';
+
+interface LessonData {
+  slots: LessonSlot[];
+  bookings: LessonBooking[];
+  slotEvents: Record<string, NostrEvent>; // by slot id, for paying the pro
+}
+
+/** Latest version of each slot and booking, leaving out expired slots */
+function collect(events: NostrEvent[]): LessonData {
+  const slots = new Map<string, { slot: LessonSlot; event: NostrEvent }>();
+  const bookings = new Map<string, LessonBooking>();
+
+  for (const event of events) {
+    const slot = isExpired(event) ? null : parseLessonSlotEvent(event);
+    if (slot) {
+      const key = `${slot.pro}:${slot.slotId}`;
+      const existing = slots.get(key);
+      if (!existing || slot.createdAt > existing.slot.createdAt) slots.set(key, { slot, event });
+    }
+    const booking = parseLessonBookingEvent(event);
+    if (booking) {
+      const key = `${booking.member}:${booking.bookingId}`;
+      const existing = bookings.get(key);
+      if (!existing || booking.createdAt > existing.createdAt) bookings.set(key, booking);
+    }
+  }
+
+  return {
+    slots: [...slots.values()].map(s => s.slot),
+    bookings: [...bookings.values()],
+    slotEvents: Object.fromEntries([...slots.values()].map(s => [s.slot.slotId, s.event])),
+  };
+}
+
+// Slots more than a day old are of no interest
+const since = () => Math.floor(Date.now() / 1000) - 24 * 60 * 60;
+
+function useLessonPublishing(invalidate: () => void) {
+  const { user } = useCurrentUser();
+  const { mutateAsync: publishEvent } = useNostrPublish();
+
+  const publishSlot = useMutation({
+    mutationFn: async (slot: Omit<LessonSlot, 'createdAt' | 'pro'>) => {
+      if (!user) throw new Error('Log in to offer lessons');
+      const event = createLessonSlotEvent({ ...slot, pro: user.pubkey });
+      return publishEvent({ kind: event.kind, content: event.content, tags: event.tags, created_at: event.created_at });
+    },
+    onSuccess: invalidate,
+  });
+
+  const publishBooking = useMutation({
+    mutationFn: async (booking: Omit<LessonBooking, 'createdAt' | 'member'>) => {
+      if (!user) throw new Error('Log in to book a lesson');
+      if (booking.pro === user.pubkey) throw new Error("You can't book your own lesson");
+      const event = createLessonBookingEvent({ ...booking, member: user.pubkey });
+      return publishEvent({ kind: event.kind, content: event.content, tags: event.tags, created_at: event.created_at });
+    },
+    onSuccess: invalidate,
+  });
+
+  return {
+    offerSlot: (slot: Omit<LessonSlot, 'createdAt' | 'pro' | 'slotId' | 'cancelled'>) =>
+      publishSlot.mutateAsync({ ...slot, slotId: uuidv4(), cancelled: false }),
+    cancelSlot: (slot: LessonSlot) => publishSlot.mutateAsync({ ...slot, cancelled: true }),
+    book: (slot: LessonSlot, note = '') =>
+      publishBooking.mutateAsync({ bookingId: uuidv4(), slotId: slot.slotId, pro: slot.pro, note, cancelled: false }),
+    cancelBooking: (booking: LessonBooking) => publishBooking.mutateAsync({ ...booking, cancelled: true }),
+    isPublishing: publishSlot.status === 'pending' || publishBooking.status === 'pending',
+  };
+}
+
+/**
+ * A teaching pro's lesson slots and the bookings for them. Slots go to the
+ * first member to book.
+ */
+export function useProLessons(pro: string | undefined) {
+  const { nostr } = useNostr();
+  const queryClient = useQueryClient();
+  const queryKey = ['lessons', pro];
+
+  const query = useQuery<LessonData>({
+    queryKey,
+    queryFn: async (c) => {
+      const signal = AbortSignal.any([c.signal, AbortSignal.timeout(5000)]);
+      const events = await nostr.query([
+        { kinds: [GOLF_KINDS.LESSON_SLOT], authors: [pro!], since: since(), limit: 200 },
+        { kinds: [GOLF_KINDS.LESSON_BOOKING], '#p': [pro!], since: since(), limit: 500 },
+      ], { signal });
+      return collect(events);
+    },
+    enabled: !!pro,
+    staleTime: 60 * 1000,
+  });
+
+  const publishing = useLessonPublishing(() => {
+    queryClient.invalidateQueries({ queryKey });
+    queryClient.invalidateQueries({ queryKey: ['my-lessons'] });
+  });
+
+  const now = Date.now();
+  const slots = query.data?.slots ?? [];
+  const bookings = query.data?.bookings ?? [];
+
+  return {
+    open: openSlots(slots, bookings, now),
+    lessons: upcomingLessons(slots, bookings, now),
+    slotEvents: query.data?.slotEvents ?? {},
+    isLoading: query.isLoading,
+    ...publishing,
+  };
+}
+
+/**
+ * The logged-in user's upcoming lessons, both ones they teach and ones
+ * they've booked
+ */
+export function useMyLessons() {
+  const { nostr } = useNostr();
+  const { user } = useCurrentUser();
+  const queryClient = useQueryClient();
+  const pubkey = user?.pubkey;
+
+  const query = useQuery<LessonData>({
+    queryKey: ['my-lessons', pubkey],
+    queryFn: async (c) => {
+      const signal = AbortSignal.any([c.signal, AbortSignal.timeout(5000)]);
+      const events = await nostr.query([
+        { kinds: [GOLF_KINDS.LESSON_SLOT], authors: [pubkey!], since: since(), limit: 200 },
+        { kinds: [GOLF_KINDS.LESSON_BOOKING], '#p': [pubkey!], since: since(), limit: 500 },
+        { kinds: [GOLF_KINDS.LESSON_BOOKING], authors: [pubkey!], since: since(), limit: 100 },
+      ], { signal });
+
+      // Fetch the slots behind my bookings, and everyone else's bookings
+      // for them so first-come order is known
+      const mine = collect(events).bookings.filter(b => b.member === pubkey);
+      const pros = [...new Set(mine.map(b => b.pro))];
+      if (pros.length === 0) return collect(events);
+
+      const slotEvents = await nostr.query([
+        { kinds: [GOLF_KINDS.LESSON_SLOT], authors: pros, '#d': mine.map(b => b.slotId) },
+        { kinds: [GOLF_KINDS.LESSON_BOOKING], '#a': mine.map(b => `${GOLF_KINDS.LESSON_SLOT}:${b.pro}:${b.slotId}`) },
+      ], { signal });
+      return collect([...events, ...slotEvents]);
+    },
+    enabled: !!pubkey,
+    staleTime: 5 * 60 * 1000,
+  });
+
+  const publishing = useLessonPublishing(() => {
+    queryClient.invalidateQueries({ queryKey: ['my-lessons', pubkey] });
+    queryClient.invalidateQueries({ queryKey: ['lessons'] });
+  });
+
+  const slots = query.data?.slots ?? [];
+  const bookings = query.data?.bookings ?? [];
+  const lessons = upcomingLessons(slots, bookings, Date.now())
+    .filter(l => l.slot.pro === pubkey || l.booking.member === pubkey);
+
+  return {
+    lessons,
+    myOpenSlots: openSlots(slots.filter(s => s.pro === pubkey), bookings, Date.now()),
+    slotEvents: query.data?.slotEvents ?? {},
+    isLoading: query.isLoading,
+    ...publishing,
+  };
+}
+
+/**
+ * Tell the pro when a lesson is booked, and remind both sides shortly
+ * before it starts. Reminders run on the in-app scheduler while the app
+ * is open.
+ */
+export function useLessonNotifications() {
+  const { nostr } = useNostr();
+  const { user } = useCurrentUser();
+  const { toast } = useToast();
+  const queryClient = useQueryClient();
+  const { lessons } = useMyLessons();
+  const reminded = useRef(new Set<string>());
+  const pubkey = user?.pubkey;
+
+  // The reminder job reads the latest lessons without being re-registered
+  const lessonsRef = useRef(lessons);
+  lessonsRef.current = lessons;
+
+  useEffect(() => {
+    if (!pubkey) return;
+    const controller = new AbortController();
+
+    (async () => {
+      try {
+        const subscription = nostr.req([
+          { kinds: [GOLF_KINDS.LESSON_BOOKING], '#p': [pubkey], since: Math.floor(Date.now() / 1000) },
+        ], { signal: controller.signal });
+
+        for await (const msg of subscription) {
+          if (msg[0] === 'CLOSED') break;
+          if (msg[0] !== 'EVENT') continue;
+
+          const booking = parseLessonBookingEvent(msg[2]);
+          if (!booking || booking.member === pubkey) continue;
+          toast({ title: booking.cancelled ? 'A lesson booking was cancelled' : 'New lesson booking', description: booking.note || undefined });
+          queryClient.invalidateQueries({ queryKey: ['my-lessons', pubkey] });
+          queryClient.invalidateQueries({ queryKey: ['lessons', pubkey] });
+        }
+      } catch (err) {
+        if (!controller.signal.aborted) console.warn('Lesson subscription ended', err);
+      }
+    })();
+
+    return () => controller.abort();
+  }, [nostr, pubkey, toast, queryClient]);
+
+  useEffect(() => {
+    if (!pubkey) return;
+
+    return scheduler.register({
+      id: 'lesson-reminders',
+      name: 'Lesson reminders',
+      schedule: '@every 1m',
+      runOnStart: true,
+      run: () => {
+        for (const lesson of dueReminders(lessonsRef.current, Date.now())) {
+          const key = `${lesson.slot.pro}:${lesson.slot.slotId}`;
+          if (reminded.current.has(key)) continue;
+          reminded.current.add(key);
+          const time = new Date(lesson.slot.startsAt).toLocaleTimeString([], { hour: '2-digit', minute: '2-digit' });
+          toast({
+            title: lesson.slot.pro === pubkey ? `You're teaching a lesson at ${time}` : `Your golf lesson starts at ${time}`,
+            description: lesson.slot.location || undefined,
+          });
+        }
+      },
+    });
+  }, [pubkey, toast]);
+}
@@ -0,0 +1,113 @@
+import { useEffect, useRef, useState } from 'react';
+import { useCurrentUser } from './useCurrentUser';
+import { useCourseNotices } from './useCourseNotices';
+import { useCourseAlerts } from './useCourseAlerts';
+import { useLocalStorage } from './useLocalStorage';
+import { scheduler } from '@/lib/scheduler/scheduler';
+import { UPSTREAM_SERVICES, upstreamFetch } from '@/lib/upstream/upstream';
+import { courseCentre } from '@/lib/golf/caddieEngine';
+import {
+  DEFAULT_LIGHTNING_WATCH,
+  LIGHTNING_ALL_CLEAR_MINUTES,
+  nearestStrikeKm,
+  parseXweatherLightning,
+  planLightningWatch,
+  suspensionEnd,
+  xweatherLightningUrl,
+  type LightningWatchSettings,
+} from '@/lib/golf/lightningEngine';
+import type { GolfCourse } from './useGolfCourses';
+
+export interface LightningCheck {
+  at: number; // ms
+  nearestKm: number | null;
+  resumeAt: number | null; // ms
+}
+
+/**
+ * Lightning watch for the club account. While enabled, checks the strike
+ * feed every minute; when lightning comes inside the radius it closes the
+ * course with a notice (which also pauses pace of play) and alerts everyone
+ * on the course, then gives the all clear 30 minutes after the last strike.
+ * Runs on the club's device while the hook is mounted; the API credentials
+ * never leave it.
+ */
+export function useLightningWatch(course: Pick<GolfCourse, 'id' | 'author' | 'name' | 'greens'> | null | undefined) {
+  const { user } = useCurrentUser();
+  const { notices, postNotice } = useCourseNotices(course);
+  const { alerts, broadcast, clear } = useCourseAlerts(course);
+  const [allSettings, setAllSettings] = useLocalStorage<Record<string, LightningWatchSettings>>('lightning-watch', {});
+  const [lastCheck, setLastCheck] = useState<LightningCheck | null>(null);
+  const [error, setError] = useState<string | null>(null);
+
+  const settings = (course && allSettings[course.id]) || DEFAULT_LIGHTNING_WATCH;
+  const centre = courseCentre(course?.greens);
+  const isClub = !!course && user?.pubkey === course.author;
+  const active = isClub && settings.enabled && !!centre && !!settings.clientId && !!settings.clientSecret;
+
+  // The job reads the latest state without being re-registered on every render
+  const latest = useRef({ notices, alerts, postNotice, broadcast, clear });
+  latest.current = { notices, alerts, postNotice, broadcast, clear };
+
+  useEffect(() => {
+    if (!active || !course || !centre) return;
+
+    return scheduler.register({
+      id: `lightning-watch:${course.id}`,
+      name: `Lightning watch (${course.name})`,
+      schedule: '@every 1m',
+      runOnStart: true,
+      run: async () => {
+        try {
+          const response = await upstreamFetch(UPSTREAM_SERVICES.lightning, xweatherLightningUrl(centre, settings));
+          const strikes = parseXweatherLightning(await response.json());
+          const now = Date.now();
+          const resumeAt = suspensionEnd(strikes, centre, settings.radiusKm);
+          setLastCheck({ at: now, nearestKm: nearestStrikeKm(strikes, centre), resumeAt });
+          setError(null);
+
+          const { notices, alerts, postNotice, broadcast, clear } = latest.current;
+          for (const action of planLightningWatch(resumeAt, notices, alerts, course.author, now)) {
+            if (action.type === 'suspend') {
+              await postNotice({
+                noticeId: action.id,
+                type: 'closure',
+                title: 'Lightning suspension',
+                details: `Play suspended: lightning within ${settings.radiusKm} km. Play resumes ${LIGHTNING_ALL_CLEAR_MINUTES} minutes after the last strike.`,
+                startsAt: now,
+                endsAt: action.until,
+                holes: [],
+                cancelled: false,
+              });
+              await broadcast({
+                alertId: action.id,
+                type: 'lightning',
+                message: 'Lightning nearby. Play is suspended; leave the course or take shelter now.',
+              });
+            } else if (action.type === 'extend') {
+              await postNotice({ ...action.notice, endsAt: action.until });
+            } else {
+              await clear(action.alert);
+            }
+          }
+        } catch (err) {
+          setError(err instanceof Error ? err.message : 'Lightning check failed');
+          throw err;
+        }
+      },
+    });
+    // Restart the job when the course or settings change; everything else is read through the ref
+    // eslint-disable-next-line react-hooks/exhaustive-deps
+  }, [active, course?.id, course?.name, course?.author, centre?.lat, centre?.lon, settings.radiusKm, settings.clientId, settings.clientSecret]);
+
+  return {
+    settings,
+    saveSettings: (next: LightningWatchSettings) => {
+      if (course) setAllSettings(prev => ({ ...prev, [course.id]: next }));
+    },
+    hasCentre: !!centre,
+    active,
+    lastCheck,
+    error,
+  };
+}
@@ -0,0 +1,73 @@
+import { useEffect, useRef, useState } from 'react';
+import { useNostr } from '@nostrify/react';
+import type { NostrEvent } from '@nostrify/nostrify';
+import {
+  EMPTY_CURSOR,
+  advanceCursor,
+  reconnectDelay,
+  roundFilters,
+  type ScoringCursor,
+} from '@/lib/sync/liveScoring';
+
+const cursorKey = (roundId: string) => `live-scoring-cursor:${roundId}`;
+
+function loadCursor(roundId: string): ScoringCursor {
+  try {
+    const stored = localStorage.getItem(cursorKey(roundId));
+    return stored ? JSON.parse(stored) : EMPTY_CURSOR;
+  } catch {
+    return EMPTY_CURSOR;
+  }
+}
+
+/**
+ * Live score cards and joins for a round over the relays' WebSocket, in
+ * place of polling. The cursor is kept per round, so a dropped connection
+ * or a reload resumes where it left off instead of missing updates.
+ */
+export function useLiveScoring(roundId: string | undefined, onEvent: (event: NostrEvent) => void) {
+  const { nostr } = useNostr();
+  const [connected, setConnected] = useState(false);
+
+  // The subscription calls the latest handler without restarting on every render
+  const onEventRef = useRef(onEvent);
+  onEventRef.current = onEvent;
+
+  useEffect(() => {
+    if (!roundId) return;
+    const controller = new AbortController();
+    let cursor = loadCursor(roundId);
+
+    (async () => {
+      let attempt = 0;
+      while (!controller.signal.aborted) {
+        try {
+          const subscription = nostr.req(roundFilters(roundId, cursor, Date.now()), { signal: controller.signal });
+          for await (const msg of subscription) {
+            if (msg[0] === 'CLOSED') break;
+            if (msg[0] === 'EOSE') {
+              setConnected(true);
+              attempt = 0;
+            }
+            if (msg[0] === 'EVENT') {
+              const result = advanceCursor(cursor, msg[2]);
+              cursor = result.cursor;
+              localStorage.setItem(cursorKey(roundId), JSON.stringify(cursor));
+              if (result.isNew) onEventRef.current(msg[2]);
+            }
+          }
+        } catch (err) {
+          if (controller.signal.aborted) return;
+          console.warn('Live scoring subscription dropped', err);
+        }
+
+        setConnected(false);
+        await new Promise(resolve => setTimeout(resolve, reconnectDelay(attempt++)));
+      }
+    })();
+
+    return () => controller.abort();
+  }, [nostr, roundId]);
+
+  return { connected };
+}
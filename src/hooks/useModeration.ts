@@ -0,0 +1,76 @@
+import { useMutation, useQuery, useQueryClient } from '@tanstack/react-query';
+import { useNostr } from '@nostrify/react';
+import type { NostrEvent } from '@nostrify/nostrify';
+import { useCurrentUser } from './useCurrentUser';
+import { useNostrPublish } from './useNostrPublish';
+import { mutedPubkeys, reportTags, updateMuteTags, type ReportType } from '@/lib/golf/moderationEngine';
+
+/**
+ * The logged-in member's NIP-51 mute list, with `mute` and `unmute` that
+ * republish it. Private (encrypted) entries in the list are carried over
+ * untouched.
+ */
+export function useMuteList() {
+  const { nostr } = useNostr();
+  const { user } = useCurrentUser();
+  const { mutateAsync: publishEvent } = useNostrPublish();
+  const queryClient = useQueryClient();
+  const queryKey = ['mute-list', user?.pubkey];
+
+  const list = useQuery<NostrEvent | null>({
+    queryKey,
+    queryFn: async (c) => {
+      const signal = AbortSignal.any([c.signal, AbortSignal.timeout(5000)]);
+      const lists = await nostr.query([{ kinds: [10000], authors: [user!.pubkey], limit: 1 }], { signal });
+      return lists.sort((a, b) => b.created_at - a.created_at)[0] ?? null;
+    },
+    enabled: !!user?.pubkey,
+    staleTime: 10 * 60 * 1000,
+  });
+
+  const update = useMutation({
+    mutationFn: async ({ pubkey, muted }: { pubkey: string; muted: boolean }) => {
+      if (!user) throw new Error('Log in to manage your mute list');
+      // Start from the latest list on the relays so other clients' changes aren't lost
+      const signal = AbortSignal.timeout(5000);
+      const [latest] = await nostr.query([{ kinds: [10000], authors: [user.pubkey], limit: 1 }], { signal });
+      const current = latest ?? list.data;
+      return publishEvent({
+        kind: 10000,
+        content: current?.content ?? '',
+        tags: updateMuteTags(current?.tags ?? [], pubkey, muted),
+        created_at: Math.floor(Date.now() / 1000),
+      });
+    },
+    onSuccess: (event) => {
+      queryClient.setQueryData(queryKey, event);
+    },
+  });
+
+  return {
+    muted: mutedPubkeys(list.data),
+    isLoading: list.isLoading,
+    mute: (pubkey: string) => update.mutateAsync({ pubkey, muted: true }),
+    unmute: (pubkey: string) => update.mutateAsync({ pubkey, muted: false }),
+    isUpdating: update.status === 'pending',
+  };
+}
+
+/**
+ * Report a person or one of their events (NIP-56). Reports from club admins
+ * hide the content for everyone using them in their spam filter.
+ */
+export function useReport() {
+  const { mutateAsync: publishEvent } = useNostrPublish();
+
+  return useMutation({
+    mutationFn: async ({ type, target, reason }: { type: ReportType; target: { pubkey: string; id?: string }; reason?: string }) => {
+      return publishEvent({
+        kind: 1984,
+        content: reason?.trim() ?? '',
+        tags: reportTags(type, target),
+        created_at: Math.floor(Date.now() / 1000),
+      });
+    },
+  });
+}
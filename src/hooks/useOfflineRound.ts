@@ -3,9 +3,26 @@ import { db, HoleScore } from '@/lib/offline/db';
 import { useNostr } from '@nostrify/react';
 import { useCurrentUser } from './useCurrentUser';
 import { enqueueOutboxEvent, publishOutboxOnce } from '@/lib/sync/outbox';
+import { latestEvent, reconcileRound, remoteHoleScores, resolveHoleScores } from '@/lib/sync/reconcile';
+import { parsePlayerScoreEvent } from '@/lib/golf/nostrEvents';
+import { scoreDigest } from '@/lib/golf/attestationEngine';
+import { scheduler } from '@/lib/scheduler/scheduler';
+import { versionTag } from '@/lib/sync/liveScoring';
 import { GOLF_KINDS } from '@/lib/golf/types';
 import { v4 as uuidv4 } from 'uuid';
 
+const DEVICE_ID_KEY = 'offline:device-id';
+
+// Identifies this device's edits when scores from several devices are reconciled
+function localDeviceId(): string {
+  let id = localStorage.getItem(DEVICE_ID_KEY);
+  if (!id) {
+    id = uuidv4();
+    localStorage.setItem(DEVICE_ID_KEY, id);
+  }
+  return id;
+}
+
 export function useOfflineRound() {
   const { nostr } = useNostr();
   const [connected, setConnected] = useState<boolean>(true);
@@ -48,6 +65,17 @@ export function useOfflineRound() {
     }
   }, [connected, nostr, user]);
 
+  useEffect(() => {
+    // Periodically retry the outbox while the app is open, in case online events were missed
+    if (!connected) return;
+    return scheduler.register({
+      id: 'outbox-sync',
+      name: 'Outbox sync',
+      schedule: '@every 1m',
+      run: () => publishOutboxOnce(nostr, user ?? null),
+    });
+  }, [connected, nostr, user]);
+
   useEffect(() => {
     // listen for messages from service worker to trigger outbox sync
     const onMessage = (ev: MessageEvent) => {
@@ -68,24 +96,82 @@ export function useOfflineRound() {
     };
   }, [nostr, user]);
 
-  const addHoleScore = async (roundId: string, playerPubkey: string, hole: number, strokes: number, deviceId: string) => {
-    const timestamp = Date.now();
-    const score: HoleScore = { roundId, playerPubkey, hole, strokes, timestamp, deviceId };
-    await db.holeScores.add(score);
+  // Queue the player's whole card: player score events are addressable per round,
+  // so publishing a single hole would replace the rest of the card on relays.
+  // Relays keep the card with the newest created_at, so each version is dated
+  // after the last one queued and after `after` (the relays' copy).
+  const enqueueScoreCard = async (roundId: string, playerPubkey: string, after = 0) => {
+    const scores = resolveHoleScores(
+      await db.holeScores.where('roundId').equals(roundId).and(s => s.playerPubkey === playerPubkey).toArray()
+    );
+    const card: Record<number, number> = {};
+    for (const score of scores) card[score.hole] = score.strokes;
 
-    // Create an outbox event - using GOLF_KINDS.PLAYER_SCORE as player score
-    const eventId = uuidv4();
+    const updatedAt = Math.max(...scores.map(s => s.timestamp));
+    const queued = await db.outbox.where('idempotencyKey').startsWith(`score:${roundId}:${playerPubkey}:`).toArray();
+    const previous = Math.max(after, ...queued.map(e => (e.payload as { created_at?: number }).created_at ?? 0));
     const payload = {
       kind: GOLF_KINDS.PLAYER_SCORE,
-      content: JSON.stringify({ roundId, playerPubkey, hole, strokes, timestamp, deviceId }),
-      tags: [['d', roundId], ['player', playerPubkey]]
+      content: JSON.stringify({ scores: card, updatedAt }),
+      tags: [['d', roundId], ['player', playerPubkey], versionTag()],
+      created_at: Math.max(previous + 1, Math.floor(Date.now() / 1000)),
     };
 
-    await enqueueOutboxEvent({ eventId, kind: GOLF_KINDS.PLAYER_SCORE, payload });
+    // Keyed on the card's latest edit, its scores and the relays' copy it
+    // supersedes, so a merge of older remote holes (or a card re-sent over a
+    // newer remote one) isn't mistaken for a card already sent
+    await enqueueOutboxEvent({
+      eventId: uuidv4(),
+      kind: GOLF_KINDS.PLAYER_SCORE,
+      payload,
+      idempotencyKey: `score:${roundId}:${playerPubkey}:${updatedAt}:${scoreDigest(card)}:${after}`,
+    });
+  };
+
+  const addHoleScore = async (roundId: string, playerPubkey: string, hole: number, strokes: number, deviceId = localDeviceId()) => {
+    const timestamp = Date.now();
+    const score: HoleScore = { roundId, playerPubkey, hole, strokes, timestamp, deviceId };
+    await db.holeScores.add(score);
+
+    await enqueueScoreCard(roundId, playerPubkey);
+  };
+
+  /**
+   * Reconcile this device's scores for a round with the relays' copy.
+   * The latest version of each hole wins; newer remote scores are stored
+   * locally and newer local scores are queued for publishing.
+   */
+  const syncRound = async (roundId: string) => {
+    const local = await db.holeScores.where('roundId').equals(roundId).toArray();
+
+    let remote: HoleScore[] = [];
+    let remoteCreatedAt = 0;
+    if (user) {
+      const events = await nostr.query([{
+        kinds: [GOLF_KINDS.PLAYER_SCORE],
+        authors: [user.pubkey],
+        '#d': [roundId],
+      }], { signal: AbortSignal.timeout(5000) });
+
+      const latest = latestEvent(events);
+      const record = latest ? parsePlayerScoreEvent(latest) : null;
+      if (record) remote = remoteHoleScores(record);
+      remoteCreatedAt = latest?.created_at ?? 0;
+    }
+
+    const result = reconcileRound(local, remote);
+    if (result.fromRemote.length > 0) {
+      await db.holeScores.bulkAdd(result.fromRemote);
+    }
+    for (const playerPubkey of result.toPublish) {
+      if (playerPubkey === user?.pubkey) await enqueueScoreCard(roundId, playerPubkey, remoteCreatedAt);
+    }
+
+    return result;
   };
 
   const getScoresForRound = async (roundId: string) => {
-    return db.holeScores.where('roundId').equals(roundId).toArray();
+    return resolveHoleScores(await db.holeScores.where('roundId').equals(roundId).toArray());
   };
 
   const flushOutbox = async () => {
@@ -97,6 +183,7 @@ export function useOfflineRound() {
     outboxCount,
     addHoleScore,
     getScoresForRound,
+    syncRound,
     flushOutbox,
   };
 }
@@ -0,0 +1,37 @@
+import { useQuery } from '@tanstack/react-query';
+import {
+  assembleOsmCourse,
+  buildOverpassQuery,
+  OVERPASS_URL,
+  type OsmArea,
+  type OverpassElement,
+} from '@/lib/golf/osmImport';
+import { UPSTREAM_SERVICES, upstreamFetch } from '@/lib/upstream/upstream';
+
+/**
+ * Hook to extract a course from OpenStreetMap for a bounding box or course
+ * relation. Pass the result through `osmToImportedCourse` and
+ * `useCourseImport().planCourses` to review it before publishing.
+ */
+export function useOsmCourse(area: OsmArea | null) {
+  return useQuery({
+    queryKey: ['osm-course', area],
+    queryFn: async (c) => {
+      const response = await upstreamFetch(UPSTREAM_SERVICES.overpass, OVERPASS_URL, {
+        method: 'POST',
+        body: new URLSearchParams({ data: buildOverpassQuery(area!) }),
+        signal: c.signal,
+      });
+
+      if (!response.ok) {
+        throw new Error(`Overpass request failed: ${response.status}`);
+      }
+
+      const data = await response.json() as { elements?: OverpassElement[] };
+      return assembleOsmCourse(data.elements ?? []);
+    },
+    enabled: !!area,
+    staleTime: 60 * 60 * 1000, // 1 hour
+    retry: 1,
+  });
+}
@@ -1,9 +1,13 @@
-import { useQuery, useMutation } from '@tanstack/react-query';
+import { useQuery, useMutation, useQueryClient } from '@tanstack/react-query';
 import { useNostr } from '@nostrify/react';
 import { useCurrentUser } from './useCurrentUser';
 import { useNostrPublish } from './useNostrPublish';
+import { useLiveScoring } from './useLiveScoring';
+import { resolveScoreCards } from './useScoringDelegations';
 import { GOLF_KINDS } from '@/lib/golf/types';
-import type { NostrEvent, NostrFilter } from '@nostrify/nostrify';
+import { LIVE_SCORING_VERSION, protocolVersion, versionTag } from '@/lib/sync/liveScoring';
+import { scoreCardFilters } from '@/lib/golf/delegationEngine';
+import type { NostrEvent } from '@nostrify/nostrify';
 
 type PlayerScorePayload = {
   scores: Record<string, number>;
@@ -14,13 +18,22 @@ type PlayerScorePayload = {
 
 /**
  * Hook to read and publish per-player scores for a round.
- * - Subscribes (query) to `GOLF_KINDS.PLAYER_SCORE` events for the given `roundId`.
+ * - Queries `GOLF_KINDS.PLAYER_SCORE` events for the given `roundId`, refreshed
+ *   over the live scoring subscription as cards change. A slow poll also runs,
+ *   since a card published late from an offline device is dated before the
+ *   subscription's cursor and never arrives over it; without a subscription
+ *   the poll is fast.
  * - Provides `publishScore` which requires the current user to be the player author.
  */
 export function usePlayerScores(roundId: string, playerPubkey?: string) {
   const { nostr } = useNostr();
   const { user } = useCurrentUser();
   const publishMutation = useNostrPublish();
+  const queryClient = useQueryClient();
+
+  const { connected } = useLiveScoring(roundId, (event) => {
+    if (event.kind === GOLF_KINDS.PLAYER_SCORE) queryClient.invalidateQueries({ queryKey: ['player-scores', roundId] });
+  });
 
   const query = useQuery({
     queryKey: ['player-scores', roundId, playerPubkey],
@@ -29,22 +42,19 @@ export function usePlayerScores(roundId: string, playerPubkey?: string) {
 
       const signal = AbortSignal.any([ctx.signal, AbortSignal.timeout(5000)]);
 
-      const filter = {
-        kinds: [GOLF_KINDS.PLAYER_SCORE],
-        '#d': [roundId],
-        limit: 200,
-      } as unknown as NostrFilter;
-
-      if (playerPubkey) filter.authors = [playerPubkey];
-
-      const events = await nostr.query([filter], { signal });
+      // Fetch every card for the round: a terminal-signed card isn't authored by its player
+      const events = await nostr.query(scoreCardFilters([roundId], { limit: 200 }), { signal });
+      // Cards posted by scoring terminals count for the player who delegated
+      const cards = await resolveScoreCards(nostr, events, signal);
 
-      // Keep the most recent event per author (pubkey)
+      // Keep the most recent event per player
       const byAuthor = new Map<string, NostrEvent>();
-      for (const ev of events as NostrEvent[]) {
-        const existing = byAuthor.get(ev.pubkey);
+      for (const { event: ev, player } of cards) {
+        if (playerPubkey && player !== playerPubkey) continue;
+        if (protocolVersion(ev) > LIVE_SCORING_VERSION) continue;
+        const existing = byAuthor.get(player);
         if (!existing || (ev.created_at || 0) > (existing.created_at || 0)) {
-          byAuthor.set(ev.pubkey, ev);
+          byAuthor.set(player, ev);
         }
       }
 
@@ -67,7 +77,7 @@ export function usePlayerScores(roundId: string, playerPubkey?: string) {
     },
     enabled: !!roundId,
     staleTime: 5 * 1000,
-    refetchInterval: 5000, // poll for updates (simple approach)
+    refetchInterval: connected ? 60 * 1000 : 5 * 1000,
   });
 
   const publish = useMutation({
@@ -79,7 +89,7 @@ export function usePlayerScores(roundId: string, playerPubkey?: string) {
       const eventPayload: Omit<NostrEvent, 'id' | 'pubkey' | 'sig'> = {
         kind: GOLF_KINDS.PLAYER_SCORE,
         content,
-        tags: [['d', roundId], ['player', payload.playerPubkey]],
+        tags: [['d', roundId], ['player', payload.playerPubkey], versionTag()],
         created_at: Math.floor(Date.now() / 1000),
       };
 
@@ -91,6 +101,7 @@ export function usePlayerScores(roundId: string, playerPubkey?: string) {
 
   return {
     ...query,
+    connected,
     publishScore: publish.mutateAsync,
     isPublishing: publish.status === 'pending',
   };
@@ -0,0 +1,64 @@
+import { useQuery } from '@tanstack/react-query';
+import { useNostr } from '@nostrify/react';
+import { fetchPlayerCards } from './useScoringDelegations';
+import { fetchRoundCourses } from './useGolfCourses';
+import { parsePlayerScoreEvent, type PlayerScoreRecord } from '@/lib/golf/nostrEvents';
+import { calculateTrends, type TrendConfig, type TrendRound } from '@/lib/golf/trendsEngine';
+
+/**
+ * Convert a player score record to trend input. Pars the score event didn't
+ * include come from the round's course; holes with neither are left
+ * without a par rather than guessed.
+ */
+export function toTrendRound(record: PlayerScoreRecord, coursePars: { [hole: number]: number } = {}): TrendRound {
+  return {
+    roundId: record.roundId,
+    date: record.updatedAt,
+    holes: Object.entries(record.scores).map(([key, strokes]) => {
+      const hole = Number(key);
+      return {
+        par: record.pars[hole] ?? coursePars[hole],
+        strokes,
+        putts: record.putts[hole],
+        fairway: record.fairways[hole],
+        gir: record.greens[hole],
+        penalties: record.penalties[hole],
+      };
+    }),
+  };
+}
+
+/**
+ * Hook for a player's performance trends (scoring average, GIR, fairways,
+ * putts and estimated strokes gained) over rolling windows of rounds.
+ */
+export function usePlayerTrends(pubkey: string | undefined, config: TrendConfig = {}, roundLimit: number = 100) {
+  const { nostr } = useNostr();
+
+  return useQuery({
+    queryKey: ['player-trends', pubkey, config.window, roundLimit],
+    queryFn: async (c) => {
+      const signal = AbortSignal.any([c.signal, AbortSignal.timeout(10000)]);
+      const cards = await fetchPlayerCards(nostr, [pubkey!], { limit: roundLimit }, signal);
+
+      // Latest record per round
+      const byRound = new Map<string, PlayerScoreRecord>();
+      for (const { event } of cards) {
+        const record = parsePlayerScoreEvent(event);
+        if (!record) continue;
+        const existing = byRound.get(record.roundId);
+        if (!existing || record.updatedAt > existing.updatedAt) {
+          byRound.set(record.roundId, record);
+        }
+      }
+
+      const courses = await fetchRoundCourses(nostr, [...byRound.keys()], pubkey!, signal);
+      return calculateTrends(
+        [...byRound.values()].map(record => toTrendRound(record, courses.get(record.roundId)?.course.holes)),
+        config
+      );
+    },
+    enabled: !!pubkey,
+    staleTime: 5 * 60 * 1000, // 5 minutes
+  });
+}
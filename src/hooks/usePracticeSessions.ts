@@ -0,0 +1,78 @@
+import { useQuery, useMutation, useQueryClient } from '@tanstack/react-query';
+import { useNostr } from '@nostrify/react';
+import { useCurrentUser } from './useCurrentUser';
+import { useNostrPublish } from './useNostrPublish';
+import { GOLF_KINDS } from '@/lib/golf/types';
+import { createPracticeSessionEvent, parsePracticeSessionEvent } from '@/lib/golf/nostrEvents';
+import {
+  calculatePracticeStats,
+  validatePracticeSession,
+  type PracticeSession,
+} from '@/lib/golf/practiceEngine';
+import { v4 as uuidv4 } from 'uuid';
+
+/**
+ * Hook for a player's practice sessions and practice stats, optionally
+ * limited to a date range (ms). Practice is kept apart from rounds and
+ * never feeds the handicap.
+ */
+export function usePracticeSessions(pubkey: string | undefined, range: { from?: number; to?: number } = {}) {
+  const { nostr } = useNostr();
+  const { user } = useCurrentUser();
+  const { mutateAsync: publishEvent } = useNostrPublish();
+  const queryClient = useQueryClient();
+
+  const query = useQuery({
+    queryKey: ['practice-sessions', pubkey],
+    queryFn: async (c) => {
+      const signal = AbortSignal.any([c.signal, AbortSignal.timeout(5000)]);
+      const events = await nostr.query([
+        { kinds: [GOLF_KINDS.PRACTICE_SESSION], authors: [pubkey!], limit: 200 },
+      ], { signal });
+
+      // Latest version of each session
+      const latest = new Map<string, { createdAt: number; session: PracticeSession }>();
+      for (const event of events) {
+        const session = parsePracticeSessionEvent(event);
+        if (!session) continue;
+        const existing = latest.get(session.sessionId);
+        if (!existing || event.created_at > existing.createdAt) {
+          latest.set(session.sessionId, { createdAt: event.created_at, session });
+        }
+      }
+
+      return [...latest.values()].map(v => v.session).sort((a, b) => b.date - a.date);
+    },
+    enabled: !!pubkey,
+    staleTime: 60 * 1000,
+  });
+
+  const saveSession = useMutation({
+    mutationFn: async (params: Omit<PracticeSession, 'sessionId'> & { sessionId?: string }) => {
+      if (!user) throw new Error('Must be logged in to save practice');
+
+      const session: PracticeSession = { ...params, sessionId: params.sessionId ?? uuidv4() };
+      const errors = validatePracticeSession(session);
+      if (errors.length > 0) throw new Error(errors[0]);
+
+      const event = createPracticeSessionEvent(session, user.pubkey);
+      await publishEvent({ kind: event.kind, content: event.content, tags: event.tags, created_at: event.created_at });
+
+      return session;
+    },
+    onSuccess: () => {
+      queryClient.invalidateQueries({ queryKey: ['practice-sessions', user?.pubkey] });
+      queryClient.invalidateQueries({ queryKey: ['golf-bag', user?.pubkey] }); // launch monitor carries
+    },
+  });
+
+  const sessions = query.data ?? [];
+
+  return {
+    ...query,
+    sessions,
+    stats: calculatePracticeStats(sessions, range),
+    saveSession: saveSession.mutateAsync,
+    isSaving: saveSession.status === 'pending',
+  };
+}
@@ -0,0 +1,118 @@
+import { useEffect } from 'react';
+import { useMutation, useQuery, useQueryClient } from '@tanstack/react-query';
+import { useNostr } from '@nostrify/react';
+import { useCurrentUser } from './useCurrentUser';
+import { useNostrPublish } from './useNostrPublish';
+import { useToast } from './useToast';
+import { recordAchievements } from './useAchievements';
+import { resolveScoreCards } from './useScoringDelegations';
+import { GOLF_KINDS, type GolfRound } from '@/lib/golf/types';
+import { scoreCardFilters } from '@/lib/golf/delegationEngine';
+import { BadgeService } from '@/lib/golf/badgeSystem';
+import {
+  createPredictionEvent,
+  parsePlayerScoreEvent,
+  parsePredictionEvent,
+  type PlayerScoreRecord,
+} from '@/lib/golf/nostrEvents';
+import {
+  latestPredictions,
+  predictionAchievements,
+  resolvePredictions,
+  type Prediction,
+  type PredictionGame,
+} from '@/lib/golf/predictionEngine';
+
+const badgeService = new BadgeService();
+
+/**
+ * A round's prediction games. Players `predict` before their first hole is
+ * scored (or the tee time, if the round has one); the games are judged from
+ * the group's score cards, and once every card is complete the winners'
+ * badges are added to their achievements.
+ */
+export function usePredictionGame(round: Partial<GolfRound> | undefined) {
+  const { nostr } = useNostr();
+  const { user } = useCurrentUser();
+  const { mutateAsync: publishEvent } = useNostrPublish();
+  const { toast } = useToast();
+  const queryClient = useQueryClient();
+  const roundId = round?.id;
+  const playersKey = (round?.players ?? []).map(p => p.playerId).filter(Boolean).join(',');
+  const queryKey = ['prediction-game', roundId, playersKey];
+
+  const query = useQuery<{ predictions: Prediction[]; cards: PlayerScoreRecord[] }>({
+    queryKey,
+    queryFn: async (c) => {
+      const signal = AbortSignal.any([c.signal, AbortSignal.timeout(5000)]);
+      const authors = playersKey.split(',');
+      // Every card in the round, as a terminal may have signed a player's card
+      const events = await nostr.query([
+        { kinds: [GOLF_KINDS.PREDICTION], authors, limit: 200 },
+        ...scoreCardFilters([roundId!]),
+      ], { signal });
+      const scoreEvents = [...new Map(events.filter(e => e.kind === GOLF_KINDS.PLAYER_SCORE).map(e => [e.id, e])).values()];
+
+      return {
+        predictions: events
+          .map(parsePredictionEvent)
+          .filter((p): p is Prediction => p !== null && p.roundId === roundId),
+        cards: (await resolveScoreCards(nostr, scoreEvents, signal))
+          .filter(card => authors.includes(card.player))
+          .flatMap(({ event, player }) => {
+            const record = parsePlayerScoreEvent(event);
+            return record ? [{ ...record, playerPubkey: player }] : [];
+          }),
+      };
+    },
+    enabled: !!roundId && !!playersKey,
+    refetchInterval: 60 * 1000,
+  });
+
+  const lockAt = round?.metadata?.teeTime;
+  const holes = round?.holes?.length || 18;
+  const results = resolvePredictions(query.data?.predictions ?? [], query.data?.cards ?? [], holes, lockAt);
+  const myPredictions = user ? latestPredictions(query.data?.predictions ?? [], lockAt).filter(p => p.predictor === user.pubkey) : [];
+
+  const mine = (round?.players ?? []).find(p => p.playerId === user?.pubkey);
+  const started = !!mine?.scores?.some(score => score > 0) || (lockAt !== undefined && Date.now() >= lockAt);
+  const canPredict = !!mine && !started && round?.status !== 'completed';
+
+  // Winners collect their badges as soon as the games are settled
+  const pubkey = user?.pubkey;
+  const wonKey = pubkey ? results.filter(r => r.winners.includes(pubkey)).map(r => `${r.game}:${r.exact.includes(pubkey)}`).join(',') : '';
+  useEffect(() => {
+    if (!pubkey || !roundId || !wonKey) return;
+    const unlocked = recordAchievements(pubkey, predictionAchievements(results, pubkey, roundId, Date.now()));
+    for (const achievement of unlocked) {
+      const badge = badgeService.getBadgeDefinition(achievement.badgeId);
+      if (badge) toast({ title: `${badge.icon} Achievement unlocked: ${badge.name}`, description: badge.description });
+    }
+    if (unlocked.length > 0) queryClient.invalidateQueries({ queryKey: ['achievements', pubkey] });
+    // results is derived from the same data as wonKey
+    // eslint-disable-next-line react-hooks/exhaustive-deps
+  }, [pubkey, roundId, wonKey, toast, queryClient]);
+
+  const publish = useMutation({
+    mutationFn: async ({ game, value }: { game: PredictionGame; value: number }) => {
+      if (!user) throw new Error('Log in to make a prediction');
+      if (!roundId || !canPredict) throw new Error('Predictions are closed once you start the round');
+      if (!Number.isInteger(value) || value < 0) throw new Error('Predict a whole number');
+      const event = createPredictionEvent({ roundId, predictor: user.pubkey, game, value });
+      return publishEvent({ kind: event.kind, content: event.content, tags: event.tags, created_at: event.created_at });
+    },
+    onSuccess: () => {
+      queryClient.invalidateQueries({ queryKey });
+    },
+  });
+
+  return {
+    ...query,
+    results,
+    myPredictions,
+    canPredict,
+    isSettled: results.some(r => r.winners.length > 0),
+    predict: (game: PredictionGame, value: number) => publish.mutateAsync({ game, value }),
+    isPublishing: publish.status === 'pending',
+  };
+}
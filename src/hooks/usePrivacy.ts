@@ -0,0 +1,238 @@
+import { useEffect, useRef } from 'react';
+import { useMutation, useQuery, useQueryClient } from '@tanstack/react-query';
+import { useNostr } from '@nostrify/react';
+import type { NostrEvent } from '@nostrify/nostrify';
+import { useCurrentUser } from './useCurrentUser';
+import { useNostrPublish } from './useNostrPublish';
+import { GOLF_KINDS, type GolfRound } from '@/lib/golf/types';
+import { createPrivacyEvent, createShotEvent, parsePrivacyEvent, parseShotEvent } from '@/lib/golf/nostrEvents';
+import { blobDeleteAuth, deletionRequests, isMemberOutboxEvent, mediaBlobs, type ErasureSummary } from '@/lib/golf/erasureEngine';
+import {
+  DEFAULT_PRIVACY_SETTINGS,
+  anonymizeShot,
+  buildDataExport,
+  retentionCutoff,
+  shotsToAnonymize,
+  type PrivacySettings,
+} from '@/lib/golf/privacyEngine';
+import { db } from '@/lib/offline/db';
+import { scheduler } from '@/lib/scheduler/scheduler';
+import { UPSTREAM_SERVICES, upstreamFetch } from '@/lib/upstream/upstream';
+
+// Device storage holding a member's rounds and payments
+const DEVICE_KEYS = ['golf-rounds', 'prize-payouts'];
+
+function readDeviceKey(key: string): unknown {
+  try {
+    return JSON.parse(localStorage.getItem(key) || 'null');
+  } catch {
+    return null;
+  }
+}
+
+/**
+ * A member's privacy settings, or the defaults if they have never saved any
+ */
+export function usePrivacySettings(pubkey: string | undefined) {
+  const { nostr } = useNostr();
+
+  return useQuery<PrivacySettings>({
+    queryKey: ['privacy-settings', pubkey],
+    queryFn: async (c) => {
+      const signal = AbortSignal.any([c.signal, AbortSignal.timeout(5000)]);
+      const events = await nostr.query([{ kinds: [GOLF_KINDS.PRIVACY], authors: [pubkey!], '#d': ['privacy'], limit: 1 }], { signal });
+      const latest = events.sort((a, b) => b.created_at - a.created_at)[0];
+      return (latest && parsePrivacyEvent(latest)) || DEFAULT_PRIVACY_SETTINGS;
+    },
+    enabled: !!pubkey,
+    staleTime: 5 * 60 * 1000,
+  });
+}
+
+/**
+ * Hook for the logged-in member's consents and retention periods, and an
+ * export of everything stored about them on relays and this device
+ */
+export function usePrivacy() {
+  const { nostr } = useNostr();
+  const { user } = useCurrentUser();
+  const { mutateAsync: publishEvent } = useNostrPublish();
+  const queryClient = useQueryClient();
+  const { data: settings = DEFAULT_PRIVACY_SETTINGS, isLoading } = usePrivacySettings(user?.pubkey);
+
+  const save = useMutation({
+    mutationFn: async (next: Omit<PrivacySettings, 'createdAt'>) => {
+      if (!user) throw new Error('Must be logged in to change privacy settings');
+      const event = createPrivacyEvent(next, user.pubkey);
+      return publishEvent({ kind: event.kind, content: event.content, tags: event.tags, created_at: event.created_at });
+    },
+    onSuccess: () => {
+      queryClient.invalidateQueries({ queryKey: ['privacy-settings', user?.pubkey] });
+    },
+  });
+
+  const exportData = async () => {
+    if (!user) throw new Error('Must be logged in to export your data');
+    const signal = AbortSignal.timeout(15000);
+    const events = await nostr.query([
+      { authors: [user.pubkey], limit: 5000 },
+      { '#p': [user.pubkey], limit: 5000 },
+    ], { signal });
+
+    const device: Record<string, unknown> = Object.fromEntries(DEVICE_KEYS.map(key => [key, readDeviceKey(key)]));
+    device.offlineRounds = await db.rounds.toArray();
+    device.offlineScores = await db.holeScores.where('playerPubkey').equals(user.pubkey).toArray();
+
+    return buildDataExport(user.pubkey, events, device, Date.now());
+  };
+
+  return {
+    settings,
+    isLoading,
+    saveSettings: save.mutateAsync,
+    isSaving: save.status === 'pending',
+    exportData,
+  };
+}
+
+/**
+ * Apply the logged-in member's retention periods while the app is open:
+ * shot positions past the GPS period are removed by republishing the shot
+ * without them, and rounds saved on this device past the personal data
+ * period are deleted
+ */
+export function usePrivacyRetention() {
+  const { nostr } = useNostr();
+  const { user } = useCurrentUser();
+  const { mutateAsync: publishEvent } = useNostrPublish();
+  const { data: settings } = usePrivacySettings(user?.pubkey);
+  // The job publishes with the current signer without being re-registered on every render
+  const publishRef = useRef(publishEvent);
+  publishRef.current = publishEvent;
+
+  const pubkey = user?.pubkey;
+  const gpsDays = settings?.gpsRetentionDays ?? null;
+  const personalDays = settings?.personalRetentionDays ?? null;
+
+  useEffect(() => {
+    if (!pubkey || (gpsDays === null && personalDays === null)) return;
+    const retention = { ...DEFAULT_PRIVACY_SETTINGS, gpsRetentionDays: gpsDays, personalRetentionDays: personalDays };
+
+    return scheduler.register({
+      id: 'privacy-retention',
+      name: 'Data retention',
+      schedule: '@every 1h',
+      runOnStart: true,
+      run: async () => {
+        const now = Date.now();
+
+        const gpsCutoff = retentionCutoff(gpsDays, now);
+        if (gpsCutoff !== null) {
+          const events = await nostr.query([{ kinds: [GOLF_KINDS.SHOT], authors: [pubkey], until: Math.floor(gpsCutoff / 1000), limit: 500 }], { signal: AbortSignal.timeout(10000) });
+
+          // Only the latest version of each shot; an older copy may still have positions
+          const latest = new Map<string, NostrEvent>();
+          for (const event of events) {
+            const d = event.tags.find(t => t[0] === 'd')?.[1];
+            if (d && (!latest.has(d) || event.created_at > latest.get(d)!.created_at)) latest.set(d, event);
+          }
+          const shots = [...latest.values()].map(parseShotEvent).filter((s): s is NonNullable<typeof s> => s !== null);
+
+          for (const shot of shotsToAnonymize(shots, retention, now)) {
+            const { shotId, clubId, distance, timestamp, ...details } = anonymizeShot(shot);
+            const event = createShotEvent({ shotId, clubId, distance, timestamp }, pubkey, details);
+            // A second later, so relays replace the original but the shot keeps its time
+            await publishRef.current({ kind: event.kind, content: event.content, tags: event.tags, created_at: event.created_at + 1 });
+          }
+        }
+
+        const personalCutoff = retentionCutoff(personalDays, now);
+        if (personalCutoff !== null) {
+          const saved = (readDeviceKey('golf-rounds') ?? {}) as Record<string, GolfRound>;
+          const kept = Object.fromEntries(Object.entries(saved).filter(([, round]) => round.date >= personalCutoff));
+          if (Object.keys(kept).length < Object.keys(saved).length) localStorage.setItem('golf-rounds', JSON.stringify(kept));
+
+          const expired = await db.rounds.where('createdAt').below(personalCutoff).and(r => r.state === 'closed').primaryKeys();
+          if (expired.length > 0) {
+            await db.holeScores.where('roundId').anyOf(expired).delete();
+            await db.rounds.bulkDelete(expired);
+          }
+        }
+      },
+    });
+  }, [nostr, pubkey, gpsDays, personalDays]);
+}
+
+/**
+ * Hook to erase the logged-in member's golf data: uploaded media is deleted
+ * from its Blossom servers, every golf event they published gets a NIP-09
+ * deletion request, and their rounds, payouts, cached data and queued
+ * events are cleared from this device. Events other people published about
+ * them can't be deleted.
+ */
+export function useDataErasure() {
+  const { nostr } = useNostr();
+  const { user } = useCurrentUser();
+  const { mutateAsync: publishEvent } = useNostrPublish();
+  const queryClient = useQueryClient();
+
+  const erase = useMutation({
+    mutationFn: async (): Promise<ErasureSummary> => {
+      if (!user) throw new Error('Must be logged in to delete your data');
+      const signal = AbortSignal.timeout(15000);
+      const events = await nostr.query([
+        { kinds: Object.values(GOLF_KINDS), authors: [user.pubkey], limit: 5000 },
+        { '#t': ['golf'], authors: [user.pubkey], limit: 5000 },
+      ], { signal });
+
+      // Media first, while the events that link to it still exist
+      let media = 0;
+      let mediaFailed = 0;
+      for (const blob of mediaBlobs(events, user.pubkey)) {
+        try {
+          const auth = await user.signer.signEvent(blobDeleteAuth(blob, Date.now()));
+          const res = await upstreamFetch(UPSTREAM_SERVICES.blossom, `${blob.server}/${blob.sha256}`, {
+            method: 'DELETE',
+            headers: { Authorization: `Nostr ${btoa(JSON.stringify(auth))}` },
+          });
+          if (res.ok || res.status === 404) media++;
+          else mediaFailed++;
+        } catch {
+          mediaFailed++;
+        }
+      }
+
+      const requests = deletionRequests(events, user.pubkey, 'Member asked for their golf data to be erased', Date.now());
+      for (const request of requests) {
+        await publishEvent(request);
+      }
+
+      // Device copies: shared stores, caches keyed by the member (e.g. achievements:<pubkey>),
+      // their offline rounds and scores, and anything still queued to publish as them
+      for (const key of DEVICE_KEYS) localStorage.removeItem(key);
+      for (const key of Object.keys(localStorage)) {
+        if (key.includes(user.pubkey)) localStorage.removeItem(key);
+      }
+      const roundIds = [...new Set((await db.holeScores.where('playerPubkey').equals(user.pubkey).toArray()).map(s => s.roundId))];
+      await db.holeScores.where('playerPubkey').equals(user.pubkey).delete();
+      await db.rounds.bulkDelete(roundIds);
+      await db.outbox.filter(entry => isMemberOutboxEvent(entry, user.pubkey)).delete();
+
+      return {
+        events: new Set(events.filter(e => e.pubkey === user.pubkey && e.kind !== 5).map(e => e.id)).size,
+        requests: requests.length,
+        media,
+        mediaFailed,
+        erasedAt: Date.now(),
+      };
+    },
+    onSuccess: () => {
+      queryClient.invalidateQueries();
+    },
+  });
+
+  return {
+    eraseData: erase.mutateAsync,
+    isErasing: erase.status === 'pending',
+  };
+}
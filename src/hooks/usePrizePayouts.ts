@@ -0,0 +1,186 @@
+import { useCallback, useState } from 'react';
+import { useNostr } from '@nostrify/react';
+import type { NostrEvent, NostrFilter } from '@nostrify/nostrify';
+import { nip57 } from 'nostr-tools';
+import { useCurrentUser } from './useCurrentUser';
+import { useAppContext } from './useAppContext';
+import { useLocalStorage } from './useLocalStorage';
+import { useNWC } from './useNWCContext';
+import { fetchZapProvider, type ZapProvider } from './useZaps';
+import { UPSTREAM_SERVICES, upstreamFetch } from '@/lib/upstream/upstream';
+import { verifyZapReceipt } from '@/lib/golf/zapReceiptEngine';
+import {
+  DEFAULT_PAYOUT_POLICY,
+  planPayouts,
+  receiptPaysPrize,
+  type PayoutPlan,
+  type PayoutPolicy,
+  type PayoutRecord,
+  type Prize,
+} from '@/lib/golf/payoutEngine';
+
+const HISTORY_KEY = 'prize-payouts';
+
+interface NostrLike {
+  query(filters: NostrFilter[], opts?: { signal?: AbortSignal }): Promise<NostrEvent[]>;
+}
+
+function loadHistory(): PayoutRecord[] {
+  try {
+    return JSON.parse(localStorage.getItem(HISTORY_KEY) || '[]');
+  } catch {
+    return [];
+  }
+}
+
+/** The zap providers of the winners' lightning addresses */
+async function fetchProviders(nostr: NostrLike, recipients: string[], signal: AbortSignal): Promise<Map<string, ZapProvider>> {
+  const profiles = await nostr.query([{ kinds: [0], authors: recipients }], { signal });
+  const providers = new Map<string, ZapProvider>();
+  for (const profile of profiles) {
+    try {
+      const provider = await fetchZapProvider(JSON.parse(profile.content));
+      if (provider) providers.set(profile.pubkey, provider);
+    } catch {
+      // Skip profiles with invalid metadata
+    }
+  }
+  return providers;
+}
+
+/**
+ * Prizes the organizer has already paid, from the winners' zap receipts
+ * (checked against each winner's zap provider), so a prize paid from another
+ * device, or whose payment went through after an error, is not paid again.
+ * A receipt only counts when it is for the prize's full amount.
+ */
+async function fetchPaidPrizes(
+  nostr: NostrLike,
+  organizer: string,
+  prizes: Prize[],
+  providers: Map<string, ZapProvider>,
+  signal: AbortSignal,
+): Promise<PayoutRecord[]> {
+  if (providers.size === 0) return [];
+  const receipts = await nostr.query([{ kinds: [9735], '#p': [...providers.keys()], limit: 1000 }], { signal });
+
+  const paid: PayoutRecord[] = [];
+  for (const event of receipts) {
+    const provider = providers.get(event.tags.find(([name]) => name === 'p')?.[1] ?? '');
+    const receipt = provider ? await verifyZapReceipt(event, provider.nostrPubkey) : null;
+    const prizeId = receipt?.request.tags.find(([name]) => name === 'prize')?.[1];
+    if (!receipt || receipt.sender !== organizer || !prizeId) continue;
+    const prize = prizes.find(p => p.prizeId === prizeId);
+    if (!prize || !receiptPaysPrize(prize, { prizeId, recipient: receipt.recipient, amountMsats: receipt.amountMsats })) continue;
+    paid.push({ prizeId, recipient: receipt.recipient, amountSats: receipt.amountSats, paidAt: receipt.paidAt });
+  }
+  return paid;
+}
+
+/**
+ * Hook for paying prizes from the organizer's connected club wallet (NWC).
+ * Prizes are sent as zaps to the winners' lightning addresses, tagged with
+ * the prize, so the receipts are public. Within the policy's limits `settle`
+ * pays automatically; everything else is returned for the organizer to
+ * approve. Before paying, the winners' zap receipts are checked so a prize
+ * is never paid twice, from this device or any other.
+ */
+export function usePrizePayouts() {
+  const { nostr } = useNostr();
+  const { user } = useCurrentUser();
+  const { config } = useAppContext();
+  const { sendPayment, getActiveConnection } = useNWC();
+  const [policy, setPolicy] = useLocalStorage<PayoutPolicy>('prize-payout-policy', DEFAULT_PAYOUT_POLICY);
+  const [history, setHistory] = useState<PayoutRecord[]>(loadHistory);
+  const [isPaying, setIsPaying] = useState(false);
+
+  const payPrize = useCallback(async (prize: Prize) => {
+    if (!user?.signer) throw new Error('Must be logged in to pay prizes');
+    const connection = getActiveConnection();
+    if (!connection?.connectionString || !connection.isConnected) {
+      throw new Error('Connect a club wallet (NWC) to pay prizes');
+    }
+    if (loadHistory().some(r => r.prizeId === prize.prizeId)) return;
+
+    const signal = AbortSignal.timeout(5000);
+    const provider = (await fetchProviders(nostr, [prize.recipient], signal)).get(prize.recipient);
+    if (!provider) throw new Error('The winner has no lightning address that accepts zaps');
+
+    const record = (r: PayoutRecord) => {
+      const next = [...loadHistory(), r];
+      localStorage.setItem(HISTORY_KEY, JSON.stringify(next));
+      setHistory(next);
+    };
+
+    const paidElsewhere = (await fetchPaidPrizes(nostr, user.pubkey, [prize], new Map([[prize.recipient, provider]]), signal))
+      .find(r => r.prizeId === prize.prizeId);
+    if (paidElsewhere) {
+      record(paidElsewhere);
+      return;
+    }
+
+    const amount = prize.amountSats * 1000;
+    const zapRequest = nip57.makeZapRequest({
+      profile: prize.recipient,
+      event: null,
+      amount,
+      relays: [config.relayUrl],
+      comment: prize.memo,
+    });
+    zapRequest.tags.push(['prize', prize.prizeId]);
+    const signed = await user.signer.signEvent(zapRequest);
+
+    const res = await upstreamFetch(UPSTREAM_SERVICES.lnurl, `${provider.callback}?amount=${amount}&nostr=${encodeURIComponent(JSON.stringify(signed))}`);
+    const data = await res.json();
+    if (!res.ok || typeof data.pr !== 'string') {
+      throw new Error(data.reason || 'The winner\'s wallet did not return an invoice');
+    }
+
+    await sendPayment(connection, data.pr);
+
+    record({ prizeId: prize.prizeId, recipient: prize.recipient, amountSats: prize.amountSats, paidAt: Date.now() });
+  }, [nostr, user, config.relayUrl, getActiveConnection, sendPayment]);
+
+  /**
+   * Pay what the policy allows and return the prizes still waiting for
+   * approval, including any whose automatic payment failed
+   */
+  const settle = useCallback(async (prizes: Prize[]) => {
+    const failed: { prize: Prize; error: string }[] = [];
+    let plan: PayoutPlan = { automatic: [], approval: [] };
+
+    setIsPaying(true);
+    try {
+      const signal = AbortSignal.timeout(10000);
+      const recipients = [...new Set(prizes.map(p => p.recipient))];
+      const receipts = user && recipients.length > 0
+        ? await fetchPaidPrizes(nostr, user.pubkey, prizes, await fetchProviders(nostr, recipients, signal), signal)
+        : [];
+      const local = loadHistory();
+      plan = planPayouts(prizes, policy, [...local, ...receipts.filter(r => !local.some(l => l.prizeId === r.prizeId))], Date.now());
+
+      for (const prize of plan.automatic) {
+        try {
+          await payPrize(prize);
+        } catch (error) {
+          failed.push({ prize, error: error instanceof Error ? error.message : 'Payment failed' });
+        }
+      }
+    } finally {
+      setIsPaying(false);
+    }
+
+    return { paid: plan.automatic.filter(p => !failed.some(f => f.prize === p)), pending: [...failed.map(f => f.prize), ...plan.approval], failed };
+  }, [nostr, user, policy, payPrize]);
+
+  const approve = useCallback(async (prize: Prize) => {
+    setIsPaying(true);
+    try {
+      await payPrize(prize);
+    } finally {
+      setIsPaying(false);
+    }
+  }, [payPrize]);
+
+  return { policy, setPolicy, history, settle, approve, isPaying };
+}
@@ -0,0 +1,85 @@
+import { useQuery } from '@tanstack/react-query';
+import { useNostr } from '@nostrify/react';
+import { GOLF_KINDS } from '@/lib/golf/types';
+import { scoreCardFilters } from '@/lib/golf/delegationEngine';
+import { parseAceEvent, parseAceWitnessEvent, parsePlayerScoreEvent, type PlayerScoreRecord } from '@/lib/golf/nostrEvents';
+import { aceWall, type Ace, type AceWitness } from '@/lib/golf/aceEngine';
+import { publicStats, type PublicStats, type StatsRound, type StatsScore } from '@/lib/golf/statsEngine';
+import { aceCoordinate } from './useCourseAces';
+import { resolveScoreCards } from './useScoringDelegations';
+
+const YEAR_MS = 365 * 24 * 60 * 60 * 1000;
+
+/**
+ * Hook for anonymized statistics across every course over the last year:
+ * rounds, average scores from complete 18-hole cards, busy hours and
+ * confirmed aces. Private rounds are left out. The noise is drawn once per
+ * load and kept for the session, so refreshing doesn't average it away.
+ */
+export function usePublicStats() {
+  const { nostr } = useNostr();
+
+  return useQuery<PublicStats & { generatedAt: number }>({
+    queryKey: ['public-stats'],
+    queryFn: async (c) => {
+      const signal = AbortSignal.any([c.signal, AbortSignal.timeout(15000)]);
+      const since = Date.now() - YEAR_MS;
+
+      const [roundEvents, aceEvents] = await Promise.all([
+        nostr.query([{ kinds: [GOLF_KINDS.ROUND], since: Math.floor(since / 1000), limit: 2000 }], { signal }),
+        nostr.query([{ kinds: [GOLF_KINDS.ACE], since: Math.floor(since / 1000), limit: 500 }], { signal }),
+      ]);
+
+      // A round is republished as it progresses; count it once
+      const rounds = new Map<string, StatsRound>();
+      for (const event of roundEvents) {
+        const tag = (name: string) => event.tags.find(([n]) => n === name);
+        const roundId = tag('round-id')?.[1] || tag('d')?.[1];
+        const course = tag('course')?.[1];
+        if (!roundId || !course || rounds.has(roundId) || tag('visibility')?.[1] === 'private') continue;
+        const teeTime = tag('tee-time')?.[1];
+        rounds.set(roundId, {
+          roundId,
+          course,
+          teeTime: teeTime ? parseInt(teeTime) * 1000 : event.created_at * 1000,
+          players: tag('players')?.slice(1).filter(Boolean) ?? [event.pubkey],
+        });
+      }
+
+      const roundIds = [...rounds.keys()];
+      const scoreEvents = roundIds.length > 0
+        ? await nostr.query(scoreCardFilters(roundIds, { limit: roundIds.length * 4 }), { signal })
+        : [];
+
+      const unique = [...new Map(scoreEvents.map(e => [e.id, e])).values()];
+
+      // Terminal-signed cards are credited to the player they were kept for
+      const latest = new Map<string, PlayerScoreRecord>();
+      for (const { event, player } of await resolveScoreCards(nostr, unique, signal)) {
+        const parsed = parsePlayerScoreEvent(event);
+        if (!parsed) continue;
+        const record = { ...parsed, playerPubkey: player };
+        const key = `${record.roundId}:${record.playerPubkey}`;
+        const existing = latest.get(key);
+        if (!existing || record.updatedAt > existing.updatedAt) latest.set(key, record);
+      }
+      const scores: StatsScore[] = [...latest.values()].flatMap(record => {
+        const round = rounds.get(record.roundId);
+        const strokes = Object.values(record.scores).filter(s => s > 0);
+        if (!round || strokes.length !== 18) return [];
+        return [{ roundId: record.roundId, course: round.course, player: record.playerPubkey, gross: strokes.reduce((a, b) => a + b, 0) }];
+      });
+
+      const aces = aceEvents.map(parseAceEvent).filter((a): a is Ace => a !== null);
+      const witnessEvents = aces.length > 0
+        ? await nostr.query([{ kinds: [GOLF_KINDS.ACE_WITNESS], '#a': aces.map(aceCoordinate) }], { signal })
+        : [];
+      const witnesses = witnessEvents.map(parseAceWitnessEvent).filter((w): w is AceWitness => w !== null);
+      const confirmed = aceWall(aces, witnesses).map(a => ({ course: a.courseName, player: a.playerPubkey }));
+
+      return { ...publicStats([...rounds.values()], scores, confirmed), generatedAt: Date.now() };
+    },
+    staleTime: Infinity,
+    gcTime: Infinity,
+  });
+}
@@ -0,0 +1,57 @@
+import { useMutation, useQuery, useQueryClient } from '@tanstack/react-query';
+import { useNostr } from '@nostrify/react';
+import type { NostrEvent, NostrFilter } from '@nostrify/nostrify';
+import { useContentFilter } from './useContentFilter';
+import { useCurrentUser } from './useCurrentUser';
+import { useNostrPublish } from './useNostrPublish';
+import { reactionTags, summarizeReactions } from '@/lib/golf/reactionEngine';
+
+/**
+ * NIP-25 reactions on an event. Reactions to addressable events (rounds) are
+ * matched by address so they carry over to later versions of the event.
+ */
+export function useReactions(target: NostrEvent | null | undefined) {
+  const { nostr } = useNostr();
+  const { user } = useCurrentUser();
+  const { mutateAsync: publishEvent } = useNostrPublish();
+  const queryClient = useQueryClient();
+  const contentFilter = useContentFilter();
+
+  const address = target ? reactionTags(target).find(([name]) => name === 'a')?.[1] : undefined;
+  const queryKey = ['reactions', address ?? target?.id];
+
+  const reactions = useQuery<NostrEvent[]>({
+    queryKey,
+    queryFn: async (c) => {
+      const signal = AbortSignal.any([c.signal, AbortSignal.timeout(5000)]);
+      const filter: NostrFilter = address
+        ? { kinds: [7], '#a': [address], limit: 500 }
+        : { kinds: [7], '#e': [target!.id], limit: 500 };
+      return nostr.query([filter], { signal });
+    },
+    enabled: !!target,
+    staleTime: 60 * 1000,
+  });
+
+  const react = useMutation({
+    mutationFn: async (emoji: string) => {
+      if (!target) throw new Error('Nothing to react to');
+      return publishEvent({
+        kind: 7,
+        content: emoji,
+        tags: reactionTags(target),
+        created_at: Math.floor(Date.now() / 1000),
+      });
+    },
+    onSuccess: () => {
+      queryClient.invalidateQueries({ queryKey });
+    },
+  });
+
+  return {
+    counts: summarizeReactions(contentFilter.filter(reactions.data ?? []), user?.pubkey),
+    isLoading: reactions.isLoading,
+    react: react.mutateAsync,
+    isReacting: react.status === 'pending',
+  };
+}
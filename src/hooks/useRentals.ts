@@ -0,0 +1,114 @@
+import { useMutation, useQuery, useQueryClient } from '@tanstack/react-query';
+import { useNostr } from '@nostrify/react';
+import { useCurrentUser } from './useCurrentUser';
+import { useNostrPublish } from './useNostrPublish';
+import { GOLF_KINDS } from '@/lib/golf/types';
+import {
+  createRentalInventoryEvent,
+  createRentalReservationEvent,
+  parseRentalInventoryEvent,
+  parseRentalReservationEvent,
+} from '@/lib/golf/nostrEvents';
+import {
+  availableCount,
+  confirmedReservations,
+  type RentalInventory,
+  type RentalItem,
+  type RentalReservation,
+} from '@/lib/golf/rentalEngine';
+import type { GolfCourse } from './useGolfCourses';
+import { v4 as uuidv4 } from 'uuid';
+
+/**
+ * Rental equipment for a course: the club's inventory and the reservations
+ * against it. Reservations are honoured first come first served, so one
+ * that would overbook an item is not confirmed. The club maintains the
+ * inventory; anyone logged in can reserve.
+ */
+export function useRentals(course: Pick<GolfCourse, 'id' | 'author'> | null | undefined) {
+  const { nostr } = useNostr();
+  const { user } = useCurrentUser();
+  const { mutateAsync: publishEvent } = useNostrPublish();
+  const queryClient = useQueryClient();
+  const queryKey = ['rentals', course?.author, course?.id];
+
+  const query = useQuery<{ inventory: RentalInventory | null; reservations: RentalReservation[] }>({
+    queryKey,
+    queryFn: async (c) => {
+      const signal = AbortSignal.any([c.signal, AbortSignal.timeout(5000)]);
+      const coordinate = `${GOLF_KINDS.COURSE}:${course!.author}:${course!.id}`;
+      const events = await nostr.query([
+        { kinds: [GOLF_KINDS.RENTAL_INVENTORY], authors: [course!.author], '#d': [course!.id] },
+        // Reservations still running or yet to start
+        { kinds: [GOLF_KINDS.RENTAL_RESERVATION], '#a': [coordinate], since: Math.floor(Date.now() / 1000) - 30 * 24 * 60 * 60, limit: 500 },
+      ], { signal });
+
+      const inventory = events
+        .map(parseRentalInventoryEvent)
+        .filter((i): i is RentalInventory => i !== null && i.courseAuthor === course!.author)
+        .sort((a, b) => b.createdAt - a.createdAt)[0] ?? null;
+
+      // Keep the latest version of each member's reservation
+      const latest = new Map<string, RentalReservation>();
+      for (const reservation of events.map(parseRentalReservationEvent)) {
+        if (!reservation) continue;
+        const key = `${reservation.renter}:${reservation.reservationId}`;
+        const existing = latest.get(key);
+        if (!existing || reservation.createdAt > existing.createdAt) latest.set(key, reservation);
+      }
+
+      return { inventory, reservations: [...latest.values()] };
+    },
+    enabled: !!course?.author && !!course?.id,
+    staleTime: 60 * 1000,
+  });
+
+  const items = query.data?.inventory?.items ?? [];
+  const confirmed = confirmedReservations(items, query.data?.reservations ?? []);
+
+  const saveInventory = useMutation({
+    mutationFn: async (inventory: Pick<RentalInventory, 'items' | 'currency'>) => {
+      if (!course) throw new Error('Select a course');
+      if (user?.pubkey !== course.author) throw new Error('Only the club account can manage rentals');
+      const event = createRentalInventoryEvent({ ...inventory, courseId: course.id, courseAuthor: course.author });
+      return publishEvent({ kind: event.kind, content: event.content, tags: event.tags, created_at: event.created_at });
+    },
+    onSuccess: () => {
+      queryClient.invalidateQueries({ queryKey });
+    },
+  });
+
+  const publishReservation = useMutation({
+    mutationFn: async (reservation: Omit<RentalReservation, 'createdAt' | 'renter' | 'courseId' | 'courseAuthor'>) => {
+      if (!course) throw new Error('Select a course');
+      if (!user) throw new Error('Log in to reserve equipment');
+      const item = items.find(i => i.id === reservation.itemId);
+      if (!item) throw new Error('That item is no longer available to hire');
+      if (!reservation.cancelled) {
+        const others = confirmed.filter(r => !(r.renter === user.pubkey && r.reservationId === reservation.reservationId));
+        if (availableCount(item, others, reservation.startsAt, reservation.endsAt) < reservation.quantity) {
+          throw new Error(`Not enough ${item.name.toLowerCase()}s free for that time`);
+        }
+      }
+      const event = createRentalReservationEvent({ ...reservation, renter: user.pubkey, courseId: course.id, courseAuthor: course.author });
+      return publishEvent({ kind: event.kind, content: event.content, tags: event.tags, created_at: event.created_at });
+    },
+    onSuccess: () => {
+      queryClient.invalidateQueries({ queryKey });
+    },
+  });
+
+  return {
+    inventory: query.data?.inventory ?? null,
+    items,
+    confirmed,
+    mine: confirmed.filter(r => r.renter === user?.pubkey),
+    isLoading: query.isLoading,
+    available: (item: RentalItem, startsAt: number, endsAt: number) => availableCount(item, confirmed, startsAt, endsAt),
+    saveInventory: saveInventory.mutateAsync,
+    reserve: (reservation: Pick<RentalReservation, 'itemId' | 'quantity' | 'startsAt' | 'endsAt'>) =>
+      publishReservation.mutateAsync({ ...reservation, reservationId: uuidv4(), cancelled: false }),
+    cancelReservation: (reservation: RentalReservation) => publishReservation.mutateAsync({ ...reservation, cancelled: true }),
+    isPublishing: saveInventory.status === 'pending' || publishReservation.status === 'pending',
+  };
+}
@@ -0,0 +1,26 @@
+import { useQuery } from '@tanstack/react-query';
+import { useNostr } from '@nostrify/react';
+import type { NostrEvent } from '@nostrify/nostrify';
+import { GOLF_KINDS } from '@/lib/golf/types';
+
+/**
+ * The published round event for a round, found by its join-code d tag or
+ * its round-id tag. Comments and reactions on the round hang off this event.
+ */
+export function useRoundEvent(roundId: string | undefined) {
+  const { nostr } = useNostr();
+
+  return useQuery<NostrEvent | null>({
+    queryKey: ['round-event', roundId],
+    queryFn: async (c) => {
+      const signal = AbortSignal.any([c.signal, AbortSignal.timeout(5000)]);
+      const events = await nostr.query([
+        { kinds: [GOLF_KINDS.ROUND], '#d': [roundId!], limit: 5 },
+        { kinds: [GOLF_KINDS.ROUND], '#round-id': [roundId!], limit: 5 },
+      ], { signal });
+      return events.sort((a, b) => b.created_at - a.created_at)[0] ?? null;
+    },
+    enabled: !!roundId,
+    staleTime: 5 * 60 * 1000,
+  });
+}
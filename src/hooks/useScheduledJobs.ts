@@ -0,0 +1,42 @@
+import { useEffect, useState } from 'react';
+import { useNostr } from '@nostrify/react';
+import { GOLF_KINDS } from '@/lib/golf/types';
+import { scheduler, type JobStatus } from '@/lib/scheduler/scheduler';
+
+/**
+ * Hook exposing the status of every job registered with the in-app scheduler
+ * (last run, duration, last error, next run). Re-renders whenever a job starts or finishes.
+ */
+export function useScheduledJobs() {
+  const [jobs, setJobs] = useState<JobStatus[]>(() => scheduler.getStatus());
+
+  useEffect(() => {
+    setJobs(scheduler.getStatus());
+    return scheduler.subscribe(() => setJobs(scheduler.getStatus()));
+  }, []);
+
+  return {
+    jobs,
+    runNow: (id: string) => scheduler.runNow(id),
+  };
+}
+
+/**
+ * Registers the relay health check: a small query against the configured
+ * relays, so a relay that stops answering shows up as a failing job
+ */
+export function useHealthCheckJob() {
+  const { nostr } = useNostr();
+
+  useEffect(() => {
+    return scheduler.register({
+      id: 'relay-health',
+      name: 'Relay health check',
+      schedule: '@every 5m',
+      runOnStart: true,
+      run: async () => {
+        await nostr.query([{ kinds: [GOLF_KINDS.ROUND], limit: 1 }], { signal: AbortSignal.timeout(5000) });
+      },
+    });
+  }, [nostr]);
+}
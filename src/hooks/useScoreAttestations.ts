@@ -0,0 +1,83 @@
+import { useQuery, useMutation, useQueryClient } from '@tanstack/react-query';
+import { useNostr } from '@nostrify/react';
+import { useCurrentUser } from './useCurrentUser';
+import { useNostrPublish } from './useNostrPublish';
+import { GOLF_KINDS } from '@/lib/golf/types';
+import {
+  createAttestationEvent,
+  parseAttestationEvent,
+  parsePlayerScoreEvent,
+  type PlayerScoreRecord,
+} from '@/lib/golf/nostrEvents';
+import { resolveAttestation, type AttestationResult, type ScoreAttestation } from '@/lib/golf/attestationEngine';
+
+export interface CardAttestation extends AttestationResult {
+  card: PlayerScoreRecord;
+}
+
+/**
+ * Hook for the attestation state of every card in a round. The other players
+ * in the round are the markers; `attest` countersigns or disputes another
+ * player's current card as the logged-in marker.
+ */
+export function useScoreAttestations(roundId: string | undefined, players: string[]) {
+  const { nostr } = useNostr();
+  const { user } = useCurrentUser();
+  const { mutateAsync: publishEvent } = useNostrPublish();
+  const queryClient = useQueryClient();
+
+  const query = useQuery({
+    queryKey: ['score-attestations', roundId, players.join(',')],
+    queryFn: async (c) => {
+      const signal = AbortSignal.any([c.signal, AbortSignal.timeout(5000)]);
+      const events = await nostr.query([
+        { kinds: [GOLF_KINDS.PLAYER_SCORE], '#d': [roundId!], authors: players },
+        { kinds: [GOLF_KINDS.SCORE_ATTESTATION], '#round': [roundId!], authors: players },
+      ], { signal });
+
+      const cards = new Map<string, PlayerScoreRecord>();
+      const attestations: ScoreAttestation[] = [];
+      for (const event of events) {
+        if (event.kind === GOLF_KINDS.SCORE_ATTESTATION) {
+          const attestation = parseAttestationEvent(event);
+          if (attestation) attestations.push(attestation);
+          continue;
+        }
+        const card = parsePlayerScoreEvent(event);
+        const existing = card && cards.get(card.playerPubkey);
+        if (card && (!existing || card.updatedAt > existing.updatedAt)) cards.set(card.playerPubkey, card);
+      }
+
+      const result: Record<string, CardAttestation> = {};
+      for (const card of cards.values()) {
+        result[card.playerPubkey] = { card, ...resolveAttestation(card, attestations, players) };
+      }
+      return result;
+    },
+    enabled: !!roundId && players.length > 1,
+    staleTime: 30 * 1000,
+  });
+
+  const attest = useMutation({
+    mutationFn: async (params: { playerPubkey: string; verdict: 'attest' | 'dispute'; comment?: string }) => {
+      if (!user) throw new Error('Must be logged in to attest a card');
+      if (params.playerPubkey === user.pubkey) throw new Error('Players cannot attest their own card');
+      if (!players.includes(user.pubkey)) throw new Error('Only players in the round can mark a card');
+
+      const card = query.data?.[params.playerPubkey]?.card;
+      if (!card) throw new Error('No card found for this player');
+
+      const event = createAttestationEvent(card, user.pubkey, params.verdict, params.comment);
+      return publishEvent({ kind: event.kind, content: event.content, tags: event.tags, created_at: event.created_at });
+    },
+    onSuccess: () => {
+      queryClient.invalidateQueries({ queryKey: ['score-attestations', roundId] });
+    },
+  });
+
+  return {
+    ...query,
+    attest: attest.mutateAsync,
+    isAttesting: attest.status === 'pending',
+  };
+}
@@ -0,0 +1,101 @@
+import { useMutation, useQuery, useQueryClient } from '@tanstack/react-query';
+import { useNostr } from '@nostrify/react';
+import { generateSecretKey, getPublicKey, nip19 } from 'nostr-tools';
+import { useCurrentUser } from './useCurrentUser';
+import { useNostrPublish } from './useNostrPublish';
+import { fetchTournamentDraw, fetchTournamentOrganizer } from './useTournamentDraw';
+import { GOLF_KINDS } from '@/lib/golf/types';
+import { createScorerDeviceEvent, parseScorerDeviceEvent } from '@/lib/golf/nostrEvents';
+import { isDelegationActive } from '@/lib/golf/delegationEngine';
+import { latestDevices, provisioningLink, type DeviceDraw, type ScorerDevice } from '@/lib/golf/deviceEngine';
+
+/**
+ * Scorer devices for a tournament, for its organizer: the published draw,
+ * the devices provisioned for it, `register` to provision a tablet for a
+ * group (returning the link its QR code carries) and `revoke`.
+ */
+export function useScorerDevices(tournamentId: string | undefined) {
+  const { nostr } = useNostr();
+  const { user } = useCurrentUser();
+  const { mutateAsync: publishEvent } = useNostrPublish();
+  const queryClient = useQueryClient();
+  const queryKey = ['scorer-devices', tournamentId];
+
+  const query = useQuery<{ draw: DeviceDraw | null; devices: ScorerDevice[] }>({
+    queryKey,
+    queryFn: async (c) => {
+      const signal = AbortSignal.any([c.signal, AbortSignal.timeout(5000)]);
+      const organizer = await fetchTournamentOrganizer(nostr, tournamentId!, signal);
+      const parsed = organizer ? await fetchTournamentDraw(nostr, tournamentId!, organizer, signal) : null;
+      if (!organizer || !parsed) return { draw: null, devices: [] };
+
+      const events = await nostr.query([{
+        kinds: [GOLF_KINDS.SCORER_DEVICE],
+        authors: [organizer],
+        '#tournament': [tournamentId!],
+      }], { signal });
+
+      return {
+        draw: { tournamentId: parsed.tournamentId, organizer, groups: parsed.groups },
+        devices: latestDevices(events.map(parseScorerDeviceEvent).filter((d): d is ScorerDevice => d !== null)),
+      };
+    },
+    enabled: !!tournamentId,
+  });
+
+  const publish = useMutation({
+    mutationFn: async (device: Omit<ScorerDevice, 'createdAt' | 'issuer'>) => {
+      if (!user) throw new Error('Log in to provision scorer devices');
+      if (query.data?.draw?.organizer !== user.pubkey) throw new Error('Only the tournament organizer can provision devices');
+      const event = createScorerDeviceEvent({ ...device, issuer: user.pubkey });
+      return publishEvent({ kind: event.kind, content: event.content, tags: event.tags, created_at: event.created_at });
+    },
+    onSuccess: () => {
+      queryClient.invalidateQueries({ queryKey });
+    },
+  });
+
+  const now = Math.floor(Date.now() / 1000);
+
+  return {
+    draw: query.data?.draw ?? null,
+    active: (query.data?.devices ?? []).filter(d => isDelegationActive(d, now)),
+    isLoading: query.isLoading,
+    isOrganizer: !!user && query.data?.draw?.organizer === user.pubkey,
+    register: async (group: number, hours: number, label: string) => {
+      const secret = generateSecretKey();
+      const nsec = nip19.nsecEncode(secret);
+      await publish.mutateAsync({
+        device: getPublicKey(secret),
+        tournamentId: tournamentId!,
+        group,
+        since: now,
+        until: now + Math.round(hours * 60 * 60),
+        label,
+      });
+      return provisioningLink(window.location.origin, { nsec, tournamentId: tournamentId!, group });
+    },
+    revoke: (device: ScorerDevice) => publish.mutateAsync({ ...device, until: now }),
+    isPublishing: publish.status === 'pending',
+  };
+}
+
+/**
+ * The active credential this device was provisioned with, if any
+ */
+export function useDeviceCredential(device: string | undefined) {
+  const { nostr } = useNostr();
+
+  return useQuery<ScorerDevice | null>({
+    queryKey: ['device-credential', device],
+    queryFn: async (c) => {
+      const signal = AbortSignal.any([c.signal, AbortSignal.timeout(5000)]);
+      const events = await nostr.query([{ kinds: [GOLF_KINDS.SCORER_DEVICE], '#d': [device!] }], { signal });
+      const now = Math.floor(Date.now() / 1000);
+      return latestDevices(events.map(parseScorerDeviceEvent).filter((d): d is ScorerDevice => d !== null && d.device === device))
+        .find(d => isDelegationActive(d, now)) ?? null;
+    },
+    enabled: !!device,
+    refetchInterval: 60 * 1000,
+  });
+}
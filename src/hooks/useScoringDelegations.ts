@@ -0,0 +1,166 @@
+import { useMutation, useQuery, useQueryClient } from '@tanstack/react-query';
+import { useNostr } from '@nostrify/react';
+import type { NostrEvent, NostrFilter } from '@nostrify/nostrify';
+import { useCurrentUser } from './useCurrentUser';
+import { useNostrPublish } from './useNostrPublish';
+import { fetchTournamentDraw, fetchTournamentOrganizer } from './useTournamentDraw';
+import { GOLF_KINDS } from '@/lib/golf/types';
+import {
+  createScoringDelegationEvent,
+  parseScorerDeviceEvent,
+  parseScoringDelegationEvent,
+} from '@/lib/golf/nostrEvents';
+import { deviceDelegations, type DeviceDraw, type ScorerDevice } from '@/lib/golf/deviceEngine';
+import {
+  isDelegationActive,
+  latestDelegations,
+  playerCardFilters,
+  scoreCardPlayer,
+  type ScoringDelegation,
+} from '@/lib/golf/delegationEngine';
+
+interface NostrLike {
+  query(filters: NostrFilter[], opts?: { signal?: AbortSignal }): Promise<NostrEvent[]>;
+}
+
+function parseDelegations(events: NostrEvent[]): ScoringDelegation[] {
+  return latestDelegations(
+    events.map(parseScoringDelegationEvent).filter((d): d is ScoringDelegation => d !== null)
+  );
+}
+
+/**
+ * Delegations implied by scorer device credentials: each device scores for
+ * the players of its group in the tournament's latest draw, when the
+ * tournament's organizer issued the credential and published the draw
+ */
+async function fetchDeviceDelegations(nostr: NostrLike, devices: string[], signal: AbortSignal): Promise<ScoringDelegation[]> {
+  const credentials = (await nostr.query([{ kinds: [GOLF_KINDS.SCORER_DEVICE], '#d': devices }], { signal }))
+    .map(parseScorerDeviceEvent)
+    .filter((d): d is ScorerDevice => d !== null);
+  if (credentials.length === 0) return [];
+
+  const tournaments = [...new Set(credentials.map(d => d.tournamentId))];
+  const draws = await Promise.all(tournaments.map(async (tournamentId): Promise<DeviceDraw | null> => {
+    const organizer = await fetchTournamentOrganizer(nostr, tournamentId, signal);
+    const draw = organizer ? await fetchTournamentDraw(nostr, tournamentId, organizer, signal) : null;
+    return organizer && draw ? { tournamentId, organizer, groups: draw.groups } : null;
+  }));
+
+  return deviceDelegations(credentials, draws.filter((d): d is DeviceDraw => d !== null));
+}
+
+/**
+ * Score cards credited to their players. Cards a terminal signed for a player
+ * are checked against the player's delegations, and the tournament's scorer
+ * device credentials, and dropped when not covered.
+ */
+export async function resolveScoreCards(
+  nostr: NostrLike,
+  events: NostrEvent[],
+  signal: AbortSignal,
+): Promise<{ event: NostrEvent; player: string }[]> {
+  const delegated = events.filter(e => {
+    const player = e.tags.find(([name]) => name === 'player')?.[1];
+    return player && player !== e.pubkey;
+  });
+
+  let delegations: ScoringDelegation[] = [];
+  if (delegated.length > 0) {
+    const players = [...new Set(delegated.map(e => e.tags.find(([name]) => name === 'player')![1]))];
+    const terminals = [...new Set(delegated.map(e => e.pubkey))];
+    const [granted, devices] = await Promise.all([
+      nostr.query([{ kinds: [GOLF_KINDS.SCORING_DELEGATION], authors: players, '#d': terminals }], { signal }),
+      fetchDeviceDelegations(nostr, terminals, signal),
+    ]);
+    delegations = [...parseDelegations(granted), ...devices];
+  }
+
+  return events.flatMap(event => {
+    const player = scoreCardPlayer(event, delegations);
+    return player ? [{ event, player }] : [];
+  });
+}
+
+/**
+ * Some players' score cards, with the cards terminals signed for them,
+ * credited to their players. `filter` narrows the query, e.g. with `since`.
+ */
+export async function fetchPlayerCards(
+  nostr: NostrLike,
+  pubkeys: string[],
+  filter: NostrFilter,
+  signal: AbortSignal,
+): Promise<{ event: NostrEvent; player: string }[]> {
+  const events = await nostr.query(playerCardFilters(pubkeys, filter), { signal });
+  const unique = [...new Map(events.map(e => [e.id, e])).values()];
+  return (await resolveScoreCards(nostr, unique, signal)).filter(card => pubkeys.includes(card.player));
+}
+
+/**
+ * The logged-in player's grants to scoring terminals, with `grant` and
+ * `revoke`
+ */
+export function useScoringDelegations() {
+  const { nostr } = useNostr();
+  const { user } = useCurrentUser();
+  const { mutateAsync: publishEvent } = useNostrPublish();
+  const queryClient = useQueryClient();
+  const queryKey = ['scoring-delegations', user?.pubkey];
+
+  const delegations = useQuery<ScoringDelegation[]>({
+    queryKey,
+    queryFn: async (c) => {
+      const signal = AbortSignal.any([c.signal, AbortSignal.timeout(5000)]);
+      const events = await nostr.query([{ kinds: [GOLF_KINDS.SCORING_DELEGATION], authors: [user!.pubkey] }], { signal });
+      return parseDelegations(events);
+    },
+    enabled: !!user?.pubkey,
+  });
+
+  const publish = useMutation({
+    mutationFn: async (delegation: Omit<ScoringDelegation, 'createdAt' | 'delegator'>) => {
+      if (!user) throw new Error('Log in to manage scoring terminals');
+      const event = createScoringDelegationEvent({ ...delegation, delegator: user.pubkey });
+      return publishEvent({ kind: event.kind, content: event.content, tags: event.tags, created_at: event.created_at });
+    },
+    onSuccess: () => {
+      queryClient.invalidateQueries({ queryKey });
+    },
+  });
+
+  const now = Math.floor(Date.now() / 1000);
+
+  return {
+    active: (delegations.data ?? []).filter(d => isDelegationActive(d, now)),
+    isLoading: delegations.isLoading,
+    grant: (terminal: string, hours: number, label: string) => publish.mutateAsync({
+      delegatee: terminal,
+      kinds: [GOLF_KINDS.PLAYER_SCORE],
+      since: now,
+      until: now + Math.round(hours * 60 * 60),
+      label,
+    }),
+    revoke: (delegation: ScoringDelegation) => publish.mutateAsync({ ...delegation, until: now }),
+    isPublishing: publish.status === 'pending',
+  };
+}
+
+/**
+ * Players who currently let a terminal post their score cards
+ */
+export function useTerminalDelegations(terminal: string | undefined) {
+  const { nostr } = useNostr();
+
+  return useQuery<ScoringDelegation[]>({
+    queryKey: ['terminal-delegations', terminal],
+    queryFn: async (c) => {
+      const signal = AbortSignal.any([c.signal, AbortSignal.timeout(5000)]);
+      const events = await nostr.query([{ kinds: [GOLF_KINDS.SCORING_DELEGATION], '#p': [terminal!] }], { signal });
+      const now = Math.floor(Date.now() / 1000);
+      return parseDelegations(events).filter(d => d.delegatee === terminal && isDelegationActive(d, now));
+    },
+    enabled: !!terminal,
+    refetchInterval: 60 * 1000,
+  });
+}
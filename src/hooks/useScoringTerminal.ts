@@ -0,0 +1,60 @@
+import { useMemo } from 'react';
+import { useMutation, useQueryClient } from '@tanstack/react-query';
+import { useNostr } from '@nostrify/react';
+import { NSecSigner } from '@nostrify/nostrify';
+import { generateSecretKey, getPublicKey, nip19 } from 'nostr-tools';
+import { useLocalStorage } from './useLocalStorage';
+import { GOLF_KINDS } from '@/lib/golf/types';
+import { versionTag } from '@/lib/sync/liveScoring';
+
+const TERMINAL_KEY = 'scoring-terminal:nsec';
+
+/**
+ * This device as a scoring terminal. The terminal has its own key, kept in
+ * local storage, and signs score cards for players who delegated to it,
+ * one card per player in the round.
+ * A tablet provisioned by a tournament organizer is given its key instead.
+ */
+export function useScoringTerminal() {
+  const { nostr } = useNostr();
+  const queryClient = useQueryClient();
+  const [nsec, setNsec] = useLocalStorage<string | null>(TERMINAL_KEY, null);
+
+  const terminal = useMemo(() => {
+    if (!nsec) return null;
+    try {
+      const decoded = nip19.decode(nsec);
+      if (decoded.type !== 'nsec') return null;
+      return { pubkey: getPublicKey(decoded.data), signer: new NSecSigner(decoded.data) };
+    } catch {
+      return null;
+    }
+  }, [nsec]);
+
+  const publishScore = useMutation({
+    mutationFn: async ({ player, roundId, scores }: { player: string; roundId: string; scores: Record<string, number> }) => {
+      if (!terminal) throw new Error('Set up this device as a scoring terminal first');
+      const total = Object.values(scores).reduce((sum, s) => sum + s, 0);
+      const event = await terminal.signer.signEvent({
+        kind: GOLF_KINDS.PLAYER_SCORE,
+        content: JSON.stringify({ scores, total, updatedAt: Date.now() }),
+        tags: [['d', `${roundId}:${player}`], ['round', roundId], ['player', player], versionTag()],
+        created_at: Math.floor(Date.now() / 1000),
+      });
+      await nostr.event(event, { signal: AbortSignal.timeout(5000) });
+      return event;
+    },
+    onSuccess: (_, { roundId }) => {
+      queryClient.invalidateQueries({ queryKey: ['player-scores', roundId] });
+    },
+  });
+
+  return {
+    pubkey: terminal?.pubkey,
+    setUp: () => setNsec(nip19.nsecEncode(generateSecretKey())),
+    provision: (deviceNsec: string) => setNsec(deviceNsec),
+    reset: () => setNsec(null),
+    publishScore: publishScore.mutateAsync,
+    isPublishing: publishScore.status === 'pending',
+  };
+}
@@ -0,0 +1,234 @@
+import { useEffect, useMemo, useState } from 'react';
+import { useNostr } from '@nostrify/react';
+import { GOLF_KINDS } from '@/lib/golf/types';
+import { resolveScoreCards } from './useScoringDelegations';
+import {
+  applyEclecticRound,
+  applyMeritEvent,
+  createEclecticState,
+  createOrderOfMeritState,
+  getEclecticLeaderboard,
+  getOrderOfMerit,
+  roundMeritEvent,
+  type EclecticState,
+  type OrderOfMeritState,
+  type SeasonConfig,
+  type SeasonRound
+} from '@/lib/golf/seasonEngine';
+import { parseAttestationEvent, parseRulingEvent } from '@/lib/golf/nostrEvents';
+import { hostedRounds, resolveAttestation, type ScoreAttestation } from '@/lib/golf/attestationEngine';
+import { applyRuling, latestRulings, type Ruling } from '@/lib/golf/disputeEngine';
+import { playerCardFilters, scoreCardRound } from '@/lib/golf/delegationEngine';
+import type { NostrEvent, NostrFilter } from '@nostrify/nostrify';
+
+type Card = { event: NostrEvent; player: string };
+type RoundInfo = { course?: string; finished: boolean };
+
+function toSeasonRound({ event, player }: Card, info?: RoundInfo): SeasonRound | null {
+  const roundId = scoreCardRound(event);
+  if (!roundId) return null;
+
+  try {
+    const parsed = JSON.parse(event.content || '{}');
+    const scores: { [hole: number]: number } = {};
+    for (const [hole, score] of Object.entries(parsed.scores || {})) {
+      if (typeof score === 'number') scores[Number(hole)] = score;
+    }
+    return { roundId, playerId: player, date: event.created_at * 1000, scores, course: info?.course };
+  } catch {
+    return null;
+  }
+}
+
+/**
+ * Live eclectic and order of merit for a season. Loads the players' score
+ * events for the season, then keeps a subscription open and applies new score
+ * events incrementally. Each card's course comes from its round, as published
+ * by the round's host; once the host marks a round completed, its complete
+ * cards are placed on gross score and scored into the order of merit.
+ * With `requireAttestation`, a card is only applied once a fellow competitor
+ * has countersigned its current scores. Rulings from the season's `committee`
+ * correct or void cards, and the leaderboard is recomputed.
+ * `config` should be memoized by the caller.
+ */
+export function useSeasonEclectic(config: SeasonConfig | undefined, players: string[]) {
+  const { nostr } = useNostr();
+  const [state, setState] = useState<EclecticState | null>(null);
+  const [merit, setMerit] = useState<OrderOfMeritState | null>(null);
+  const [isLoading, setIsLoading] = useState(false);
+  const playersKey = players.join(',');
+
+  useEffect(() => {
+    const authors = playersKey ? playersKey.split(',') : [];
+    if (!config || authors.length === 0) {
+      setState(null);
+      setMerit(null);
+      return;
+    }
+
+    const controller = new AbortController();
+    const season = {
+      since: Math.floor(config.startDate / 1000),
+      until: Math.floor(config.endDate / 1000),
+    };
+    // Markers may countersign after the season ends
+    const attestationFilter = {
+      kinds: [GOLF_KINDS.SCORE_ATTESTATION],
+      '#p': authors,
+      since: Math.floor(config.startDate / 1000),
+    };
+
+    // Committee rulings correct or void cards, and recompute the leaderboard
+    const rulingFilter = {
+      kinds: [GOLF_KINDS.RULING],
+      authors: config.committee ?? [],
+      '#p': authors,
+      since: Math.floor(config.startDate / 1000),
+    };
+
+    const extraFilters: NostrFilter[] = [
+      ...(config.requireAttestation ? [attestationFilter] : []),
+      ...(config.committee?.length ? [rulingFilter] : []),
+    ];
+
+    const pending = new Map<string, Card>(); // cards awaiting attestation
+    const applied = new Map<string, Card>(); // latest applied card per player and round
+    const attestations: ScoreAttestation[] = [];
+    const rulings: Ruling[] = [];
+    const roundInfo = new Map<string, RoundInfo>(); // course and status from each round's host
+    const roundCards = new Map<string, Map<string, SeasonRound>>(); // applied rounds per round id and player
+    const cardKey = (card: Card) => `${card.player}:${scoreCardRound(card.event)}`;
+
+    const releaseAttested = (received: Card[], ruled: Map<string, Ruling>): Card[] => {
+      for (const card of received) {
+        const existing = pending.get(cardKey(card));
+        if (!existing || card.event.created_at >= existing.event.created_at) pending.set(cardKey(card), card);
+      }
+
+      const ready: Card[] = [];
+      for (const [key, card] of pending) {
+        const round = toSeasonRound(card, roundInfo.get(scoreCardRound(card.event) ?? ''));
+        const markers = authors.filter(p => p !== card.player);
+        if (!round || ruled.has(`${round.roundId}:${round.playerId}`) || resolveAttestation(
+          { roundId: round.roundId, playerPubkey: round.playerId, scores: round.scores },
+          attestations,
+          markers
+        ).status === 'attested') {
+          pending.delete(key);
+          if (round) ready.push(card);
+        }
+      }
+      return ready;
+    };
+
+    // Score cards arrive credited to their players; other events are attestations and rulings
+    const apply = (received: NostrEvent[], credited: Card[] = []) => {
+      const cards: Card[] = [...credited];
+      for (const event of received) {
+        if (event.kind === GOLF_KINDS.SCORE_ATTESTATION) {
+          const attestation = parseAttestationEvent(event);
+          if (attestation) attestations.push(attestation);
+        } else if (event.kind === GOLF_KINDS.RULING) {
+          const ruling = parseRulingEvent(event);
+          if (!ruling) continue;
+          rulings.push(ruling);
+          // Re-apply the cards the ruling changes
+          for (const player of ruling.players) {
+            const card = applied.get(`${player}:${ruling.roundId}`) ?? pending.get(`${player}:${ruling.roundId}`);
+            if (card) cards.push(card);
+          }
+        }
+      }
+
+      const ruled = latestRulings(rulings, config.committee ?? []);
+      // Pending attestations are re-checked as attestations arrive
+      const ready = config.requireAttestation ? releaseAttested(cards, ruled) : cards;
+
+      const rounds: SeasonRound[] = [];
+      for (const card of ready) {
+        const round = toSeasonRound(card, roundInfo.get(scoreCardRound(card.event) ?? ''));
+        if (!round) continue;
+        applied.set(cardKey(card), card);
+        const ruling = ruled.get(`${round.roundId}:${round.playerId}`);
+        rounds.push({ ...round, scores: applyRuling(round.playerId, round.scores, ruling) });
+      }
+
+      setState(prev => rounds.reduce(applyEclecticRound, prev ?? createEclecticState(config)));
+
+      // Re-place the finished rounds these cards belong to
+      for (const round of rounds) {
+        roundCards.set(round.roundId, new Map(roundCards.get(round.roundId)).set(round.playerId, round));
+      }
+      const events = [...new Set(rounds.map(r => r.roundId))]
+        .filter(roundId => roundInfo.get(roundId)?.finished)
+        .map(roundId => roundMeritEvent(config, roundInfo.get(roundId)?.course ?? roundId, [...roundCards.get(roundId)!.values()]))
+        .filter((event): event is NonNullable<typeof event> => event !== null);
+      setMerit(prev => events.reduce(applyMeritEvent, prev ?? createOrderOfMeritState(config.merit)));
+    };
+
+    // Rounds are only taken from their host, so nobody can move a card to another course
+    const loadRounds = async (roundIds: string[], signal: AbortSignal) => {
+      if (roundIds.length === 0) return;
+      const events = await nostr.query([{ kinds: [GOLF_KINDS.ROUND], '#round-id': roundIds }], { signal });
+      for (const [roundId, event] of hostedRounds(events)) {
+        const tag = (name: string) => event.tags.find(t => t[0] === name)?.[1];
+        roundInfo.set(roundId, { course: tag('course'), finished: tag('status') === 'completed' });
+      }
+    };
+
+    (async () => {
+      setIsLoading(true);
+      setState(createEclecticState(config));
+      setMerit(createOrderOfMeritState(config.merit));
+      try {
+        const signal = AbortSignal.any([controller.signal, AbortSignal.timeout(5000)]);
+        const events = await nostr.query([...playerCardFilters(authors, { ...season, limit: 1000 }), ...extraFilters], { signal });
+        const scoreEvents = [...new Map(events.filter(e => e.kind === GOLF_KINDS.PLAYER_SCORE).map(e => [e.id, e])).values()];
+        const credited = (await resolveScoreCards(nostr, scoreEvents, signal)).filter(card => authors.includes(card.player));
+        await loadRounds([...new Set(credited.flatMap(card => scoreCardRound(card.event) ?? []))], signal);
+        // Oldest first so corrected score events replace earlier versions
+        apply(
+          events.filter(e => e.kind !== GOLF_KINDS.PLAYER_SCORE),
+          credited.sort((a, b) => a.event.created_at - b.event.created_at)
+        );
+      } catch (err) {
+        if (!controller.signal.aborted) console.warn('Failed to load season scores', err);
+      } finally {
+        setIsLoading(false);
+      }
+
+      if (controller.signal.aborted || Date.now() > config.endDate) return;
+
+      try {
+        const since = Math.floor(Date.now() / 1000);
+        const subscription = nostr.req(
+          [...playerCardFilters(authors, { ...season, since }), ...extraFilters.map(f => ({ ...f, since }))],
+          { signal: controller.signal }
+        );
+        for await (const msg of subscription) {
+          if (msg[0] === 'CLOSED') break;
+          if (msg[0] !== 'EVENT') continue;
+          const event = msg[2];
+          if (event.kind !== GOLF_KINDS.PLAYER_SCORE) {
+            apply([event]);
+            continue;
+          }
+          const signal = AbortSignal.any([controller.signal, AbortSignal.timeout(5000)]);
+          const credited = (await resolveScoreCards(nostr, [event], signal)).filter(card => authors.includes(card.player));
+          // Refreshed on every card, as hosts mark the round completed around the final cards
+          await loadRounds([...new Set(credited.flatMap(card => scoreCardRound(card.event) ?? []))], signal);
+          apply([], credited);
+        }
+      } catch (err) {
+        if (!controller.signal.aborted) console.warn('Season score subscription ended', err);
+      }
+    })();
+
+    return () => controller.abort();
+  }, [nostr, config, playersKey]);
+
+  const leaderboard = useMemo(() => (state ? getEclecticLeaderboard(state) : []), [state]);
+  const orderOfMerit = useMemo(() => (merit ? getOrderOfMerit(merit) : []), [merit]);
+
+  return { state, leaderboard, orderOfMerit, isLoading };
+}
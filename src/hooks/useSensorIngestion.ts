@@ -0,0 +1,26 @@
+import { useCurrentUser } from './useCurrentUser';
+import { useGolfBag } from './useGolfBag';
+import { DEFAULT_BAG } from '@/lib/golf/bagEngine';
+import { ingestSensorBatches, type SensorBatch, type SensorIngest } from '@/lib/golf/sensorEngine';
+
+/**
+ * Hook for pushing sensor data into shot tracking. Any device (rangefinder,
+ * ball tracker, smart grip) hands over batches of measurements with its
+ * device id; `ingest` turns them into shots against the player's bag and
+ * records them, optionally against a round. Shots are keyed by device and
+ * time, so a batch sent twice doesn't record its shots twice.
+ */
+export function useSensorIngestion() {
+  const { user } = useCurrentUser();
+  const { data: bag, recordShot, isSaving } = useGolfBag(user?.pubkey);
+
+  const ingest = async (batches: SensorBatch[], roundId?: string): Promise<SensorIngest> => {
+    const result = ingestSensorBatches(batches, bag?.clubs ?? DEFAULT_BAG);
+    for (const { deviceId: _deviceId, ...shot } of result.shots) {
+      await recordShot({ ...shot, roundId });
+    }
+    return result;
+  };
+
+  return { ingest, isIngesting: isSaving };
+}
@@ -0,0 +1,41 @@
+import { useQuery } from '@tanstack/react-query';
+import { useNostr } from '@nostrify/react';
+import { GOLF_KINDS } from '@/lib/golf/types';
+import { parseShotEvent, type ShotDetails } from '@/lib/golf/nostrEvents';
+import type { ShotSample } from '@/lib/golf/bagEngine';
+import { buildReplay, replayDuration, type ReplayHole } from '@/lib/golf/replayEngine';
+
+/**
+ * Hook for replaying a player's round: their shots from the round, laid out
+ * hole by hole for the shot tracer. Shots stripped of positions (by the
+ * player's retention settings) drop out of the replay.
+ */
+export function useShotReplay(roundId: string | undefined, pubkey: string | undefined) {
+  const { nostr } = useNostr();
+
+  return useQuery<{ holes: ReplayHole[]; duration: number; shots: number }>({
+    queryKey: ['shot-replay', roundId, pubkey],
+    queryFn: async (c) => {
+      const signal = AbortSignal.any([c.signal, AbortSignal.timeout(5000)]);
+      const events = await nostr.query([
+        { kinds: [GOLF_KINDS.SHOT], authors: [pubkey!], '#round': [roundId!], limit: 300 },
+      ], { signal });
+
+      // Latest version of each shot, in case it was edited or anonymized
+      const latest = new Map<string, { createdAt: number; shot: ShotSample & ShotDetails }>();
+      for (const event of events) {
+        const shot = parseShotEvent(event);
+        const existing = shot && latest.get(shot.shotId);
+        if (shot && (!existing || event.created_at > existing.createdAt)) {
+          latest.set(shot.shotId, { createdAt: event.created_at, shot });
+        }
+      }
+
+      const shots = [...latest.values()].map(s => s.shot);
+      const holes = buildReplay(shots);
+      return { holes, duration: replayDuration(holes), shots: shots.length };
+    },
+    enabled: !!roundId && !!pubkey,
+    staleTime: 5 * 60 * 1000,
+  });
+}
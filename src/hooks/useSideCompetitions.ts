@@ -0,0 +1,88 @@
+import { useEffect } from 'react';
+import { useQuery, useQueryClient } from '@tanstack/react-query';
+import { useNostr } from '@nostrify/react';
+import { GOLF_KINDS, type GolfRound } from '@/lib/golf/types';
+import { parseShotEvent } from '@/lib/golf/nostrEvents';
+import { sideCompetitionPrizes, sideStandings, type SideShot } from '@/lib/golf/sideCompetitionEngine';
+import type { GeoPoint } from '@/lib/golf/caddieEngine';
+
+// Shots from this long before the round date can belong to it
+const SHOT_LEAD_SECONDS = 12 * 60 * 60;
+
+/**
+ * Live closest-to-the-pin and longest-drive standings for a round, fed by
+ * the players' GPS shot events. Leaders update as shots are recorded; once
+ * the round is closed (completed, or every player has a score on every
+ * hole) the leaders are the winners and `prizes` holds what they won.
+ */
+export function useSideCompetitions(round: Partial<GolfRound> | undefined, greens?: { [hole: number]: GeoPoint }) {
+  const { nostr } = useNostr();
+  const queryClient = useQueryClient();
+  const competitions = round?.metadata?.sideCompetitions ?? [];
+  const roundId = round?.id;
+  const playersKey = (round?.players ?? []).map(p => p.playerId).filter(Boolean).join(',');
+  const since = round?.date ? Math.floor(round.date / 1000) - SHOT_LEAD_SECONDS : undefined;
+
+  const query = useQuery<SideShot[]>({
+    queryKey: ['side-competition-shots', roundId, playersKey],
+    queryFn: async (c) => {
+      const signal = AbortSignal.any([c.signal, AbortSignal.timeout(5000)]);
+      const events = await nostr.query([{
+        kinds: [GOLF_KINDS.SHOT],
+        authors: playersKey.split(','),
+        since,
+        limit: 500,
+      }], { signal });
+
+      return events.flatMap(event => {
+        const shot = parseShotEvent(event);
+        if (!shot || shot.roundId !== roundId || !shot.hole) return [];
+        return [{ playerPubkey: event.pubkey, hole: shot.hole, distance: shot.distance, end: shot.end, timestamp: shot.timestamp }];
+      });
+    },
+    enabled: !!roundId && !!playersKey && competitions.length > 0,
+    refetchInterval: 5 * 60 * 1000,
+  });
+
+  // Live updates: refetch when a player records a shot
+  const live = !!roundId && !!playersKey && competitions.length > 0;
+  useEffect(() => {
+    if (!live) return;
+    const controller = new AbortController();
+
+    (async () => {
+      try {
+        const subscription = nostr.req([{
+          kinds: [GOLF_KINDS.SHOT],
+          authors: playersKey.split(','),
+          since: Math.floor(Date.now() / 1000),
+        }], { signal: controller.signal });
+        for await (const msg of subscription) {
+          if (msg[0] === 'CLOSED') break;
+          if (msg[0] === 'EVENT') queryClient.invalidateQueries({ queryKey: ['side-competition-shots', roundId] });
+        }
+      } catch (err) {
+        if (!controller.signal.aborted) console.warn('Side competition subscription ended', err);
+      }
+    })();
+
+    return () => controller.abort();
+  }, [nostr, queryClient, roundId, playersKey, live]);
+
+  // A drive only counts if the player didn't record missing the fairway
+  // (hole details are kept by hole index)
+  const missedFairways = (round?.players ?? []).flatMap(p =>
+    Object.entries(p.holeDetails ?? {})
+      .filter(([, d]) => d.fairways === false && !!d.fairwayMissSide)
+      .map(([index]) => `${p.playerId}:${round?.holes?.[Number(index)]?.holeNumber ?? Number(index) + 1}`)
+  );
+
+  const standings = sideStandings(competitions, query.data ?? [], greens, missedFairways);
+  const holeCount = round?.holes?.length ?? 0;
+  const isFinal = round?.status === 'completed' || (holeCount > 0 && (round?.players ?? []).every(p =>
+    Array.from({ length: holeCount }, (_, i) => p.scores?.[i] ?? 0).every(score => score > 0)
+  ));
+  const prizes = roundId && isFinal ? sideCompetitionPrizes(standings, roundId) : [];
+
+  return { ...query, standings, isFinal, prizes };
+}
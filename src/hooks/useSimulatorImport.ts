@@ -0,0 +1,61 @@
+import { useMutation, useQueryClient } from '@tanstack/react-query';
+import { useCurrentUser } from './useCurrentUser';
+import { useGolfBag } from './useGolfBag';
+import { useNostrPublish } from './useNostrPublish';
+import { usePracticeSessions } from './usePracticeSessions';
+import { GOLF_KINDS } from '@/lib/golf/types';
+import { DEFAULT_BAG } from '@/lib/golf/bagEngine';
+import { launchMonitorSession } from '@/lib/golf/launchMonitorImport';
+import { parseSimulatorRound, type SimulatorRound } from '@/lib/golf/simulatorImport';
+import { versionTag } from '@/lib/sync/liveScoring';
+import { v4 as uuidv4 } from 'uuid';
+
+/**
+ * Hook for importing simulator rounds (GSPro, TrackMan). `preview` parses an
+ * export; `apply` publishes the card as an indoor round, which the handicap
+ * leaves out unless the player opts in, and saves any full swings as a
+ * simulator practice session.
+ */
+export function useSimulatorImport() {
+  const { user } = useCurrentUser();
+  const { data: bag } = useGolfBag(user?.pubkey);
+  const { saveSession } = usePracticeSessions(user?.pubkey);
+  const { mutateAsync: publishEvent } = useNostrPublish();
+  const queryClient = useQueryClient();
+
+  const preview = (text: string) => parseSimulatorRound(text, bag?.clubs ?? DEFAULT_BAG);
+
+  const apply = useMutation({
+    mutationFn: async (round: SimulatorRound) => {
+      if (!user) throw new Error('Log in to import simulator rounds');
+      if (round.holes.length === 0) throw new Error('No holes to import');
+
+      const roundId = `sim-${uuidv4()}`;
+      const event = await publishEvent({
+        kind: GOLF_KINDS.PLAYER_SCORE,
+        content: JSON.stringify({ holes: round.holes, updatedAt: round.date }),
+        tags: [
+          ['d', roundId],
+          ['player', user.pubkey],
+          ...(round.course ? [['course', round.course]] : []),
+          ['origin', 'simulator'],
+          ['simulator', round.source],
+          ['t', 'indoor'],
+          versionTag(),
+        ],
+        created_at: Math.floor(round.date / 1000),
+      });
+
+      const practice = launchMonitorSession(round.shots, roundId, 'simulator');
+      if (practice.blocks.length > 0) await saveSession(practice);
+
+      return event;
+    },
+    onSuccess: () => {
+      queryClient.invalidateQueries({ queryKey: ['handicap-calculation'] });
+      queryClient.invalidateQueries({ queryKey: ['handicap-history'] });
+    },
+  });
+
+  return { preview, apply: apply.mutateAsync, isApplying: apply.status === 'pending' };
+}
@@ -0,0 +1,100 @@
+import { useCallback, useState } from 'react';
+import { useQuery, useMutation, useQueryClient } from '@tanstack/react-query';
+import { useNostr } from '@nostrify/react';
+import { useCurrentUser } from './useCurrentUser';
+import { useNostrPublish } from './useNostrPublish';
+import { GOLF_KINDS } from '@/lib/golf/types';
+import { createSponsorSlotEvent, parseSponsorSlotEvent } from '@/lib/golf/nostrEvents';
+import { countImpression, validateSponsorSlot, type SponsorImpressions, type SponsorSlot } from '@/lib/golf/sponsorEngine';
+import type { GolfCourse } from './useGolfCourses';
+import { v4 as uuidv4 } from 'uuid';
+
+/**
+ * Hook for a club's sponsor slots. Only slots published by the course's
+ * author count, so anyone else tagging the course can't place banners.
+ */
+export function useSponsorSlots(course: Pick<GolfCourse, 'id' | 'author'> | undefined) {
+  const { nostr } = useNostr();
+  const { user } = useCurrentUser();
+  const { mutateAsync: publishEvent } = useNostrPublish();
+  const queryClient = useQueryClient();
+
+  const query = useQuery<SponsorSlot[]>({
+    queryKey: ['sponsor-slots', course?.author, course?.id],
+    queryFn: async (c) => {
+      const signal = AbortSignal.any([c.signal, AbortSignal.timeout(5000)]);
+      const events = await nostr.query([{
+        kinds: [GOLF_KINDS.SPONSOR_SLOT],
+        authors: [course!.author],
+        '#a': [`${GOLF_KINDS.COURSE}:${course!.author}:${course!.id}`],
+        limit: 100,
+      }], { signal });
+
+      // Latest version of each slot
+      const slots = new Map<string, { slot: SponsorSlot; createdAt: number }>();
+      for (const event of events) {
+        const slot = parseSponsorSlotEvent(event);
+        if (!slot) continue;
+        const existing = slots.get(slot.slotId);
+        if (!existing || event.created_at > existing.createdAt) slots.set(slot.slotId, { slot, createdAt: event.created_at });
+      }
+      return [...slots.values()].map(s => s.slot).sort((a, b) => b.startsAt - a.startsAt);
+    },
+    enabled: !!course?.author && !!course?.id,
+    staleTime: 10 * 60 * 1000,
+  });
+
+  const save = useMutation({
+    mutationFn: async (slot: Omit<SponsorSlot, 'slotId' | 'courseId'> & { slotId?: string }) => {
+      if (!user) throw new Error('Must be logged in to manage sponsors');
+      if (!course || course.author !== user.pubkey) throw new Error('Only the course author can manage its sponsors');
+
+      const full: SponsorSlot = { ...slot, slotId: slot.slotId ?? uuidv4(), courseId: course.id };
+      const errors = validateSponsorSlot(full);
+      if (errors.length > 0) throw new Error(errors[0]);
+
+      const event = createSponsorSlotEvent(full, user.pubkey);
+      await publishEvent({ kind: event.kind, content: event.content, tags: event.tags, created_at: event.created_at });
+      return full;
+    },
+    onSuccess: () => {
+      queryClient.invalidateQueries({ queryKey: ['sponsor-slots', course?.author, course?.id] });
+    },
+  });
+
+  return {
+    ...query,
+    saveSlot: save.mutateAsync,
+    isSaving: save.status === 'pending',
+  };
+}
+
+const IMPRESSIONS_KEY = 'sponsor-impressions';
+
+function loadImpressions(): SponsorImpressions {
+  try {
+    return JSON.parse(localStorage.getItem(IMPRESSIONS_KEY) || '{}');
+  } catch {
+    return {};
+  }
+}
+
+/**
+ * Impressions of each sponsor slot on this device. Counted locally: the
+ * display showing the banner is the one that knows it was seen.
+ */
+export function useSponsorImpressions() {
+  const [impressions, setImpressions] = useState<SponsorImpressions>(loadImpressions);
+
+  const recordImpression = useCallback((slotId: string) => {
+    const next = countImpression(loadImpressions(), slotId, Date.now());
+    try {
+      localStorage.setItem(IMPRESSIONS_KEY, JSON.stringify(next));
+    } catch (error) {
+      console.warn('Failed to save sponsor impressions:', error);
+    }
+    setImpressions(next);
+  }, []);
+
+  return { impressions, recordImpression };
+}
@@ -0,0 +1,32 @@
+import { useMemo } from 'react';
+import { useCourseScoreSamples } from './useCourseScoreSamples';
+import {
+  recommendStrokeIndexes,
+  type HoleScoreSample,
+  type StrokeIndexConfig,
+  type StrokeIndexHole,
+} from '@/lib/golf/strokeIndexEngine';
+
+/**
+ * Hook for committees re-rating a course's stroke index table from
+ * the scores submitted at that course.
+ */
+export function useStrokeIndexRecommendation(
+  courseName: string | undefined,
+  holes: StrokeIndexHole[],
+  config: StrokeIndexConfig = {}
+) {
+  const { data: records, isLoading, error } = useCourseScoreSamples(courseName);
+
+  const report = useMemo(() => {
+    if (!records || holes.length !== 18) return null;
+
+    const samples: HoleScoreSample[] = records.flatMap(r =>
+      Object.entries(r.scores).map(([hole, strokes]) => ({ hole: Number(hole), strokes }))
+    );
+
+    return recommendStrokeIndexes(holes, samples, config);
+  }, [records, holes, config]);
+
+  return { data: report, isLoading, error };
+}
@@ -0,0 +1,63 @@
+import { useMutation, useQuery, useQueryClient } from '@tanstack/react-query';
+import { useNostr } from '@nostrify/react';
+import { useCurrentUser } from './useCurrentUser';
+import { useNostrPublish } from './useNostrPublish';
+import { GOLF_KINDS } from '@/lib/golf/types';
+import { createTeamEventEvent, parseTeamEventEvent } from '@/lib/golf/nostrEvents';
+import { validateTeamEvent, type TeamEvent, type TeamEventConfig } from '@/lib/golf/teamEventEngine';
+
+/**
+ * A published team event (Ryder Cup style): its teams, session line-ups and
+ * the round each session is played in. The organizer `create`s it, and
+ * republishing replaces the line-ups.
+ */
+export function useTeamEvent(eventId: string | undefined) {
+  const { nostr } = useNostr();
+  const { user } = useCurrentUser();
+  const { mutateAsync: publishEvent } = useNostrPublish();
+  const queryClient = useQueryClient();
+  const queryKey = ['team-event', eventId];
+
+  const query = useQuery<TeamEvent | null>({
+    queryKey,
+    queryFn: async (c) => {
+      const signal = AbortSignal.any([c.signal, AbortSignal.timeout(5000)]);
+      const versions = await nostr.query([{
+        kinds: [GOLF_KINDS.TEAM_EVENT],
+        '#d': [eventId!],
+        limit: 10,
+      }], { signal });
+
+      // The organizer is the event id's only author; a contested id has no event
+      const organizers = new Set(versions.map(e => e.pubkey));
+      const [latest] = organizers.size === 1 ? versions.sort((a, b) => b.created_at - a.created_at) : [];
+      return latest ? parseTeamEventEvent(latest) : null;
+    },
+    enabled: !!eventId,
+    staleTime: 60 * 1000,
+  });
+
+  const publish = useMutation({
+    mutationFn: async (params: { config: TeamEventConfig; sessionRounds: Record<string, string> }) => {
+      if (!user) throw new Error('Log in to organize a team event');
+      if (query.data && query.data.organizer !== user.pubkey) throw new Error('Only the organizer can change the event');
+      const [problem] = validateTeamEvent(params.config);
+      if (problem) throw new Error(problem);
+      const missing = params.config.sessions.find(s => !params.sessionRounds[s.id]);
+      if (missing) throw new Error(`${missing.name} needs the round it is played in`);
+
+      const event = createTeamEventEvent({ eventId: eventId!, organizer: user.pubkey, ...params });
+      return publishEvent({ kind: event.kind, content: event.content, tags: event.tags, created_at: event.created_at });
+    },
+    onSuccess: () => {
+      queryClient.invalidateQueries({ queryKey });
+    },
+  });
+
+  return {
+    ...query,
+    isOrganizer: !!user && query.data?.organizer === user.pubkey,
+    create: publish.mutateAsync,
+    isPublishing: publish.status === 'pending',
+  };
+}
@@ -0,0 +1,72 @@
+import { useQuery } from '@tanstack/react-query';
+import { useNostr } from '@nostrify/react';
+import { scoreCardFilters, scoreCardRound } from '@/lib/golf/delegationEngine';
+import { type CoreRoundData } from '@/lib/golf/strokeEngine';
+import { teamEventEngine, type TeamEventConfig } from '@/lib/golf/teamEventEngine';
+import { resolveScoreCards } from './useScoringDelegations';
+import type { NostrEvent } from '@nostrify/nostrify';
+
+/**
+ * Hook for a live team event (Ryder Cup style) scoreboard.
+ * Each session is played as its own round; the players' PLAYER_SCORE events
+ * for those rounds drive the session and match results.
+ *
+ * @param sessionRounds - map of session id to the round id it is being played in
+ */
+export function useTeamEventScoreboard(
+  config: TeamEventConfig | undefined,
+  sessionRounds: Record<string, string>
+) {
+  const { nostr } = useNostr();
+  const roundIds = Object.values(sessionRounds);
+
+  return useQuery({
+    queryKey: ['team-event-scoreboard', config?.name, sessionRounds],
+    queryFn: async (c) => {
+      if (!config) return null;
+
+      const signal = AbortSignal.any([c.signal, AbortSignal.timeout(5000)]);
+      const events = await nostr.query(scoreCardFilters(roundIds, { limit: 500 }), { signal });
+      const unique = [...new Map(events.map(e => [e.id, e])).values()];
+
+      // Keep the latest score event per round and player, crediting terminal-signed cards to their player
+      const latest = new Map<string, { event: NostrEvent; player: string }>();
+      for (const card of await resolveScoreCards(nostr, unique, signal)) {
+        const roundId = scoreCardRound(card.event);
+        if (!roundId) continue;
+        const key = `${roundId}:${card.player}`;
+        const existing = latest.get(key);
+        if (!existing || card.event.created_at > existing.event.created_at) {
+          latest.set(key, card);
+        }
+      }
+
+      const roundData: Record<string, CoreRoundData> = {};
+      for (const [sessionId, roundId] of Object.entries(sessionRounds)) {
+        const data: CoreRoundData = { players: [], strokes: {}, course: { holes: {} } };
+
+        for (const { event, player } of latest.values()) {
+          if (scoreCardRound(event) !== roundId) continue;
+          try {
+            const parsed = JSON.parse(event.content || '{}');
+            const strokes: { [hole: number]: number } = {};
+            for (const [hole, score] of Object.entries(parsed.scores || {})) {
+              if (typeof score === 'number') strokes[Number(hole)] = score;
+            }
+            data.players.push(player);
+            data.strokes[player] = strokes;
+          } catch (err) {
+            console.warn('Failed to parse player-score content', err);
+          }
+        }
+
+        roundData[sessionId] = data;
+      }
+
+      return teamEventEngine(config, roundData);
+    },
+    enabled: !!config && roundIds.length > 0,
+    staleTime: 5 * 1000,
+    refetchInterval: 5000,
+  });
+}
@@ -0,0 +1,154 @@
+import { useQuery, useMutation, useQueryClient } from '@tanstack/react-query';
+import { useNostr } from '@nostrify/react';
+import type { NostrEvent, NostrFilter } from '@nostrify/nostrify';
+import { useCurrentUser } from './useCurrentUser';
+import { useNostrPublish } from './useNostrPublish';
+import { GOLF_KINDS } from '@/lib/golf/types';
+import { createDrawEvent, parseDrawEvent } from '@/lib/golf/nostrEvents';
+import { drawConflicts, generateDraw, shiftDraw, type Draw, type DrawConfig, type DrawPlayer } from '@/lib/golf/drawEngine';
+
+interface NostrLike {
+  query(filters: NostrFilter[], opts?: { signal?: AbortSignal }): Promise<NostrEvent[]>;
+}
+
+/**
+ * The tournament's organizer: whoever first published the round it was set
+ * up from, or its tournament event. Events reusing the id from other keys
+ * are ignored, so nobody can take over someone else's tournament.
+ */
+export async function fetchTournamentOrganizer(nostr: NostrLike, tournamentId: string, signal: AbortSignal): Promise<string | null> {
+  const events = await nostr.query([
+    { kinds: [GOLF_KINDS.ROUND], '#round-id': [tournamentId] },
+    { kinds: [GOLF_KINDS.TOURNAMENT], '#d': [`${tournamentId}-tournament`] },
+  ], { signal });
+
+  const [first] = events.sort((a, b) => a.created_at - b.created_at);
+  return first?.pubkey ?? null;
+}
+
+/** The organizer's latest draw for a tournament */
+export async function fetchTournamentDraw(
+  nostr: NostrLike,
+  tournamentId: string,
+  organizer: string,
+  signal: AbortSignal,
+): Promise<(Draw & { tournamentId: string }) | null> {
+  const [latest] = (await nostr.query([{
+    kinds: [GOLF_KINDS.DRAW],
+    authors: [organizer],
+    '#d': [`${tournamentId}-draw`],
+    limit: 10,
+  }], { signal })).sort((a, b) => b.created_at - a.created_at);
+
+  return latest ? parseDrawEvent(latest) : null;
+}
+
+/**
+ * Hook to read and publish the draw (tee times and pairings) for a tournament.
+ * The draw is an addressable event, so republishing replaces the previous draw.
+ * `shiftTeeTimes` moves the tee sheet (e.g. for a frost delay) and refuses
+ * changes that cause crossovers or late starts unless `force` is set. The
+ * republished draw still tags every player, so they are notified.
+ * Both take a `latestTeeTime`, usually the last tee time before dark from
+ * `lastTeeTimes`, and refuse a draw with groups off after it.
+ * Only the tournament's organizer can publish or move its draw, and only
+ * their draw is read.
+ */
+export function useTournamentDraw(tournamentId: string | undefined) {
+  const { nostr } = useNostr();
+  const { user } = useCurrentUser();
+  const { mutateAsync: publishEvent } = useNostrPublish();
+  const queryClient = useQueryClient();
+
+  const query = useQuery({
+    queryKey: ['tournament-draw', tournamentId],
+    queryFn: async (c) => {
+      if (!tournamentId) return null;
+
+      const signal = AbortSignal.any([c.signal, AbortSignal.timeout(5000)]);
+      const organizer = await fetchTournamentOrganizer(nostr, tournamentId, signal);
+      const draw = organizer ? await fetchTournamentDraw(nostr, tournamentId, organizer, signal) : null;
+      return { organizer, draw };
+    },
+    enabled: !!tournamentId,
+    staleTime: 30 * 1000,
+  });
+
+  const publish = useMutation({
+    mutationFn: async (params: {
+      players: DrawPlayer[];
+      config: DrawConfig;
+      latestTeeTime?: number;
+      force?: boolean;
+      tournamentName?: string;
+    }) => {
+      if (!user) throw new Error('Must be logged in to publish a draw');
+      if (!tournamentId) throw new Error('Tournament id is required');
+      if (query.data?.organizer !== user.pubkey) throw new Error('Only the tournament organizer can publish the draw');
+
+      const draw = generateDraw(params.players, params.config);
+      const conflicts = drawConflicts(draw, { latestTeeTime: params.latestTeeTime });
+      if (conflicts.length > 0 && !params.force) {
+        throw new Error(`The draw has conflicts for group${conflicts.length > 1 ? 's' : ''} ${conflicts.map(c => c.group).join(', ')}`);
+      }
+      const event = createDrawEvent(tournamentId, draw, user.pubkey, params.tournamentName);
+
+      await publishEvent({
+        kind: event.kind,
+        content: event.content,
+        tags: event.tags,
+        created_at: event.created_at,
+      });
+
+      return draw;
+    },
+    onSuccess: () => {
+      queryClient.invalidateQueries({ queryKey: ['tournament-draw', tournamentId] });
+    },
+  });
+
+  const shift = useMutation({
+    mutationFn: async (params: {
+      minutes: number;
+      fromGroup?: number;
+      latestTeeTime?: number;
+      force?: boolean;
+      tournamentName?: string;
+    }) => {
+      if (!user) throw new Error('Must be logged in to move tee times');
+      if (!tournamentId) throw new Error('Tournament id is required');
+      if (query.data?.organizer !== user.pubkey) throw new Error('Only the tournament organizer can move tee times');
+      if (!query.data.draw) throw new Error('There is no draw to move');
+
+      const { tournamentId: _id, ...current } = query.data.draw;
+      const draw = shiftDraw(current, params.minutes, params.fromGroup);
+      const conflicts = drawConflicts(draw, { latestTeeTime: params.latestTeeTime });
+      if (conflicts.length > 0 && !params.force) {
+        throw new Error(`Moving tee times causes conflicts for group${conflicts.length > 1 ? 's' : ''} ${conflicts.map(c => c.group).join(', ')}`);
+      }
+
+      const event = createDrawEvent(tournamentId, draw, user.pubkey, params.tournamentName);
+      await publishEvent({
+        kind: event.kind,
+        content: event.content,
+        tags: event.tags,
+        created_at: event.created_at,
+      });
+
+      return { draw, conflicts };
+    },
+    onSuccess: () => {
+      queryClient.invalidateQueries({ queryKey: ['tournament-draw', tournamentId] });
+    },
+  });
+
+  return {
+    ...query,
+    data: query.data?.draw ?? null,
+    organizer: query.data?.organizer ?? null,
+    isOrganizer: !!user && query.data?.organizer === user.pubkey,
+    publishDraw: publish.mutateAsync,
+    shiftTeeTimes: shift.mutateAsync,
+    isPublishing: publish.status === 'pending' || shift.status === 'pending',
+  };
+}
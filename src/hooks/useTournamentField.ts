@@ -0,0 +1,66 @@
+import { useQuery } from '@tanstack/react-query';
+import { useNostr } from '@nostrify/react';
+import type { NostrEvent, NostrFilter } from '@nostrify/nostrify';
+import { useHandicapSettings } from './useHandicapSettings';
+import { fetchRoundDifferentials, handicapFromDifferentials } from './useHandicapCalculation';
+import { GOLF_KINDS } from '@/lib/golf/types';
+import { hostedRounds } from '@/lib/golf/attestationEngine';
+import type { DrawPlayer } from '@/lib/golf/drawEngine';
+import type { HandicapSettings } from '@/lib/golf/handicapCalculator';
+import { genUserName } from '@/lib/genUserName';
+
+interface NostrLike {
+  query(filters: NostrFilter[], opts?: { signal?: AbortSignal }): Promise<NostrEvent[]>;
+}
+
+/**
+ * The players listed on the organizer's tournament round, named from their
+ * profiles, with the handicap index their own cards give
+ */
+export async function fetchTournamentField(
+  nostr: NostrLike,
+  tournamentId: string,
+  organizer: string,
+  settings: HandicapSettings,
+  signal: AbortSignal,
+): Promise<DrawPlayer[]> {
+  const rounds = await nostr.query([{ kinds: [GOLF_KINDS.ROUND], authors: [organizer], '#round-id': [tournamentId] }], { signal });
+  const players = hostedRounds(rounds).get(tournamentId)?.tags.find(t => t[0] === 'players')?.slice(1) ?? [];
+  if (players.length === 0) return [];
+
+  const names = new Map<string, string>();
+  for (const event of await nostr.query([{ kinds: [0], authors: players }], { signal })) {
+    try {
+      const metadata = JSON.parse(event.content) as { name?: string; display_name?: string };
+      const name = metadata.display_name || metadata.name;
+      if (name) names.set(event.pubkey, name);
+    } catch {
+      // Ignore malformed profiles
+    }
+  }
+
+  return Promise.all(players.map(async id => {
+    const differentials = await fetchRoundDifferentials(nostr, id, signal, 20, settings.committeePubkeys);
+    const { index } = handicapFromDifferentials(differentials, settings.requireAttestation ?? false);
+    return { id, name: names.get(id) ?? genUserName(id), handicap: index ?? 0 };
+  }));
+}
+
+/**
+ * The field a tournament's organizer draws from: the players on their
+ * tournament round and each player's current handicap index
+ */
+export function useTournamentField(tournamentId: string | undefined, organizer: string | null) {
+  const { nostr } = useNostr();
+  const settings = useHandicapSettings();
+
+  return useQuery({
+    queryKey: ['tournament-field', tournamentId, organizer, settings],
+    queryFn: async (c) => {
+      const signal = AbortSignal.any([c.signal, AbortSignal.timeout(10000)]);
+      return fetchTournamentField(nostr, tournamentId!, organizer!, settings, signal);
+    },
+    enabled: !!tournamentId && !!organizer,
+    staleTime: 5 * 60 * 1000,
+  });
+}
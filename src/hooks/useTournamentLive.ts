@@ -0,0 +1,111 @@
+import { useEffect } from 'react';
+import { useQuery, useQueryClient } from '@tanstack/react-query';
+import { useNostr } from '@nostrify/react';
+import type { NostrFilter } from '@nostrify/nostrify';
+import { GOLF_KINDS } from '@/lib/golf/types';
+import { parsePlayerScoreEvent, parseScorerDeviceEvent, type PlayerScoreRecord } from '@/lib/golf/nostrEvents';
+import type { ScorerDevice } from '@/lib/golf/deviceEngine';
+import { mergeActivity, scoreHighlights, type ActivityItem } from '@/lib/golf/activityEngine';
+import { liveStandings, type LiveStanding } from '@/lib/golf/kioskEngine';
+import { scoreCardFilters } from '@/lib/golf/delegationEngine';
+import { resolveScoreCards } from './useScoringDelegations';
+import { fetchTournamentDraw, fetchTournamentOrganizer } from './useTournamentDraw';
+
+// Cards from this long before the first tee time count towards the tournament
+const CARD_LEAD_SECONDS = 2 * 60 * 60;
+
+interface TournamentLive {
+  standings: LiveStanding[];
+  highlights: ActivityItem[];
+}
+
+/**
+ * Live standings and highlights for a tournament. With a draw published by
+ * the tournament's organizer, the field is the drawn players and their cards from the tournament day,
+ * including cards from scorer devices the organizer provisioned;
+ * otherwise the id is taken as a round id and its cards are used.
+ * Refreshes as players post scores.
+ */
+export function useTournamentLive(tournamentId: string | undefined, pars: number[] = []) {
+  const { nostr } = useNostr();
+  const queryClient = useQueryClient();
+  const parsKey = pars.join(',');
+
+  const scoreFilters = useQuery<NostrFilter[]>({
+    queryKey: ['tournament-score-filter', tournamentId],
+    queryFn: async (c) => {
+      const signal = AbortSignal.any([c.signal, AbortSignal.timeout(5000)]);
+      const organizer = await fetchTournamentOrganizer(nostr, tournamentId!, signal);
+      const draw = organizer ? await fetchTournamentDraw(nostr, tournamentId!, organizer, signal) : null;
+      if (!organizer || !draw || draw.groups.length === 0) {
+        return scoreCardFilters([tournamentId!]);
+      }
+
+      // Tablets the organizer provisioned post cards for their groups
+      const devices = (await nostr.query([{
+        kinds: [GOLF_KINDS.SCORER_DEVICE],
+        authors: [organizer],
+        '#tournament': [tournamentId!],
+      }], { signal })).map(parseScorerDeviceEvent).filter((d): d is ScorerDevice => d !== null);
+
+      const firstTee = Math.min(...draw.groups.map(g => g.teeTime));
+      return [{
+        kinds: [GOLF_KINDS.PLAYER_SCORE],
+        authors: [...draw.groups.flatMap(g => g.players.map(p => p.id)), ...new Set(devices.map(d => d.device))],
+        since: Math.floor(firstTee / 1000) - CARD_LEAD_SECONDS,
+      }];
+    },
+    enabled: !!tournamentId,
+    staleTime: 10 * 60 * 1000,
+  });
+
+  const filters = scoreFilters.data;
+
+  const query = useQuery<TournamentLive>({
+    queryKey: ['tournament-live', tournamentId, parsKey],
+    queryFn: async (c) => {
+      const signal = AbortSignal.any([c.signal, AbortSignal.timeout(5000)]);
+      const events = await nostr.query(filters!.map(f => ({ ...f, limit: 500 })), { signal });
+
+      const cards = await resolveScoreCards(nostr, events, signal);
+      const records = cards
+        .map(({ event, player }) => {
+          const record = parsePlayerScoreEvent(event);
+          return record && { ...record, playerPubkey: player };
+        })
+        .filter((r): r is PlayerScoreRecord => r !== null);
+
+      return {
+        standings: liveStandings(records, pars),
+        highlights: mergeActivity([], records.flatMap(r => scoreHighlights(r, pars)), 20),
+      };
+    },
+    enabled: !!filters,
+    refetchInterval: 5 * 60 * 1000,
+  });
+
+  // Live updates: refetch when any card in the field changes
+  const filtersKey = filters ? JSON.stringify(filters) : '';
+  useEffect(() => {
+    if (!filtersKey) return;
+    const controller = new AbortController();
+    const since = Math.floor(Date.now() / 1000);
+    const liveFilters = (JSON.parse(filtersKey) as NostrFilter[]).map(f => ({ ...f, since }));
+
+    (async () => {
+      try {
+        const subscription = nostr.req(liveFilters, { signal: controller.signal });
+        for await (const msg of subscription) {
+          if (msg[0] === 'CLOSED') break;
+          if (msg[0] === 'EVENT') queryClient.invalidateQueries({ queryKey: ['tournament-live', tournamentId] });
+        }
+      } catch (err) {
+        if (!controller.signal.aborted) console.warn('Tournament subscription ended', err);
+      }
+    })();
+
+    return () => controller.abort();
+  }, [nostr, queryClient, tournamentId, filtersKey]);
+
+  return { ...query, isLoading: scoreFilters.isLoading || query.isLoading };
+}
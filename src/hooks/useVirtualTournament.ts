@@ -0,0 +1,257 @@
+import { useEffect, useRef } from 'react';
+import { useMutation, useQuery, useQueryClient } from '@tanstack/react-query';
+import { useNostr } from '@nostrify/react';
+import type { NostrEvent, NostrFilter } from '@nostrify/nostrify';
+import { useCurrentUser } from './useCurrentUser';
+import { fetchRoundCourses, teeRating } from './useGolfCourses';
+import { fetchRoundDifferentials, handicapFromDifferentials } from './useHandicapCalculation';
+import { useHandicapSettings } from './useHandicapSettings';
+import { useNostrPublish } from './useNostrPublish';
+import { fetchPlayerCards } from './useScoringDelegations';
+import { GOLF_KINDS } from '@/lib/golf/types';
+import {
+  createVirtualEntryEvent,
+  createVirtualTournamentEvent,
+  parsePlayerScoreEvent,
+  parseVirtualEntryEvent,
+  parseVirtualTournamentEvent,
+} from '@/lib/golf/nostrEvents';
+import {
+  entryProblem,
+  shouldCloseVirtual,
+  virtualPhase,
+  virtualStandings,
+  withEvidence,
+  type EntryEvidence,
+  type VirtualEntry,
+  type VirtualTournament,
+} from '@/lib/golf/virtualTournamentEngine';
+import { scoreCardRound } from '@/lib/golf/delegationEngine';
+import { scheduler } from '@/lib/scheduler/scheduler';
+import type { HandicapSettings } from '@/lib/golf/handicapCalculator';
+
+export interface PlayedRound extends EntryEvidence {
+  player: string;
+  roundId: string;
+}
+
+interface NostrLike {
+  query(filters: NostrFilter[], opts?: { signal?: AbortSignal }): Promise<NostrEvent[]>;
+}
+
+/**
+ * Players' rounds in a tournament's window, from their latest PLAYER_SCORE
+ * card for each round, with the played tee's rating and slope from the
+ * round's course event and the player's handicap index going into the round,
+ * worked out from their own earlier cards
+ */
+async function fetchRoundsInWindow(
+  nostr: NostrLike,
+  players: string[],
+  tournament: VirtualTournament,
+  settings: HandicapSettings,
+  signal: AbortSignal,
+): Promise<PlayedRound[]> {
+  if (players.length === 0) return [];
+  const cards = await fetchPlayerCards(nostr, players, {
+    since: Math.floor(tournament.opens / 1000),
+    until: Math.floor(tournament.closes / 1000),
+    limit: 50 * players.length,
+  }, signal);
+
+  const latest = new Map<string, { event: NostrEvent; player: string; roundId: string }>();
+  for (const { event, player } of cards) {
+    const roundId = scoreCardRound(event);
+    if (!roundId) continue;
+    const existing = latest.get(`${player}:${roundId}`);
+    if (!existing || event.created_at > existing.event.created_at) latest.set(`${player}:${roundId}`, { event, player, roundId });
+  }
+
+  const courses = new Map(await Promise.all(players.map(async player => [
+    player,
+    await fetchRoundCourses(nostr, [...latest.values()].filter(c => c.player === player).map(c => c.roundId), player, signal),
+  ] as const)));
+  // Enough history to rebuild an index from the 20 rounds before the window
+  const differentials = new Map(await Promise.all(players.map(async player => [
+    player,
+    await fetchRoundDifferentials(nostr, player, signal, 40, settings.committeePubkeys),
+  ] as const)));
+
+  const rounds: PlayedRound[] = [];
+  for (const { event, player, roundId } of latest.values()) {
+    const record = parsePlayerScoreEvent(event);
+    const gross = record ? Object.values(record.scores).reduce((sum, s) => sum + s, 0) : 0;
+    if (!(gross > 0)) continue;
+
+    const played = courses.get(player)?.get(roundId);
+    const rating = played ? teeRating(played.course, played.tee) : undefined;
+    const before = (differentials.get(player) ?? []).filter(d => d.date < event.created_at * 1000);
+    const handicap = handicapFromDifferentials(before, settings.requireAttestation ?? false);
+    rounds.push({
+      player,
+      roundId,
+      course: played?.course.name ?? event.tags.find(t => t[0] === 'course')?.[1] ?? 'Unknown Course',
+      gross,
+      playedAt: event.created_at * 1000,
+      courseRating: rating?.courseRating,
+      slope: rating?.slopeRating,
+      handicapIndex: handicap.index ?? undefined,
+    });
+  }
+  return rounds.sort((a, b) => b.playedAt - a.playedAt);
+}
+
+/**
+ * A virtual tournament, its entries and standings. Players `submit` one of
+ * their rounds from the window; each entry's score and ratings are checked
+ * against the player's card and the course. The organizer `create`s the
+ * tournament, and while their app is open the scheduler closes it at the
+ * submission deadline, publishing the final results so later cards can't
+ * change them.
+ */
+export function useVirtualTournament(tournamentId: string | undefined) {
+  const { nostr } = useNostr();
+  const { user } = useCurrentUser();
+  const { mutateAsync: publishEvent } = useNostrPublish();
+  const queryClient = useQueryClient();
+  const settings = useHandicapSettings();
+  const queryKey = ['virtual-tournament', tournamentId, settings];
+
+  const query = useQuery<{ tournament: VirtualTournament | null; entries: VirtualEntry[] }>({
+    queryKey,
+    queryFn: async (c) => {
+      const signal = AbortSignal.any([c.signal, AbortSignal.timeout(5000)]);
+      const versions = await nostr.query([{
+        kinds: [GOLF_KINDS.VIRTUAL_TOURNAMENT],
+        '#d': [tournamentId!],
+        limit: 10,
+      }], { signal });
+
+      // The organizer is the tournament id's only author; a contested id has no tournament
+      const organizers = new Set(versions.map(e => e.pubkey));
+      const [latest] = organizers.size === 1 ? versions.sort((a, b) => b.created_at - a.created_at) : [];
+
+      const tournament = latest ? parseVirtualTournamentEvent(latest) : null;
+      if (!tournament) return { tournament: null, entries: [] };
+
+      const events = await nostr.query([{
+        kinds: [GOLF_KINDS.VIRTUAL_ENTRY],
+        '#a': [`${GOLF_KINDS.VIRTUAL_TOURNAMENT}:${tournament.organizer}:${tournament.tournamentId}`],
+        limit: 500,
+      }], { signal });
+
+      const claimed = events.map(parseVirtualEntryEvent).filter((e): e is VirtualEntry => e !== null);
+      const rounds = await fetchRoundsInWindow(nostr, [...new Set(claimed.map(e => e.player))], tournament, settings, signal);
+
+      return {
+        tournament,
+        entries: claimed.map(entry => withEvidence(entry, rounds.find(r => r.player === entry.player && r.roundId === entry.roundId))),
+      };
+    },
+    enabled: !!tournamentId,
+    refetchInterval: 60 * 1000,
+  });
+
+  const tournament = query.data?.tournament ?? null;
+  const entries = query.data?.entries ?? [];
+  const isOrganizer = !!user && !!tournament && tournament.organizer === user.pubkey;
+
+  const publish = useMutation({
+    mutationFn: async (event: NostrEvent) => {
+      return publishEvent({ kind: event.kind, content: event.content, tags: event.tags, created_at: event.created_at });
+    },
+    onSuccess: () => {
+      queryClient.invalidateQueries({ queryKey });
+    },
+  });
+
+  // The deadline job reads the latest entries without being re-registered
+  const latest = useRef({ tournament, entries, publish: publish.mutateAsync });
+  latest.current = { tournament, entries, publish: publish.mutateAsync };
+
+  const closeable = isOrganizer && !tournament?.closed;
+
+  useEffect(() => {
+    if (!closeable || !tournamentId) return;
+
+    return scheduler.register({
+      id: `virtual-tournament:${tournamentId}`,
+      name: `Close virtual tournament (${tournamentId})`,
+      schedule: '@every 1m',
+      runOnStart: true,
+      run: async () => {
+        const { tournament, entries, publish } = latest.current;
+        if (!tournament || !shouldCloseVirtual(tournament, Date.now())) return;
+        await publish(createVirtualTournamentEvent({
+          ...tournament,
+          closed: true,
+          results: virtualStandings(tournament, entries),
+        }));
+      },
+    });
+  }, [closeable, tournamentId]);
+
+  const myEntry = user ? entries.filter(e => e.player === user.pubkey).sort((a, b) => b.submittedAt - a.submittedAt)[0] : undefined;
+
+  return {
+    tournament,
+    phase: tournament ? virtualPhase(tournament, Date.now()) : null,
+    standings: tournament?.closed ? tournament.results : tournament ? virtualStandings(tournament, entries) : [],
+    myEntry,
+    isLoading: query.isLoading,
+    isOrganizer,
+    create: async (details: Pick<VirtualTournament, 'name' | 'opens' | 'closes' | 'deadline'>) => {
+      if (!user) throw new Error('Log in to organize a virtual tournament');
+      if (tournament) throw new Error('A tournament with this id already exists');
+      if (!(details.opens < details.closes && details.closes <= details.deadline)) {
+        throw new Error('The window must open before it closes, and close by the submission deadline');
+      }
+      return publish.mutateAsync(createVirtualTournamentEvent({
+        ...details,
+        tournamentId: tournamentId!,
+        organizer: user.pubkey,
+        closed: false,
+        results: [],
+      }));
+    },
+    submit: async (round: PlayedRound) => {
+      if (!user) throw new Error('Log in to submit a card');
+      if (!tournament) throw new Error('Tournament not found');
+      if (Date.now() > tournament.deadline) throw new Error('The submission deadline has passed');
+      const card = withEvidence({
+        tournamentId: tournament.tournamentId,
+        player: user.pubkey,
+        roundId: round.roundId,
+        course: round.course,
+        courseRating: 0,
+        slope: 0,
+        handicapIndex: 0,
+        gross: 0,
+        playedAt: 0,
+        submittedAt: Date.now(),
+      }, round);
+      const problem = entryProblem(tournament, card);
+      if (problem) throw new Error(problem);
+      return publish.mutateAsync(createVirtualEntryEvent(card, tournament.organizer));
+    },
+    isPublishing: publish.status === 'pending',
+  };
+}
+
+/**
+ * The current user's rounds played in a tournament's window, to submit from
+ */
+export function useRoundsInWindow(tournament: VirtualTournament | null) {
+  const { nostr } = useNostr();
+  const { user } = useCurrentUser();
+  const settings = useHandicapSettings();
+
+  return useQuery<PlayedRound[]>({
+    queryKey: ['rounds-in-window', user?.pubkey, tournament?.tournamentId, settings],
+    queryFn: async (c) => {
+      const signal = AbortSignal.any([c.signal, AbortSignal.timeout(5000)]);
+      return fetchRoundsInWindow(nostr, [user!.pubkey], tournament!, settings, signal);
+    },
+    enabled: !!user && !!tournament,
+  });
+}
@@ -0,0 +1,184 @@
+import { useMutation, useQuery, useQueryClient } from '@tanstack/react-query';
+import { useNostr } from '@nostrify/react';
+import type { NostrEvent, NostrFilter } from '@nostrify/nostrify';
+import { useCurrentUser } from './useCurrentUser';
+import { useNostrPublish } from './useNostrPublish';
+import { GOLF_KINDS } from '@/lib/golf/types';
+import {
+  createVoucherEvent,
+  createVoucherRedemptionEvent,
+  parseVoucherEvent,
+  parseVoucherRedemptionEvent,
+} from '@/lib/golf/nostrEvents';
+import {
+  generateVoucherCode,
+  hashVoucherCode,
+  redemptionProblem,
+  type Voucher,
+  type VoucherRedemption,
+} from '@/lib/golf/voucherEngine';
+import type { GolfCourse } from './useGolfCourses';
+import { v4 as uuidv4 } from 'uuid';
+
+interface NostrLike {
+  query(filters: NostrFilter[], opts?: { signal?: AbortSignal }): Promise<NostrEvent[]>;
+}
+
+const PAGE_SIZE = 500;
+
+/**
+ * Every event matching a filter, paged back through time so a busy club's
+ * redemptions are never cut off at a relay's limit
+ */
+async function queryAll(nostr: NostrLike, filter: NostrFilter, signal: AbortSignal): Promise<NostrEvent[]> {
+  const events = new Map<string, NostrEvent>();
+  let until: number | undefined;
+  for (;;) {
+    const page = await nostr.query([{ ...filter, ...(until !== undefined ? { until } : {}), limit: PAGE_SIZE }], { signal });
+    const before = events.size;
+    for (const event of page) events.set(event.id, event);
+    // Pages overlap by a second, so stop once a page brings nothing new
+    if (page.length < PAGE_SIZE || events.size === before) break;
+    until = Math.min(...page.map(e => e.created_at));
+  }
+  return [...events.values()];
+}
+
+/**
+ * A course's vouchers (latest version of each) and all redemptions against them
+ */
+async function fetchVoucherLedger(
+  nostr: NostrLike,
+  course: Pick<GolfCourse, 'id' | 'author'>,
+  signal: AbortSignal,
+): Promise<{ vouchers: Voucher[]; redemptions: VoucherRedemption[] }> {
+  const filter = {
+    authors: [course.author],
+    '#a': [`${GOLF_KINDS.COURSE}:${course.author}:${course.id}`],
+  };
+  const [voucherEvents, redemptionEvents] = await Promise.all([
+    queryAll(nostr, { ...filter, kinds: [GOLF_KINDS.VOUCHER] }, signal),
+    queryAll(nostr, { ...filter, kinds: [GOLF_KINDS.VOUCHER_REDEMPTION] }, signal),
+  ]);
+
+  // Keep the latest version of each voucher
+  const latest = new Map<string, Voucher>();
+  for (const voucher of voucherEvents.map(parseVoucherEvent)) {
+    if (!voucher) continue;
+    const existing = latest.get(voucher.voucherId);
+    if (!existing || voucher.createdAt > existing.createdAt) latest.set(voucher.voucherId, voucher);
+  }
+
+  const redemptions = new Map<string, VoucherRedemption>();
+  for (const redemption of redemptionEvents.map(parseVoucherRedemptionEvent)) {
+    if (redemption) redemptions.set(redemption.redemptionId, redemption);
+  }
+
+  return {
+    vouchers: [...latest.values()].sort((a, b) => b.createdAt - a.createdAt),
+    redemptions: [...redemptions.values()],
+  };
+}
+
+/**
+ * Gift vouchers for a course and the pro shop's redemptions against them.
+ * Only the club account issues and redeems; a redemption needs the code
+ * from the voucher, which the club checks against the published hash.
+ */
+export function useVouchers(course: Pick<GolfCourse, 'id' | 'author'> | null | undefined) {
+  const { nostr } = useNostr();
+  const { user } = useCurrentUser();
+  const { mutateAsync: publishEvent } = useNostrPublish();
+  const queryClient = useQueryClient();
+  const queryKey = ['vouchers', course?.author, course?.id];
+
+  const query = useQuery<{ vouchers: Voucher[]; redemptions: VoucherRedemption[] }>({
+    queryKey,
+    queryFn: async (c) => {
+      const signal = AbortSignal.any([c.signal, AbortSignal.timeout(5000)]);
+      return fetchVoucherLedger(nostr, course!, signal);
+    },
+    enabled: !!course?.author && !!course?.id,
+    staleTime: 30 * 1000,
+  });
+
+  const requireClub = () => {
+    if (!course) throw new Error('Select a course');
+    if (user?.pubkey !== course.author) throw new Error('Only the club account can manage vouchers');
+    return course;
+  };
+
+  const publishVoucher = async (voucher: Omit<Voucher, 'createdAt'>) => {
+    const event = createVoucherEvent(voucher);
+    await publishEvent({ kind: event.kind, content: event.content, tags: event.tags, created_at: event.created_at });
+  };
+
+  const issue = useMutation({
+    mutationFn: async (params: Pick<Voucher, 'kind' | 'amount' | 'currency' | 'recipient' | 'message' | 'expiresAt'>) => {
+      const club = requireClub();
+      const code = generateVoucherCode();
+      const voucher = {
+        ...params,
+        voucherId: uuidv4(),
+        courseId: club.id,
+        courseAuthor: club.author,
+        codeHash: await hashVoucherCode(code),
+        voided: false,
+        issuedAt: Date.now(),
+      };
+      await publishVoucher(voucher);
+      // The code is never published; hand it over with the voucher
+      return { voucher, code };
+    },
+    onSuccess: () => {
+      queryClient.invalidateQueries({ queryKey });
+    },
+  });
+
+  const redeem = useMutation({
+    mutationFn: async (params: { voucher: Voucher; code: string; amount: number; note?: string }) => {
+      const club = requireClub();
+      if (await hashVoucherCode(params.code) !== params.voucher.codeHash) throw new Error('The voucher code does not match');
+
+      // Check against the relays' current ledger, not the cached one, so a
+      // voucher spent at another till (or voided since) can't be spent again
+      const ledger = await fetchVoucherLedger(nostr, club, AbortSignal.timeout(10000));
+      const voucher = ledger.vouchers.find(v => v.voucherId === params.voucher.voucherId) ?? params.voucher;
+      const problem = redemptionProblem(voucher, ledger.redemptions, params.amount, Date.now());
+      if (problem) throw new Error(problem);
+
+      const event = createVoucherRedemptionEvent({
+        redemptionId: uuidv4(),
+        voucherId: params.voucher.voucherId,
+        courseId: club.id,
+        courseAuthor: club.author,
+        amount: params.amount,
+        note: params.note ?? '',
+      });
+      return publishEvent({ kind: event.kind, content: event.content, tags: event.tags, created_at: event.created_at });
+    },
+    onSuccess: () => {
+      queryClient.invalidateQueries({ queryKey });
+    },
+  });
+
+  const cancel = useMutation({
+    mutationFn: async (voucher: Voucher) => {
+      requireClub();
+      await publishVoucher({ ...voucher, voided: true });
+    },
+    onSuccess: () => {
+      queryClient.invalidateQueries({ queryKey });
+    },
+  });
+
+  return {
+    vouchers: query.data?.vouchers ?? [],
+    redemptions: query.data?.redemptions ?? [],
+    isLoading: query.isLoading,
+    issueVoucher: issue.mutateAsync,
+    redeemVoucher: redeem.mutateAsync,
+    cancelVoucher: cancel.mutateAsync,
+    isPublishing: issue.status === 'pending' || redeem.status === 'pending' || cancel.status === 'pending',
+  };
+}
@@ -10,7 +10,32 @@ import type { Event } from 'nostr-tools';
 import type { WebLNProvider } from 'webln';
 import { useQuery, useQueryClient } from '@tanstack/react-query';
 import { useNostr } from '@nostrify/react';
+import { UPSTREAM_SERVICES, upstreamFetch } from '@/lib/upstream/upstream';
 import type { NostrEvent } from '@nostrify/nostrify';
+import { lnurlPayUrl } from '@/lib/golf/zapReceiptEngine';
+
+export interface ZapProvider {
+  callback: string; // LNURL-pay callback that issues the invoices
+  nostrPubkey: string; // key the provider signs zap receipts with
+}
+
+/**
+ * The LNURL-pay provider behind a profile's lightning address, if it supports
+ * zaps. Only zap receipts signed by its `nostrPubkey` prove a payment to the
+ * profile.
+ */
+export async function fetchZapProvider(metadata: { lud06?: string; lud16?: string }): Promise<ZapProvider | null> {
+  const url = lnurlPayUrl(metadata);
+  if (!url) return null;
+  try {
+    const res = await upstreamFetch(UPSTREAM_SERVICES.lnurl, url);
+    const data = await res.json();
+    if (!res.ok || !data.allowsNostr || typeof data.callback !== 'string' || !/^[a-f0-9]{64}$/.test(data.nostrPubkey)) return null;
+    return { callback: data.callback, nostrPubkey: data.nostrPubkey };
+  } catch {
+    return null;
+  }
+}
 
 export function useZaps(
   target: Event | Event[],
@@ -216,7 +241,7 @@ export function useZaps(
       const signedZapRequest = await user.signer.signEvent(zapRequest);
 
       try {
-        const res = await fetch(`${zapEndpoint}?amount=${zapAmount}&nostr=${encodeURI(JSON.stringify(signedZapRequest))}`);
+        const res = await upstreamFetch(UPSTREAM_SERVICES.lnurl, `${zapEndpoint}?amount=${zapAmount}&nostr=${encodeURI(JSON.stringify(signedZapRequest))}`);
             const responseData = await res.json();
 
             if (!res.ok) {
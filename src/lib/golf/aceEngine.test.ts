@@ -0,0 +1,47 @@
+import { describe, it, expect } from 'vitest';
+import { aceAnnouncement, aceSignatures, aceWall, isConfirmed, type Ace, type AceWitness } from './aceEngine';
+
+const ace: Ace = {
+  aceId: 'r1:7',
+  playerPubkey: 'player',
+  courseId: 'oak-hills',
+  courseAuthor: 'club',
+  courseName: 'Oak Hills',
+  roundId: 'r1',
+  hole: 7,
+  yards: 152,
+  club: '8 iron',
+  witnesses: ['w1', 'w2', 'w3'],
+  note: '',
+  createdAt: 1000,
+};
+
+const sign = (witnessPubkey: string, aceId = 'r1:7'): AceWitness => ({ aceId, playerPubkey: 'player', witnessPubkey, createdAt: 2000 });
+
+describe('Ace Engine', () => {
+  it('should need two witness signatures', () => {
+    expect(isConfirmed(ace, [sign('w1')])).toBe(false);
+    expect(isConfirmed(ace, [sign('w1'), sign('w2')])).toBe(true);
+  });
+
+  it('should only count named witnesses, once each', () => {
+    expect(aceSignatures(ace, [sign('w1'), sign('w1'), sign('stranger'), sign('player')])).toEqual(['w1']);
+  });
+
+  it('should ignore signatures for another ace', () => {
+    expect(isConfirmed(ace, [sign('w1'), sign('w2', 'r1:8')])).toBe(false);
+  });
+
+  it('should list confirmed aces newest first on the wall', () => {
+    const older = { ...ace, aceId: 'r0:3', roundId: 'r0', hole: 3, createdAt: 500 };
+    const unconfirmed = { ...ace, aceId: 'r2:7', roundId: 'r2', createdAt: 3000 };
+    const wall = aceWall([older, ace, unconfirmed], [sign('w1'), sign('w2'), sign('w1', 'r0:3'), sign('w3', 'r0:3')]);
+    expect(wall.map(a => a.aceId)).toEqual(['r1:7', 'r0:3']);
+    expect(wall[0].signedBy).toEqual(['w1', 'w2']);
+  });
+
+  it('should announce the ace with the shot details', () => {
+    expect(aceAnnouncement(ace, 'Sam')).toContain('Sam on an ace at hole 7 of Oak Hills, 152 yards with a 8 iron');
+    expect(aceAnnouncement({ ...ace, yards: undefined, club: undefined }, 'Sam')).toContain('of Oak Hills. Witnessed');
+  });
+});
@@ -0,0 +1,68 @@
+// Hole-in-one registry: an ace counts once two witnesses have signed it
+
+export interface Ace {
+  aceId: string; // `${roundId}:${hole}`, one per player, round and hole
+  playerPubkey: string;
+  courseId: string;
+  courseAuthor: string; // the club account that publishes the course
+  courseName: string;
+  roundId: string;
+  hole: number;
+  yards?: number;
+  club?: string;
+  witnesses: string[]; // pubkeys the player named as witnesses
+  note: string;
+  createdAt: number; // ms
+}
+
+export interface AceWitness {
+  aceId: string;
+  playerPubkey: string;
+  witnessPubkey: string;
+  createdAt: number; // ms
+}
+
+export interface ConfirmedAce extends Ace {
+  signedBy: string[]; // witnesses who signed
+}
+
+export const REQUIRED_WITNESSES = 2;
+
+/**
+ * Witnesses who signed an ace. Only the witnesses the player named count,
+ * never the player, and each only once.
+ */
+export function aceSignatures(ace: Ace, witnesses: AceWitness[]): string[] {
+  const signed = new Set<string>();
+  for (const w of witnesses) {
+    if (w.aceId !== ace.aceId || w.playerPubkey !== ace.playerPubkey) continue;
+    if (w.witnessPubkey === ace.playerPubkey || !ace.witnesses.includes(w.witnessPubkey)) continue;
+    signed.add(w.witnessPubkey);
+  }
+  return [...signed];
+}
+
+export function isConfirmed(ace: Ace, witnesses: AceWitness[]): boolean {
+  return aceSignatures(ace, witnesses).length >= REQUIRED_WITNESSES;
+}
+
+/**
+ * A course's wall of fame: its confirmed aces, newest first
+ */
+export function aceWall(aces: Ace[], witnesses: AceWitness[]): ConfirmedAce[] {
+  return aces
+    .map(ace => ({ ...ace, signedBy: aceSignatures(ace, witnesses) }))
+    .filter(ace => ace.signedBy.length >= REQUIRED_WITNESSES)
+    .sort((a, b) => b.createdAt - a.createdAt);
+}
+
+/**
+ * The club's announcement of a confirmed ace
+ */
+export function aceAnnouncement(ace: Ace, playerName: string): string {
+  const shot = [ace.yards ? `${ace.yards} yards` : null, ace.club ? `with a ${ace.club}` : null]
+    .filter(Boolean)
+    .join(' ');
+  return `⛳ Hole in one! Congratulations to ${playerName} on an ace at hole ${ace.hole} of ${ace.courseName}` +
+    `${shot ? `, ${shot}` : ''}. Witnessed and confirmed. #golf #holeinone`;
+}
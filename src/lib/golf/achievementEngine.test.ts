@@ -0,0 +1,81 @@
+import { describe, it, expect } from 'vitest';
+import { evaluateAchievements, mergeAchievements, withCoursePars } from './achievementEngine';
+import type { PlayerScoreRecord } from './nostrEvents';
+
+const holes = Array.from({ length: 18 }, (_, i) => i + 1);
+
+function card(roundId: string, strokes: (hole: number) => number, updatedAt: number, greens = false): PlayerScoreRecord {
+  const byHole = <T>(value: (hole: number) => T) => Object.fromEntries(holes.map(h => [h, value(h)]));
+  return {
+    roundId,
+    playerPubkey: 'p1',
+    scores: byHole(strokes),
+    putts: {},
+    pars: byHole(() => 4),
+    fairways: {},
+    greens: byHole(() => greens),
+    penalties: {},
+    updatedAt,
+  };
+}
+
+const ids = (cards: PlayerScoreRecord[], aces: { roundId: string; hole: number }[] = []) =>
+  evaluateAchievements(cards, aces).map(a => a.badgeId);
+
+describe('Achievement Engine', () => {
+  it('should award first round and first birdie from the round that earned them', () => {
+    const earned = evaluateAchievements([
+      card('r1', () => 6, 1000),
+      card('r2', h => (h === 5 ? 3 : 6), 2000),
+    ]);
+    expect(earned.find(a => a.badgeId === 'first-round')?.roundId).toBe('r1');
+    expect(earned.find(a => a.badgeId === 'first-birdie')?.roundId).toBe('r2');
+  });
+
+  it('should award breaking 90 and 80 for full rounds only', () => {
+    expect(ids([card('r1', () => 5, 1000)])).not.toContain('breaking-90'); // 90
+    expect(ids([card('r1', h => (h === 1 ? 4 : 5), 1000)])).toContain('breaking-90');
+    expect(ids([card('r1', h => (h <= 11 ? 4 : 5), 1000)])).toContain('breaking-80');
+
+    const nine = card('r1', () => 4, 1000);
+    holes.filter(h => h > 9).forEach(h => delete nine.scores[h]);
+    expect(ids([nine])).not.toContain('breaking-90');
+  });
+
+  it('should award all greens in regulation only when every green was hit', () => {
+    expect(ids([card('r1', () => 4, 1000, true)])).toContain('all-greens');
+    const missedOne = card('r1', () => 4, 1000, true);
+    missedOne.greens[18] = false;
+    expect(ids([missedOne])).not.toContain('all-greens');
+  });
+
+  it('should count 100 completed rounds, using the latest version of each card', () => {
+    const cards = Array.from({ length: 100 }, (_, i) => card(`r${i}`, () => 6, i));
+    expect(ids(cards)).toContain('fifty-rounds');
+    expect(ids(cards)).toContain('century-round');
+    expect(ids([...cards.slice(0, 99), card('r0', () => 6, 500)])).not.toContain('century-round');
+  });
+
+  it('should only award a hole in one for an ace its witnesses confirmed', () => {
+    const ace = card('r1', h => (h === 7 ? 1 : 4), 1000);
+    expect(ids([ace])).not.toContain('hole-in-one');
+    expect(ids([ace], [{ roundId: 'r2', hole: 7 }])).not.toContain('hole-in-one');
+    expect(ids([ace], [{ roundId: 'r1', hole: 7 }])).toContain('hole-in-one');
+  });
+
+  it('should judge birdies against course pars when the card has none', () => {
+    const bare = { ...card('r1', h => (h === 5 ? 2 : 4), 1000), pars: {} };
+    expect(ids([bare])).not.toContain('first-birdie');
+    expect(ids([withCoursePars(bare, Object.fromEntries(holes.map(h => [h, h === 5 ? 3 : 4])))])).toContain('first-birdie');
+  });
+
+  it('should keep known badges and report only new ones', () => {
+    const known = [{ badgeId: 'first-round', roundId: 'r1', earnedAt: 1000, published: true }];
+    const { earned, unlocked } = mergeAchievements(known, [
+      { badgeId: 'first-round', roundId: 'r9', earnedAt: 9000 },
+      { badgeId: 'first-birdie', roundId: 'r9', earnedAt: 9000 },
+    ]);
+    expect(earned[0]).toEqual(known[0]);
+    expect(unlocked.map(a => a.badgeId)).toEqual(['first-birdie']);
+  });
+});
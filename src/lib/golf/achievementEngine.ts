@@ -0,0 +1,103 @@
+// Achievements: badges a player has earned, worked out from their score cards
+
+import type { PlayerScoreRecord } from './nostrEvents';
+import type { Ace } from './aceEngine';
+
+export interface EarnedAchievement {
+  badgeId: string; // see BadgeService definitions
+  roundId: string; // the round it was earned in
+  earnedAt: number; // ms
+  published?: boolean; // awarded as a NIP-58 badge
+}
+
+// A card with at least this many holes scored is a completed round
+const COMPLETE_HOLES = 9;
+
+const ROUND_MILESTONES: [number, string][] = [
+  [1, 'first-round'],
+  [10, 'ten-rounds'],
+  [50, 'fifty-rounds'],
+  [100, 'century-round'],
+];
+
+/**
+ * The card with the pars it doesn't record taken from its course, so a card
+ * that only has strokes can still earn birdie and par badges
+ */
+export function withCoursePars(card: PlayerScoreRecord, coursePars: { [hole: number]: number }): PlayerScoreRecord {
+  return { ...card, pars: { ...coursePars, ...card.pars } };
+}
+
+/**
+ * Badges earned in a single card. A hole in one only counts once the ace
+ * is confirmed by its witnesses.
+ */
+function cardAchievements(card: PlayerScoreRecord, confirmedAces: Pick<Ace, 'roundId' | 'hole'>[]): string[] {
+  const holes = Object.keys(card.scores).map(Number);
+  const vsPar = holes.filter(h => card.pars[h]).map(h => card.scores[h] - card.pars[h]);
+  const total = (list: number[]) => list.reduce((sum, h) => sum + card.scores[h], 0);
+  const par = (list: number[]) => list.reduce((sum, h) => sum + (card.pars[h] ?? 0), 0);
+  const nine = (from: number) => Array.from({ length: 9 }, (_, i) => from + i);
+  const parOrBetter = (list: number[]) => list.every(h => card.scores[h] && card.pars[h]) && total(list) <= par(list);
+  const full = holes.length >= 18;
+  const gross = total(holes);
+
+  const earned: string[] = [];
+  if (confirmedAces.some(ace => ace.roundId === card.roundId && card.scores[ace.hole] === 1)) earned.push('hole-in-one');
+  if (vsPar.some(d => d === -1)) earned.push('first-birdie');
+  if (vsPar.some(d => d <= -2)) earned.push('eagle-or-better');
+  if (full && gross <= 89) earned.push('breaking-90');
+  if (full && gross <= 79) earned.push('breaking-80');
+  if (full && parOrBetter(holes)) earned.push('par-or-better');
+  if (parOrBetter(nine(1))) earned.push('perfect-front-nine');
+  if (parOrBetter(nine(10))) earned.push('perfect-back-nine');
+  if (full && holes.every(h => card.greens[h] === true)) earned.push('all-greens');
+  return earned;
+}
+
+/**
+ * Every badge a player has earned, each from the first round that earned it,
+ * in the order they were earned. Only the latest version of each card counts.
+ * `confirmedAces` are the player's aces with enough witness signatures.
+ */
+export function evaluateAchievements(
+  cards: PlayerScoreRecord[],
+  confirmedAces: Pick<Ace, 'roundId' | 'hole'>[] = []
+): EarnedAchievement[] {
+  const latest = new Map<string, PlayerScoreRecord>();
+  for (const card of cards) {
+    const seen = latest.get(card.roundId);
+    if (!seen || card.updatedAt > seen.updatedAt) latest.set(card.roundId, card);
+  }
+
+  const earned = new Map<string, EarnedAchievement>();
+  const award = (badgeId: string, card: PlayerScoreRecord) => {
+    if (!earned.has(badgeId)) earned.set(badgeId, { badgeId, roundId: card.roundId, earnedAt: card.updatedAt });
+  };
+
+  let completed = 0;
+  for (const card of [...latest.values()].sort((a, b) => a.updatedAt - b.updatedAt)) {
+    cardAchievements(card, confirmedAces).forEach(badgeId => award(badgeId, card));
+    if (Object.keys(card.scores).length < COMPLETE_HOLES) continue;
+    completed++;
+    for (const [rounds, badgeId] of ROUND_MILESTONES) {
+      if (completed === rounds) award(badgeId, card);
+    }
+  }
+
+  return [...earned.values()];
+}
+
+/**
+ * Merge freshly evaluated badges into what the player already has. Known
+ * badges keep their original date and publish state, so nothing is lost if
+ * old cards drop off the relays. Returns the merged list and what is new.
+ */
+export function mergeAchievements(
+  known: EarnedAchievement[],
+  evaluated: EarnedAchievement[]
+): { earned: EarnedAchievement[]; unlocked: EarnedAchievement[] } {
+  const have = new Set(known.map(a => a.badgeId));
+  const unlocked = evaluated.filter(a => !have.has(a.badgeId));
+  return { earned: [...known, ...unlocked], unlocked };
+}
@@ -0,0 +1,69 @@
+import { describe, it, expect } from 'vitest';
+import type { NostrEvent } from '@nostrify/nostrify';
+import { competitionActivity, highlightName, mergeActivity, roundActivity, scoreHighlights } from './activityEngine';
+import type { PlayerScoreRecord } from './nostrEvents';
+
+describe('Activity Engine', () => {
+  const roundEvent = (tags: string[][], created_at = 2000): NostrEvent => ({
+    id: 'e1',
+    kind: 36901,
+    pubkey: 'alice',
+    created_at,
+    tags: [['d', 'join-ABC123'], ['round-id', 'r1'], ['course', 'Pebble'], ['players', 'alice', 'bob'], ...tags],
+    content: '',
+    sig: '',
+  });
+
+  const record = (scores: PlayerScoreRecord['scores'], pars: PlayerScoreRecord['pars'] = {}): PlayerScoreRecord => ({
+    roundId: 'r1',
+    playerPubkey: 'bob',
+    scores,
+    putts: {},
+    pars,
+    fairways: {},
+    greens: {},
+    penalties: {},
+    updatedAt: 5000,
+  });
+
+  it('should name scores under par', () => {
+    expect(highlightName(3, 4)).toBe('Birdie');
+    expect(highlightName(3, 5)).toBe('Eagle');
+    expect(highlightName(1, 3)).toBe('Hole in one');
+    expect(highlightName(4, 4)).toBeNull();
+  });
+
+  it('should report a round teeing off and finishing', () => {
+    const active = roundActivity(roundEvent([['status', 'active'], ['tee-time', '1000']]));
+    expect(active).toHaveLength(1);
+    expect(active[0]).toMatchObject({ id: 'r1:started', roundId: 'r1', text: 'Teed off with 1 other', createdAt: 1000000 });
+
+    const finished = roundActivity(roundEvent([['status', 'completed']]));
+    expect(finished.map(i => i.type)).toEqual(['round-started', 'round-finished']);
+    expect(roundActivity(roundEvent([['status', 'active'], ['visibility', 'private']]))).toEqual([]);
+  });
+
+  it('should highlight birdies using card pars, falling back to course pars', () => {
+    const items = scoreHighlights(record({ 1: 3, 2: 4, 3: 2 }, { 1: 4 }), [5, 4, 4]);
+    expect(items.map(i => i.text)).toEqual(['Birdie on 1', 'Eagle on 3']);
+    expect(scoreHighlights(record({ 1: 3 }))).toEqual([]);
+  });
+
+  it('should report competitions posted for a round', () => {
+    const event = { ...roundEvent([]), kind: 36905, tags: [['d', 'r1-tournament'], ['t', 'pinseekr-cup']] };
+    expect(competitionActivity(event)).toMatchObject({ roundId: 'r1', text: 'Posted a Pinseekr Cup' });
+    expect(competitionActivity({ ...event, tags: [['d', 'r1']] })).toBeNull();
+  });
+
+  it('should merge items once each, newest first, keeping the first sighting', () => {
+    const [birdie] = scoreHighlights(record({ 1: 3 }, { 1: 4 }));
+    const started = roundActivity(roundEvent([['status', 'active'], ['tee-time', '1']]))[0];
+    const feed = mergeActivity([started], [birdie]);
+    expect(feed.map(i => i.id)).toEqual(['r1:bob:1', 'r1:started']);
+
+    const republished = mergeActivity(feed, [{ ...birdie, createdAt: 9000 }]);
+    expect(republished).toHaveLength(2);
+    expect(republished[0].createdAt).toBe(5000);
+    expect(mergeActivity(feed, [], 1)).toHaveLength(1);
+  });
+});
@@ -0,0 +1,117 @@
+// Club activity: rounds, notable scores and competitions on a course as a normalized feed
+
+import type { NostrEvent } from '@nostrify/nostrify';
+import type { PlayerScoreRecord } from './nostrEvents';
+
+export type ActivityType = 'round-started' | 'round-finished' | 'highlight' | 'competition-posted';
+
+export interface ActivityItem {
+  id: string; // stable, so repeated updates of the same event don't duplicate items
+  type: ActivityType;
+  roundId: string;
+  pubkey: string; // player (or organizer for competitions)
+  hole?: number;
+  text: string; // e.g. "Birdie on 7"
+  createdAt: number; // ms
+}
+
+const SCORE_NAMES: { [toPar: number]: string } = { [-1]: 'Birdie', [-2]: 'Eagle', [-3]: 'Albatross' };
+
+/**
+ * Name of a notable hole score, or null for par or worse
+ */
+export function highlightName(strokes: number, par: number): string | null {
+  if (strokes === 1) return 'Hole in one';
+  return SCORE_NAMES[strokes - par] ?? (strokes - par < -3 ? 'Condor' : null);
+}
+
+/**
+ * Start and finish items for a round event. Private rounds are left out.
+ */
+export function roundActivity(event: NostrEvent): ActivityItem[] {
+  const tag = (name: string) => event.tags.find(([n]) => n === name)?.[1];
+  const roundId = tag('round-id') || tag('d');
+  if (!roundId || tag('visibility') === 'private') return [];
+
+  const teeTime = tag('tee-time');
+  const players = event.tags.find(([n]) => n === 'players')?.slice(1).filter(Boolean) ?? [];
+  const pubkey = players[0] ?? event.pubkey;
+  const group = players.length > 1 ? ` with ${players.length - 1} other${players.length === 2 ? '' : 's'}` : '';
+
+  const items: ActivityItem[] = [{
+    id: `${roundId}:started`,
+    type: 'round-started',
+    roundId,
+    pubkey,
+    text: `Teed off${group}`,
+    createdAt: teeTime ? parseInt(teeTime) * 1000 : event.created_at * 1000,
+  }];
+
+  if (tag('status') === 'completed') {
+    items.push({
+      id: `${roundId}:finished`,
+      type: 'round-finished',
+      roundId,
+      pubkey,
+      text: 'Finished their round',
+      createdAt: event.created_at * 1000,
+    });
+  }
+  return items;
+}
+
+/**
+ * Item for a competition published for a round (d tag `<roundId>-tournament`)
+ */
+export function competitionActivity(event: NostrEvent): ActivityItem | null {
+  const d = event.tags.find(([n]) => n === 'd')?.[1];
+  if (!d?.endsWith('-tournament')) return null;
+
+  const cup = event.tags.some(([n, v]) => n === 't' && v === 'pinseekr-cup');
+  return {
+    id: d,
+    type: 'competition-posted',
+    roundId: d.slice(0, -'-tournament'.length),
+    pubkey: event.pubkey,
+    text: cup ? 'Posted a Pinseekr Cup' : 'Posted a competition',
+    createdAt: event.created_at * 1000,
+  };
+}
+
+/**
+ * Items for notable hole scores on a card. Pars come from the card, falling
+ * back to the course's pars (indexed by hole - 1).
+ */
+export function scoreHighlights(record: PlayerScoreRecord, coursePars: number[] = []): ActivityItem[] {
+  const items: ActivityItem[] = [];
+  for (const [hole, strokes] of Object.entries(record.scores)) {
+    const n = Number(hole);
+    const par = record.pars[n] ?? coursePars[n - 1];
+    if (!par) continue;
+    const name = highlightName(strokes, par);
+    if (!name) continue;
+    items.push({
+      id: `${record.roundId}:${record.playerPubkey}:${n}`,
+      type: 'highlight',
+      roundId: record.roundId,
+      pubkey: record.playerPubkey,
+      hole: n,
+      text: `${name} on ${n}`,
+      createdAt: record.updatedAt,
+    });
+  }
+  return items;
+}
+
+/**
+ * Merge new items into a feed: one item per id (the earliest sighting keeps its
+ * time, so a card republished later doesn't bump old birdies), newest first
+ */
+export function mergeActivity(feed: ActivityItem[], items: ActivityItem[], limit = 50): ActivityItem[] {
+  const byId = new Map(feed.map(item => [item.id, item]));
+  for (const item of items) {
+    const existing = byId.get(item.id);
+    if (!existing || item.createdAt < existing.createdAt) byId.set(item.id, item);
+  }
+  return [...byId.values()].sort((a, b) => b.createdAt - a.createdAt).slice(0, limit);
+}
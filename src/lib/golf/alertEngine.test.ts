@@ -0,0 +1,38 @@
+import { describe, it, expect } from 'vitest';
+import { alertMapUrl, alertSummary, liveAlerts, type CourseAlert } from './alertEngine';
+import { createCourseAlertEvent, parseCourseAlertEvent } from './nostrEvents';
+
+const now = 1_000_000_000;
+
+function alert(alertId: string, overrides: Partial<CourseAlert> = {}): CourseAlert {
+  return {
+    alertId, courseId: 'oak', courseAuthor: 'club', type: 'lightning', message: 'Leave the course now',
+    expiresAt: now + 60_000, cleared: false, createdAt: now - 1000, ...overrides,
+  };
+}
+
+describe('Alert Engine', () => {
+  it('should show the club\'s alerts in force, newest first', () => {
+    const alerts = [alert('a1'), alert('a2', { createdAt: now - 500 }), alert('fake', { courseAuthor: 'prankster' })];
+    expect(liveAlerts(alerts, 'club', now).map(a => a.alertId)).toEqual(['a2', 'a1']);
+  });
+
+  it('should drop alerts that were cleared or have expired', () => {
+    const alerts = [alert('a1'), alert('a1', { cleared: true, createdAt: now }), alert('a2', { expiresAt: now - 1 })];
+    expect(liveAlerts(alerts, 'club', now)).toEqual([]);
+  });
+
+  it('should say where an emergency is', () => {
+    const medical = alert('m1', { type: 'medical', hole: 7, location: { lat: 51.5, lon: -0.1 } });
+    expect(alertSummary(medical)).toBe('Medical emergency near hole 7');
+    expect(alertMapUrl(medical)).toContain('mlat=51.5&mlon=-0.1');
+    expect(alertMapUrl(alert('a1'))).toBeNull();
+  });
+
+  it('should round-trip an alert through a Nostr event', () => {
+    const original = alert('m1', { type: 'medical', hole: 7, location: { lat: 51.5, lon: -0.1 }, expiresAt: now + 3_600_000 });
+    const event = { ...createCourseAlertEvent(original), created_at: original.createdAt / 1000 };
+    expect(event.tags).toContainEqual(['expiration', String((now + 3_600_000) / 1000)]);
+    expect(parseCourseAlertEvent(event)).toEqual(original);
+  });
+});
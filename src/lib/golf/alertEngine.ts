@@ -0,0 +1,57 @@
+// Urgent on-course alerts (lightning, medical emergencies, wildlife)
+// broadcast by the club to everyone playing the course
+
+export type AlertType = 'lightning' | 'medical' | 'wildlife' | 'hazard' | 'other';
+
+export const ALERT_TYPE_NAMES: Record<AlertType, string> = {
+  lightning: 'Lightning warning',
+  medical: 'Medical emergency',
+  wildlife: 'Wildlife',
+  hazard: 'Hazard',
+  other: 'Alert',
+};
+
+export interface CourseAlert {
+  alertId: string;
+  courseId: string;
+  courseAuthor: string;
+  type: AlertType;
+  message: string;
+  hole?: number;
+  location?: { lat: number; lon: number };
+  expiresAt: number; // ms
+  cleared: boolean; // the club gave the all clear
+  createdAt: number; // ms
+}
+
+// Alerts lapse on their own if the club forgets to clear them
+export const ALERT_DURATION_MINUTES = 120;
+
+/**
+ * Alerts in force, newest first. Only the club's alerts count, and the
+ * latest version of each replaces earlier ones.
+ */
+export function liveAlerts(alerts: CourseAlert[], club: string, now: number): CourseAlert[] {
+  const latest = new Map<string, CourseAlert>();
+  for (const alert of alerts) {
+    if (alert.courseAuthor !== club) continue;
+    const existing = latest.get(alert.alertId);
+    if (!existing || alert.createdAt > existing.createdAt) latest.set(alert.alertId, alert);
+  }
+
+  return [...latest.values()]
+    .filter(a => !a.cleared && a.expiresAt > now)
+    .sort((a, b) => b.createdAt - a.createdAt);
+}
+
+/** One-line summary for banners and notifications */
+export function alertSummary(alert: CourseAlert): string {
+  const where = alert.hole ? ` near hole ${alert.hole}` : '';
+  return `${ALERT_TYPE_NAMES[alert.type]}${where}`;
+}
+
+/** Map link for an alert's position, e.g. to guide help to a casualty */
+export function alertMapUrl(alert: CourseAlert): string | null {
+  if (!alert.location) return null;
+  return `https://www.openstreetmap.org/?mlat=${alert.location.lat}&mlon=${alert.location.lon}#map=18/${alert.location.lat}/${alert.location.lon}`;
+}
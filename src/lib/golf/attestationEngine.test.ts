@@ -0,0 +1,67 @@
+import { describe, it, expect } from 'vitest';
+import { countsForPlay, hostedRounds, resolveAttestation, scoreDigest, type ScoreAttestation } from './attestationEngine';
+
+describe('Attestation Engine', () => {
+  const card = { roundId: 'r1', playerPubkey: 'alice', scores: { 2: 5, 1: 4, 3: 3 } };
+  const digest = '1:4,2:5,3:3';
+
+  const attestation = (markerPubkey: string, verdict: 'attest' | 'dispute', createdAt: number, d = digest): ScoreAttestation => ({
+    roundId: 'r1',
+    playerPubkey: 'alice',
+    markerPubkey,
+    digest: d,
+    verdict,
+    createdAt,
+  });
+
+  it('should digest scores in hole order, ignoring empty holes', () => {
+    expect(scoreDigest(card.scores)).toBe(digest);
+    expect(scoreDigest({ 10: 4, 9: 0, 2: 3 })).toBe('2:3,10:4');
+  });
+
+  it('should be pending until a marker attests the current scores', () => {
+    expect(resolveAttestation(card, [], ['alice', 'bob']).status).toBe('pending');
+    expect(resolveAttestation(card, [attestation('bob', 'attest', 1)], ['alice', 'bob']).status).toBe('attested');
+  });
+
+  it('should ignore self-attestation and non-markers', () => {
+    const attestations = [attestation('alice', 'attest', 1), attestation('mallory', 'attest', 1)];
+    expect(resolveAttestation(card, attestations, ['alice', 'bob']).status).toBe('pending');
+  });
+
+  it('should return to pending when the card changes after attestation', () => {
+    const result = resolveAttestation(card, [attestation('bob', 'attest', 1, '1:4,2:4,3:3')], ['alice', 'bob']);
+    expect(result.status).toBe('pending');
+  });
+
+  it('should use each marker\'s latest verdict, with disputes outweighing attestations', () => {
+    const markers = ['alice', 'bob', 'carol'];
+
+    expect(resolveAttestation(card, [attestation('bob', 'dispute', 1), attestation('bob', 'attest', 2)], markers).status)
+      .toBe('attested');
+    expect(resolveAttestation(card, [attestation('bob', 'attest', 1), attestation('carol', 'dispute', 2)], markers).status)
+      .toBe('disputed');
+  });
+
+  it('should only count attested cards when attestation is required', () => {
+    expect(countsForPlay('pending', false)).toBe(true);
+    expect(countsForPlay('pending', true)).toBe(false);
+    expect(countsForPlay('attested', true)).toBe(true);
+    expect(countsForPlay('disputed', false)).toBe(false);
+  });
+
+  it('should take markers from the host\'s latest round and ignore versions from anyone else', () => {
+    const round = (pubkey: string, roundId: string, createdAt: number, players: string[]) =>
+      ({ pubkey, created_at: createdAt, tags: [['d', `code-${roundId}`], ['round-id', roundId], ['players', ...players]] });
+    const rounds = hostedRounds([
+      round('alice', 'r1', 1, ['alice', 'bob']),
+      round('alice', 'r1', 2, ['alice', 'carol']),
+      round('alice', 'r2', 1, ['alice', 'bob']),
+      round('mallory', 'r2', 2, ['alice', 'mallory']),
+    ]);
+
+    expect(rounds.get('r1')?.tags.find(t => t[0] === 'players')?.slice(1)).toEqual(['alice', 'carol']);
+    expect(rounds.get('r2')?.pubkey).toBe('alice');
+    expect(rounds.get('r2')?.tags.find(t => t[0] === 'players')?.slice(1)).toEqual(['alice', 'bob']);
+  });
+});
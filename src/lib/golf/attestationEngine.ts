@@ -0,0 +1,88 @@
+// Scorecard attestation: a marker countersigns a player's card before it counts
+
+export type AttestationStatus = 'pending' | 'attested' | 'disputed';
+
+export interface ScoreAttestation {
+  roundId: string;
+  playerPubkey: string;
+  markerPubkey: string;
+  digest: string; // the scores the marker signed off, see scoreDigest
+  verdict: 'attest' | 'dispute';
+  comment?: string;
+  createdAt: number; // ms
+}
+
+export interface AttestationResult {
+  status: AttestationStatus;
+  attestation: ScoreAttestation | null; // the attestation or dispute that decided the status
+}
+
+/**
+ * The host's latest version of each round, keyed by its round id (or join
+ * code). The host is whoever published the round first: later versions from
+ * anyone else are ignored, so nobody can list markers on someone else's round.
+ */
+export function hostedRounds<T extends { pubkey: string; created_at: number; tags: string[][] }>(events: T[]): Map<string, T> {
+  const versions = new Map<string, T[]>();
+  for (const event of events) {
+    const roundKey = event.tags.find(t => t[0] === 'round-id')?.[1] || event.tags.find(t => t[0] === 'd')?.[1];
+    if (roundKey) versions.set(roundKey, [...(versions.get(roundKey) ?? []), event]);
+  }
+
+  const rounds = new Map<string, T>();
+  for (const [roundKey, list] of versions) {
+    const host = list.reduce((first, e) => (e.created_at < first.created_at ? e : first)).pubkey;
+    rounds.set(roundKey, list.filter(e => e.pubkey === host).reduce((latest, e) => (e.created_at > latest.created_at ? e : latest)));
+  }
+  return rounds;
+}
+
+/**
+ * Compact, order-independent digest of a card's hole scores ("1:4,2:5,...").
+ * An attestation only covers the scores it was made against; any later edit
+ * puts the card back to pending.
+ */
+export function scoreDigest(scores: { [hole: number]: number }): string {
+  return Object.entries(scores)
+    .filter(([, strokes]) => strokes > 0)
+    .map(([hole, strokes]) => [Number(hole), strokes])
+    .sort((a, b) => a[0] - b[0])
+    .map(([hole, strokes]) => `${hole}:${strokes}`)
+    .join(',');
+}
+
+/**
+ * Attestation status of a player's card. Only markers count (never the player),
+ * and only each marker's latest verdict on the current scores; a dispute
+ * outweighs an attestation.
+ */
+export function resolveAttestation(
+  card: { roundId: string; playerPubkey: string; scores: { [hole: number]: number } },
+  attestations: ScoreAttestation[],
+  markers: string[]
+): AttestationResult {
+  const digest = scoreDigest(card.scores);
+  const latest = new Map<string, ScoreAttestation>();
+
+  for (const a of attestations) {
+    if (a.roundId !== card.roundId || a.playerPubkey !== card.playerPubkey) continue;
+    if (a.markerPubkey === card.playerPubkey || !markers.includes(a.markerPubkey)) continue;
+    const existing = latest.get(a.markerPubkey);
+    if (!existing || a.createdAt > existing.createdAt) latest.set(a.markerPubkey, a);
+  }
+
+  const current = [...latest.values()].filter(a => a.digest === digest);
+  const dispute = current.find(a => a.verdict === 'dispute');
+  if (dispute) return { status: 'disputed', attestation: dispute };
+
+  const attestation = current.find(a => a.verdict === 'attest');
+  return attestation ? { status: 'attested', attestation } : { status: 'pending', attestation: null };
+}
+
+/**
+ * Whether a card counts for handicap or competitions. Disputed cards never
+ * count; pending cards count unless attestation is required.
+ */
+export function countsForPlay(status: AttestationStatus, requireAttestation: boolean): boolean {
+  return status === 'attested' || (status === 'pending' && !requireAttestation);
+}
@@ -25,6 +25,18 @@ export class BadgeService {
         },
         rarity: 'legendary'
       },
+      {
+        id: 'first-birdie',
+        category: BadgeCategory.SCORING,
+        name: 'First Birdie',
+        description: 'Score your first birdie',
+        icon: '🐦',
+        criteria: {
+          type: 'hole-score',
+          conditions: { strokes: (par: number) => par - 1 }
+        },
+        rarity: 'common'
+      },
       {
         id: 'eagle-or-better',
         category: BadgeCategory.SCORING,
@@ -73,6 +85,18 @@ export class BadgeService {
         },
         rarity: 'epic'
       },
+      {
+        id: 'all-greens',
+        category: BadgeCategory.SCORING,
+        name: 'Greens Machine',
+        description: 'Hit all 18 greens in regulation',
+        icon: '🟢',
+        criteria: {
+          type: 'round-score',
+          conditions: { greens: 18, holes: 18 }
+        },
+        rarity: 'legendary'
+      },
       // Participation badges
       {
         id: 'first-round',
@@ -135,6 +159,30 @@ export class BadgeService {
         },
         rarity: 'legendary'
       },
+      {
+        id: 'closest-prediction',
+        category: BadgeCategory.SOCIAL,
+        name: 'Crystal Ball',
+        description: 'Make the closest prediction in your group',
+        icon: '🔮',
+        criteria: {
+          type: 'prediction',
+          conditions: { closest: true }
+        },
+        rarity: 'common'
+      },
+      {
+        id: 'called-it',
+        category: BadgeCategory.SOCIAL,
+        name: 'Called It',
+        description: 'Predict a result exactly',
+        icon: '🎯',
+        criteria: {
+          type: 'prediction',
+          conditions: { exact: true }
+        },
+        rarity: 'rare'
+      },
       // Milestone badges
       {
         id: 'century-round',
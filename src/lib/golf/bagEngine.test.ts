@@ -0,0 +1,108 @@
+import { describe, it, expect } from 'vitest';
+import {
+  calculateClubDistances,
+  filterMishits,
+  suggestClub,
+  validateBag,
+  DEFAULT_BAG,
+  type BagClub,
+  type ShotSample
+} from './bagEngine';
+import { createBagEvent, parseBagEvent, createShotEvent, parseShotEvent } from './nostrEvents';
+
+describe('Bag Engine', () => {
+  const sevenIron: BagClub = { id: '7i', type: 'iron', label: '7 Iron', carry: 150 };
+
+  const shots = (clubId: string, distances: number[]): ShotSample[] =>
+    distances.map((distance, i) => ({ shotId: `${clubId}-${i}`, clubId, distance, timestamp: 1000 + i }));
+
+  describe('validateBag', () => {
+    it('should accept the default bag', () => {
+      expect(validateBag(DEFAULT_BAG)).toEqual([]);
+      expect(DEFAULT_BAG).toHaveLength(14);
+    });
+
+    it('should reject more than 14 clubs and duplicates', () => {
+      const bag = [...DEFAULT_BAG, { ...sevenIron, id: '4i' }, { ...sevenIron, id: '3i' }];
+      expect(validateBag(bag)).toContain('A bag can hold at most 14 clubs');
+      expect(validateBag([sevenIron, sevenIron])).toContain('Duplicate club: 7i');
+    });
+  });
+
+  describe('filterMishits', () => {
+    it('should drop thin and fat shots', () => {
+      expect(filterMishits([160, 158, 162, 161, 90, 159, 200])).toEqual([158, 159, 160, 161, 162]);
+    });
+  });
+
+  describe('calculateClubDistances', () => {
+    it('should blend learned carry with the registered carry', () => {
+      const [distance] = calculateClubDistances([sevenIron], shots('7i', [160, 160, 160, 160, 160]));
+
+      expect(distance.learnedCarry).toBe(160);
+      expect(distance.effectiveCarry).toBe(155); // 5 shots weigh the same as the registered carry
+      expect(distance.samples).toBe(5);
+      expect(distance.dispersion).toBe(0);
+    });
+
+    it('should not report a learned carry until there are enough shots', () => {
+      const [distance] = calculateClubDistances([sevenIron], shots('7i', [170, 170]));
+
+      expect(distance.learnedCarry).toBeNull();
+      expect(distance.effectiveCarry).toBe(156);
+    });
+
+    it('should only use the most recent shots', () => {
+      const old = shots('7i', Array(10).fill(130));
+      const recent = shots('7i', Array(10).fill(160)).map(s => ({ ...s, shotId: `new-${s.shotId}`, timestamp: s.timestamp + 10_000 }));
+
+      const [distance] = calculateClubDistances([sevenIron], [...old, ...recent], { recentShots: 10 });
+      expect(distance.learnedCarry).toBe(160);
+    });
+  });
+
+  describe('suggestClub', () => {
+    const distances = calculateClubDistances(DEFAULT_BAG, []);
+
+    it('should pick the shortest club that carries the distance', () => {
+      expect(suggestClub(distances, 150)?.club.clubId).toBe('7i');
+      expect(suggestClub(distances, 150)?.margin).toBe(5);
+    });
+
+    it('should offer the shorter club when between clubs', () => {
+      const suggestion = suggestClub(distances, 148);
+      expect(suggestion?.club.clubId).toBe('7i');
+      expect(suggestion?.alternative?.clubId).toBe('8i');
+    });
+
+    it('should fall back to the longest club', () => {
+      expect(suggestClub(distances, 280)?.club.clubId).toBe('dr');
+    });
+  });
+
+  describe('Bag and shot events', () => {
+    it('should round-trip a bag', () => {
+      const event = createBagEvent([sevenIron], 'alice');
+      expect(parseBagEvent(event)).toEqual([sevenIron]);
+    });
+
+    it('should round-trip a shot with positions', () => {
+      const event = createShotEvent(
+        { shotId: 's1', clubId: '7i', distance: 152.4, timestamp: 1_700_000_000_000 },
+        'alice',
+        { roundId: 'r1', hole: 3, start: { lat: 40.1, lon: -105.2 } }
+      );
+
+      expect(parseShotEvent(event)).toEqual({
+        shotId: 's1',
+        clubId: '7i',
+        distance: 152,
+        timestamp: 1_700_000_000_000,
+        roundId: 'r1',
+        hole: 3,
+        start: { lat: 40.1, lon: -105.2 },
+        end: undefined
+      });
+    });
+  });
+});
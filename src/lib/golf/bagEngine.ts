@@ -0,0 +1,176 @@
+// Club and bag management: registered carry distances, learned from recorded shots
+
+export type ClubType =
+  | 'driver'
+  | 'wood'
+  | 'hybrid'
+  | 'iron'
+  | 'wedge'
+  | 'putter';
+
+export interface BagClub {
+  id: string; // e.g. "7i", "3w", "56"
+  type: ClubType;
+  label: string; // display name, e.g. "7 Iron"
+  carry: number; // typical carry registered by the player (yards)
+}
+
+export interface ShotSample {
+  shotId: string;
+  clubId: string;
+  distance: number; // measured carry (yards)
+  timestamp: number;
+}
+
+export interface ClubDistance {
+  clubId: string;
+  label: string;
+  registeredCarry: number;
+  learnedCarry: number | null; // from shots alone, once there are enough
+  effectiveCarry: number; // what suggestions use: blend of registered and learned
+  dispersion: number | null; // standard deviation of measured carries
+  samples: number; // shots used after discarding mishits
+}
+
+export interface BagConfig {
+  recentShots?: number; // only the most recent N shots per club count (default 30)
+  minSamples?: number; // shots needed before a learned carry is reported (default 5)
+  priorWeight?: number; // how many shots the registered carry is worth when blending (default 5)
+}
+
+// Standard 14-club set used when a player hasn't registered a bag
+export const DEFAULT_BAG: BagClub[] = [
+  { id: 'dr', type: 'driver', label: 'Driver', carry: 230 },
+  { id: '3w', type: 'wood', label: '3 Wood', carry: 210 },
+  { id: '5w', type: 'wood', label: '5 Wood', carry: 200 },
+  { id: '4h', type: 'hybrid', label: '4 Hybrid', carry: 190 },
+  { id: '5i', type: 'iron', label: '5 Iron', carry: 175 },
+  { id: '6i', type: 'iron', label: '6 Iron', carry: 165 },
+  { id: '7i', type: 'iron', label: '7 Iron', carry: 155 },
+  { id: '8i', type: 'iron', label: '8 Iron', carry: 145 },
+  { id: '9i', type: 'iron', label: '9 Iron', carry: 135 },
+  { id: 'pw', type: 'wedge', label: 'Pitching Wedge', carry: 125 },
+  { id: 'gw', type: 'wedge', label: 'Gap Wedge', carry: 110 },
+  { id: 'sw', type: 'wedge', label: 'Sand Wedge', carry: 95 },
+  { id: 'lw', type: 'wedge', label: 'Lob Wedge', carry: 80 },
+  { id: 'pt', type: 'putter', label: 'Putter', carry: 0 },
+];
+
+const MAX_CLUBS = 14; // Rule 4.2
+
+/**
+ * Validate a bag: unique ids, sensible carries, no more than 14 clubs
+ */
+export function validateBag(clubs: BagClub[]): string[] {
+  const errors: string[] = [];
+  const ids = new Set<string>();
+
+  if (clubs.length > MAX_CLUBS) {
+    errors.push(`A bag can hold at most ${MAX_CLUBS} clubs`);
+  }
+
+  for (const club of clubs) {
+    if (ids.has(club.id)) errors.push(`Duplicate club: ${club.id}`);
+    ids.add(club.id);
+
+    if (club.type !== 'putter' && (club.carry <= 0 || club.carry > 400)) {
+      errors.push(`${club.label}: carry must be between 1 and 400 yards`);
+    }
+  }
+
+  return errors;
+}
+
+function median(values: number[]): number {
+  const sorted = [...values].sort((a, b) => a - b);
+  const mid = Math.floor(sorted.length / 2);
+  return sorted.length % 2 === 0 ? (sorted[mid - 1] + sorted[mid]) / 2 : sorted[mid];
+}
+
+/**
+ * Drop mishits and outliers: anything outside 1.5 × IQR, or under 60% of the median
+ */
+export function filterMishits(distances: number[]): number[] {
+  if (distances.length < 4) return distances.filter(d => d > 0);
+
+  const sorted = [...distances].filter(d => d > 0).sort((a, b) => a - b);
+  const q1 = median(sorted.slice(0, Math.floor(sorted.length / 2)));
+  const q3 = median(sorted.slice(Math.ceil(sorted.length / 2)));
+  const iqr = q3 - q1;
+  const mid = median(sorted);
+
+  return sorted.filter(d => d >= q1 - 1.5 * iqr && d <= q3 + 1.5 * iqr && d >= mid * 0.6);
+}
+
+/**
+ * Learned distances for every club in the bag.
+ * The registered carry acts as a prior, so a couple of good or bad shots
+ * only nudge the effective carry until enough shots have been recorded.
+ */
+export function calculateClubDistances(
+  clubs: BagClub[],
+  shots: ShotSample[],
+  config: BagConfig = {}
+): ClubDistance[] {
+  const recent = config.recentShots ?? 30;
+  const minSamples = config.minSamples ?? 5;
+  const priorWeight = config.priorWeight ?? 5;
+
+  return clubs.map(club => {
+    const distances = shots
+      .filter(s => s.clubId === club.id)
+      .sort((a, b) => b.timestamp - a.timestamp)
+      .slice(0, recent)
+      .map(s => s.distance);
+
+    const kept = filterMishits(distances);
+    const mean = kept.length > 0 ? kept.reduce((sum, d) => sum + d, 0) / kept.length : 0;
+    const dispersion = kept.length >= 2
+      ? Math.sqrt(kept.reduce((sum, d) => sum + (d - mean) ** 2, 0) / (kept.length - 1))
+      : null;
+
+    const effective = kept.length > 0
+      ? (club.carry * priorWeight + mean * kept.length) / (priorWeight + kept.length)
+      : club.carry;
+
+    return {
+      clubId: club.id,
+      label: club.label,
+      registeredCarry: club.carry,
+      learnedCarry: kept.length >= minSamples ? Math.round(mean) : null,
+      effectiveCarry: Math.round(effective),
+      dispersion: dispersion !== null ? Math.round(dispersion * 10) / 10 : null,
+      samples: kept.length,
+    };
+  });
+}
+
+export interface ClubSuggestion {
+  club: ClubDistance;
+  alternative: ClubDistance | null; // next club down/up when between clubs
+  margin: number; // effective carry minus plays-like distance
+}
+
+/**
+ * Suggest the club for a plays-like distance: the shortest club that carries it,
+ * or the longest club if nothing does. Putters are never suggested.
+ */
+export function suggestClub(distances: ClubDistance[], playsLike: number): ClubSuggestion | null {
+  const candidates = distances
+    .filter(d => d.effectiveCarry > 0)
+    .sort((a, b) => a.effectiveCarry - b.effectiveCarry);
+  if (candidates.length === 0) return null;
+
+  const index = candidates.findIndex(d => d.effectiveCarry >= playsLike);
+  if (index === -1) {
+    const longest = candidates[candidates.length - 1];
+    return { club: longest, alternative: null, margin: longest.effectiveCarry - playsLike };
+  }
+
+  const club = candidates[index];
+  const shorter = index > 0 ? candidates[index - 1] : null;
+  // Offer the shorter club when the distance sits close to it
+  const alternative = shorter && playsLike - shorter.effectiveCarry <= 5 ? shorter : null;
+
+  return { club, alternative, margin: club.effectiveCarry - playsLike };
+}
@@ -0,0 +1,101 @@
+import { describe, it, expect } from 'vitest';
+import {
+  bearing,
+  courseCentre,
+  destinationPoint,
+  distanceYards,
+  playsLikeDistance,
+  puttingHint,
+  recommendShot,
+  windComponents,
+  type GeoPoint
+} from './caddieEngine';
+import { calculateClubDistances, DEFAULT_BAG } from './bagEngine';
+
+describe('Caddie Engine', () => {
+  const tee: GeoPoint = { lat: 40, lon: -105 };
+  // 150 yards due north of the tee
+  const green = destinationPoint(tee, 0, 150);
+  const distances = calculateClubDistances(DEFAULT_BAG, []);
+
+  describe('geometry', () => {
+    it('should measure distance and bearing', () => {
+      expect(distanceYards(tee, green)).toBeCloseTo(150, 1);
+      expect(bearing(green, tee)).toBeCloseTo(180, 3);
+      expect(bearing(tee, destinationPoint(tee, 90, 200))).toBeCloseTo(90, 1);
+    });
+
+    it('should find the middle of a course from its greens', () => {
+      expect(courseCentre({ 1: { lat: 51, lon: -1 }, 2: { lat: 52, lon: 0 } })).toEqual({ lat: 51.5, lon: -0.5 });
+      expect(courseCentre(undefined)).toBeNull();
+    });
+  });
+
+  describe('windComponents', () => {
+    it('should split wind relative to the shot line', () => {
+      expect(windComponents(0, { windSpeed: 10, windDirection: 0 })).toEqual({ headwind: 10, crosswind: 0 });
+      expect(windComponents(0, { windSpeed: 10, windDirection: 180 }).headwind).toBe(-10);
+      expect(windComponents(0, { windSpeed: 10, windDirection: 270 }).crosswind).toBe(10); // left to right
+    });
+
+    it('should ignore wind without a direction', () => {
+      expect(windComponents(45, { windSpeed: 15 })).toEqual({ headwind: 0, crosswind: 0 });
+    });
+  });
+
+  describe('playsLikeDistance', () => {
+    it('should penalise headwind more than it rewards tailwind', () => {
+      expect(playsLikeDistance(150, 10)).toBe(165);
+      expect(playsLikeDistance(150, -10)).toBe(143);
+    });
+
+    it('should add elevation change', () => {
+      expect(playsLikeDistance(150, 0, 10)).toBe(160);
+      expect(playsLikeDistance(150, 0, -10)).toBe(140);
+    });
+  });
+
+  describe('recommendShot', () => {
+    it('should suggest a club for still conditions and aim at the target', () => {
+      const rec = recommendShot(tee, green, distances);
+
+      expect(rec.distance).toBe(150);
+      expect(rec.playsLike).toBe(150);
+      expect(rec.suggestion?.club.clubId).toBe('7i');
+      expect(rec.aimOffset).toBe(0);
+      expect(rec.aimPoint).toEqual(green);
+    });
+
+    it('should club up into the wind and uphill', () => {
+      const rec = recommendShot(tee, green, distances, { windSpeed: 10, windDirection: 0, elevationChange: 5 });
+
+      expect(rec.playsLike).toBe(170);
+      expect(rec.elevationChange).toBe(5);
+      expect(rec.suggestion?.club.clubId).toBe('5i');
+    });
+
+    it('should aim into a crosswind', () => {
+      const rec = recommendShot(tee, green, distances, { windSpeed: 10, windDirection: 270 });
+
+      expect(rec.aimOffset).toBe(-7.5); // left of the target
+      expect(rec.aimPoint.lon).toBeLessThan(green.lon);
+      expect(distanceYards(rec.aimPoint, green)).toBeCloseTo(7.5, 1);
+    });
+  });
+
+  describe('puttingHint', () => {
+    it('should shorten putts and add break on fast greens', () => {
+      const hint = puttingHint(24, { stimp: 12 });
+      expect(hint.playsLikeFeet).toBe(20);
+      expect(hint.breakFactor).toBe(1.2);
+      expect(hint.tips[0]).toContain('Quick greens');
+    });
+
+    it('should lengthen putts on slow greens and mention firmness', () => {
+      const hint = puttingHint(16, { stimp: 8, firmness: 'soft' });
+      expect(hint.playsLikeFeet).toBe(20);
+      expect(hint.tips).toHaveLength(2);
+      expect(puttingHint(10, { stimp: 10 }).tips).toEqual([]);
+    });
+  });
+});
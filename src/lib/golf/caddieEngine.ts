@@ -0,0 +1,209 @@
+// Caddie: plays-like distance, club suggestion and aim point for a shot
+
+import { suggestClub, type ClubDistance, type ClubSuggestion } from './bagEngine';
+
+export interface GeoPoint {
+  lat: number;
+  lon: number;
+}
+
+export interface ShotConditions {
+  windSpeed?: number; // mph
+  windDirection?: number; // degrees the wind blows FROM (0 = north)
+  elevationChange?: number; // target height minus player height (yards)
+}
+
+export interface CaddieRecommendation {
+  distance: number; // straight-line yards to the target
+  playsLike: number; // adjusted for wind and elevation
+  elevationChange: number; // yards, positive = uphill
+  headwind: number; // mph, negative when downwind
+  crosswind: number; // mph, positive when blowing left to right
+  suggestion: ClubSuggestion | null;
+  aimPoint: GeoPoint; // where to aim so the wind brings the ball back to the target
+  aimOffset: number; // yards; positive = aim right of target
+  missRadius: number | null; // expected dispersion of the suggested club (yards)
+}
+
+const EARTH_RADIUS_YARDS = 6371000 * 1.09361;
+
+const toRad = (degrees: number) => degrees * (Math.PI / 180);
+const toDeg = (radians: number) => radians * (180 / Math.PI);
+
+/** Middle of a course, from its green positions */
+export function courseCentre(greens: { [hole: number]: GeoPoint } | undefined): GeoPoint | null {
+  const points = Object.values(greens ?? {});
+  if (points.length === 0) return null;
+  return {
+    lat: points.reduce((sum, p) => sum + p.lat, 0) / points.length,
+    lon: points.reduce((sum, p) => sum + p.lon, 0) / points.length,
+  };
+}
+
+/**
+ * Distance between two points in yards (Haversine formula)
+ */
+export function distanceYards(from: GeoPoint, to: GeoPoint): number {
+  const dLat = toRad(to.lat - from.lat);
+  const dLon = toRad(to.lon - from.lon);
+  const a =
+    Math.sin(dLat / 2) * Math.sin(dLat / 2) +
+    Math.cos(toRad(from.lat)) * Math.cos(toRad(to.lat)) *
+    Math.sin(dLon / 2) * Math.sin(dLon / 2);
+
+  return EARTH_RADIUS_YARDS * 2 * Math.atan2(Math.sqrt(a), Math.sqrt(1 - a));
+}
+
+/**
+ * Initial bearing from one point to another, degrees clockwise from north
+ */
+export function bearing(from: GeoPoint, to: GeoPoint): number {
+  const y = Math.sin(toRad(to.lon - from.lon)) * Math.cos(toRad(to.lat));
+  const x =
+    Math.cos(toRad(from.lat)) * Math.sin(toRad(to.lat)) -
+    Math.sin(toRad(from.lat)) * Math.cos(toRad(to.lat)) * Math.cos(toRad(to.lon - from.lon));
+
+  return (toDeg(Math.atan2(y, x)) + 360) % 360;
+}
+
+/**
+ * Point reached travelling `yards` from `from` on the given bearing
+ */
+export function destinationPoint(from: GeoPoint, bearingDegrees: number, yards: number): GeoPoint {
+  const angular = yards / EARTH_RADIUS_YARDS;
+  const lat1 = toRad(from.lat);
+  const lon1 = toRad(from.lon);
+  const b = toRad(bearingDegrees);
+
+  const lat2 = Math.asin(Math.sin(lat1) * Math.cos(angular) + Math.cos(lat1) * Math.sin(angular) * Math.cos(b));
+  const lon2 = lon1 + Math.atan2(
+    Math.sin(b) * Math.sin(angular) * Math.cos(lat1),
+    Math.cos(angular) - Math.sin(lat1) * Math.sin(lat2)
+  );
+
+  return { lat: toDeg(lat2), lon: toDeg(lon2) };
+}
+
+/**
+ * Distance in yards from a point to a polyline, using a local flat projection
+ */
+export function distanceToLine(point: GeoPoint, line: GeoPoint[]): number {
+  if (line.length === 1) return distanceYards(point, line[0]);
+
+  const yardsPerDegLat = distanceYards({ lat: 0, lon: 0 }, { lat: 1, lon: 0 });
+  const yardsPerDegLon = yardsPerDegLat * Math.cos(point.lat * (Math.PI / 180));
+  const project = (p: GeoPoint) => ({
+    x: (p.lon - point.lon) * yardsPerDegLon,
+    y: (p.lat - point.lat) * yardsPerDegLat,
+  });
+
+  let best = Infinity;
+  for (let i = 0; i < line.length - 1; i++) {
+    const a = project(line[i]);
+    const b = project(line[i + 1]);
+    const dx = b.x - a.x;
+    const dy = b.y - a.y;
+    const lengthSq = dx * dx + dy * dy;
+    const t = lengthSq === 0 ? 0 : Math.max(0, Math.min(1, -(a.x * dx + a.y * dy) / lengthSq));
+    best = Math.min(best, Math.hypot(a.x + t * dx, a.y + t * dy));
+  }
+  return best;
+}
+
+/**
+ * Split the wind into headwind and crosswind components relative to the shot line
+ */
+export function windComponents(shotBearing: number, conditions: ShotConditions): { headwind: number; crosswind: number } {
+  const speed = conditions.windSpeed ?? 0;
+  if (speed === 0 || conditions.windDirection === undefined) return { headwind: 0, crosswind: 0 };
+
+  // Wind direction is where it comes from; relative angle 0 means straight into the player
+  const relative = toRad(conditions.windDirection - shotBearing);
+  return {
+    headwind: Math.round(speed * Math.cos(relative) * 10) / 10 || 0,
+    crosswind: Math.round(-speed * Math.sin(relative) * 10) / 10 || 0,
+  };
+}
+
+/**
+ * Plays-like distance. Rules of thumb: a headwind adds 1% per mph, a tailwind
+ * takes off 0.5% per mph, and each yard of elevation counts as a yard of distance.
+ */
+export function playsLikeDistance(distance: number, headwind: number, elevationChange: number = 0): number {
+  const windFactor = headwind >= 0 ? 0.01 * headwind : 0.005 * headwind;
+  return Math.round(distance * (1 + windFactor) + elevationChange);
+}
+
+/**
+ * Recommend a club and aim point for a shot from `position` to `target`.
+ * Crosswind drift is about half a yard per mph per 100 yards of carry.
+ */
+export function recommendShot(
+  position: GeoPoint,
+  target: GeoPoint,
+  distances: ClubDistance[],
+  conditions: ShotConditions = {}
+): CaddieRecommendation {
+  const distance = Math.round(distanceYards(position, target));
+  const shotBearing = bearing(position, target);
+  const { headwind, crosswind } = windComponents(shotBearing, conditions);
+  const playsLike = playsLikeDistance(distance, headwind, conditions.elevationChange);
+
+  const suggestion = suggestClub(distances, playsLike);
+
+  // Aim into the crosswind so the ball drifts back to the target
+  const drift = crosswind * 0.005 * distance;
+  const aimOffset = Math.round(-drift * 10) / 10 || 0;
+  const aimPoint = aimOffset === 0
+    ? target
+    : destinationPoint(target, (shotBearing + (aimOffset > 0 ? 90 : 270)) % 360, Math.abs(aimOffset));
+
+  return {
+    distance,
+    playsLike,
+    elevationChange: conditions.elevationChange ?? 0,
+    headwind,
+    crosswind,
+    suggestion,
+    aimPoint,
+    aimOffset,
+    missRadius: suggestion?.club.dispersion ?? null,
+  };
+}
+
+export type GreenFirmness = 'soft' | 'medium' | 'firm';
+
+export interface GreenConditions {
+  stimp: number; // Stimpmeter reading in feet
+  firmness?: GreenFirmness;
+}
+
+export interface PuttingHint {
+  playsLikeFeet: number; // the putt's length on a stimp 10 green
+  breakFactor: number; // how much more (or less) to play the break than usual
+  tips: string[];
+}
+
+// The pace players are used to; readings are compared against it
+export const REFERENCE_STIMP = 10;
+
+/**
+ * Putting read from the day's green speed. A ball rolls about as far as the
+ * stimp for the same stroke, so a putt on faster greens plays shorter, and
+ * takes more break since it is moving slower as it nears the hole.
+ */
+export function puttingHint(distanceFeet: number, green: GreenConditions): PuttingHint {
+  const speed = green.stimp / REFERENCE_STIMP;
+  const tips: string[] = [];
+
+  if (green.stimp >= 11) tips.push('Quick greens: die the ball at the hole and play extra break');
+  else if (green.stimp <= 8.5) tips.push('Slow greens: be firm and take less break');
+  if (green.firmness === 'firm') tips.push('Firm greens: approaches release, land it short');
+  if (green.firmness === 'soft') tips.push('Soft greens: approaches check up, fly it to the pin');
+
+  return {
+    playsLikeFeet: Math.round((distanceFeet / speed) * 10) / 10,
+    breakFactor: Math.round(speed * 100) / 100,
+    tips,
+  };
+}
@@ -0,0 +1,58 @@
+import { describe, it, expect } from 'vitest';
+import type { NostrEvent } from '@nostrify/nostrify';
+import {
+  affectedHoles,
+  conditionReviewTags,
+  conditionStatus,
+  currentConditions,
+  parseConditionReview,
+  type ConditionReport,
+  type ConditionReviewLabel,
+} from './conditionEngine';
+
+const DAY = 24 * 60 * 60 * 1000;
+const now = 30 * DAY;
+
+function report(reportId: string, hole: number, createdAt = now - DAY): ConditionReport {
+  return { reportId, courseId: 'oak', courseAuthor: 'club', reporter: 'alice', hole, type: 'gur', description: '', images: [], createdAt };
+}
+
+function review(reportId: string, verdict: ConditionReviewLabel['review'], createdAt = now, reviewer = 'club'): ConditionReviewLabel {
+  return { reportAddress: `36925:alice:${reportId}`, review: verdict, reviewer, createdAt };
+}
+
+const addressOf = (r: ConditionReport) => `36925:${r.reporter}:${r.reportId}`;
+
+describe('Condition Engine', () => {
+  it('should use the club\'s latest review and ignore anyone else\'s', () => {
+    const reviews = [review('r1', 'confirmed', 1000), review('r1', 'fixed', 2000), review('r2', 'rejected', 1000, 'troll')];
+    expect(conditionStatus('36925:alice:r1', 'club', reviews)).toBe('fixed');
+    expect(conditionStatus('36925:alice:r2', 'club', reviews)).toBe('pending');
+  });
+
+  it('should hide rejected and fixed reports', () => {
+    const current = currentConditions([report('r1', 3), report('r2', 5), report('r3', 7)], [review('r1', 'rejected'), review('r2', 'fixed')], addressOf, now);
+    expect(current.map(c => c.reportId)).toEqual(['r3']);
+  });
+
+  it('should keep confirmed reports until fixed but let unreviewed ones expire', () => {
+    const old = now - 10 * DAY;
+    const current = currentConditions([report('r1', 3, old), report('r2', 5, old)], [review('r1', 'confirmed')], addressOf, now);
+    expect(current.map(c => [c.reportId, c.status])).toEqual([['r1', 'confirmed']]);
+  });
+
+  it('should list conditions by hole', () => {
+    const current = currentConditions([report('r1', 12), report('r2', 4), report('r3', 12)], [], addressOf, now);
+    expect(affectedHoles(current)).toEqual([4, 12]);
+    expect(current[0].hole).toBe(4);
+  });
+
+  it('should round-trip a review label', () => {
+    const label: NostrEvent = {
+      id: 'l1', pubkey: 'club', created_at: 100, kind: 1985, content: '', sig: '',
+      tags: conditionReviewTags('36925:alice:r1', 'alice', 'confirmed'),
+    };
+    expect(parseConditionReview(label)).toEqual({ reportAddress: '36925:alice:r1', review: 'confirmed', reviewer: 'club', createdAt: 100000 });
+    expect(parseConditionReview({ ...label, tags: [['l', 'confirmed', 'other'], ['a', 'x']] })).toBeNull();
+  });
+});
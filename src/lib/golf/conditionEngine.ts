@@ -0,0 +1,105 @@
+// Course conditions reported by players (ground under repair, temporary
+// greens, washed-out bunkers), reviewed by club staff with NIP-32 labels
+
+import type { NostrEvent } from '@nostrify/nostrify';
+
+export type ConditionType = 'gur' | 'temporary-green' | 'bunker-washout' | 'standing-water' | 'other';
+
+export const CONDITION_TYPE_NAMES: Record<ConditionType, string> = {
+  gur: 'Ground under repair',
+  'temporary-green': 'Temporary green',
+  'bunker-washout': 'Bunker washout',
+  'standing-water': 'Standing water',
+  other: 'Other',
+};
+
+export interface ConditionReport {
+  reportId: string;
+  courseId: string;
+  courseAuthor: string;
+  reporter: string; // pubkey
+  hole: number;
+  type: ConditionType;
+  description: string;
+  images: string[]; // photo URLs
+  createdAt: number; // ms
+}
+
+export type ConditionReview = 'confirmed' | 'rejected' | 'fixed';
+export type ConditionStatus = ConditionReview | 'pending';
+
+// NIP-32 label namespace for club reviews of condition reports
+export const CONDITION_LABEL_NAMESPACE = 'golf.pinseekr.condition';
+
+// Unreviewed reports drop off after a week; confirmed ones stay until fixed
+export const CONDITION_REPORT_MAX_AGE_DAYS = 7;
+
+export interface ConditionReviewLabel {
+  reportAddress: string; // <kind>:<reporter>:<reportId>
+  review: ConditionReview;
+  reviewer: string;
+  createdAt: number; // ms
+}
+
+export interface CurrentCondition extends ConditionReport {
+  status: 'confirmed' | 'pending';
+}
+
+/** Tags for a club's review label (kind 1985) on a report */
+export function conditionReviewTags(reportAddress: string, reporter: string, review: ConditionReview): string[][] {
+  return [
+    ['L', CONDITION_LABEL_NAMESPACE],
+    ['l', review, CONDITION_LABEL_NAMESPACE],
+    ['a', reportAddress],
+    ['p', reporter],
+  ];
+}
+
+/** Parse a review label, ignoring labels in other namespaces */
+export function parseConditionReview(event: NostrEvent): ConditionReviewLabel | null {
+  if (event.kind !== 1985) return null;
+  const review = event.tags.find(([name, , namespace]) => name === 'l' && namespace === CONDITION_LABEL_NAMESPACE)?.[1];
+  const reportAddress = event.tags.find(([name]) => name === 'a')?.[1];
+  if (!reportAddress || (review !== 'confirmed' && review !== 'rejected' && review !== 'fixed')) return null;
+  return { reportAddress, review, reviewer: event.pubkey, createdAt: event.created_at * 1000 };
+}
+
+/**
+ * Where a report stands: the club's latest review of it, or pending. Only
+ * the club's own labels count.
+ */
+export function conditionStatus(address: string, club: string, reviews: ConditionReviewLabel[]): ConditionStatus {
+  const latest = reviews
+    .filter(r => r.reportAddress === address && r.reviewer === club)
+    .sort((a, b) => b.createdAt - a.createdAt)[0];
+  return latest?.review ?? 'pending';
+}
+
+/**
+ * Conditions to show players, by hole then newest first: confirmed reports
+ * until the club marks them fixed, and recent reports the club hasn't
+ * reviewed yet
+ */
+export function currentConditions(
+  reports: ConditionReport[],
+  reviews: ConditionReviewLabel[],
+  addressOf: (report: ConditionReport) => string,
+  now: number
+): CurrentCondition[] {
+  const cutoff = now - CONDITION_REPORT_MAX_AGE_DAYS * 24 * 60 * 60 * 1000;
+  const current: CurrentCondition[] = [];
+
+  for (const report of reports) {
+    const status = conditionStatus(addressOf(report), report.courseAuthor, reviews);
+    if (status === 'confirmed' || (status === 'pending' && report.createdAt >= cutoff)) {
+      current.push({ ...report, status });
+    }
+  }
+
+  return current.sort((a, b) => a.hole - b.hole || b.createdAt - a.createdAt);
+}
+
+/** Holes with current conditions, in order */
+export function affectedHoles(conditions: CurrentCondition[]): number[] {
+  return [...new Set(conditions.map(c => c.hole))].sort((a, b) => a - b);
+}
@@ -0,0 +1,61 @@
+import { describe, it, expect } from 'vitest';
+import type { NostrEvent } from '@nostrify/nostrify';
+import { DEFAULT_CONTENT_FILTER, passesContentFilter, powDifficulty, trustGraph } from './contentFilterEngine';
+
+const ADMIN = 'a'.repeat(64);
+const FRIEND = 'b'.repeat(64);
+const FRIEND_OF_FRIEND = 'c'.repeat(64);
+const STRANGER = 'd'.repeat(64);
+
+function event(pubkey: string, id = 'f'.repeat(64), kind = 1, tags: string[][] = []): NostrEvent {
+  return { id, pubkey, created_at: 1000, kind, tags, content: 'Nice round', sig: '' };
+}
+
+const contactLists = new Map([
+  [ADMIN, event(ADMIN, '1', 3, [['p', FRIEND]])],
+  [FRIEND, event(FRIEND, '2', 3, [['p', FRIEND_OF_FRIEND]])],
+]);
+
+describe('Content Filter Engine', () => {
+  it('should count leading zero bits of the event id', () => {
+    expect(powDifficulty(event(STRANGER, '000f' + '0'.repeat(60)))).toBe(12);
+    expect(powDifficulty(event(STRANGER, '002a' + 'f'.repeat(60)))).toBe(10);
+    expect(powDifficulty(event(STRANGER, 'f'.repeat(64)))).toBe(0);
+  });
+
+  it('should follow contact lists out to the configured depth', () => {
+    expect([...trustGraph([ADMIN], contactLists, 1)]).toEqual([ADMIN, FRIEND]);
+    expect(trustGraph([ADMIN], contactLists, 2).has(FRIEND_OF_FRIEND)).toBe(true);
+    expect(trustGraph([ADMIN], contactLists, 0).size).toBe(1);
+  });
+
+  it('should hide authors outside the web of trust when it is enabled', () => {
+    const config = { ...DEFAULT_CONTENT_FILTER, trustedPubkeys: [ADMIN], wotDepth: 1 };
+    const trusted = trustGraph([ADMIN], contactLists, 1);
+    expect(passesContentFilter(event(FRIEND), config, { trusted })).toBe(true);
+    expect(passesContentFilter(event(STRANGER), config, { trusted })).toBe(false);
+    expect(passesContentFilter(event(STRANGER), DEFAULT_CONTENT_FILTER, { trusted })).toBe(true);
+  });
+
+  it('should require the proof-of-work minimum and honour the mute list', () => {
+    const config = { ...DEFAULT_CONTENT_FILTER, minPow: 8 };
+    expect(passesContentFilter(event(STRANGER, '00ff' + '0'.repeat(60)), config)).toBe(true);
+    expect(passesContentFilter(event(STRANGER, '0fff' + '0'.repeat(60)), config)).toBe(false);
+    expect(passesContentFilter(event(STRANGER), DEFAULT_CONTENT_FILTER, { muted: [STRANGER] })).toBe(false);
+    expect(passesContentFilter(event(STRANGER), { ...DEFAULT_CONTENT_FILTER, useMuteList: false }, { muted: [STRANGER] })).toBe(true);
+  });
+
+  it('should hide content reported by an admin', () => {
+    const reported = { events: new Set(['e'.repeat(64)]), authors: new Set([FRIEND]) };
+    expect(passesContentFilter(event(STRANGER, 'e'.repeat(64)), DEFAULT_CONTENT_FILTER, { reported })).toBe(false);
+    expect(passesContentFilter(event(FRIEND), DEFAULT_CONTENT_FILTER, { reported })).toBe(false);
+    expect(passesContentFilter(event(STRANGER), DEFAULT_CONTENT_FILTER, { reported })).toBe(true);
+  });
+
+  it('should always show admins and the viewer', () => {
+    const config = { ...DEFAULT_CONTENT_FILTER, trustedPubkeys: [ADMIN], wotDepth: 1, minPow: 20 };
+    const trusted = new Set<string>([ADMIN]);
+    expect(passesContentFilter(event(ADMIN), config, { trusted })).toBe(true);
+    expect(passesContentFilter(event(STRANGER), config, { trusted, viewer: STRANGER })).toBe(true);
+  });
+});
@@ -0,0 +1,80 @@
+// Spam filtering for notes, comments and reactions pulled in from Nostr
+
+import type { NostrEvent } from '@nostrify/nostrify';
+import { followedPubkeys } from './friendsEngine';
+
+export interface ContentFilterConfig {
+  trustedPubkeys: string[]; // club admins the web of trust starts from
+  wotDepth: number; // 0 = off, 1 = admins' follows, 2 = follows of follows
+  minPow: number; // NIP-13 leading zero bits, 0 = off
+  useMuteList: boolean; // hide authors on the viewer's NIP-51 mute list
+}
+
+export const DEFAULT_CONTENT_FILTER: ContentFilterConfig = {
+  trustedPubkeys: [],
+  wotDepth: 0,
+  minPow: 0,
+  useMuteList: true,
+};
+
+export const MAX_WOT_DEPTH = 2;
+
+/** Proof-of-work difficulty of an event: leading zero bits of its id (NIP-13) */
+export function powDifficulty(event: NostrEvent): number {
+  let bits = 0;
+  for (const char of event.id) {
+    const nibble = parseInt(char, 16);
+    if (Number.isNaN(nibble)) break;
+    if (nibble === 0) {
+      bits += 4;
+      continue;
+    }
+    bits += Math.clz32(nibble) - 28;
+    break;
+  }
+  return bits;
+}
+
+/**
+ * Web of trust: the trusted pubkeys plus everyone reachable from them through
+ * contact lists, up to `depth` hops. `contactLists` maps a pubkey to its
+ * latest kind 3 event; lists that weren't fetched are skipped.
+ */
+export function trustGraph(roots: string[], contactLists: Map<string, NostrEvent>, depth: number): Set<string> {
+  const trusted = new Set(roots);
+  let frontier = [...trusted];
+
+  for (let hop = 0; hop < Math.min(depth, MAX_WOT_DEPTH); hop++) {
+    const next: string[] = [];
+    for (const pubkey of frontier) {
+      for (const followed of followedPubkeys(contactLists.get(pubkey))) {
+        if (trusted.has(followed)) continue;
+        trusted.add(followed);
+        next.push(followed);
+      }
+    }
+    frontier = next;
+  }
+  return trusted;
+}
+
+export interface FilterContext {
+  trusted?: Set<string>; // web of trust, when enabled
+  muted?: string[];
+  reported?: { events: Set<string>; authors: Set<string> }; // reports by the admins
+  viewer?: string; // the viewer's own events always show
+}
+
+/**
+ * Whether an event gets through the filter. Trusted admins and the viewer
+ * always do; everyone else must be in the web of trust (when it is enabled),
+ * not muted or reported by an admin, and meet the proof-of-work minimum.
+ */
+export function passesContentFilter(event: NostrEvent, config: ContentFilterConfig, context: FilterContext = {}): boolean {
+  if (event.pubkey === context.viewer || config.trustedPubkeys.includes(event.pubkey)) return true;
+  if (config.useMuteList && context.muted?.includes(event.pubkey)) return false;
+  if (context.reported?.events.has(event.id) || context.reported?.authors.has(event.pubkey)) return false;
+  if (config.wotDepth > 0 && context.trusted && !context.trusted.has(event.pubkey)) return false;
+  if (config.minPow > 0 && powDifficulty(event) < config.minPow) return false;
+  return true;
+}
@@ -0,0 +1,124 @@
+import { describe, it, expect } from 'vitest';
+import {
+  parseCsv,
+  parseCourseCsv,
+  parseCourseJson,
+  planCourseImport,
+  validateImportedCourse,
+  type ExistingCourse
+} from './courseImport';
+
+describe('Course Import', () => {
+  const pars = [4, 4, 3, 5, 4, 4, 3, 4, 5];
+
+  it('should parse quoted CSV fields', () => {
+    expect(parseCsv('a,"b, c","d ""e"""\r\n1,2,3\n')).toEqual([
+      ['a', 'b, c', 'd "e"'],
+      ['1', '2', '3']
+    ]);
+  });
+
+  it('should parse long-format CSV with tees and ratings', () => {
+    const rows = pars.flatMap((par, i) => [
+      `Pine Valley,Clementon,NJ,Blue,72.1,131,${i + 1},${par},${i + 1},${350 + i}`,
+      `Pine Valley,Clementon,NJ,White,70.2,125,${i + 1},${par},${i + 1},${320 + i}`
+    ]);
+    const csv = ['Course Name,City,State,Tee Name,Course Rating,Slope Rating,Hole,Par,HCP,Yards', ...rows].join('\n');
+
+    const { courses, errors } = parseCourseCsv(csv);
+
+    expect(errors).toEqual([]);
+    expect(courses).toHaveLength(1);
+    expect(courses[0].location).toBe('Clementon, NJ');
+    expect(courses[0].tees).toEqual(['Blue', 'White']);
+    expect(courses[0].teeRatings?.White).toEqual({ courseRating: 70.2, slopeRating: 125 });
+    expect(courses[0].holes[4]).toBe(5);
+    expect(courses[0].teeYardages?.Blue[9]).toBe(358);
+    expect(validateImportedCourse(courses[0])).toEqual([]);
+  });
+
+  it('should parse wide-format CSV', () => {
+    const header = ['Course', 'Tee', 'Rating', 'Slope', ...pars.map((_, i) => `Par${i + 1}`), ...pars.map((_, i) => `Yds${i + 1}`)];
+    const row = ['Nine Hole Club', 'Red', '33.1', '110', ...pars.map(String), ...pars.map(p => String(p * 80))];
+
+    const { courses } = parseCourseCsv([header.join(','), row.join(',')].join('\n'));
+
+    expect(Object.keys(courses[0].holes)).toHaveLength(9);
+    expect(courses[0].teeYardages?.Red[4]).toBe(400);
+    expect(courses[0].teeRatings?.Red.slopeRating).toBe(110);
+  });
+
+  it('should report missing columns', () => {
+    expect(parseCourseCsv('Course,Tee\nA,Blue').errors).toEqual(['Expected Hole and Par columns, or Par1..ParN columns']);
+  });
+
+  it('should parse JSON exports', () => {
+    const json = JSON.stringify([{
+      courseName: 'Links',
+      city: 'St Andrews',
+      country: 'Scotland',
+      tees: [{ name: 'Medal', rating: 73.1, slope: 132, holes: pars.map((par, i) => ({ hole: i + 1, par, strokeIndex: i + 1, yards: 400 })) }]
+    }]);
+
+    const { courses, errors } = parseCourseJson(json);
+
+    expect(errors).toEqual([]);
+    expect(courses[0].location).toBe('St Andrews, Scotland');
+    expect(courses[0].handicaps?.[9]).toBe(9);
+    expect(parseCourseJson('{').errors).toEqual(['Invalid JSON']);
+  });
+
+  it('should flag invalid courses', () => {
+    const errors = validateImportedCourse({
+      name: 'Bad',
+      location: '',
+      holes: { 1: 4, 2: 8 },
+      handicaps: { 1: 1, 2: 1 },
+      teeRatings: { Blue: { courseRating: 70, slopeRating: 170 } }
+    });
+
+    expect(errors).toEqual([
+      'Bad: expected 9 or 18 holes, found 2',
+      'Bad: hole 2 has par 8',
+      'Bad: duplicate stroke indexes',
+      'Bad: Blue slope rating must be between 55 and 155'
+    ]);
+  });
+
+  describe('planCourseImport', () => {
+    const holes = Object.fromEntries(pars.map((par, i) => [i + 1, par]));
+    const existing: ExistingCourse = {
+      id: 'links-1',
+      name: 'Links',
+      location: 'St Andrews',
+      holes,
+      tees: ['Medal'],
+      teeRatings: { Medal: { courseRating: 72.0, slopeRating: 130 } }
+    };
+
+    it('should create new courses and leave unchanged ones alone', () => {
+      const plan = planCourseImport([existing], [
+        { name: 'New Course', location: 'Somewhere', holes },
+        { name: 'links', location: '', holes }
+      ]);
+
+      expect(plan.map(p => p.action)).toEqual(['create', 'unchanged']);
+    });
+
+    it('should describe updates and merge over the existing course', () => {
+      const [entry] = planCourseImport([existing], [{
+        name: 'LINKS',
+        location: 'St Andrews',
+        holes: { ...holes, 9: 4 },
+        tees: ['Medal', 'Forward'],
+        teeRatings: { Medal: { courseRating: 73.1, slopeRating: 132 } }
+      }]);
+
+      expect(entry.action).toBe('update');
+      expect(entry.existingId).toBe('links-1');
+      expect(entry.changes).toEqual(['hole 9 par: 5 → 4', 'Medal rating: 72/130 → 73.1/132', 'new tee: Forward']);
+      expect(entry.course.name).toBe('Links');
+      expect(entry.course.tees).toEqual(['Medal', 'Forward']);
+    });
+  });
+});
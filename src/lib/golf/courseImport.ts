@@ -0,0 +1,392 @@
+// Bulk course import from course data exports (iGolf, USGA NCRDB, rate sheets)
+
+export interface TeeRatingRecord {
+  courseRating: number;
+  slopeRating: number;
+}
+
+export interface ImportedCourse {
+  name: string;
+  location: string;
+  holes: { [hole: number]: number }; // hole number -> par
+  handicaps?: { [hole: number]: number }; // hole number -> stroke index
+  tees?: string[];
+  teeYardages?: { [teeName: string]: { [hole: number]: number } };
+  teeRatings?: { [teeName: string]: TeeRatingRecord };
+  greens?: { [hole: number]: { lat: number; lon: number } }; // green centre positions
+}
+
+// Existing course as loaded by useGolfCourses
+export interface ExistingCourse extends ImportedCourse {
+  id: string;
+}
+
+export interface CourseImportResult {
+  courses: ImportedCourse[];
+  errors: string[];
+}
+
+export type CourseImportAction = 'create' | 'update' | 'unchanged';
+
+export interface CourseImportPlanEntry {
+  action: CourseImportAction;
+  course: ImportedCourse;
+  existingId?: string;
+  changes: string[]; // human-readable differences from the existing course
+}
+
+// Header aliases seen in common exports, normalised to lower case without punctuation
+const COLUMN_ALIASES: Record<string, string[]> = {
+  name: ['coursename', 'course', 'name', 'facilitycourse'],
+  city: ['city', 'town'],
+  state: ['state', 'stateprovince', 'province', 'region'],
+  country: ['country'],
+  location: ['location', 'address'],
+  tee: ['teename', 'tee', 'teecolor', 'teeset'],
+  rating: ['courserating', 'rating', 'cr', 'teerating'],
+  slope: ['sloperating', 'slope', 'sr'],
+  hole: ['hole', 'holenumber', 'holeno'],
+  par: ['par', 'holepar'],
+  handicap: ['handicap', 'hcp', 'hdcp', 'strokeindex', 'si'],
+  yards: ['yards', 'yardage', 'distance', 'length'],
+};
+
+const normaliseHeader = (header: string) => header.toLowerCase().replace(/[^a-z0-9]/g, '');
+
+/**
+ * Split CSV text into rows, honouring quoted fields
+ */
+export function parseCsv(text: string): string[][] {
+  const rows: string[][] = [];
+  let row: string[] = [];
+  let field = '';
+  let quoted = false;
+
+  for (let i = 0; i < text.length; i++) {
+    const char = text[i];
+    if (quoted) {
+      if (char === '"' && text[i + 1] === '"') {
+        field += '"';
+        i++;
+      } else if (char === '"') {
+        quoted = false;
+      } else {
+        field += char;
+      }
+    } else if (char === '"') {
+      quoted = true;
+    } else if (char === ',') {
+      row.push(field.trim());
+      field = '';
+    } else if (char === '\n' || char === '\r') {
+      if (char === '\r' && text[i + 1] === '\n') i++;
+      row.push(field.trim());
+      if (row.some(f => f !== '')) rows.push(row);
+      row = [];
+      field = '';
+    } else {
+      field += char;
+    }
+  }
+
+  row.push(field.trim());
+  if (row.some(f => f !== '')) rows.push(row);
+  return rows;
+}
+
+function courseKey(name: string): string {
+  return name.toLowerCase().trim();
+}
+
+function getOrCreate(courses: Map<string, ImportedCourse>, name: string, location: string): ImportedCourse {
+  const key = courseKey(name);
+  let course = courses.get(key);
+  if (!course) {
+    course = { name, location, holes: {} };
+    courses.set(key, course);
+  }
+  return course;
+}
+
+function addTee(course: ImportedCourse, tee: string, rating: number, slope: number) {
+  if (!tee) return;
+  course.tees = course.tees ?? [];
+  if (!course.tees.includes(tee)) course.tees.push(tee);
+  if (!isNaN(rating) && !isNaN(slope)) {
+    course.teeRatings = { ...course.teeRatings, [tee]: { courseRating: rating, slopeRating: slope } };
+  }
+}
+
+function setHole(course: ImportedCourse, tee: string, hole: number, par: number, handicap: number, yards: number) {
+  if (!isNaN(par)) course.holes[hole] = par;
+  if (!isNaN(handicap)) course.handicaps = { ...course.handicaps, [hole]: handicap };
+  if (tee && !isNaN(yards)) {
+    course.teeYardages = course.teeYardages ?? {};
+    course.teeYardages[tee] = { ...course.teeYardages[tee], [hole]: yards };
+  }
+}
+
+/**
+ * Parse a course CSV export. Supports one row per tee and hole (long format)
+ * and one row per tee with Par1..Par18 / Hcp1.. / Yds1.. columns (wide format).
+ */
+export function parseCourseCsv(text: string): CourseImportResult {
+  const rows = parseCsv(text);
+  const errors: string[] = [];
+  if (rows.length < 2) return { courses: [], errors: ['No data rows found'] };
+
+  const headers = rows[0].map(normaliseHeader);
+  const column = (field: string) => headers.findIndex(h => COLUMN_ALIASES[field].includes(h));
+  const holeColumns = (prefixes: string[]) => {
+    const result = new Map<number, number>();
+    headers.forEach((h, index) => {
+      const match = h.match(/^([a-z]+)(\d{1,2})$/);
+      if (match && prefixes.includes(match[1])) result.set(Number(match[2]), index);
+    });
+    return result;
+  };
+
+  const nameCol = column('name');
+  if (nameCol === -1) return { courses: [], errors: ['Missing course name column'] };
+
+  const cols = {
+    city: column('city'),
+    state: column('state'),
+    country: column('country'),
+    location: column('location'),
+    tee: column('tee'),
+    rating: column('rating'),
+    slope: column('slope'),
+    hole: column('hole'),
+    par: column('par'),
+    handicap: column('handicap'),
+    yards: column('yards'),
+  };
+  const widePars = holeColumns(['par']);
+  const wideHandicaps = holeColumns(['hcp', 'hdcp', 'handicap', 'si']);
+  const wideYards = holeColumns(['yds', 'yards', 'yardage']);
+  const isWide = widePars.size > 0;
+
+  if (!isWide && (cols.hole === -1 || cols.par === -1)) {
+    return { courses: [], errors: ['Expected Hole and Par columns, or Par1..ParN columns'] };
+  }
+
+  const courses = new Map<string, ImportedCourse>();
+  const cell = (row: string[], index: number) => (index >= 0 ? row[index] ?? '' : '');
+  const num = (row: string[], index: number) => parseFloat(cell(row, index));
+
+  rows.slice(1).forEach((row, i) => {
+    const line = i + 2;
+    const name = cell(row, nameCol);
+    if (!name) {
+      errors.push(`Row ${line}: missing course name`);
+      return;
+    }
+
+    const location = cell(row, cols.location) ||
+      [cell(row, cols.city), cell(row, cols.state), cell(row, cols.country)].filter(Boolean).join(', ');
+    const course = getOrCreate(courses, name, location);
+    const tee = cell(row, cols.tee);
+    addTee(course, tee, num(row, cols.rating), num(row, cols.slope));
+
+    if (isWide) {
+      for (const [hole, index] of widePars) {
+        setHole(course, tee, hole, num(row, index), num(row, wideHandicaps.get(hole) ?? -1), num(row, wideYards.get(hole) ?? -1));
+      }
+    } else {
+      const hole = num(row, cols.hole);
+      if (!Number.isInteger(hole) || hole < 1) {
+        errors.push(`Row ${line}: invalid hole number`);
+        return;
+      }
+      setHole(course, tee, hole, num(row, cols.par), num(row, cols.handicap), num(row, cols.yards));
+    }
+  });
+
+  return { courses: [...courses.values()], errors };
+}
+
+interface JsonHole {
+  number?: number;
+  hole?: number;
+  par?: number;
+  handicap?: number;
+  strokeIndex?: number;
+  yards?: number;
+  yardage?: number;
+}
+
+interface JsonTee {
+  name?: string;
+  teeName?: string;
+  courseRating?: number;
+  rating?: number;
+  slopeRating?: number;
+  slope?: number;
+  holes?: JsonHole[];
+}
+
+interface JsonCourse {
+  name?: string;
+  courseName?: string;
+  location?: string;
+  city?: string;
+  state?: string;
+  country?: string;
+  holes?: JsonHole[];
+  tees?: JsonTee[];
+}
+
+/**
+ * Parse a course JSON export: a course or array of courses, each with
+ * holes and/or tees carrying ratings and per-hole data
+ */
+export function parseCourseJson(text: string): CourseImportResult {
+  let data: unknown;
+  try {
+    data = JSON.parse(text);
+  } catch {
+    return { courses: [], errors: ['Invalid JSON'] };
+  }
+
+  const items = (Array.isArray(data) ? data : [data]) as JsonCourse[];
+  const courses = new Map<string, ImportedCourse>();
+  const errors: string[] = [];
+
+  items.forEach((item, i) => {
+    const name = item?.name || item?.courseName;
+    if (!name) {
+      errors.push(`Course ${i + 1}: missing name`);
+      return;
+    }
+
+    const location = item.location || [item.city, item.state, item.country].filter(Boolean).join(', ');
+    const course = getOrCreate(courses, name, location);
+    const addHoles = (tee: string, holes: JsonHole[] = []) => {
+      for (const h of holes) {
+        const number = h.number ?? h.hole;
+        if (!number) continue;
+        setHole(course, tee, number, h.par ?? NaN, h.handicap ?? h.strokeIndex ?? NaN, h.yards ?? h.yardage ?? NaN);
+      }
+    };
+
+    addHoles('', item.holes);
+    for (const tee of item.tees ?? []) {
+      const teeName = tee.name || tee.teeName || '';
+      addTee(course, teeName, tee.courseRating ?? tee.rating ?? NaN, tee.slopeRating ?? tee.slope ?? NaN);
+      addHoles(teeName, tee.holes);
+    }
+  });
+
+  return { courses: [...courses.values()], errors };
+}
+
+/**
+ * Validate an imported course before publishing
+ */
+export function validateImportedCourse(course: ImportedCourse): string[] {
+  const errors: string[] = [];
+  const holes = Object.keys(course.holes).map(Number);
+
+  if (holes.length !== 9 && holes.length !== 18) {
+    errors.push(`${course.name}: expected 9 or 18 holes, found ${holes.length}`);
+  }
+  for (const hole of holes) {
+    const par = course.holes[hole];
+    if (par < 3 || par > 6) errors.push(`${course.name}: hole ${hole} has par ${par}`);
+  }
+
+  if (course.handicaps) {
+    const indexes = Object.values(course.handicaps);
+    if (new Set(indexes).size !== indexes.length) {
+      errors.push(`${course.name}: duplicate stroke indexes`);
+    }
+  }
+
+  for (const [tee, rating] of Object.entries(course.teeRatings ?? {})) {
+    if (rating.slopeRating < 55 || rating.slopeRating > 155) {
+      errors.push(`${course.name}: ${tee} slope rating must be between 55 and 155`);
+    }
+  }
+
+  return errors;
+}
+
+function describeChanges(existing: ExistingCourse, course: ImportedCourse): string[] {
+  const changes: string[] = [];
+  const same = (a: unknown, b: unknown) => JSON.stringify(a ?? null) === JSON.stringify(b ?? null);
+
+  if (course.location && course.location !== existing.location) {
+    changes.push(`location: ${existing.location || '(none)'} → ${course.location}`);
+  }
+  for (const hole of Object.keys(course.holes).map(Number)) {
+    if (existing.holes[hole] !== course.holes[hole]) {
+      changes.push(`hole ${hole} par: ${existing.holes[hole] ?? '-'} → ${course.holes[hole]}`);
+    }
+  }
+  if (course.handicaps && !same(existing.handicaps, course.handicaps)) changes.push('stroke indexes');
+  if (course.greens && !same(existing.greens, course.greens)) changes.push('green positions');
+  for (const tee of course.tees ?? []) {
+    if (!existing.tees?.includes(tee)) {
+      changes.push(`new tee: ${tee}`);
+      continue;
+    }
+    if (!same(existing.teeYardages?.[tee], course.teeYardages?.[tee]) && course.teeYardages?.[tee]) {
+      changes.push(`${tee} yardages`);
+    }
+    const before = existing.teeRatings?.[tee];
+    const after = course.teeRatings?.[tee];
+    if (after && !same(before, after)) {
+      changes.push(`${tee} rating: ${before ? `${before.courseRating}/${before.slopeRating}` : '-'} → ${after.courseRating}/${after.slopeRating}`);
+    }
+  }
+
+  return changes;
+}
+
+/**
+ * Dry-run plan: which imported courses would be created, updated or left alone.
+ * Courses are matched by name, the same way useGolfCourses deduplicates them.
+ */
+export function planCourseImport(existing: ExistingCourse[], imported: ImportedCourse[]): CourseImportPlanEntry[] {
+  const byName = new Map(existing.map(c => [courseKey(c.name), c]));
+
+  return imported.map(course => {
+    const match = byName.get(courseKey(course.name));
+    if (!match) return { action: 'create', course, changes: [] };
+
+    const changes = describeChanges(match, course);
+    return {
+      action: changes.length > 0 ? 'update' : 'unchanged',
+      course: mergeCourse(match, course),
+      existingId: match.id,
+      changes,
+    };
+  });
+}
+
+/**
+ * Imported data layered over an existing course, keeping tees and
+ * yardages the import doesn't mention
+ */
+export function mergeCourse(existing: ImportedCourse, course: ImportedCourse): ImportedCourse {
+  const tees = [...(existing.tees ?? [])];
+  for (const tee of course.tees ?? []) {
+    if (!tees.includes(tee)) tees.push(tee);
+  }
+
+  return {
+    name: existing.name,
+    location: course.location || existing.location,
+    holes: { ...existing.holes, ...course.holes },
+    handicaps: course.handicaps ?? existing.handicaps,
+    tees: tees.length > 0 ? tees : undefined,
+    teeYardages: existing.teeYardages || course.teeYardages
+      ? { ...existing.teeYardages, ...course.teeYardages }
+      : undefined,
+    teeRatings: existing.teeRatings || course.teeRatings
+      ? { ...existing.teeRatings, ...course.teeRatings }
+      : undefined,
+    greens: course.greens ?? existing.greens,
+  };
+}
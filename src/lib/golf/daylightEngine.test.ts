@@ -0,0 +1,45 @@
+import { describe, it, expect } from 'vitest';
+import { finishesInDaylight, lastTeeTimes, roundMinutes, sunTimes } from './daylightEngine';
+
+const MINUTE = 60 * 1000;
+const pars = [4, 4, 3, 5, 4, 4, 3, 4, 5, 4, 4, 3, 5, 4, 4, 3, 4, 5];
+
+describe('Daylight Engine', () => {
+  it('should calculate sunrise and sunset', () => {
+    // London, midsummer: 03:43 and 20:21 UTC
+    const london = sunTimes('2026-06-21', { lat: 51.5074, lon: -0.1278 })!;
+    expect(Math.abs(london.sunrise - Date.UTC(2026, 5, 21, 3, 43))).toBeLessThan(3 * MINUTE);
+    expect(Math.abs(london.sunset - Date.UTC(2026, 5, 21, 20, 21))).toBeLessThan(3 * MINUTE);
+
+    // Denver, early winter: 14:05 and 23:37 UTC
+    const denver = sunTimes('2026-12-01', { lat: 39.7392, lon: -104.9903 })!;
+    expect(Math.abs(denver.sunrise - Date.UTC(2026, 11, 1, 14, 5))).toBeLessThan(3 * MINUTE);
+    expect(Math.abs(denver.sunset - Date.UTC(2026, 11, 1, 23, 37))).toBeLessThan(3 * MINUTE);
+  });
+
+  it('should return null when the sun never sets or rises', () => {
+    expect(sunTimes('2026-06-21', { lat: 78.2, lon: 15.6 })).toBeNull();
+    expect(sunTimes('2026-12-21', { lat: 78.2, lon: 15.6 })).toBeNull();
+  });
+
+  it('should time a round from the pace of play hole times', () => {
+    expect(roundMinutes([4, 3, 5])).toBe(42);
+    expect(roundMinutes(pars)).toBe(252);
+    expect(roundMinutes([])).toBe(0);
+  });
+
+  it('should give last tee times for nine holes and the full course', () => {
+    const sunset = Date.UTC(2026, 5, 21, 20, 0);
+    expect(lastTeeTimes(sunset, pars)).toEqual([
+      { holes: 9, teeTime: sunset - 126 * MINUTE },
+      { holes: 18, teeTime: sunset - 252 * MINUTE },
+    ]);
+    expect(lastTeeTimes(sunset, pars.slice(0, 9))).toEqual([{ holes: 9, teeTime: sunset - 126 * MINUTE }]);
+  });
+
+  it('should check whether a round finishes before dark', () => {
+    const sunset = Date.UTC(2026, 5, 21, 20, 0);
+    expect(finishesInDaylight(sunset - 252 * MINUTE, sunset, pars)).toBe(true);
+    expect(finishesInDaylight(sunset - 200 * MINUTE, sunset, pars)).toBe(false);
+  });
+});
@@ -0,0 +1,76 @@
+// Sunrise, sunset and the last tee time that still finishes in daylight
+
+import type { GeoPoint } from './caddieEngine';
+import { expectedFinishTimes, type PaceConfig } from './paceEngine';
+
+export interface SunTimes {
+  sunrise: number; // ms
+  sunset: number; // ms
+}
+
+export interface LastTeeTime {
+  holes: number;
+  teeTime: number; // ms
+}
+
+const DAY_MS = 24 * 60 * 60 * 1000;
+const J2000 = 2451545.0;
+const UNIX_EPOCH_JD = 2440587.5;
+
+const toRad = (degrees: number) => degrees * (Math.PI / 180);
+const toDeg = (radians: number) => radians * (180 / Math.PI);
+
+const fromJulian = (jd: number) => (jd - UNIX_EPOCH_JD) * DAY_MS;
+
+/**
+ * Sunrise and sunset on a date (YYYY-MM-DD, local to the course), from the
+ * sunrise equation; good to a minute or two. Null during polar day or night.
+ */
+export function sunTimes(date: string, point: GeoPoint): SunTimes | null {
+  const [year, month, day] = date.split('-').map(Number);
+  const n = Math.round(Date.UTC(year, month - 1, day, 12) / DAY_MS + UNIX_EPOCH_JD - J2000);
+
+  // Solar noon at this longitude
+  const meanSolarTime = n - point.lon / 360;
+  const anomaly = (357.5291 + 0.98560028 * meanSolarTime) % 360;
+  const M = toRad(anomaly);
+  const centre = 1.9148 * Math.sin(M) + 0.02 * Math.sin(2 * M) + 0.0003 * Math.sin(3 * M);
+  const lambda = toRad((anomaly + centre + 180 + 102.9372) % 360);
+  const transit = J2000 + meanSolarTime + 0.0053 * Math.sin(M) - 0.0069 * Math.sin(2 * lambda);
+
+  // Hour angle when the sun's upper edge is on the horizon, allowing for refraction
+  const sinDeclination = Math.sin(lambda) * Math.sin(toRad(23.4397));
+  const cosDeclination = Math.cos(Math.asin(sinDeclination));
+  const lat = toRad(point.lat);
+  const cosHourAngle = (Math.sin(toRad(-0.833)) - Math.sin(lat) * sinDeclination) / (Math.cos(lat) * cosDeclination);
+  if (cosHourAngle < -1 || cosHourAngle > 1) return null;
+
+  const hourAngle = toDeg(Math.acos(cosHourAngle)) / 360;
+  return {
+    sunrise: Math.round(fromJulian(transit - hourAngle)),
+    sunset: Math.round(fromJulian(transit + hourAngle)),
+  };
+}
+
+/** Expected minutes to play the given holes at pace-of-play hole times */
+export function roundMinutes(pars: number[], config: PaceConfig = {}): number {
+  const times = expectedFinishTimes(pars, config);
+  return times.length > 0 ? times[times.length - 1] : 0;
+}
+
+/**
+ * Latest tee times that still finish by sunset, for nine holes (the front
+ * nine) and the full course
+ */
+export function lastTeeTimes(sunset: number, pars: number[], config: PaceConfig = {}): LastTeeTime[] {
+  const lengths = pars.length > 9 ? [9, pars.length] : [pars.length];
+  return lengths.map(holes => ({
+    holes,
+    teeTime: sunset - roundMinutes(pars.slice(0, holes), config) * 60 * 1000,
+  }));
+}
+
+/** Whether a round teeing off at `teeTime` is expected to finish by sunset */
+export function finishesInDaylight(teeTime: number, sunset: number, pars: number[], config: PaceConfig = {}): boolean {
+  return teeTime + roundMinutes(pars, config) * 60 * 1000 <= sunset;
+}
@@ -0,0 +1,60 @@
+import { describe, it, expect } from 'vitest';
+import type { NostrEvent } from '@nostrify/nostrify';
+import { delegationConditions, isDelegated, latestDelegations, scoreCardPlayer, scoreCardRound, type ScoringDelegation } from './delegationEngine';
+import { GOLF_KINDS } from './types';
+
+const grant: ScoringDelegation = {
+  delegator: 'player',
+  delegatee: 'terminal',
+  kinds: [GOLF_KINDS.PLAYER_SCORE],
+  since: 1000,
+  until: 2000,
+  label: 'Clubhouse terminal',
+  createdAt: 500_000,
+};
+
+function card(pubkey: string, created_at: number, player?: string): NostrEvent {
+  return {
+    id: `${pubkey}-${created_at}`,
+    pubkey,
+    created_at,
+    kind: GOLF_KINDS.PLAYER_SCORE,
+    tags: [['d', 'round-1'], ...(player ? [['player', player]] : [])],
+    content: '{"scores":{"1":4}}',
+    sig: '',
+  };
+}
+
+describe('Delegation Engine', () => {
+  it('should describe a grant as NIP-26 conditions', () => {
+    expect(delegationConditions(grant)).toBe('kind=36903&created_at>999&created_at<2000');
+  });
+
+  it('should accept terminal cards within the grant window', () => {
+    expect(isDelegated(card('terminal', 1500), 'player', [grant])).toBe(true);
+    expect(isDelegated(card('terminal', 2000), 'player', [grant])).toBe(false);
+    expect(isDelegated(card('other-terminal', 1500), 'player', [grant])).toBe(false);
+  });
+
+  it('should honour a revocation that replaced the grant', () => {
+    const revoked = { ...grant, until: 1200, createdAt: 1_200_000 };
+    expect(latestDelegations([grant, revoked])).toEqual([revoked]);
+    expect(isDelegated(card('terminal', 1500), 'player', [grant, revoked])).toBe(false);
+  });
+
+  it('should credit a delegated card to the player', () => {
+    expect(scoreCardPlayer(card('terminal', 1500, 'player'), [grant])).toBe('player');
+    expect(scoreCardPlayer(card('terminal', 1500, 'player'), [])).toBeNull();
+  });
+
+  it('should credit self-signed cards to their author', () => {
+    expect(scoreCardPlayer(card('player', 1500), [])).toBe('player');
+    expect(scoreCardPlayer(card('player', 1500, 'player'), [])).toBe('player');
+  });
+
+  it('should read the round of a terminal card from its round tag', () => {
+    const terminalCard = { ...card('terminal', 1500, 'player'), tags: [['d', 'round-1:player'], ['round', 'round-1'], ['player', 'player']] };
+    expect(scoreCardRound(terminalCard)).toBe('round-1');
+    expect(scoreCardRound(card('player', 1500))).toBe('round-1');
+  });
+});
@@ -0,0 +1,96 @@
+// Scoring terminals posting score cards on behalf of players.
+//
+// Modelled on NIP-26 conditions (kinds and a created_at window), but the
+// grant is a signed event from the player rather than a token signature, so
+// it works with browser and remote signers that can't sign arbitrary hashes.
+
+import type { NostrEvent, NostrFilter } from '@nostrify/nostrify';
+import { GOLF_KINDS } from './types';
+
+export interface ScoringDelegation {
+  delegator: string; // the player
+  delegatee: string; // the terminal's pubkey
+  kinds: number[];
+  since: number; // seconds, inclusive
+  until: number; // seconds, exclusive; a grant is revoked by moving this into the past
+  label: string;
+  createdAt: number; // ms
+  rounds?: string[]; // the rounds the grant covers; any round when unset
+}
+
+export const DEFAULT_DELEGATION_HOURS = 12;
+
+/** The grant as NIP-26 conditions, e.g. `kind=36903&created_at>1700000000&created_at<1700043200` */
+export function delegationConditions(delegation: ScoringDelegation): string {
+  return [
+    ...delegation.kinds.map(kind => `kind=${kind}`),
+    `created_at>${delegation.since - 1}`,
+    `created_at<${delegation.until}`,
+  ].join('&');
+}
+
+/**
+ * The latest grant from each player to each terminal; an older grant doesn't
+ * outlive a revocation that replaced it
+ */
+export function latestDelegations(delegations: ScoringDelegation[]): ScoringDelegation[] {
+  const latest = new Map<string, ScoringDelegation>();
+  for (const delegation of delegations) {
+    const key = `${delegation.delegator}:${delegation.delegatee}`;
+    const existing = latest.get(key);
+    if (!existing || delegation.createdAt > existing.createdAt) latest.set(key, delegation);
+  }
+  return [...latest.values()];
+}
+
+export function isDelegationActive(delegation: Pick<ScoringDelegation, 'since' | 'until'>, nowSeconds: number): boolean {
+  return nowSeconds >= delegation.since && nowSeconds < delegation.until;
+}
+
+/** Whether an event signed by the terminal falls within a player's grant */
+export function isDelegated(event: NostrEvent, delegator: string, delegations: ScoringDelegation[]): boolean {
+  return latestDelegations(delegations).some(d =>
+    d.delegator === delegator &&
+    d.delegatee === event.pubkey &&
+    d.kinds.includes(event.kind) &&
+    isDelegationActive(d, event.created_at) &&
+    (!d.rounds || d.rounds.includes(scoreCardRound(event) ?? ''))
+  );
+}
+
+/**
+ * Who a score card counts for: the player named in its `player` tag when
+ * they signed it themselves or a terminal they delegated to did, otherwise
+ * null. Cards without a `player` tag belong to their author.
+ */
+export function scoreCardPlayer(event: NostrEvent, delegations: ScoringDelegation[]): string | null {
+  if (event.kind !== GOLF_KINDS.PLAYER_SCORE) return null;
+  const player = event.tags.find(([name]) => name === 'player')?.[1];
+  if (!player || player === event.pubkey) return event.pubkey;
+  return isDelegated(event, player, delegations) ? player : null;
+}
+
+/**
+ * The round a score card is for. A player's own card is addressed by the
+ * round id; a terminal keeps one card per player, so its `d` tag is
+ * `<roundId>:<player>` and the round id is in a `round` tag.
+ */
+export function scoreCardRound(event: NostrEvent): string | undefined {
+  return event.tags.find(([name]) => name === 'round')?.[1] ?? event.tags.find(([name]) => name === 'd')?.[1];
+}
+
+/** Filters for every card in some rounds, whether the player or a terminal signed it */
+export function scoreCardFilters(roundIds: string[], filter: NostrFilter = {}): NostrFilter[] {
+  return [
+    { ...filter, kinds: [GOLF_KINDS.PLAYER_SCORE], '#d': roundIds },
+    { ...filter, kinds: [GOLF_KINDS.PLAYER_SCORE], '#round': roundIds },
+  ];
+}
+
+/** Filters for some players' cards, whether they signed them or a terminal did */
+export function playerCardFilters(pubkeys: string[], filter: NostrFilter = {}): NostrFilter[] {
+  return [
+    { ...filter, kinds: [GOLF_KINDS.PLAYER_SCORE], authors: pubkeys },
+    { ...filter, kinds: [GOLF_KINDS.PLAYER_SCORE], '#player': pubkeys },
+  ];
+}
@@ -0,0 +1,84 @@
+import { describe, it, expect } from 'vitest';
+import type { NostrEvent } from '@nostrify/nostrify';
+import { nip19 } from 'nostr-tools';
+import { createScorerDeviceEvent, parseScorerDeviceEvent } from './nostrEvents';
+import { scoreCardPlayer } from './delegationEngine';
+import { deviceDelegations, parseProvisioning, provisioningLink, type DeviceDraw, type ScorerDevice } from './deviceEngine';
+import { GOLF_KINDS } from './types';
+
+const organizer = 'o'.repeat(64);
+const tablet = 't'.repeat(64);
+
+const device: ScorerDevice = {
+  device: tablet,
+  issuer: organizer,
+  tournamentId: 'club-champs',
+  group: 2,
+  since: 1000,
+  until: 2000,
+  label: 'Tablet 2',
+  createdAt: 500_000,
+};
+
+const draw: DeviceDraw = {
+  tournamentId: 'club-champs',
+  organizer,
+  groups: [
+    { group: 1, teeTime: 0, startingHole: 1, players: [{ id: 'alice', name: 'Alice', handicap: 4 }] },
+    { group: 2, teeTime: 0, startingHole: 1, players: [{ id: 'bob', name: 'Bob', handicap: 12 }, { id: 'cara', name: 'Cara', handicap: 20 }] },
+  ],
+};
+
+function card(created_at: number, player: string, round = 'club-champs'): NostrEvent {
+  return {
+    id: `${player}-${created_at}`,
+    pubkey: tablet,
+    created_at,
+    kind: GOLF_KINDS.PLAYER_SCORE,
+    tags: [['d', `${round}:${player}`], ['round', round], ['player', player]],
+    content: '{"scores":{"1":4}}',
+    sig: '',
+  };
+}
+
+describe('Device Engine', () => {
+  it('should carry the key and binding in a provisioning link', () => {
+    const nsec = nip19.nsecEncode(new Uint8Array(32).fill(7));
+    const link = provisioningLink('https://pinseekr.golf', { nsec, tournamentId: 'club-champs', group: 2 });
+
+    expect(link.startsWith('https://pinseekr.golf/terminal#')).toBe(true);
+    expect(parseProvisioning(new URL(link).hash)).toEqual({ nsec, tournamentId: 'club-champs', group: 2 });
+    expect(parseProvisioning('#device=nope&tournament=club-champs&group=2')).toBeNull();
+  });
+
+  it('should credit the device only for players in its group', () => {
+    const delegations = deviceDelegations([device], [draw]);
+
+    expect(scoreCardPlayer(card(1500, 'bob'), delegations)).toBe('bob');
+    expect(scoreCardPlayer(card(1500, 'cara'), delegations)).toBe('cara');
+    expect(scoreCardPlayer(card(1500, 'alice'), delegations)).toBeNull();
+    expect(scoreCardPlayer(card(2500, 'bob'), delegations)).toBeNull();
+  });
+
+  it('should only credit the device for the tournament round', () => {
+    const delegations = deviceDelegations([device], [draw]);
+    expect(scoreCardPlayer(card(1500, 'bob', 'bobs-casual-round'), delegations)).toBeNull();
+  });
+
+  it('should ignore credentials not issued by the draw organizer', () => {
+    expect(deviceDelegations([{ ...device, issuer: 'm'.repeat(64) }], [draw])).toEqual([]);
+  });
+
+  it('should stop crediting a revoked device', () => {
+    const revoked = { ...device, until: 1200, createdAt: 1_200_000 };
+    const delegations = deviceDelegations([device, revoked], [draw]);
+
+    expect(scoreCardPlayer(card(1100, 'bob'), delegations)).toBe('bob');
+    expect(scoreCardPlayer(card(1500, 'bob'), delegations)).toBeNull();
+  });
+
+  it('should round-trip a device credential through a Nostr event', () => {
+    const event = { ...createScorerDeviceEvent(device), created_at: device.createdAt / 1000 };
+    expect(parseScorerDeviceEvent(event)).toEqual(device);
+  });
+});
@@ -0,0 +1,103 @@
+// Scorer devices: borrowed tablets provisioned by a tournament organizer to
+// score one group, without a member logging in on them.
+//
+// The organizer generates a key for the tablet and publishes a credential
+// binding it to a tournament and a draw group. The tablet gets the key by
+// scanning a QR code. Its cards count for the group's players the same way
+// a player's own delegation to a terminal would.
+
+import { nip19 } from 'nostr-tools';
+import type { DrawGroup } from './drawEngine';
+import type { ScoringDelegation } from './delegationEngine';
+import { GOLF_KINDS } from './types';
+
+export interface ScorerDevice {
+  device: string; // the tablet's pubkey
+  issuer: string; // the organizer who provisioned it
+  tournamentId: string;
+  group: number;
+  since: number; // seconds, inclusive
+  until: number; // seconds, exclusive; revoked by moving this into the past
+  label: string;
+  createdAt: number; // ms
+}
+
+/** What the QR code carries to the tablet */
+export interface DeviceProvisioning {
+  nsec: string;
+  tournamentId: string;
+  group: number;
+}
+
+/** The published draw a credential is checked against */
+export interface DeviceDraw {
+  tournamentId: string;
+  organizer: string;
+  groups: DrawGroup[];
+}
+
+export const DEFAULT_DEVICE_HOURS = 10;
+
+/**
+ * Link that provisions a tablet when opened. The key travels in the
+ * fragment, which browsers never send to a server.
+ */
+export function provisioningLink(origin: string, provisioning: DeviceProvisioning): string {
+  const params = new URLSearchParams({
+    device: provisioning.nsec,
+    tournament: provisioning.tournamentId,
+    group: String(provisioning.group),
+  });
+  return `${origin}/terminal#${params}`;
+}
+
+/** Read a provisioning link's fragment; null unless it carries a valid key */
+export function parseProvisioning(hash: string): DeviceProvisioning | null {
+  const params = new URLSearchParams(hash.replace(/^#/, ''));
+  const nsec = params.get('device');
+  const tournamentId = params.get('tournament');
+  const group = parseInt(params.get('group') ?? '');
+  if (!nsec || !tournamentId || isNaN(group)) return null;
+
+  try {
+    if (nip19.decode(nsec).type !== 'nsec') return null;
+  } catch {
+    return null;
+  }
+  return { nsec, tournamentId, group };
+}
+
+/** The latest credential for each device; a revocation replaces the grant */
+export function latestDevices(devices: ScorerDevice[]): ScorerDevice[] {
+  const latest = new Map<string, ScorerDevice>();
+  for (const device of devices) {
+    const key = `${device.issuer}:${device.device}`;
+    const existing = latest.get(key);
+    if (!existing || device.createdAt > existing.createdAt) latest.set(key, device);
+  }
+  return [...latest.values()];
+}
+
+/**
+ * Device credentials as delegations from each player in the device's group,
+ * for the tournament's own round only. Only credentials from the organizer
+ * who published the draw count.
+ */
+export function deviceDelegations(devices: ScorerDevice[], draws: DeviceDraw[]): ScoringDelegation[] {
+  return latestDevices(devices).flatMap(device => {
+    const draw = draws.find(d => d.tournamentId === device.tournamentId && d.organizer === device.issuer);
+    const group = draw?.groups.find(g => g.group === device.group);
+    if (!group) return [];
+
+    return group.players.map(player => ({
+      delegator: player.id,
+      delegatee: device.device,
+      kinds: [GOLF_KINDS.PLAYER_SCORE],
+      since: device.since,
+      until: device.until,
+      label: device.label,
+      createdAt: device.createdAt,
+      rounds: [device.tournamentId],
+    }));
+  });
+}
@@ -0,0 +1,69 @@
+import { describe, it, expect } from 'vitest';
+import { applyRuling, latestRulings, parseCorrections, resolveDisputes, type Dispute, type Ruling } from './disputeEngine';
+
+describe('Dispute Engine', () => {
+  const dispute: Dispute = {
+    disputeId: 'd1',
+    raisedBy: 'bob',
+    roundId: 'r1',
+    subject: 'score',
+    players: ['alice'],
+    committee: ['chair'],
+    reason: 'Hole 7 was a 6, not a 5',
+    evidence: [],
+    createdAt: 1,
+  };
+
+  const ruling = (committee: string, decision: Ruling['decision'], createdAt: number, corrections: Ruling['corrections'] = []): Ruling => ({
+    rulingId: 'd1',
+    committee,
+    roundId: 'r1',
+    disputeId: 'd1',
+    players: ['alice'],
+    decision,
+    corrections,
+    explanation: '',
+    createdAt,
+  });
+
+  it('should keep disputes open until the trusted committee rules', () => {
+    expect(resolveDisputes([dispute], [], ['chair'])[0].status).toBe('open');
+    expect(resolveDisputes([dispute], [ruling('mallory', 'void', 2)], ['chair'])[0].status).toBe('open');
+    expect(resolveDisputes([dispute], [ruling('chair', 'dismissed', 2)], ['chair'])[0].status).toBe('dismissed');
+  });
+
+  it('should ignore a committee chosen by whoever raised the dispute', () => {
+    const packed = { ...dispute, committee: ['mallory'] };
+    expect(resolveDisputes([packed], [ruling('mallory', 'void', 2)], ['chair'])[0].status).toBe('open');
+  });
+
+  it('should take the latest ruling from a trusted committee member', () => {
+    const rulings = [ruling('chair', 'void', 2), ruling('chair', 'dismissed', 3), ruling('mallory', 'corrected', 4)];
+    expect(latestRulings(rulings, ['chair']).get('r1:alice')?.decision).toBe('dismissed');
+    expect(latestRulings(rulings, []).size).toBe(0);
+  });
+
+  it('should replace corrected holes for the ruled player only', () => {
+    const corrected = ruling('chair', 'corrected', 2, [
+      { playerPubkey: 'alice', hole: 7, strokes: 6 },
+      { playerPubkey: 'bob', hole: 1, strokes: 3 },
+    ]);
+    expect(applyRuling('alice', { 1: 4, 7: 5 }, corrected)).toEqual({ 1: 4, 7: 6 });
+  });
+
+  it('should drop every score from a voided card and keep dismissed cards', () => {
+    const scores = { 1: 4, 2: 5 };
+    expect(applyRuling('alice', scores, ruling('chair', 'void', 2))).toEqual({});
+    expect(applyRuling('alice', scores, ruling('chair', 'dismissed', 2))).toBe(scores);
+    expect(applyRuling('alice', scores, undefined)).toBe(scores);
+  });
+
+  it('should parse typed corrections and reject malformed ones', () => {
+    expect(parseCorrections('alice', '7:5, 12 : 4')).toEqual([
+      { playerPubkey: 'alice', hole: 7, strokes: 5 },
+      { playerPubkey: 'alice', hole: 12, strokes: 4 },
+    ]);
+    expect(parseCorrections('alice', '7-5')).toBeNull();
+    expect(parseCorrections('alice', '')).toEqual([]);
+  });
+});
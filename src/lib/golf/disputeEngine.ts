@@ -0,0 +1,108 @@
+// Disputes: contested scores or match results, decided by a committee ruling
+
+export type DisputeSubject = 'score' | 'match';
+
+export interface Dispute {
+  disputeId: string;
+  raisedBy: string; // pubkey
+  roundId: string;
+  subject: DisputeSubject;
+  players: string[]; // players whose cards are contested
+  committee: string[]; // committee pubkeys notified of the dispute
+  reason: string;
+  evidence: string[]; // URLs (photos, GPS exports, ...)
+  createdAt: number; // ms
+}
+
+export type RulingDecision = 'dismissed' | 'corrected' | 'void';
+
+export interface ScoreCorrection {
+  playerPubkey: string;
+  hole: number;
+  strokes: number;
+}
+
+export interface Ruling {
+  rulingId: string;
+  committee: string; // pubkey of the ruling committee member
+  roundId: string;
+  disputeId?: string; // absent when ruling on a round held for review
+  players: string[]; // cards the ruling applies to
+  decision: RulingDecision; // dismissed: cards stand; corrected: see corrections; void: cards don't count
+  corrections: ScoreCorrection[];
+  explanation: string;
+  createdAt: number; // ms
+}
+
+export type DisputeStatus = 'open' | RulingDecision;
+
+export interface DisputeWithStatus extends Dispute {
+  status: DisputeStatus;
+  ruling: Ruling | null;
+}
+
+/**
+ * Latest ruling from a trusted committee member for each round and player
+ */
+export function latestRulings(rulings: Ruling[], committee: string[]): Map<string, Ruling> {
+  const latest = new Map<string, Ruling>();
+  for (const ruling of rulings) {
+    if (!committee.includes(ruling.committee)) continue;
+    for (const player of ruling.players) {
+      const key = `${ruling.roundId}:${player}`;
+      const existing = latest.get(key);
+      if (!existing || ruling.createdAt > existing.createdAt) latest.set(key, ruling);
+    }
+  }
+  return latest;
+}
+
+/**
+ * Status of each dispute: open until a member of the trusted committee rules
+ * on it. The committee is the club's, never the one named by whoever raised
+ * the dispute.
+ */
+export function resolveDisputes(disputes: Dispute[], rulings: Ruling[], committee: string[]): DisputeWithStatus[] {
+  return disputes
+    .map(dispute => {
+      const ruling = rulings
+        .filter(r => r.disputeId === dispute.disputeId && committee.includes(r.committee))
+        .sort((a, b) => b.createdAt - a.createdAt)[0] ?? null;
+      return { ...dispute, status: ruling ? ruling.decision : 'open' as const, ruling };
+    })
+    .sort((a, b) => b.createdAt - a.createdAt);
+}
+
+/**
+ * A player's hole scores after a ruling: corrected holes replaced, or no
+ * scores at all when the card was voided
+ */
+export function applyRuling(
+  playerPubkey: string,
+  scores: { [hole: number]: number },
+  ruling: Ruling | undefined
+): { [hole: number]: number } {
+  if (!ruling) return scores;
+  if (ruling.decision === 'void') return {};
+  if (ruling.decision !== 'corrected') return scores;
+
+  const corrected = { ...scores };
+  for (const c of ruling.corrections) {
+    if (c.playerPubkey === playerPubkey) corrected[c.hole] = c.strokes;
+  }
+  return corrected;
+}
+
+/**
+ * Parse corrections typed as "hole:strokes" pairs, e.g. "7:5, 12:4"
+ */
+export function parseCorrections(playerPubkey: string, text: string): ScoreCorrection[] | null {
+  const pairs = text.split(',').map(p => p.trim()).filter(Boolean);
+  const corrections: ScoreCorrection[] = [];
+  for (const pair of pairs) {
+    const match = pair.match(/^(\d{1,2})\s*:\s*(\d{1,2})$/);
+    if (!match) return null;
+    corrections.push({ playerPubkey, hole: parseInt(match[1]), strokes: parseInt(match[2]) });
+  }
+  return corrections;
+}
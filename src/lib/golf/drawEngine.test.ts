@@ -0,0 +1,158 @@
+import { describe, it, expect } from 'vitest';
+import {
+  generateDraw,
+  calculateGroupSizes,
+  groupHandicapAverages,
+  shiftDraw,
+  drawConflicts,
+  type DrawPlayer,
+  type DrawConfig
+} from './drawEngine';
+import { createDrawEvent, parseDrawEvent } from './nostrEvents';
+
+describe('Draw Engine', () => {
+  const players: DrawPlayer[] = Array.from({ length: 10 }, (_, i) => ({
+    id: `player${i + 1}`,
+    name: `Player ${i + 1}`,
+    handicap: i * 3, // 0, 3, 6 ... 27
+    seed: i + 1
+  }));
+
+  const firstTeeTime = new Date('2025-06-01T08:00:00Z').getTime();
+  const baseConfig: DrawConfig = { method: 'random', firstTeeTime, randomSeed: 'club-champs' };
+
+  describe('calculateGroupSizes', () => {
+    it('should use 3-balls instead of leaving a short group', () => {
+      expect(calculateGroupSizes(8)).toEqual([4, 4]);
+      expect(calculateGroupSizes(9)).toEqual([3, 3, 3]);
+      expect(calculateGroupSizes(10)).toEqual([4, 3, 3]);
+      expect(calculateGroupSizes(11)).toEqual([4, 4, 3]);
+    });
+
+    it('should join leftovers to a group rather than send out a 2-ball', () => {
+      expect(calculateGroupSizes(7, 3)).toEqual([4, 3]);
+      expect(calculateGroupSizes(2)).toEqual([2]);
+    });
+
+    it('should never make a group bigger than a 4-ball', () => {
+      expect(calculateGroupSizes(5)).toEqual([3, 2]);
+      expect(calculateGroupSizes(5, 3)).toEqual([3, 2]);
+    });
+
+    it('should give an odd match play field one 3-ball', () => {
+      expect(calculateGroupSizes(7, 2)).toEqual([3, 2, 2]);
+    });
+
+    it('should reject unsupported group sizes', () => {
+      expect(() => calculateGroupSizes(8, 5)).toThrow('Group size must be between 2 and 4');
+    });
+  });
+
+  describe('generateDraw', () => {
+    it('should place every player exactly once', () => {
+      const draw = generateDraw(players, baseConfig);
+      const placed = draw.groups.flatMap(g => g.players.map(p => p.id)).sort();
+
+      expect(draw.playerCount).toBe(10);
+      expect(placed).toEqual(players.map(p => p.id).sort());
+      expect(draw.groups.map(g => g.players.length)).toEqual([4, 3, 3]);
+    });
+
+    it('should reproduce random draws from the same seed', () => {
+      const first = generateDraw(players, baseConfig);
+      const second = generateDraw(players, baseConfig);
+      const other = generateDraw(players, { ...baseConfig, randomSeed: 'different' });
+
+      expect(second).toEqual(first);
+      expect(other).not.toEqual(first);
+    });
+
+    it('should send the top seeds out last', () => {
+      const draw = generateDraw(players, { ...baseConfig, method: 'seeded' });
+      const lastGroup = draw.groups[draw.groups.length - 1];
+
+      expect(lastGroup.players.map(p => p.seed)).toEqual([1, 2, 3]);
+      expect(draw.groups[0].players.map(p => p.seed)).toEqual([7, 8, 9, 10]);
+    });
+
+    it('should balance handicaps across groups', () => {
+      const balanced = generateDraw(players, { ...baseConfig, method: 'handicap-balanced' });
+      const seeded = generateDraw(players, { ...baseConfig, method: 'seeded' });
+
+      const spread = (averages: number[]) => Math.max(...averages) - Math.min(...averages);
+
+      expect(spread(groupHandicapAverages(balanced))).toBeLessThan(spread(groupHandicapAverages(seeded)));
+      expect(balanced.groups.map(g => g.players.length)).toEqual([4, 3, 3]);
+    });
+
+    it('should assign tee times at the configured interval', () => {
+      const draw = generateDraw(players, { ...baseConfig, intervalMinutes: 9 });
+
+      expect(draw.groups.map(g => g.teeTime)).toEqual([
+        firstTeeTime,
+        firstTeeTime + 9 * 60 * 1000,
+        firstTeeTime + 18 * 60 * 1000
+      ]);
+      expect(draw.groups.every(g => g.startingHole === 1)).toBe(true);
+    });
+
+    it('should alternate the 1st and 10th tees for a two-tee start', () => {
+      const draw = generateDraw(players, { ...baseConfig, startingTees: 2 });
+
+      expect(draw.groups.map(g => g.startingHole)).toEqual([1, 10, 1]);
+      expect(draw.groups[0].teeTime).toBe(draw.groups[1].teeTime);
+      expect(draw.groups[2].teeTime).toBe(firstTeeTime + 10 * 60 * 1000);
+    });
+
+    it('should reject duplicate players', () => {
+      expect(() => generateDraw([players[0], players[0]], baseConfig)).toThrow('Duplicate players');
+    });
+  });
+
+  describe('shiftDraw', () => {
+    it('should delay groups from the given group onwards', () => {
+      const draw = generateDraw(players, baseConfig);
+      const shifted = shiftDraw(draw, 30, 2);
+
+      expect(shifted.groups[0].teeTime).toBe(draw.groups[0].teeTime);
+      expect(shifted.groups[1].teeTime).toBe(draw.groups[1].teeTime + 30 * 60 * 1000);
+      expect(shifted.groups[2].teeTime).toBe(draw.groups[2].teeTime + 30 * 60 * 1000);
+      expect(drawConflicts(shifted)).toEqual([]);
+    });
+
+    it('should flag crossovers, late starts and groups out of order', () => {
+      const draw = generateDraw(players, { ...baseConfig, startingTees: 2 });
+      // The 10th tee group is still waiting when the first group reaches the turn
+      expect(drawConflicts(shiftDraw(draw, 130, 2)).map(c => [c.group, c.reason])).toEqual([[2, 'crossover']]);
+      // Group 3 moved ahead of group 1 on the 1st tee
+      expect(drawConflicts(shiftDraw(draw, -20, 3)).map(c => [c.group, c.reason])).toEqual([[3, 'out-of-order']]);
+      expect(drawConflicts(draw, { latestTeeTime: firstTeeTime }).map(c => c.group)).toEqual([3]);
+    });
+  });
+
+  describe('Draw events', () => {
+    it('should round-trip a draw through a Nostr event', () => {
+      const draw = generateDraw(players, baseConfig);
+      const event = createDrawEvent('club-champs-2025', draw, 'organizer', 'Club Championship');
+
+      expect(event.tags).toContainEqual(['d', 'club-champs-2025-draw']);
+      expect(event.tags.filter(t => t[0] === 'p')).toHaveLength(10);
+      expect(event.tags.filter(t => t[0] === 'group')).toHaveLength(3);
+
+      const parsed = parseDrawEvent(event);
+      expect(parsed?.tournamentId).toBe('club-champs-2025');
+      expect(parsed?.groups).toEqual(draw.groups);
+    });
+
+    it('should fall back to group tags when content is not JSON', () => {
+      const draw = generateDraw(players, baseConfig);
+      const event = { ...createDrawEvent('t1', draw, 'organizer'), content: '' };
+
+      const parsed = parseDrawEvent(event);
+      expect(parsed?.groups.map(g => g.players.map(p => p.id))).toEqual(
+        draw.groups.map(g => g.players.map(p => p.id))
+      );
+      expect(parsed?.groups[1].teeTime).toBe(draw.groups[1].teeTime);
+    });
+  });
+});
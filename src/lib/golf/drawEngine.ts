@@ -0,0 +1,261 @@
+// Competition draw and tee-time pairing generator
+
+export type DrawMethod = 'seeded' | 'random' | 'handicap-balanced';
+
+export interface DrawPlayer {
+  id: string;
+  name: string;
+  handicap: number;
+  seed?: number; // lower is better (1 = top seed)
+}
+
+export interface DrawConfig {
+  method: DrawMethod;
+  firstTeeTime: number; // ms timestamp of the first group off
+  intervalMinutes?: number; // gap between tee times (default 10)
+  groupSize?: number; // preferred group size, 2-4 (default 4)
+  startingTees?: 1 | 2; // 2 = two-tee start from the 1st and 10th
+  randomSeed?: string; // makes random draws reproducible
+}
+
+export interface DrawGroup {
+  group: number;
+  teeTime: number;
+  startingHole: 1 | 10;
+  players: DrawPlayer[];
+}
+
+export interface Draw {
+  method: DrawMethod;
+  groups: DrawGroup[];
+  playerCount: number;
+}
+
+/**
+ * Split players into groups as evenly as possible, larger groups first.
+ * With a preferred size of 4, leftovers become 3-balls rather than a lone 1- or 2-ball
+ * (e.g. 10 players -> 4, 3, 3). Where that would still leave a 2-ball in a 3- or 4-ball
+ * draw, the leftovers join a group instead (7 players in 3-balls -> 4, 3), unless that
+ * makes a group bigger than a 4-ball (5 players -> 3, 2).
+ * With 2-balls an odd field gets one 3-ball.
+ */
+export function calculateGroupSizes(playerCount: number, groupSize: number = 4): number[] {
+  if (playerCount <= 0) return [];
+  if (groupSize < 2 || groupSize > 4) {
+    throw new Error('Group size must be between 2 and 4');
+  }
+
+  let groupCount = groupSize === 2
+    ? Math.max(1, Math.floor(playerCount / 2))
+    : Math.ceil(playerCount / groupSize);
+  if (
+    groupSize > 2 && groupCount > 1 &&
+    Math.floor(playerCount / groupCount) < 3 &&
+    Math.ceil(playerCount / (groupCount - 1)) <= 4
+  ) groupCount--;
+
+  const base = Math.floor(playerCount / groupCount);
+  const remainder = playerCount % groupCount;
+
+  return Array.from({ length: groupCount }, (_, i) => base + (i < remainder ? 1 : 0));
+}
+
+/**
+ * Deterministic PRNG (mulberry32) seeded from a string, so a published random draw can be reproduced
+ */
+export function createSeededRandom(seed: string): () => number {
+  let h = 1779033703 ^ seed.length;
+  for (let i = 0; i < seed.length; i++) {
+    h = Math.imul(h ^ seed.charCodeAt(i), 3432918353);
+    h = (h << 13) | (h >>> 19);
+  }
+
+  let state = h >>> 0;
+  return () => {
+    state = (state + 0x6d2b79f5) | 0;
+    let t = state;
+    t = Math.imul(t ^ (t >>> 15), t | 1);
+    t ^= t + Math.imul(t ^ (t >>> 7), t | 61);
+    return ((t ^ (t >>> 14)) >>> 0) / 4294967296;
+  };
+}
+
+function shuffle<T>(items: T[], random: () => number): T[] {
+  const result = [...items];
+  for (let i = result.length - 1; i > 0; i--) {
+    const j = Math.floor(random() * (i + 1));
+    [result[i], result[j]] = [result[j], result[i]];
+  }
+  return result;
+}
+
+function chunk(players: DrawPlayer[], sizes: number[]): DrawPlayer[][] {
+  const groups: DrawPlayer[][] = [];
+  let offset = 0;
+  for (const size of sizes) {
+    groups.push(players.slice(offset, offset + size));
+    offset += size;
+  }
+  return groups;
+}
+
+/**
+ * Seeded draw: players are grouped by seed and the top seeds go out last,
+ * so the leading groups are on the course together at the finish.
+ * Unseeded players follow the seeds, ordered by handicap.
+ */
+function seededGroups(players: DrawPlayer[], sizes: number[]): DrawPlayer[][] {
+  const ordered = [...players].sort((a, b) => {
+    const seedA = a.seed ?? Infinity;
+    const seedB = b.seed ?? Infinity;
+    if (seedA !== seedB) return seedA - seedB;
+    return a.handicap - b.handicap;
+  });
+
+  // Fill from the last tee time backwards
+  return chunk(ordered, [...sizes].reverse()).reverse();
+}
+
+/**
+ * Handicap-balanced draw: snake-draft players sorted by handicap across the groups
+ * so each group has a similar spread of abilities.
+ */
+function balancedGroups(players: DrawPlayer[], sizes: number[]): DrawPlayer[][] {
+  const ordered = [...players].sort((a, b) => a.handicap - b.handicap);
+  const groups: DrawPlayer[][] = sizes.map(() => []);
+
+  let index = 0;
+  let direction = 1;
+  for (const player of ordered) {
+    // Skip groups that are already full, reversing at either end
+    while (groups[index].length >= sizes[index]) {
+      index += direction;
+      if (index < 0 || index >= groups.length) {
+        direction = -direction;
+        index += direction;
+      }
+    }
+
+    groups[index].push(player);
+
+    index += direction;
+    if (index < 0 || index >= groups.length) {
+      direction = -direction;
+      index += direction;
+    }
+  }
+
+  return groups;
+}
+
+/**
+ * Generate a competition draw with tee times
+ */
+export function generateDraw(players: DrawPlayer[], config: DrawConfig): Draw {
+  if (players.length < 2) {
+    throw new Error('A draw requires at least 2 players');
+  }
+
+  const ids = new Set(players.map(p => p.id));
+  if (ids.size !== players.length) {
+    throw new Error('Duplicate players in draw');
+  }
+
+  const sizes = calculateGroupSizes(players.length, config.groupSize ?? 4);
+
+  let grouped: DrawPlayer[][];
+  switch (config.method) {
+    case 'seeded':
+      grouped = seededGroups(players, sizes);
+      break;
+    case 'random': {
+      const random = createSeededRandom(config.randomSeed ?? String(config.firstTeeTime));
+      grouped = chunk(shuffle(players, random), sizes);
+      break;
+    }
+    case 'handicap-balanced':
+      grouped = balancedGroups(players, sizes);
+      break;
+    default:
+      throw new Error(`Unsupported draw method: ${config.method}`);
+  }
+
+  const intervalMs = (config.intervalMinutes ?? 10) * 60 * 1000;
+  const tees = config.startingTees ?? 1;
+
+  const groups: DrawGroup[] = grouped.map((groupPlayers, i) => ({
+    group: i + 1,
+    // With a two-tee start, the 1st and 10th share each time slot
+    teeTime: config.firstTeeTime + Math.floor(i / tees) * intervalMs,
+    startingHole: tees === 2 && i % 2 === 1 ? 10 : 1,
+    players: groupPlayers,
+  }));
+
+  return {
+    method: config.method,
+    groups,
+    playerCount: players.length,
+  };
+}
+
+/**
+ * Average handicap per group - useful for checking how balanced a draw is
+ */
+export function groupHandicapAverages(draw: Draw): number[] {
+  return draw.groups.map(g =>
+    g.players.length > 0
+      ? Math.round((g.players.reduce((sum, p) => sum + p.handicap, 0) / g.players.length) * 10) / 10
+      : 0
+  );
+}
+
+/**
+ * Push tee times back (or forward, with negative minutes), e.g. for a frost
+ * delay. Only groups from `fromGroup` onwards move, so groups already out
+ * can keep their times.
+ */
+export function shiftDraw(draw: Draw, minutes: number, fromGroup: number = 1): Draw {
+  const shiftMs = minutes * 60 * 1000;
+  return {
+    ...draw,
+    groups: draw.groups.map(g => (g.group >= fromGroup ? { ...g, teeTime: g.teeTime + shiftMs } : g)),
+  };
+}
+
+export interface DrawConflict {
+  group: number;
+  teeTime: number;
+  reason: 'crossover' | 'too-late' | 'out-of-order';
+}
+
+/**
+ * Groups that no longer fit after the tee sheet moves:
+ * - crossover: with a two-tee start, a 10th tee group still waiting to go off
+ *   when the first group from the 1st tee reaches the turn
+ * - too-late: off after the latest tee time (e.g. to finish before dark)
+ * - out-of-order: off before the group ahead of it on the same tee
+ */
+export function drawConflicts(
+  draw: Draw,
+  options: { nineHoleMinutes?: number; latestTeeTime?: number } = {}
+): DrawConflict[] {
+  const conflicts: DrawConflict[] = [];
+  const turnMs = (options.nineHoleMinutes ?? 120) * 60 * 1000;
+
+  const firstOut = draw.groups.filter(g => g.startingHole === 1).map(g => g.teeTime);
+  const firstAtTurn = firstOut.length > 0 ? Math.min(...firstOut) + turnMs : Infinity;
+
+  const lastOnTee = new Map<number, number>();
+  for (const g of draw.groups) {
+    if (g.startingHole === 10 && g.teeTime >= firstAtTurn) {
+      conflicts.push({ group: g.group, teeTime: g.teeTime, reason: 'crossover' });
+    } else if (options.latestTeeTime !== undefined && g.teeTime > options.latestTeeTime) {
+      conflicts.push({ group: g.group, teeTime: g.teeTime, reason: 'too-late' });
+    } else if (g.teeTime < (lastOnTee.get(g.startingHole) ?? -Infinity)) {
+      conflicts.push({ group: g.group, teeTime: g.teeTime, reason: 'out-of-order' });
+    }
+    lastOnTee.set(g.startingHole, Math.max(g.teeTime, lastOnTee.get(g.startingHole) ?? -Infinity));
+  }
+
+  return conflicts;
+}
@@ -0,0 +1,55 @@
+import { describe, it, expect } from 'vitest';
+import { elevationChangeYards, hgtTileName, hgtTileUrl, parseHgt, sampleElevation } from './elevation';
+
+describe('Elevation', () => {
+  // 3 arc-second tile rising 1 m per column eastwards, plus 10 m per row southwards
+  const makeTile = (name: string, voidAt?: [number, number]) => {
+    const size = 1201;
+    const view = new DataView(new ArrayBuffer(size * size * 2));
+    for (let row = 0; row < size; row++) {
+      for (let col = 0; col < size; col++) {
+        const value = voidAt && voidAt[0] === row && voidAt[1] === col ? -32768 : col + row * 10;
+        view.setInt16((row * size + col) * 2, value, false);
+      }
+    }
+    return parseHgt(name, view.buffer);
+  };
+
+  it('should name tiles by their south-west corner', () => {
+    expect(hgtTileName({ lat: 36.57, lon: -121.95 })).toBe('N36W122');
+    expect(hgtTileName({ lat: -33.9, lon: 151.2 })).toBe('S34E151');
+    expect(hgtTileUrl('N36W122')).toMatch(/\/skadi\/N36\/N36W122\.hgt\.gz$/);
+  });
+
+  it('should reject files of the wrong size', () => {
+    expect(() => parseHgt('N36W122', new ArrayBuffer(100))).toThrow('Unexpected .hgt size');
+  });
+
+  it('should interpolate between samples', () => {
+    const tile = makeTile('N36W122');
+    expect(tile.lat).toBe(36);
+    expect(tile.lon).toBe(-122);
+
+    // North-west corner, then half a sample east
+    expect(sampleElevation(tile, { lat: 37, lon: -122 })).toBe(0);
+    expect(sampleElevation(tile, { lat: 37, lon: -122 + 0.5 / 1200 })).toBeCloseTo(0.5, 5);
+    // South edge
+    expect(sampleElevation(tile, { lat: 36, lon: -122 })).toBe(12000);
+    expect(sampleElevation(tile, { lat: 38, lon: -122 })).toBeNull();
+  });
+
+  it('should return null over voids', () => {
+    const tile = makeTile('N36W122', [0, 0]);
+    expect(sampleElevation(tile, { lat: 37, lon: -122 })).toBeNull();
+  });
+
+  it('should report elevation change in yards', () => {
+    const tiles = new Map([['N36W122', makeTile('N36W122')]]);
+    const from = { lat: 36.5, lon: -122 };
+    const to = { lat: 36.5, lon: -122 + 10 / 1200 }; // 10 columns east: 10 m higher
+
+    expect(elevationChangeYards(tiles, from, to)).toBe(10.9);
+    expect(elevationChangeYards(tiles, to, from)).toBe(-10.9);
+    expect(elevationChangeYards(tiles, from, { lat: 40, lon: -100 })).toBeNull();
+  });
+});
@@ -0,0 +1,110 @@
+// Elevation from SRTM .hgt tiles (1 or 3 arc-second, big-endian 16-bit metres)
+
+import type { GeoPoint } from './caddieEngine';
+
+// Public SRTM mirror (Mapzen/Tilezen "skadi" tiles, gzipped)
+export const SKADI_URL = 'https://s3.amazonaws.com/elevation-tiles-prod/skadi';
+
+const VOID = -32768;
+const YARDS_PER_METRE = 1.09361;
+
+export interface HgtTile {
+  name: string; // e.g. "N36W122"
+  lat: number; // south-west corner
+  lon: number;
+  size: number; // samples per side: 1201 (3") or 3601 (1")
+  data: DataView;
+}
+
+/**
+ * SRTM tile name for the tile containing a point
+ */
+export function hgtTileName(point: GeoPoint): string {
+  const lat = Math.floor(point.lat);
+  const lon = Math.floor(point.lon);
+  const ns = lat >= 0 ? 'N' : 'S';
+  const ew = lon >= 0 ? 'E' : 'W';
+  return `${ns}${String(Math.abs(lat)).padStart(2, '0')}${ew}${String(Math.abs(lon)).padStart(3, '0')}`;
+}
+
+/**
+ * URL of a gzipped tile on the skadi mirror
+ */
+export function hgtTileUrl(name: string): string {
+  return `${SKADI_URL}/${name.slice(0, 3)}/${name}.hgt.gz`;
+}
+
+/**
+ * Read a raw (uncompressed) .hgt file
+ */
+export function parseHgt(name: string, buffer: ArrayBuffer): HgtTile {
+  const size = Math.sqrt(buffer.byteLength / 2);
+  if (size !== 1201 && size !== 3601) {
+    throw new Error(`Unexpected .hgt size for ${name}: ${buffer.byteLength} bytes`);
+  }
+
+  const match = name.match(/^([NS])(\d{2})([EW])(\d{3})$/);
+  if (!match) throw new Error(`Invalid tile name: ${name}`);
+
+  return {
+    name,
+    lat: (match[1] === 'N' ? 1 : -1) * Number(match[2]),
+    lon: (match[3] === 'E' ? 1 : -1) * Number(match[4]),
+    size,
+    data: new DataView(buffer),
+  };
+}
+
+function sample(tile: HgtTile, row: number, col: number): number | null {
+  const value = tile.data.getInt16((row * tile.size + col) * 2, false);
+  return value === VOID ? null : value;
+}
+
+/**
+ * Elevation in metres at a point, bilinearly interpolated.
+ * Returns null outside the tile or over voids.
+ */
+export function sampleElevation(tile: HgtTile, point: GeoPoint): number | null {
+  const x = (point.lon - tile.lon) * (tile.size - 1);
+  // Rows run from the north edge down
+  const y = (tile.lat + 1 - point.lat) * (tile.size - 1);
+  if (x < 0 || y < 0 || x > tile.size - 1 || y > tile.size - 1) return null;
+
+  const col = Math.min(Math.floor(x), tile.size - 2);
+  const row = Math.min(Math.floor(y), tile.size - 2);
+  const fx = x - col;
+  const fy = y - row;
+
+  const corners = [
+    sample(tile, row, col),
+    sample(tile, row, col + 1),
+    sample(tile, row + 1, col),
+    sample(tile, row + 1, col + 1),
+  ];
+  if (corners.some(c => c === null)) return null;
+  const [nw, ne, sw, se] = corners as number[];
+
+  const north = nw + (ne - nw) * fx;
+  const south = sw + (se - sw) * fx;
+  return north + (south - north) * fy;
+}
+
+/**
+ * Elevation change from one point to another in yards (positive = uphill).
+ * Tiles are looked up by name; null if either point has no data.
+ */
+export function elevationChangeYards(
+  tiles: Map<string, HgtTile>,
+  from: GeoPoint,
+  to: GeoPoint
+): number | null {
+  const fromTile = tiles.get(hgtTileName(from));
+  const toTile = tiles.get(hgtTileName(to));
+  if (!fromTile || !toTile) return null;
+
+  const start = sampleElevation(fromTile, from);
+  const end = sampleElevation(toTile, to);
+  if (start === null || end === null) return null;
+
+  return Math.round((end - start) * YARDS_PER_METRE * 10) / 10;
+}
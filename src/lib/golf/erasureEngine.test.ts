@@ -0,0 +1,65 @@
+import { describe, it, expect } from 'vitest';
+import type { NostrEvent } from '@nostrify/nostrify';
+import { DELETION_BATCH, blobDeleteAuth, deletionRequests, isMemberOutboxEvent, mediaBlobs } from './erasureEngine';
+
+const alice = 'a'.repeat(64);
+const bob = 'b'.repeat(64);
+const now = 1_780_000_000_000;
+const hash = 'c'.repeat(64);
+
+const event = (id: string, kind: number, overrides: Partial<NostrEvent> = {}): NostrEvent => ({
+  id, kind, pubkey: alice, created_at: 1000, tags: [], content: '', sig: '', ...overrides,
+});
+
+describe('Erasure Engine', () => {
+  it('should ask to delete the member\'s events by id and coordinate', () => {
+    const [request] = deletionRequests([
+      event('round', 36901, { tags: [['d', 'r1']] }),
+      event('note', 1),
+      event('bag', 36908, { tags: [['d', 'bag']] }),
+      event('others', 1, { pubkey: bob }),
+    ], alice, 'Erasure requested', now);
+
+    expect(request.kind).toBe(5);
+    expect(request.content).toBe('Erasure requested');
+    expect(request.tags).toEqual([
+      ['e', 'round'], ['e', 'note'], ['e', 'bag'],
+      ['a', `36901:${alice}:r1`], ['a', `36908:${alice}:bag`],
+      ['k', '1'], ['k', '36901'], ['k', '36908'],
+    ]);
+  });
+
+  it('should split large erasures into batches', () => {
+    const events = Array.from({ length: DELETION_BATCH + 1 }, (_, i) => event(`e${i}`, 1));
+    const requests = deletionRequests([...events, events[0]], alice, '', now);
+    expect(requests).toHaveLength(2);
+    expect(requests[1].tags).toEqual([['e', `e${DELETION_BATCH}`], ['k', '1']]);
+  });
+
+  it('should find uploaded media in imeta tags and content', () => {
+    const blobs = mediaBlobs([
+      event('photo', 36925, { tags: [['imeta', `url https://blossom.primal.net/${hash}.jpg`, 'm image/jpeg']] }),
+      event('post', 1, { content: `Great day! https://cdn.example.com/${'d'.repeat(64)}.png and https://blossom.primal.net/${hash}.jpg` }),
+      event('theirs', 1, { pubkey: bob, content: `https://cdn.example.com/${'e'.repeat(64)}` }),
+    ], alice);
+
+    expect(blobs).toEqual([
+      { server: 'https://blossom.primal.net', sha256: hash },
+      { server: 'https://cdn.example.com', sha256: 'd'.repeat(64) },
+    ]);
+  });
+
+  it('should authorize deleting a blob for a minute', () => {
+    const auth = blobDeleteAuth({ server: 'https://blossom.primal.net', sha256: hash }, now);
+    expect(auth.kind).toBe(24242);
+    expect(auth.tags).toEqual([['t', 'delete'], ['x', hash], ['expiration', String(now / 1000 + 60)]]);
+  });
+
+  it('should find the member\'s queued outbox events', () => {
+    const card = { kind: 36903, tags: [['player', alice]] };
+    expect(isMemberOutboxEvent({ payload: card }, alice)).toBe(true);
+    expect(isMemberOutboxEvent({ payload: card, signed: event('mine', 36903) }, alice)).toBe(true);
+    expect(isMemberOutboxEvent({ payload: card, signed: event('terminal', 36903, { pubkey: bob, tags: [['player', alice]] }) }, alice)).toBe(true);
+    expect(isMemberOutboxEvent({ payload: card, signed: event('theirs', 36903, { pubkey: bob, tags: [['player', bob]] }) }, alice)).toBe(false);
+  });
+});
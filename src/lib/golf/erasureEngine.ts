@@ -0,0 +1,117 @@
+// Right to erasure: NIP-09 deletion requests for everything a member
+// published, and the Blossom media they uploaded with it
+
+import type { NostrEvent } from '@nostrify/nostrify';
+
+export type EventTemplate = Omit<NostrEvent, 'id' | 'pubkey' | 'sig'>;
+
+export interface MediaBlob {
+  server: string; // origin of the Blossom server, e.g. "https://blossom.primal.net"
+  sha256: string;
+}
+
+export interface ErasureSummary {
+  events: number; // events asked to be deleted
+  requests: number; // deletion requests published
+  media: number; // blobs deleted from their servers
+  mediaFailed: number; // blobs a server refused or couldn't be reached for
+  erasedAt: number; // ms
+}
+
+// Keeps each deletion request well under relays' tag and size limits
+export const DELETION_BATCH = 400;
+
+const BLOB_URL = /https?:\/\/[^\s"'<>]+?\/([0-9a-f]{64})(?:\.[a-z0-9]+)?(?=[\s"'<>?#]|$)/gi;
+
+function isReplaceable(kind: number): boolean {
+  return kind === 0 || kind === 3 || (kind >= 10000 && kind < 20000);
+}
+
+function isAddressable(kind: number): boolean {
+  return kind >= 30000 && kind < 40000;
+}
+
+/**
+ * NIP-09 deletion requests for the member's own events, in batches. Each
+ * event is named by id, and replaceable and addressable events by their
+ * coordinate too, so earlier versions on relays are deleted as well.
+ */
+export function deletionRequests(events: NostrEvent[], pubkey: string, reason: string, now: number): EventTemplate[] {
+  const own = [...new Map(events.filter(e => e.pubkey === pubkey && e.kind !== 5).map(e => [e.id, e])).values()];
+  const requests: EventTemplate[] = [];
+
+  for (let i = 0; i < own.length; i += DELETION_BATCH) {
+    const batch = own.slice(i, i + DELETION_BATCH);
+    const coordinates = new Set<string>();
+    for (const e of batch) {
+      if (isAddressable(e.kind)) coordinates.add(`${e.kind}:${pubkey}:${e.tags.find(t => t[0] === 'd')?.[1] ?? ''}`);
+      else if (isReplaceable(e.kind)) coordinates.add(`${e.kind}:${pubkey}:`);
+    }
+    const kinds = [...new Set(batch.map(e => e.kind))].sort((a, b) => a - b);
+
+    requests.push({
+      kind: 5,
+      content: reason,
+      tags: [
+        ...batch.map(e => ['e', e.id]),
+        ...[...coordinates].map(a => ['a', a]),
+        ...kinds.map(k => ['k', String(k)]),
+      ],
+      created_at: Math.floor(now / 1000),
+    });
+  }
+
+  return requests;
+}
+
+/** Blossom blobs the member's events link to, from `imeta` tags and URLs in the content */
+export function mediaBlobs(events: NostrEvent[], pubkey: string): MediaBlob[] {
+  const blobs = new Map<string, MediaBlob>();
+  const add = (url: string) => {
+    for (const match of url.matchAll(BLOB_URL)) {
+      try {
+        const server = new URL(match[0]).origin;
+        const sha256 = match[1].toLowerCase();
+        blobs.set(`${server}/${sha256}`, { server, sha256 });
+      } catch {
+        // not a URL after all
+      }
+    }
+  };
+
+  for (const event of events) {
+    if (event.pubkey !== pubkey) continue;
+    for (const tag of event.tags) {
+      if (tag[0] === 'imeta') tag.slice(1).filter(entry => entry.startsWith('url ')).forEach(entry => add(entry.slice(4)));
+      if (tag[0] === 'url' || tag[0] === 'image') add(tag[1] ?? '');
+    }
+    add(event.content);
+  }
+
+  return [...blobs.values()];
+}
+
+/** Blossom (BUD-02) authorization to delete one blob, valid for a minute */
+export function blobDeleteAuth(blob: MediaBlob, now: number): EventTemplate {
+  const created_at = Math.floor(now / 1000);
+  return {
+    kind: 24242,
+    content: 'Delete my golf data',
+    tags: [
+      ['t', 'delete'],
+      ['x', blob.sha256],
+      ['expiration', String(created_at + 60)],
+    ],
+    created_at,
+  };
+}
+
+/**
+ * Whether a queued outbox event is the member's: signed by them, about their
+ * card, or not signed yet, which would publish it as them
+ */
+export function isMemberOutboxEvent(entry: { payload: unknown; signed?: unknown }, pubkey: string): boolean {
+  if (!entry.signed) return true;
+  const event = entry.signed as Partial<NostrEvent>;
+  return event.pubkey === pubkey || !!event.tags?.some(([name, value]) => (name === 'player' || name === 'p') && value === pubkey);
+}
@@ -0,0 +1,128 @@
+import { describe, it, expect } from 'vitest';
+import {
+  fantasyPrizes,
+  fantasySource,
+  fantasyStandings,
+  lockedPicks,
+  paidMembers,
+  parseEspnLeaderboard,
+  parseResultsCsv,
+  proPoints,
+  type FantasyLeague,
+  type FantasyPicks,
+} from './fantasyEngine';
+import { createFantasyLeagueEvent, createFantasyPicksEvent, parseFantasyLeagueEvent, parseFantasyPicksEvent } from './nostrEvents';
+
+const masters = 1_780_000_000_000;
+const open = masters + 60 * 24 * 60 * 60 * 1000;
+
+const league: FantasyLeague = {
+  leagueId: 'club-fantasy',
+  commissioner: 'com',
+  name: 'Club Fantasy',
+  entryFeeSats: 1000,
+  picksPerMajor: 2,
+  payout: [60, 30, 10],
+  source: 'manual',
+  majors: [
+    { eventId: 'masters', name: 'The Masters', startsAt: masters },
+    { eventId: 'us-open', name: 'US Open', startsAt: open },
+  ],
+  results: {
+    masters: [
+      { name: 'Scottie Scheffler', position: 1 },
+      { name: 'Ludvig Åberg', position: 2 },
+      { name: 'Rory McIlroy', position: 2 },
+      { name: 'Jon Rahm', position: null },
+    ],
+  },
+  createdAt: masters - 1000,
+};
+
+const picks = (member: string, pros: string[], eventId = 'masters', pickedAt = masters - 1000): FantasyPicks => ({
+  leagueId: 'club-fantasy', eventId, member, pros, pickedAt,
+});
+
+describe('Fantasy Engine', () => {
+  it('should read results from each data source', () => {
+    const espn = JSON.stringify({ events: [{ competitions: [{ competitors: [
+      { athlete: { displayName: 'Scottie Scheffler' }, status: { position: { displayName: '1' } } },
+      { athlete: { displayName: 'Rory McIlroy' }, status: { position: { displayName: 'T2' } } },
+      { athlete: { displayName: 'Jon Rahm' }, status: { position: { displayName: 'CUT' } } },
+    ] }] }] });
+    expect(fantasySource('espn').parse(espn)).toEqual([
+      { name: 'Scottie Scheffler', position: 1 },
+      { name: 'Rory McIlroy', position: 2 },
+      { name: 'Jon Rahm', position: null },
+    ]);
+    expect(() => parseEspnLeaderboard(JSON.stringify({ events: [] }))).toThrow('No leaderboard');
+
+    expect(parseResultsCsv('Pos,Player\n1,Scottie Scheffler\nT2,Rory McIlroy\nCUT,Jon Rahm')).toEqual([
+      { name: 'Scottie Scheffler', position: 1 },
+      { name: 'Rory McIlroy', position: 2 },
+      { name: 'Jon Rahm', position: null },
+    ]);
+    expect(() => parseResultsCsv('Player\nRory')).toThrow('name and a position column');
+    expect(fantasySource('unknown').id).toBe('manual');
+  });
+
+  it('should split points between tied pros and give none for a missed cut', () => {
+    const points = proPoints(league.results.masters);
+    expect(points['scottie scheffler']).toBe(100);
+    expect(points['ludvig aberg']).toBe(67.5);
+    expect(points['rory mcilroy']).toBe(67.5);
+    expect(points['jon rahm']).toBeUndefined();
+  });
+
+  it('should lock picks at the first tee time and cap them per major', () => {
+    const locked = lockedPicks(league, [
+      picks('alice', ['Rory McIlroy'], 'masters', masters - 5000),
+      picks('alice', ['Scottie Scheffler', 'scottie scheffler', 'Rory McIlroy', 'Jon Rahm'], 'masters', masters - 1000),
+      picks('alice', ['Jon Rahm'], 'masters', masters + 1000),
+      picks('bob', ['Jon Rahm'], 'the-open'),
+    ]);
+    expect(locked).toEqual([picks('alice', ['Scottie Scheffler', 'Rory McIlroy'], 'masters', masters - 1000)]);
+  });
+
+  it('should rank members and pay the pot to paid members only', () => {
+    const standings = fantasyStandings(league, [
+      picks('alice', ['Scottie Scheffler', 'Jon Rahm']),
+      picks('bob', ['Rory McIlroy', 'Ludvig Aberg']),
+      picks('carol', ['Scottie Scheffler']),
+      picks('dave', ['Jon Rahm']),
+      picks('alice', ['Rory McIlroy'], 'us-open', open - 1000),
+    ]);
+    expect(standings.map(s => [s.member, s.points, s.position])).toEqual([
+      ['bob', 135, 1],
+      ['alice', 100, 2],
+      ['carol', 100, 2],
+      ['dave', 0, 4],
+    ]);
+
+    const paid = paidMembers(league, [
+      { sender: 'alice', amountSats: 1000 },
+      { sender: 'carol', amountSats: 500 },
+      { sender: 'carol', amountSats: 500 },
+      { sender: 'dave', amountSats: 1000 },
+      { sender: 'bob', amountSats: 100 },
+    ]);
+    expect([...paid].sort()).toEqual(['alice', 'carol', 'dave']);
+
+    // 3000 sats: alice and carol tie for first and split 60% + 30%
+    expect(fantasyPrizes(league, standings, paid).map(p => [p.recipient, p.amountSats])).toEqual([
+      ['alice', 1350],
+      ['carol', 1350],
+      ['dave', 300],
+    ]);
+  });
+
+  it('should round-trip a league and picks through Nostr events', () => {
+    const event = { ...createFantasyLeagueEvent(league), created_at: league.createdAt / 1000 };
+    expect(parseFantasyLeagueEvent(event)).toEqual(league);
+
+    const p = picks('alice', ['Scottie Scheffler', 'Rory McIlroy']);
+    const { pickedAt: _pickedAt, ...choice } = p;
+    const picked = { ...createFantasyPicksEvent(choice, 'com'), created_at: p.pickedAt / 1000 };
+    expect(parseFantasyPicksEvent(picked)).toEqual(p);
+  });
+});
@@ -0,0 +1,212 @@
+// Fantasy golf: league members pick pros for each major, score points from
+// where their pros finish, and the entry pot is paid out on the final
+// standings.
+//
+// Results come from a pluggable data source. Each source knows where to fetch
+// an event's leaderboard (if anywhere) and how to read it, so adding a tour
+// feed means adding a source, not changing the scoring.
+
+import { calculateEventPoints, DEFAULT_MERIT_POINTS } from './seasonEngine';
+import { parseCsv } from './courseImport';
+import type { Prize } from './payoutEngine';
+
+export interface FantasyMajor {
+  eventId: string; // the data source's id for the event
+  name: string;
+  startsAt: number; // ms, picks lock at the first tee time
+}
+
+export interface ProResult {
+  name: string;
+  position: number | null; // null when the pro missed the cut or withdrew
+}
+
+export interface FantasyLeague {
+  leagueId: string;
+  commissioner: string;
+  name: string;
+  entryFeeSats: number;
+  picksPerMajor: number;
+  payout: number[]; // percent of the pot for 1st, 2nd, 3rd...
+  source: string; // see FANTASY_SOURCES
+  majors: FantasyMajor[];
+  results: { [eventId: string]: ProResult[] };
+  createdAt: number;
+}
+
+export interface FantasyPicks {
+  leagueId: string;
+  eventId: string;
+  member: string;
+  pros: string[];
+  pickedAt: number; // ms
+}
+
+export interface FantasyStanding {
+  member: string;
+  points: number;
+  majors: { [eventId: string]: number };
+  position: number; // ties share a position
+}
+
+export interface EntryPayment {
+  sender: string;
+  amountSats: number;
+}
+
+export interface FantasySource {
+  id: string;
+  name: string;
+  url?: (eventId: string) => string; // sources without one take pasted results
+  parse: (body: string) => ProResult[];
+}
+
+export const DEFAULT_FANTASY_PAYOUT = [60, 30, 10];
+export const DEFAULT_PICKS_PER_MAJOR = 4;
+
+/** Pro names compared without case, accents or punctuation */
+export function proKey(name: string): string {
+  return name.normalize('NFD').replace(/[\u0300-\u036f]/g, '').toLowerCase().replace(/[^a-z ]/g, '').replace(/\s+/g, ' ').trim();
+}
+
+function parsePosition(value: unknown): number | null {
+  const n = parseInt(String(value ?? '').replace(/^T/i, ''));
+  return isNaN(n) || n <= 0 ? null : n;
+}
+
+/** Leaderboard from ESPN's golf scoreboard API */
+export function parseEspnLeaderboard(body: string): ProResult[] {
+  const json = JSON.parse(body);
+  const competitors = json?.events?.[0]?.competitions?.[0]?.competitors ?? json?.competitions?.[0]?.competitors;
+  if (!Array.isArray(competitors)) throw new Error('No leaderboard in the response');
+
+  return competitors
+    .map((c: { athlete?: { displayName?: string }; status?: { position?: { displayName?: string } }; order?: number }) => ({
+      name: c.athlete?.displayName ?? '',
+      position: parsePosition(c.status?.position?.displayName ?? c.order),
+    }))
+    .filter((r: ProResult) => r.name);
+}
+
+/** Results pasted as CSV with name and position columns ("T3", "CUT") */
+export function parseResultsCsv(body: string): ProResult[] {
+  const rows = parseCsv(body);
+  const header = rows[0]?.map(h => h.toLowerCase().trim()) ?? [];
+  const name = header.findIndex(h => h === 'name' || h === 'player');
+  const position = header.findIndex(h => h === 'position' || h === 'pos');
+  if (name === -1 || position === -1) throw new Error('Results need a name and a position column');
+
+  return rows.slice(1)
+    .filter(row => row[name]?.trim())
+    .map(row => ({ name: row[name].trim(), position: parsePosition(row[position]) }));
+}
+
+export const FANTASY_SOURCES: FantasySource[] = [
+  {
+    id: 'espn',
+    name: 'ESPN (PGA Tour)',
+    url: eventId => `https://site.api.espn.com/apis/site/v2/sports/golf/pga/scoreboard?event=${encodeURIComponent(eventId)}`,
+    parse: parseEspnLeaderboard,
+  },
+  { id: 'manual', name: 'Pasted results', parse: parseResultsCsv },
+];
+
+export function fantasySource(id: string): FantasySource {
+  return FANTASY_SOURCES.find(s => s.id === id) ?? FANTASY_SOURCES[FANTASY_SOURCES.length - 1];
+}
+
+/**
+ * Each member's picks for each major: their latest picks made before the
+ * major started, capped at the league's picks per major
+ */
+export function lockedPicks(league: FantasyLeague, picks: FantasyPicks[]): FantasyPicks[] {
+  const latest = new Map<string, FantasyPicks>();
+  for (const p of picks) {
+    const major = league.majors.find(m => m.eventId === p.eventId);
+    if (p.leagueId !== league.leagueId || !major || p.pickedAt >= major.startsAt) continue;
+    const key = `${p.member}:${p.eventId}`;
+    const existing = latest.get(key);
+    if (!existing || p.pickedAt > existing.pickedAt) latest.set(key, p);
+  }
+  return [...latest.values()].map(p => ({
+    ...p,
+    pros: p.pros.filter((pro, i) => p.pros.findIndex(other => proKey(other) === proKey(pro)) === i).slice(0, league.picksPerMajor),
+  }));
+}
+
+/**
+ * Points for each pro in a major, by finishing position on the order of
+ * merit table. Tied pros share the points for the places they cover.
+ */
+export function proPoints(results: ProResult[]): { [pro: string]: number } {
+  return calculateEventPoints({
+    eventId: '',
+    name: '',
+    date: 0,
+    results: results
+      .filter((r): r is ProResult & { position: number } => r.position !== null)
+      .map(r => ({ playerId: proKey(r.name), position: r.position })),
+  }, { pointsTable: DEFAULT_MERIT_POINTS });
+}
+
+/**
+ * League standings: each member scores their pros' points in every major
+ * with results, most points first
+ */
+export function fantasyStandings(league: FantasyLeague, picks: FantasyPicks[]): FantasyStanding[] {
+  const points = Object.fromEntries(Object.entries(league.results).map(([eventId, results]) => [eventId, proPoints(results)]));
+  const members = new Map<string, { [eventId: string]: number }>();
+
+  for (const p of lockedPicks(league, picks)) {
+    const majors = members.get(p.member) ?? {};
+    const table = points[p.eventId];
+    if (table) majors[p.eventId] = Math.round(p.pros.reduce((sum, pro) => sum + (table[proKey(pro)] ?? 0), 0) * 100) / 100;
+    members.set(p.member, majors);
+  }
+
+  const sorted = [...members.entries()]
+    .map(([member, majors]) => ({
+      member,
+      majors,
+      points: Math.round(Object.values(majors).reduce((sum, p) => sum + p, 0) * 100) / 100,
+    }))
+    .sort((a, b) => b.points - a.points);
+
+  const standings: FantasyStanding[] = [];
+  sorted.forEach((s, i) => {
+    const prev = standings[i - 1];
+    standings.push({ ...s, position: prev && prev.points === s.points ? prev.position : i + 1 });
+  });
+  return standings;
+}
+
+/** Members who have paid at least the entry fee */
+export function paidMembers(league: FantasyLeague, payments: EntryPayment[]): Set<string> {
+  const paid = new Map<string, number>();
+  for (const p of payments) paid.set(p.sender, (paid.get(p.sender) ?? 0) + p.amountSats);
+  return new Set([...paid.entries()].filter(([, sats]) => sats >= league.entryFeeSats).map(([member]) => member));
+}
+
+/**
+ * Prizes from the entry pot. Only paid members are in the money; tied
+ * members split the shares of the places they cover.
+ */
+export function fantasyPrizes(league: FantasyLeague, standings: FantasyStanding[], paid: Set<string>): Prize[] {
+  const eligible = standings.filter(s => paid.has(s.member));
+  const pot = league.entryFeeSats * paid.size;
+  const prizes: Prize[] = [];
+
+  let place = 0;
+  while (place < eligible.length && place < league.payout.length) {
+    const tied = eligible.filter(s => s.points === eligible[place].points);
+    const share = league.payout.slice(place, place + tied.length).reduce((sum, p) => sum + p, 0);
+    const amountSats = Math.floor((pot * share) / 100 / tied.length);
+    for (const s of tied) {
+      if (amountSats > 0) {
+        prizes.push({ prizeId: `${league.leagueId}:fantasy:${s.member}`, recipient: s.member, amountSats, memo: `${league.name} fantasy winnings` });
+      }
+    }
+    place += tied.length;
+  }
+  return prizes;
+}
@@ -0,0 +1,40 @@
+import { describe, it, expect } from 'vitest';
+import type { NostrEvent } from '@nostrify/nostrify';
+import { nip19 } from 'nostr-tools';
+import { buildFeed, isGolfNote, referencesGolfEvent } from './feedEngine';
+
+function note(id: string, content: string, tags: string[][] = [['t', 'golf']], created_at = 1000, pubkey = 'alice'): NostrEvent {
+  return { id, pubkey, created_at, kind: 1, tags, content, sig: '' };
+}
+
+describe('Feed Engine', () => {
+  it('should accept notes tagged golf in any case', () => {
+    expect(isGolfNote(note('1', 'Great day out', [['t', 'Golf']]))).toBe(true);
+    expect(isGolfNote(note('2', 'Great day out', [['t', 'tennis']]))).toBe(false);
+  });
+
+  it('should accept notes referencing Pinseekr events', () => {
+    expect(referencesGolfEvent(note('1', 'Ace!', [['a', '36916:pub:r1:7']]))).toBe(true);
+    const naddr = nip19.naddrEncode({ kind: 36901, pubkey: 'a'.repeat(64), identifier: 'round-1' });
+    expect(referencesGolfEvent(note('2', `Join my round nostr:${naddr}`, []))).toBe(true);
+    expect(referencesGolfEvent(note('3', 'Blog post', [['a', '30023:pub:post']]))).toBe(false);
+  });
+
+  it('should show each event once, newest first', () => {
+    const feed = buildFeed([note('a', 'first', undefined, 1000), note('b', 'second', undefined, 2000), note('a', 'first', undefined, 1000)]);
+    expect(feed.map(e => e.id)).toEqual(['b', 'a']);
+  });
+
+  it('should drop replies, muted authors and hashtag spam', () => {
+    const spam = note('s', 'buy now', Array.from({ length: 12 }, (_, i) => ['t', i === 0 ? 'golf' : `tag${i}`]));
+    const reply = note('r', 'nice shot', [['t', 'golf'], ['e', 'root-id', '', 'root']]);
+    const mention = note('m', 'see this', [['t', 'golf'], ['e', 'other', '', 'mention']]);
+    const muted = note('x', 'hello', [['t', 'golf']], 1000, 'troll');
+    expect(buildFeed([spam, reply, mention, muted], ['troll']).map(e => e.id)).toEqual(['m']);
+  });
+
+  it('should keep only the latest copy of repeated text from an author', () => {
+    const feed = buildFeed([note('old', 'Tee times open!', undefined, 1000), note('new', 'Tee times open!', undefined, 2000), note('bob', 'Tee times open!', undefined, 1500, 'bob')]);
+    expect(feed.map(e => e.id)).toEqual(['new', 'bob']);
+  });
+});
@@ -0,0 +1,73 @@
+// Social feed: golf notes from the relays, filtered and deduplicated
+
+import type { NostrEvent } from '@nostrify/nostrify';
+import { nip19 } from 'nostr-tools';
+
+export const FEED_HASHTAGS = ['golf', 'Golf', 'GOLF', 'pinseekr', 'holeinone'];
+
+// Pinseekr custom kinds use the 369xx block
+const isGolfKind = (kind: number) => kind >= 36900 && kind < 37000;
+
+// Notes stuffed with more hashtags than this are treated as spam
+const MAX_HASHTAGS = 10;
+
+/**
+ * Whether a note references a Pinseekr event: an `a` or `q` tag pointing at
+ * a golf kind, or a nostr:naddr link to one in the content
+ */
+export function referencesGolfEvent(event: NostrEvent): boolean {
+  const tagged = event.tags.some(([name, value]) =>
+    (name === 'a' || name === 'q') && !!value && isGolfKind(parseInt(value.split(':')[0]))
+  );
+  if (tagged) return true;
+
+  for (const [, bech32] of event.content.matchAll(/nostr:(naddr1[023456789acdefghjklmnpqrstuvwxyz]+)/g)) {
+    try {
+      const decoded = nip19.decode(bech32);
+      if (decoded.type === 'naddr' && isGolfKind(decoded.data.kind)) return true;
+    } catch {
+      // not a valid naddr
+    }
+  }
+  return false;
+}
+
+export function isGolfNote(event: NostrEvent): boolean {
+  const hashtags = event.tags.filter(([name]) => name === 't').map(([, value]) => value?.toLowerCase());
+  return hashtags.includes('golf') || hashtags.includes('pinseekr') || referencesGolfEvent(event);
+}
+
+/**
+ * Whether a note replies to another (NIP-10): any `e` tag that isn't a mention
+ */
+export function isReply(event: NostrEvent): boolean {
+  return event.tags.some(([name, , , marker]) => name === 'e' && marker !== 'mention');
+}
+
+/**
+ * The feed: top-level golf notes, newest first. Events seen on several
+ * relays appear once, muted authors and hashtag spam are dropped, and an
+ * author posting the same text again only shows the latest copy.
+ */
+export function buildFeed(events: NostrEvent[], muted: string[] = [], limit = 50): NostrEvent[] {
+  const mutedSet = new Set(muted);
+  const seenIds = new Set<string>();
+  const seenText = new Set<string>();
+  const feed: NostrEvent[] = [];
+
+  for (const event of [...events].sort((a, b) => b.created_at - a.created_at)) {
+    if (event.kind !== 1 || seenIds.has(event.id)) continue;
+    seenIds.add(event.id);
+
+    if (mutedSet.has(event.pubkey) || isReply(event) || !isGolfNote(event)) continue;
+    if (event.tags.filter(([name]) => name === 't').length > MAX_HASHTAGS) continue;
+
+    const text = `${event.pubkey}:${event.content.trim()}`;
+    if (!event.content.trim() || seenText.has(text)) continue;
+    seenText.add(text);
+
+    feed.push(event);
+    if (feed.length >= limit) break;
+  }
+  return feed;
+}
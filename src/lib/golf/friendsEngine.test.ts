@@ -0,0 +1,48 @@
+import { describe, it, expect } from 'vitest';
+import type { NostrEvent } from '@nostrify/nostrify';
+import type { PlayerScoreRecord } from './nostrEvents';
+import { followedPubkeys, friendsLeaderboard, monthStart } from './friendsEngine';
+
+const A = 'a'.repeat(64);
+const B = 'b'.repeat(64);
+
+function card(playerPubkey: string, roundId: string, holes: number, strokes: number, updatedAt = 1000): PlayerScoreRecord {
+  const scores: { [hole: number]: number } = {};
+  for (let hole = 1; hole <= holes; hole++) scores[hole] = strokes;
+  return { roundId, playerPubkey, scores, putts: {}, pars: {}, fairways: {}, greens: {}, penalties: {}, updatedAt };
+}
+
+describe('Friends Engine', () => {
+  it('should read follows from a contact list without duplicates', () => {
+    const list: NostrEvent = { id: '1', pubkey: 'me', created_at: 1000, kind: 3, tags: [['p', A], ['p', B], ['p', A], ['p', 'not-a-key'], ['t', 'golf']], content: '', sig: '' };
+    expect(followedPubkeys(list)).toEqual([A, B]);
+    expect(followedPubkeys(undefined)).toEqual([]);
+  });
+
+  it('should start the month on the first day', () => {
+    const start = new Date(monthStart(new Date(2026, 9, 15, 14, 30)));
+    expect(start.getDate()).toBe(1);
+    expect(start.getMonth()).toBe(9);
+    expect(start.getHours()).toBe(0);
+  });
+
+  it('should rank players by scoring average per 18 holes', () => {
+    const board = friendsLeaderboard([card(A, 'r1', 18, 5), card(B, 'r2', 9, 4)]);
+    expect(board.map(s => s.playerPubkey)).toEqual([B, A]);
+    expect(board[0].average).toBe(72);
+    expect(board[1].best18).toBe(90);
+    expect(board[0].best18).toBeUndefined();
+  });
+
+  it('should count only the latest version of a card', () => {
+    const board = friendsLeaderboard([card(A, 'r1', 18, 6, 1000), card(A, 'r1', 18, 5, 2000)]);
+    expect(board[0].rounds).toBe(1);
+    expect(board[0].strokes).toBe(90);
+  });
+
+  it('should leave out players short of nine holes and share tied positions', () => {
+    const board = friendsLeaderboard([card(A, 'r1', 18, 4), card(B, 'r2', 9, 4), card('c'.repeat(64), 'r3', 6, 3)]);
+    expect(board).toHaveLength(2);
+    expect(board.map(s => s.position)).toEqual([1, 1]);
+  });
+});
@@ -0,0 +1,73 @@
+// Friends leaderboards from a player's follow list (NIP-02)
+
+import type { NostrEvent } from '@nostrify/nostrify';
+import type { PlayerScoreRecord } from './nostrEvents';
+
+export interface FriendStanding {
+  playerPubkey: string;
+  rounds: number;
+  holes: number;
+  strokes: number;
+  average: number; // strokes per 18 holes
+  best18?: number; // best gross over a full 18-hole card
+  position: number;
+}
+
+export const MIN_FRIEND_HOLES = 9; // a player needs a nine to be ranked
+
+/** Pubkeys a contact list follows, in list order without duplicates */
+export function followedPubkeys(contactList: NostrEvent | undefined): string[] {
+  if (!contactList || contactList.kind !== 3) return [];
+  const follows = contactList.tags
+    .filter(([name, value]) => name === 'p' && /^[a-f0-9]{64}$/.test(value ?? ''))
+    .map(([, value]) => value);
+  return [...new Set(follows)];
+}
+
+/** First millisecond of the calendar month containing `now`, in local time */
+export function monthStart(now: Date): number {
+  return new Date(now.getFullYear(), now.getMonth(), 1).getTime();
+}
+
+/**
+ * Leaderboard of players' cards, ranked by scoring average per 18 holes.
+ * Only the latest version of each card counts, and players who haven't
+ * completed nine holes yet are left out. Ties share a position.
+ */
+export function friendsLeaderboard(records: PlayerScoreRecord[]): FriendStanding[] {
+  const latest = new Map<string, PlayerScoreRecord>();
+  for (const record of records) {
+    const key = `${record.playerPubkey}:${record.roundId}`;
+    const existing = latest.get(key);
+    if (!existing || record.updatedAt > existing.updatedAt) latest.set(key, record);
+  }
+
+  const byPlayer = new Map<string, Omit<FriendStanding, 'average' | 'position'>>();
+  for (const record of latest.values()) {
+    const scores = Object.values(record.scores).filter(s => s > 0);
+    if (scores.length === 0) continue;
+    const gross = scores.reduce((sum, s) => sum + s, 0);
+
+    const standing = byPlayer.get(record.playerPubkey)
+      ?? { playerPubkey: record.playerPubkey, rounds: 0, holes: 0, strokes: 0 };
+    standing.rounds += 1;
+    standing.holes += scores.length;
+    standing.strokes += gross;
+    if (scores.length >= 18 && (standing.best18 === undefined || gross < standing.best18)) {
+      standing.best18 = gross;
+    }
+    byPlayer.set(record.playerPubkey, standing);
+  }
+
+  const ranked = [...byPlayer.values()]
+    .filter(s => s.holes >= MIN_FRIEND_HOLES)
+    .map(s => ({ ...s, average: Math.round((s.strokes / s.holes) * 18 * 10) / 10, position: 0 }))
+    .sort((a, b) => a.average - b.average || b.rounds - a.rounds);
+
+  ranked.forEach((standing, i) => {
+    const prev = ranked[i - 1];
+    standing.position = prev && prev.average === standing.average ? prev.position : i + 1;
+  });
+
+  return ranked;
+}
@@ -0,0 +1,195 @@
+import { describe, it, expect } from 'vitest';
+import {
+  calculateDifferential,
+  calculateHandicapIndex,
+  buildHandicapHistory,
+  exceptionalScoreReduction,
+  applyExceptionalScoreReductions,
+  calculateCourseHandicap,
+  calculatePlayingHandicap,
+  calculatePlayingHandicapFromIndex,
+  calculateStrokesOffLow,
+  combineNineHoleDifferential,
+  calculateAdjustedGrossScore,
+  type AdjustedHoleInput,
+  type RoundDifferential
+} from './handicapCalculator';
+import { createHandicapPenaltyEvent, parseHandicapPenaltyEvent } from './nostrEvents';
+
+describe('Handicap Calculator', () => {
+  const day = 24 * 60 * 60 * 1000;
+
+  const round = (i: number, differential: number): RoundDifferential => ({
+    roundId: `r${i}`,
+    date: i * day,
+    gross: 72 + Math.round(differential),
+    courseRating: 72,
+    slope: 113,
+    differential
+  });
+
+  describe('calculateDifferential', () => {
+    it('should adjust for slope', () => {
+      expect(calculateDifferential(85, 72, 113)).toBe(13);
+      expect(calculateDifferential(85, 72, 130)).toBeCloseTo(11.3, 1);
+    });
+
+    it('should return 0 for missing ratings', () => {
+      expect(calculateDifferential(85, 0, 113)).toBe(0);
+    });
+  });
+
+  describe('calculateHandicapIndex', () => {
+    it('should need 5 rounds', () => {
+      const result = calculateHandicapIndex([1, 2, 3, 4].map(i => round(i, 10)));
+      expect(result.index).toBeNull();
+      expect(result.minimumRoundsNeeded).toBe(1);
+    });
+
+    it('should average the best differentials', () => {
+      const result = calculateHandicapIndex([10, 12, 14, 16, 18].map((d, i) => round(i, d)));
+      expect(result.method).toBe('best-2-of-5');
+      expect(result.index).toBe(10.6); // (10 + 12) / 2 * 0.96
+    });
+  });
+
+  describe('buildHandicapHistory', () => {
+    it('should record a revision for every round in date order', () => {
+      const rounds = [20, 18, 16, 14, 12, 10].map((d, i) => round(i + 1, d));
+      const history = buildHandicapHistory([...rounds].reverse());
+
+      expect(history.map(r => r.roundId)).toEqual(['r1', 'r2', 'r3', 'r4', 'r5', 'r6']);
+      expect(history.slice(0, 4).every(r => r.index === null)).toBe(true);
+
+      expect(history[4]).toMatchObject({ index: 12.5, previousIndex: null, change: null });
+      expect(history[4].contributingRoundIds).toEqual(['r5', 'r4']);
+
+      expect(history[5]).toMatchObject({ index: 10.6, previousIndex: 12.5, change: -1.9 });
+      expect(history[5].contributingRoundIds).toEqual(['r6', 'r5']);
+    });
+
+    it('should only use the most recent 20 rounds for each revision', () => {
+      // One excellent round followed by 20 ordinary ones
+      const rounds = [round(0, 0), ...Array.from({ length: 20 }, (_, i) => round(i + 1, 15))];
+      const history = buildHandicapHistory(rounds);
+
+      expect(history[19].contributingRoundIds).toContain('r0');
+      expect(history[20].contributingRoundIds).not.toContain('r0');
+      expect(history[20].index).toBe(14.4);
+    });
+  });
+
+  describe('Exceptional score reduction', () => {
+    it('should reduce by 1 for 7+ and 2 for 10+ strokes better than the index', () => {
+      expect(exceptionalScoreReduction(8.1, 15)).toBe(0);
+      expect(exceptionalScoreReduction(8.0, 15)).toBe(-1);
+      expect(exceptionalScoreReduction(5.0, 15)).toBe(-2);
+      expect(exceptionalScoreReduction(0, null)).toBe(0);
+    });
+
+    it('should adjust the most recent 20 differentials when an exceptional score is posted', () => {
+      const rounds = [...Array.from({ length: 20 }, (_, i) => round(i + 1, 15)), round(21, 3)];
+      const history = buildHandicapHistory(rounds);
+
+      expect(history[19].index).toBe(14.4);
+      expect(history[20].exceptionalReduction).toBe(-2);
+      // best 8: 3 + seven 15s, each reduced by 2 => (1 + 7 * 13) / 8 * 0.96
+      expect(history[20].index).toBe(11);
+
+      const adjusted = applyExceptionalScoreReductions(rounds);
+      expect(adjusted[0].roundId).toBe('r21');
+      expect(adjusted.filter(r => r.adjustment === -2)).toHaveLength(20);
+      expect(adjusted.find(r => r.roundId === 'r1')?.adjustment).toBe(0);
+    });
+
+    it('should not treat penalty scores as exceptional', () => {
+      const rounds = [
+        ...Array.from({ length: 5 }, (_, i) => round(i + 1, 15)),
+        { ...round(6, 2), source: 'penalty' as const }
+      ];
+
+      expect(buildHandicapHistory(rounds)[5].exceptionalReduction).toBe(0);
+    });
+  });
+
+  describe('Penalty score events', () => {
+    it('should round-trip a committee penalty into the scoring record', () => {
+      const event = createHandicapPenaltyEvent('p1', 'player', 'committee', 4.5, 'Failure to post scores', 10 * day);
+
+      expect(event.tags).toContainEqual(['p', 'player']);
+
+      const penalty = parseHandicapPenaltyEvent(event);
+      expect(penalty).toMatchObject({ roundId: 'penalty-p1', differential: 4.5, date: 10 * day, source: 'penalty' });
+    });
+  });
+
+  describe('Course and playing handicap', () => {
+    const tee = { courseRating: 71.2, slopeRating: 131, par: 72 };
+
+    it('should adjust the index for slope and rating minus par', () => {
+      // 14.3 * 131 / 113 + (71.2 - 72) = 15.78
+      expect(calculateCourseHandicap(14.3, tee)).toBe(16);
+      expect(calculateCourseHandicap(-2.1, tee)).toBe(-3);
+    });
+
+    it('should apply format allowances', () => {
+      expect(calculatePlayingHandicap(16)).toBe(15);
+      expect(calculatePlayingHandicap(16, 'individual-match')).toBe(16);
+      expect(calculatePlayingHandicap(16, 'fourball-stroke')).toBe(14);
+      expect(calculatePlayingHandicap(16, 0.75)).toBe(12);
+      expect(calculatePlayingHandicapFromIndex(14.3, tee, 'fourball-match')).toEqual({ courseHandicap: 16, playingHandicap: 14 });
+    });
+
+    it('should give match play strokes off the low handicap', () => {
+      expect(calculateStrokesOffLow({ alice: 4, bob: 12, carol: 9 })).toEqual({ alice: 0, bob: 8, carol: 5 });
+    });
+  });
+
+  describe('Nine-hole and partial rounds', () => {
+    it('should add the expected differential for the other nine', () => {
+      // 10.0 * 0.52 + 1.2 = 6.4
+      expect(combineNineHoleDifferential(5.5, 10)).toBe(11.9);
+      expect(combineNineHoleDifferential(5.5, null)).toBe(11);
+    });
+
+    it('should combine nine-hole rounds using the index at the time', () => {
+      const rounds: RoundDifferential[] = [
+        ...[10, 10, 10, 10, 10].map((d, i) => round(i + 1, d)),
+        { ...round(6, 0), holes: 9, nineHoleDifferential: 4 }
+      ];
+      const history = buildHandicapHistory(rounds);
+
+      // Index before the nine-hole round is 9.6, so 4 + 9.6 * 0.52 + 1.2 = 10.19
+      expect(history[4].index).toBe(9.6);
+      expect(history[5].index).toBe(9.6);
+      expect(applyExceptionalScoreReductions(rounds)[0].differential).toBe(10.2);
+    });
+
+    const holes = (strokes: (number | null)[]): AdjustedHoleInput[] =>
+      strokes.map((s, i) => ({ hole: i + 1, par: 4, strokeIndex: i + 1, strokes: s }));
+
+    it('should cap holes at net double bogey', () => {
+      const result = calculateAdjustedGrossScore(holes([10, 9, 4, 4, 4, 4, 4, 4, 4, 4, 4, 4, 4, 4, 4, 4, 4, 4]), 1);
+
+      // Hole 1 gets a stroke: max 7; hole 2 max 6
+      expect(result.adjustedGross).toBe(7 + 6 + 16 * 4);
+      expect(result.isAcceptable).toBe(true);
+    });
+
+    it('should count holes not played as net par', () => {
+      const scores: (number | null)[] = Array(18).fill(5);
+      scores[16] = null;
+      scores[17] = null;
+      const result = calculateAdjustedGrossScore(holes(scores), 18);
+
+      expect(result.holesNotPlayed).toEqual([17, 18]);
+      expect(result.adjustedGross).toBe(16 * 5 + 2 * 5);
+      expect(result.isAcceptable).toBe(true);
+    });
+
+    it('should require 14 holes for 18 and 7 for nine', () => {
+      expect(calculateAdjustedGrossScore(holes([...Array(13).fill(4), ...Array(5).fill(null)]), 0).isAcceptable).toBe(false);
+      expect(calculateAdjustedGrossScore(holes([...Array(7).fill(4), null, null]), 0).isAcceptable).toBe(true);
+    });
+  });
+});
@@ -8,8 +8,19 @@
  * 
  * Formula: Handicap Differential = (Gross Score - Course Rating) × 113 / Slope Rating
  * Handicap Index = Average of best differentials × 0.96 (soft cap)
+ *
+ * Exceptional scores (7+ strokes better than the index) reduce the most recent
+ * 20 differentials by 1, or by 2 for 10+ strokes (WHS Rule 5.9).
+ *
+ * Nine-hole scores become 18-hole differentials by adding the expected
+ * differential for the other nine, based on the index at the time (Rule 5.1b).
  */
 
+import { calculatePops } from './strokeEngine';
+import type { IntegrityFlag } from './integrityEngine';
+import type { AttestationStatus } from './attestationEngine';
+import type { RulingDecision } from './disputeEngine';
+
 export interface RoundDifferential {
   roundId: string;
   date: number;
@@ -18,6 +29,14 @@ export interface RoundDifferential {
   slope: number;
   differential: number;
   courseName?: string;
+  source?: 'round' | 'penalty'; // penalty scores are applied by a handicap committee
+  adjustment?: number; // exceptional score reduction applied to this differential (e.g. -1)
+  holes?: 9 | 18; // nine-hole rounds are combined with an expected score when replayed
+  nineHoleDifferential?: number; // raw differential for the nine holes played
+  integrityFlags?: IntegrityFlag[]; // plausibility check results
+  attestation?: AttestationStatus; // marker countersignature of the card
+  ruling?: RulingDecision; // committee decision on a disputed or held round
+  indoor?: boolean; // played on a simulator
 }
 
 export interface HandicapResult {
@@ -28,6 +47,8 @@ export interface HandicapResult {
   bestDifferentials: RoundDifferential[];
   method: 'best-2-of-5' | 'best-3-of-10' | 'best-8-of-20' | 'insufficient';
   minimumRoundsNeeded: number;
+  heldForReview?: RoundDifferential[]; // implausible or disputed rounds awaiting a committee decision
+  awaitingAttestation?: RoundDifferential[]; // cards not yet countersigned, when attestation is required
 }
 
 /**
@@ -44,6 +65,24 @@ export function calculateDifferential(
   return ((gross - courseRating) * 113) / slope;
 }
 
+/**
+ * Differential with any exceptional score reduction applied
+ */
+export function adjustedDifferential(round: RoundDifferential): number {
+  return round.differential + (round.adjustment ?? 0);
+}
+
+/**
+ * WHS exceptional score reduction for a new differential against the index before the round
+ */
+export function exceptionalScoreReduction(differential: number, indexBefore: number | null): 0 | -1 | -2 {
+  if (indexBefore === null) return 0;
+  const margin = indexBefore - differential;
+  if (margin >= 10) return -2;
+  if (margin >= 7) return -1;
+  return 0;
+}
+
 /**
  * Get the calculation method based on number of rounds
  */
@@ -83,13 +122,13 @@ export function calculateHandicapIndex(differentials: RoundDifferential[]): Hand
   }
 
   // Sort by differential (lowest first = best rounds)
-  const sorted = [...differentials].sort((a, b) => a.differential - b.differential);
+  const sorted = [...differentials].sort((a, b) => adjustedDifferential(a) - adjustedDifferential(b));
   
   // Take the best N differentials
   const bestDifferentials = sorted.slice(0, bestCount);
   
   // Calculate average
-  const sum = bestDifferentials.reduce((acc, d) => acc + d.differential, 0);
+  const sum = bestDifferentials.reduce((acc, d) => acc + adjustedDifferential(d), 0);
   const average = sum / bestCount;
   
   // Apply 96% soft cap (USGA/WHS standard)
@@ -106,6 +145,232 @@ export function calculateHandicapIndex(differentials: RoundDifferential[]): Hand
   };
 }
 
+export interface HandicapRevision {
+  roundId: string; // the round that triggered this revision
+  date: number;
+  index: number | null;
+  previousIndex: number | null;
+  change: number | null; // index - previousIndex, when both exist
+  method: HandicapResult['method'];
+  roundsAvailable: number;
+  contributingRoundIds: string[]; // differentials averaged into this index
+  exceptionalReduction: 0 | -1 | -2; // ESR triggered by this round
+}
+
+/**
+ * Replay rounds in date order to rebuild every handicap index revision.
+ * Each round produces a revision calculated from the most recent 20 rounds at that time,
+ * so committees can see exactly which differentials produced each index.
+ * Exceptional score reductions are applied as they would have been when each round was posted.
+ */
+export function buildHandicapHistory(differentials: RoundDifferential[]): HandicapRevision[] {
+  return replayHandicap(differentials).revisions;
+}
+
+/**
+ * Apply exceptional score reductions to a scoring record, returning the differentials
+ * with their accumulated adjustment (newest first, like the input to calculateHandicapIndex)
+ */
+export function applyExceptionalScoreReductions(differentials: RoundDifferential[]): RoundDifferential[] {
+  return replayHandicap(differentials).rounds.sort((a, b) => b.date - a.date);
+}
+
+function replayHandicap(differentials: RoundDifferential[]): {
+  revisions: HandicapRevision[];
+  rounds: RoundDifferential[];
+} {
+  // Adjustments are recalculated from scratch, so drop any carried in
+  const chronological = [...differentials]
+    .sort((a, b) => a.date - b.date)
+    .map(d => ({ ...d, adjustment: 0 }));
+  const revisions: HandicapRevision[] = [];
+  let previousIndex: number | null = null;
+
+  chronological.forEach((round, i) => {
+    if (round.holes === 9 && round.nineHoleDifferential !== undefined) {
+      round.differential = combineNineHoleDifferential(round.nineHoleDifferential, previousIndex);
+    }
+
+    const window = chronological.slice(Math.max(0, i - 19), i + 1);
+
+    // Penalty scores are committee decisions and never count as exceptional
+    const reduction = round.source === 'penalty' ? 0 : exceptionalScoreReduction(round.differential, previousIndex);
+    if (reduction !== 0) {
+      for (const d of window) d.adjustment += reduction;
+    }
+
+    const result = calculateHandicapIndex(window);
+
+    revisions.push({
+      roundId: round.roundId,
+      date: round.date,
+      index: result.index,
+      previousIndex,
+      change: result.index !== null && previousIndex !== null
+        ? Math.round((result.index - previousIndex) * 10) / 10
+        : null,
+      method: result.method,
+      roundsAvailable: result.roundsAvailable,
+      contributingRoundIds: result.bestDifferentials.map(d => d.roundId),
+      exceptionalReduction: reduction,
+    });
+
+    previousIndex = result.index;
+  });
+
+  return { revisions, rounds: chronological };
+}
+
+// Nine-hole and partial rounds
+
+/**
+ * Expected differential for nine holes at a given index
+ */
+export function expectedNineHoleDifferential(handicapIndex: number): number {
+  return handicapIndex * 0.52 + 1.2;
+}
+
+/**
+ * 18-hole differential from a nine-hole differential. Without an index yet,
+ * the nine-hole differential is doubled.
+ */
+export function combineNineHoleDifferential(nineHoleDifferential: number, handicapIndex: number | null): number {
+  if (handicapIndex === null) return Math.round(nineHoleDifferential * 2 * 10) / 10;
+  return Math.round((nineHoleDifferential + expectedNineHoleDifferential(handicapIndex)) * 10) / 10;
+}
+
+export interface AdjustedHoleInput {
+  hole: number;
+  par: number;
+  strokeIndex: number;
+  strokes?: number | null; // missing = not played
+  pickedUp?: boolean; // started but not holed out
+}
+
+export interface AdjustedGrossScore {
+  adjustedGross: number;
+  holesPlayed: number;
+  holesNotPlayed: number[];
+  isAcceptable: boolean; // enough holes played to post for handicap
+}
+
+/**
+ * Adjusted gross score for handicap purposes:
+ * - played holes are capped at net double bogey
+ * - holes started but not finished count as net double bogey
+ * - holes not played count as net par
+ * 18-hole rounds need 14 holes played, nine-hole rounds need 7.
+ */
+export function calculateAdjustedGrossScore(holes: AdjustedHoleInput[], courseHandicap: number): AdjustedGrossScore {
+  const holeInfo = Object.fromEntries(holes.map(h => [h.hole, { strokeIndex: h.strokeIndex }]));
+  const pops = calculatePops(courseHandicap, holeInfo, holes.map(h => h.hole));
+
+  let adjustedGross = 0;
+  let holesPlayed = 0;
+  const holesNotPlayed: number[] = [];
+
+  for (const h of holes) {
+    const received = pops[h.hole] || 0;
+    const netDoubleBogey = h.par + 2 + received;
+
+    if (h.pickedUp) {
+      adjustedGross += netDoubleBogey;
+      holesPlayed++;
+    } else if (h.strokes && h.strokes > 0) {
+      adjustedGross += Math.min(h.strokes, netDoubleBogey);
+      holesPlayed++;
+    } else {
+      adjustedGross += h.par + received;
+      holesNotPlayed.push(h.hole);
+    }
+  }
+
+  const required = holes.length <= 9 ? 7 : 14;
+
+  return {
+    adjustedGross,
+    holesPlayed,
+    holesNotPlayed,
+    isAcceptable: holesPlayed >= required,
+  };
+}
+
+// Course and playing handicaps (WHS Rules 6.1 and 6.2)
+
+export type HandicapAllowanceFormat =
+  | 'individual-stroke'
+  | 'individual-match'
+  | 'stableford'
+  | 'fourball-stroke'
+  | 'fourball-match'
+  | 'foursomes';
+
+/**
+ * Recommended WHS handicap allowances per format.
+ * Foursomes applies to the combined course handicap of the pair.
+ */
+export const HANDICAP_ALLOWANCES: Record<HandicapAllowanceFormat, number> = {
+  'individual-stroke': 0.95,
+  'individual-match': 1.0,
+  'stableford': 0.95,
+  'fourball-stroke': 0.85,
+  'fourball-match': 0.9,
+  'foursomes': 0.5,
+};
+
+export interface TeeRating {
+  courseRating: number;
+  slopeRating: number;
+  par: number;
+}
+
+/**
+ * Course handicap = Index × (Slope ÷ 113) + (Course Rating − Par), rounded.
+ * Plus handicaps (negative indexes) stay negative.
+ */
+export function calculateCourseHandicap(handicapIndex: number, tee: TeeRating): number {
+  if (tee.slopeRating <= 0 || tee.courseRating <= 0) {
+    return Math.round(handicapIndex);
+  }
+  return Math.round(handicapIndex * (tee.slopeRating / 113) + (tee.courseRating - tee.par));
+}
+
+/**
+ * Playing handicap = Course handicap × format allowance, rounded
+ */
+export function calculatePlayingHandicap(
+  courseHandicap: number,
+  format: HandicapAllowanceFormat | number = 'individual-stroke'
+): number {
+  const allowance = typeof format === 'number' ? format : HANDICAP_ALLOWANCES[format];
+  return Math.round(courseHandicap * allowance);
+}
+
+/**
+ * Convenience: index + tee + format straight to playing handicap
+ */
+export function calculatePlayingHandicapFromIndex(
+  handicapIndex: number,
+  tee: TeeRating,
+  format: HandicapAllowanceFormat | number = 'individual-stroke'
+): { courseHandicap: number; playingHandicap: number } {
+  const courseHandicap = calculateCourseHandicap(handicapIndex, tee);
+  return { courseHandicap, playingHandicap: calculatePlayingHandicap(courseHandicap, format) };
+}
+
+/**
+ * Match play strokes: every player plays off the lowest playing handicap in the group
+ */
+export function calculateStrokesOffLow(playingHandicaps: Record<string, number>): Record<string, number> {
+  const values = Object.values(playingHandicaps);
+  if (values.length === 0) return {};
+
+  const low = Math.min(...values);
+  return Object.fromEntries(
+    Object.entries(playingHandicaps).map(([playerId, hcp]) => [playerId, hcp - low])
+  );
+}
+
 /**
  * Get human-readable description of the calculation method
  */
@@ -128,3 +393,17 @@ export function getMethodDescription(method: HandicapResult['method']): string {
  */
 export const DEFAULT_COURSE_RATING = 72.0;
 export const DEFAULT_SLOPE = 113; // Standard slope rating
+
+/**
+ * Which committees' penalty scores and rulings count towards a player's
+ * index, and whether cards need a marker's countersignature
+ */
+export interface HandicapSettings {
+  committeePubkeys: string[]; // handicap committees whose penalty scores and rulings count
+  requireAttestation?: boolean; // only count cards a marker has countersigned
+}
+
+export const DEFAULT_HANDICAP_SETTINGS: HandicapSettings = {
+  committeePubkeys: [],
+  requireAttestation: false,
+};
@@ -0,0 +1,69 @@
+import { describe, it, expect } from 'vitest';
+import { createHoleTracker, detectHoles, nearestHole, updateHoleTracker, type DetectionHole, type GpsFix } from './holeDetection';
+import { destinationPoint, type GeoPoint } from './caddieEngine';
+
+describe('Hole Detection', () => {
+  const origin: GeoPoint = { lat: 40, lon: -75 };
+  // Three parallel holes running north, 150 yards apart
+  const tee = (n: number) => destinationPoint(origin, 90, (n - 1) * 150);
+  const holes: DetectionHole[] = [1, 2, 3].map(number => ({
+    number,
+    tee: tee(number),
+    green: destinationPoint(tee(number), 0, 400),
+  }));
+
+  const along = (hole: number, yards: number, timestamp: number): GpsFix => ({
+    ...destinationPoint(tee(hole), 0, yards),
+    timestamp,
+  });
+
+  it('should find the hole whose corridor contains the fix', () => {
+    expect(nearestHole(holes, along(2, 200, 0), null)).toBe(2);
+    expect(nearestHole(holes, destinationPoint(tee(1), 270, 200), null)).toBeNull();
+  });
+
+  it('should prefer the next hole where corridors overlap', () => {
+    // Midway between holes 1 and 2
+    const between = destinationPoint(tee(1), 90, 75);
+    expect(nearestHole(holes, between, 1, { corridorYards: 100 })).toBe(1);
+    expect(nearestHole(holes, between, 3, { corridorYards: 100 })).toBe(1); // 1 follows 3
+  });
+
+  it('should only switch after consecutive fixes on the new hole', () => {
+    let state = createHoleTracker(1);
+    let result = updateHoleTracker(state, holes, along(2, 10, 1));
+    expect(result.transition).toBeNull();
+
+    // A single fix back on hole 1 resets the count
+    state = updateHoleTracker(result.state, holes, along(1, 300, 2)).state;
+    result = updateHoleTracker(state, holes, along(2, 10, 3));
+    result = updateHoleTracker(result.state, holes, along(2, 20, 4));
+    expect(result.transition).toBeNull();
+
+    result = updateHoleTracker(result.state, holes, along(2, 30, 5));
+    expect(result.transition).toEqual({ from: 1, to: 2, timestamp: 5, completedHole: null });
+  });
+
+  it('should ignore inaccurate fixes', () => {
+    const state = createHoleTracker(1);
+    const result = updateHoleTracker(state, holes, { ...along(3, 10, 1), accuracy: 80 });
+    expect(result.state).toBe(state);
+  });
+
+  it('should detect a round from a batch of fixes', () => {
+    const fixes: GpsFix[] = [];
+    let t = 0;
+    for (const hole of [1, 2, 3]) {
+      for (const yards of [0, 100, 200, 300, 400]) fixes.push(along(hole, yards, t++));
+    }
+
+    const state = detectHoles(holes, fixes);
+
+    expect(state.currentHole).toBe(3);
+    expect(state.transitions.map(tr => [tr.from, tr.to, tr.completedHole])).toEqual([
+      [null, 1, null],
+      [1, 2, 1],
+      [2, 3, 2],
+    ]);
+  });
+});
@@ -0,0 +1,142 @@
+// Geofenced hole detection: which hole a player is on from a stream of GPS fixes
+
+import { distanceToLine, distanceYards, type GeoPoint } from './caddieEngine';
+
+export interface DetectionHole {
+  number: number;
+  green: GeoPoint;
+  tee?: GeoPoint; // when known, the whole tee-to-green corridor counts
+}
+
+export interface GpsFix extends GeoPoint {
+  timestamp: number; // ms
+  accuracy?: number; // yards
+}
+
+export interface HoleDetectionConfig {
+  corridorYards?: number; // max distance from a hole's corridor to count as on it (default 50)
+  greenYards?: number; // within this of the green centre counts as reaching the green (default 25)
+  dwellFixes?: number; // consecutive fixes on a new hole before switching (default 3)
+  maxAccuracy?: number; // ignore fixes less accurate than this (default 30 yards)
+  nextHoleBias?: number; // distance multiplier favouring the next hole in sequence (default 0.8)
+}
+
+export interface HoleTransition {
+  from: number | null;
+  to: number;
+  timestamp: number;
+  completedHole: number | null; // `from`, if the player reached its green
+}
+
+export interface HoleTrackerState {
+  currentHole: number | null;
+  reachedGreen: boolean;
+  candidate: number | null;
+  candidateFixes: number;
+  transitions: HoleTransition[];
+}
+
+export function createHoleTracker(startingHole: number | null = null): HoleTrackerState {
+  return {
+    currentHole: startingHole,
+    reachedGreen: false,
+    candidate: null,
+    candidateFixes: 0,
+    transitions: [],
+  };
+}
+
+/**
+ * Hole whose corridor is closest to the fix, or null when off every hole
+ */
+export function nearestHole(
+  holes: DetectionHole[],
+  fix: GeoPoint,
+  currentHole: number | null,
+  config: HoleDetectionConfig = {}
+): number | null {
+  const corridor = config.corridorYards ?? 50;
+  const bias = config.nextHoleBias ?? 0.8;
+  const ordered = [...holes].sort((a, b) => a.number - b.number);
+  const currentIndex = ordered.findIndex(h => h.number === currentHole);
+  const nextHole = currentIndex >= 0 ? ordered[(currentIndex + 1) % ordered.length].number : null;
+
+  let best: number | null = null;
+  let bestScore = Infinity;
+  for (const hole of ordered) {
+    const distance = hole.tee ? distanceToLine(fix, [hole.tee, hole.green]) : distanceYards(fix, hole.green);
+    if (distance > corridor) continue;
+
+    // Where corridors overlap, prefer staying put, then moving on in order
+    const score = hole.number === currentHole ? distance * bias * bias : hole.number === nextHole ? distance * bias : distance;
+    if (score < bestScore) {
+      best = hole.number;
+      bestScore = score;
+    }
+  }
+  return best;
+}
+
+/**
+ * Feed one GPS fix to the tracker. A new hole must hold for `dwellFixes`
+ * consecutive fixes before the tracker switches, so walking past another
+ * hole's tee doesn't advance the scorecard.
+ */
+export function updateHoleTracker(
+  state: HoleTrackerState,
+  holes: DetectionHole[],
+  fix: GpsFix,
+  config: HoleDetectionConfig = {}
+): { state: HoleTrackerState; transition: HoleTransition | null } {
+  if (fix.accuracy !== undefined && fix.accuracy > (config.maxAccuracy ?? 30)) {
+    return { state, transition: null };
+  }
+
+  const current = holes.find(h => h.number === state.currentHole);
+  const reachedGreen = state.reachedGreen ||
+    (!!current && distanceYards(fix, current.green) <= (config.greenYards ?? 25));
+
+  const detected = nearestHole(holes, fix, state.currentHole, config);
+  if (detected === null || detected === state.currentHole) {
+    return { state: { ...state, reachedGreen, candidate: null, candidateFixes: 0 }, transition: null };
+  }
+
+  const candidateFixes = detected === state.candidate ? state.candidateFixes + 1 : 1;
+  if (candidateFixes < (config.dwellFixes ?? 3)) {
+    return { state: { ...state, reachedGreen, candidate: detected, candidateFixes }, transition: null };
+  }
+
+  const transition: HoleTransition = {
+    from: state.currentHole,
+    to: detected,
+    timestamp: fix.timestamp,
+    completedHole: state.currentHole !== null && reachedGreen ? state.currentHole : null,
+  };
+
+  return {
+    state: {
+      currentHole: detected,
+      reachedGreen: distanceYards(fix, holes.find(h => h.number === detected)!.green) <= (config.greenYards ?? 25),
+      candidate: null,
+      candidateFixes: 0,
+      transitions: [...state.transitions, transition],
+    },
+    transition,
+  };
+}
+
+/**
+ * Run a batch of fixes (e.g. uploaded after a round) through a fresh tracker
+ */
+export function detectHoles(
+  holes: DetectionHole[],
+  fixes: GpsFix[],
+  config: HoleDetectionConfig = {},
+  startingHole: number | null = null
+): HoleTrackerState {
+  let state = createHoleTracker(startingHole);
+  for (const fix of [...fixes].sort((a, b) => a.timestamp - b.timestamp)) {
+    state = updateHoleTracker(state, holes, fix, config).state;
+  }
+  return state;
+}
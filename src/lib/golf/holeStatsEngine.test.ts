@@ -0,0 +1,51 @@
+import { describe, it, expect } from 'vitest';
+import { calculateHoleStats, type HoleScoreRecord } from './holeStatsEngine';
+
+describe('Hole Stats Engine', () => {
+  const pars = { 1: 4, 2: 3, 3: 5 };
+
+  const records: HoleScoreRecord[] = [
+    { scores: { 1: 3, 2: 3, 3: 5 }, putts: { 1: 1, 2: 2, 3: 2 } },
+    { scores: { 1: 4, 2: 4, 3: 6 }, putts: { 1: 2, 2: 3, 3: 2 } },
+    { scores: { 1: 4, 2: 3, 3: 4 }, putts: { 1: 2, 2: 2, 3: 1 } },
+    { scores: { 1: 5, 2: 5, 3: 5 }, putts: { 1: 3, 2: 3, 3: 2 } },
+    { scores: { 1: 6, 2: 3 } }
+  ];
+
+  it('should aggregate scoring rates per hole', () => {
+    const stats = calculateHoleStats(records, pars, { minSamples: 4 });
+    const hole1 = stats.find(s => s.hole === 1)!;
+
+    expect(hole1.samples).toBe(5);
+    expect(hole1.averageScore).toBe(4.4);
+    expect(hole1.averageToPar).toBe(0.4);
+    expect(hole1.birdieRate).toBe(0.2);
+    expect(hole1.parRate).toBe(0.4);
+    expect(hole1.bogeyRate).toBe(0.2);
+    expect(hole1.doubleBogeyOrWorseRate).toBe(0.2);
+  });
+
+  it('should calculate putting stats from holes with putts recorded', () => {
+    const stats = calculateHoleStats(records, pars, { minSamples: 4 });
+    const hole2 = stats.find(s => s.hole === 2)!;
+
+    expect(hole2.puttSamples).toBe(4);
+    expect(hole2.averagePutts).toBe(2.5);
+    expect(hole2.threePuttRate).toBe(0.5);
+  });
+
+  it('should rank holes by how they play against par', () => {
+    const stats = calculateHoleStats(records, pars, { minSamples: 4 });
+
+    // Hole 2 averages +0.6, hole 1 +0.4, hole 3 0
+    expect(stats.map(s => [s.hole, s.difficultyRank])).toEqual([[1, 2], [2, 1], [3, 3]]);
+  });
+
+  it('should withhold holes below the sample threshold', () => {
+    const stats = calculateHoleStats(records, pars, { minSamples: 5 });
+
+    expect(stats.map(s => s.hole)).toEqual([1, 2]);
+    // Only 4 putt samples: putting stats are withheld too
+    expect(stats[0].averagePutts).toBeNull();
+  });
+});
@@ -0,0 +1,95 @@
+// Per-hole scoring statistics for a course, aggregated from submitted scores
+
+export interface HoleScoreRecord {
+  scores: { [hole: number]: number };
+  putts?: { [hole: number]: number };
+}
+
+export interface HoleStats {
+  hole: number;
+  par: number;
+  samples: number;
+  averageScore: number;
+  averageToPar: number;
+  eagleOrBetterRate: number;
+  birdieRate: number;
+  parRate: number;
+  bogeyRate: number;
+  doubleBogeyOrWorseRate: number;
+  puttSamples: number;
+  averagePutts: number | null;
+  threePuttRate: number | null; // share of holes with 3 or more putts
+  difficultyRank: number; // 1 = plays hardest relative to par
+}
+
+export interface HoleStatsConfig {
+  // Holes with fewer samples are withheld so individual scores can't be picked out
+  minSamples?: number; // default 5
+}
+
+function rate(count: number, total: number): number {
+  return total > 0 ? Math.round((count / total) * 1000) / 1000 : 0;
+}
+
+function round2(value: number): number {
+  return Math.round(value * 100) / 100;
+}
+
+/**
+ * Aggregate scoring data per hole. Only totals leave this function, never
+ * individual scores, and holes below the sample threshold are omitted.
+ */
+export function calculateHoleStats(
+  records: HoleScoreRecord[],
+  pars: { [hole: number]: number },
+  config: HoleStatsConfig = {}
+): HoleStats[] {
+  const minSamples = config.minSamples ?? 5;
+  const stats: Omit<HoleStats, 'difficultyRank'>[] = [];
+
+  for (const [holeKey, par] of Object.entries(pars)) {
+    const hole = Number(holeKey);
+    const scores: number[] = [];
+    const putts: number[] = [];
+
+    for (const record of records) {
+      const strokes = record.scores[hole];
+      if (!strokes || strokes <= 0) continue;
+      scores.push(strokes);
+
+      const holePutts = record.putts?.[hole];
+      if (typeof holePutts === 'number' && holePutts >= 0 && holePutts <= strokes) {
+        putts.push(holePutts);
+      }
+    }
+
+    if (scores.length < minSamples) continue;
+
+    const count = (predicate: (toPar: number) => boolean) => scores.filter(s => predicate(s - par)).length;
+    const averageScore = scores.reduce((sum, s) => sum + s, 0) / scores.length;
+    const hasPutts = putts.length >= minSamples;
+
+    stats.push({
+      hole,
+      par,
+      samples: scores.length,
+      averageScore: round2(averageScore),
+      averageToPar: round2(averageScore - par),
+      eagleOrBetterRate: rate(count(d => d <= -2), scores.length),
+      birdieRate: rate(count(d => d === -1), scores.length),
+      parRate: rate(count(d => d === 0), scores.length),
+      bogeyRate: rate(count(d => d === 1), scores.length),
+      doubleBogeyOrWorseRate: rate(count(d => d >= 2), scores.length),
+      puttSamples: putts.length,
+      averagePutts: hasPutts ? round2(putts.reduce((sum, p) => sum + p, 0) / putts.length) : null,
+      threePuttRate: hasPutts ? rate(putts.filter(p => p >= 3).length, putts.length) : null,
+    });
+  }
+
+  const ranked = [...stats].sort((a, b) => b.averageToPar - a.averageToPar || a.hole - b.hole);
+  const rankOf = new Map(ranked.map((s, i) => [s.hole, i + 1]));
+
+  return stats
+    .sort((a, b) => a.hole - b.hole)
+    .map(s => ({ ...s, difficultyRank: rankOf.get(s.hole)! }));
+}
@@ -0,0 +1,64 @@
+// iCalendar (RFC 5545) export for course notices, lessons and other dated events
+
+export interface CalendarEntry {
+  uid: string; // globally unique, stable across exports so calendars update in place
+  startsAt: number; // ms
+  endsAt: number; // ms
+  summary: string;
+  location?: string;
+  description?: string;
+  cancelled?: boolean;
+}
+
+function icsDate(ms: number): string {
+  return new Date(ms).toISOString().replace(/[-:]/g, '').replace(/\.\d{3}/, '');
+}
+
+function icsText(value: string): string {
+  return value.replace(/\\/g, '\\\\').replace(/;/g, '\\;').replace(/,/g, '\\,').replace(/\r?\n/g, '\\n');
+}
+
+/**
+ * An iCalendar feed of the entries. Cancelled entries are included as
+ * cancelled events so calendars drop them.
+ */
+export function toIcs(entries: CalendarEntry[], calendarName: string, product: string, now = Date.now()): string {
+  const lines = [
+    'BEGIN:VCALENDAR',
+    'VERSION:2.0',
+    `PRODID:-//Pinseekr//${product}//EN`,
+    'CALSCALE:GREGORIAN',
+    `X-WR-CALNAME:${icsText(calendarName)}`,
+  ];
+
+  for (const entry of entries) {
+    lines.push(
+      'BEGIN:VEVENT',
+      `UID:${entry.uid}`,
+      `DTSTAMP:${icsDate(now)}`,
+      `DTSTART:${icsDate(entry.startsAt)}`,
+      `DTEND:${icsDate(entry.endsAt)}`,
+      `SUMMARY:${icsText(entry.summary)}`,
+      ...(entry.location ? [`LOCATION:${icsText(entry.location)}`] : []),
+      ...(entry.description ? [`DESCRIPTION:${icsText(entry.description)}`] : []),
+      `STATUS:${entry.cancelled ? 'CANCELLED' : 'CONFIRMED'}`,
+      'END:VEVENT',
+    );
+  }
+
+  lines.push('END:VCALENDAR');
+  return lines.join('\r\n') + '\r\n';
+}
+
+/** Save an iCalendar feed as a file download */
+export function downloadIcs(ics: string, filename: string): void {
+  const blob = new Blob([ics], { type: 'text/calendar' });
+  const url = URL.createObjectURL(blob);
+  const a = document.createElement('a');
+  a.href = url;
+  a.download = filename;
+  document.body.appendChild(a);
+  a.click();
+  document.body.removeChild(a);
+  URL.revokeObjectURL(url);
+}
@@ -0,0 +1,63 @@
+import { describe, it, expect } from 'vitest';
+import { checkRoundEvidence, holdForReview, needsReview } from './integrityEngine';
+import type { RoundDifferential } from './handicapCalculator';
+
+describe('Integrity Engine', () => {
+  const minute = 60 * 1000;
+  const green = { lat: 40.0, lon: -105.0 };
+
+  it('should flag rounds scored too quickly', () => {
+    const flags = checkRoundEvidence({
+      holesPlayed: 18,
+      startedAt: 0,
+      finishedAt: 60 * minute,
+      track: [green],
+      course: [green],
+    });
+
+    expect(flags.map(f => f.check)).toEqual(['too-fast']);
+    expect(flags[0].message).toBe('18 holes scored in 60 minutes');
+    expect(checkRoundEvidence({ holesPlayed: 18, startedAt: 0, finishedAt: 240 * minute, track: [green], course: [green] })).toEqual([]);
+  });
+
+  it('should note a missing GPS track, holding it only when required', () => {
+    const evidence = { holesPlayed: 18, track: [], course: [green] };
+
+    expect(checkRoundEvidence(evidence)[0]).toMatchObject({ check: 'no-gps', severity: 'info' });
+    expect(checkRoundEvidence(evidence, { requireGps: true })[0].severity).toBe('review');
+  });
+
+  it('should flag a GPS track away from the course', () => {
+    const elsewhere = { lat: 41.0, lon: -105.0 }; // about 69 miles north
+    const flags = checkRoundEvidence({ holesPlayed: 18, track: [elsewhere, elsewhere, green], course: [green] });
+
+    expect(flags.map(f => f.check)).toEqual(['off-course']);
+    expect(checkRoundEvidence({ holesPlayed: 18, track: [elsewhere], course: [] })).toEqual([]);
+  });
+
+  const round = (roundId: string, day: number, differential: number): RoundDifferential => ({
+    roundId,
+    date: day * 24 * 60 * minute,
+    gross: 72 + Math.round(differential),
+    courseRating: 72,
+    slope: 113,
+    differential,
+  });
+
+  it('should hold scores wildly below the index at the time', () => {
+    const history = [1, 2, 3, 4, 5].map(day => round(`r${day}`, day, 20));
+    const { accepted, held } = holdForReview([...history, round('low', 6, 5), round('ok', 7, 14)]);
+
+    expect(held.map(r => r.roundId)).toEqual(['low']);
+    expect(held[0].integrityFlags?.[0].check).toBe('below-handicap');
+    expect(accepted.map(r => r.roundId)).toContain('ok');
+  });
+
+  it('should keep info-only flags in the handicap', () => {
+    const quiet = { ...round('r1', 1, 20), integrityFlags: [{ check: 'no-gps' as const, severity: 'info' as const, message: '' }] };
+    const fast = { ...round('r2', 2, 20), integrityFlags: [{ check: 'too-fast' as const, severity: 'review' as const, message: '' }] };
+
+    expect(needsReview(quiet)).toBe(false);
+    expect(holdForReview([quiet, fast]).held.map(r => r.roundId)).toEqual(['r2']);
+  });
+});
@@ -0,0 +1,117 @@
+// Score integrity: plausibility checks that hold suspicious rounds for committee review
+
+import { distanceYards, type GeoPoint } from './caddieEngine';
+import {
+  applyExceptionalScoreReductions,
+  calculateHandicapIndex,
+  type RoundDifferential,
+} from './handicapCalculator';
+
+export type IntegrityCheck = 'too-fast' | 'no-gps' | 'off-course' | 'below-handicap';
+
+export interface IntegrityFlag {
+  check: IntegrityCheck;
+  severity: 'info' | 'review'; // review flags keep the round out of the handicap
+  message: string;
+}
+
+export interface RoundEvidence {
+  holesPlayed: number;
+  startedAt?: number; // ms: tee time, or first recorded shot
+  finishedAt?: number; // ms: last score update
+  track: GeoPoint[]; // GPS fixes recorded during the round
+  course: GeoPoint[]; // known course positions (greens), if any
+}
+
+export interface IntegrityConfig {
+  minMinutesPerHole?: number; // faster than this is implausible (default 5)
+  requireGps?: boolean; // hold rounds without a GPS track (default false: flagged for info only)
+  offCourseYards?: number; // fixes further than this from every course position are off the course (default 1500)
+  belowHandicapStrokes?: number; // differential this far below the index needs review (default 10)
+}
+
+/**
+ * Check a round's evidence: how long it took, and whether the GPS track was on the course
+ */
+export function checkRoundEvidence(evidence: RoundEvidence, config: IntegrityConfig = {}): IntegrityFlag[] {
+  const flags: IntegrityFlag[] = [];
+  const minMinutes = config.minMinutesPerHole ?? 5;
+  const offCourseYards = config.offCourseYards ?? 1500;
+
+  if (evidence.startedAt !== undefined && evidence.finishedAt !== undefined && evidence.holesPlayed > 0) {
+    const minutes = (evidence.finishedAt - evidence.startedAt) / 60000;
+    if (minutes >= 0 && minutes / evidence.holesPlayed < minMinutes) {
+      flags.push({
+        check: 'too-fast',
+        severity: 'review',
+        message: `${evidence.holesPlayed} holes scored in ${Math.round(minutes)} minutes`,
+      });
+    }
+  }
+
+  if (evidence.track.length === 0) {
+    flags.push({
+      check: 'no-gps',
+      severity: config.requireGps ? 'review' : 'info',
+      message: 'No GPS track recorded for this round',
+    });
+  } else if (evidence.course.length > 0) {
+    const offCourse = evidence.track.filter(fix =>
+      Math.min(...evidence.course.map(point => distanceYards(fix, point))) > offCourseYards
+    );
+    if (offCourse.length > evidence.track.length / 2) {
+      flags.push({
+        check: 'off-course',
+        severity: 'review',
+        message: `${offCourse.length} of ${evidence.track.length} GPS positions are away from the course`,
+      });
+    }
+  }
+
+  return flags;
+}
+
+/**
+ * True when a round has a flag that needs a committee decision and the
+ * committee hasn't ruled on it yet
+ */
+export function needsReview(round: RoundDifferential): boolean {
+  if (round.ruling === 'dismissed' || round.ruling === 'corrected') return false;
+  return !!round.integrityFlags?.some(f => f.severity === 'review');
+}
+
+/**
+ * Replay a scoring record in date order, flagging scores wildly below the index
+ * at the time, and split off rounds that need committee review. Held rounds
+ * don't count towards the index used to check later rounds.
+ */
+export function holdForReview(
+  differentials: RoundDifferential[],
+  config: IntegrityConfig = {}
+): { accepted: RoundDifferential[]; held: RoundDifferential[] } {
+  const strokes = config.belowHandicapStrokes ?? 10;
+  const accepted: RoundDifferential[] = [];
+  const held: RoundDifferential[] = [];
+
+  for (const round of [...differentials].sort((a, b) => a.date - b.date)) {
+    const flags = [...(round.integrityFlags ?? [])];
+
+    // Penalty scores and ruled rounds are committee decisions
+    if (round.source !== 'penalty' && !round.ruling) {
+      const before = calculateHandicapIndex(applyExceptionalScoreReductions(accepted).slice(0, 20)).index;
+      if (before !== null && round.differential <= before - strokes) {
+        flags.push({
+          check: 'below-handicap',
+          severity: 'review',
+          message: `Differential ${round.differential.toFixed(1)} is ${(before - round.differential).toFixed(1)} below the index of ${before.toFixed(1)}`,
+        });
+      }
+    }
+
+    const checked = flags.length > 0 ? { ...round, integrityFlags: flags } : round;
+    if (needsReview(checked)) held.push(checked);
+    else accepted.push(checked);
+  }
+
+  return { accepted, held };
+}
@@ -0,0 +1,59 @@
+import { describe, it, expect } from 'vitest';
+import { formatToPar, liveStandings, parseKioskConfig } from './kioskEngine';
+import type { PlayerScoreRecord } from './nostrEvents';
+
+describe('Kiosk Engine', () => {
+  const record = (playerPubkey: string, scores: PlayerScoreRecord['scores'], updatedAt = 1, pars: PlayerScoreRecord['pars'] = {}): PlayerScoreRecord => ({
+    roundId: 'r1',
+    playerPubkey,
+    scores,
+    putts: {},
+    pars,
+    fairways: {},
+    greens: {},
+    penalties: {},
+    updatedAt,
+  });
+
+  it('should rank by score to par, then holes played', () => {
+    const standings = liveStandings([
+      record('alice', { 1: 4, 2: 4 }),
+      record('bob', { 1: 3, 2: 4 }),
+      record('carol', { 1: 3, 2: 4, 3: 4 }),
+    ], [4, 4, 4]);
+    expect(standings.map(s => [s.playerPubkey, s.position, s.toPar, s.thru])).toEqual([
+      ['carol', 1, -1, 3],
+      ['bob', 1, -1, 2],
+      ['alice', 3, 0, 2],
+    ]);
+    expect(standings[0].tied).toBe(true);
+    expect(standings[2].tied).toBe(false);
+  });
+
+  it('should use each player\'s latest card and card pars first', () => {
+    const standings = liveStandings([
+      record('alice', { 1: 6 }, 1),
+      record('alice', { 1: 3 }, 2, { 1: 5 }),
+    ], [4]);
+    expect(standings).toHaveLength(1);
+    expect(standings[0].toPar).toBe(-2);
+  });
+
+  it('should rank players without pars by gross after the rest', () => {
+    const standings = liveStandings([record('alice', { 1: 5 }), record('bob', { 1: 4 }), record('carol', {})]);
+    expect(standings.map(s => [s.playerPubkey, s.toPar, s.gross])).toEqual([['bob', null, 4], ['alice', null, 5]]);
+  });
+
+  it('should format scores to par', () => {
+    expect(formatToPar(0)).toBe('E');
+    expect(formatToPar(-3)).toBe('-3');
+    expect(formatToPar(2)).toBe('+2');
+    expect(formatToPar(null)).toBe('-');
+  });
+
+  it('should read kiosk settings from the URL with defaults and limits', () => {
+    expect(parseKioskConfig(new URLSearchParams())).toEqual({ slides: ['leaderboard', 'highlights', 'sponsors'], rotateSeconds: 20, course: undefined });
+    const config = parseKioskConfig(new URLSearchParams('slides=highlights,bogus,highlights&rotate=1&course=Pebble'));
+    expect(config).toEqual({ slides: ['highlights'], rotateSeconds: 5, course: 'Pebble' });
+  });
+});
@@ -0,0 +1,94 @@
+// Clubhouse TV kiosk: live standings and the slides the display rotates through
+
+import type { PlayerScoreRecord } from './nostrEvents';
+
+export interface LiveStanding {
+  playerPubkey: string;
+  position: number;
+  tied: boolean;
+  toPar: number | null; // over holes with a known par; null if none
+  gross: number;
+  thru: number; // holes scored
+}
+
+export type KioskSlide = 'leaderboard' | 'highlights' | 'sponsors';
+
+export interface KioskConfig {
+  slides: KioskSlide[];
+  rotateSeconds: number;
+  course?: string; // course name or id: pars missing from the cards, and whose sponsors to show
+}
+
+const KIOSK_SLIDES: KioskSlide[] = ['leaderboard', 'highlights', 'sponsors'];
+
+/**
+ * Standings from each player's latest card: best score to par first, then
+ * more holes played. Players without a known par rank by gross after them.
+ */
+export function liveStandings(records: PlayerScoreRecord[], coursePars: number[] = []): LiveStanding[] {
+  const latest = new Map<string, PlayerScoreRecord>();
+  for (const record of records) {
+    const existing = latest.get(record.playerPubkey);
+    if (!existing || record.updatedAt > existing.updatedAt) latest.set(record.playerPubkey, record);
+  }
+
+  const standings = [...latest.values()]
+    .map(record => {
+      const holes = Object.entries(record.scores).map(([hole, strokes]) => ({
+        strokes,
+        par: record.pars[Number(hole)] ?? coursePars[Number(hole) - 1],
+      }));
+      const withPar = holes.filter(h => h.par);
+      return {
+        playerPubkey: record.playerPubkey,
+        position: 0,
+        tied: false,
+        toPar: withPar.length > 0 ? withPar.reduce((sum, h) => sum + h.strokes - h.par, 0) : null,
+        gross: holes.reduce((sum, h) => sum + h.strokes, 0),
+        thru: holes.length,
+      };
+    })
+    .filter(s => s.thru > 0)
+    .sort((a, b) => {
+      if (a.toPar !== null && b.toPar !== null) return a.toPar - b.toPar || b.thru - a.thru;
+      if (a.toPar !== null) return -1;
+      if (b.toPar !== null) return 1;
+      return a.gross - b.gross;
+    });
+
+  const key = (s: LiveStanding) => `${s.toPar ?? 'gross'}:${s.toPar === null ? s.gross : ''}`;
+  standings.forEach((s, i) => {
+    const prev = standings[i - 1];
+    s.position = prev && key(prev) === key(s) ? prev.position : i + 1;
+  });
+  for (const s of standings) s.tied = standings.filter(o => o.position === s.position).length > 1;
+
+  return standings;
+}
+
+/**
+ * Format a score to par the way leaderboards show it: E, -3, +2
+ */
+export function formatToPar(toPar: number | null): string {
+  if (toPar === null) return '-';
+  if (toPar === 0) return 'E';
+  return toPar > 0 ? `+${toPar}` : String(toPar);
+}
+
+/**
+ * Kiosk settings from the display URL, e.g. `?slides=leaderboard&rotate=30&course=Pebble`.
+ * Unknown slides are ignored; the rotation is kept between 5 seconds and 5 minutes.
+ */
+export function parseKioskConfig(params: URLSearchParams): KioskConfig {
+  const requested = (params.get('slides') ?? '')
+    .split(',')
+    .map(s => s.trim())
+    .filter((s): s is KioskSlide => (KIOSK_SLIDES as string[]).includes(s));
+  const rotate = parseInt(params.get('rotate') ?? '');
+
+  return {
+    slides: requested.length > 0 ? [...new Set(requested)] : KIOSK_SLIDES,
+    rotateSeconds: isNaN(rotate) ? 20 : Math.min(300, Math.max(5, rotate)),
+    course: params.get('course') || undefined,
+  };
+}
@@ -0,0 +1,73 @@
+import { describe, it, expect } from 'vitest';
+import { canonicalClub, launchMonitorSession, matchClub, parseLaunchMonitorCsv } from './launchMonitorImport';
+import { DEFAULT_BAG } from './bagEngine';
+
+describe('Launch Monitor Import', () => {
+  it('should canonicalise club names', () => {
+    expect(canonicalClub('7 Iron')).toBe('7i');
+    expect(canonicalClub('Iron 7')).toBe('7i');
+    expect(canonicalClub('3-Wood')).toBe('3w');
+    expect(canonicalClub('Driver')).toBe('dr');
+    expect(canonicalClub('Pitching Wedge')).toBe('pw');
+    expect(canonicalClub('56°')).toBe('56');
+    expect(canonicalClub('Chipper')).toBeNull();
+    expect(matchClub('4 Hybrid', DEFAULT_BAG)).toBe('4h');
+    expect(matchClub('60°', DEFAULT_BAG)).toBeNull();
+  });
+
+  it('should parse a Garmin R10 export with a units row', () => {
+    const csv = [
+      'Date,Club Name,Club Type,Ball Speed,Launch Angle,Spin Rate,Carry Distance,Carry Deviation Distance,Total Distance',
+      ',,,[km/h],[deg],[rpm],[m],[m],[m]',
+      '2024-05-01 10:00:00,7 Iron,7 Iron,180,17.2,6500,140,-3,150',
+      '2024-05-01 10:30:00,Driver,Driver,240,12,2600,200,5,220',
+    ].join('\n');
+
+    const result = parseLaunchMonitorCsv(csv, DEFAULT_BAG);
+
+    expect(result.format).toBe('garmin-r10');
+    expect(result.errors).toEqual([]);
+    expect(result.shots).toHaveLength(2);
+    expect(result.shots[0].clubId).toBe('7i');
+    expect(result.shots[0].carry).toBe(153.1);
+    expect(result.shots[0].offline).toBe(-3.3);
+    expect(result.shots[0].ballSpeed).toBe(111.8);
+    expect(result.shots[0].spin).toBe(6500);
+    expect(result.shots[1].clubId).toBe('dr');
+  });
+
+  it('should parse a Mevo export with sides and unmatched clubs', () => {
+    const csv = [
+      'Club,Ball (mph),Club (mph),Carry (yds),Total (yds),Lateral (yds),Spin (rpm),Launch V (°)',
+      '8i,105,80,146,152,4.2 R,7100,19',
+      '8i,103,79,141,147,2.0 L,7300,20',
+      'Chipper,40,30,20,30,0,3000,30',
+    ].join('\n');
+
+    const result = parseLaunchMonitorCsv(csv, DEFAULT_BAG);
+
+    expect(result.format).toBe('mevo');
+    expect(result.shots.map(s => s.offline)).toEqual([4.2, -2, 0]);
+    expect(result.shots[0].ballSpeed).toBe(105);
+    expect(result.shots[0].launchAngle).toBe(19);
+    expect(result.unmatchedClubs).toEqual(['Chipper']);
+  });
+
+  it('should build a practice session from matched shots', () => {
+    const start = Date.parse('2024-05-01T10:00:00Z');
+    const session = launchMonitorSession([
+      { club: '7 Iron', clubId: '7i', carry: 150, timestamp: start },
+      { club: '7 Iron', clubId: '7i', carry: 155, timestamp: start + 20 * 60000 },
+      { club: 'Chipper', clubId: null, carry: 20, timestamp: start + 30 * 60000 },
+    ], 'lm-1', 'simulator');
+
+    expect(session.type).toBe('simulator');
+    expect(session.date).toBe(start);
+    expect(session.durationMinutes).toBe(30);
+    expect(session.blocks).toEqual([{ clubId: '7i', balls: 2, shots: [{ carry: 150 }, { carry: 155 }] }]);
+  });
+
+  it('should report a missing club column', () => {
+    expect(parseLaunchMonitorCsv('Carry,Total\n150,160', DEFAULT_BAG).errors).toEqual(['No club column found']);
+  });
+});
@@ -0,0 +1,225 @@
+// Launch monitor CSV import (Garmin R10, FlightScope Mevo/Mevo+ and similar exports)
+
+import { parseCsv } from './courseImport';
+import type { BagClub } from './bagEngine';
+import type { PracticeBlock, PracticeSession, PracticeShot, PracticeType } from './practiceEngine';
+
+export type LaunchMonitorFormat = 'garmin-r10' | 'mevo' | 'generic';
+
+export interface LaunchMonitorShot extends PracticeShot {
+  club: string; // club as named in the export
+  clubId: string | null; // matching club in the player's bag
+  timestamp?: number; // ms
+}
+
+export interface LaunchMonitorImport {
+  format: LaunchMonitorFormat;
+  shots: LaunchMonitorShot[];
+  unmatchedClubs: string[]; // export club names with no club in the bag
+  errors: string[];
+}
+
+const YARDS_PER_METRE = 1.09361;
+
+// Header aliases in priority order; headers are compared without their units
+const COLUMNS = {
+  club: ['club type', 'club', 'club name'],
+  carry: ['carry distance', 'carry'],
+  total: ['total distance', 'total'],
+  offline: ['carry deviation distance', 'lateral', 'offline', 'side', 'carry side'],
+  ballSpeed: ['ball speed', 'ball'],
+  launchAngle: ['launch angle', 'launch v', 'vertical launch'],
+  spin: ['spin rate', 'spin', 'backspin', 'total spin'],
+  date: ['date', 'time', 'timestamp'],
+} as const;
+
+type Column = keyof typeof COLUMNS;
+
+const NAMED_CLUBS: { [name: string]: string } = {
+  dr: 'dr', driver: 'dr', '1w': 'dr', '1 wood': 'dr',
+  pt: 'pt', putter: 'pt',
+  pw: 'pw', 'pitching wedge': 'pw', p: 'pw',
+  gw: 'gw', 'gap wedge': 'gw', aw: 'gw', 'approach wedge': 'gw', uw: 'gw',
+  sw: 'sw', 'sand wedge': 'sw',
+  lw: 'lw', 'lob wedge': 'lw',
+};
+
+const CLUB_SUFFIXES: { [word: string]: string } = {
+  i: 'i', iron: 'i',
+  w: 'w', wood: 'w',
+  h: 'h', hy: 'h', hybrid: 'h',
+};
+
+/**
+ * Canonical club id for a club name: "7 Iron", "7i" and "Iron 7" all give "7i";
+ * wedges given by loft ("56°") give the loft.
+ */
+export function canonicalClub(name: string): string | null {
+  const s = name.toLowerCase().replace(/[°º]/g, '').replace(/[-_]/g, ' ').replace(/\s+/g, ' ').trim();
+  if (NAMED_CLUBS[s]) return NAMED_CLUBS[s];
+
+  const loft = s.match(/^(\d{2}) ?(deg|degree)?( wedge)?$/);
+  if (loft) return loft[1];
+
+  const numbered = s.match(/^(\d{1,2}) ?([a-z]+)$/);
+  const reversed = s.match(/^([a-z]+) ?(\d{1,2})$/);
+  const [number, word] = numbered ? [numbered[1], numbered[2]] : reversed ? [reversed[2], reversed[1]] : [];
+  if (number && word && CLUB_SUFFIXES[word]) return `${parseInt(number)}${CLUB_SUFFIXES[word]}`;
+  return null;
+}
+
+/**
+ * Find the bag club for an exported club name
+ */
+export function matchClub(name: string, bag: BagClub[]): string | null {
+  const key = canonicalClub(name);
+  if (!key) return null;
+  return bag.find(c => c.id.toLowerCase() === key || canonicalClub(c.id) === key || canonicalClub(c.label) === key)?.id ?? null;
+}
+
+function splitHeader(header: string): { name: string; unit: string } {
+  const match = header.match(/^(.*?)\s*[([]\s*(.*?)\s*[)\]]\s*$/);
+  return match
+    ? { name: match[1].toLowerCase().trim(), unit: match[2].toLowerCase() }
+    : { name: header.toLowerCase().trim(), unit: '' };
+}
+
+function distanceFactor(unit: string): number {
+  return /^(m|meters?|metres?)$/.test(unit) ? YARDS_PER_METRE : 1;
+}
+
+function speedFactor(unit: string): number {
+  if (/^(km\/h|kph|kmh)$/.test(unit)) return 0.621371;
+  if (unit === 'm/s') return 2.23694;
+  return 1;
+}
+
+// Numbers may carry a side ("5.2 R", "L 3.1"): left is negative
+function parseValue(value: string | undefined): number | undefined {
+  if (!value) return undefined;
+  const n = parseFloat(value.replace(/[^\d.-]/g, ''));
+  if (isNaN(n)) return undefined;
+  return /\bl\b/i.test(value) ? -Math.abs(n) : n;
+}
+
+export function detectLaunchMonitorFormat(headers: string[]): LaunchMonitorFormat {
+  const names = headers.map(h => splitHeader(h).name);
+  if (names.includes('club type') && names.includes('carry deviation distance')) return 'garmin-r10';
+  if (names.includes('lateral') || (names.includes('ball') && names.includes('carry'))) return 'mevo';
+  return 'generic';
+}
+
+/**
+ * Parse a launch monitor CSV export into shots in yards and mph, matched to
+ * the player's bag. Units come from the header ("Carry (m)") or, for Garmin
+ * exports, from a units row under the header ("[m]").
+ */
+export function parseLaunchMonitorCsv(text: string, bag: BagClub[]): LaunchMonitorImport {
+  const rows = parseCsv(text);
+  const errors: string[] = [];
+  if (rows.length < 2) {
+    return { format: 'generic', shots: [], unmatchedClubs: [], errors: ['No shots found'] };
+  }
+
+  const headers = rows[0].map(splitHeader);
+  let dataRows = rows.slice(1);
+  let firstRow = 2; // spreadsheet row number of the first shot
+
+  // Garmin puts units in the row below the header
+  const unitsRow = dataRows[0].every(v => v === '' || /^\[.*\]$/.test(v)) ? dataRows[0] : null;
+  if (unitsRow) {
+    unitsRow.forEach((v, i) => {
+      if (headers[i] && v) headers[i].unit = v.slice(1, -1).toLowerCase();
+    });
+    dataRows = dataRows.slice(1);
+    firstRow++;
+  }
+
+  const columns = {} as { [column in Column]?: number };
+  for (const column of Object.keys(COLUMNS) as Column[]) {
+    for (const alias of COLUMNS[column]) {
+      // The club column is a name, never a speed ("Club (mph)")
+      const index = headers.findIndex(h => h.name === alias && (column !== 'club' || h.unit === ''));
+      if (index !== -1) {
+        columns[column] = index;
+        break;
+      }
+    }
+  }
+
+  if (columns.club === undefined) {
+    return { format: 'generic', shots: [], unmatchedClubs: [], errors: ['No club column found'] };
+  }
+  if (columns.carry === undefined) errors.push('No carry column found');
+
+  const unit = (column: Column) => (columns[column] !== undefined ? headers[columns[column]!].unit : '');
+  const value = (row: string[], column: Column) =>
+    columns[column] !== undefined ? parseValue(row[columns[column]!]) : undefined;
+  const distance = (row: string[], column: Column) => {
+    const v = value(row, column);
+    return v === undefined ? undefined : Math.round(v * distanceFactor(unit(column)) * 10) / 10;
+  };
+
+  const shots: LaunchMonitorShot[] = [];
+  const unmatched = new Set<string>();
+
+  dataRows.forEach((row, index) => {
+    const club = row[columns.club!];
+    if (!club) {
+      errors.push(`Row ${index + firstRow}: missing club`);
+      return;
+    }
+
+    const clubId = matchClub(club, bag);
+    if (!clubId) unmatched.add(club);
+
+    const ballSpeed = value(row, 'ballSpeed');
+    const date = columns.date !== undefined ? Date.parse(row[columns.date]) : NaN;
+
+    shots.push({
+      club,
+      clubId,
+      carry: distance(row, 'carry'),
+      total: distance(row, 'total'),
+      offline: distance(row, 'offline'),
+      ballSpeed: ballSpeed === undefined ? undefined : Math.round(ballSpeed * speedFactor(unit('ballSpeed')) * 10) / 10,
+      launchAngle: value(row, 'launchAngle'),
+      spin: value(row, 'spin'),
+      timestamp: isNaN(date) ? undefined : date,
+    });
+  });
+
+  return {
+    format: detectLaunchMonitorFormat(rows[0]),
+    shots,
+    unmatchedClubs: [...unmatched],
+    errors,
+  };
+}
+
+/**
+ * Practice session from imported shots, one block per club. Shots that
+ * don't match a club in the bag are left out.
+ */
+export function launchMonitorSession(
+  shots: LaunchMonitorShot[],
+  sessionId: string,
+  type: PracticeType = 'range'
+): PracticeSession {
+  const blocks = new Map<string, PracticeBlock>();
+  for (const { club, clubId, timestamp, ...shot } of shots) {
+    if (!clubId) continue;
+    const block = blocks.get(clubId) ?? { clubId, balls: 0, shots: [] };
+    block.balls++;
+    block.shots!.push(shot);
+    blocks.set(clubId, block);
+  }
+
+  const timestamps = shots.map(s => s.timestamp).filter((t): t is number => t !== undefined);
+  const date = timestamps.length > 0 ? Math.min(...timestamps) : Date.now();
+  const durationMinutes = timestamps.length > 1
+    ? Math.round((Math.max(...timestamps) - date) / 60000)
+    : undefined;
+
+  return { sessionId, date, type, durationMinutes, blocks: [...blocks.values()] };
+}
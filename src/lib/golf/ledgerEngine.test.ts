@@ -0,0 +1,117 @@
+import { describe, it, expect } from 'vitest';
+import {
+  LEDGER_ACCOUNTS,
+  accountBalance,
+  entriesInPeriod,
+  payoutEntries,
+  rentalEntries,
+  toLedgerCsv,
+  toLedgerJournal,
+  voucherEntries,
+} from './ledgerEngine';
+import type { Voucher, VoucherRedemption } from './voucherEngine';
+import type { RentalInventory, RentalReservation } from './rentalEngine';
+
+const DAY = 24 * 60 * 60 * 1000;
+// Midday, so local dates match whatever the timezone
+const start = new Date(2026, 5, 1, 12).getTime();
+
+function voucher(overrides: Partial<Voucher> = {}): Voucher {
+  return {
+    voucherId: 'v1',
+    courseId: 'oak',
+    courseAuthor: 'club',
+    kind: 'value',
+    amount: 100,
+    currency: 'GBP',
+    recipient: 'Sam',
+    message: '',
+    expiresAt: start + 60 * DAY,
+    codeHash: 'abc',
+    voided: false,
+    issuedAt: start,
+    createdAt: start,
+    ...overrides,
+  };
+}
+
+const redemption = (amount: number, day: number): VoucherRedemption => ({
+  redemptionId: `r${day}`,
+  voucherId: 'v1',
+  courseId: 'oak',
+  courseAuthor: 'club',
+  amount,
+  note: 'Green fee',
+  createdAt: start + day * DAY,
+});
+
+describe('Ledger Engine', () => {
+  it('should carry a voucher as a liability until it is used or expires', () => {
+    const entries = voucherEntries([voucher()], [redemption(30, 10)]);
+    expect(entries.map(e => e.description)).toEqual(['Gift voucher sold for Sam', 'Gift voucher redeemed: Green fee', 'Gift voucher expired for Sam']);
+    expect(entries[2].postings).toEqual([
+      { account: LEDGER_ACCOUNTS.vouchers, amount: 70, currency: 'GBP' },
+      { account: LEDGER_ACCOUNTS.expired, amount: -70, currency: 'GBP' },
+    ]);
+
+    expect(accountBalance(entries, LEDGER_ACCOUNTS.vouchers, start + 20 * DAY)).toEqual({ GBP: -70 });
+    expect(accountBalance(entries, LEDGER_ACCOUNTS.vouchers, start + 61 * DAY)).toEqual({ GBP: 0 });
+  });
+
+  it('should refund cancelled vouchers and leave out round vouchers', () => {
+    const cancelled = voucher({ voided: true, createdAt: start + 5 * DAY });
+    const rounds = voucher({ voucherId: 'v2', kind: 'rounds', amount: 2 });
+    const entries = voucherEntries([cancelled, rounds], []);
+    expect(entries).toHaveLength(2);
+    expect(entries[1]).toMatchObject({ date: start + 5 * DAY, description: 'Gift voucher cancelled for Sam' });
+    expect(entries[1].postings[1]).toEqual({ account: LEDGER_ACCOUNTS.proShop, amount: -100, currency: 'GBP' });
+  });
+
+  it('should book confirmed rentals and prize payouts', () => {
+    const inventory: RentalInventory = {
+      courseId: 'oak', courseAuthor: 'club', currency: 'EUR', createdAt: 0,
+      items: [{ id: 'buggy', type: 'buggy', name: 'Buggy', count: 1, price: 30 }],
+    };
+    const reservation = (id: string, quantity: number, createdAt: number): RentalReservation => ({
+      reservationId: id, courseId: 'oak', courseAuthor: 'club', renter: id, itemId: 'buggy', quantity,
+      startsAt: start, endsAt: start + 5 * 60 * 60 * 1000, cancelled: false, createdAt,
+    });
+
+    // Only one buggy, so the second reservation is never confirmed
+    const rentals = rentalEntries(inventory, [reservation('a', 1, 1), reservation('b', 1, 2)]);
+    expect(rentals).toHaveLength(1);
+    expect(rentals[0].postings[1]).toEqual({ account: LEDGER_ACCOUNTS.rentals, amount: -30, currency: 'EUR' });
+
+    const payouts = payoutEntries([{ prizeId: 'p1', recipient: 'alice', amountSats: 2100, paidAt: start }]);
+    expect(payouts[0].postings[0]).toEqual({ account: LEDGER_ACCOUNTS.prizes, amount: 2100, currency: 'sats' });
+  });
+
+  it('should keep only entries in the period, oldest first', () => {
+    const entries = voucherEntries([voucher()], [redemption(30, 10), redemption(20, 40)]);
+    const june = entriesInPeriod(entries, start, start + 30 * DAY);
+    expect(june.map(e => e.reference)).toEqual(['v1', 'r10']);
+  });
+
+  it('should write a ledger journal and a CSV', () => {
+    const entries = voucherEntries([voucher({ recipient: 'Sam, "Birdie" Jones' })], [redemption(30, 10)]).slice(0, 2);
+
+    expect(toLedgerJournal(entries)).toBe([
+      '2026/06/01 Gift voucher sold for Sam, "Birdie" Jones',
+      '    ; ref: v1',
+      '    Assets:Pro Shop  100.00 GBP',
+      '    Liabilities:Gift Vouchers  -100.00 GBP',
+      '',
+      '2026/06/11 Gift voucher redeemed: Green fee',
+      '    ; ref: r10',
+      '    Liabilities:Gift Vouchers  30.00 GBP',
+      '    Income:Voucher Redemptions  -30.00 GBP',
+      '',
+    ].join('\n'));
+
+    const rows = toLedgerCsv(entries).split('\r\n');
+    expect(rows[0]).toBe('Date,Reference,Description,Account,Debit,Credit,Currency');
+    expect(rows[1]).toBe('2026-06-01,v1,"Gift voucher sold for Sam, ""Birdie"" Jones",Assets:Pro Shop,100.00,,GBP');
+    expect(rows[2]).toBe('2026-06-01,v1,"Gift voucher sold for Sam, ""Birdie"" Jones",Liabilities:Gift Vouchers,,100.00,GBP');
+    expect(rows).toHaveLength(6);
+  });
+});
@@ -0,0 +1,171 @@
+// Club accounts: double-entry journal entries for voucher liabilities, pro
+// shop takings and prize payouts, exported for the treasurer's software
+
+import { confirmedReservations, type RentalInventory, type RentalReservation } from './rentalEngine';
+import { voucherBalance, voucherRedemptions, type Voucher, type VoucherRedemption } from './voucherEngine';
+import type { PayoutRecord } from './payoutEngine';
+
+export const LEDGER_ACCOUNTS = {
+  proShop: 'Assets:Pro Shop',
+  clubWallet: 'Assets:Club Wallet',
+  vouchers: 'Liabilities:Gift Vouchers',
+  rentals: 'Income:Rentals',
+  redeemed: 'Income:Voucher Redemptions',
+  expired: 'Income:Expired Vouchers',
+  prizes: 'Expenses:Prizes',
+} as const;
+
+export interface Posting {
+  account: string;
+  amount: number; // debit positive, credit negative
+  currency: string;
+}
+
+export interface LedgerEntry {
+  date: number; // ms
+  description: string;
+  reference: string; // id of the voucher, redemption, reservation or prize
+  postings: Posting[];
+}
+
+function transfer(date: number, description: string, reference: string, debit: string, credit: string, amount: number, currency: string): LedgerEntry {
+  return {
+    date,
+    description,
+    reference,
+    postings: [
+      { account: debit, amount, currency },
+      { account: credit, amount: -amount, currency },
+    ],
+  };
+}
+
+/**
+ * Vouchers sold, redeemed, cancelled and expired. Selling a voucher takes
+ * money at the pro shop and owes it back as play; whatever is left when it
+ * expires becomes income, and a cancelled voucher is refunded. Round
+ * vouchers have no money value, so they stay out of the accounts.
+ */
+export function voucherEntries(vouchers: Voucher[], redemptions: VoucherRedemption[]): LedgerEntry[] {
+  const entries: LedgerEntry[] = [];
+
+  for (const voucher of vouchers) {
+    if (voucher.kind !== 'value') continue;
+    const { currency } = voucher;
+    const who = voucher.recipient ? ` for ${voucher.recipient}` : '';
+
+    entries.push(transfer(voucher.issuedAt, `Gift voucher sold${who}`, voucher.voucherId, LEDGER_ACCOUNTS.proShop, LEDGER_ACCOUNTS.vouchers, voucher.amount, currency));
+
+    for (const r of voucherRedemptions(voucher, redemptions)) {
+      entries.push(transfer(r.createdAt, `Gift voucher redeemed${r.note ? `: ${r.note}` : ''}`, r.redemptionId, LEDGER_ACCOUNTS.vouchers, LEDGER_ACCOUNTS.redeemed, r.amount, currency));
+    }
+
+    const left = voucherBalance(voucher, redemptions);
+    if (left <= 0) continue;
+    if (voucher.voided && voucher.createdAt < voucher.expiresAt) {
+      entries.push(transfer(voucher.createdAt, `Gift voucher cancelled${who}`, voucher.voucherId, LEDGER_ACCOUNTS.vouchers, LEDGER_ACCOUNTS.proShop, left, currency));
+    } else {
+      entries.push(transfer(voucher.expiresAt, `Gift voucher expired${who}`, voucher.voucherId, LEDGER_ACCOUNTS.vouchers, LEDGER_ACCOUNTS.expired, left, currency));
+    }
+  }
+
+  return entries;
+}
+
+/**
+ * Equipment hire collected at the pro shop, on the day each confirmed
+ * reservation starts, at the inventory's current prices
+ */
+export function rentalEntries(inventory: RentalInventory | null, reservations: RentalReservation[]): LedgerEntry[] {
+  if (!inventory) return [];
+  return confirmedReservations(inventory.items, reservations).flatMap(r => {
+    const item = inventory.items.find(i => i.id === r.itemId)!;
+    const amount = item.price * r.quantity;
+    if (amount <= 0) return [];
+    const description = r.quantity > 1 ? `${item.name} hire x${r.quantity}` : `${item.name} hire`;
+    return [transfer(r.startsAt, description, r.reservationId, LEDGER_ACCOUNTS.proShop, LEDGER_ACCOUNTS.rentals, amount, inventory.currency)];
+  });
+}
+
+/** Prizes paid from the club wallet, in sats */
+export function payoutEntries(history: PayoutRecord[]): LedgerEntry[] {
+  return history.map(p => transfer(p.paidAt, 'Prize payout', p.prizeId, LEDGER_ACCOUNTS.prizes, LEDGER_ACCOUNTS.clubWallet, p.amountSats, 'sats'));
+}
+
+/** Entries dated within [from, to), oldest first */
+export function entriesInPeriod(entries: LedgerEntry[], from: number, to: number): LedgerEntry[] {
+  return entries
+    .filter(e => e.date >= from && e.date < to)
+    .sort((a, b) => a.date - b.date || a.reference.localeCompare(b.reference));
+}
+
+/** An account's balance by currency from every entry before `before` */
+export function accountBalance(entries: LedgerEntry[], account: string, before: number): Record<string, number> {
+  const balance: Record<string, number> = {};
+  for (const entry of entries) {
+    if (entry.date >= before) continue;
+    for (const p of entry.postings) {
+      if (p.account === account) balance[p.currency] = (balance[p.currency] ?? 0) + p.amount;
+    }
+  }
+  for (const currency of Object.keys(balance)) balance[currency] = round(balance[currency]);
+  return balance;
+}
+
+function round(amount: number): number {
+  return Math.round(amount * 100) / 100;
+}
+
+function formatAmount(amount: number, currency: string): string {
+  return currency === 'sats' ? String(Math.round(amount)) : amount.toFixed(2);
+}
+
+function localDate(ms: number, separator: string): string {
+  const d = new Date(ms);
+  return [d.getFullYear(), String(d.getMonth() + 1).padStart(2, '0'), String(d.getDate()).padStart(2, '0')].join(separator);
+}
+
+/** A ledger-cli journal, one transaction per entry */
+export function toLedgerJournal(entries: LedgerEntry[]): string {
+  return entries.map(entry => [
+    `${localDate(entry.date, '/')} ${entry.description.replace(/\r?\n/g, ' ')}`,
+    `    ; ref: ${entry.reference}`,
+    ...entry.postings.map(p => `    ${p.account}  ${formatAmount(p.amount, p.currency)} ${p.currency}`),
+  ].join('\n')).join('\n\n') + '\n';
+}
+
+function csvField(value: string): string {
+  return /[",\r\n]/.test(value) ? `"${value.replace(/"/g, '""')}"` : value;
+}
+
+/** A CSV with one row per posting, debits and credits in separate columns */
+export function toLedgerCsv(entries: LedgerEntry[]): string {
+  const rows = [['Date', 'Reference', 'Description', 'Account', 'Debit', 'Credit', 'Currency']];
+  for (const entry of entries) {
+    for (const p of entry.postings) {
+      rows.push([
+        localDate(entry.date, '-'),
+        entry.reference,
+        entry.description,
+        p.account,
+        p.amount > 0 ? formatAmount(p.amount, p.currency) : '',
+        p.amount < 0 ? formatAmount(-p.amount, p.currency) : '',
+        p.currency,
+      ]);
+    }
+  }
+  return rows.map(row => row.map(csvField).join(',')).join('\r\n') + '\r\n';
+}
+
+/** Save an export as a file download */
+export function downloadLedger(text: string, filename: string, type: string): void {
+  const blob = new Blob([text], { type });
+  const url = URL.createObjectURL(blob);
+  const a = document.createElement('a');
+  a.href = url;
+  a.download = filename;
+  document.body.appendChild(a);
+  a.click();
+  document.body.removeChild(a);
+  URL.revokeObjectURL(url);
+}
@@ -0,0 +1,47 @@
+import { describe, it, expect } from 'vitest';
+import { dueReminders, lessonsToIcs, openSlots, slotBookings, upcomingLessons, type LessonBooking, type LessonSlot } from './lessonEngine';
+
+const HOUR = 60 * 60 * 1000;
+
+function slot(slotId: string, startHour: number, overrides: Partial<LessonSlot> = {}): LessonSlot {
+  return {
+    slotId, pro: 'pro', startsAt: startHour * HOUR, endsAt: (startHour + 1) * HOUR, price: 50, currency: 'USD',
+    location: 'Range', note: '', cancelled: false, createdAt: 0, ...overrides,
+  };
+}
+
+function booking(bookingId: string, slotId: string, member: string, createdAt: number, overrides: Partial<LessonBooking> = {}): LessonBooking {
+  return { bookingId, slotId, pro: 'pro', member, note: '', cancelled: false, createdAt, ...overrides };
+}
+
+describe('Lesson Engine', () => {
+  it('should give a slot to the earliest booking', () => {
+    const held = slotBookings([slot('s1', 10)], [booking('b2', 's1', 'bob', 2000), booking('b1', 's1', 'alice', 1000)]);
+    expect(held.get('s1')?.member).toBe('alice');
+  });
+
+  it('should free a slot when its booking is cancelled', () => {
+    const bookings = [booking('b1', 's1', 'alice', 1000, { cancelled: true }), booking('b2', 's1', 'bob', 2000)];
+    expect(slotBookings([slot('s1', 10)], bookings).get('s1')?.member).toBe('bob');
+    expect(openSlots([slot('s1', 10), slot('s2', 11)], [bookings[0]], 0).map(s => s.slotId)).toEqual(['s1', 's2']);
+  });
+
+  it('should ignore cancelled slots, past slots and bookings for another pro', () => {
+    const slots = [slot('s1', 10, { cancelled: true }), slot('s2', 1), slot('s3', 12)];
+    expect(openSlots(slots, [booking('b1', 's3', 'alice', 1000, { pro: 'other' })], 5 * HOUR).map(s => s.slotId)).toEqual(['s3']);
+  });
+
+  it('should remind shortly before a booked lesson', () => {
+    const lessons = upcomingLessons([slot('s1', 10), slot('s2', 14)], [booking('b1', 's1', 'alice', 1), booking('b2', 's2', 'bob', 1)], 9 * HOUR);
+    expect(lessons).toHaveLength(2);
+    expect(dueReminders(lessons, 9.5 * HOUR).map(l => l.slot.slotId)).toEqual(['s1']);
+  });
+
+  it('should export lessons to a calendar', () => {
+    const lessons = upcomingLessons([slot('s1', 10)], [booking('b1', 's1', 'alice', 1)], 0);
+    const ics = lessonsToIcs(lessons, 'My lessons', () => 'Alice');
+    expect(ics).toContain('SUMMARY:Golf lesson: Alice');
+    expect(ics).toContain('LOCATION:Range');
+    expect(ics).toContain('UID:s1@pro.lessons.pinseekr.golf');
+  });
+});
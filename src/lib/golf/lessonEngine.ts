@@ -0,0 +1,85 @@
+// Lessons with teaching pros: the pro's open slots and members' bookings
+
+import { toIcs } from './icsCalendar';
+
+export interface LessonSlot {
+  slotId: string;
+  pro: string; // pubkey
+  startsAt: number; // ms
+  endsAt: number; // ms
+  price: number;
+  currency: string;
+  location: string;
+  note: string;
+  cancelled: boolean;
+  createdAt: number; // ms
+}
+
+export interface LessonBooking {
+  bookingId: string;
+  slotId: string;
+  pro: string;
+  member: string; // pubkey
+  note: string;
+  cancelled: boolean;
+  createdAt: number; // ms
+}
+
+export interface Lesson {
+  slot: LessonSlot;
+  booking: LessonBooking;
+}
+
+// How long before a lesson the pro and the member are reminded
+export const LESSON_REMINDER_MINUTES = 60;
+
+/**
+ * The booking that holds each slot: the earliest one still standing.
+ * A pro can't book their own slot.
+ */
+export function slotBookings(slots: LessonSlot[], bookings: LessonBooking[]): Map<string, LessonBooking> {
+  const held = new Map<string, LessonBooking>();
+  const ordered = [...bookings].sort((a, b) => a.createdAt - b.createdAt || a.bookingId.localeCompare(b.bookingId));
+
+  for (const booking of ordered) {
+    const slot = slots.find(s => s.slotId === booking.slotId && s.pro === booking.pro);
+    if (!slot || slot.cancelled || booking.cancelled || booking.member === slot.pro) continue;
+    if (!held.has(slot.slotId)) held.set(slot.slotId, booking);
+  }
+
+  return held;
+}
+
+/** Slots still free to book, soonest first */
+export function openSlots(slots: LessonSlot[], bookings: LessonBooking[], now: number): LessonSlot[] {
+  const held = slotBookings(slots, bookings);
+  return slots
+    .filter(s => !s.cancelled && s.startsAt > now && !held.has(s.slotId))
+    .sort((a, b) => a.startsAt - b.startsAt);
+}
+
+/** Booked lessons that haven't finished, soonest first */
+export function upcomingLessons(slots: LessonSlot[], bookings: LessonBooking[], now: number): Lesson[] {
+  const held = slotBookings(slots, bookings);
+  return slots
+    .filter(s => s.endsAt > now && held.has(s.slotId))
+    .map(slot => ({ slot, booking: held.get(slot.slotId)! }))
+    .sort((a, b) => a.slot.startsAt - b.slot.startsAt);
+}
+
+/** Lessons starting within the reminder window */
+export function dueReminders(lessons: Lesson[], now: number, leadMinutes = LESSON_REMINDER_MINUTES): Lesson[] {
+  return lessons.filter(l => l.slot.startsAt > now && l.slot.startsAt <= now + leadMinutes * 60 * 1000);
+}
+
+/** An iCalendar feed of lessons, titled with the other party's name */
+export function lessonsToIcs(lessons: Lesson[], calendarName: string, nameOf: (lesson: Lesson) => string, now = Date.now()): string {
+  return toIcs(lessons.map(lesson => ({
+    uid: `${lesson.slot.slotId}@${lesson.slot.pro}.lessons.pinseekr.golf`,
+    startsAt: lesson.slot.startsAt,
+    endsAt: lesson.slot.endsAt,
+    summary: `Golf lesson: ${nameOf(lesson)}`,
+    location: lesson.slot.location,
+    description: [lesson.slot.note, lesson.booking.note].filter(Boolean).join('\n'),
+  })), calendarName, 'Lessons', now);
+}
@@ -0,0 +1,93 @@
+import { describe, it, expect } from 'vitest';
+import {
+  nearestStrikeKm,
+  parseXweatherLightning,
+  planLightningWatch,
+  suspensionEnd,
+  type LightningStrike,
+} from './lightningEngine';
+import type { CourseNotice } from './maintenanceEngine';
+import type { CourseAlert } from './alertEngine';
+
+const MINUTE = 60 * 1000;
+const now = new Date(2026, 6, 4, 15, 0).getTime();
+const centre = { lat: 51.5, lon: -0.1 };
+
+// ~0.009 degrees of latitude per km
+const strike = (km: number, minutesAgo: number): LightningStrike => ({
+  lat: centre.lat + km * 0.009,
+  lon: centre.lon,
+  time: now - minutesAgo * MINUTE,
+});
+
+function suspension(overrides: Partial<CourseNotice> = {}): CourseNotice {
+  return {
+    noticeId: 'lightning-1',
+    courseId: 'oak-hills',
+    courseAuthor: 'club',
+    courseName: 'Oak Hills',
+    type: 'closure',
+    title: 'Lightning suspension',
+    details: '',
+    startsAt: now - 20 * MINUTE,
+    endsAt: now + 10 * MINUTE,
+    holes: [],
+    cancelled: false,
+    createdAt: now - 20 * MINUTE,
+    ...overrides,
+  };
+}
+
+describe('Lightning Engine', () => {
+  it('should read strikes from an Xweather response', () => {
+    const strikes = parseXweatherLightning({
+      success: true,
+      error: null,
+      response: [
+        { loc: { lat: 51.52, long: -0.11 }, ob: { timestamp: 1783170000 } },
+        { loc: { lat: 51.53 }, ob: { timestamp: 1783170001 } },
+      ],
+    });
+
+    expect(strikes).toEqual([{ lat: 51.52, lon: -0.11, time: 1783170000000 }]);
+    expect(() => parseXweatherLightning({ success: false, error: { description: 'invalid_client' } })).toThrow('invalid_client');
+  });
+
+  it('should resume 30 minutes after the last strike inside the radius', () => {
+    const strikes = [strike(20, 1), strike(8, 12), strike(5, 25)];
+
+    expect(suspensionEnd(strikes, centre, 10)).toBe(now + 18 * MINUTE);
+    expect(suspensionEnd([strike(20, 1)], centre, 10)).toBeNull();
+    expect(nearestStrikeKm(strikes, centre)).toBeCloseTo(5, 0);
+  });
+
+  it('should suspend once and extend while strikes continue', () => {
+    expect(planLightningWatch(now + 30 * MINUTE, [], [], 'club', now)).toEqual([
+      { type: 'suspend', id: `lightning-${now}`, until: now + 30 * MINUTE },
+    ]);
+
+    const current = suspension();
+    expect(planLightningWatch(now + 30 * MINUTE, [current], [], 'club', now)).toEqual([
+      { type: 'extend', notice: current, until: now + 30 * MINUTE },
+    ]);
+    expect(planLightningWatch(now + 5 * MINUTE, [current], [], 'club', now)).toEqual([]);
+  });
+
+  it('should give the all clear once the suspension has ended', () => {
+    const alert: CourseAlert = {
+      alertId: 'lightning-1',
+      courseId: 'oak-hills',
+      courseAuthor: 'club',
+      type: 'lightning',
+      message: 'Lightning nearby',
+      expiresAt: now + 60 * MINUTE,
+      cleared: false,
+      createdAt: now - 40 * MINUTE,
+    };
+
+    expect(planLightningWatch(null, [suspension()], [alert], 'club', now)).toEqual([]);
+    expect(planLightningWatch(null, [suspension({ endsAt: now - MINUTE })], [alert], 'club', now)).toEqual([
+      { type: 'all-clear', alert },
+    ]);
+  });
+});
@@ -0,0 +1,115 @@
+// Lightning watch: suspend play automatically when strikes come near the
+// course, following the 30-30 rule (suspend within about 6 miles, resume 30
+// minutes after the last strike)
+
+import { distanceYards, type GeoPoint } from './caddieEngine';
+import { activeNotices, type CourseNotice } from './maintenanceEngine';
+import { liveAlerts, type CourseAlert } from './alertEngine';
+
+export interface LightningStrike {
+  lat: number;
+  lon: number;
+  time: number; // ms
+}
+
+export interface LightningWatchSettings {
+  enabled: boolean;
+  radiusKm: number;
+  clientId: string; // Xweather API credentials, kept on the club's device
+  clientSecret: string;
+}
+
+export const LIGHTNING_RADIUS_KM = 10;
+export const LIGHTNING_ALL_CLEAR_MINUTES = 30;
+
+// Suspension notices and alerts published by the watch share this id prefix
+export const LIGHTNING_ID_PREFIX = 'lightning-';
+
+export const DEFAULT_LIGHTNING_WATCH: LightningWatchSettings = {
+  enabled: false,
+  radiusKm: LIGHTNING_RADIUS_KM,
+  clientId: '',
+  clientSecret: '',
+};
+
+const XWEATHER_URL = 'https://data.api.xweather.com/lightning/closest';
+const KM_PER_YARD = 0.0009144;
+
+export type LightningAction =
+  | { type: 'suspend'; id: string; until: number }
+  | { type: 'extend'; notice: CourseNotice; until: number }
+  | { type: 'all-clear'; alert: CourseAlert };
+
+/** Xweather query for recent cloud-to-ground strikes around a point */
+export function xweatherLightningUrl(centre: GeoPoint, settings: LightningWatchSettings): string {
+  const params = new URLSearchParams({
+    p: `${centre.lat},${centre.lon}`,
+    radius: `${settings.radiusKm}km`,
+    from: `-${LIGHTNING_ALL_CLEAR_MINUTES}minutes`,
+    filter: 'cg',
+    limit: '100',
+    client_id: settings.clientId,
+    client_secret: settings.clientSecret,
+  });
+  return `${XWEATHER_URL}?${params}`;
+}
+
+/** Strikes from an Xweather lightning response */
+export function parseXweatherLightning(json: unknown): LightningStrike[] {
+  const body = json as { success?: boolean; error?: { description?: string } | null; response?: unknown };
+  if (body?.success === false) {
+    throw new Error(body.error?.description ?? 'Lightning data request failed');
+  }
+
+  const strikes: LightningStrike[] = [];
+  for (const item of Array.isArray(body?.response) ? body.response : []) {
+    const lat = Number(item?.loc?.lat);
+    const lon = Number(item?.loc?.long);
+    const timestamp = Number(item?.ob?.timestamp);
+    if (isNaN(lat) || isNaN(lon) || isNaN(timestamp)) continue;
+    strikes.push({ lat, lon, time: timestamp * 1000 });
+  }
+  return strikes;
+}
+
+/** Distance from the course to the nearest strike, in km */
+export function nearestStrikeKm(strikes: LightningStrike[], centre: GeoPoint): number | null {
+  if (strikes.length === 0) return null;
+  return Math.min(...strikes.map(s => distanceYards(centre, s) * KM_PER_YARD));
+}
+
+/**
+ * When play may resume: 30 minutes after the last strike inside the radius,
+ * or null if there were none
+ */
+export function suspensionEnd(strikes: LightningStrike[], centre: GeoPoint, radiusKm: number): number | null {
+  const near = strikes.filter(s => distanceYards(centre, s) * KM_PER_YARD <= radiusKm);
+  if (near.length === 0) return null;
+  return Math.max(...near.map(s => s.time)) + LIGHTNING_ALL_CLEAR_MINUTES * 60 * 1000;
+}
+
+/**
+ * What the watch should publish: suspend the course when lightning comes
+ * close, push the restart back while strikes continue, and give the all
+ * clear once the suspension has run out
+ */
+export function planLightningWatch(
+  resumeAt: number | null,
+  notices: CourseNotice[],
+  alerts: CourseAlert[],
+  club: string,
+  now: number
+): LightningAction[] {
+  const suspension = activeNotices(notices, now).find(n => n.noticeId.startsWith(LIGHTNING_ID_PREFIX));
+
+  if (resumeAt !== null && resumeAt > now) {
+    if (!suspension) return [{ type: 'suspend', id: `${LIGHTNING_ID_PREFIX}${now}`, until: resumeAt }];
+    if (resumeAt > suspension.endsAt) return [{ type: 'extend', notice: suspension, until: resumeAt }];
+    return [];
+  }
+
+  if (suspension) return [];
+  return liveAlerts(alerts, club, now)
+    .filter(a => a.alertId.startsWith(LIGHTNING_ID_PREFIX))
+    .map(alert => ({ type: 'all-clear', alert }));
+}
@@ -0,0 +1,77 @@
+import { describe, it, expect } from 'vitest';
+import { activeNotices, courseStatus, currentGreenReading, noticesOn, noticesToIcs, type CourseNotice, type GreenReading } from './maintenanceEngine';
+import { createCourseNoticeEvent, createGreenReportEvent, isExpired } from './nostrEvents';
+
+const HOUR = 60 * 60 * 1000;
+const start = new Date(2026, 3, 14, 7, 0).getTime();
+
+function notice(overrides: Partial<CourseNotice> = {}): CourseNotice {
+  return {
+    noticeId: 'n1',
+    courseId: 'oak-hills',
+    courseAuthor: 'club',
+    courseName: 'Oak Hills',
+    type: 'aeration',
+    title: 'Greens aeration',
+    details: '',
+    startsAt: start,
+    endsAt: start + 8 * HOUR,
+    holes: [],
+    cancelled: false,
+    createdAt: start - 24 * HOUR,
+    ...overrides,
+  };
+}
+
+describe('Maintenance Engine', () => {
+  it('should find notices in effect at a time', () => {
+    expect(activeNotices([notice()], start + HOUR)).toHaveLength(1);
+    expect(activeNotices([notice()], start + 8 * HOUR)).toHaveLength(0);
+    expect(activeNotices([notice({ cancelled: true })], start + HOUR)).toHaveLength(0);
+  });
+
+  it('should find notices overlapping a day', () => {
+    const overnight = notice({ noticeId: 'n2', startsAt: start - 12 * HOUR, endsAt: start - 6 * HOUR });
+    const ids = noticesOn([notice(), overnight], new Date(start)).map(n => n.noticeId);
+    expect(ids).toEqual(['n2', 'n1']);
+    expect(noticesOn([notice()], new Date(start + 24 * HOUR))).toHaveLength(0);
+  });
+
+  it('should close the course only for a whole-course closure', () => {
+    expect(courseStatus([notice({ type: 'closure' })], start + HOUR)).toBe('closed');
+    expect(courseStatus([notice({ type: 'closure', holes: [3, 4] })], start + HOUR)).toBe('restricted');
+    expect(courseStatus([notice()], start - HOUR)).toBe('open');
+  });
+
+  it('should write notices as calendar events', () => {
+    const ics = noticesToIcs([notice({ holes: [1, 2], details: 'Sand, then rolled' })], 'Oak Hills notices', start);
+    expect(ics.startsWith('BEGIN:VCALENDAR\r\n')).toBe(true);
+    expect(ics).toContain('UID:n1@oak-hills.pinseekr.golf');
+    expect(ics).toContain('SUMMARY:Aeration: Greens aeration (holes 1\\, 2)');
+    expect(ics).toContain('DESCRIPTION:Sand\\, then rolled');
+    expect(ics).toContain(`DTSTART:${new Date(start).toISOString().replace(/[-:]/g, '').replace(/\.\d{3}/, '')}`);
+  });
+
+  it('should mark cancelled notices as cancelled in the calendar', () => {
+    expect(noticesToIcs([notice({ cancelled: true })], 'Oak Hills')).toContain('STATUS:CANCELLED');
+  });
+
+  it('should use the latest green reading from the last few days', () => {
+    const reading = (date: string, stimp: number, createdAt = 0): GreenReading =>
+      ({ courseId: 'oak-hills', courseAuthor: 'club', date, stimp, firmness: 'medium', note: '', createdAt });
+    const readings = [reading('2026-04-12', 9), reading('2026-04-14', 10, 1), reading('2026-04-14', 11, 2), reading('2026-04-15', 12)];
+    expect(currentGreenReading(readings, new Date(start))?.stimp).toBe(11);
+    expect(currentGreenReading(readings, new Date(2026, 3, 13))?.stimp).toBe(9);
+    expect(currentGreenReading(readings, new Date(2026, 3, 20))).toBeNull();
+  });
+
+  it('should expire notices a day after they end and green reports once they are too old', () => {
+    const noticeEvent = createCourseNoticeEvent(notice());
+    expect(isExpired(noticeEvent, start + 32 * HOUR - 1000)).toBe(false);
+    expect(isExpired(noticeEvent, start + 32 * HOUR)).toBe(true);
+
+    const report = createGreenReportEvent({ courseId: 'oak-hills', courseAuthor: 'club', date: '2026-04-14', stimp: 10, firmness: 'medium', note: '' });
+    expect(isExpired(report, new Date(2026, 3, 17, 23, 59).getTime())).toBe(false);
+    expect(isExpired(report, new Date(2026, 3, 18).getTime())).toBe(true);
+  });
+});
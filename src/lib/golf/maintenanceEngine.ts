@@ -0,0 +1,121 @@
+// Course maintenance: aeration, closures and other greenkeeping notices,
+// and the daily green speed report
+
+import type { GreenFirmness } from './caddieEngine';
+import { toIcs } from './icsCalendar';
+
+export type NoticeType = 'closure' | 'aeration' | 'maintenance' | 'frost' | 'other';
+
+export const NOTICE_TYPE_NAMES: Record<NoticeType, string> = {
+  closure: 'Closure',
+  aeration: 'Aeration',
+  maintenance: 'Maintenance',
+  frost: 'Frost delay',
+  other: 'Notice',
+};
+
+export interface CourseNotice {
+  noticeId: string;
+  courseId: string;
+  courseAuthor: string;
+  courseName: string;
+  type: NoticeType;
+  title: string;
+  details: string;
+  startsAt: number; // ms
+  endsAt: number; // ms, exclusive
+  holes: number[]; // affected holes; empty means the whole course
+  cancelled: boolean;
+  createdAt: number; // ms
+}
+
+export type CourseStatus = 'open' | 'restricted' | 'closed';
+
+export interface GreenReading {
+  courseId: string;
+  courseAuthor: string;
+  date: string; // YYYY-MM-DD, local to the course
+  stimp: number; // feet
+  firmness: GreenFirmness;
+  note: string;
+  createdAt: number; // ms
+}
+
+// Greens don't change much day to day; an older reading still beats none
+export const GREEN_READING_MAX_AGE_DAYS = 3;
+
+/** Notices in effect at `at`, soonest ending first */
+export function activeNotices(notices: CourseNotice[], at: number): CourseNotice[] {
+  return notices
+    .filter(n => !n.cancelled && n.startsAt <= at && at < n.endsAt)
+    .sort((a, b) => a.endsAt - b.endsAt);
+}
+
+/** Notices overlapping the local calendar day containing `day` */
+export function noticesOn(notices: CourseNotice[], day: Date): CourseNotice[] {
+  const start = new Date(day.getFullYear(), day.getMonth(), day.getDate()).getTime();
+  const end = new Date(day.getFullYear(), day.getMonth(), day.getDate() + 1).getTime();
+  return notices
+    .filter(n => !n.cancelled && n.startsAt < end && n.endsAt > start)
+    .sort((a, b) => a.startsAt - b.startsAt);
+}
+
+/** Notices that haven't ended yet, earliest first */
+export function upcomingNotices(notices: CourseNotice[], now: number): CourseNotice[] {
+  return notices
+    .filter(n => !n.cancelled && n.endsAt > now)
+    .sort((a, b) => a.startsAt - b.startsAt);
+}
+
+/**
+ * The course is closed while a whole-course closure is in effect, and
+ * restricted while any other notice is
+ */
+export function courseStatus(notices: CourseNotice[], at: number): CourseStatus {
+  const active = activeNotices(notices, at);
+  if (active.some(n => n.type === 'closure' && n.holes.length === 0)) return 'closed';
+  return active.length > 0 ? 'restricted' : 'open';
+}
+
+/** Periods when the whole course was or will be closed, e.g. to pause pace of play */
+export function closurePeriods(notices: CourseNotice[]): { start: number; end: number }[] {
+  return notices
+    .filter(n => !n.cancelled && n.type === 'closure' && n.holes.length === 0)
+    .map(n => ({ start: n.startsAt, end: n.endsAt }));
+}
+
+/**
+ * An iCalendar (RFC 5545) feed of the notices, for members' calendars.
+ * Cancelled notices are included as cancelled events so calendars drop them.
+ */
+export function noticesToIcs(notices: CourseNotice[], calendarName: string, now = Date.now()): string {
+  return toIcs(notices.map(notice => ({
+    uid: `${notice.noticeId}@${notice.courseId}.pinseekr.golf`,
+    startsAt: notice.startsAt,
+    endsAt: notice.endsAt,
+    summary: `${NOTICE_TYPE_NAMES[notice.type]}: ${notice.title}${notice.holes.length > 0 ? ` (holes ${notice.holes.join(', ')})` : ''}`,
+    location: notice.courseName,
+    description: notice.details,
+    cancelled: notice.cancelled,
+  })), calendarName, 'Course notices', now);
+}
+
+/** Local calendar date as YYYY-MM-DD */
+export function readingDate(day: Date): string {
+  const month = String(day.getMonth() + 1).padStart(2, '0');
+  const date = String(day.getDate()).padStart(2, '0');
+  return `${day.getFullYear()}-${month}-${date}`;
+}
+
+/**
+ * The reading to use on `day`: that day's, or the most recent from the last
+ * few days. The latest report for a date replaces earlier ones.
+ */
+export function currentGreenReading(readings: GreenReading[], day: Date): GreenReading | null {
+  const today = readingDate(day);
+  const oldest = readingDate(new Date(day.getFullYear(), day.getMonth(), day.getDate() - GREEN_READING_MAX_AGE_DAYS));
+  const candidates = readings
+    .filter(r => r.date <= today && r.date >= oldest)
+    .sort((a, b) => b.date.localeCompare(a.date) || b.createdAt - a.createdAt);
+  return candidates[0] ?? null;
+}
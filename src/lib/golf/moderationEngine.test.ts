@@ -0,0 +1,40 @@
+import { describe, it, expect } from 'vitest';
+import type { NostrEvent } from '@nostrify/nostrify';
+import { mutedPubkeys, reportedBy, reportTags, updateMuteTags } from './moderationEngine';
+
+function event(kind: number, pubkey: string, tags: string[][]): NostrEvent {
+  return { id: `${pubkey}-${kind}`, pubkey, created_at: 1000, kind, tags, content: '', sig: '' };
+}
+
+describe('Moderation Engine', () => {
+  it('should read muted pubkeys from a mute list', () => {
+    const list = event(10000, 'me', [['p', 'troll'], ['t', 'crypto'], ['p', 'bot']]);
+    expect(mutedPubkeys(list)).toEqual(['troll', 'bot']);
+    expect(mutedPubkeys(event(3, 'me', [['p', 'friend']]))).toEqual([]);
+  });
+
+  it('should add a pubkey once and keep other entries', () => {
+    const tags = updateMuteTags([['word', 'airdrop'], ['p', 'troll']], 'troll', true);
+    expect(tags).toEqual([['word', 'airdrop'], ['p', 'troll']]);
+  });
+
+  it('should remove a pubkey when unmuting', () => {
+    expect(updateMuteTags([['p', 'troll'], ['p', 'bot']], 'troll', false)).toEqual([['p', 'bot']]);
+  });
+
+  it('should tag the author and event with the report type', () => {
+    expect(reportTags('spam', { pubkey: 'bot', id: 'note1' })).toEqual([['p', 'bot', 'spam'], ['e', 'note1', 'spam']]);
+    expect(reportTags('impersonation', { pubkey: 'fake' })).toEqual([['p', 'fake', 'impersonation']]);
+  });
+
+  it('should only count reports from the given reporters', () => {
+    const reports = [
+      event(1984, 'admin', [['p', 'bot', 'spam'], ['e', 'note1', 'spam']]),
+      event(1984, 'admin', [['p', 'fake', 'impersonation']]),
+      event(1984, 'stranger', [['p', 'friend', 'spam'], ['e', 'note2', 'spam']]),
+    ];
+    const reported = reportedBy(reports, ['admin']);
+    expect([...reported.events]).toEqual(['note1']);
+    expect([...reported.authors]).toEqual(['fake']);
+  });
+});
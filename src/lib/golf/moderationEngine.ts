@@ -0,0 +1,58 @@
+// Muting (NIP-51 mute lists) and reporting (NIP-56) for members
+
+import type { NostrEvent } from '@nostrify/nostrify';
+
+export type ReportType = 'spam' | 'profanity' | 'impersonation' | 'nudity' | 'illegal' | 'malware' | 'other';
+
+export const REPORT_TYPES: { type: ReportType; label: string }[] = [
+  { type: 'spam', label: 'Spam' },
+  { type: 'profanity', label: 'Abusive or hateful' },
+  { type: 'impersonation', label: 'Impersonation' },
+  { type: 'nudity', label: 'Nudity' },
+  { type: 'illegal', label: 'Illegal content' },
+  { type: 'malware', label: 'Malware or scam' },
+  { type: 'other', label: 'Something else' },
+];
+
+/** Pubkeys on a mute list's public entries */
+export function mutedPubkeys(list: NostrEvent | null | undefined): string[] {
+  if (!list || list.kind !== 10000) return [];
+  return list.tags.filter(([name, value]) => name === 'p' && value).map(([, value]) => value);
+}
+
+/**
+ * Tags for a mute list with a pubkey added or removed. Other entries (muted
+ * words, hashtags, threads) are kept as they are.
+ */
+export function updateMuteTags(tags: string[][], pubkey: string, muted: boolean): string[][] {
+  const rest = tags.filter(([name, value]) => !(name === 'p' && value === pubkey));
+  return muted ? [...rest, ['p', pubkey]] : rest;
+}
+
+/**
+ * Tags for a report (kind 1984): the author, and the event when reporting a
+ * specific note or comment, each marked with the report type
+ */
+export function reportTags(type: ReportType, target: { pubkey: string; id?: string }): string[][] {
+  return [
+    ['p', target.pubkey, type],
+    ...(target.id ? [['e', target.id, type]] : []),
+  ];
+}
+
+/** Event ids and authors reported by any of the given reporters */
+export function reportedBy(reports: NostrEvent[], reporters: string[]): { events: Set<string>; authors: Set<string> } {
+  const events = new Set<string>();
+  const authors = new Set<string>();
+  for (const report of reports) {
+    if (report.kind !== 1984 || !reporters.includes(report.pubkey)) continue;
+    const reportedEvents = report.tags.filter(([name, value]) => name === 'e' && value).map(([, value]) => value);
+    if (reportedEvents.length > 0) {
+      reportedEvents.forEach(id => events.add(id));
+    } else {
+      // A report without an event is about the person
+      report.tags.filter(([name, value]) => name === 'p' && value).forEach(([, value]) => authors.add(value));
+    }
+  }
+  return { events, authors };
+}
@@ -1,4 +1,28 @@
-import { GOLF_KINDS, GolfRound, HoleScore, PlayerInRound, GameMode, GameSettings } from './types';
+import { GOLF_KINDS, GolfRound, HoleScore, PlayerInRound, GameMode, GameSettings, type BadgeDefinition } from './types';
+import type { Draw, DrawMethod } from './drawEngine';
+import type { RoundDifferential } from './handicapCalculator';
+import type { BagClub, ClubType, ShotSample } from './bagEngine';
+import type { PracticeBlock, PracticeSession, PracticeType } from './practiceEngine';
+import { scoreDigest, type ScoreAttestation } from './attestationEngine';
+import type { Dispute, DisputeSubject, Ruling, RulingDecision } from './disputeEngine';
+import type { SponsorPlacement, SponsorSlot } from './sponsorEngine';
+import type { SideCompetitionType } from './sideCompetitionEngine';
+import type { Ace, AceWitness } from './aceEngine';
+import { scoreCardRound, type ScoringDelegation } from './delegationEngine';
+import { GREEN_READING_MAX_AGE_DAYS, type CourseNotice, type GreenReading, type NoticeType } from './maintenanceEngine';
+import type { GreenFirmness } from './caddieEngine';
+import type { RentalInventory, RentalItemType, RentalReservation } from './rentalEngine';
+import type { LessonBooking, LessonSlot } from './lessonEngine';
+import type { ConditionReport, ConditionType } from './conditionEngine';
+import type { AlertType, CourseAlert } from './alertEngine';
+import type { PricingRule, RateCard } from './pricingEngine';
+import type { Voucher, VoucherRedemption } from './voucherEngine';
+import { CONSENT_PURPOSES, type ConsentPurpose, type PrivacySettings } from './privacyEngine';
+import type { ScorerDevice } from './deviceEngine';
+import type { VirtualEntry, VirtualStanding, VirtualTournament } from './virtualTournamentEngine';
+import type { FantasyLeague, FantasyMajor, FantasyPicks, ProResult } from './fantasyEngine';
+import type { Prediction, PredictionGame } from './predictionEngine';
+import type { TeamEvent, TeamEventConfig } from './teamEventEngine';
 
 // Nostr event type
 interface NostrEvent {
@@ -48,7 +72,9 @@ export function createRoundEvent(round: GolfRound, roundCode?: string): NostrEve
       ...(typeof round.metadata.teeYardage !== 'undefined' ? [['tee-yardage', String(round.metadata.teeYardage)]] : []),
       ...(round.metadata.weather ? [['weather', round.metadata.weather]] : []),
       ...(round.metadata.origin ? [['origin', round.metadata.origin]] : []),
+      ...(round.metadata.teeTime ? [['tee-time', String(Math.floor(round.metadata.teeTime / 1000))]] : []),
       ...(round.metadata.visibility ? [['visibility', round.metadata.visibility]] : []),
+      ...(round.metadata.sideCompetitions || []).map(c => ['side-comp', c.type, String(c.hole), String(c.prizeSats)]),
       ...scorecardImageTags,
     ],
     content: round.metadata.notes || '',
@@ -198,6 +224,1742 @@ export function createBadgeAwardEvent(
   };
 }
 
+/**
+ * Create a NIP-58 badge definition (kind 30009) for one of the golf badges.
+ * Players issue their own, so each keeps a definition per badge.
+ */
+export function createBadgeDefinitionEvent(badge: BadgeDefinition, issuer: string): NostrEvent {
+  return {
+    kind: 30009,
+    pubkey: issuer,
+    created_at: Math.floor(Date.now() / 1000),
+    tags: [
+      ['d', `pinseekr-${badge.id}`],
+      ['name', `${badge.icon} ${badge.name}`],
+      ['description', badge.description],
+      ['t', 'golf'],
+      ['alt', `Golf badge: ${badge.name}`],
+    ],
+    content: '',
+  };
+}
+
+/**
+ * Create a NIP-58 badge award (kind 8) of a badge definition to a player
+ */
+export function createNip58AwardEvent(badge: BadgeDefinition, issuer: string, recipient: string): NostrEvent {
+  return {
+    kind: 8,
+    pubkey: issuer,
+    created_at: Math.floor(Date.now() / 1000),
+    tags: [
+      ['a', `30009:${issuer}:pinseekr-${badge.id}`],
+      ['p', recipient],
+      ['t', 'golf'],
+      ['alt', `Awarded the golf badge: ${badge.name}`],
+    ],
+    content: '',
+  };
+}
+
+/**
+ * Create a tournament draw event
+ * @param tournamentId - The tournament the draw belongs to (one draw per tournament)
+ * @param draw - Generated draw with tee times and pairings
+ * @param organizer - Pubkey of the organizer publishing the draw
+ */
+export function createDrawEvent(
+  tournamentId: string,
+  draw: Draw,
+  organizer: string,
+  tournamentName?: string
+): NostrEvent {
+  // One indexed tag per group: ['group', <number>, <tee time unix>, <starting hole>, ...pubkeys]
+  const groupTags = draw.groups.map(g => [
+    'group',
+    String(g.group),
+    String(Math.floor(g.teeTime / 1000)),
+    String(g.startingHole),
+    ...g.players.map(p => p.id),
+  ]);
+
+  return {
+    kind: GOLF_KINDS.DRAW,
+    pubkey: organizer,
+    created_at: Math.floor(Date.now() / 1000),
+    tags: [
+      ['d', `${tournamentId}-draw`],
+      ['tournament', tournamentId],
+      ['t', 'golf'],
+      ['t', 'draw'],
+      ['method', draw.method],
+      ...draw.groups.flatMap(g => g.players.map(p => ['p', p.id])),
+      ...groupTags,
+      ['alt', `Tee times and pairings${tournamentName ? ` for ${tournamentName}` : ''}`],
+    ],
+    content: JSON.stringify(draw),
+  };
+}
+
+/**
+ * Parse a tournament draw event
+ */
+export function parseDrawEvent(event: NostrEvent): (Draw & { tournamentId: string }) | null {
+  if (event.kind !== GOLF_KINDS.DRAW) return null;
+
+  const tournamentId = event.tags.find((t: string[]) => t[0] === 'tournament')?.[1];
+  if (!tournamentId) return null;
+
+  // Prefer the full JSON content (includes names and handicaps)
+  try {
+    const parsed = JSON.parse(event.content) as Draw;
+    if (Array.isArray(parsed.groups)) {
+      return { ...parsed, tournamentId };
+    }
+  } catch {
+    // Fall back to group tags below
+  }
+
+  const groups = event.tags
+    .filter((t: string[]) => t[0] === 'group' && t.length >= 4)
+    .map((t: string[]) => ({
+      group: parseInt(t[1]),
+      teeTime: parseInt(t[2]) * 1000,
+      startingHole: (t[3] === '10' ? 10 : 1) as 1 | 10,
+      players: t.slice(4).map(id => ({ id, name: '', handicap: 0 })),
+    }));
+
+  if (groups.length === 0) return null;
+
+  return {
+    tournamentId,
+    method: (event.tags.find((t: string[]) => t[0] === 'method')?.[1] || 'random') as DrawMethod,
+    groups,
+    playerCount: groups.reduce((sum, g) => sum + g.players.length, 0),
+  };
+}
+
+/**
+ * Create a committee penalty score event
+ * @param penaltyId - Unique id for the penalty (addressable, so it can be amended or withdrawn)
+ * @param playerPubkey - Player the penalty score applies to
+ * @param committee - Pubkey of the handicap committee applying the penalty
+ * @param differential - Score differential entered into the player's scoring record
+ */
+export function createHandicapPenaltyEvent(
+  penaltyId: string,
+  playerPubkey: string,
+  committee: string,
+  differential: number,
+  reason: string,
+  date: number = Date.now()
+): NostrEvent {
+  return {
+    kind: GOLF_KINDS.HANDICAP_PENALTY,
+    pubkey: committee,
+    created_at: Math.floor(Date.now() / 1000),
+    tags: [
+      ['d', penaltyId],
+      ['p', playerPubkey],
+      ['t', 'golf'],
+      ['t', 'handicap-penalty'],
+      ['differential', differential.toFixed(1)],
+      ['date', String(Math.floor(date / 1000))],
+      ['alt', `Handicap penalty score: ${reason}`],
+    ],
+    content: reason,
+  };
+}
+
+/**
+ * Parse a committee penalty score event into a scoring record entry
+ */
+export function parseHandicapPenaltyEvent(event: NostrEvent): RoundDifferential | null {
+  if (event.kind !== GOLF_KINDS.HANDICAP_PENALTY) return null;
+
+  const penaltyId = event.tags.find((t: string[]) => t[0] === 'd')?.[1];
+  const differential = parseFloat(event.tags.find((t: string[]) => t[0] === 'differential')?.[1] || '');
+  if (!penaltyId || isNaN(differential)) return null;
+
+  const date = parseInt(event.tags.find((t: string[]) => t[0] === 'date')?.[1] || '') || event.created_at;
+
+  return {
+    roundId: `penalty-${penaltyId}`,
+    date: date * 1000,
+    gross: 0,
+    courseRating: 0,
+    slope: 0,
+    differential,
+    courseName: event.content || 'Penalty score',
+    source: 'penalty',
+  };
+}
+
+/**
+ * Create a golf bag event (one per player; republishing replaces it)
+ */
+export function createBagEvent(clubs: BagClub[], playerId: string): NostrEvent {
+  return {
+    kind: GOLF_KINDS.GOLF_BAG,
+    pubkey: playerId,
+    created_at: Math.floor(Date.now() / 1000),
+    tags: [
+      ['d', 'bag'],
+      ['t', 'golf'],
+      ['t', 'bag'],
+      ...clubs.map(c => ['club', c.id, c.type, String(c.carry), c.label]),
+      ['alt', 'Golf bag: clubs and carry distances'],
+    ],
+    content: '',
+  };
+}
+
+/**
+ * Parse a golf bag event
+ */
+export function parseBagEvent(event: NostrEvent): BagClub[] | null {
+  if (event.kind !== GOLF_KINDS.GOLF_BAG) return null;
+
+  return event.tags
+    .filter((t: string[]) => t[0] === 'club' && t[1])
+    .map((t: string[]) => ({
+      id: t[1],
+      type: (t[2] || 'iron') as ClubType,
+      carry: parseInt(t[3]) || 0,
+      label: t[4] || t[1],
+    }));
+}
+
+export interface ShotDetails {
+  roundId?: string;
+  hole?: number;
+  start?: { lat: number; lon: number };
+  end?: { lat: number; lon: number };
+  path?: { lat: number; lon: number }[]; // traced flight between start and end, if recorded
+}
+
+/**
+ * Create a shot event
+ */
+export function createShotEvent(shot: ShotSample, playerId: string, details: ShotDetails = {}): NostrEvent {
+  const position = (p: { lat: number; lon: number }) => `${p.lat.toFixed(6)},${p.lon.toFixed(6)}`;
+
+  return {
+    kind: GOLF_KINDS.SHOT,
+    pubkey: playerId,
+    created_at: Math.floor(shot.timestamp / 1000),
+    tags: [
+      ['d', shot.shotId],
+      ['t', 'golf'],
+      ['t', 'shot'],
+      ['club', shot.clubId],
+      ['distance', String(Math.round(shot.distance))],
+      ...(details.roundId ? [['round', details.roundId]] : []),
+      ...(details.hole ? [['hole', String(details.hole)]] : []),
+      ...(details.start ? [['start', position(details.start)]] : []),
+      ...(details.end ? [['end', position(details.end)]] : []),
+      ...(details.path?.length ? [['path', ...details.path.map(position)]] : []),
+      ['alt', `Golf shot: ${Math.round(shot.distance)} yards`],
+    ],
+    content: '',
+  };
+}
+
+/**
+ * Parse a shot event
+ */
+export function parseShotEvent(event: NostrEvent): (ShotSample & ShotDetails) | null {
+  if (event.kind !== GOLF_KINDS.SHOT) return null;
+
+  const tag = (name: string) => event.tags.find((t: string[]) => t[0] === name)?.[1];
+  const shotId = tag('d');
+  const clubId = tag('club');
+  const distance = parseFloat(tag('distance') || '');
+  if (!shotId || !clubId || isNaN(distance)) return null;
+
+  const position = (value?: string) => {
+    const [lat, lon] = (value || '').split(',').map(parseFloat);
+    return isNaN(lat) || isNaN(lon) ? undefined : { lat, lon };
+  };
+
+  return {
+    shotId,
+    clubId,
+    distance,
+    timestamp: event.created_at * 1000,
+    roundId: tag('round'),
+    hole: tag('hole') ? parseInt(tag('hole')!) : undefined,
+    start: position(tag('start')),
+    end: position(tag('end')),
+    path: event.tags.find((t: string[]) => t[0] === 'path')?.slice(1).map(position).filter((p): p is { lat: number; lon: number } => !!p),
+  };
+}
+
+/**
+ * Create a practice session event (republishing with the same id replaces it)
+ */
+export function createPracticeSessionEvent(session: PracticeSession, playerId: string): NostrEvent {
+  const balls = session.blocks.reduce((sum, b) => sum + b.balls, 0);
+  const clubs = [...new Set(session.blocks.map(b => b.clubId))];
+
+  return {
+    kind: GOLF_KINDS.PRACTICE_SESSION,
+    pubkey: playerId,
+    created_at: Math.floor(Date.now() / 1000),
+    tags: [
+      ['d', session.sessionId],
+      ['t', 'golf'],
+      ['t', 'practice'],
+      ['type', session.type],
+      ['date', String(Math.floor(session.date / 1000))],
+      ...clubs.map(club => ['club', club]),
+      ['alt', `Golf practice session: ${balls} balls`],
+    ],
+    content: JSON.stringify({
+      blocks: session.blocks,
+      durationMinutes: session.durationMinutes,
+      location: session.location,
+      notes: session.notes,
+    }),
+  };
+}
+
+/**
+ * Parse a practice session event
+ */
+export function parsePracticeSessionEvent(event: NostrEvent): PracticeSession | null {
+  if (event.kind !== GOLF_KINDS.PRACTICE_SESSION) return null;
+
+  const tag = (name: string) => event.tags.find((t: string[]) => t[0] === name)?.[1];
+  const sessionId = tag('d');
+  if (!sessionId) return null;
+
+  try {
+    const content = JSON.parse(event.content);
+    const blocks: PracticeBlock[] = Array.isArray(content.blocks) ? content.blocks : [];
+    const date = parseInt(tag('date') || '');
+
+    return {
+      sessionId,
+      date: isNaN(date) ? event.created_at * 1000 : date * 1000,
+      type: (tag('type') || 'range') as PracticeType,
+      location: content.location,
+      durationMinutes: content.durationMinutes,
+      blocks: blocks.filter(b => b && typeof b.clubId === 'string' && typeof b.balls === 'number'),
+      notes: content.notes,
+    };
+  } catch {
+    return null;
+  }
+}
+
+/**
+ * Create a score attestation: the marker countersigns (or disputes) a player's
+ * card. One per marker, round and player; republishing replaces it.
+ */
+export function createAttestationEvent(
+  card: { roundId: string; playerPubkey: string; scores: { [hole: number]: number } },
+  markerPubkey: string,
+  verdict: 'attest' | 'dispute',
+  comment: string = ''
+): NostrEvent {
+  return {
+    kind: GOLF_KINDS.SCORE_ATTESTATION,
+    pubkey: markerPubkey,
+    created_at: Math.floor(Date.now() / 1000),
+    tags: [
+      ['d', `${card.roundId}:${card.playerPubkey}`],
+      ['a', `${GOLF_KINDS.PLAYER_SCORE}:${card.playerPubkey}:${card.roundId}`],
+      ['p', card.playerPubkey],
+      ['round', card.roundId],
+      ['digest', scoreDigest(card.scores)],
+      ['verdict', verdict],
+      ['t', 'golf'],
+      ['alt', verdict === 'attest' ? 'Golf scorecard attested by marker' : 'Golf scorecard disputed by marker'],
+    ],
+    content: comment,
+  };
+}
+
+/**
+ * Parse a score attestation event
+ */
+export function parseAttestationEvent(event: NostrEvent): ScoreAttestation | null {
+  if (event.kind !== GOLF_KINDS.SCORE_ATTESTATION) return null;
+
+  const tag = (name: string) => event.tags.find((t: string[]) => t[0] === name)?.[1];
+  const roundId = tag('round');
+  const playerPubkey = tag('p');
+  const digest = tag('digest');
+  const verdict = tag('verdict');
+  if (!roundId || !playerPubkey || digest === undefined || (verdict !== 'attest' && verdict !== 'dispute')) return null;
+
+  return {
+    roundId,
+    playerPubkey,
+    markerPubkey: event.pubkey,
+    digest,
+    verdict,
+    comment: event.content || undefined,
+    createdAt: event.created_at * 1000,
+  };
+}
+
+/**
+ * Create a dispute event. Players and committee members are p-tagged (with
+ * a role) so they are notified.
+ */
+export function createDisputeEvent(dispute: Omit<Dispute, 'raisedBy' | 'createdAt'>, raisedBy: string): NostrEvent {
+  return {
+    kind: GOLF_KINDS.DISPUTE,
+    pubkey: raisedBy,
+    created_at: Math.floor(Date.now() / 1000),
+    tags: [
+      ['d', dispute.disputeId],
+      ['round', dispute.roundId],
+      ['subject', dispute.subject],
+      ...dispute.players.map(p => ['p', p, '', 'player']),
+      ...dispute.committee.map(p => ['p', p, '', 'committee']),
+      ...dispute.evidence.map(url => ['evidence', url]),
+      ['t', 'golf'],
+      ['alt', `Golf ${dispute.subject === 'match' ? 'match result' : 'score'} dispute: ${dispute.reason}`],
+    ],
+    content: dispute.reason,
+  };
+}
+
+/**
+ * Parse a dispute event
+ */
+export function parseDisputeEvent(event: NostrEvent): Dispute | null {
+  if (event.kind !== GOLF_KINDS.DISPUTE) return null;
+
+  const tag = (name: string) => event.tags.find((t: string[]) => t[0] === name)?.[1];
+  const disputeId = tag('d');
+  const roundId = tag('round');
+  if (!disputeId || !roundId) return null;
+
+  const role = (r: string) => event.tags.filter((t: string[]) => t[0] === 'p' && t[1] && t[3] === r).map((t: string[]) => t[1]);
+
+  return {
+    disputeId,
+    raisedBy: event.pubkey,
+    roundId,
+    subject: (tag('subject') === 'match' ? 'match' : 'score') as DisputeSubject,
+    players: role('player'),
+    committee: role('committee'),
+    reason: event.content,
+    evidence: event.tags.filter((t: string[]) => t[0] === 'evidence' && t[1]).map((t: string[]) => t[1]),
+    createdAt: event.created_at * 1000,
+  };
+}
+
+/**
+ * Create a committee ruling event
+ */
+export function createRulingEvent(ruling: Omit<Ruling, 'committee' | 'createdAt'>, committee: string): NostrEvent {
+  return {
+    kind: GOLF_KINDS.RULING,
+    pubkey: committee,
+    created_at: Math.floor(Date.now() / 1000),
+    tags: [
+      ['d', ruling.rulingId],
+      ['round', ruling.roundId],
+      ...(ruling.disputeId ? [['dispute', ruling.disputeId]] : []),
+      ...ruling.players.map(p => ['p', p]),
+      ['decision', ruling.decision],
+      ...ruling.corrections.map(c => ['correction', c.playerPubkey, String(c.hole), String(c.strokes)]),
+      ['t', 'golf'],
+      ['alt', `Golf committee ruling: ${ruling.decision}`],
+    ],
+    content: ruling.explanation,
+  };
+}
+
+/**
+ * Parse a committee ruling event
+ */
+export function parseRulingEvent(event: NostrEvent): Ruling | null {
+  if (event.kind !== GOLF_KINDS.RULING) return null;
+
+  const tag = (name: string) => event.tags.find((t: string[]) => t[0] === name)?.[1];
+  const rulingId = tag('d');
+  const roundId = tag('round');
+  const decision = tag('decision');
+  if (!rulingId || !roundId || (decision !== 'dismissed' && decision !== 'corrected' && decision !== 'void')) return null;
+
+  return {
+    rulingId,
+    committee: event.pubkey,
+    roundId,
+    disputeId: tag('dispute'),
+    players: event.tags.filter((t: string[]) => t[0] === 'p' && t[1]).map((t: string[]) => t[1]),
+    decision: decision as RulingDecision,
+    corrections: event.tags
+      .filter((t: string[]) => t[0] === 'correction' && t[1])
+      .map((t: string[]) => ({ playerPubkey: t[1], hole: parseInt(t[2]), strokes: parseInt(t[3]) }))
+      .filter(c => !isNaN(c.hole) && !isNaN(c.strokes)),
+    explanation: event.content,
+    createdAt: event.created_at * 1000,
+  };
+}
+
+/**
+ * Create a sponsor slot event. Published by the course author, and tied to
+ * the course with an `a` tag, so displays only show the club's own sponsors.
+ */
+export function createSponsorSlotEvent(slot: SponsorSlot, clubPubkey: string): NostrEvent {
+  return {
+    kind: GOLF_KINDS.SPONSOR_SLOT,
+    pubkey: clubPubkey,
+    created_at: Math.floor(Date.now() / 1000),
+    tags: [
+      ['d', slot.slotId],
+      ['a', `${GOLF_KINDS.COURSE}:${clubPubkey}:${slot.courseId}`],
+      ['title', slot.sponsor],
+      ['image', slot.imageUrl],
+      ...(slot.link ? [['r', slot.link]] : []),
+      ...slot.placements.map(p => ['placement', p]),
+      ['starts', String(Math.floor(slot.startsAt / 1000))],
+      ['ends', String(Math.floor(slot.endsAt / 1000))],
+      ['weight', String(slot.weight)],
+      ['t', 'golf'],
+      ['alt', `Sponsor banner: ${slot.sponsor}`],
+    ],
+    content: '',
+  };
+}
+
+/**
+ * Parse a sponsor slot event
+ */
+export function parseSponsorSlotEvent(event: NostrEvent): SponsorSlot | null {
+  if (event.kind !== GOLF_KINDS.SPONSOR_SLOT) return null;
+
+  const tag = (name: string) => event.tags.find((t: string[]) => t[0] === name)?.[1];
+  const slotId = tag('d');
+  const courseId = tag('a')?.split(':').slice(2).join(':');
+  const imageUrl = tag('image');
+  const startsAt = parseInt(tag('starts') ?? '') * 1000;
+  const endsAt = parseInt(tag('ends') ?? '') * 1000;
+  if (!slotId || !courseId || !imageUrl || isNaN(startsAt) || isNaN(endsAt)) return null;
+
+  const weight = parseInt(tag('weight') ?? '');
+  return {
+    slotId,
+    courseId,
+    sponsor: tag('title') ?? '',
+    imageUrl,
+    link: tag('r'),
+    placements: event.tags
+      .filter((t: string[]) => t[0] === 'placement' && (t[1] === 'tv' || t[1] === 'scorecard'))
+      .map((t: string[]) => t[1] as SponsorPlacement),
+    startsAt,
+    endsAt,
+    weight: isNaN(weight) ? 1 : weight,
+  };
+}
+
+/**
+ * Create a hole-in-one claim. The course is referenced with an `a` tag so
+ * the club's wall of fame can find it, and the witnesses are p-tagged so
+ * they are asked to sign.
+ */
+export function createAceEvent(ace: Omit<Ace, 'aceId' | 'playerPubkey' | 'createdAt'>, playerPubkey: string): NostrEvent {
+  return {
+    kind: GOLF_KINDS.ACE,
+    pubkey: playerPubkey,
+    created_at: Math.floor(Date.now() / 1000),
+    tags: [
+      ['d', `${ace.roundId}:${ace.hole}`],
+      ['a', `${GOLF_KINDS.COURSE}:${ace.courseAuthor}:${ace.courseId}`],
+      ['course', ace.courseName],
+      ['round', ace.roundId],
+      ['hole', String(ace.hole)],
+      ...(ace.yards ? [['yards', String(ace.yards)]] : []),
+      ...(ace.club ? [['club', ace.club]] : []),
+      ...ace.witnesses.map(w => ['p', w, '', 'witness']),
+      ['t', 'golf'],
+      ['t', 'holeinone'],
+      ['alt', `Hole in one at hole ${ace.hole}, ${ace.courseName}`],
+    ],
+    content: ace.note,
+  };
+}
+
+/**
+ * Parse a hole-in-one claim
+ */
+export function parseAceEvent(event: NostrEvent): Ace | null {
+  if (event.kind !== GOLF_KINDS.ACE) return null;
+
+  const tag = (name: string) => event.tags.find((t: string[]) => t[0] === name)?.[1];
+  const aceId = tag('d');
+  const [, courseAuthor, ...courseIdParts] = (tag('a') ?? '').split(':');
+  const courseId = courseIdParts.join(':');
+  const roundId = tag('round');
+  const hole = parseInt(tag('hole') ?? '');
+  if (!aceId || !courseAuthor || !courseId || !roundId || isNaN(hole)) return null;
+
+  const yards = parseInt(tag('yards') ?? '');
+  return {
+    aceId,
+    playerPubkey: event.pubkey,
+    courseId,
+    courseAuthor,
+    courseName: tag('course') ?? '',
+    roundId,
+    hole,
+    yards: isNaN(yards) ? undefined : yards,
+    club: tag('club'),
+    witnesses: event.tags.filter((t: string[]) => t[0] === 'p' && t[1]).map((t: string[]) => t[1]),
+    note: event.content,
+    createdAt: event.created_at * 1000,
+  };
+}
+
+/**
+ * Create a witness signature for a hole in one. One per witness and ace;
+ * the `a` tag points at the player's claim.
+ */
+export function createAceWitnessEvent(ace: Ace, witnessPubkey: string): NostrEvent {
+  return {
+    kind: GOLF_KINDS.ACE_WITNESS,
+    pubkey: witnessPubkey,
+    created_at: Math.floor(Date.now() / 1000),
+    tags: [
+      ['d', `${ace.playerPubkey}:${ace.aceId}`],
+      ['a', `${GOLF_KINDS.ACE}:${ace.playerPubkey}:${ace.aceId}`],
+      ['p', ace.playerPubkey],
+      ['t', 'golf'],
+      ['alt', `Witnessed a hole in one at hole ${ace.hole}, ${ace.courseName}`],
+    ],
+    content: '',
+  };
+}
+
+/**
+ * Parse a witness signature
+ */
+export function parseAceWitnessEvent(event: NostrEvent): AceWitness | null {
+  if (event.kind !== GOLF_KINDS.ACE_WITNESS) return null;
+
+  const [kind, playerPubkey, ...aceIdParts] = (event.tags.find((t: string[]) => t[0] === 'a')?.[1] ?? '').split(':');
+  const aceId = aceIdParts.join(':');
+  if (kind !== String(GOLF_KINDS.ACE) || !playerPubkey || !aceId) return null;
+
+  return { aceId, playerPubkey, witnessPubkey: event.pubkey, createdAt: event.created_at * 1000 };
+}
+
+/**
+ * Create a scoring delegation: the player lets a terminal post their score
+ * cards for a time window. Republishing with `until` in the past revokes it.
+ */
+export function createScoringDelegationEvent(delegation: Omit<ScoringDelegation, 'createdAt'>): NostrEvent {
+  return {
+    kind: GOLF_KINDS.SCORING_DELEGATION,
+    pubkey: delegation.delegator,
+    created_at: Math.floor(Date.now() / 1000),
+    tags: [
+      ['d', delegation.delegatee],
+      ['p', delegation.delegatee],
+      ...delegation.kinds.map(kind => ['k', String(kind)]),
+      ['valid-from', String(delegation.since)],
+      ['valid-until', String(delegation.until)],
+      ['t', 'golf'],
+      ['alt', `Lets a scoring terminal post score cards until ${new Date(delegation.until * 1000).toISOString()}`],
+    ],
+    content: delegation.label,
+  };
+}
+
+/**
+ * Parse a scoring delegation
+ */
+export function parseScoringDelegationEvent(event: NostrEvent): ScoringDelegation | null {
+  if (event.kind !== GOLF_KINDS.SCORING_DELEGATION) return null;
+
+  const delegatee = event.tags.find((t: string[]) => t[0] === 'p')?.[1];
+  const since = parseInt(event.tags.find((t: string[]) => t[0] === 'valid-from')?.[1] ?? '');
+  const until = parseInt(event.tags.find((t: string[]) => t[0] === 'valid-until')?.[1] ?? '');
+  const kinds = event.tags
+    .filter((t: string[]) => t[0] === 'k')
+    .map((t: string[]) => parseInt(t[1]))
+    .filter((kind: number) => !isNaN(kind));
+  if (!delegatee || isNaN(since) || isNaN(until) || kinds.length === 0) return null;
+
+  return {
+    delegator: event.pubkey,
+    delegatee,
+    kinds,
+    since,
+    until,
+    label: event.content,
+    createdAt: event.created_at * 1000,
+  };
+}
+
+// Ended notices stay readable for a day, so the pace clock can still skip a closure
+const NOTICE_EXPIRY_GRACE_MS = 24 * 60 * 60 * 1000;
+
+/**
+ * Create a course notice (aeration, closure, maintenance), published by the
+ * course author. Republish with `cancelled` to withdraw it. It expires
+ * (NIP-40) a day after it ends.
+ */
+export function createCourseNoticeEvent(notice: Omit<CourseNotice, 'createdAt'>): NostrEvent {
+  return {
+    kind: GOLF_KINDS.COURSE_NOTICE,
+    pubkey: notice.courseAuthor,
+    created_at: Math.floor(Date.now() / 1000),
+    tags: [
+      ['d', notice.noticeId],
+      ['a', `${GOLF_KINDS.COURSE}:${notice.courseAuthor}:${notice.courseId}`],
+      ['course', notice.courseName],
+      ['type', notice.type],
+      ['title', notice.title],
+      ['starts', String(Math.floor(notice.startsAt / 1000))],
+      ['ends', String(Math.floor(notice.endsAt / 1000))],
+      ['expiration', String(Math.floor((notice.endsAt + NOTICE_EXPIRY_GRACE_MS) / 1000))],
+      ...notice.holes.map(hole => ['hole', String(hole)]),
+      ...(notice.cancelled ? [['status', 'cancelled']] : []),
+      ['t', 'golf'],
+      ['alt', `${notice.title} at ${notice.courseName}`],
+    ],
+    content: notice.details,
+  };
+}
+
+/**
+ * Parse a course notice
+ */
+export function parseCourseNoticeEvent(event: NostrEvent): CourseNotice | null {
+  if (event.kind !== GOLF_KINDS.COURSE_NOTICE) return null;
+
+  const tag = (name: string) => event.tags.find((t: string[]) => t[0] === name)?.[1];
+  const noticeId = tag('d');
+  const [kind, courseAuthor, ...courseIdParts] = (tag('a') ?? '').split(':');
+  const courseId = courseIdParts.join(':');
+  const startsAt = parseInt(tag('starts') ?? '') * 1000;
+  const endsAt = parseInt(tag('ends') ?? '') * 1000;
+  if (!noticeId || kind !== String(GOLF_KINDS.COURSE) || !courseId || isNaN(startsAt) || isNaN(endsAt)) return null;
+
+  const type = tag('type');
+  return {
+    noticeId,
+    courseId,
+    courseAuthor,
+    courseName: tag('course') ?? '',
+    type: (['closure', 'aeration', 'maintenance', 'frost'].includes(type ?? '') ? type : 'other') as NoticeType,
+    title: tag('title') ?? '',
+    details: event.content,
+    startsAt,
+    endsAt,
+    holes: event.tags
+      .filter((t: string[]) => t[0] === 'hole')
+      .map((t: string[]) => parseInt(t[1]))
+      .filter((hole: number) => !isNaN(hole)),
+    cancelled: tag('status') === 'cancelled',
+    createdAt: event.created_at * 1000,
+  };
+}
+
+/**
+ * Create the day's green report for a course, published by the course author.
+ * It expires (NIP-40) once it is too old to be shown as current.
+ */
+export function createGreenReportEvent(reading: Omit<GreenReading, 'createdAt'>): NostrEvent {
+  const [year, month, day] = reading.date.split('-').map(Number);
+  const expiresAt = new Date(year, month - 1, day + GREEN_READING_MAX_AGE_DAYS + 1).getTime();
+  return {
+    kind: GOLF_KINDS.GREEN_REPORT,
+    pubkey: reading.courseAuthor,
+    created_at: Math.floor(Date.now() / 1000),
+    tags: [
+      ['d', `${reading.courseId}:${reading.date}`],
+      ['a', `${GOLF_KINDS.COURSE}:${reading.courseAuthor}:${reading.courseId}`],
+      ['date', reading.date],
+      ['stimp', String(reading.stimp)],
+      ['firmness', reading.firmness],
+      ['expiration', String(Math.floor(expiresAt / 1000))],
+      ['t', 'golf'],
+      ['alt', `Green speed ${reading.stimp} ft on ${reading.date}`],
+    ],
+    content: reading.note,
+  };
+}
+
+/**
+ * Parse a green report
+ */
+export function parseGreenReportEvent(event: NostrEvent): GreenReading | null {
+  if (event.kind !== GOLF_KINDS.GREEN_REPORT) return null;
+
+  const tag = (name: string) => event.tags.find((t: string[]) => t[0] === name)?.[1];
+  const [kind, courseAuthor, ...courseIdParts] = (tag('a') ?? '').split(':');
+  const courseId = courseIdParts.join(':');
+  const date = tag('date');
+  const stimp = parseFloat(tag('stimp') ?? '');
+  if (kind !== String(GOLF_KINDS.COURSE) || !courseId || !date || !/^\d{4}-\d{2}-\d{2}$/.test(date) || !(stimp > 0)) return null;
+
+  const firmness = tag('firmness');
+  return {
+    courseId,
+    courseAuthor,
+    date,
+    stimp,
+    firmness: (firmness === 'soft' || firmness === 'firm' ? firmness : 'medium') as GreenFirmness,
+    note: event.content,
+    createdAt: event.created_at * 1000,
+  };
+}
+
+/**
+ * Create a course's rental inventory, published by the course author.
+ * Republishing replaces the whole inventory.
+ */
+export function createRentalInventoryEvent(inventory: Omit<RentalInventory, 'createdAt'>): NostrEvent {
+  return {
+    kind: GOLF_KINDS.RENTAL_INVENTORY,
+    pubkey: inventory.courseAuthor,
+    created_at: Math.floor(Date.now() / 1000),
+    tags: [
+      ['d', inventory.courseId],
+      ['a', `${GOLF_KINDS.COURSE}:${inventory.courseAuthor}:${inventory.courseId}`],
+      ['currency', inventory.currency],
+      // ['item', <id>, <type>, <name>, <units>, <price>]
+      ...inventory.items.map(item => ['item', item.id, item.type, item.name, String(item.count), String(item.price)]),
+      ['t', 'golf'],
+      ['alt', 'Golf equipment rental inventory'],
+    ],
+    content: '',
+  };
+}
+
+/**
+ * Parse a course's rental inventory
+ */
+export function parseRentalInventoryEvent(event: NostrEvent): RentalInventory | null {
+  if (event.kind !== GOLF_KINDS.RENTAL_INVENTORY) return null;
+
+  const tag = (name: string) => event.tags.find((t: string[]) => t[0] === name)?.[1];
+  const [kind, courseAuthor, ...courseIdParts] = (tag('a') ?? '').split(':');
+  const courseId = courseIdParts.join(':');
+  if (kind !== String(GOLF_KINDS.COURSE) || !courseId) return null;
+
+  return {
+    courseId,
+    courseAuthor,
+    currency: tag('currency') ?? 'USD',
+    items: event.tags
+      .filter((t: string[]) => t[0] === 'item' && t[1] && t.length >= 5)
+      .map((t: string[]) => ({
+        id: t[1],
+        type: (['buggy', 'trolley', 'clubs'].includes(t[2]) ? t[2] : 'other') as RentalItemType,
+        name: t[3],
+        count: parseInt(t[4]) || 0,
+        price: parseFloat(t[5] ?? '') || 0,
+      })),
+    createdAt: event.created_at * 1000,
+  };
+}
+
+/**
+ * Create a member's reservation of rental equipment
+ */
+export function createRentalReservationEvent(reservation: Omit<RentalReservation, 'createdAt'>): NostrEvent {
+  return {
+    kind: GOLF_KINDS.RENTAL_RESERVATION,
+    pubkey: reservation.renter,
+    created_at: Math.floor(Date.now() / 1000),
+    tags: [
+      ['d', reservation.reservationId],
+      ['a', `${GOLF_KINDS.COURSE}:${reservation.courseAuthor}:${reservation.courseId}`],
+      ['p', reservation.courseAuthor],
+      ['item', reservation.itemId],
+      ['quantity', String(reservation.quantity)],
+      ['starts', String(Math.floor(reservation.startsAt / 1000))],
+      ['ends', String(Math.floor(reservation.endsAt / 1000))],
+      ...(reservation.cancelled ? [['status', 'cancelled']] : []),
+      ['t', 'golf'],
+      ['alt', 'Golf equipment rental reservation'],
+    ],
+    content: '',
+  };
+}
+
+/**
+ * Parse a rental reservation
+ */
+export function parseRentalReservationEvent(event: NostrEvent): RentalReservation | null {
+  if (event.kind !== GOLF_KINDS.RENTAL_RESERVATION) return null;
+
+  const tag = (name: string) => event.tags.find((t: string[]) => t[0] === name)?.[1];
+  const reservationId = tag('d');
+  const itemId = tag('item');
+  const [kind, courseAuthor, ...courseIdParts] = (tag('a') ?? '').split(':');
+  const courseId = courseIdParts.join(':');
+  const startsAt = parseInt(tag('starts') ?? '') * 1000;
+  const endsAt = parseInt(tag('ends') ?? '') * 1000;
+  if (!reservationId || !itemId || kind !== String(GOLF_KINDS.COURSE) || !courseId || isNaN(startsAt) || isNaN(endsAt)) return null;
+
+  return {
+    reservationId,
+    courseId,
+    courseAuthor,
+    renter: event.pubkey,
+    itemId,
+    quantity: parseInt(tag('quantity') ?? '1') || 1,
+    startsAt,
+    endsAt,
+    cancelled: tag('status') === 'cancelled',
+    createdAt: event.created_at * 1000,
+  };
+}
+
+/**
+ * Create a teaching pro's lesson slot. Republishing with `cancelled`
+ * withdraws it. It expires (NIP-40) when the lesson ends.
+ */
+export function createLessonSlotEvent(slot: Omit<LessonSlot, 'createdAt'>): NostrEvent {
+  return {
+    kind: GOLF_KINDS.LESSON_SLOT,
+    pubkey: slot.pro,
+    created_at: Math.floor(Date.now() / 1000),
+    tags: [
+      ['d', slot.slotId],
+      ['starts', String(Math.floor(slot.startsAt / 1000))],
+      ['ends', String(Math.floor(slot.endsAt / 1000))],
+      ['expiration', String(Math.floor(slot.endsAt / 1000))],
+      ['price', String(slot.price), slot.currency],
+      ...(slot.location ? [['location', slot.location]] : []),
+      ...(slot.cancelled ? [['status', 'cancelled']] : []),
+      ['t', 'golf'],
+      ['t', 'lesson'],
+      ['alt', `Golf lesson slot at ${new Date(slot.startsAt).toISOString()}`],
+    ],
+    content: slot.note,
+  };
+}
+
+/**
+ * Parse a lesson slot
+ */
+export function parseLessonSlotEvent(event: NostrEvent): LessonSlot | null {
+  if (event.kind !== GOLF_KINDS.LESSON_SLOT) return null;
+
+  const tag = (name: string) => event.tags.find((t: string[]) => t[0] === name);
+  const slotId = tag('d')?.[1];
+  const startsAt = parseInt(tag('starts')?.[1] ?? '') * 1000;
+  const endsAt = parseInt(tag('ends')?.[1] ?? '') * 1000;
+  if (!slotId || isNaN(startsAt) || isNaN(endsAt) || endsAt <= startsAt) return null;
+
+  const price = tag('price');
+  return {
+    slotId,
+    pro: event.pubkey,
+    startsAt,
+    endsAt,
+    price: parseFloat(price?.[1] ?? '') || 0,
+    currency: price?.[2] ?? 'USD',
+    location: tag('location')?.[1] ?? '',
+    note: event.content,
+    cancelled: tag('status')?.[1] === 'cancelled',
+    createdAt: event.created_at * 1000,
+  };
+}
+
+/**
+ * Create a member's booking of a lesson slot. Republishing with
+ * `cancelled` withdraws it.
+ */
+export function createLessonBookingEvent(booking: Omit<LessonBooking, 'createdAt'>): NostrEvent {
+  return {
+    kind: GOLF_KINDS.LESSON_BOOKING,
+    pubkey: booking.member,
+    created_at: Math.floor(Date.now() / 1000),
+    tags: [
+      ['d', booking.bookingId],
+      ['a', `${GOLF_KINDS.LESSON_SLOT}:${booking.pro}:${booking.slotId}`],
+      ['p', booking.pro],
+      ...(booking.cancelled ? [['status', 'cancelled']] : []),
+      ['t', 'golf'],
+      ['alt', 'Golf lesson booking'],
+    ],
+    content: booking.note,
+  };
+}
+
+/**
+ * Parse a lesson booking
+ */
+export function parseLessonBookingEvent(event: NostrEvent): LessonBooking | null {
+  if (event.kind !== GOLF_KINDS.LESSON_BOOKING) return null;
+
+  const tag = (name: string) => event.tags.find((t: string[]) => t[0] === name)?.[1];
+  const bookingId = tag('d');
+  const [kind, pro, ...slotIdParts] = (tag('a') ?? '').split(':');
+  const slotId = slotIdParts.join(':');
+  if (!bookingId || kind !== String(GOLF_KINDS.LESSON_SLOT) || !pro || !slotId) return null;
+
+  return {
+    bookingId,
+    slotId,
+    pro,
+    member: event.pubkey,
+    note: event.content,
+    cancelled: tag('status') === 'cancelled',
+    createdAt: event.created_at * 1000,
+  };
+}
+
+/**
+ * Create a player's report of a course condition. `imeta` holds the
+ * uploaded photos' NIP-94 tags (NIP-92).
+ */
+export function createConditionReportEvent(report: Omit<ConditionReport, 'createdAt' | 'images'>, photos: string[][][] = []): NostrEvent {
+  return {
+    kind: GOLF_KINDS.CONDITION_REPORT,
+    pubkey: report.reporter,
+    created_at: Math.floor(Date.now() / 1000),
+    tags: [
+      ['d', report.reportId],
+      ['a', `${GOLF_KINDS.COURSE}:${report.courseAuthor}:${report.courseId}`],
+      ['p', report.courseAuthor],
+      ['hole', String(report.hole)],
+      ['type', report.type],
+      ...photos.map(tags => ['imeta', ...tags.map(t => t.join(' '))]),
+      ['t', 'golf'],
+      ['alt', `Course condition report: hole ${report.hole}`],
+    ],
+    content: report.description,
+  };
+}
+
+/**
+ * Parse a course condition report
+ */
+export function parseConditionReportEvent(event: NostrEvent): ConditionReport | null {
+  if (event.kind !== GOLF_KINDS.CONDITION_REPORT) return null;
+
+  const tag = (name: string) => event.tags.find((t: string[]) => t[0] === name)?.[1];
+  const reportId = tag('d');
+  const [kind, courseAuthor, ...courseIdParts] = (tag('a') ?? '').split(':');
+  const courseId = courseIdParts.join(':');
+  const hole = parseInt(tag('hole') ?? '');
+  if (!reportId || kind !== String(GOLF_KINDS.COURSE) || !courseId || !(hole > 0)) return null;
+
+  const type = tag('type');
+  return {
+    reportId,
+    courseId,
+    courseAuthor,
+    reporter: event.pubkey,
+    hole,
+    type: (['gur', 'temporary-green', 'bunker-washout', 'standing-water'].includes(type ?? '') ? type : 'other') as ConditionType,
+    description: event.content,
+    images: event.tags
+      .filter((t: string[]) => t[0] === 'imeta')
+      .map((t: string[]) => t.find(entry => entry.startsWith('url '))?.slice(4))
+      .filter((url): url is string => !!url),
+    createdAt: event.created_at * 1000,
+  };
+}
+
+/**
+ * Create an urgent alert for everyone on a course, published by the course
+ * author. It expires on its own (NIP-40); republishing with `cleared`
+ * gives the all clear sooner.
+ */
+export function createCourseAlertEvent(alert: Omit<CourseAlert, 'createdAt'>): NostrEvent {
+  return {
+    kind: GOLF_KINDS.COURSE_ALERT,
+    pubkey: alert.courseAuthor,
+    created_at: Math.floor(Date.now() / 1000),
+    tags: [
+      ['d', alert.alertId],
+      ['a', `${GOLF_KINDS.COURSE}:${alert.courseAuthor}:${alert.courseId}`],
+      ['type', alert.type],
+      ...(alert.hole ? [['hole', String(alert.hole)]] : []),
+      ...(alert.location ? [['location', String(alert.location.lat), String(alert.location.lon)]] : []),
+      ['expiration', String(Math.floor(alert.expiresAt / 1000))],
+      ...(alert.cleared ? [['status', 'cleared']] : []),
+      ['t', 'golf'],
+      ['alt', `Course alert: ${alert.type}`],
+    ],
+    content: alert.message,
+  };
+}
+
+/**
+ * Parse a course alert
+ */
+export function parseCourseAlertEvent(event: NostrEvent): CourseAlert | null {
+  if (event.kind !== GOLF_KINDS.COURSE_ALERT) return null;
+
+  const tag = (name: string) => event.tags.find((t: string[]) => t[0] === name);
+  const alertId = tag('d')?.[1];
+  const [kind, courseAuthor, ...courseIdParts] = (tag('a')?.[1] ?? '').split(':');
+  const courseId = courseIdParts.join(':');
+  const expiresAt = parseInt(tag('expiration')?.[1] ?? '') * 1000;
+  if (!alertId || kind !== String(GOLF_KINDS.COURSE) || !courseId || isNaN(expiresAt)) return null;
+
+  const type = tag('type')?.[1];
+  const hole = parseInt(tag('hole')?.[1] ?? '');
+  const location = tag('location');
+  const lat = parseFloat(location?.[1] ?? '');
+  const lon = parseFloat(location?.[2] ?? '');
+  return {
+    alertId,
+    courseId,
+    courseAuthor,
+    type: (['lightning', 'medical', 'wildlife', 'hazard'].includes(type ?? '') ? type : 'other') as AlertType,
+    message: event.content,
+    ...(hole > 0 ? { hole } : {}),
+    ...(!isNaN(lat) && !isNaN(lon) ? { location: { lat, lon } } : {}),
+    expiresAt,
+    cleared: tag('status')?.[1] === 'cleared',
+    createdAt: event.created_at * 1000,
+  };
+}
+
+/**
+ * Create a course's green fee rate card, published by the course author.
+ * The pricing rules go in the content as JSON; republishing replaces them.
+ */
+export function createRateCardEvent(card: Omit<RateCard, 'createdAt'>): NostrEvent {
+  return {
+    kind: GOLF_KINDS.RATE_CARD,
+    pubkey: card.courseAuthor,
+    created_at: Math.floor(Date.now() / 1000),
+    tags: [
+      ['d', card.courseId],
+      ['a', `${GOLF_KINDS.COURSE}:${card.courseAuthor}:${card.courseId}`],
+      ['fee', String(card.baseFee), card.currency],
+      ...card.memberClasses.map(memberClass => ['class', memberClass]),
+      ['t', 'golf'],
+      ['alt', 'Golf course green fees'],
+    ],
+    content: JSON.stringify({ rules: card.rules }),
+  };
+}
+
+/**
+ * Parse a course's green fee rate card
+ */
+export function parseRateCardEvent(event: NostrEvent): RateCard | null {
+  if (event.kind !== GOLF_KINDS.RATE_CARD) return null;
+
+  const tag = (name: string) => event.tags.find((t: string[]) => t[0] === name);
+  const [kind, courseAuthor, ...courseIdParts] = (tag('a')?.[1] ?? '').split(':');
+  const courseId = courseIdParts.join(':');
+  const baseFee = parseFloat(tag('fee')?.[1] ?? '');
+  if (kind !== String(GOLF_KINDS.COURSE) || !courseId || isNaN(baseFee)) return null;
+
+  let rules: PricingRule[] = [];
+  try {
+    const content = JSON.parse(event.content || '{}');
+    if (Array.isArray(content.rules)) {
+      rules = content.rules.filter((r: PricingRule) => r && typeof r.id === 'string' && typeof r.value === 'number' &&
+        ['percent', 'amount', 'fixed'].includes(r.adjustment));
+    }
+  } catch {
+    return null;
+  }
+
+  return {
+    courseId,
+    courseAuthor,
+    currency: tag('fee')?.[2] ?? 'USD',
+    baseFee,
+    memberClasses: event.tags.filter((t: string[]) => t[0] === 'class' && t[1]).map((t: string[]) => t[1]),
+    rules,
+    createdAt: event.created_at * 1000,
+  };
+}
+
+/**
+ * Create a gift voucher, published by the course author. Only a hash of
+ * the code is published; republish with a `status` of `void` to cancel it.
+ */
+export function createVoucherEvent(voucher: Omit<Voucher, 'createdAt'>): NostrEvent {
+  return {
+    kind: GOLF_KINDS.VOUCHER,
+    pubkey: voucher.courseAuthor,
+    created_at: Math.floor(Date.now() / 1000),
+    tags: [
+      ['d', voucher.voucherId],
+      ['a', `${GOLF_KINDS.COURSE}:${voucher.courseAuthor}:${voucher.courseId}`],
+      ['amount', String(voucher.amount), voucher.kind, voucher.currency],
+      ['recipient', voucher.recipient],
+      ['issued', String(Math.floor(voucher.issuedAt / 1000))],
+      ['expires', String(Math.floor(voucher.expiresAt / 1000))],
+      ['code-hash', voucher.codeHash],
+      ...(voucher.voided ? [['status', 'void']] : []),
+      ['t', 'golf'],
+      ['alt', `Golf gift voucher for ${voucher.recipient}`],
+    ],
+    content: voucher.message,
+  };
+}
+
+/**
+ * Parse a gift voucher
+ */
+export function parseVoucherEvent(event: NostrEvent): Voucher | null {
+  if (event.kind !== GOLF_KINDS.VOUCHER) return null;
+
+  const tag = (name: string) => event.tags.find((t: string[]) => t[0] === name);
+  const voucherId = tag('d')?.[1];
+  const [kind, courseAuthor, ...courseIdParts] = (tag('a')?.[1] ?? '').split(':');
+  const courseId = courseIdParts.join(':');
+  const amount = tag('amount');
+  const value = parseFloat(amount?.[1] ?? '');
+  const expiresAt = parseInt(tag('expires')?.[1] ?? '') * 1000;
+  const codeHash = tag('code-hash')?.[1];
+  if (!voucherId || kind !== String(GOLF_KINDS.COURSE) || !courseId || isNaN(value) || isNaN(expiresAt) || !codeHash) return null;
+
+  return {
+    voucherId,
+    courseId,
+    courseAuthor,
+    kind: amount?.[2] === 'rounds' ? 'rounds' : 'value',
+    amount: value,
+    currency: amount?.[3] ?? 'USD',
+    recipient: tag('recipient')?.[1] ?? '',
+    message: event.content,
+    expiresAt,
+    codeHash,
+    voided: tag('status')?.[1] === 'void',
+    issuedAt: (parseInt(tag('issued')?.[1] ?? '') || event.created_at) * 1000,
+    createdAt: event.created_at * 1000,
+  };
+}
+
+/**
+ * Create a redemption against a gift voucher, published by the course
+ * author at the pro shop
+ */
+export function createVoucherRedemptionEvent(redemption: Omit<VoucherRedemption, 'createdAt'>): NostrEvent {
+  return {
+    kind: GOLF_KINDS.VOUCHER_REDEMPTION,
+    pubkey: redemption.courseAuthor,
+    created_at: Math.floor(Date.now() / 1000),
+    tags: [
+      ['d', redemption.redemptionId],
+      ['a', `${GOLF_KINDS.COURSE}:${redemption.courseAuthor}:${redemption.courseId}`],
+      ['voucher', redemption.voucherId],
+      ['amount', String(redemption.amount)],
+      ['t', 'golf'],
+      ['alt', 'Golf gift voucher redemption'],
+    ],
+    content: redemption.note,
+  };
+}
+
+/**
+ * Parse a redemption against a gift voucher
+ */
+export function parseVoucherRedemptionEvent(event: NostrEvent): VoucherRedemption | null {
+  if (event.kind !== GOLF_KINDS.VOUCHER_REDEMPTION) return null;
+
+  const tag = (name: string) => event.tags.find((t: string[]) => t[0] === name)?.[1];
+  const redemptionId = tag('d');
+  const voucherId = tag('voucher');
+  const [kind, courseAuthor, ...courseIdParts] = (tag('a') ?? '').split(':');
+  const courseId = courseIdParts.join(':');
+  const amount = parseFloat(tag('amount') ?? '');
+  if (!redemptionId || !voucherId || kind !== String(GOLF_KINDS.COURSE) || !courseId || !(amount > 0)) return null;
+
+  return {
+    redemptionId,
+    voucherId,
+    courseId,
+    courseAuthor,
+    amount,
+    note: event.content,
+    createdAt: event.created_at * 1000,
+  };
+}
+
+/**
+ * Create a member's privacy settings: what they consent to, with when they
+ * last changed it, and how long their data is kept
+ */
+export function createPrivacyEvent(settings: Omit<PrivacySettings, 'createdAt'>, pubkey: string): NostrEvent {
+  return {
+    kind: GOLF_KINDS.PRIVACY,
+    pubkey,
+    created_at: Math.floor(Date.now() / 1000),
+    tags: [
+      ['d', 'privacy'],
+      ...settings.consents.map(c => ['consent', c.purpose, c.granted ? 'granted' : 'withdrawn', String(Math.floor(c.at / 1000))]),
+      ...(settings.gpsRetentionDays !== null ? [['retention', 'gps', String(settings.gpsRetentionDays)]] : []),
+      ...(settings.personalRetentionDays !== null ? [['retention', 'personal', String(settings.personalRetentionDays)]] : []),
+      ['t', 'golf'],
+      ['alt', 'Golf privacy settings'],
+    ],
+    content: '',
+  };
+}
+
+/**
+ * Parse a member's privacy settings
+ */
+export function parsePrivacyEvent(event: NostrEvent): PrivacySettings | null {
+  if (event.kind !== GOLF_KINDS.PRIVACY) return null;
+  if (event.tags.find((t: string[]) => t[0] === 'd')?.[1] !== 'privacy') return null;
+
+  const retention = (category: string) => {
+    const days = parseInt(event.tags.find((t: string[]) => t[0] === 'retention' && t[1] === category)?.[2] ?? '');
+    return isNaN(days) ? null : days;
+  };
+
+  return {
+    consents: event.tags
+      .filter((t: string[]) => t[0] === 'consent' && t[1] in CONSENT_PURPOSES)
+      .map((t: string[]) => ({
+        purpose: t[1] as ConsentPurpose,
+        granted: t[2] === 'granted',
+        at: (parseInt(t[3]) || event.created_at) * 1000,
+      })),
+    gpsRetentionDays: retention('gps'),
+    personalRetentionDays: retention('personal'),
+    createdAt: event.created_at * 1000,
+  };
+}
+
+/**
+ * Create a scorer device credential: the tournament organizer binds a
+ * tablet's key to a draw group. Republishing with `until` in the past revokes it.
+ */
+export function createScorerDeviceEvent(device: Omit<ScorerDevice, 'createdAt'>): NostrEvent {
+  return {
+    kind: GOLF_KINDS.SCORER_DEVICE,
+    pubkey: device.issuer,
+    created_at: Math.floor(Date.now() / 1000),
+    tags: [
+      ['d', device.device],
+      ['p', device.device],
+      ['tournament', device.tournamentId],
+      ['group', String(device.group)],
+      ['valid-from', String(device.since)],
+      ['valid-until', String(device.until)],
+      ['t', 'golf'],
+      ['alt', `Scorer device for group ${device.group} until ${new Date(device.until * 1000).toISOString()}`],
+    ],
+    content: device.label,
+  };
+}
+
+/**
+ * Parse a scorer device credential
+ */
+export function parseScorerDeviceEvent(event: NostrEvent): ScorerDevice | null {
+  if (event.kind !== GOLF_KINDS.SCORER_DEVICE) return null;
+
+  const tag = (name: string) => event.tags.find((t: string[]) => t[0] === name)?.[1];
+  const device = tag('p');
+  const tournamentId = tag('tournament');
+  const group = parseInt(tag('group') ?? '');
+  const since = parseInt(tag('valid-from') ?? '');
+  const until = parseInt(tag('valid-until') ?? '');
+  if (!device || !tournamentId || isNaN(group) || isNaN(since) || isNaN(until)) return null;
+
+  return {
+    device,
+    issuer: event.pubkey,
+    tournamentId,
+    group,
+    since,
+    until,
+    label: event.content,
+    createdAt: event.created_at * 1000,
+  };
+}
+
+/**
+ * Create a virtual tournament event. Republished with `closed` and the final
+ * results once the submission deadline has passed.
+ */
+export function createVirtualTournamentEvent(tournament: Omit<VirtualTournament, 'createdAt'>): NostrEvent {
+  return {
+    kind: GOLF_KINDS.VIRTUAL_TOURNAMENT,
+    pubkey: tournament.organizer,
+    created_at: Math.floor(Date.now() / 1000),
+    tags: [
+      ['d', tournament.tournamentId],
+      ['name', tournament.name],
+      ['opens', String(Math.floor(tournament.opens / 1000))],
+      ['closes', String(Math.floor(tournament.closes / 1000))],
+      ['deadline', String(Math.floor(tournament.deadline / 1000))],
+      ['status', tournament.closed ? 'closed' : 'open'],
+      ['t', 'golf'],
+      ['t', 'virtual-tournament'],
+      ['alt', `Virtual tournament: ${tournament.name}`],
+    ],
+    content: tournament.closed ? JSON.stringify({ results: tournament.results }) : '',
+  };
+}
+
+/**
+ * Parse a virtual tournament event
+ */
+export function parseVirtualTournamentEvent(event: NostrEvent): VirtualTournament | null {
+  if (event.kind !== GOLF_KINDS.VIRTUAL_TOURNAMENT) return null;
+
+  const tag = (name: string) => event.tags.find((t: string[]) => t[0] === name)?.[1];
+  const tournamentId = tag('d');
+  const opens = parseInt(tag('opens') ?? '');
+  const closes = parseInt(tag('closes') ?? '');
+  const deadline = parseInt(tag('deadline') ?? '');
+  if (!tournamentId || isNaN(opens) || isNaN(closes) || isNaN(deadline)) return null;
+
+  let results: VirtualStanding[] = [];
+  try {
+    const content = JSON.parse(event.content || '{}');
+    if (Array.isArray(content.results)) results = content.results;
+  } catch {
+    // Open tournaments have no results yet
+  }
+
+  return {
+    tournamentId,
+    organizer: event.pubkey,
+    name: tag('name') ?? tournamentId,
+    opens: opens * 1000,
+    closes: closes * 1000,
+    deadline: deadline * 1000,
+    closed: tag('status') === 'closed',
+    results,
+    createdAt: event.created_at * 1000,
+  };
+}
+
+/**
+ * Create a virtual tournament entry: the card a player submits, with the
+ * course rating, slope and handicap index it is normalized by. One entry per
+ * player per tournament; a later entry replaces it.
+ */
+export function createVirtualEntryEvent(entry: Omit<VirtualEntry, 'submittedAt'>, organizer: string): NostrEvent {
+  return {
+    kind: GOLF_KINDS.VIRTUAL_ENTRY,
+    pubkey: entry.player,
+    created_at: Math.floor(Date.now() / 1000),
+    tags: [
+      ['d', entry.tournamentId],
+      ['a', `${GOLF_KINDS.VIRTUAL_TOURNAMENT}:${organizer}:${entry.tournamentId}`],
+      ['p', organizer],
+      ['round', entry.roundId],
+      ['course', entry.course],
+      ['rating', String(entry.courseRating)],
+      ['slope', String(entry.slope)],
+      ['handicap', String(entry.handicapIndex)],
+      ['gross', String(entry.gross)],
+      ['played', String(Math.floor(entry.playedAt / 1000))],
+      ['t', 'golf'],
+      ['alt', `Virtual tournament card: ${entry.gross} at ${entry.course}`],
+    ],
+    content: '',
+  };
+}
+
+/**
+ * Parse a virtual tournament entry
+ */
+export function parseVirtualEntryEvent(event: NostrEvent): VirtualEntry | null {
+  if (event.kind !== GOLF_KINDS.VIRTUAL_ENTRY) return null;
+
+  const tag = (name: string) => event.tags.find((t: string[]) => t[0] === name)?.[1];
+  const tournamentId = tag('d');
+  const roundId = tag('round');
+  const courseRating = parseFloat(tag('rating') ?? '');
+  const slope = parseInt(tag('slope') ?? '');
+  const handicapIndex = parseFloat(tag('handicap') ?? '');
+  const gross = parseInt(tag('gross') ?? '');
+  const played = parseInt(tag('played') ?? '');
+  if (!tournamentId || !roundId || [courseRating, slope, handicapIndex, gross, played].some(isNaN)) return null;
+
+  return {
+    tournamentId,
+    player: event.pubkey,
+    roundId,
+    course: tag('course') ?? '',
+    courseRating,
+    slope,
+    handicapIndex,
+    gross,
+    playedAt: played * 1000,
+    submittedAt: event.created_at * 1000,
+  };
+}
+
+/**
+ * Create a fantasy league event. The commissioner republishes it as results
+ * come in; they are kept in the content, by major.
+ */
+export function createFantasyLeagueEvent(league: Omit<FantasyLeague, 'createdAt'>): NostrEvent {
+  return {
+    kind: GOLF_KINDS.FANTASY_LEAGUE,
+    pubkey: league.commissioner,
+    created_at: Math.floor(Date.now() / 1000),
+    tags: [
+      ['d', league.leagueId],
+      ['name', league.name],
+      ['fee', String(league.entryFeeSats)],
+      ['picks', String(league.picksPerMajor)],
+      ['payout', ...league.payout.map(String)],
+      ['source', league.source],
+      ...league.majors.map(m => ['major', m.eventId, String(Math.floor(m.startsAt / 1000)), m.name]),
+      ['t', 'golf'],
+      ['t', 'fantasy'],
+      ['alt', `Fantasy golf league: ${league.name}`],
+    ],
+    content: JSON.stringify({ results: league.results }),
+  };
+}
+
+/**
+ * Parse a fantasy league event
+ */
+export function parseFantasyLeagueEvent(event: NostrEvent): FantasyLeague | null {
+  if (event.kind !== GOLF_KINDS.FANTASY_LEAGUE) return null;
+
+  const tag = (name: string) => event.tags.find((t: string[]) => t[0] === name);
+  const leagueId = tag('d')?.[1];
+  const entryFeeSats = parseInt(tag('fee')?.[1] ?? '');
+  const picksPerMajor = parseInt(tag('picks')?.[1] ?? '');
+  if (!leagueId || isNaN(entryFeeSats) || isNaN(picksPerMajor)) return null;
+
+  const majors: FantasyMajor[] = event.tags
+    .filter((t: string[]) => t[0] === 'major' && t[1] && !isNaN(parseInt(t[2])))
+    .map((t: string[]) => ({ eventId: t[1], name: t[3] || t[1], startsAt: parseInt(t[2]) * 1000 }));
+
+  let results: { [eventId: string]: ProResult[] } = {};
+  try {
+    const content = JSON.parse(event.content || '{}');
+    if (content.results && typeof content.results === 'object') results = content.results;
+  } catch {
+    // A league without results yet
+  }
+
+  return {
+    leagueId,
+    commissioner: event.pubkey,
+    name: tag('name')?.[1] ?? leagueId,
+    entryFeeSats,
+    picksPerMajor,
+    payout: (tag('payout') ?? []).slice(1).map(Number).filter(n => !isNaN(n)),
+    source: tag('source')?.[1] ?? 'manual',
+    majors,
+    results,
+    createdAt: event.created_at * 1000,
+  };
+}
+
+/**
+ * Create a member's picks for one major of a fantasy league
+ */
+export function createFantasyPicksEvent(picks: Omit<FantasyPicks, 'pickedAt'>, commissioner: string): NostrEvent {
+  return {
+    kind: GOLF_KINDS.FANTASY_PICKS,
+    pubkey: picks.member,
+    created_at: Math.floor(Date.now() / 1000),
+    tags: [
+      ['d', `${picks.leagueId}:${picks.eventId}`],
+      ['a', `${GOLF_KINDS.FANTASY_LEAGUE}:${commissioner}:${picks.leagueId}`],
+      ['league', picks.leagueId],
+      ['event', picks.eventId],
+      ...picks.pros.map(pro => ['pick', pro]),
+      ['t', 'golf'],
+      ['alt', `Fantasy golf picks: ${picks.pros.join(', ')}`],
+    ],
+    content: '',
+  };
+}
+
+/**
+ * Parse a member's fantasy picks
+ */
+export function parseFantasyPicksEvent(event: NostrEvent): FantasyPicks | null {
+  if (event.kind !== GOLF_KINDS.FANTASY_PICKS) return null;
+
+  const leagueId = event.tags.find((t: string[]) => t[0] === 'league')?.[1];
+  const eventId = event.tags.find((t: string[]) => t[0] === 'event')?.[1];
+  if (!leagueId || !eventId) return null;
+
+  return {
+    leagueId,
+    eventId,
+    member: event.pubkey,
+    pros: event.tags.filter((t: string[]) => t[0] === 'pick' && t[1]).map((t: string[]) => t[1]),
+    pickedAt: event.created_at * 1000,
+  };
+}
+
+/**
+ * Create a player's prediction for one of a round's prediction games
+ */
+export function createPredictionEvent(prediction: Omit<Prediction, 'predictedAt'>): NostrEvent {
+  return {
+    kind: GOLF_KINDS.PREDICTION,
+    pubkey: prediction.predictor,
+    created_at: Math.floor(Date.now() / 1000),
+    tags: [
+      ['d', `${prediction.roundId}:${prediction.game}`],
+      ['round', prediction.roundId],
+      ['game', prediction.game],
+      ['value', String(prediction.value)],
+      ['t', 'golf'],
+      ['alt', `Golf prediction: ${prediction.value} (${prediction.game})`],
+    ],
+    content: '',
+  };
+}
+
+/**
+ * Parse a player's prediction
+ */
+export function parsePredictionEvent(event: NostrEvent): Prediction | null {
+  if (event.kind !== GOLF_KINDS.PREDICTION) return null;
+
+  const roundId = event.tags.find((t: string[]) => t[0] === 'round')?.[1];
+  const game = event.tags.find((t: string[]) => t[0] === 'game')?.[1] as PredictionGame | undefined;
+  const value = Number(event.tags.find((t: string[]) => t[0] === 'value')?.[1]);
+  if (!roundId || (game !== 'own-score' && game !== 'group-birdies') || !Number.isFinite(value) || value < 0) return null;
+
+  return {
+    roundId,
+    predictor: event.pubkey,
+    game,
+    value,
+    predictedAt: event.created_at * 1000,
+  };
+}
+
+/**
+ * Create a team event. Every player is `p`-tagged so they can find it.
+ */
+export function createTeamEventEvent(teamEvent: Omit<TeamEvent, 'createdAt'>): NostrEvent {
+  const { config } = teamEvent;
+  const players = [...config.teams['Team A'].players, ...config.teams['Team B'].players];
+  return {
+    kind: GOLF_KINDS.TEAM_EVENT,
+    pubkey: teamEvent.organizer,
+    created_at: Math.floor(Date.now() / 1000),
+    tags: [
+      ['d', teamEvent.eventId],
+      ['name', config.name],
+      ...Object.entries(teamEvent.sessionRounds).map(([sessionId, roundId]) => ['session', sessionId, roundId]),
+      ...players.map(p => ['p', p]),
+      ['t', 'golf'],
+      ['t', 'team-event'],
+      ['alt', `Golf team event: ${config.name}`],
+    ],
+    content: JSON.stringify({
+      teams: config.teams,
+      sessions: config.sessions,
+      holder: config.holder,
+      useNet: config.useNet,
+    }),
+  };
+}
+
+/**
+ * Parse a team event
+ */
+export function parseTeamEventEvent(event: NostrEvent): TeamEvent | null {
+  if (event.kind !== GOLF_KINDS.TEAM_EVENT) return null;
+
+  const eventId = event.tags.find((t: string[]) => t[0] === 'd')?.[1];
+  if (!eventId) return null;
+
+  try {
+    const content = JSON.parse(event.content || '{}');
+    if (!content.teams?.['Team A'] || !content.teams?.['Team B'] || !Array.isArray(content.sessions)) return null;
+
+    const config: TeamEventConfig = {
+      name: event.tags.find((t: string[]) => t[0] === 'name')?.[1] ?? eventId,
+      teams: content.teams,
+      sessions: content.sessions,
+      holder: content.holder === 'Team A' || content.holder === 'Team B' ? content.holder : undefined,
+      useNet: content.useNet === true ? true : undefined,
+    };
+    const sessionRounds: Record<string, string> = {};
+    for (const t of event.tags) {
+      if (t[0] === 'session' && t[1] && t[2]) sessionRounds[t[1]] = t[2];
+    }
+
+    return { eventId, organizer: event.pubkey, config, sessionRounds, createdAt: event.created_at * 1000 };
+  } catch {
+    return null;
+  }
+}
+
+export interface PlayerScoreRecord {
+  roundId: string;
+  playerPubkey: string;
+  scores: { [hole: number]: number };
+  putts: { [hole: number]: number };
+  pars: { [hole: number]: number };
+  fairways: { [hole: number]: boolean };
+  greens: { [hole: number]: boolean };
+  penalties: { [hole: number]: number };
+  updatedAt: number;
+}
+
+/**
+ * Parse a player score event. Accepts both content shapes in use:
+ * `{ scores: { "1": 4 } }` and `{ holes: [{ hole: 1, strokes: 4, putts: 2 }] }`.
+ * Per-hole stats (par, fairways, greens, penalties) are only available in the `holes` shape.
+ */
+export function parsePlayerScoreEvent(event: NostrEvent): PlayerScoreRecord | null {
+  if (event.kind !== GOLF_KINDS.PLAYER_SCORE) return null;
+
+  const roundId = scoreCardRound(event);
+  if (!roundId) return null;
+
+  try {
+    const content = JSON.parse(event.content || '{}');
+    const scores: { [hole: number]: number } = {};
+    const putts: { [hole: number]: number } = {};
+    const pars: { [hole: number]: number } = {};
+    const fairways: { [hole: number]: boolean } = {};
+    const greens: { [hole: number]: boolean } = {};
+    const penalties: { [hole: number]: number } = {};
+
+    for (const [hole, strokes] of Object.entries(content.scores || {})) {
+      if (typeof strokes === 'number' && strokes > 0) scores[Number(hole)] = strokes;
+    }
+
+    if (Array.isArray(content.holes)) {
+      for (const h of content.holes) {
+        if (typeof h?.hole !== 'number') continue;
+        if (typeof h.strokes === 'number' && h.strokes > 0) scores[h.hole] = h.strokes;
+        if (typeof h.putts === 'number') putts[h.hole] = h.putts;
+        if (typeof h.par === 'number') pars[h.hole] = h.par;
+        if (typeof h.fairways === 'boolean') fairways[h.hole] = h.fairways;
+        if (typeof h.greens === 'boolean') greens[h.hole] = h.greens;
+        if (typeof h.penalties === 'number') penalties[h.hole] = h.penalties;
+      }
+    }
+
+    return {
+      roundId,
+      playerPubkey: event.pubkey, // author must be the player
+      scores,
+      putts,
+      pars,
+      fairways,
+      greens,
+      penalties,
+      updatedAt: content.updatedAt || event.created_at * 1000,
+    };
+  } catch {
+    return null;
+  }
+}
+
 /**
  * Parse a round event
  */
@@ -211,6 +1973,7 @@ export function parseRoundEvent(event: NostrEvent): GolfRound | null {
   const dateTag = tags.find((t: string[]) => t[0] === 'date')?.[1];
   const gameModeTag = tags.find((t: string[]) => t[0] === 'game-mode')?.[1] as GameMode;
   const statusTag = tags.find((t: string[]) => t[0] === 'status')?.[1] as 'active' | 'completed' | 'cancelled';
+  const teeTimeTag = tags.find((t: string[]) => t[0] === 'tee-time')?.[1];
 
   if (!dTag || !titleTag || !courseTag || !dateTag || !gameModeTag || !statusTag) {
     return null;
@@ -229,6 +1992,11 @@ export function parseRoundEvent(event: NostrEvent): GolfRound | null {
       courseLocation: tags.find((t: string[]) => t[0] === 'location')?.[1],
       teeBox: tags.find((t: string[]) => t[0] === 'tee-box')?.[1],
       weather: tags.find((t: string[]) => t[0] === 'weather')?.[1],
+      teeTime: teeTimeTag ? parseInt(teeTimeTag) * 1000 : undefined,
+      sideCompetitions: tags
+        .filter((t: string[]) => t[0] === 'side-comp' && (t[1] === 'closest-to-pin' || t[1] === 'longest-drive'))
+        .map((t: string[]) => ({ type: t[1] as SideCompetitionType, hole: parseInt(t[2]), prizeSats: parseInt(t[3]) || 0 }))
+        .filter(c => !isNaN(c.hole)),
       notes: event.content,
     },
   };
@@ -275,6 +2043,15 @@ export function generateRoundId(): string {
   return `round-${Date.now()}-${Math.random().toString(36).substr(2, 9)}`;
 }
 
+/**
+ * Whether an event is past its NIP-40 expiration. Relays that don't support
+ * NIP-40 keep serving expired events, so readers drop them themselves.
+ */
+export function isExpired(event: NostrEvent, now = Date.now()): boolean {
+  const expiration = parseInt(event.tags.find((t: string[]) => t[0] === 'expiration')?.[1] ?? '');
+  return !isNaN(expiration) && expiration * 1000 <= now;
+}
+
 /**
  * Validate golf event tags
  */
@@ -308,6 +2085,140 @@ export function validateGolfEvent(event: NostrEvent): boolean {
       return !!event.tags.find((t: string[]) => t[0] === 'd' && t[1]) &&
              !!event.tags.find((t: string[]) => t[0] === 'badge' && t[1]);
 
+    case GOLF_KINDS.DRAW:
+      return !!event.tags.find((t: string[]) => t[0] === 'd' && t[1]) &&
+             !!event.tags.find((t: string[]) => t[0] === 'tournament' && t[1]);
+
+    case GOLF_KINDS.HANDICAP_PENALTY:
+      return !!event.tags.find((t: string[]) => t[0] === 'd' && t[1]) &&
+             !!event.tags.find((t: string[]) => t[0] === 'p' && t[1]) &&
+             !!event.tags.find((t: string[]) => t[0] === 'differential' && t[1]);
+
+    case GOLF_KINDS.GOLF_BAG:
+      return !!event.tags.find((t: string[]) => t[0] === 'd' && t[1]);
+
+    case GOLF_KINDS.SHOT:
+      return !!event.tags.find((t: string[]) => t[0] === 'd' && t[1]) &&
+             !!event.tags.find((t: string[]) => t[0] === 'club' && t[1]) &&
+             !!event.tags.find((t: string[]) => t[0] === 'distance' && t[1]);
+
+    case GOLF_KINDS.PRACTICE_SESSION:
+      return !!event.tags.find((t: string[]) => t[0] === 'd' && t[1]) &&
+             !!event.tags.find((t: string[]) => t[0] === 'type' && t[1]);
+
+    case GOLF_KINDS.SCORE_ATTESTATION:
+      return !!event.tags.find((t: string[]) => t[0] === 'p' && t[1]) &&
+             !!event.tags.find((t: string[]) => t[0] === 'round' && t[1]) &&
+             !!event.tags.find((t: string[]) => t[0] === 'verdict' && t[1]);
+
+    case GOLF_KINDS.DISPUTE:
+      return !!event.tags.find((t: string[]) => t[0] === 'd' && t[1]) &&
+             !!event.tags.find((t: string[]) => t[0] === 'round' && t[1]);
+
+    case GOLF_KINDS.RULING:
+      return !!event.tags.find((t: string[]) => t[0] === 'round' && t[1]) &&
+             !!event.tags.find((t: string[]) => t[0] === 'decision' && t[1]);
+
+    case GOLF_KINDS.SPONSOR_SLOT:
+      return !!event.tags.find((t: string[]) => t[0] === 'd' && t[1]) &&
+             !!event.tags.find((t: string[]) => t[0] === 'a' && t[1]) &&
+             !!event.tags.find((t: string[]) => t[0] === 'image' && t[1]);
+
+    case GOLF_KINDS.ACE:
+      return !!event.tags.find((t: string[]) => t[0] === 'a' && t[1]) &&
+             !!event.tags.find((t: string[]) => t[0] === 'round' && t[1]) &&
+             !!event.tags.find((t: string[]) => t[0] === 'hole' && t[1]);
+
+    case GOLF_KINDS.ACE_WITNESS:
+      return !!event.tags.find((t: string[]) => t[0] === 'a' && t[1]) &&
+             !!event.tags.find((t: string[]) => t[0] === 'p' && t[1]);
+
+    case GOLF_KINDS.SCORING_DELEGATION:
+      return !!event.tags.find((t: string[]) => t[0] === 'p' && t[1]) &&
+             !!event.tags.find((t: string[]) => t[0] === 'valid-until' && t[1]);
+
+    case GOLF_KINDS.COURSE_NOTICE:
+      return !!event.tags.find((t: string[]) => t[0] === 'a' && t[1]) &&
+             !!event.tags.find((t: string[]) => t[0] === 'starts' && t[1]) &&
+             !!event.tags.find((t: string[]) => t[0] === 'ends' && t[1]);
+
+    case GOLF_KINDS.GREEN_REPORT:
+      return !!event.tags.find((t: string[]) => t[0] === 'a' && t[1]) &&
+             !!event.tags.find((t: string[]) => t[0] === 'stimp' && t[1]);
+
+    case GOLF_KINDS.RENTAL_INVENTORY:
+      return !!event.tags.find((t: string[]) => t[0] === 'a' && t[1]);
+
+    case GOLF_KINDS.RENTAL_RESERVATION:
+      return !!event.tags.find((t: string[]) => t[0] === 'a' && t[1]) &&
+             !!event.tags.find((t: string[]) => t[0] === 'item' && t[1]) &&
+             !!event.tags.find((t: string[]) => t[0] === 'starts' && t[1]) &&
+             !!event.tags.find((t: string[]) => t[0] === 'ends' && t[1]);
+
+    case GOLF_KINDS.LESSON_SLOT:
+      return !!event.tags.find((t: string[]) => t[0] === 'starts' && t[1]) &&
+             !!event.tags.find((t: string[]) => t[0] === 'ends' && t[1]);
+
+    case GOLF_KINDS.LESSON_BOOKING:
+      return !!event.tags.find((t: string[]) => t[0] === 'a' && t[1]) &&
+             !!event.tags.find((t: string[]) => t[0] === 'p' && t[1]);
+
+    case GOLF_KINDS.CONDITION_REPORT:
+      return !!event.tags.find((t: string[]) => t[0] === 'a' && t[1]) &&
+             !!event.tags.find((t: string[]) => t[0] === 'hole' && t[1]);
+
+    case GOLF_KINDS.COURSE_ALERT:
+      return !!event.tags.find((t: string[]) => t[0] === 'a' && t[1]) &&
+             !!event.tags.find((t: string[]) => t[0] === 'expiration' && t[1]);
+
+    case GOLF_KINDS.RATE_CARD:
+      return !!event.tags.find((t: string[]) => t[0] === 'a' && t[1]) &&
+             !!event.tags.find((t: string[]) => t[0] === 'fee' && t[1]);
+
+    case GOLF_KINDS.VOUCHER:
+      return !!event.tags.find((t: string[]) => t[0] === 'a' && t[1]) &&
+             !!event.tags.find((t: string[]) => t[0] === 'amount' && t[1]) &&
+             !!event.tags.find((t: string[]) => t[0] === 'code-hash' && t[1]);
+
+    case GOLF_KINDS.VOUCHER_REDEMPTION:
+      return !!event.tags.find((t: string[]) => t[0] === 'a' && t[1]) &&
+             !!event.tags.find((t: string[]) => t[0] === 'voucher' && t[1]) &&
+             !!event.tags.find((t: string[]) => t[0] === 'amount' && t[1]);
+
+    case GOLF_KINDS.PRIVACY:
+      return event.tags.find((t: string[]) => t[0] === 'd')?.[1] === 'privacy';
+
+    case GOLF_KINDS.SCORER_DEVICE:
+      return !!event.tags.find((t: string[]) => t[0] === 'p' && t[1]) &&
+             !!event.tags.find((t: string[]) => t[0] === 'tournament' && t[1]) &&
+             !!event.tags.find((t: string[]) => t[0] === 'valid-until' && t[1]);
+
+    case GOLF_KINDS.VIRTUAL_TOURNAMENT:
+      return !!event.tags.find((t: string[]) => t[0] === 'd' && t[1]) &&
+             !!event.tags.find((t: string[]) => t[0] === 'deadline' && t[1]);
+
+    case GOLF_KINDS.VIRTUAL_ENTRY:
+      return !!event.tags.find((t: string[]) => t[0] === 'a' && t[1]) &&
+             !!event.tags.find((t: string[]) => t[0] === 'round' && t[1]) &&
+             !!event.tags.find((t: string[]) => t[0] === 'gross' && t[1]);
+
+    case GOLF_KINDS.FANTASY_LEAGUE:
+      return !!event.tags.find((t: string[]) => t[0] === 'd' && t[1]) &&
+             !!event.tags.find((t: string[]) => t[0] === 'fee' && t[1]);
+
+    case GOLF_KINDS.FANTASY_PICKS:
+      return !!event.tags.find((t: string[]) => t[0] === 'a' && t[1]) &&
+             !!event.tags.find((t: string[]) => t[0] === 'event' && t[1]);
+
+    case GOLF_KINDS.PREDICTION:
+      return !!event.tags.find((t: string[]) => t[0] === 'round' && t[1]) &&
+             !!event.tags.find((t: string[]) => t[0] === 'game' && t[1]) &&
+             !!event.tags.find((t: string[]) => t[0] === 'value' && t[1]);
+
+    case GOLF_KINDS.TEAM_EVENT:
+      return !!event.tags.find((t: string[]) => t[0] === 'd' && t[1]) &&
+             !!event.tags.find((t: string[]) => t[0] === 'session' && t[1] && t[2]);
+
     default:
       return false;
   }
@@ -0,0 +1,60 @@
+import { describe, it, expect } from 'vitest';
+import { occupancyAt, occupancyGrid, offPeakHours, peakHour, type TeeTimeRecord } from './occupancyEngine';
+
+// Monday 6 July 2026, local time
+const at = (dayOffset: number, hour: number, minute = 0) => new Date(2026, 6, 6 + dayOffset, hour, minute).getTime();
+
+const record = (roundId: string, teeTime: number, players = 4): TeeTimeRecord => ({ roundId, teeTime, players });
+
+describe('Occupancy Engine', () => {
+  it('should lay out a row per day, Monday first, with a cell per hour', () => {
+    const grid = occupancyGrid([], 1, { firstHour: 7, lastHour: 9 });
+    expect(grid).toHaveLength(7);
+    expect(grid[0].map(c => c.hour)).toEqual([7, 8, 9]);
+    expect(grid[6][0].day).toBe(6);
+  });
+
+  it('should average rounds and players per week', () => {
+    const records = [
+      record('a', at(0, 8, 0)),
+      record('b', at(0, 8, 10), 2),
+      record('c', at(7, 8, 20)), // the next Monday
+      record('d', at(6, 10, 0), 3), // Sunday
+    ];
+    const grid = occupancyGrid(records, 2);
+
+    const mondayEight = grid[0].find(c => c.hour === 8)!;
+    expect(mondayEight.rounds).toBe(1.5);
+    expect(mondayEight.players).toBe(5);
+    expect(grid[6].find(c => c.hour === 10)!.players).toBe(1.5);
+  });
+
+  it('should measure occupancy against tee sheet capacity', () => {
+    const records = Array.from({ length: 6 }, (_, i) => record(`r${i}`, at(5, 9, i * 10)));
+    const grid = occupancyGrid(records, 1);
+    expect(grid[5].find(c => c.hour === 9)!.occupancy).toBe(1);
+
+    const halfFull = occupancyGrid(records, 1, { intervalMinutes: 5 });
+    expect(halfFull[5].find(c => c.hour === 9)!.occupancy).toBe(0.5);
+    expect(occupancyAt(halfFull, at(5, 9, 40))).toBe(0.5);
+    expect(occupancyAt(halfFull, at(5, 22))).toBeUndefined();
+  });
+
+  it('should find the quietest hours within the hours in use', () => {
+    const records = [
+      record('a', at(0, 7)), record('b', at(0, 7, 10)), record('c', at(0, 7, 20)),
+      record('d', at(0, 9)),
+      record('e', at(0, 10)), record('f', at(0, 10, 10)),
+    ];
+    const quiet = offPeakHours(occupancyGrid(records, 1), 2);
+
+    expect(quiet.map(c => c.hour)).toEqual([8, 9]);
+    expect(quiet[0].day).toBe(0);
+  });
+
+  it('should find the busiest hour', () => {
+    const records = [record('a', at(2, 14)), record('b', at(5, 8)), record('c', at(5, 8, 10))];
+    expect(peakHour(occupancyGrid(records, 1))).toMatchObject({ day: 5, hour: 8, rounds: 2 });
+    expect(peakHour(occupancyGrid([], 1))).toBeNull();
+  });
+});
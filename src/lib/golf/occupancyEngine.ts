@@ -0,0 +1,93 @@
+// Tee sheet occupancy: how full the course is by day of week and hour,
+// from the rounds played there, to help the club set off-peak rates
+
+export interface TeeTimeRecord {
+  roundId: string;
+  teeTime: number; // ms
+  players: number;
+}
+
+export interface OccupancyConfig {
+  intervalMinutes?: number; // gap between tee times (default 10)
+  groupSize?: number; // players per tee time (default 4)
+  firstHour?: number; // first hour shown (default 6)
+  lastHour?: number; // last hour shown, inclusive (default 19)
+}
+
+export interface OccupancyCell {
+  day: number; // 0 = Monday
+  hour: number;
+  rounds: number; // average per week
+  players: number; // average per week
+  occupancy: number; // share of player capacity used, 0-1
+}
+
+export const DAY_NAMES = ['Mon', 'Tue', 'Wed', 'Thu', 'Fri', 'Sat', 'Sun'];
+
+/**
+ * Average rounds and players per week for each day and hour, in local time.
+ * Returns one row per day, Monday first, each with a cell per hour.
+ */
+export function occupancyGrid(records: TeeTimeRecord[], weeks: number, config: OccupancyConfig = {}): OccupancyCell[][] {
+  const firstHour = config.firstHour ?? 6;
+  const lastHour = config.lastHour ?? 19;
+  const capacity = (60 / (config.intervalMinutes ?? 10)) * (config.groupSize ?? 4);
+
+  const rounds = new Map<string, number>();
+  const players = new Map<string, number>();
+  for (const record of records) {
+    const at = new Date(record.teeTime);
+    const key = `${(at.getDay() + 6) % 7}:${at.getHours()}`;
+    rounds.set(key, (rounds.get(key) ?? 0) + 1);
+    players.set(key, (players.get(key) ?? 0) + record.players);
+  }
+
+  return DAY_NAMES.map((_, day) => {
+    const row: OccupancyCell[] = [];
+    for (let hour = firstHour; hour <= lastHour; hour++) {
+      const key = `${day}:${hour}`;
+      const perWeek = (players.get(key) ?? 0) / weeks;
+      row.push({
+        day,
+        hour,
+        rounds: (rounds.get(key) ?? 0) / weeks,
+        players: perWeek,
+        occupancy: Math.min(1, perWeek / capacity),
+      });
+    }
+    return row;
+  });
+}
+
+/**
+ * The quietest hours, least full first, within the hours the course is
+ * actually used (the first and last hour anyone tees off on that day)
+ */
+export function offPeakHours(grid: OccupancyCell[][], limit = 5): OccupancyCell[] {
+  const candidates: OccupancyCell[] = [];
+  for (const row of grid) {
+    const used = row.filter(cell => cell.rounds > 0);
+    if (used.length === 0) continue;
+    const first = used[0].hour;
+    const last = used[used.length - 1].hour;
+    candidates.push(...row.filter(cell => cell.hour >= first && cell.hour <= last));
+  }
+  return candidates
+    .sort((a, b) => a.occupancy - b.occupancy || a.day - b.day || a.hour - b.hour)
+    .slice(0, limit);
+}
+
+/** Busiest hour of the week, or null with no rounds */
+export function peakHour(grid: OccupancyCell[][]): OccupancyCell | null {
+  let peak: OccupancyCell | null = null;
+  for (const cell of grid.flat()) {
+    if (cell.rounds > 0 && (!peak || cell.occupancy > peak.occupancy)) peak = cell;
+  }
+  return peak;
+}
+
+/** Expected occupancy at a tee time, or undefined outside the grid's hours */
+export function occupancyAt(grid: OccupancyCell[][], at: number): number | undefined {
+  const date = new Date(at);
+  return grid[(date.getDay() + 6) % 7]?.find(cell => cell.hour === date.getHours())?.occupancy;
+}
@@ -0,0 +1,70 @@
+import { describe, it, expect } from 'vitest';
+import { assembleOsmCourse, buildOverpassQuery, osmToImportedCourse, type OverpassElement } from './osmImport';
+import { destinationPoint, distanceYards, type GeoPoint } from './caddieEngine';
+
+describe('OSM Import', () => {
+  const origin: GeoPoint = { lat: 36.5, lon: -121.9 };
+  // Hole n runs north from a tee 100 yards east of the previous one
+  const tee = (n: number) => destinationPoint(origin, 90, (n - 1) * 100);
+  const holeWay = (n: number, length: number, tags: Record<string, string> = {}): OverpassElement => ({
+    type: 'way',
+    id: n,
+    geometry: [tee(n), destinationPoint(tee(n), 0, length)],
+    tags: { golf: 'hole', ref: String(n), ...tags },
+  });
+  const square = (centre: GeoPoint, id: number, golf: string): OverpassElement => ({
+    type: 'way',
+    id,
+    geometry: [0, 90, 180, 270].map(b => destinationPoint(centre, b, 10)),
+    tags: { golf },
+  });
+
+  it('should build bbox and relation queries', () => {
+    expect(buildOverpassQuery({ bbox: { south: 1, west: 2, north: 3, east: 4 } })).toContain('way["golf"](1,2,3,4)');
+    expect(buildOverpassQuery({ relationId: 123 })).toContain('rel(123);map_to_area->.course;');
+  });
+
+  it('should assemble holes with greens, bunkers and hazards', () => {
+    const greenCentre = destinationPoint(tee(1), 0, 395);
+    const elements: OverpassElement[] = [
+      { type: 'relation', id: 1, tags: { leisure: 'golf_course', name: 'Seaside Links', 'addr:city': 'Monterey' } },
+      holeWay(2, 160, { par: '3' }),
+      holeWay(1, 400, { par: '4', handicap: '5' }),
+      square(greenCentre, 10, 'green'),
+      square(destinationPoint(tee(1), 0, 250), 11, 'bunker'),
+      square(destinationPoint(tee(2), 0, 100), 12, 'water_hazard'),
+      square(destinationPoint(tee(1), 270, 500), 13, 'bunker'), // nowhere near a hole
+    ];
+
+    const course = assembleOsmCourse(elements);
+
+    expect(course.name).toBe('Seaside Links');
+    expect(course.location).toBe('Monterey');
+    expect(course.holes.map(h => h.number)).toEqual([1, 2]);
+    expect(course.holes[0].length).toBe(400);
+    expect(course.holes[0].handicap).toBe(5);
+    expect(distanceYards(course.holes[0].green, greenCentre)).toBeLessThan(1);
+    expect(course.holes[0].bunkers).toHaveLength(1);
+    expect(course.holes[1].waterHazards).toBe(1);
+    expect(course.warnings).toEqual(['Found 2 holes']);
+  });
+
+  it('should skip holes without a number and warn about missing pars', () => {
+    const unnumbered = { ...holeWay(3, 300), tags: { golf: 'hole' } };
+    const course = assembleOsmCourse([holeWay(1, 380), unnumbered]);
+
+    expect(course.holes).toHaveLength(1);
+    expect(course.warnings).toContain('Skipped hole way 3: missing ref or geometry');
+    expect(course.warnings).toContain('No par mapped for holes 1');
+  });
+
+  it('should convert to an importable course, estimating missing pars', () => {
+    const course = assembleOsmCourse([holeWay(1, 180), holeWay(2, 420, { par: '5' }), holeWay(3, 520)]);
+    const imported = osmToImportedCourse(course);
+
+    expect(imported.holes).toEqual({ 1: 3, 2: 5, 3: 5 });
+    expect(imported.tees).toEqual(['OSM']);
+    expect(imported.teeYardages?.OSM[2]).toBe(420);
+    expect(imported.greens?.[1]).toEqual(course.holes[0].green);
+  });
+});
@@ -0,0 +1,208 @@
+// OpenStreetMap course extraction: golf=* geometries from Overpass assembled into holes
+
+import { distanceToLine, distanceYards, type GeoPoint } from './caddieEngine';
+import type { ImportedCourse } from './courseImport';
+
+export const OVERPASS_URL = 'https://overpass-api.de/api/interpreter';
+
+export type OsmArea =
+  | { bbox: { south: number; west: number; north: number; east: number } }
+  | { relationId: number };
+
+export interface OverpassElement {
+  type: 'node' | 'way' | 'relation';
+  id: number;
+  lat?: number;
+  lon?: number;
+  geometry?: { lat: number; lon: number }[];
+  tags?: Record<string, string>;
+}
+
+export interface OsmHole {
+  number: number;
+  par: number | null;
+  handicap: number | null;
+  length: number; // yards along the hole's centre line
+  tee: GeoPoint;
+  green: GeoPoint; // green centroid, or the end of the hole line when no green is mapped
+  bunkers: GeoPoint[];
+  waterHazards: number;
+}
+
+export interface OsmCourse {
+  name: string;
+  location: string;
+  holes: OsmHole[];
+  warnings: string[];
+}
+
+// A green further than this from the end of a hole line belongs to another hole
+const GREEN_MATCH_YARDS = 60;
+// Bunkers and hazards further than this from a hole's line are ignored
+const FEATURE_MATCH_YARDS = 80;
+
+/**
+ * Overpass QL for every golf=* feature in a bounding box or inside a course relation
+ */
+export function buildOverpassQuery(area: OsmArea): string {
+  if ('relationId' in area) {
+    return [
+      '[out:json][timeout:25];',
+      `rel(${area.relationId});map_to_area->.course;`,
+      '(way["golf"](area.course);node["golf"](area.course););',
+      'out geom;',
+      `rel(${area.relationId});out tags;`,
+    ].join('');
+  }
+
+  const { south, west, north, east } = area.bbox;
+  const box = `(${south},${west},${north},${east})`;
+  return [
+    '[out:json][timeout:25];',
+    `(way["golf"]${box};node["golf"]${box};way["leisure"="golf_course"]${box};rel["leisure"="golf_course"]${box};);`,
+    'out geom;',
+  ].join('');
+}
+
+function centroid(points: GeoPoint[]): GeoPoint {
+  const sum = points.reduce((acc, p) => ({ lat: acc.lat + p.lat, lon: acc.lon + p.lon }), { lat: 0, lon: 0 });
+  return { lat: sum.lat / points.length, lon: sum.lon / points.length };
+}
+
+function elementPoints(element: OverpassElement): GeoPoint[] {
+  if (element.geometry && element.geometry.length > 0) return element.geometry;
+  if (element.lat !== undefined && element.lon !== undefined) return [{ lat: element.lat, lon: element.lon }];
+  return [];
+}
+
+function lineLength(line: GeoPoint[]): number {
+  let total = 0;
+  for (let i = 1; i < line.length; i++) total += distanceYards(line[i - 1], line[i]);
+  return total;
+}
+
+const parseNumber = (value?: string) => {
+  const n = parseInt(value ?? '');
+  return isNaN(n) ? null : n;
+};
+
+/**
+ * Assemble holes from Overpass elements. Holes come from golf=hole ways
+ * (tee to green, `ref` = hole number); greens, bunkers and water hazards are
+ * attached to the nearest hole.
+ */
+export function assembleOsmCourse(elements: OverpassElement[]): OsmCourse {
+  const warnings: string[] = [];
+  const byGolf = (value: string) => elements.filter(e => e.tags?.golf === value);
+
+  const courseElement = elements.find(e => e.tags?.leisure === 'golf_course');
+  const tags = courseElement?.tags ?? {};
+  const name = tags.name || 'Unnamed Course';
+  const location = [tags['addr:city'], tags['addr:state'], tags['addr:country']].filter(Boolean).join(', ');
+
+  const holeLines = byGolf('hole')
+    .map(e => ({ element: e, line: elementPoints(e), number: parseNumber(e.tags?.ref) }))
+    .filter(h => {
+      if (h.number === null || h.line.length < 2) {
+        warnings.push(`Skipped hole way ${h.element.id}: missing ref or geometry`);
+        return false;
+      }
+      return true;
+    });
+
+  const holes: OsmHole[] = holeLines.map(h => ({
+    number: h.number!,
+    par: parseNumber(h.element.tags?.par),
+    handicap: parseNumber(h.element.tags?.handicap),
+    length: Math.round(lineLength(h.line)),
+    tee: h.line[0],
+    green: h.line[h.line.length - 1],
+    bunkers: [],
+    waterHazards: 0,
+  }));
+
+  const nearestHole = (point: GeoPoint, maxYards: number): number | null => {
+    let best: number | null = null;
+    let bestDistance = maxYards;
+    for (let i = 0; i < holeLines.length; i++) {
+      const distance = distanceToLine(point, holeLines[i].line);
+      if (distance <= bestDistance) {
+        best = i;
+        bestDistance = distance;
+      }
+    }
+    return best;
+  };
+
+  for (const green of byGolf('green')) {
+    const points = elementPoints(green);
+    if (points.length === 0) continue;
+    const centre = centroid(points);
+
+    let match: number | null = null;
+    let matchDistance = GREEN_MATCH_YARDS;
+    for (let i = 0; i < holes.length; i++) {
+      const line = holeLines[i].line;
+      const distance = distanceYards(centre, line[line.length - 1]);
+      if (distance <= matchDistance) {
+        match = i;
+        matchDistance = distance;
+      }
+    }
+    if (match !== null) holes[match].green = centre;
+  }
+
+  for (const bunker of byGolf('bunker')) {
+    const points = elementPoints(bunker);
+    if (points.length === 0) continue;
+    const centre = centroid(points);
+    const match = nearestHole(centre, FEATURE_MATCH_YARDS);
+    if (match !== null) holes[match].bunkers.push(centre);
+  }
+
+  for (const hazard of [...byGolf('water_hazard'), ...byGolf('lateral_water_hazard')]) {
+    const points = elementPoints(hazard);
+    if (points.length === 0) continue;
+    const match = nearestHole(centroid(points), FEATURE_MATCH_YARDS);
+    if (match !== null) holes[match].waterHazards++;
+  }
+
+  holes.sort((a, b) => a.number - b.number);
+
+  const numbers = holes.map(h => h.number);
+  const duplicates = numbers.filter((n, i) => numbers.indexOf(n) !== i);
+  if (duplicates.length > 0) warnings.push(`Duplicate hole numbers: ${[...new Set(duplicates)].join(', ')}`);
+  if (holes.length !== 9 && holes.length !== 18) warnings.push(`Found ${holes.length} holes`);
+  const missingPar = holes.filter(h => h.par === null).map(h => h.number);
+  if (missingPar.length > 0) warnings.push(`No par mapped for holes ${missingPar.join(', ')}`);
+
+  return { name, location, holes, warnings };
+}
+
+/**
+ * Convert an OSM course to an importable course record. Holes without a
+ * mapped par are estimated from their length.
+ */
+export function osmToImportedCourse(course: OsmCourse, teeName: string = 'OSM'): ImportedCourse {
+  const holes: { [hole: number]: number } = {};
+  const handicaps: { [hole: number]: number } = {};
+  const yardages: { [hole: number]: number } = {};
+  const greens: { [hole: number]: GeoPoint } = {};
+
+  for (const hole of course.holes) {
+    holes[hole.number] = hole.par ?? (hole.length < 250 ? 3 : hole.length < 470 ? 4 : 5);
+    if (hole.handicap !== null) handicaps[hole.number] = hole.handicap;
+    yardages[hole.number] = hole.length;
+    greens[hole.number] = hole.green;
+  }
+
+  return {
+    name: course.name,
+    location: course.location,
+    holes,
+    handicaps: Object.keys(handicaps).length > 0 ? handicaps : undefined,
+    tees: [teeName],
+    teeYardages: { [teeName]: yardages },
+    greens,
+  };
+}
@@ -0,0 +1,80 @@
+import { describe, it, expect } from 'vitest';
+import { calculateCoursePace, expectedFinishTimes, groupProgress, type GroupProgress } from './paceEngine';
+
+describe('Pace Engine', () => {
+  const pars = [4, 4, 3, 5, 4, 4, 3, 4, 5];
+  const minute = 60 * 1000;
+  const teeTime = 1_000_000_000_000;
+
+  it('should accumulate expected hole times by par', () => {
+    expect(expectedFinishTimes([4, 3, 5])).toEqual([14, 25, 42]);
+    expect(expectedFinishTimes([4, 3], { parMinutes: { 3: 8 } })).toEqual([14, 22]);
+  });
+
+  it('should count holes finished by every player', () => {
+    const progress = groupProgress('r1', teeTime, [1, 2, 3], [
+      { scores: { 1: 4, 2: 5, 3: 3 }, updatedAt: teeTime + 40 * minute },
+      { scores: { 1: 5, 2: 4 }, updatedAt: teeTime + 30 * minute },
+    ]);
+
+    expect(progress.holesCompleted).toBe(2);
+    expect(progress.lastCompletedAt).toBe(teeTime + 40 * minute);
+    expect(groupProgress('r2', teeTime, [1, 2], []).holesCompleted).toBe(0);
+  });
+
+  const group = (groupId: string, start: number, holesCompleted: number, lastAt: number | null): GroupProgress => ({
+    groupId,
+    teeTime: teeTime + start * minute,
+    holesCompleted,
+    lastCompletedAt: lastAt === null ? null : teeTime + lastAt * minute,
+  });
+
+  it('should report on-pace and ahead groups', () => {
+    // Expected: 14, 28, 39 ...
+    const [onPace, ahead] = calculateCoursePace([
+      group('a', 0, 2, 29),
+      group('b', 10, 3, 35),
+    ], pars, teeTime + 32 * minute);
+
+    expect(onPace.status).toBe('on-pace');
+    expect(onPace.currentHole).toBe(3);
+    expect(onPace.minutesBehind).toBe(1);
+    expect(ahead.status).toBe('ahead');
+    expect(ahead.gapToGroupAhead).toBe(-1);
+  });
+
+  it('should flag a slow group with open holes ahead as out of position', () => {
+    const pace = calculateCoursePace([
+      group('lead', 0, 6, 80),
+      group('slow', 10, 2, 60), // finished hole 2 fifty minutes in, expected 28
+      group('stuck', 20, 1, 50), // behind, but right behind the slow group
+    ], pars, teeTime + 85 * minute);
+
+    expect(pace.map(g => g.status)).toEqual(['on-pace', 'out-of-position', 'behind']);
+    expect(pace[1].gapToGroupAhead).toBe(4);
+    expect(pace[1].minutesBehind).toBe(36); // 3rd hole was due 39 minutes in; it is now 75
+    expect(pace[2].gapToGroupAhead).toBe(1);
+  });
+
+  it('should handle groups not started or finished', () => {
+    const pace = calculateCoursePace([
+      group('done', 0, 9, 120),
+      group('later', 60, 0, null),
+    ], pars, teeTime + 30 * minute);
+
+    expect(pace[0].status).toBe('finished');
+    expect(pace[0].currentHole).toBeNull();
+    expect(pace[1].status).toBe('not-started');
+    expect(pace[1].gapToGroupAhead).toBeNull();
+  });
+
+  it('should stop the clock during a suspension of play', () => {
+    const groups = [group('a', 0, 2, 29)];
+    const suspensions = [{ start: teeTime + 30 * minute, end: teeTime + 90 * minute }];
+
+    expect(calculateCoursePace(groups, pars, teeTime + 92 * minute)[0].status).toBe('out-of-position');
+    const [paused] = calculateCoursePace(groups, pars, teeTime + 92 * minute, { suspensions });
+    expect(paused.status).toBe('on-pace');
+    expect(paused.minutesBehind).toBe(1);
+  });
+});
@@ -0,0 +1,136 @@
+// Pace of play: group progress against expected hole times from the tee time
+
+export interface PaceConfig {
+  parMinutes?: { [par: number]: number }; // expected minutes per hole by par
+  behindMinutes?: number; // minutes over schedule before a group counts as behind (default 10)
+  openHoles?: number; // gap to the group ahead, in holes, that makes a behind group out of position (default 1)
+  suspensions?: { start: number; end: number }[]; // ms; play stopped, so the clock doesn't run
+}
+
+export const DEFAULT_PAR_MINUTES: { [par: number]: number } = { 3: 11, 4: 14, 5: 17 };
+
+export type PaceStatus = 'not-started' | 'ahead' | 'on-pace' | 'behind' | 'out-of-position' | 'finished';
+
+export interface GroupProgress {
+  groupId: string; // round id
+  teeTime: number; // ms
+  holesCompleted: number; // holes finished, in playing order
+  lastCompletedAt: number | null; // ms, when the last hole was finished
+}
+
+export interface GroupPace extends GroupProgress {
+  currentHole: number | null; // hole being played, in course numbering
+  expectedHolesCompleted: number;
+  minutesBehind: number; // negative when ahead of schedule
+  gapToGroupAhead: number | null; // holes between this group and the one in front
+  status: PaceStatus;
+}
+
+/**
+ * Expected elapsed minutes at the end of each hole, in playing order
+ */
+export function expectedFinishTimes(pars: number[], config: PaceConfig = {}): number[] {
+  const minutes = { ...DEFAULT_PAR_MINUTES, ...config.parMinutes };
+  let total = 0;
+  return pars.map(par => (total += minutes[par] ?? minutes[4]));
+}
+
+/**
+ * Group progress from its players' scores: a hole counts as finished once
+ * every player has a score for it (holes in playing order).
+ */
+export function groupProgress(
+  groupId: string,
+  teeTime: number,
+  playOrder: number[],
+  players: { scores: { [hole: number]: number }; updatedAt: number }[]
+): GroupProgress {
+  let holesCompleted = 0;
+  if (players.length > 0) {
+    while (
+      holesCompleted < playOrder.length &&
+      players.every(p => (p.scores[playOrder[holesCompleted]] ?? 0) > 0)
+    ) {
+      holesCompleted++;
+    }
+  }
+
+  return {
+    groupId,
+    teeTime,
+    holesCompleted,
+    lastCompletedAt: holesCompleted > 0 ? Math.max(...players.map(p => p.updatedAt)) : null,
+  };
+}
+
+/**
+ * Minutes between two times that fell inside a suspension of play
+ */
+export function suspendedMinutes(suspensions: { start: number; end: number }[], from: number, to: number): number {
+  let total = 0;
+  for (const s of suspensions) {
+    total += Math.max(0, Math.min(s.end, to) - Math.max(s.start, from));
+  }
+  return total / 60000;
+}
+
+function paceFor(group: GroupProgress, playOrder: number[], finishTimes: number[], now: number, behind: number, suspensions: { start: number; end: number }[]) {
+  // Playing time since the tee time, with suspensions taken out
+  const playedUntil = (at: number) => (at - group.teeTime) / 60000 - suspendedMinutes(suspensions, group.teeTime, at);
+  const elapsed = playedUntil(now);
+  const expectedHolesCompleted = finishTimes.filter(t => t <= elapsed).length;
+  const k = group.holesCompleted;
+
+  // How late the last hole was finished, and how overdue the current one is
+  const completionDelay = k > 0 && group.lastCompletedAt !== null
+    ? playedUntil(group.lastCompletedAt) - finishTimes[k - 1]
+    : -Infinity;
+  const overdue = k < finishTimes.length ? elapsed - finishTimes[k] : -Infinity;
+  const minutesBehind = Math.round(Math.max(completionDelay, overdue, k === 0 ? elapsed - finishTimes[0] : -Infinity));
+
+  let status: PaceStatus;
+  if (now < group.teeTime) status = 'not-started';
+  else if (k >= playOrder.length) status = 'finished';
+  else if (minutesBehind > behind) status = 'behind';
+  else if (minutesBehind < -behind / 2) status = 'ahead';
+  else status = 'on-pace';
+
+  return {
+    currentHole: now < group.teeTime || k >= playOrder.length ? null : playOrder[k],
+    expectedHolesCompleted,
+    minutesBehind: Number.isFinite(minutesBehind) ? minutesBehind : 0,
+    status,
+  };
+}
+
+/**
+ * Pace for every group on the course. Groups are ordered by tee time; a group
+ * that is behind with open holes in front of it is out of position.
+ */
+export function calculateCoursePace(
+  groups: GroupProgress[],
+  pars: number[],
+  now: number,
+  config: PaceConfig = {},
+  playOrder: number[] = pars.map((_, i) => i + 1)
+): GroupPace[] {
+  const orderedPars = playOrder.map(hole => pars[hole - 1]);
+  const finishTimes = expectedFinishTimes(orderedPars, config);
+  const behind = config.behindMinutes ?? 10;
+  const openHoles = config.openHoles ?? 1;
+
+  const sorted = [...groups].sort((a, b) => a.teeTime - b.teeTime);
+  return sorted.map((group, index) => {
+    const pace = paceFor(group, playOrder, finishTimes, now, behind, config.suspensions ?? []);
+
+    // Nearest group ahead that is still on the course
+    const ahead = sorted.slice(0, index).reverse().find(g => g.holesCompleted < playOrder.length);
+    const gapToGroupAhead = ahead ? ahead.holesCompleted - group.holesCompleted : null;
+
+    const status: PaceStatus = pace.status === 'behind' && (gapToGroupAhead === null || gapToGroupAhead > openHoles)
+      ? 'out-of-position'
+      : pace.status;
+
+    return { ...group, ...pace, gapToGroupAhead, status };
+  });
+}
@@ -0,0 +1,52 @@
+import { describe, it, expect } from 'vitest';
+import { DEFAULT_PAYOUT_POLICY, planPayouts, prizesFromLedger, receiptPaysPrize, type PayoutPolicy, type Prize } from './payoutEngine';
+
+describe('Payout Engine', () => {
+  const prize = (prizeId: string, amountSats: number): Prize => ({ prizeId, recipient: prizeId, amountSats, memo: 'Skins winnings' });
+  const automatic: PayoutPolicy = { mode: 'automatic', maxPerPayoutSats: 1000, maxDailySats: 1500 };
+
+  it('should pay each winner their net winnings', () => {
+    const prizes = prizesFromLedger([
+      { from: 'bob', to: 'alice', amount: 100 },
+      { from: 'carol', to: 'alice', amount: 100 },
+      { from: 'alice', to: 'bob', amount: 50 },
+      { from: 'carol', to: 'bob', amount: 0 },
+    ], 'r1', 'Skins');
+    expect(prizes).toEqual([
+      { prizeId: 'r1:Skins:alice', recipient: 'alice', amountSats: 150, memo: 'Skins winnings' },
+    ]);
+  });
+
+  it('should wait for approval of everything by default', () => {
+    const plan = planPayouts([prize('a', 100)], DEFAULT_PAYOUT_POLICY, [], 0);
+    expect(plan).toEqual({ automatic: [], approval: [prize('a', 100)] });
+  });
+
+  it('should pay automatically within the per-payout and daily limits', () => {
+    const plan = planPayouts([prize('a', 900), prize('b', 2000), prize('c', 500), prize('d', 200)], automatic, [], 0);
+    expect(plan.automatic.map(p => p.prizeId)).toEqual(['a', 'c']);
+    expect(plan.approval.map(p => p.prizeId)).toEqual(['b', 'd']);
+  });
+
+  it('should count payouts from the last 24 hours against the daily limit', () => {
+    const day = 24 * 60 * 60 * 1000;
+    const recent = [{ prizeId: 'x', recipient: 'x', amountSats: 1000, paidAt: day }];
+    expect(planPayouts([prize('a', 600)], automatic, recent, day + 1000).approval).toHaveLength(1);
+    expect(planPayouts([prize('a', 600)], automatic, recent, 2 * day + 1).automatic).toHaveLength(1);
+  });
+
+  it('should never plan a prize twice', () => {
+    const history = [{ prizeId: 'a', recipient: 'a', amountSats: 100, paidAt: 0 }];
+    const plan = planPayouts([prize('a', 100), prize('b', 100), prize('b', 100)], automatic, history, 0);
+    expect(plan.automatic.map(p => p.prizeId)).toEqual(['b']);
+    expect(plan.approval).toEqual([]);
+  });
+
+  it('should only count a receipt for the whole prize as paying it', () => {
+    const receipt = { prizeId: 'a', recipient: 'a', amountMsats: 100000 };
+    expect(receiptPaysPrize(prize('a', 100), receipt)).toBe(true);
+    expect(receiptPaysPrize(prize('a', 100), { ...receipt, amountMsats: 1000 })).toBe(false);
+    expect(receiptPaysPrize(prize('a', 100), { ...receipt, amountMsats: 99999 })).toBe(false);
+    expect(receiptPaysPrize(prize('a', 100), { ...receipt, recipient: 'b' })).toBe(false);
+  });
+});
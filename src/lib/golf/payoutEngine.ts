@@ -0,0 +1,91 @@
+// Prize payouts: what a club wallet pays winners, within spending limits
+
+import { netting, type Payable } from './scoringEngine';
+
+export interface Prize {
+  prizeId: string; // stable, so a prize is never paid twice
+  recipient: string; // pubkey
+  amountSats: number;
+  memo: string;
+}
+
+export interface PayoutPolicy {
+  mode: 'automatic' | 'approval'; // approval: every payout waits for the organizer
+  maxPerPayoutSats: number; // larger prizes always wait for approval
+  maxDailySats: number; // automatic payouts stop once this much went out in 24 hours
+}
+
+export interface PayoutRecord {
+  prizeId: string;
+  recipient: string;
+  amountSats: number;
+  paidAt: number; // ms
+}
+
+export interface PayoutPlan {
+  automatic: Prize[];
+  approval: Prize[];
+}
+
+export const DEFAULT_PAYOUT_POLICY: PayoutPolicy = {
+  mode: 'approval',
+  maxPerPayoutSats: 10000,
+  maxDailySats: 50000,
+};
+
+const DAY_MS = 24 * 60 * 60 * 1000;
+
+/**
+ * Prizes from a game's ledger: each winner is paid their net winnings (what
+ * the ledger sends them less what they owe), by the club instead of by the
+ * other players
+ */
+export function prizesFromLedger(ledger: Payable[], roundId: string, game: string): Prize[] {
+  const won = new Map<string, number>();
+  for (const p of netting(ledger)) {
+    if (p.amount > 0) won.set(p.to, (won.get(p.to) ?? 0) + p.amount);
+  }
+  return [...won.entries()].map(([recipient, amountSats]) => ({
+    prizeId: `${roundId}:${game}:${recipient}`,
+    recipient,
+    amountSats,
+    memo: `${game} winnings`,
+  }));
+}
+
+/**
+ * Whether a zap receipt pays `prize`: tagged with it, sent to its winner, and
+ * for the whole prize, comparing the invoice's millisats with the prize amount
+ */
+export function receiptPaysPrize(prize: Prize, receipt: { prizeId: string; recipient: string; amountMsats: number }): boolean {
+  return receipt.prizeId === prize.prizeId
+    && receipt.recipient === prize.recipient
+    && receipt.amountMsats === prize.amountSats * 1000;
+}
+
+/**
+ * Split unpaid prizes into those the wallet can pay now and those that wait
+ * for the organizer. Automatic payouts go in order until the daily limit,
+ * counting what was already paid in the last 24 hours.
+ */
+export function planPayouts(prizes: Prize[], policy: PayoutPolicy, history: PayoutRecord[], now: number): PayoutPlan {
+  const paid = new Set(history.map(r => r.prizeId));
+  let spentToday = history
+    .filter(r => now - r.paidAt < DAY_MS)
+    .reduce((sum, r) => sum + r.amountSats, 0);
+
+  const plan: PayoutPlan = { automatic: [], approval: [] };
+  for (const prize of prizes) {
+    if (paid.has(prize.prizeId) || prize.amountSats <= 0) continue;
+    paid.add(prize.prizeId);
+
+    const withinLimits = prize.amountSats <= policy.maxPerPayoutSats && spentToday + prize.amountSats <= policy.maxDailySats;
+    if (policy.mode === 'automatic' && withinLimits) {
+      plan.automatic.push(prize);
+      spentToday += prize.amountSats;
+    } else {
+      plan.approval.push(prize);
+    }
+  }
+  return plan;
+}
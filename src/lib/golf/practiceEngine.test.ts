@@ -0,0 +1,77 @@
+import { describe, it, expect } from 'vitest';
+import {
+  calculatePracticeStats,
+  practiceShotSamples,
+  summarizeByClub,
+  validatePracticeSession,
+  type PracticeSession,
+} from './practiceEngine';
+
+describe('Practice Engine', () => {
+  const session = (sessionId: string, date: number, overrides: Partial<PracticeSession> = {}): PracticeSession => ({
+    sessionId,
+    date,
+    type: 'range',
+    durationMinutes: 45,
+    blocks: [{ clubId: '7i', balls: 30 }, { clubId: 'D', balls: 20 }],
+    ...overrides,
+  });
+
+  it('should validate ball counts and launch monitor shots', () => {
+    expect(validatePracticeSession(session('s1', 0))).toEqual([]);
+    expect(validatePracticeSession(session('s2', 0, { blocks: [] }))).toContain('Add at least one club');
+    expect(validatePracticeSession(session('s3', 0, {
+      blocks: [{ clubId: '7i', balls: 1, shots: [{ carry: 150 }, { carry: 152 }] }],
+    }))).toEqual(['7i: more shots recorded than balls hit']);
+    expect(validatePracticeSession(session('s4', 0, { blocks: [{ clubId: 'PW', balls: 2.5 }] }))).toHaveLength(1);
+  });
+
+  it('should summarise dispersion per club from launch monitor data', () => {
+    const [iron, driver] = summarizeByClub([
+      { clubId: '7i', balls: 20, shots: [{ carry: 150, offline: 4 }, { carry: 160, offline: -2 }, { carry: 155, offline: 4 }] },
+      { clubId: '7i', balls: 10 },
+      { clubId: 'D', balls: 15 },
+    ]);
+
+    expect(iron.clubId).toBe('7i');
+    expect(iron.balls).toBe(30);
+    expect(iron.shotsMeasured).toBe(3);
+    expect(iron.averageCarry).toBe(155);
+    expect(iron.carryDeviation).toBe(5);
+    expect(iron.averageOffline).toBe(2);
+    expect(driver.averageCarry).toBeNull();
+    expect(driver.carryDeviation).toBeNull();
+  });
+
+  it('should total sessions, balls and minutes by type', () => {
+    const stats = calculatePracticeStats([
+      session('s1', 1000),
+      session('s2', 2000, { type: 'putting', durationMinutes: 30, blocks: [{ clubId: 'Putter', balls: 50 }] }),
+    ]);
+
+    expect(stats.sessions).toBe(2);
+    expect(stats.totalBalls).toBe(100);
+    expect(stats.minutesPractised).toBe(75);
+    expect(stats.byType).toEqual({ range: 1, putting: 1 });
+    expect(stats.byClub[0].clubId).toBe('Putter');
+  });
+
+  it('should limit stats to a date range', () => {
+    const sessions = [session('s1', 1000), session('s2', 2000), session('s3', 3000)];
+
+    expect(calculatePracticeStats(sessions, { from: 2000 }).sessions).toBe(2);
+    expect(calculatePracticeStats(sessions, { from: 1500, to: 2500 }).sessions).toBe(1);
+    expect(calculatePracticeStats([], {}).byClub).toEqual([]);
+  });
+
+  it('should turn launch monitor carries into bag samples', () => {
+    const samples = practiceShotSamples([session('s1', 5000, {
+      blocks: [{ clubId: '7i', balls: 3, shots: [{ carry: 150 }, { ballSpeed: 100 }, { carry: 154 }] }],
+    })]);
+
+    expect(samples).toEqual([
+      { shotId: 's1:7i:0', clubId: '7i', distance: 150, timestamp: 5000 },
+      { shotId: 's1:7i:2', clubId: '7i', distance: 154, timestamp: 5000 },
+    ]);
+  });
+});
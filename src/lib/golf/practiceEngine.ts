@@ -0,0 +1,159 @@
+// Practice and range sessions, kept separate from rounds
+
+import type { ShotSample } from './bagEngine';
+
+export type PracticeType = 'range' | 'short-game' | 'putting' | 'simulator';
+
+export interface PracticeShot {
+  carry?: number; // yards
+  total?: number; // yards
+  offline?: number; // yards from target line, positive = right
+  ballSpeed?: number; // mph
+  launchAngle?: number; // degrees
+  spin?: number; // rpm
+}
+
+export interface PracticeBlock {
+  clubId: string; // club id from the player's bag, e.g. "7i"
+  balls: number;
+  shots?: PracticeShot[]; // launch monitor data, when available
+}
+
+export interface PracticeSession {
+  sessionId: string;
+  date: number; // ms
+  type: PracticeType;
+  location?: string;
+  durationMinutes?: number;
+  blocks: PracticeBlock[];
+  notes?: string;
+}
+
+export interface ClubPracticeSummary {
+  clubId: string;
+  balls: number;
+  shotsMeasured: number;
+  averageCarry: number | null;
+  carryDeviation: number | null; // standard deviation (yards)
+  averageOffline: number | null; // mean miss, positive = right
+  offlineDeviation: number | null;
+  ballSpeed: number | null;
+  launchAngle: number | null;
+  spin: number | null;
+}
+
+export interface PracticeStats {
+  sessions: number;
+  totalBalls: number;
+  minutesPractised: number;
+  byType: { [type in PracticeType]?: number }; // sessions per type
+  byClub: ClubPracticeSummary[]; // most practised first
+}
+
+const round1 = (n: number) => Math.round(n * 10) / 10;
+
+function mean(values: number[]): number | null {
+  return values.length > 0 ? round1(values.reduce((sum, v) => sum + v, 0) / values.length) : null;
+}
+
+function deviation(values: number[]): number | null {
+  if (values.length < 2) return null;
+  const m = values.reduce((sum, v) => sum + v, 0) / values.length;
+  return round1(Math.sqrt(values.reduce((sum, v) => sum + (v - m) ** 2, 0) / (values.length - 1)));
+}
+
+/**
+ * Validate a session before saving
+ */
+export function validatePracticeSession(session: PracticeSession): string[] {
+  const errors: string[] = [];
+  if (session.blocks.length === 0) errors.push('Add at least one club');
+
+  for (const block of session.blocks) {
+    if (!block.clubId) errors.push('Every block needs a club');
+    if (!Number.isInteger(block.balls) || block.balls < 1) {
+      errors.push(`${block.clubId || 'Block'}: ball count must be a positive whole number`);
+    }
+    if (block.shots && block.shots.length > block.balls) {
+      errors.push(`${block.clubId}: more shots recorded than balls hit`);
+    }
+  }
+  return errors;
+}
+
+/**
+ * Summarise practice per club. Dispersion needs launch monitor shots.
+ */
+export function summarizeByClub(blocks: PracticeBlock[]): ClubPracticeSummary[] {
+  const byClub = new Map<string, { balls: number; shots: PracticeShot[] }>();
+  for (const block of blocks) {
+    const entry = byClub.get(block.clubId) ?? { balls: 0, shots: [] };
+    entry.balls += block.balls;
+    entry.shots.push(...(block.shots ?? []));
+    byClub.set(block.clubId, entry);
+  }
+
+  return [...byClub.entries()]
+    .map(([clubId, { balls, shots }]) => {
+      const values = (key: keyof PracticeShot) =>
+        shots.map(s => s[key]).filter((v): v is number => typeof v === 'number');
+      return {
+        clubId,
+        balls,
+        shotsMeasured: shots.length,
+        averageCarry: mean(values('carry')),
+        carryDeviation: deviation(values('carry')),
+        averageOffline: mean(values('offline')),
+        offlineDeviation: deviation(values('offline')),
+        ballSpeed: mean(values('ballSpeed')),
+        launchAngle: mean(values('launchAngle')),
+        spin: mean(values('spin')),
+      };
+    })
+    .sort((a, b) => b.balls - a.balls || a.clubId.localeCompare(b.clubId));
+}
+
+/**
+ * Practice statistics over a set of sessions, optionally limited to a date range
+ */
+export function calculatePracticeStats(
+  sessions: PracticeSession[],
+  range: { from?: number; to?: number } = {}
+): PracticeStats {
+  const included = sessions.filter(s =>
+    (range.from === undefined || s.date >= range.from) &&
+    (range.to === undefined || s.date <= range.to)
+  );
+
+  const byType: PracticeStats['byType'] = {};
+  for (const session of included) {
+    byType[session.type] = (byType[session.type] ?? 0) + 1;
+  }
+
+  return {
+    sessions: included.length,
+    totalBalls: included.reduce((sum, s) => sum + s.blocks.reduce((b, block) => b + block.balls, 0), 0),
+    minutesPractised: included.reduce((sum, s) => sum + (s.durationMinutes ?? 0), 0),
+    byType,
+    byClub: summarizeByClub(included.flatMap(s => s.blocks)),
+  };
+}
+
+/**
+ * Launch monitor shots as carry samples for the bag's distance learning
+ */
+export function practiceShotSamples(sessions: PracticeSession[]): ShotSample[] {
+  return sessions.flatMap(session =>
+    session.blocks.flatMap(block =>
+      (block.shots ?? [])
+        .map((shot, index) => ({ shot, index }))
+        .filter(({ shot }) => typeof shot.carry === 'number')
+        .map(({ shot, index }) => ({
+          shotId: `${session.sessionId}:${block.clubId}:${index}`,
+          clubId: block.clubId,
+          distance: shot.carry!,
+          timestamp: session.date,
+        }))
+    )
+  );
+}
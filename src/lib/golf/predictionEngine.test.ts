@@ -0,0 +1,93 @@
+import { describe, it, expect } from 'vitest';
+import {
+  countBirdies,
+  latestPredictions,
+  predictionAchievements,
+  resolvePredictions,
+  type Prediction,
+} from './predictionEngine';
+import { createPredictionEvent, parsePredictionEvent, type PlayerScoreRecord } from './nostrEvents';
+
+const teeTime = 1_780_000_000_000;
+
+function card(playerPubkey: string, scores: number[], pars: number[] = [4, 3, 5]): PlayerScoreRecord {
+  return {
+    roundId: 'r1',
+    playerPubkey,
+    scores: Object.fromEntries(scores.map((s, i) => [i + 1, s])),
+    putts: {},
+    pars: Object.fromEntries(pars.map((p, i) => [i + 1, p])),
+    fairways: {},
+    greens: {},
+    penalties: {},
+    updatedAt: teeTime + 3 * 60 * 60 * 1000,
+  };
+}
+
+function prediction(predictor: string, game: Prediction['game'], value: number, predictedAt = teeTime - 1000): Prediction {
+  return { roundId: 'r1', predictor, game, value, predictedAt };
+}
+
+describe('Prediction Engine', () => {
+  it('should count birdies and better against par', () => {
+    expect(countBirdies(card('a', [3, 3, 3]))).toBe(2);
+    expect(countBirdies({ ...card('a', [3, 2, 4]), pars: {} })).toBe(0);
+  });
+
+  it('should keep each predictor\'s latest prediction made before the lock', () => {
+    const latest = latestPredictions([
+      prediction('a', 'own-score', 12, teeTime - 2000),
+      prediction('a', 'own-score', 13, teeTime - 1000),
+      prediction('a', 'own-score', 10, teeTime + 1000),
+      prediction('a', 'group-birdies', 2),
+    ], teeTime);
+
+    expect(latest.map(p => [p.game, p.value])).toEqual([['own-score', 13], ['group-birdies', 2]]);
+  });
+
+  it('should judge own scores and group birdies, with ties all winning', () => {
+    const cards = [card('a', [4, 3, 4]), card('b', [5, 2, 6])];
+    const results = resolvePredictions([
+      prediction('a', 'own-score', 12),
+      prediction('b', 'own-score', 14),
+      prediction('a', 'group-birdies', 1),
+      prediction('b', 'group-birdies', 3),
+    ], cards, 3, teeTime);
+
+    const own = results.find(r => r.game === 'own-score')!;
+    expect(own.entries.map(e => [e.predictor, e.actual, e.miss])).toEqual([['a', 11, 1], ['b', 13, 1]]);
+    expect(own.winners).toEqual(['a', 'b']);
+    expect(own.exact).toEqual([]);
+
+    const birdies = results.find(r => r.game === 'group-birdies')!;
+    expect(birdies.entries[0].actual).toBe(2);
+    expect(birdies.winners).toEqual(['a', 'b']);
+  });
+
+  it('should not name winners until every card is complete', () => {
+    const results = resolvePredictions(
+      [prediction('a', 'group-birdies', 0)],
+      [card('a', [4, 3, 5]), card('b', [4, 3])],
+      3
+    );
+
+    expect(results[0].entries).toHaveLength(1);
+    expect(results[0].winners).toEqual([]);
+  });
+
+  it('should award badges to winners and exact predictions', () => {
+    const results = resolvePredictions([prediction('a', 'own-score', 12)], [card('a', [4, 3, 5])], 3);
+    const earned = predictionAchievements(results, 'a', 'r1', teeTime);
+
+    expect(earned.map(a => a.badgeId)).toEqual(['closest-prediction', 'called-it']);
+    expect(predictionAchievements(results, 'b', 'r1', teeTime)).toEqual([]);
+  });
+
+  it('should round-trip a prediction through a Nostr event', () => {
+    const p = prediction('a', 'group-birdies', 4, teeTime);
+    const event = { ...createPredictionEvent(p), created_at: p.predictedAt / 1000 };
+
+    expect(parsePredictionEvent(event)).toEqual(p);
+    expect(parsePredictionEvent({ ...event, tags: event.tags.filter(t => t[0] !== 'game') })).toBeNull();
+  });
+});
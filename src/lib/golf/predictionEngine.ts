@@ -0,0 +1,118 @@
+// Prediction games: before a round, each player in the group predicts their
+// own score or how many birdies the group will make. When the cards are in,
+// the closest predictions win badges rather than money, so the games can be
+// played where sweepstakes aren't allowed.
+
+import type { PlayerScoreRecord } from './nostrEvents';
+import type { EarnedAchievement } from './achievementEngine';
+
+export type PredictionGame = 'own-score' | 'group-birdies';
+
+export const PREDICTION_GAME_NAMES: Record<PredictionGame, string> = {
+  'own-score': 'Predict your score',
+  'group-birdies': 'Group birdies',
+};
+
+export interface Prediction {
+  roundId: string;
+  predictor: string;
+  game: PredictionGame;
+  value: number;
+  predictedAt: number; // ms
+}
+
+export interface PredictionEntry {
+  predictor: string;
+  value: number;
+  actual: number; // what the prediction is judged against
+  miss: number;
+}
+
+export interface PredictionResult {
+  game: PredictionGame;
+  entries: PredictionEntry[]; // closest first
+  winners: string[]; // empty until the round is complete; ties all win
+  exact: string[]; // winners who called it exactly
+}
+
+// Badges for the winners, see BadgeService definitions
+export const PREDICTION_BADGES = { winner: 'closest-prediction', exact: 'called-it' };
+
+/** Holes a card has better than par: birdies, eagles and aces */
+export function countBirdies(card: PlayerScoreRecord): number {
+  return Object.entries(card.scores).filter(([hole, strokes]) => {
+    const par = card.pars[Number(hole)];
+    return !!par && strokes < par;
+  }).length;
+}
+
+function isComplete(card: PlayerScoreRecord | undefined, holes: number): boolean {
+  return !!card && Object.keys(card.scores).length >= holes;
+}
+
+/**
+ * Each predictor's latest prediction for each game, made before the round
+ * locked (if it has a lock time)
+ */
+export function latestPredictions(predictions: Prediction[], lockAt?: number): Prediction[] {
+  const latest = new Map<string, Prediction>();
+  for (const p of predictions) {
+    if (lockAt !== undefined && p.predictedAt >= lockAt) continue;
+    const key = `${p.predictor}:${p.game}`;
+    const existing = latest.get(key);
+    if (!existing || p.predictedAt > existing.predictedAt) latest.set(key, p);
+  }
+  return [...latest.values()];
+}
+
+/**
+ * Judge a round's prediction games against its score cards. Own-score
+ * predictions are judged against the predictor's own gross, birdie
+ * predictions against the whole group's birdies. Nobody wins until every
+ * card in the round has all its holes scored.
+ */
+export function resolvePredictions(
+  predictions: Prediction[],
+  cards: PlayerScoreRecord[],
+  holes: number,
+  lockAt?: number
+): PredictionResult[] {
+  const byPlayer = new Map(cards.map(card => [card.playerPubkey, card]));
+  const gross = (card: PlayerScoreRecord) => Object.values(card.scores).reduce((sum, s) => sum + s, 0);
+  const birdies = cards.reduce((sum, card) => sum + countBirdies(card), 0);
+  const complete = cards.length > 0 && cards.every(card => isComplete(card, holes));
+  const valid = latestPredictions(predictions, lockAt);
+
+  return (['own-score', 'group-birdies'] as PredictionGame[])
+    .map(game => {
+      const entries = valid
+        .filter(p => p.game === game)
+        .flatMap(p => {
+          const card = byPlayer.get(p.predictor);
+          if (game === 'own-score' && !card) return [];
+          const actual = game === 'own-score' ? gross(card!) : birdies;
+          return [{ predictor: p.predictor, value: p.value, actual, miss: Math.abs(p.value - actual) }];
+        })
+        .sort((a, b) => a.miss - b.miss);
+
+      const best = entries[0]?.miss;
+      const winners = complete ? entries.filter(e => e.miss === best).map(e => e.predictor) : [];
+      return { game, entries, winners, exact: best === 0 ? winners : [] };
+    })
+    .filter(result => result.entries.length > 0);
+}
+
+/**
+ * Badges a player won in a round's prediction games
+ */
+export function predictionAchievements(results: PredictionResult[], pubkey: string, roundId: string, at: number): EarnedAchievement[] {
+  const earned: EarnedAchievement[] = [];
+  const award = (badgeId: string) => {
+    if (!earned.some(a => a.badgeId === badgeId)) earned.push({ badgeId, roundId, earnedAt: at });
+  };
+  for (const result of results) {
+    if (result.winners.includes(pubkey)) award(PREDICTION_BADGES.winner);
+    if (result.exact.includes(pubkey)) award(PREDICTION_BADGES.exact);
+  }
+  return earned;
+}
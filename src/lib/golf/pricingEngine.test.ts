@@ -0,0 +1,100 @@
+import { describe, it, expect } from 'vitest';
+import {
+  classifyWeather,
+  describeRule,
+  forecastAt,
+  parseOpenMeteoHourly,
+  quoteGreenFee,
+  ruleMatches,
+  type PricingRule,
+  type RateCard,
+} from './pricingEngine';
+import { createRateCardEvent, parseRateCardEvent } from './nostrEvents';
+
+// Saturday 11 July 2026, local time
+const saturday = (hour: number, minute = 0) => new Date(2026, 6, 11, hour, minute).getTime();
+
+const rule = (overrides: Partial<PricingRule>): PricingRule => ({
+  id: 'r',
+  label: 'Rule',
+  adjustment: 'percent',
+  value: 0,
+  ...overrides,
+});
+
+const card: RateCard = {
+  courseId: 'oak-hills',
+  courseAuthor: 'club',
+  currency: 'GBP',
+  baseFee: 60,
+  memberClasses: ['Member', 'Junior', 'Visitor'],
+  rules: [
+    rule({ id: 'weekend', label: 'Weekend', days: [5, 6], adjustment: 'amount', value: 15 }),
+    rule({ id: 'twilight', label: 'Twilight', fromHour: 16, adjustment: 'percent', value: -40 }),
+    rule({ id: 'member', label: 'Member rate', memberClasses: ['Member'], adjustment: 'fixed', value: 0 }),
+    rule({ id: 'quiet', label: 'Quiet tee sheet', maxOccupancy: 0.3, adjustment: 'percent', value: -10 }),
+  ],
+  createdAt: 0,
+};
+
+describe('Pricing Engine', () => {
+  it('should match rules on class, day, hour, occupancy and weather', () => {
+    const context = { memberClass: 'Visitor', teeTime: saturday(16, 30), occupancy: 0.2, weather: 'rain' as const };
+
+    expect(ruleMatches(rule({ memberClasses: ['Junior'] }), context)).toBe(false);
+    expect(ruleMatches(rule({ days: [5] }), context)).toBe(true);
+    expect(ruleMatches(rule({ fromHour: 16, toHour: 16.5 }), context)).toBe(false);
+    expect(ruleMatches(rule({ maxOccupancy: 0.3, weather: ['rain', 'wind'] }), context)).toBe(true);
+    expect(ruleMatches(rule({ minOccupancy: 0.5 }), { ...context, occupancy: undefined })).toBe(false);
+  });
+
+  it('should apply matching rules in order', () => {
+    expect(quoteGreenFee(card, { memberClass: 'Visitor', teeTime: saturday(9), occupancy: 0.9 })).toMatchObject({ fee: 75, currency: 'GBP' });
+
+    // (60 + 15) x 0.6 x 0.9
+    const twilight = quoteGreenFee(card, { memberClass: 'Visitor', teeTime: saturday(17), occupancy: 0.1 });
+    expect(twilight.fee).toBe(40.5);
+    expect(twilight.applied.map(r => r.id)).toEqual(['weekend', 'twilight', 'quiet']);
+  });
+
+  it('should let a fixed fee replace earlier adjustments', () => {
+    expect(quoteGreenFee(card, { memberClass: 'Member', teeTime: saturday(17) }).fee).toBe(0);
+    const withFloor = { ...card, rules: [rule({ adjustment: 'amount', value: -100 })] };
+    expect(quoteGreenFee(withFloor, { memberClass: 'Visitor', teeTime: saturday(9) }).fee).toBe(0);
+  });
+
+  it('should describe a rule', () => {
+    expect(describeRule(card.rules[0], 'GBP')).toBe('Sat, Sun: +15 GBP');
+    expect(describeRule(rule({ fromHour: 16, toHour: 18.5, maxOccupancy: 0.3, weather: ['rain'], value: -20 }), 'GBP'))
+      .toBe('16:00-18:30 · up to 30% full · Rain: -20%');
+    expect(describeRule(rule({ adjustment: 'fixed', value: 25 }), 'GBP')).toBe('Always: 25 GBP');
+  });
+
+  it('should classify the weather', () => {
+    expect(classifyWeather({ temperature: 18, precipitationProbability: 70, windSpeed: 50 })).toBe('rain');
+    expect(classifyWeather({ temperature: 18, precipitationProbability: 10, windSpeed: 45 })).toBe('wind');
+    expect(classifyWeather({ temperature: 2, precipitationProbability: 10, windSpeed: 10 })).toBe('cold');
+    expect(classifyWeather({ temperature: 20, precipitationProbability: 10, windSpeed: 10 })).toBe('fair');
+  });
+
+  it('should read an Open-Meteo forecast and find the nearest hour', () => {
+    const forecast = parseOpenMeteoHourly({
+      hourly: {
+        time: [1783760400, 1783764000, 1783767600],
+        temperature_2m: [14.2, 15.1, null],
+        precipitation_probability: [20, 65, 80],
+        wind_speed_10m: [12, 18, 20],
+      },
+    });
+
+    expect(forecast).toHaveLength(2);
+    expect(forecastAt(forecast, 1783764000 * 1000 + 20 * 60 * 1000)?.precipitationProbability).toBe(65);
+    expect(forecastAt(forecast, 1783767600 * 1000 + 45 * 60 * 1000)).toBeNull();
+    expect(parseOpenMeteoHourly({})).toEqual([]);
+  });
+
+  it('should round-trip a rate card through a Nostr event', () => {
+    const event = { ...createRateCardEvent(card), created_at: card.createdAt / 1000 };
+    expect(parseRateCardEvent(event)).toEqual(card);
+  });
+});
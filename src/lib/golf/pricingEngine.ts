@@ -0,0 +1,165 @@
+// Green fee pricing rules: a base fee adjusted by member class, time of day,
+// expected occupancy and the weather forecast
+
+import { DAY_NAMES } from './occupancyEngine';
+
+export type WeatherCondition = 'fair' | 'rain' | 'wind' | 'cold' | 'hot';
+
+export const WEATHER_NAMES: Record<WeatherCondition, string> = {
+  fair: 'Fair',
+  rain: 'Rain',
+  wind: 'Windy',
+  cold: 'Cold',
+  hot: 'Hot',
+};
+
+export type PriceAdjustment = 'percent' | 'amount' | 'fixed';
+
+export interface PricingRule {
+  id: string;
+  label: string; // shown on the quote, e.g. "Twilight"
+  memberClasses?: string[]; // any class when unset
+  days?: number[]; // 0 = Monday; any day when unset
+  fromHour?: number; // tee times from this hour...
+  toHour?: number; // ...up to, not including, this one
+  minOccupancy?: number; // 0-1
+  maxOccupancy?: number; // 0-1
+  weather?: WeatherCondition[];
+  adjustment: PriceAdjustment; // percent change, amount added, or a fixed fee
+  value: number;
+}
+
+export interface RateCard {
+  courseId: string;
+  courseAuthor: string;
+  currency: string;
+  baseFee: number;
+  memberClasses: string[]; // e.g. Member, Junior, Visitor
+  rules: PricingRule[]; // applied in order
+  createdAt: number; // ms
+}
+
+export interface QuoteContext {
+  memberClass: string;
+  teeTime: number; // ms
+  occupancy?: number; // expected share of the tee sheet taken, 0-1
+  weather?: WeatherCondition;
+}
+
+export interface GreenFeeQuote {
+  baseFee: number;
+  fee: number;
+  currency: string;
+  applied: PricingRule[];
+}
+
+export interface HourlyForecast {
+  time: number; // ms
+  temperature: number; // °C
+  precipitationProbability: number; // %
+  windSpeed: number; // km/h
+}
+
+/**
+ * Whether a rule applies. A rule about occupancy or weather never applies
+ * when that isn't known.
+ */
+export function ruleMatches(rule: PricingRule, context: QuoteContext): boolean {
+  const at = new Date(context.teeTime);
+  const day = (at.getDay() + 6) % 7;
+  const hour = at.getHours() + at.getMinutes() / 60;
+
+  if (rule.memberClasses?.length && !rule.memberClasses.includes(context.memberClass)) return false;
+  if (rule.days?.length && !rule.days.includes(day)) return false;
+  if (rule.fromHour !== undefined && hour < rule.fromHour) return false;
+  if (rule.toHour !== undefined && hour >= rule.toHour) return false;
+  if (rule.minOccupancy !== undefined && (context.occupancy === undefined || context.occupancy < rule.minOccupancy)) return false;
+  if (rule.maxOccupancy !== undefined && (context.occupancy === undefined || context.occupancy > rule.maxOccupancy)) return false;
+  if (rule.weather?.length && (!context.weather || !rule.weather.includes(context.weather))) return false;
+  return true;
+}
+
+/**
+ * The green fee for a tee time: every matching rule is applied to the base
+ * fee in order, so a fixed fee can be adjusted by later rules
+ */
+export function quoteGreenFee(card: RateCard, context: QuoteContext): GreenFeeQuote {
+  const applied = card.rules.filter(rule => ruleMatches(rule, context));
+
+  let fee = card.baseFee;
+  for (const rule of applied) {
+    if (rule.adjustment === 'fixed') fee = rule.value;
+    else if (rule.adjustment === 'percent') fee *= 1 + rule.value / 100;
+    else fee += rule.value;
+  }
+
+  return {
+    baseFee: card.baseFee,
+    fee: Math.max(0, Math.round(fee * 100) / 100),
+    currency: card.currency,
+    applied,
+  };
+}
+
+const formatHour = (hour: number) => `${String(Math.floor(hour)).padStart(2, '0')}:${String(Math.round((hour % 1) * 60)).padStart(2, '0')}`;
+
+/** One-line summary of a rule's conditions and adjustment, for the club's rate card */
+export function describeRule(rule: PricingRule, currency: string): string {
+  const conditions = [
+    rule.memberClasses?.length ? rule.memberClasses.join(', ') : null,
+    rule.days?.length ? rule.days.map(d => DAY_NAMES[d]).join(', ') : null,
+    rule.fromHour !== undefined && rule.toHour !== undefined ? `${formatHour(rule.fromHour)}-${formatHour(rule.toHour)}`
+      : rule.fromHour !== undefined ? `from ${formatHour(rule.fromHour)}`
+      : rule.toHour !== undefined ? `before ${formatHour(rule.toHour)}`
+      : null,
+    rule.minOccupancy !== undefined ? `at least ${Math.round(rule.minOccupancy * 100)}% full` : null,
+    rule.maxOccupancy !== undefined ? `up to ${Math.round(rule.maxOccupancy * 100)}% full` : null,
+    rule.weather?.length ? rule.weather.map(w => WEATHER_NAMES[w]).join(' or ') : null,
+  ].filter(Boolean);
+
+  const change = rule.adjustment === 'fixed' ? `${rule.value} ${currency}`
+    : rule.adjustment === 'percent' ? `${rule.value > 0 ? '+' : ''}${rule.value}%`
+    : `${rule.value > 0 ? '+' : ''}${rule.value} ${currency}`;
+
+  return `${conditions.length > 0 ? conditions.join(' · ') : 'Always'}: ${change}`;
+}
+
+/** Weather for pricing, worst first: rain, then wind, then temperature */
+export function classifyWeather(forecast: Omit<HourlyForecast, 'time'>): WeatherCondition {
+  if (forecast.precipitationProbability >= 50) return 'rain';
+  if (forecast.windSpeed >= 40) return 'wind';
+  if (forecast.temperature <= 5) return 'cold';
+  if (forecast.temperature >= 32) return 'hot';
+  return 'fair';
+}
+
+/** Hourly forecast from an Open-Meteo response requested with `timeformat=unixtime` */
+export function parseOpenMeteoHourly(json: unknown): HourlyForecast[] {
+  const hourly = (json as { hourly?: Record<string, unknown> })?.hourly;
+  const time = hourly?.time;
+  if (!Array.isArray(time)) return [];
+
+  const series = (name: string) => (Array.isArray(hourly?.[name]) ? hourly[name] as (number | null)[] : []);
+  const temperature = series('temperature_2m');
+  const precipitation = series('precipitation_probability');
+  const wind = series('wind_speed_10m');
+
+  return time.map((t: number, i: number) => ({
+    time: t * 1000,
+    temperature: temperature[i] ?? NaN,
+    precipitationProbability: precipitation[i] ?? 0,
+    windSpeed: wind[i] ?? 0,
+  })).filter(hour => !isNaN(hour.temperature));
+}
+
+/** Forecast hour nearest a time, if the forecast reaches that far */
+export function forecastAt(forecast: HourlyForecast[], at: number): HourlyForecast | null {
+  const HOUR = 60 * 60 * 1000;
+  let nearest: HourlyForecast | null = null;
+  for (const hour of forecast) {
+    if (Math.abs(hour.time - at) <= HOUR / 2 && (!nearest || Math.abs(hour.time - at) < Math.abs(nearest.time - at))) {
+      nearest = hour;
+    }
+  }
+  return nearest;
+}
@@ -0,0 +1,68 @@
+import { describe, it, expect } from 'vitest';
+import { createPrivacyEvent, parsePrivacyEvent } from './nostrEvents';
+import {
+  DEFAULT_PRIVACY_SETTINGS,
+  anonymizeShot,
+  buildDataExport,
+  hasConsent,
+  setConsent,
+  shotsToAnonymize,
+  type PrivacySettings,
+} from './privacyEngine';
+
+const DAY = 24 * 60 * 60 * 1000;
+const now = 1_780_000_000_000;
+const alice = 'a'.repeat(64);
+
+const shot = (id: string, daysAgo: number, withPositions = true) => ({
+  shotId: id,
+  clubId: '7i',
+  distance: 150,
+  timestamp: now - daysAgo * DAY,
+  hole: 4,
+  ...(withPositions ? { start: { lat: 51.5, lon: -0.1 }, end: { lat: 51.501, lon: -0.1 }, path: [{ lat: 51.5005, lon: -0.1 }] } : {}),
+});
+
+describe('Privacy Engine', () => {
+  it('should use the default until the member chooses', () => {
+    expect(hasConsent(DEFAULT_PRIVACY_SETTINGS, 'gps')).toBe(true);
+
+    const withdrawn = setConsent(DEFAULT_PRIVACY_SETTINGS, 'gps', false, now);
+    expect(hasConsent(withdrawn, 'gps')).toBe(false);
+    expect(hasConsent(withdrawn, 'club-stats')).toBe(true);
+
+    const regranted = setConsent(withdrawn, 'gps', true, now + DAY);
+    expect(regranted.consents).toEqual([{ purpose: 'gps', granted: true, at: now + DAY }]);
+  });
+
+  it('should remove shot positions after the retention period', () => {
+    const settings = { ...DEFAULT_PRIVACY_SETTINGS, gpsRetentionDays: 90 };
+    const shots = [shot('old', 120), shot('recent', 10), shot('already', 200, false)];
+
+    expect(shotsToAnonymize(shots, DEFAULT_PRIVACY_SETTINGS, now)).toEqual([]);
+    const due = shotsToAnonymize(shots, settings, now);
+    expect(due.map(s => s.shotId)).toEqual(['old']);
+    expect(anonymizeShot(due[0])).toEqual({ shotId: 'old', clubId: '7i', distance: 150, timestamp: now - 120 * DAY, hole: 4 });
+  });
+
+  it('should split an export into published events and mentions', () => {
+    const event = (id: string, pubkey: string, created_at: number) => ({ id, pubkey, created_at, kind: 1, tags: [], content: '', sig: '' });
+    const data = buildDataExport(alice, [event('2', alice, 20), event('1', alice, 10), event('3', 'b'.repeat(64), 15), event('1', alice, 10)], { rounds: [] }, now);
+
+    expect(data.npub.startsWith('npub1')).toBe(true);
+    expect(data.published.map(e => e.id)).toEqual(['1', '2']);
+    expect(data.mentions.map(e => e.id)).toEqual(['3']);
+    expect(data.device).toEqual({ rounds: [] });
+  });
+
+  it('should round-trip privacy settings through a Nostr event', () => {
+    const original: PrivacySettings = {
+      consents: [{ purpose: 'gps', granted: false, at: now - DAY }],
+      gpsRetentionDays: 30,
+      personalRetentionDays: null,
+      createdAt: now,
+    };
+    const event = { ...createPrivacyEvent(original, alice), created_at: original.createdAt / 1000 };
+    expect(parsePrivacyEvent(event)).toEqual(original);
+  });
+});
@@ -0,0 +1,93 @@
+// Member privacy: consent to each use of their data, how long it is kept,
+// and an export of everything stored about them
+
+import type { NostrEvent } from '@nostrify/nostrify';
+import { nip19 } from 'nostr-tools';
+import type { ShotSample } from './bagEngine';
+import type { ShotDetails } from './nostrEvents';
+
+export type ConsentPurpose = 'gps' | 'club-stats';
+
+export const CONSENT_PURPOSES: Record<ConsentPurpose, { name: string; description: string; granted: boolean }> = {
+  gps: {
+    name: 'Shot positions',
+    description: 'Publish where each recorded shot started and finished',
+    granted: true,
+  },
+  'club-stats': {
+    name: 'Club statistics',
+    description: 'Let clubs count my rounds in tee sheet occupancy',
+    granted: true,
+  },
+};
+
+export interface ConsentRecord {
+  purpose: ConsentPurpose;
+  granted: boolean;
+  at: number; // ms, when the member last changed it
+}
+
+export interface PrivacySettings {
+  consents: ConsentRecord[];
+  gpsRetentionDays: number | null; // shot positions older than this are removed; null keeps them
+  personalRetentionDays: number | null; // rounds and scores saved on this device
+  createdAt: number; // ms
+}
+
+export const DEFAULT_PRIVACY_SETTINGS: PrivacySettings = {
+  consents: [],
+  gpsRetentionDays: null,
+  personalRetentionDays: null,
+  createdAt: 0,
+};
+
+export const RETENTION_CHOICES = [30, 90, 365, 730];
+
+const DAY_MS = 24 * 60 * 60 * 1000;
+
+/** Whether the member agrees to a use of their data; the default until they choose */
+export function hasConsent(settings: PrivacySettings, purpose: ConsentPurpose): boolean {
+  return settings.consents.find(c => c.purpose === purpose)?.granted ?? CONSENT_PURPOSES[purpose].granted;
+}
+
+/** Settings with a consent given or withdrawn, stamped with when */
+export function setConsent(settings: PrivacySettings, purpose: ConsentPurpose, granted: boolean, now: number): PrivacySettings {
+  return {
+    ...settings,
+    consents: [...settings.consents.filter(c => c.purpose !== purpose), { purpose, granted, at: now }],
+  };
+}
+
+/** Anything recorded before this is past its retention period */
+export function retentionCutoff(days: number | null, now: number): number | null {
+  return days === null ? null : now - days * DAY_MS;
+}
+
+/** Shots that still carry positions after the GPS retention period */
+export function shotsToAnonymize<T extends ShotSample & ShotDetails>(shots: T[], settings: PrivacySettings, now: number): T[] {
+  const cutoff = retentionCutoff(settings.gpsRetentionDays, now);
+  if (cutoff === null) return [];
+  return shots.filter(s => s.timestamp < cutoff && (s.start || s.end || s.path?.length));
+}
+
+/** A shot without where it was played; club, distance and hole stay */
+export function anonymizeShot<T extends ShotDetails>(shot: T): T {
+  const { start: _start, end: _end, path: _path, ...rest } = shot;
+  return rest as T;
+}
+
+/**
+ * An export of everything stored about a member: the events they
+ * published, events by others that tag them, and what this device keeps
+ */
+export function buildDataExport(pubkey: string, events: NostrEvent[], device: Record<string, unknown>, now: number) {
+  const unique = [...new Map(events.map(e => [e.id, e])).values()].sort((a, b) => a.created_at - b.created_at);
+  return {
+    npub: nip19.npubEncode(pubkey),
+    pubkey,
+    exportedAt: new Date(now).toISOString(),
+    published: unique.filter(e => e.pubkey === pubkey),
+    mentions: unique.filter(e => e.pubkey !== pubkey),
+    device,
+  };
+}
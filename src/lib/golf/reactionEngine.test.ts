@@ -0,0 +1,42 @@
+import { describe, it, expect } from 'vitest';
+import type { NostrEvent } from '@nostrify/nostrify';
+import { reactionEmoji, reactionTags, summarizeReactions } from './reactionEngine';
+
+function reaction(pubkey: string, content: string): NostrEvent {
+  return { id: `${pubkey}-${content}`, pubkey, created_at: 1000, kind: 7, tags: [], content, sig: '' };
+}
+
+describe('Reaction Engine', () => {
+  it('should show likes and dislikes as emoji', () => {
+    expect(reactionEmoji('+')).toBe('👍');
+    expect(reactionEmoji('')).toBe('👍');
+    expect(reactionEmoji('-')).toBe('👎');
+    expect(reactionEmoji('🔥')).toBe('🔥');
+  });
+
+  it('should count each person once per emoji', () => {
+    const counts = summarizeReactions([reaction('a', '+'), reaction('a', '👍'), reaction('b', '+')]);
+    expect(counts).toEqual([{ emoji: '👍', count: 2, mine: false }]);
+  });
+
+  it('should put the most popular reaction first and mark the viewer\'s own', () => {
+    const counts = summarizeReactions([reaction('a', '🔥'), reaction('b', '⛳'), reaction('c', '⛳')], 'a');
+    expect(counts.map(c => c.emoji)).toEqual(['⛳', '🔥']);
+    expect(counts[1].mine).toBe(true);
+  });
+
+  it('should tag an addressable round by address as well as id', () => {
+    const round: NostrEvent = { id: 'ev1', pubkey: 'host', created_at: 1000, kind: 36901, tags: [['d', 'join-ABC123']], content: '', sig: '' };
+    expect(reactionTags(round)).toEqual([
+      ['e', 'ev1'],
+      ['a', '36901:host:join-ABC123'],
+      ['p', 'host'],
+      ['k', '36901'],
+    ]);
+  });
+
+  it('should not tag an address for regular events', () => {
+    const note: NostrEvent = { id: 'n1', pubkey: 'alice', created_at: 1000, kind: 1, tags: [], content: 'hi', sig: '' };
+    expect(reactionTags(note).map(t => t[0])).toEqual(['e', 'p', 'k']);
+  });
+});
@@ -0,0 +1,56 @@
+// Reactions (NIP-25) on rounds and other golf events
+
+import type { NostrEvent } from '@nostrify/nostrify';
+
+export interface ReactionCount {
+  emoji: string;
+  count: number;
+  mine: boolean; // the viewer reacted with this emoji
+}
+
+export const QUICK_REACTIONS = ['👍', '🔥', '⛳', '😂', '😬'];
+
+/**
+ * Display form of a reaction's content: "+" (or empty) is a like and "-" a
+ * dislike, as in NIP-25; anything else is shown as is
+ */
+export function reactionEmoji(content: string): string {
+  const trimmed = content.trim();
+  if (trimmed === '' || trimmed === '+') return '👍';
+  if (trimmed === '-') return '👎';
+  return trimmed;
+}
+
+/**
+ * Reaction counts, most popular first. Each person counts once per emoji,
+ * however many times their reaction was published.
+ */
+export function summarizeReactions(reactions: NostrEvent[], viewer?: string): ReactionCount[] {
+  const byEmoji = new Map<string, Set<string>>();
+  for (const reaction of reactions) {
+    if (reaction.kind !== 7) continue;
+    const emoji = reactionEmoji(reaction.content);
+    const people = byEmoji.get(emoji) ?? new Set<string>();
+    people.add(reaction.pubkey);
+    byEmoji.set(emoji, people);
+  }
+
+  return [...byEmoji.entries()]
+    .map(([emoji, people]) => ({ emoji, count: people.size, mine: !!viewer && people.has(viewer) }))
+    .sort((a, b) => b.count - a.count || a.emoji.localeCompare(b.emoji));
+}
+
+/**
+ * Tags for a reaction to an event (NIP-25): the event, its address when it
+ * is addressable, its author and kind
+ */
+export function reactionTags(target: NostrEvent): string[][] {
+  const d = target.tags.find(([name]) => name === 'd')?.[1];
+  const addressable = target.kind >= 30000 && target.kind < 40000;
+  return [
+    ['e', target.id],
+    ...(addressable ? [['a', `${target.kind}:${target.pubkey}:${d ?? ''}`]] : []),
+    ['p', target.pubkey],
+    ['k', String(target.kind)],
+  ];
+}
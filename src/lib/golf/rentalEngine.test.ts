@@ -0,0 +1,57 @@
+import { describe, it, expect } from 'vitest';
+import { createRentalReservationEvent, parseRentalReservationEvent } from './nostrEvents';
+import { availableCount, confirmedReservations, rentalTotal, type RentalItem, type RentalReservation } from './rentalEngine';
+
+const HOUR = 60 * 60 * 1000;
+const buggies: RentalItem = { id: 'buggy', type: 'buggy', name: 'Buggy', count: 2, price: 30 };
+const trolleys: RentalItem = { id: 'trolley', type: 'trolley', name: 'Electric trolley', count: 5, price: 10 };
+
+function reservation(id: string, itemId: string, startHour: number, endHour: number, quantity = 1, createdAt = 1000): RentalReservation {
+  return {
+    reservationId: id, courseId: 'oak', courseAuthor: 'club', renter: `renter-${id}`, itemId, quantity,
+    startsAt: startHour * HOUR, endsAt: endHour * HOUR, cancelled: false, createdAt,
+  };
+}
+
+describe('Rental Engine', () => {
+  it('should confirm reservations first come first served', () => {
+    const confirmed = confirmedReservations([buggies], [
+      reservation('late', 'buggy', 9, 13, 1, 3000),
+      reservation('a', 'buggy', 8, 12, 1, 1000),
+      reservation('b', 'buggy', 10, 14, 1, 2000),
+    ]);
+    expect(confirmed.map(r => r.reservationId)).toEqual(['a', 'b']);
+  });
+
+  it('should let units be reused once they are returned', () => {
+    const confirmed = confirmedReservations([buggies], [
+      reservation('a', 'buggy', 8, 12, 2, 1000),
+      reservation('b', 'buggy', 12, 16, 2, 2000),
+    ]);
+    expect(confirmed).toHaveLength(2);
+  });
+
+  it('should skip cancelled reservations and unknown items', () => {
+    const cancelled = { ...reservation('a', 'buggy', 8, 12, 2), cancelled: true };
+    const confirmed = confirmedReservations([buggies], [cancelled, reservation('b', 'bikes', 8, 12), reservation('c', 'buggy', 8, 12, 2)]);
+    expect(confirmed.map(r => r.reservationId)).toEqual(['c']);
+  });
+
+  it('should count units free for the whole window', () => {
+    const confirmed = [reservation('a', 'trolley', 8, 12, 2), reservation('b', 'trolley', 11, 15, 2), reservation('c', 'buggy', 8, 12, 2)];
+    expect(availableCount(trolleys, confirmed, 9, 10)).toBe(5);
+    expect(availableCount(trolleys, confirmed, 8 * HOUR, 10 * HOUR)).toBe(3);
+    expect(availableCount(trolleys, confirmed, 10 * HOUR, 14 * HOUR)).toBe(1);
+    expect(availableCount(buggies, confirmed, 12 * HOUR, 16 * HOUR)).toBe(2);
+  });
+
+  it('should total the amount due at the pro shop', () => {
+    expect(rentalTotal([buggies, trolleys], [reservation('a', 'buggy', 8, 12), reservation('b', 'trolley', 8, 12, 2)])).toBe(50);
+  });
+
+  it('should round-trip a reservation through a Nostr event', () => {
+    const original = reservation('a', 'buggy', 8, 12, 2);
+    const event = { ...createRentalReservationEvent(original), created_at: original.createdAt / 1000 };
+    expect(parseRentalReservationEvent(event)).toEqual(original);
+  });
+});
@@ -0,0 +1,91 @@
+// Buggy, trolley and club hire: the club's rental inventory and members'
+// reservations against it
+
+export type RentalItemType = 'buggy' | 'trolley' | 'clubs' | 'other';
+
+export const RENTAL_ITEM_NAMES: Record<RentalItemType, string> = {
+  buggy: 'Buggy',
+  trolley: 'Trolley',
+  clubs: 'Rental set',
+  other: 'Equipment',
+};
+
+export interface RentalItem {
+  id: string;
+  type: RentalItemType;
+  name: string;
+  count: number; // units the club has
+  price: number; // per reservation, in the club's currency
+}
+
+export interface RentalInventory {
+  courseId: string;
+  courseAuthor: string;
+  currency: string;
+  items: RentalItem[];
+  createdAt: number; // ms
+}
+
+export interface RentalReservation {
+  reservationId: string;
+  courseId: string;
+  courseAuthor: string;
+  renter: string; // pubkey
+  itemId: string;
+  quantity: number;
+  startsAt: number; // ms
+  endsAt: number; // ms, exclusive
+  cancelled: boolean;
+  createdAt: number; // ms
+}
+
+// A round plus time to collect and return the equipment
+export const DEFAULT_RENTAL_HOURS = 5;
+
+/**
+ * Most units of an item out at once during [startsAt, endsAt)
+ */
+function peakInUse(reservations: RentalReservation[], startsAt: number, endsAt: number): number {
+  const overlapping = reservations.filter(r => r.startsAt < endsAt && r.endsAt > startsAt);
+  // Usage only rises when a reservation starts, so the peak is at one of the starts
+  const points = [startsAt, ...overlapping.map(r => r.startsAt).filter(t => t > startsAt)];
+  return Math.max(0, ...points.map(t =>
+    overlapping.filter(r => r.startsAt <= t && t < r.endsAt).reduce((sum, r) => sum + r.quantity, 0)
+  ));
+}
+
+/**
+ * Reservations the club can honour, first come first served. A reservation
+ * that would take an item beyond the units the club has is left out, as are
+ * cancelled ones and ones for items no longer in the inventory.
+ */
+export function confirmedReservations(items: RentalItem[], reservations: RentalReservation[]): RentalReservation[] {
+  const confirmed: RentalReservation[] = [];
+  const ordered = [...reservations].sort((a, b) => a.createdAt - b.createdAt || a.reservationId.localeCompare(b.reservationId));
+
+  for (const reservation of ordered) {
+    const item = items.find(i => i.id === reservation.itemId);
+    if (!item || reservation.cancelled || reservation.quantity <= 0) continue;
+    const sameItem = confirmed.filter(r => r.itemId === item.id);
+    if (peakInUse([...sameItem, reservation], reservation.startsAt, reservation.endsAt) <= item.count) {
+      confirmed.push(reservation);
+    }
+  }
+
+  return confirmed;
+}
+
+/**
+ * Units of an item still free for the whole of [startsAt, endsAt)
+ */
+export function availableCount(item: RentalItem, confirmed: RentalReservation[], startsAt: number, endsAt: number): number {
+  const inUse = peakInUse(confirmed.filter(r => r.itemId === item.id), startsAt, endsAt);
+  return Math.max(0, item.count - inUse);
+}
+
+/**
+ * Amount to collect for reservations at the pro shop
+ */
+export function rentalTotal(items: RentalItem[], reservations: RentalReservation[]): number {
+  return reservations.reduce((sum, r) => sum + (items.find(i => i.id === r.itemId)?.price ?? 0) * r.quantity, 0);
+}
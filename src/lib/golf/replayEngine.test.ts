@@ -0,0 +1,79 @@
+import { describe, it, expect } from 'vitest';
+import { createShotEvent, parseShotEvent } from './nostrEvents';
+import {
+  HOLE_PAUSE_MS,
+  SHOT_FLIGHT_MS,
+  SHOT_PAUSE_MS,
+  buildReplay,
+  replayDuration,
+  replayFrame,
+  seekToTime,
+} from './replayEngine';
+
+const tee = { lat: 51.5, lon: -0.1 };
+const fairway = { lat: 51.502, lon: -0.1 };
+const green = { lat: 51.503, lon: -0.1 };
+const t0 = 1_780_000_000_000;
+
+const shot = (id: string, hole: number, minutes: number, start = tee, end = fairway) => ({
+  shotId: id,
+  clubId: '7i',
+  distance: 150,
+  timestamp: t0 + minutes * 60_000,
+  hole,
+  start,
+  end,
+});
+
+describe('Replay Engine', () => {
+  it('should group shots by hole in the order they were played', () => {
+    const holes = buildReplay([
+      shot('b', 1, 3, fairway, green),
+      shot('c', 2, 12),
+      shot('a', 1, 0),
+      { ...shot('x', 2, 14), end: undefined },
+    ]);
+
+    expect(holes.map(h => h.hole)).toEqual([1, 2]);
+    expect(holes[0].shots.map(s => s.shotId)).toEqual(['a', 'b']);
+    expect(holes[1].shots.map(s => s.shotId)).toEqual(['c']);
+    expect(holes[0].shots[1].startsAt).toBe(SHOT_FLIGHT_MS + SHOT_PAUSE_MS);
+    expect(holes[1].shots[0].startsAt).toBe(2 * SHOT_FLIGHT_MS + SHOT_PAUSE_MS + HOLE_PAUSE_MS);
+    expect(replayDuration(holes)).toBe(holes[1].shots[0].startsAt + SHOT_FLIGHT_MS);
+  });
+
+  it('should move the ball along the flight by distance', () => {
+    const holes = buildReplay([shot('a', 1, 0)]);
+
+    expect(replayFrame(holes, -1)).toBeNull();
+    const half = replayFrame(holes, SHOT_FLIGHT_MS / 2)!;
+    expect(half.point.lat).toBeCloseTo(51.501, 6);
+    expect(half.trail).toEqual([tee, half.point]);
+    expect(replayFrame(holes, SHOT_FLIGHT_MS + 100)!.point).toEqual(fairway);
+  });
+
+  it('should follow a traced path through its bend', () => {
+    const bend = { lat: 51.501, lon: -0.099 };
+    const holes = buildReplay([{ ...shot('a', 1, 0), path: [bend] }]);
+
+    const frame = replayFrame(holes, SHOT_FLIGHT_MS / 2)!;
+    expect(frame.point.lat).toBeCloseTo(bend.lat, 5);
+    expect(frame.point.lon).toBeCloseTo(bend.lon, 5);
+    expect(frame.trail[0]).toEqual(tee);
+  });
+
+  it('should seek to the shot played at a real time', () => {
+    const holes = buildReplay([shot('a', 1, 0), shot('b', 1, 3, fairway, green), shot('c', 2, 12)]);
+
+    expect(seekToTime(holes, t0 + 60_000)).toBe(holes[0].shots[1].startsAt);
+    expect(seekToTime(holes, t0 + 60 * 60_000)).toBe(holes[1].shots[0].startsAt);
+    expect(replayFrame(holes, seekToTime(holes, t0 + 60_000))!.time).toBe(t0 + 3 * 60_000);
+  });
+
+  it('should round-trip a traced path through a shot event', () => {
+    const original = { ...shot('a', 1, 0), path: [{ lat: 51.501, lon: -0.099 }] };
+    const { shotId, clubId, distance, timestamp, ...details } = original;
+    const parsed = parseShotEvent(createShotEvent({ shotId, clubId, distance, timestamp }, 'a'.repeat(64), details));
+    expect(parsed).toMatchObject(original);
+  });
+});
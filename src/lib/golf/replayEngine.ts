@@ -0,0 +1,119 @@
+// Shot tracer replay: a player's recorded shots laid out hole by hole on a
+// compressed playback timeline, so a finished round can be animated
+
+import { distanceYards, type GeoPoint } from './caddieEngine';
+import type { ShotSample } from './bagEngine';
+import type { ShotDetails } from './nostrEvents';
+
+export interface ReplayShot {
+  shotId: string;
+  clubId: string;
+  distance: number; // yards, as recorded
+  timestamp: number; // ms, when the shot was played
+  path: GeoPoint[]; // start, any traced points, end
+  startsAt: number; // ms into playback
+}
+
+export interface ReplayHole {
+  hole: number;
+  shots: ReplayShot[];
+}
+
+export interface ReplayFrame {
+  hole: number;
+  shot: ReplayShot;
+  point: GeoPoint; // where the ball is
+  trail: GeoPoint[]; // the flight so far, ending at point
+  time: number; // ms, the real time of the shot being played
+}
+
+export const SHOT_FLIGHT_MS = 1500;
+export const SHOT_PAUSE_MS = 700;
+export const HOLE_PAUSE_MS = 1500;
+
+/**
+ * Shots with positions grouped by hole in the order they were played.
+ * A shot with no traced path flies straight from start to end; shots
+ * without both ends, or off a hole, can't be drawn and are left out.
+ */
+export function buildReplay(shots: (ShotSample & ShotDetails)[]): ReplayHole[] {
+  const drawable = shots
+    .filter(s => s.hole && s.start && s.end)
+    .sort((a, b) => a.timestamp - b.timestamp);
+
+  const holes = new Map<number, ReplayHole>();
+  for (const s of drawable) {
+    if (!holes.has(s.hole!)) holes.set(s.hole!, { hole: s.hole!, shots: [] });
+    holes.get(s.hole!)!.shots.push({
+      shotId: s.shotId,
+      clubId: s.clubId,
+      distance: s.distance,
+      timestamp: s.timestamp,
+      path: [s.start!, ...(s.path ?? []), s.end!],
+      startsAt: 0,
+    });
+  }
+
+  // Holes in the order they were started, so a shotgun start replays as played
+  let clock = 0;
+  return [...holes.values()].map((hole, i) => {
+    if (i > 0) clock += HOLE_PAUSE_MS - SHOT_PAUSE_MS;
+    for (const shot of hole.shots) {
+      shot.startsAt = clock;
+      clock += SHOT_FLIGHT_MS + SHOT_PAUSE_MS;
+    }
+    return hole;
+  });
+}
+
+/** Length of the whole replay in ms */
+export function replayDuration(holes: ReplayHole[]): number {
+  const shots = holes[holes.length - 1]?.shots ?? [];
+  const last = shots[shots.length - 1];
+  return last ? last.startsAt + SHOT_FLIGHT_MS : 0;
+}
+
+/** Point a fraction of the way along a path, by distance */
+function along(path: GeoPoint[], fraction: number): { point: GeoPoint; trail: GeoPoint[] } {
+  const legs = path.slice(1).map((p, i) => distanceYards(path[i], p));
+  const total = legs.reduce((a, b) => a + b, 0);
+  let remaining = Math.min(1, Math.max(0, fraction)) * total;
+
+  for (let i = 0; i < legs.length; i++) {
+    if (remaining <= legs[i] && legs[i] > 0) {
+      const t = remaining / legs[i];
+      const point = {
+        lat: path[i].lat + (path[i + 1].lat - path[i].lat) * t,
+        lon: path[i].lon + (path[i + 1].lon - path[i].lon) * t,
+      };
+      return { point, trail: [...path.slice(0, i + 1), point] };
+    }
+    remaining -= legs[i];
+  }
+  const end = path[path.length - 1];
+  return { point: end, trail: path };
+}
+
+/**
+ * The frame at a moment of playback: the shot in the air (or the last one
+ * to land, during a pause) and how far along its flight the ball is
+ */
+export function replayFrame(holes: ReplayHole[], elapsed: number): ReplayFrame | null {
+  let current: { hole: number; shot: ReplayShot } | null = null;
+  for (const hole of holes) {
+    for (const shot of hole.shots) {
+      if (shot.startsAt > elapsed) break;
+      current = { hole: hole.hole, shot };
+    }
+  }
+  if (!current) return null;
+
+  const { point, trail } = along(current.shot.path, (elapsed - current.shot.startsAt) / SHOT_FLIGHT_MS);
+  return { hole: current.hole, shot: current.shot, point, trail, time: current.shot.timestamp };
+}
+
+/** Playback position of the first shot played at or after a real time */
+export function seekToTime(holes: ReplayHole[], time: number): number {
+  const shots = holes.flatMap(h => h.shots);
+  return (shots.find(s => s.timestamp >= time) ?? shots[shots.length - 1])?.startsAt ?? 0;
+}
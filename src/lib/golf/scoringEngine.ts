@@ -1,5 +1,6 @@
 import { GameMode, GameConfig, PlayerInRound, HoleScore } from './types';
 import { sixesEngine } from './sixesEngine';
+import { calculateCourseHandicap } from './handicapCalculator';
 
 // Payment and settlement interfaces
 export interface Payable {
@@ -234,13 +235,8 @@ export class ScoringEngine {
   /**
    * Calculate course handicap
    */
-  calculateCourseHandicap(playerHandicap: number, slope: number, courseRating: number): number {
-    if (slope <= 0 || courseRating <= 0) {
-      return playerHandicap;
-    }
-
-    const differential = (playerHandicap * 113) / slope;
-    return Math.max(0, differential + (courseRating - 113));
+  calculateCourseHandicap(playerHandicap: number, slope: number, courseRating: number, par: number = 72): number {
+    return calculateCourseHandicap(playerHandicap, { courseRating, slopeRating: slope, par });
   }
 
   /**
@@ -0,0 +1,143 @@
+import { describe, it, expect } from 'vitest';
+import {
+  createEclecticState,
+  applyEclecticRound,
+  getEclecticLeaderboard,
+  createOrderOfMeritState,
+  applyMeritEvent,
+  calculateEventPoints,
+  getOrderOfMerit,
+  roundMeritEvent,
+  type SeasonConfig,
+  type SeasonRound,
+  type MeritEvent
+} from './seasonEngine';
+
+describe('Season Engine', () => {
+  const season: SeasonConfig = {
+    name: '2025 Eclectic',
+    startDate: new Date('2025-04-01').getTime(),
+    endDate: new Date('2025-09-30').getTime(),
+    holes: 3
+  };
+
+  const round = (roundId: string, playerId: string, date: string, scores: number[]): SeasonRound => ({
+    roundId,
+    playerId,
+    date: new Date(date).getTime(),
+    scores: Object.fromEntries(scores.map((s, i) => [i + 1, s]))
+  });
+
+  describe('Eclectic', () => {
+    it('should keep the best score per hole across rounds', () => {
+      let state = createEclecticState(season);
+      state = applyEclecticRound(state, round('r1', 'alice', '2025-05-01', [5, 4, 3]));
+      state = applyEclecticRound(state, round('r2', 'alice', '2025-06-01', [4, 5, 3]));
+
+      const card = state.cards.alice;
+      expect(card.total).toBe(11);
+      expect(card.roundsCounted).toBe(2);
+      expect(card.holes[1].roundId).toBe('r2');
+      // Tie on hole 3 stays with the earlier round
+      expect(card.holes[3].roundId).toBe('r1');
+    });
+
+    it('should ignore rounds outside the season', () => {
+      const state = applyEclecticRound(createEclecticState(season), round('r1', 'alice', '2025-10-15', [3, 3, 3]));
+      expect(state.cards.alice).toBeUndefined();
+    });
+
+    it('should rebuild a card when a round is corrected', () => {
+      let state = createEclecticState(season);
+      state = applyEclecticRound(state, round('r1', 'alice', '2025-05-01', [3, 4, 4]));
+      state = applyEclecticRound(state, round('r2', 'alice', '2025-06-01', [5, 5, 5]));
+      // r1 hole 1 was mis-entered
+      state = applyEclecticRound(state, round('r1', 'alice', '2025-05-01', [6, 4, 4]));
+
+      expect(state.cards.alice.holes[1]).toEqual({ score: 5, roundId: 'r2', date: new Date('2025-06-01').getTime() });
+      expect(state.cards.alice.total).toBe(13);
+      expect(state.cards.alice.roundsCounted).toBe(2);
+    });
+
+    it('should only count rounds on the season\'s course', () => {
+      let state = createEclecticState({ ...season, course: 'Pebble Creek' });
+      state = applyEclecticRound(state, { ...round('r1', 'alice', '2025-05-01', [4, 4, 4]), course: 'pebble creek ' });
+      state = applyEclecticRound(state, { ...round('r2', 'alice', '2025-05-02', [3, 3, 3]), course: 'Oak Hills' });
+      state = applyEclecticRound(state, round('r3', 'alice', '2025-05-03', [2, 2, 2]));
+
+      expect(state.cards.alice.total).toBe(12);
+      expect(state.cards.alice.roundsCounted).toBe(1);
+    });
+
+    it('should rank by holes covered then total', () => {
+      let state = createEclecticState(season);
+      state = applyEclecticRound(state, round('r1', 'alice', '2025-05-01', [4, 4, 4]));
+      state = applyEclecticRound(state, round('r2', 'bob', '2025-05-01', [3, 3, 0]));
+      state = applyEclecticRound(state, round('r3', 'carol', '2025-05-01', [4, 4, 4]));
+
+      const board = getEclecticLeaderboard(state);
+      expect(board.map(e => e.playerId)).toEqual(['alice', 'carol', 'bob']);
+      expect(board.map(e => e.position)).toEqual([1, 1, 3]);
+    });
+  });
+
+  describe('Order of Merit', () => {
+    const event = (eventId: string, results: [string, number][], multiplier?: number): MeritEvent => ({
+      eventId,
+      name: eventId,
+      date: 0,
+      multiplier,
+      results: results.map(([playerId, position]) => ({ playerId, position }))
+    });
+
+    it('should split points between tied players', () => {
+      const points = calculateEventPoints(event('e1', [['a', 1], ['b', 2], ['c', 2], ['d', 4]]));
+      expect(points).toEqual({ a: 100, b: 67.5, c: 67.5, d: 50 });
+    });
+
+    it('should apply multipliers and participation points', () => {
+      const points = calculateEventPoints(
+        event('major', [['a', 1], ['b', 2], ['c', 3]], 2),
+        { pointsTable: [10, 5], participationPoints: 1 }
+      );
+      expect(points).toEqual({ a: 20, b: 10, c: 2 });
+    });
+
+    it('should place a finished round\'s complete cards by gross score', () => {
+      const merit = roundMeritEvent(season, 'Medal', [
+        round('r1', 'alice', '2025-05-01', [4, 4, 4]),
+        round('r1', 'bob', '2025-05-01', [3, 4, 4]),
+        round('r1', 'carol', '2025-05-01', [4, 5, 3]),
+        round('r1', 'dave', '2025-05-01', [3, 3, 0]),
+      ]);
+
+      expect(merit).toMatchObject({ eventId: 'r1', name: 'Medal' });
+      expect(merit?.results).toEqual([
+        { playerId: 'bob', position: 1 },
+        { playerId: 'alice', position: 2 },
+        { playerId: 'carol', position: 2 },
+      ]);
+      expect(roundMeritEvent(season, 'Medal', [round('r1', 'dave', '2025-05-01', [3, 3, 0])])).toBeNull();
+      expect(roundMeritEvent(season, 'Medal', [round('r1', 'alice', '2026-05-01', [4, 4, 4])])).toBeNull();
+    });
+
+    it('should total points with a best-of rule and replace updated events', () => {
+      let state = createOrderOfMeritState({ pointsTable: [10, 6, 4], bestOf: 2 });
+      state = applyMeritEvent(state, event('e1', [['a', 1], ['b', 2], ['c', 3]]));
+      state = applyMeritEvent(state, event('e2', [['b', 1], ['a', 3], ['c', 2]]));
+      state = applyMeritEvent(state, event('e3', [['c', 1], ['a', 2], ['b', 3]]));
+
+      let table = getOrderOfMerit(state);
+      // a: 10+6, b: 10+6, c: 10+6 - all tied on points and wins
+      expect(table.map(e => e.points)).toEqual([16, 16, 16]);
+      expect(table.every(e => e.position === 1 && e.eventsPlayed === 3 && e.eventsCounted === 2)).toBe(true);
+
+      // Correct e3 results
+      state = applyMeritEvent(state, event('e3', [['a', 1], ['c', 2], ['b', 3]]));
+      table = getOrderOfMerit(state);
+      expect(table[0]).toMatchObject({ playerId: 'a', points: 20, wins: 2, position: 1 });
+      expect(table.map(e => e.playerId)).toEqual(['a', 'b', 'c']);
+      expect(table[2]).toMatchObject({ points: 12, position: 3 });
+    });
+  });
+});
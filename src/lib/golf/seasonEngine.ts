@@ -0,0 +1,312 @@
+// Season-long competitions: eclectic (best score per hole) and order of merit
+
+export interface SeasonRound {
+  roundId: string;
+  playerId: string;
+  date: number; // ms timestamp the round was played
+  scores: { [hole: number]: number };
+  course?: string; // the round's course
+}
+
+export interface SeasonConfig {
+  name: string;
+  startDate: number; // ms timestamp, inclusive
+  endDate: number; // ms timestamp, inclusive
+  holes?: number; // default 18
+  requireAttestation?: boolean; // only cards countersigned by a fellow competitor count
+  committee?: string[]; // pubkeys whose rulings correct or void cards
+  course?: string; // only rounds on this course count
+  merit?: OrderOfMeritConfig; // points table for the season's order of merit
+}
+
+export interface EclecticHole {
+  score: number;
+  roundId: string;
+  date: number;
+}
+
+export interface EclecticCard {
+  playerId: string;
+  holes: { [hole: number]: EclecticHole };
+  total: number; // sum of best scores for the holes played so far
+  holesCovered: number;
+  roundsCounted: number;
+}
+
+export interface EclecticEntry extends EclecticCard {
+  position: number;
+}
+
+/**
+ * Incremental eclectic state. Rounds are kept per player so a corrected
+ * score event can replace an earlier version of the same round.
+ */
+export interface EclecticState {
+  config: SeasonConfig;
+  rounds: { [playerId: string]: { [roundId: string]: SeasonRound } };
+  cards: { [playerId: string]: EclecticCard };
+}
+
+export function createEclecticState(config: SeasonConfig): EclecticState {
+  return { config, rounds: {}, cards: {} };
+}
+
+function inSeason(config: SeasonConfig, date: number): boolean {
+  return date >= config.startDate && date <= config.endDate;
+}
+
+const courseKey = (name: string) => name.toLowerCase().trim();
+
+/**
+ * Whether a round counts towards the season: played within its dates and,
+ * for a season on one course, on that course
+ */
+export function countsForSeason(config: SeasonConfig, round: SeasonRound): boolean {
+  if (!inSeason(config, round.date)) return false;
+  return !config.course || (!!round.course && courseKey(round.course) === courseKey(config.course));
+}
+
+function buildCard(playerId: string, rounds: SeasonRound[], holeCount: number): EclecticCard {
+  const holes: { [hole: number]: EclecticHole } = {};
+
+  for (const round of rounds) {
+    mergeRound(holes, round, holeCount);
+  }
+
+  return summarizeCard(playerId, holes, rounds.length);
+}
+
+function mergeRound(holes: { [hole: number]: EclecticHole }, round: SeasonRound, holeCount: number): void {
+  for (let hole = 1; hole <= holeCount; hole++) {
+    const score = round.scores[hole];
+    if (!score || score <= 0) continue;
+
+    const best = holes[hole];
+    // Earlier rounds keep the hole on a tie
+    if (!best || score < best.score || (score === best.score && round.date < best.date)) {
+      holes[hole] = { score, roundId: round.roundId, date: round.date };
+    }
+  }
+}
+
+function summarizeCard(playerId: string, holes: { [hole: number]: EclecticHole }, roundsCounted: number): EclecticCard {
+  const bestScores = Object.values(holes);
+  return {
+    playerId,
+    holes,
+    total: bestScores.reduce((sum, h) => sum + h.score, 0),
+    holesCovered: bestScores.length,
+    roundsCounted,
+  };
+}
+
+/**
+ * Apply a round to the eclectic. New rounds are merged in place; a round that
+ * replaces an earlier version of itself triggers a rebuild of that player's card only.
+ * Returns a new state so it can be used directly as React state.
+ */
+export function applyEclecticRound(state: EclecticState, round: SeasonRound): EclecticState {
+  if (!countsForSeason(state.config, round)) return state;
+
+  const holeCount = state.config.holes ?? 18;
+  const playerRounds = state.rounds[round.playerId] ?? {};
+  const isUpdate = round.roundId in playerRounds;
+
+  const rounds = {
+    ...state.rounds,
+    [round.playerId]: { ...playerRounds, [round.roundId]: round },
+  };
+
+  let card: EclecticCard;
+  if (isUpdate) {
+    card = buildCard(round.playerId, Object.values(rounds[round.playerId]), holeCount);
+  } else {
+    const existing = state.cards[round.playerId];
+    const holes = { ...(existing?.holes ?? {}) };
+    mergeRound(holes, round, holeCount);
+    card = summarizeCard(round.playerId, holes, (existing?.roundsCounted ?? 0) + 1);
+  }
+
+  return {
+    ...state,
+    rounds,
+    cards: { ...state.cards, [round.playerId]: card },
+  };
+}
+
+/**
+ * Eclectic leaderboard: players with more holes covered rank first, then lowest total
+ */
+export function getEclecticLeaderboard(state: EclecticState): EclecticEntry[] {
+  const sorted = Object.values(state.cards).sort((a, b) => {
+    if (a.holesCovered !== b.holesCovered) return b.holesCovered - a.holesCovered;
+    return a.total - b.total;
+  });
+
+  const entries: EclecticEntry[] = [];
+  sorted.forEach((card, i) => {
+    const prev = entries[i - 1];
+    const tied = prev && prev.holesCovered === card.holesCovered && prev.total === card.total;
+    entries.push({ ...card, position: tied ? prev.position : i + 1 });
+  });
+
+  return entries;
+}
+
+// Order of merit
+
+export interface MeritEventResult {
+  playerId: string;
+  position: number; // finishing position; ties share a position
+}
+
+export interface MeritEvent {
+  eventId: string;
+  name: string;
+  date: number;
+  multiplier?: number; // e.g. 2 for majors / club championship
+  results: MeritEventResult[];
+}
+
+export interface OrderOfMeritConfig {
+  pointsTable?: number[]; // points for 1st, 2nd, 3rd...
+  participationPoints?: number; // points for finishing outside the table
+  bestOf?: number; // only the best N events count
+}
+
+export interface MeritEntry {
+  playerId: string;
+  position: number;
+  points: number;
+  eventsPlayed: number;
+  eventsCounted: number;
+  wins: number;
+  eventPoints: { [eventId: string]: number };
+}
+
+export interface OrderOfMeritState {
+  config: OrderOfMeritConfig;
+  events: { [eventId: string]: MeritEvent };
+  eventPoints: { [eventId: string]: { [playerId: string]: number } };
+}
+
+export const DEFAULT_MERIT_POINTS = [100, 75, 60, 50, 45, 40, 36, 32, 29, 26, 24, 22, 20, 18, 16, 15, 14, 13, 12, 11];
+
+export function createOrderOfMeritState(config: OrderOfMeritConfig = {}): OrderOfMeritState {
+  return { config, events: {}, eventPoints: {} };
+}
+
+/**
+ * Points for one event. Tied players share the points for the places they cover
+ * (e.g. two players tied 2nd split 2nd and 3rd place points).
+ */
+export function calculateEventPoints(event: MeritEvent, config: OrderOfMeritConfig = {}): { [playerId: string]: number } {
+  const table = config.pointsTable ?? DEFAULT_MERIT_POINTS;
+  const participation = config.participationPoints ?? 0;
+  const multiplier = event.multiplier ?? 1;
+
+  const byPosition = new Map<number, string[]>();
+  for (const result of event.results) {
+    const players = byPosition.get(result.position) ?? [];
+    players.push(result.playerId);
+    byPosition.set(result.position, players);
+  }
+
+  const points: { [playerId: string]: number } = {};
+  for (const [position, players] of byPosition) {
+    let sum = 0;
+    for (let place = position; place < position + players.length; place++) {
+      sum += table[place - 1] ?? participation;
+    }
+    const share = Math.round((sum / players.length) * multiplier * 100) / 100;
+    for (const playerId of players) {
+      points[playerId] = share;
+    }
+  }
+
+  return points;
+}
+
+/**
+ * A finished round as an order of merit event: players who completed every
+ * hole are placed by gross score, ties sharing a position. Cards with holes
+ * missing, and rounds that don't count for the season, are left out.
+ */
+export function roundMeritEvent(config: SeasonConfig, name: string, rounds: SeasonRound[]): MeritEvent | null {
+  const holeCount = config.holes ?? 18;
+  const complete = rounds
+    .filter(round => countsForSeason(config, round))
+    .map(round => {
+      const scores = Array.from({ length: holeCount }, (_, i) => round.scores[i + 1] ?? 0);
+      return { round, gross: scores.every(s => s > 0) ? scores.reduce((sum, s) => sum + s, 0) : null };
+    })
+    .filter((card): card is { round: SeasonRound; gross: number } => card.gross !== null)
+    .sort((a, b) => a.gross - b.gross);
+  if (complete.length === 0) return null;
+
+  const results: MeritEventResult[] = [];
+  complete.forEach(({ round, gross }, i) => {
+    const prev = complete[i - 1];
+    const position = prev && prev.gross === gross ? results[i - 1].position : i + 1;
+    results.push({ playerId: round.playerId, position });
+  });
+
+  return {
+    eventId: complete[0].round.roundId,
+    name,
+    date: Math.min(...complete.map(c => c.round.date)),
+    results,
+  };
+}
+
+/**
+ * Add or replace an event's results. Only that event's points are recomputed.
+ */
+export function applyMeritEvent(state: OrderOfMeritState, event: MeritEvent): OrderOfMeritState {
+  return {
+    ...state,
+    events: { ...state.events, [event.eventId]: event },
+    eventPoints: { ...state.eventPoints, [event.eventId]: calculateEventPoints(event, state.config) },
+  };
+}
+
+/**
+ * Order of merit table, applying the best-of rule per player
+ */
+export function getOrderOfMerit(state: OrderOfMeritState): MeritEntry[] {
+  const players = new Map<string, { eventPoints: { [eventId: string]: number }; wins: number }>();
+
+  for (const [eventId, points] of Object.entries(state.eventPoints)) {
+    const event = state.events[eventId];
+    for (const [playerId, value] of Object.entries(points)) {
+      const entry = players.get(playerId) ?? { eventPoints: {}, wins: 0 };
+      entry.eventPoints[eventId] = value;
+      if (event.results.some(r => r.playerId === playerId && r.position === 1)) entry.wins++;
+      players.set(playerId, entry);
+    }
+  }
+
+  const entries = [...players.entries()].map(([playerId, { eventPoints, wins }]) => {
+    const values = Object.values(eventPoints).sort((a, b) => b - a);
+    const counted = state.config.bestOf ? values.slice(0, state.config.bestOf) : values;
+    return {
+      playerId,
+      position: 0,
+      points: Math.round(counted.reduce((sum, p) => sum + p, 0) * 100) / 100,
+      eventsPlayed: values.length,
+      eventsCounted: counted.length,
+      wins,
+      eventPoints,
+    };
+  });
+
+  // Ties on points are split by wins
+  entries.sort((a, b) => b.points - a.points || b.wins - a.wins);
+
+  entries.forEach((entry, i) => {
+    const prev = entries[i - 1];
+    entry.position = prev && prev.points === entry.points && prev.wins === entry.wins ? prev.position : i + 1;
+  });
+
+  return entries;
+}
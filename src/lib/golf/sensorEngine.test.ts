@@ -0,0 +1,65 @@
+import { describe, it, expect } from 'vitest';
+import { DEFAULT_BAG } from './bagEngine';
+import { MAX_SENSOR_BATCH, ingestSensorBatches, type SensorMeasurement } from './sensorEngine';
+
+const t0 = 1_780_000_000_000;
+const tee = { lat: 51.5, lon: -0.1 };
+const mid = { lat: 51.5006, lon: -0.1 };
+const landing = { lat: 51.5012, lon: -0.1 };
+
+describe('Sensor Engine', () => {
+  it('should build a shot from tracker positions', () => {
+    const { shots, errors } = ingestSensorBatches([{
+      deviceId: 'tracker-1',
+      measurements: [
+        { type: 'ball-position', at: t0 + 2000, values: mid },
+        { type: 'shot', at: t0, hole: 3, values: { club: '7 Iron', ...tee } },
+        { type: 'ball-position', at: t0 + 5000, values: landing },
+      ],
+    }], DEFAULT_BAG);
+
+    expect(errors).toEqual([]);
+    expect(shots).toHaveLength(1);
+    expect(shots[0]).toMatchObject({ shotId: `tracker-1-${t0}`, clubId: '7i', hole: 3, start: tee, end: landing, path: [mid], deviceId: 'tracker-1' });
+    expect(shots[0].distance).toBe(146);
+  });
+
+  it('should take the club from a smart grip and the distance in metres', () => {
+    const { shots } = ingestSensorBatches([{
+      deviceId: 'grip-1',
+      measurements: [
+        { type: 'club', at: t0, values: { club: 'Driver' } },
+        { type: 'shot', at: t0 + 1000, shotId: 'abc', values: { distance: 200, unit: 'm' } },
+      ],
+    }], DEFAULT_BAG);
+
+    expect(shots).toMatchObject([{ shotId: 'abc', clubId: 'dr', distance: 218.7, timestamp: t0 + 1000 }]);
+  });
+
+  it('should report bad measurements without losing the rest of the batch', () => {
+    const { shots, errors } = ingestSensorBatches([{
+      deviceId: 'pin-7',
+      measurements: [
+        { type: 'pin-tilt', at: t0, values: {} },
+        { type: 'shot', at: t0 + 1, values: { distance: 150 } },
+        { type: 'shot', at: t0 + 2, values: { club: 'Spoon', distance: 150 } },
+        { type: 'shot', at: t0 + 3, values: { club: '9i', distance: 130 } },
+      ],
+    }], DEFAULT_BAG);
+
+    expect(shots.map(s => s.clubId)).toEqual(['9i']);
+    expect(errors).toEqual([
+      `pin-7 at ${t0}: unknown measurement type "pin-tilt"`,
+      `pin-7 at ${t0 + 1}: shot without a club`,
+      `pin-7 at ${t0 + 2}: unknown club "Spoon"`,
+    ]);
+  });
+
+  it('should refuse oversized batches and batches without a device', () => {
+    const many: SensorMeasurement[] = Array.from({ length: MAX_SENSOR_BATCH + 1 }, (_, i) => ({ type: 'club', at: t0 + i, values: { club: '7i' } }));
+    const { shots, errors } = ingestSensorBatches([{ deviceId: 'big', measurements: many }, { deviceId: '', measurements: [] }], DEFAULT_BAG);
+
+    expect(shots).toEqual([]);
+    expect(errors).toEqual([`big: more than ${MAX_SENSOR_BATCH} measurements in one batch`, 'Batch without a device id']);
+  });
+});
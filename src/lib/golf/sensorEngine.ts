@@ -0,0 +1,146 @@
+// Sensor ingestion: batched measurements from rangefinders, ball trackers,
+// smart grips and similar hardware, turned into recorded shots.
+//
+// Every device sends the same batch shape, tagged with its device id; what a
+// measurement means is decided by its type. Supporting new hardware means
+// adding a handler for its measurement type, not a new import path.
+
+import { distanceYards, type GeoPoint } from './caddieEngine';
+import type { BagClub, ShotSample } from './bagEngine';
+import type { ShotDetails } from './nostrEvents';
+import { matchClub } from './launchMonitorImport';
+
+export interface SensorMeasurement {
+  type: string; // see SENSOR_TYPES
+  at: number; // ms, when the device took it
+  hole?: number;
+  shotId?: string; // ties measurements to one shot; otherwise the device's latest shot
+  values: { [name: string]: number | string };
+}
+
+export interface SensorBatch {
+  deviceId: string;
+  measurements: SensorMeasurement[];
+}
+
+export interface SensorShot extends ShotSample, ShotDetails {
+  deviceId: string;
+}
+
+export interface SensorIngest {
+  shots: SensorShot[];
+  errors: string[];
+}
+
+export const MAX_SENSOR_BATCH = 500;
+
+const YARDS_PER_METRE = 1.09361;
+
+interface DeviceState {
+  deviceId: string;
+  bag: BagClub[];
+  club: string | null; // last club a smart grip reported
+  shots: Map<string, { shot: Omit<SensorShot, 'distance'> & { distance?: number }; points: GeoPoint[] }>;
+  latest: string | null;
+}
+
+type Handler = (state: DeviceState, m: SensorMeasurement) => string | null;
+
+function position(m: SensorMeasurement): GeoPoint | null {
+  const lat = Number(m.values.lat);
+  const lon = Number(m.values.lon);
+  if (isNaN(lat) || isNaN(lon) || Math.abs(lat) > 90 || Math.abs(lon) > 180) return null;
+  return { lat, lon };
+}
+
+function yards(m: SensorMeasurement, name: string): number | undefined {
+  const value = Number(m.values[name]);
+  if (m.values[name] === undefined || isNaN(value) || value < 0) return undefined;
+  return m.values.unit === 'm' ? Math.round(value * YARDS_PER_METRE * 10) / 10 : value;
+}
+
+const HANDLERS: { [type: string]: Handler } = {
+  // Smart grip or club tag: the club for the next shot
+  club: (state, m) => {
+    const clubId = matchClub(String(m.values.club ?? ''), state.bag);
+    if (!clubId) return `unknown club "${m.values.club}"`;
+    state.club = clubId;
+    return null;
+  },
+
+  // Ball tracker or rangefinder: a shot struck, with where from and how far if known
+  shot: (state, m) => {
+    const clubId = m.values.club !== undefined ? matchClub(String(m.values.club), state.bag) : state.club;
+    if (!clubId) return m.values.club !== undefined ? `unknown club "${m.values.club}"` : 'shot without a club';
+
+    const shotId = m.shotId ?? `${state.deviceId}-${m.at}`;
+    const start = position(m);
+    state.shots.set(shotId, {
+      shot: { shotId, clubId, distance: yards(m, 'distance'), timestamp: m.at, hole: m.hole, deviceId: state.deviceId },
+      points: start ? [start] : [],
+    });
+    state.latest = shotId;
+    return null;
+  },
+
+  // Ball tracker: where the ball was during or after a shot
+  'ball-position': (state, m) => {
+    const entry = state.shots.get(m.shotId ?? state.latest ?? '');
+    if (!entry) return 'ball position before any shot';
+    const point = position(m);
+    if (!point) return 'invalid position';
+    entry.points.push(point);
+    return null;
+  },
+};
+
+export const SENSOR_TYPES = Object.keys(HANDLERS);
+
+/**
+ * Turn sensor batches into shots. Positions become the shot's start, end
+ * and traced path; a shot with no reported distance is measured from its
+ * start to its end. Problems are reported per measurement, not thrown, so
+ * one bad reading doesn't lose a batch.
+ */
+export function ingestSensorBatches(batches: SensorBatch[], bag: BagClub[]): SensorIngest {
+  const shots: SensorShot[] = [];
+  const errors: string[] = [];
+
+  for (const batch of batches) {
+    if (!batch.deviceId) {
+      errors.push('Batch without a device id');
+      continue;
+    }
+    if (batch.measurements.length > MAX_SENSOR_BATCH) {
+      errors.push(`${batch.deviceId}: more than ${MAX_SENSOR_BATCH} measurements in one batch`);
+      continue;
+    }
+
+    const state: DeviceState = { deviceId: batch.deviceId, bag, club: null, shots: new Map(), latest: null };
+    const measurements = [...batch.measurements].sort((a, b) => a.at - b.at);
+    measurements.forEach(m => {
+      const handler = HANDLERS[m.type];
+      const error = !handler ? `unknown measurement type "${m.type}"` : !Number.isFinite(m.at) ? 'missing time' : handler(state, m);
+      if (error) errors.push(`${batch.deviceId} at ${m.at}: ${error}`);
+    });
+
+    for (const { shot, points } of state.shots.values()) {
+      const start = points[0];
+      const end = points.length > 1 ? points[points.length - 1] : undefined;
+      const distance = shot.distance ?? (start && end ? Math.round(distanceYards(start, end)) : undefined);
+      if (distance === undefined) {
+        errors.push(`${batch.deviceId}: shot ${shot.shotId} has no distance or landing position`);
+        continue;
+      }
+      shots.push({
+        ...shot,
+        distance,
+        start,
+        end,
+        path: points.length > 2 ? points.slice(1, -1) : undefined,
+      });
+    }
+  }
+
+  return { shots: shots.sort((a, b) => a.timestamp - b.timestamp), errors };
+}
@@ -0,0 +1,60 @@
+import { describe, it, expect } from 'vitest';
+import { sideCompetitionPrizes, sideStandings, teeShots, type SideCompetition, type SideShot } from './sideCompetitionEngine';
+
+const green = { lat: 51.5, lon: -0.1 };
+const near = { lat: 51.50003, lon: -0.1 }; // a few yards short
+const nearer = { lat: 51.50001, lon: -0.1 };
+
+const ctp: SideCompetition = { hole: 3, type: 'closest-to-pin', prizeSats: 1000 };
+const ld: SideCompetition = { hole: 7, type: 'longest-drive', prizeSats: 500 };
+
+describe('Side Competition Engine', () => {
+  it('should take each player\'s first shot on a hole as the tee shot', () => {
+    const shots: SideShot[] = [
+      { playerPubkey: 'a', hole: 3, distance: 20, timestamp: 200 },
+      { playerPubkey: 'a', hole: 3, distance: 150, timestamp: 100 },
+    ];
+    expect(teeShots(shots).get('a:3')?.distance).toBe(150);
+  });
+
+  it('should lead closest to the pin with the ball nearest the green', () => {
+    const shots: SideShot[] = [
+      { playerPubkey: 'a', hole: 3, distance: 150, end: near, timestamp: 1 },
+      { playerPubkey: 'b', hole: 3, distance: 152, end: nearer, timestamp: 2 },
+      { playerPubkey: 'c', hole: 3, distance: 90, end: { lat: 51.501, lon: -0.1 }, timestamp: 3 },
+    ];
+    const [standing] = sideStandings([ctp], shots, { 3: green });
+    expect(standing.leader?.playerPubkey).toBe('b');
+    expect(standing.entries.map(e => e.playerPubkey)).toEqual(['b', 'a']); // c missed the green
+  });
+
+  it('should not count a drive that missed the fairway', () => {
+    const shots: SideShot[] = [
+      { playerPubkey: 'a', hole: 7, distance: 310, timestamp: 1 },
+      { playerPubkey: 'b', hole: 7, distance: 265, timestamp: 2 },
+    ];
+    const [standing] = sideStandings([ld], shots, {}, ['a:7']);
+    expect(standing.leader).toEqual({ playerPubkey: 'b', yards: 265 });
+  });
+
+  it('should pay each winner the competition prize', () => {
+    const shots: SideShot[] = [
+      { playerPubkey: 'a', hole: 3, distance: 150, end: nearer, timestamp: 1 },
+      { playerPubkey: 'b', hole: 7, distance: 280, timestamp: 2 },
+    ];
+    const prizes = sideCompetitionPrizes(sideStandings([ctp, ld], shots, { 3: green }), 'r1');
+    expect(prizes).toEqual([
+      { prizeId: 'r1:closest-to-pin-3:a', recipient: 'a', amountSats: 1000, memo: 'Closest to the Pin, hole 3' },
+      { prizeId: 'r1:longest-drive-7:b', recipient: 'b', amountSats: 500, memo: 'Longest Drive, hole 7' },
+    ]);
+  });
+
+  it('should split a tied prize with the odd sat to the first to hit', () => {
+    const shots: SideShot[] = [
+      { playerPubkey: 'b', hole: 7, distance: 280, timestamp: 2 },
+      { playerPubkey: 'a', hole: 7, distance: 280, timestamp: 1 },
+    ];
+    const prizes = sideCompetitionPrizes(sideStandings([{ ...ld, prizeSats: 501 }], shots), 'r1');
+    expect(prizes.map(p => [p.recipient, p.amountSats])).toEqual([['a', 251], ['b', 250]]);
+  });
+});
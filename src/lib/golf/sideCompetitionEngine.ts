@@ -0,0 +1,115 @@
+// Side competitions: closest to the pin and longest drive, judged from GPS shots
+
+import { distanceYards, type GeoPoint } from './caddieEngine';
+import type { Prize } from './payoutEngine';
+
+export type SideCompetitionType = 'closest-to-pin' | 'longest-drive';
+
+export interface SideCompetition {
+  hole: number;
+  type: SideCompetitionType;
+  prizeSats: number;
+}
+
+export interface SideShot {
+  playerPubkey: string;
+  hole: number;
+  distance: number; // measured carry (yards)
+  end?: GeoPoint;
+  timestamp: number;
+}
+
+export interface SideEntry {
+  playerPubkey: string;
+  yards: number;
+}
+
+export interface SideStanding {
+  competition: SideCompetition;
+  entries: SideEntry[]; // best first
+  leader?: SideEntry;
+}
+
+export const SIDE_COMPETITION_NAMES: Record<SideCompetitionType, string> = {
+  'closest-to-pin': 'Closest to the Pin',
+  'longest-drive': 'Longest Drive',
+};
+
+// A ball farther than this from the green centre missed the green
+export const MAX_PIN_YARDS = 30;
+
+/**
+ * Each player's tee shot per hole: their first shot recorded on it
+ */
+export function teeShots(shots: SideShot[]): Map<string, SideShot> {
+  const first = new Map<string, SideShot>();
+  for (const shot of shots) {
+    const key = `${shot.playerPubkey}:${shot.hole}`;
+    const seen = first.get(key);
+    if (!seen || shot.timestamp < seen.timestamp) first.set(key, shot);
+  }
+  return first;
+}
+
+/**
+ * Current standings for each side competition. Closest to the pin measures
+ * where the tee shot finished from the green centre; longest drive takes the
+ * tee shot's carry, unless the player recorded missing the fairway
+ * (`missedFairways` holds `pubkey:hole` keys).
+ */
+export function sideStandings(
+  competitions: SideCompetition[],
+  shots: SideShot[],
+  greens: { [hole: number]: GeoPoint } = {},
+  missedFairways: string[] = []
+): SideStanding[] {
+  const tee = [...teeShots(shots).values()];
+  const missed = new Set(missedFairways);
+
+  return competitions.map(competition => {
+    const onHole = tee
+      .filter(s => s.hole === competition.hole)
+      .sort((a, b) => a.timestamp - b.timestamp); // ties stay in the order they were hit
+    let entries: SideEntry[];
+
+    if (competition.type === 'closest-to-pin') {
+      const green = greens[competition.hole];
+      entries = green
+        ? onHole
+          .filter(s => s.end)
+          .map(s => ({ playerPubkey: s.playerPubkey, yards: distanceYards(s.end!, green) }))
+          .filter(e => e.yards <= MAX_PIN_YARDS)
+          .sort((a, b) => a.yards - b.yards)
+        : [];
+    } else {
+      entries = onHole
+        .filter(s => !missed.has(`${s.playerPubkey}:${s.hole}`))
+        .map(s => ({ playerPubkey: s.playerPubkey, yards: s.distance }))
+        .sort((a, b) => b.yards - a.yards);
+    }
+
+    return { competition, entries, leader: entries[0] };
+  });
+}
+
+/**
+ * Prizes for the winners once the round closes. A tie for the lead splits
+ * the prize, with any odd sat going to the first to hit.
+ */
+export function sideCompetitionPrizes(standings: SideStanding[], roundId: string): Prize[] {
+  const prizes: Prize[] = [];
+  for (const { competition, entries, leader } of standings) {
+    if (!leader || competition.prizeSats <= 0) continue;
+    const winners = entries.filter(e => e.yards === leader.yards);
+    const share = Math.floor(competition.prizeSats / winners.length);
+    const remainder = competition.prizeSats - share * winners.length;
+
+    winners.forEach((winner, i) => prizes.push({
+      prizeId: `${roundId}:${competition.type}-${competition.hole}:${winner.playerPubkey}`,
+      recipient: winner.playerPubkey,
+      amountSats: share + (i === 0 ? remainder : 0),
+      memo: `${SIDE_COMPETITION_NAMES[competition.type]}, hole ${competition.hole}`,
+    }));
+  }
+  return prizes;
+}
@@ -0,0 +1,63 @@
+import { describe, it, expect } from 'vitest';
+import { DEFAULT_BAG } from './bagEngine';
+import { detectSimulatorSource, parseSimulatorRound } from './simulatorImport';
+
+describe('Simulator Import', () => {
+  it('should detect the simulator from its headers', () => {
+    expect(detectSimulatorSource(['Hole', 'Club', 'Carry (yds)', 'HLA', 'VLA'])).toBe('gspro');
+    expect(detectSimulatorSource(['Hole', 'Club', 'Club Speed', 'Smash Factor', 'Carry'])).toBe('trackman');
+    expect(detectSimulatorSource(['Hole', 'Par', 'Score'])).toBe('generic');
+  });
+
+  it('should parse a scorecard export and skip the totals row', () => {
+    const csv = [
+      'Date,Course,Hole,Par,Score,Putts',
+      '2026-03-01,Pebble Beach,1,4,5,2',
+      '2026-03-01,Pebble Beach,2,5,5,1',
+      '2026-03-01,Pebble Beach,3,4,3,1',
+      ',,Total,13,13,4',
+    ].join('\n');
+
+    const round = parseSimulatorRound(csv, DEFAULT_BAG);
+
+    expect(round.errors).toEqual([]);
+    expect(round.course).toBe('Pebble Beach');
+    expect(round.date).toBe(Date.parse('2026-03-01'));
+    expect(round.holes).toEqual([
+      { hole: 1, strokes: 5, par: 4, putts: 2 },
+      { hole: 2, strokes: 5, par: 5, putts: 1 },
+      { hole: 3, strokes: 3, par: 4, putts: 1 },
+    ]);
+    expect(round.shots).toEqual([]);
+  });
+
+  it('should count strokes and putts from a GSPro shot log', () => {
+    const csv = [
+      'Course,Hole,Club,Carry (yds),Total (yds),HLA,VLA',
+      'St Andrews,1,Driver,240,262,1.2,11',
+      'St Andrews,1,PW,118,121,-0.5,27',
+      'St Andrews,1,Putter,,12,0,0',
+      'St Andrews,1,Putter,,2,0,0',
+      'St Andrews,2,7 Iron,160,168,0.3,17',
+      'St Andrews,2,Putter,,8,0,0',
+    ].join('\n');
+
+    const round = parseSimulatorRound(csv, DEFAULT_BAG);
+
+    expect(round.source).toBe('gspro');
+    expect(round.holes).toEqual([
+      { hole: 1, strokes: 4, par: undefined, putts: 2 },
+      { hole: 2, strokes: 2, par: undefined, putts: 1 },
+    ]);
+    expect(round.shots.map(s => [s.clubId, s.carry])).toEqual([['dr', 240], ['pw', 118], ['7i', 160]]);
+  });
+
+  it('should report bad rows and exports it cannot read', () => {
+    const bad = parseSimulatorRound('Hole,Par,Score\n1,4,\n19,4,4\n2,3,3', DEFAULT_BAG);
+    expect(bad.holes).toEqual([{ hole: 2, strokes: 3, par: 3, putts: undefined }]);
+    expect(bad.errors).toEqual(['Row 2: missing score', 'Row 3: hole 19 out of range']);
+
+    expect(parseSimulatorRound('Club,Carry\nDriver,240', DEFAULT_BAG).errors).toEqual(['No hole column found']);
+    expect(parseSimulatorRound('Hole,Carry\n1,240', DEFAULT_BAG).errors).toEqual(['Neither a score nor a club column found']);
+  });
+});
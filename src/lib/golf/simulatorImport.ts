@@ -0,0 +1,130 @@
+// Simulator round import (GSPro, TrackMan and similar round exports)
+//
+// A simulator export is either a scorecard (one row per hole) or a shot log
+// (one row per shot, with the hole it was played on). Either way it becomes
+// an indoor round; shot logs also give practice shots for the bag.
+
+import { parseCsv } from './courseImport';
+import type { BagClub } from './bagEngine';
+import { canonicalClub, parseLaunchMonitorCsv, type LaunchMonitorShot } from './launchMonitorImport';
+
+export type SimulatorSource = 'gspro' | 'trackman' | 'generic';
+
+export interface SimulatorHole {
+  hole: number;
+  strokes: number;
+  par?: number;
+  putts?: number;
+}
+
+export interface SimulatorRound {
+  source: SimulatorSource;
+  course: string;
+  date: number; // ms
+  holes: SimulatorHole[];
+  shots: LaunchMonitorShot[]; // full swings from a shot log, for practice stats
+  unmatchedClubs: string[];
+  errors: string[];
+}
+
+// Header aliases in priority order; headers are compared without their units
+const COLUMNS = {
+  hole: ['hole', 'hole number', 'hole #'],
+  par: ['par'],
+  strokes: ['score', 'strokes', 'gross'],
+  putts: ['putts'],
+  club: ['club', 'club type', 'club name'],
+  course: ['course', 'course name'],
+  date: ['date', 'played', 'time', 'timestamp'],
+} as const;
+
+type Column = keyof typeof COLUMNS;
+
+function headerName(header: string): string {
+  return header.replace(/\s*[([].*?[)\]]\s*$/, '').toLowerCase().trim();
+}
+
+export function detectSimulatorSource(headers: string[]): SimulatorSource {
+  const names = headers.map(headerName);
+  // GSPro reports launch direction as HLA/VLA
+  if (names.includes('hla') || names.includes('vla') || names.some(n => n.includes('gspro'))) return 'gspro';
+  if (names.includes('smash factor') || names.includes('attack angle') || names.some(n => n.includes('trackman'))) return 'trackman';
+  return 'generic';
+}
+
+/**
+ * Parse a simulator round export. Scorecard exports give strokes per hole;
+ * shot logs count the shots played on each hole, with putter strokes as
+ * putts, and keep the other shots (in yards, matched to the bag) for
+ * practice stats.
+ */
+export function parseSimulatorRound(text: string, bag: BagClub[]): SimulatorRound {
+  const rows = parseCsv(text);
+  const empty = { course: '', date: Date.now(), holes: [], shots: [], unmatchedClubs: [] };
+  if (rows.length < 2) return { source: 'generic', ...empty, errors: ['No holes found'] };
+
+  const source = detectSimulatorSource(rows[0]);
+  const names = rows[0].map(headerName);
+  const columns = {} as { [column in Column]?: number };
+  for (const column of Object.keys(COLUMNS) as Column[]) {
+    const index = COLUMNS[column].map(alias => names.indexOf(alias)).find(i => i !== -1);
+    if (index !== undefined) columns[column] = index;
+  }
+
+  if (columns.hole === undefined) return { source, ...empty, errors: ['No hole column found'] };
+  const isScorecard = columns.strokes !== undefined;
+  if (!isScorecard && columns.club === undefined) {
+    return { source, ...empty, errors: ['Neither a score nor a club column found'] };
+  }
+
+  const errors: string[] = [];
+  const holes = new Map<number, SimulatorHole>();
+  const cell = (row: string[], column: Column) => (columns[column] !== undefined ? row[columns[column]!]?.trim() ?? '' : '');
+  const number = (row: string[], column: Column) => {
+    const n = parseInt(cell(row, column));
+    return isNaN(n) ? undefined : n;
+  };
+
+  rows.slice(1).forEach((row, index) => {
+    if (row.every(v => v.trim() === '')) return;
+    const hole = number(row, 'hole');
+    // Totals and unit rows have no hole number
+    if (hole === undefined) return;
+    if (hole < 1 || hole > 18) {
+      errors.push(`Row ${index + 2}: hole ${hole} out of range`);
+      return;
+    }
+
+    const entry = holes.get(hole) ?? { hole, strokes: 0, par: number(row, 'par') };
+    if (isScorecard) {
+      const strokes = number(row, 'strokes');
+      if (!strokes || strokes < 1) {
+        errors.push(`Row ${index + 2}: missing score`);
+        return;
+      }
+      entry.strokes = strokes;
+      entry.putts = number(row, 'putts');
+    } else {
+      entry.strokes++;
+      if (canonicalClub(cell(row, 'club')) === 'pt') entry.putts = (entry.putts ?? 0) + 1;
+    }
+    holes.set(hole, entry);
+  });
+
+  const rowWith = (column: Column) => rows.slice(1).find(row => cell(row, column) !== '');
+  const courseRow = rowWith('course');
+  const dateRow = rowWith('date');
+  const date = dateRow ? Date.parse(cell(dateRow, 'date')) : NaN;
+
+  const swings = isScorecard ? null : parseLaunchMonitorCsv(text, bag);
+
+  return {
+    source,
+    course: courseRow ? cell(courseRow, 'course') : '',
+    date: isNaN(date) ? Date.now() : date,
+    holes: [...holes.values()].sort((a, b) => a.hole - b.hole),
+    shots: swings ? swings.shots.filter(s => s.clubId !== 'pt' && s.carry !== undefined) : [],
+    unmatchedClubs: swings ? swings.unmatchedClubs : [],
+    errors: holes.size === 0 ? [...errors, 'No holes found'] : errors,
+  };
+}
@@ -0,0 +1,45 @@
+import { describe, it, expect } from 'vitest';
+import { activeSlots, countImpression, sponsorForRotation, validateSponsorSlot, type SponsorSlot } from './sponsorEngine';
+
+describe('Sponsor Engine', () => {
+  const slot = (slotId: string, overrides: Partial<SponsorSlot> = {}): SponsorSlot => ({
+    slotId,
+    courseId: 'pebble',
+    sponsor: `Sponsor ${slotId}`,
+    imageUrl: `https://blossom.example/${slotId}.png`,
+    placements: ['tv'],
+    startsAt: 1000,
+    endsAt: 2000,
+    weight: 1,
+    ...overrides,
+  });
+
+  it('should validate a slot before it is published', () => {
+    expect(validateSponsorSlot(slot('a'))).toEqual([]);
+    expect(validateSponsorSlot(slot('a', { sponsor: ' ', placements: [], endsAt: 500, weight: 11 }))).toHaveLength(4);
+  });
+
+  it('should only run slots for their placement and dates', () => {
+    const slots = [slot('b'), slot('a', { placements: ['scorecard'] }), slot('c', { startsAt: 1500 })];
+    expect(activeSlots(slots, 'tv', 1200).map(s => s.slotId)).toEqual(['b']);
+    expect(activeSlots(slots, 'tv', 1600).map(s => s.slotId)).toEqual(['b', 'c']);
+    expect(activeSlots(slots, 'scorecard', 2000)).toEqual([]);
+  });
+
+  it('should share rotations in proportion to weight, spread out', () => {
+    const slots = [slot('a', { weight: 2 }), slot('b', { weight: 1 })];
+    const order = [0, 1, 2, 3, 4, 5].map(r => sponsorForRotation(slots, r)?.slotId);
+    expect(order).toEqual(['a', 'b', 'a', 'a', 'b', 'a']);
+  });
+
+  it('should show nothing without slots', () => {
+    expect(sponsorForRotation([], 3)).toBeNull();
+  });
+
+  it('should count impressions per slot', () => {
+    const once = countImpression({}, 'a', 10);
+    const twice = countImpression(once, 'a', 20);
+    expect(twice).toEqual({ a: { count: 2, lastShownAt: 20 } });
+    expect(once.a.count).toBe(1);
+  });
+});
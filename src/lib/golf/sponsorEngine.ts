@@ -0,0 +1,70 @@
+// Sponsor slots: club sponsor banners scheduled on the clubhouse TV and scorecards
+
+export type SponsorPlacement = 'tv' | 'scorecard';
+
+export interface SponsorSlot {
+  slotId: string;
+  courseId: string;
+  sponsor: string; // sponsor name, also the banner's alt text
+  imageUrl: string;
+  link?: string;
+  placements: SponsorPlacement[];
+  startsAt: number; // ms
+  endsAt: number; // ms
+  weight: number; // relative share of rotations (1-10)
+}
+
+export interface SponsorImpressions {
+  [slotId: string]: { count: number; lastShownAt: number };
+}
+
+/**
+ * Problems with a slot before it's published
+ */
+export function validateSponsorSlot(slot: SponsorSlot): string[] {
+  const errors: string[] = [];
+  if (!slot.sponsor.trim()) errors.push('Sponsor name is required');
+  if (!slot.imageUrl) errors.push('Upload a banner image');
+  if (slot.placements.length === 0) errors.push('Choose where the banner is shown');
+  if (!(slot.endsAt > slot.startsAt)) errors.push('The slot must end after it starts');
+  if (!Number.isInteger(slot.weight) || slot.weight < 1 || slot.weight > 10) errors.push('Weight must be between 1 and 10');
+  return errors;
+}
+
+/**
+ * Slots running at a time for a placement
+ */
+export function activeSlots(slots: SponsorSlot[], placement: SponsorPlacement, now: number): SponsorSlot[] {
+  return slots
+    .filter(s => s.placements.includes(placement) && s.startsAt <= now && now < s.endsAt)
+    .sort((a, b) => a.slotId.localeCompare(b.slotId));
+}
+
+/**
+ * The slot to show for the nth rotation. Each slot gets a share of rotations
+ * in proportion to its weight, spread out rather than shown back to back.
+ */
+export function sponsorForRotation(slots: SponsorSlot[], rotation: number): SponsorSlot | null {
+  const total = slots.reduce((sum, s) => sum + s.weight, 0);
+  if (total === 0) return null;
+
+  // Smooth weighted round robin over one full cycle
+  const current = slots.map(() => 0);
+  let chosen = 0;
+  for (let r = 0; r <= rotation % total; r++) {
+    slots.forEach((s, i) => (current[i] += s.weight));
+    chosen = current.indexOf(Math.max(...current));
+    current[chosen] -= total;
+  }
+  return slots[chosen];
+}
+
+/**
+ * Count one more impression of a slot
+ */
+export function countImpression(impressions: SponsorImpressions, slotId: string, now: number): SponsorImpressions {
+  return {
+    ...impressions,
+    [slotId]: { count: (impressions[slotId]?.count ?? 0) + 1, lastShownAt: now },
+  };
+}
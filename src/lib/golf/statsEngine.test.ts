@@ -0,0 +1,48 @@
+import { describe, it, expect } from 'vitest';
+import { K_ANONYMITY, ROUNDS_PER_PLAYER, laplace, publicStats, type StatsRound, type StatsScore } from './statsEngine';
+
+const noNoise = () => 0.5;
+const at = (hour: number) => new Date(2026, 5, 1, hour).getTime();
+
+function rounds(course: string, players: number, hour = 9): StatsRound[] {
+  return Array.from({ length: players }, (_, i) => ({ roundId: `${course}-${i}`, course, teeTime: at(hour), players: [`p${i}`] }));
+}
+
+describe('Stats Engine', () => {
+  it('should leave out courses with too few players', () => {
+    const stats = publicStats([...rounds('Oak Hills', K_ANONYMITY), ...rounds('Tiny Links', K_ANONYMITY - 1)], [], [], noNoise);
+    expect(stats.courses.map(c => c.course)).toEqual(['Oak Hills']);
+    expect(stats.courses[0]).toMatchObject({ rounds: K_ANONYMITY, averageScore: null, aces: 0 });
+  });
+
+  it('should average each player once, within the score range', () => {
+    const scores: StatsScore[] = [
+      ...Array.from({ length: 4 }, (_, i) => ({ roundId: `r${i}`, course: 'Oak Hills', player: `p${i}`, gross: 80 })),
+      // One player with many rounds counts the same as the others, and 200 is clamped
+      ...Array.from({ length: 5 }, (_, i) => ({ roundId: `x${i}`, course: 'Oak Hills', player: 'p4', gross: 200 })),
+    ];
+    const stats = publicStats(rounds('Oak Hills', 5), scores, [], noNoise);
+    expect(stats.courses[0].averageScore).toBe(90);
+  });
+
+  it('should cap how many rounds one player adds to a count', () => {
+    const regular: StatsRound[] = Array.from({ length: 50 }, (_, i) => ({ roundId: `reg-${i}`, course: 'Oak Hills', teeTime: at(9), players: ['regular'] }));
+    const stats = publicStats([...rounds('Oak Hills', K_ANONYMITY), ...regular], [], [], noNoise);
+    expect(stats.courses[0].rounds).toBe(K_ANONYMITY + ROUNDS_PER_PLAYER);
+  });
+
+  it('should report busy hours with enough players', () => {
+    const stats = publicStats([...rounds('Oak Hills', 6, 8), ...rounds('Elm Park', 2, 15)], [], [], noNoise);
+    expect(stats.hours).toEqual([{ hour: 8, rounds: 6 }]);
+  });
+
+  it('should add noise that averages out', () => {
+    let seed = 1;
+    const random = () => (seed = (seed * 16807) % 2147483647) / 2147483647;
+    const samples = Array.from({ length: 5000 }, () => laplace(2, random));
+    const mean = samples.reduce((a, b) => a + b, 0) / samples.length;
+    const meanAbs = samples.reduce((a, b) => a + Math.abs(b), 0) / samples.length;
+    expect(Math.abs(mean)).toBeLessThan(0.2);
+    expect(Math.abs(meanAbs - 2)).toBeLessThan(0.2);
+  });
+});
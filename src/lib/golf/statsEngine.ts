@@ -0,0 +1,115 @@
+// Public statistics: course averages, busy hours and aces, aggregated so
+// no one player can be picked out. Groups with fewer than K_ANONYMITY
+// players are left out, and Laplace noise is added to what remains.
+
+export interface StatsRound {
+  roundId: string;
+  course: string;
+  teeTime: number; // ms
+  players: string[]; // pubkeys
+}
+
+export interface StatsScore {
+  roundId: string;
+  course: string;
+  player: string;
+  gross: number; // a complete 18-hole card
+}
+
+export interface StatsAce {
+  course: string;
+  player: string;
+}
+
+export interface CourseStats {
+  course: string;
+  rounds: number;
+  averageScore: number | null; // null when too few players have complete cards
+  aces: number;
+}
+
+export interface HourStats {
+  hour: number; // 0-23 in the viewer's timezone
+  rounds: number;
+}
+
+export interface PublicStats {
+  k: number;
+  epsilon: number;
+  courses: CourseStats[];
+  hours: HourStats[];
+}
+
+export const K_ANONYMITY = 5;
+export const STATS_EPSILON = 1; // privacy budget for each published number
+export const ROUNDS_PER_PLAYER = 10; // most rounds one player adds to any count
+export const SCORE_RANGE: [number, number] = [60, 130]; // gross scores are clamped to this
+
+/** A sample from the Laplace distribution centred on 0 */
+export function laplace(scale: number, random: () => number = Math.random): number {
+  const u = random() - 0.5;
+  return -scale * Math.sign(u) * Math.log(1 - 2 * Math.abs(u));
+}
+
+/** Keep at most `cap` items per player, so no one player moves a count far */
+function capPerPlayer<T>(items: T[], player: (item: T) => string, cap: number): T[] {
+  const seen = new Map<string, number>();
+  return items.filter(item => {
+    const n = (seen.get(player(item)) ?? 0) + 1;
+    seen.set(player(item), n);
+    return n <= cap;
+  });
+}
+
+function noisyCount(count: number, sensitivity: number, random: () => number): number {
+  return Math.max(0, Math.round(count + laplace(sensitivity / STATS_EPSILON, random)));
+}
+
+/**
+ * Mean of each player's average score, so everyone counts once. Changing
+ * one player moves it by at most the score range over the number of players.
+ */
+function noisyAverage(scores: StatsScore[], random: () => number): number | null {
+  const byPlayer = new Map<string, number[]>();
+  for (const s of scores) byPlayer.set(s.player, [...(byPlayer.get(s.player) ?? []), s.gross]);
+  if (byPlayer.size < K_ANONYMITY) return null;
+
+  const [lo, hi] = SCORE_RANGE;
+  const averages = [...byPlayer.values()].map(g => Math.min(hi, Math.max(lo, g.reduce((a, b) => a + b, 0) / g.length)));
+  const mean = averages.reduce((a, b) => a + b, 0) / averages.length;
+  const noisy = mean + laplace((hi - lo) / (averages.length * STATS_EPSILON), random);
+  return Math.round(Math.min(hi, Math.max(lo, noisy)) * 10) / 10;
+}
+
+/**
+ * Statistics safe to publish. A course or hour only appears once
+ * K_ANONYMITY different players have rounds in it.
+ */
+export function publicStats(rounds: StatsRound[], scores: StatsScore[], aces: StatsAce[], random: () => number = Math.random): PublicStats {
+  const played = rounds.flatMap(r => r.players.map(player => ({ ...r, player })));
+  const courses = [...new Set(played.map(p => p.course))].sort();
+
+  const courseStats = courses.flatMap(course => {
+    const here = played.filter(p => p.course === course);
+    if (new Set(here.map(p => p.player)).size < K_ANONYMITY) return [];
+
+    const roundIds = new Set(capPerPlayer(here, p => p.player, ROUNDS_PER_PLAYER).map(p => p.roundId));
+    const aceCount = new Set(aces.filter(a => a.course === course).map(a => a.player)).size;
+    return [{
+      course,
+      rounds: noisyCount(roundIds.size, ROUNDS_PER_PLAYER, random),
+      averageScore: noisyAverage(scores.filter(s => s.course === course), random),
+      // Aces are rare, so each player counts once; a handful of noise would otherwise swamp them
+      aces: noisyCount(aceCount, 1, random),
+    }];
+  });
+
+  const hours = Array.from({ length: 24 }, (_, hour) => hour).flatMap(hour => {
+    const here = played.filter(p => new Date(p.teeTime).getHours() === hour);
+    if (new Set(here.map(p => p.player)).size < K_ANONYMITY) return [];
+    const roundIds = new Set(capPerPlayer(here, p => p.player, ROUNDS_PER_PLAYER).map(p => p.roundId));
+    return [{ hour, rounds: noisyCount(roundIds.size, ROUNDS_PER_PLAYER, random) }];
+  });
+
+  return { k: K_ANONYMITY, epsilon: STATS_EPSILON, courses: courseStats, hours };
+}
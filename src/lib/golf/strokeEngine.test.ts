@@ -4,6 +4,7 @@ import {
   applyMaxScore,
   calculatePops,
   convertToRoundData,
+  holesInPlay,
   type CoreRoundData,
   type StrokeConfig,
   type MaxScoreRule
@@ -137,4 +138,47 @@ describe('Stroke Play Engine', () => {
       expect(converted.handicap?.pops.alice).toBeDefined();
     });
   });
+
+  describe('Nine-hole and partial rounds', () => {
+    const frontNine = { ...sampleRoundData, strokes: {
+      alice: { 1: 4, 2: 5, 3: 3, 4: 6, 5: 4, 6: 2, 7: 4, 8: 5, 9: 4 },
+      bob: { 1: 5, 2: 6, 3: 4, 4: 7, 5: 5, 6: 4, 7: 5, 8: 6, 9: 5 },
+      charlie: { 1: 3, 2: 4, 3: 2, 4: 5, 5: 3, 6: 3, 7: 3, 8: 4, 9: 3 }
+    } };
+
+    it('should only score the holes in play', () => {
+      const result = strokeEngine(frontNine, { useNet: true, holes: holesInPlay(9) });
+
+      expect(result.breakdown.totals.alice).toEqual({ gross: 37, net: 35 });
+      expect(Object.keys(result.breakdown.holeByHole.alice)).toHaveLength(9);
+    });
+
+    it('should skip unscored holes by default', () => {
+      const result = strokeEngine(frontNine, { useNet: true });
+      expect(result.breakdown.totals.bob).toEqual({ gross: 47, net: 42 });
+    });
+
+    it('should fill holes not played with net par or net double bogey', () => {
+      const partial: CoreRoundData = { ...sampleRoundData, strokes: { ...sampleRoundData.strokes, bob: { ...sampleRoundData.strokes.bob } } };
+      delete partial.strokes.bob[18]; // par 4, no stroke received
+      delete partial.strokes.bob[17]; // par 5, one stroke received
+
+      const netPar = strokeEngine(partial, { useNet: true, holeNotPlayed: 'net-par' });
+      expect(netPar.breakdown.holeByHole.bob[17]).toEqual({ gross: 6, net: 5, notPlayed: true });
+      expect(netPar.breakdown.holeByHole.bob[18]).toEqual({ gross: 4, net: 4, notPlayed: true });
+
+      const ndb = strokeEngine(partial, { useNet: true, holeNotPlayed: 'net-double-bogey' });
+      expect(ndb.breakdown.holeByHole.bob[17].gross).toBe(8);
+    });
+
+    it('should allocate strokes within the nine holes in play', () => {
+      // Back nine stroke indexes: 9, 5, 15, 1, 13, 17, 7, 3, 11
+      const pops = calculatePops(5, sampleCourse.holes, holesInPlay(9, 10));
+
+      expect(Object.values(pops).reduce((sum, p) => sum + p, 0)).toBe(5);
+      expect([13, 17, 11, 16, 10].map(h => pops[h])).toEqual([1, 1, 1, 1, 1]);
+      expect(pops[18]).toBe(0);
+      expect(pops[1]).toBeUndefined();
+    });
+  });
 });
@@ -13,6 +13,8 @@ export interface CoreRoundData {
 export interface StrokeConfig {
   useNet: boolean;
   maxScoreRule?: MaxScoreRule;
+  holes?: number[]; // holes in play, e.g. [1..9] or [10..18] for nine-hole rounds (default 1-18)
+  holeNotPlayed?: 'none' | 'net-par' | 'net-double-bogey'; // score for holes without a score (default 'none')
 }
 
 export interface MaxScoreRule {
@@ -28,6 +30,7 @@ export interface PlayerTotals {
 export interface HoleBreakdown {
   gross: number;
   net: number;
+  notPlayed?: boolean; // score was filled in by the hole-not-played rule
 }
 
 export interface StrokeBreakdown {
@@ -104,10 +107,37 @@ export function rankPlayers(totals: { [playerId: string]: PlayerTotals }, useNet
 }
 
 /**
- * Calculate handicap "pops" (strokes received) for each hole
+ * Holes 1-18, or 1-9 / 10-18 for a nine-hole round
  */
-export function calculatePops(playerHandicap: number, holes: { [hole: number]: { strokeIndex: number } }): { [hole: number]: number } {
+export function holesInPlay(holeCount: 9 | 18 = 18, startingHole: 1 | 10 = 1): number[] {
+  return Array.from({ length: holeCount }, (_, i) => startingHole + i);
+}
+
+/**
+ * Calculate handicap "pops" (strokes received) for each hole.
+ * For a nine-hole round pass the holes in play; strokes are then allocated
+ * by stroke index order within those nine holes.
+ */
+export function calculatePops(
+  playerHandicap: number,
+  holes: { [hole: number]: { strokeIndex: number } },
+  playedHoles?: number[]
+): { [hole: number]: number } {
   const pops: { [hole: number]: number } = {};
+
+  if (playedHoles && playedHoles.length !== 18) {
+    const ranked = [...playedHoles].sort(
+      (a, b) => (holes[a]?.strokeIndex || a) - (holes[b]?.strokeIndex || b)
+    );
+    const count = ranked.length;
+    const handicap = Math.max(0, playerHandicap);
+
+    ranked.forEach((hole, i) => {
+      pops[hole] = Math.floor(handicap / count) + (i < handicap % count ? 1 : 0);
+    });
+
+    return pops;
+  }
   
   // Standard 18-hole handicap distribution
   for (let hole = 1; hole <= 18; hole++) {
@@ -136,14 +166,24 @@ export function strokeEngine(data: CoreRoundData, cfg: StrokeConfig): StrokeLaye
     let net = 0;
     holeByHole[playerId] = {};
 
-    for (let hole = 1; hole <= 18; hole++) {
+    for (const hole of cfg.holes ?? holesInPlay()) {
       // Get gross score for this hole
-      const score = data.strokes[playerId]?.[hole] || 0;
+      let score = data.strokes[playerId]?.[hole] || 0;
+      const pops = data.handicap?.pops[playerId]?.[hole] || 0;
+      const par = data.course.holes[hole]?.par;
+      let notPlayed = false;
+
+      if (!score) {
+        // Hole not played: skip it, or fill in net par / net double bogey (WHS Rule 3.3)
+        if (!cfg.holeNotPlayed || cfg.holeNotPlayed === 'none' || !par) continue;
+        score = par + pops + (cfg.holeNotPlayed === 'net-double-bogey' ? 2 : 0);
+        notPlayed = true;
+      }
+
       gross += score;
 
       // Calculate net score
-      const pops = cfg.useNet ? (data.handicap?.pops[playerId]?.[hole] || 0) : 0;
-      let netScore = score - pops;
+      let netScore = score - (cfg.useNet ? pops : 0);
 
       // Apply maximum score rule if specified
       if (cfg.maxScoreRule && data.course.holes[hole]) {
@@ -155,7 +195,8 @@ export function strokeEngine(data: CoreRoundData, cfg: StrokeConfig): StrokeLaye
       // Store hole breakdown
       holeByHole[playerId][hole] = {
         gross: score,
-        net: netScore
+        net: netScore,
+        ...(notPlayed ? { notPlayed } : {})
       };
     }
 
@@ -253,12 +294,14 @@ export function calculateRoundStats(
   let pars = 0;
   let bogeys = 0;
   let doubleBogeyOrWorse = 0;
+  let holesPlayed = 0;
 
   for (let hole = 1; hole <= 18; hole++) {
     const holeBreakdown = holeData[hole];
     const holePar = courseData.holes[hole]?.par || 4;
     
     if (holeBreakdown) {
+      holesPlayed++;
       grossTotal += holeBreakdown.gross;
       netTotal += holeBreakdown.net;
 
@@ -280,6 +323,6 @@ export function calculateRoundStats(
     pars,
     bogeys,
     doubleBogeyOrWorse,
-    averageScore: grossTotal / 18
+    averageScore: holesPlayed > 0 ? grossTotal / holesPlayed : 0
   };
 }
@@ -0,0 +1,83 @@
+import { describe, it, expect } from 'vitest';
+import { recommendStrokeIndexes, type HoleScoreSample, type StrokeIndexHole } from './strokeIndexEngine';
+import { parsePlayerScoreEvent } from './nostrEvents';
+import { GOLF_KINDS } from './types';
+
+describe('Stroke Index Engine', () => {
+  const holes: StrokeIndexHole[] = Array.from({ length: 18 }, (_, i) => ({
+    hole: i + 1,
+    par: 4,
+    currentIndex: i + 1
+  }));
+
+  // Hole n plays `overPar[n]` over par on average
+  const samplesFor = (overPar: number[], count: number = 20): HoleScoreSample[] =>
+    overPar.flatMap((over, i) =>
+      Array.from({ length: count }, (_, j) => ({ hole: i + 1, strokes: 4 + over + (j % 2 === 0 ? 0.5 : -0.5) }))
+    );
+
+  it('should give odd indexes to the harder nine', () => {
+    // Back nine is harder: hole 13 hardest, hole 5 hardest on the front
+    const overPar = [0.3, 0.2, 0.1, 0.4, 0.9, 0.5, 0.6, 0.2, 0.1, 0.8, 0.7, 0.6, 1.5, 0.9, 0.4, 0.5, 1.0, 0.3];
+    const report = recommendStrokeIndexes(holes, samplesFor(overPar));
+    const index = (hole: number) => report.holes.find(h => h.hole === hole)!.recommendedIndex;
+
+    expect(report.oddNine).toBe('back');
+    expect(index(13)).toBe(1);
+    expect(index(17)).toBe(3);
+    expect(index(5)).toBe(2);
+    expect(report.holes.filter(h => h.hole > 9).every(h => h.recommendedIndex % 2 === 1)).toBe(true);
+    expect(report.holes.map(h => h.recommendedIndex).sort((a, b) => a - b)).toEqual(
+      Array.from({ length: 18 }, (_, i) => i + 1)
+    );
+    expect(report.holes.find(h => h.hole === 13)!.difficultyRank).toBe(1);
+  });
+
+  it('should fall back to the current order when holes play the same', () => {
+    const report = recommendStrokeIndexes(holes, samplesFor(Array(18).fill(0.5)), { oddIndexesOn: 'front' });
+
+    expect(report.holes.filter(h => h.hole <= 9).map(h => h.recommendedIndex)).toEqual([1, 3, 5, 7, 9, 11, 13, 15, 17]);
+    // Only holes 1 and 18 keep their index once odd and even are split by nine
+    expect(report.changedHoles).toEqual(Array.from({ length: 16 }, (_, i) => i + 2));
+  });
+
+  it('should flag holes without enough samples', () => {
+    const samples = samplesFor(Array(18).fill(0.5), 20).filter(s => s.hole !== 7);
+    const report = recommendStrokeIndexes(holes, samples);
+
+    expect(report.insufficientHoles).toEqual([7]);
+    expect(report.totalSamples).toBe(17 * 20);
+  });
+
+  it('should rate holes by the high/low handicap spread', () => {
+    const samples: HoleScoreSample[] = holes.flatMap(h => [
+      // Hole 2 punishes high handicappers far more than anyone else
+      { hole: h.hole, strokes: 4, handicap: 5 },
+      { hole: h.hole, strokes: h.hole === 2 ? 8 : 5, handicap: 24 },
+      // Hole 1 is hard for everyone
+      ...(h.hole === 1 ? [{ hole: 1, strokes: 8, handicap: 4 }, { hole: 1, strokes: 8, handicap: 20 }] : [])
+    ]);
+
+    const spread = recommendStrokeIndexes(holes, samples, { method: 'handicap-spread', oddIndexesOn: 'front' });
+    const overPar = recommendStrokeIndexes(holes, samples, { oddIndexesOn: 'front' });
+
+    expect(spread.holes.find(h => h.hole === 2)!.recommendedIndex).toBe(1);
+    expect(overPar.holes.find(h => h.hole === 1)!.recommendedIndex).toBe(1);
+  });
+
+  it('should require 18 holes', () => {
+    expect(() => recommendStrokeIndexes(holes.slice(0, 9), [])).toThrow('requires 18 holes');
+  });
+
+  it('should parse both player score content shapes', () => {
+    const base = { kind: GOLF_KINDS.PLAYER_SCORE, pubkey: 'alice', created_at: 100, tags: [['d', 'round1']] };
+
+    expect(parsePlayerScoreEvent({ ...base, content: JSON.stringify({ scores: { 1: 4, 2: 0 } }) })?.scores).toEqual({ 1: 4 });
+
+    const record = parsePlayerScoreEvent({
+      ...base,
+      content: JSON.stringify({ holes: [{ hole: 1, strokes: 5, putts: 3 }] })
+    });
+    expect(record).toMatchObject({ roundId: 'round1', playerPubkey: 'alice', scores: { 1: 5 }, putts: { 1: 3 }, updatedAt: 100000 });
+  });
+});
@@ -0,0 +1,146 @@
+// Stroke index recommendations from historical hole scoring data
+
+export interface HoleScoreSample {
+  hole: number;
+  strokes: number;
+  handicap?: number; // player's handicap at the time, needed for the 'handicap-spread' method
+}
+
+export interface StrokeIndexHole {
+  hole: number;
+  par: number;
+  currentIndex?: number;
+}
+
+export type StrokeIndexMethod = 'average-over-par' | 'handicap-spread';
+
+export interface StrokeIndexConfig {
+  method?: StrokeIndexMethod; // default 'average-over-par'
+  minSamplesPerHole?: number; // default 20
+  // Handicap bands for the 'handicap-spread' method
+  lowHandicapMax?: number; // default 9
+  highHandicapMin?: number; // default 18
+  oddIndexesOn?: 'front' | 'back' | 'harder-nine'; // default 'harder-nine'
+}
+
+export interface StrokeIndexRecommendation {
+  hole: number;
+  par: number;
+  samples: number;
+  averageScore: number;
+  difficulty: number; // higher is harder relative to the other holes
+  difficultyRank: number; // 1 = hardest hole overall
+  currentIndex?: number;
+  recommendedIndex: number;
+}
+
+export interface StrokeIndexReport {
+  method: StrokeIndexMethod;
+  holes: StrokeIndexRecommendation[];
+  oddNine: 'front' | 'back';
+  totalSamples: number;
+  insufficientHoles: number[]; // holes with fewer samples than required
+  changedHoles: number[]; // holes whose recommended index differs from the current one
+}
+
+function average(values: number[]): number {
+  return values.length > 0 ? values.reduce((sum, v) => sum + v, 0) / values.length : 0;
+}
+
+/**
+ * How hard a hole plays.
+ * - average-over-par: mean strokes over par
+ * - handicap-spread: how many more strokes high handicappers take than low handicappers,
+ *   which is what stroke indexes exist to balance (the CONGU approach)
+ */
+function holeDifficulty(samples: HoleScoreSample[], par: number, config: StrokeIndexConfig): number {
+  if (config.method === 'handicap-spread') {
+    const lowMax = config.lowHandicapMax ?? 9;
+    const highMin = config.highHandicapMin ?? 18;
+    const low = samples.filter(s => s.handicap !== undefined && s.handicap <= lowMax).map(s => s.strokes);
+    const high = samples.filter(s => s.handicap !== undefined && s.handicap >= highMin).map(s => s.strokes);
+    if (low.length > 0 && high.length > 0) {
+      return average(high) - average(low);
+    }
+  }
+
+  return average(samples.map(s => s.strokes)) - par;
+}
+
+/**
+ * Recommend stroke indexes for an 18-hole course.
+ * Odd indexes go to one nine and even indexes to the other, so strokes are
+ * spread evenly across both nines; within each nine the hardest hole gets the lowest index.
+ */
+export function recommendStrokeIndexes(
+  holes: StrokeIndexHole[],
+  samples: HoleScoreSample[],
+  config: StrokeIndexConfig = {}
+): StrokeIndexReport {
+  if (holes.length !== 18) {
+    throw new Error('Stroke index allocation requires 18 holes');
+  }
+
+  const method = config.method ?? 'average-over-par';
+  const minSamples = config.minSamplesPerHole ?? 20;
+
+  const byHole = new Map<number, HoleScoreSample[]>();
+  for (const sample of samples) {
+    if (sample.strokes <= 0) continue;
+    const list = byHole.get(sample.hole) ?? [];
+    list.push(sample);
+    byHole.set(sample.hole, list);
+  }
+
+  const rated = holes.map(h => {
+    const holeSamples = byHole.get(h.hole) ?? [];
+    return {
+      hole: h.hole,
+      par: h.par,
+      currentIndex: h.currentIndex,
+      samples: holeSamples.length,
+      averageScore: Math.round(average(holeSamples.map(s => s.strokes)) * 100) / 100,
+      difficulty: Math.round(holeDifficulty(holeSamples, h.par, { ...config, method }) * 1000) / 1000,
+    };
+  });
+
+  // Hardest first; ties fall back to the current index so an unchanged table stays stable
+  const hardestFirst = (a: typeof rated[number], b: typeof rated[number]) =>
+    b.difficulty - a.difficulty || (a.currentIndex ?? a.hole) - (b.currentIndex ?? b.hole);
+
+  const overall = [...rated].sort(hardestFirst);
+  const rankOf = new Map(overall.map((h, i) => [h.hole, i + 1]));
+
+  const front = rated.filter(h => h.hole <= 9).sort(hardestFirst);
+  const back = rated.filter(h => h.hole > 9).sort(hardestFirst);
+
+  let oddNine: 'front' | 'back';
+  if (config.oddIndexesOn === 'front' || config.oddIndexesOn === 'back') {
+    oddNine = config.oddIndexesOn;
+  } else {
+    const sum = (nine: typeof rated) => nine.reduce((total, h) => total + h.difficulty, 0);
+    oddNine = sum(back) > sum(front) ? 'back' : 'front';
+  }
+
+  const recommended = new Map<number, number>();
+  const [oddHoles, evenHoles] = oddNine === 'front' ? [front, back] : [back, front];
+  oddHoles.forEach((h, i) => recommended.set(h.hole, i * 2 + 1));
+  evenHoles.forEach((h, i) => recommended.set(h.hole, i * 2 + 2));
+
+  const result: StrokeIndexRecommendation[] = rated.map(h => ({
+    ...h,
+    difficultyRank: rankOf.get(h.hole)!,
+    recommendedIndex: recommended.get(h.hole)!,
+  }));
+
+  return {
+    method,
+    holes: result,
+    oddNine,
+    totalSamples: result.reduce((sum, h) => sum + h.samples, 0),
+    insufficientHoles: result.filter(h => h.samples < minSamples).map(h => h.hole),
+    changedHoles: result
+      .filter(h => h.currentIndex !== undefined && h.currentIndex !== h.recommendedIndex)
+      .map(h => h.hole),
+  };
+}
@@ -0,0 +1,219 @@
+import { describe, it, expect } from 'vitest';
+import {
+  teamEventEngine,
+  scoreTeamMatch,
+  validateTeamEvent,
+  type TeamEvent,
+  type TeamEventConfig,
+  type TeamSession
+} from './teamEventEngine';
+import { type CoreRoundData } from './strokeEngine';
+import { createTeamEventEvent, parseTeamEventEvent } from './nostrEvents';
+
+describe('Team Event Engine', () => {
+  const course = {
+    holes: Object.fromEntries(
+      Array.from({ length: 18 }, (_, i) => [i + 1, { par: 4, strokeIndex: i + 1 }])
+    )
+  };
+
+  // Fill holes 1..n with the same score
+  const card = (score: number, holes: number = 18) =>
+    Object.fromEntries(Array.from({ length: holes }, (_, i) => [i + 1, score]));
+
+  const config: TeamEventConfig = {
+    name: 'Club Cup',
+    teams: {
+      'Team A': { name: 'Europe', players: ['a1', 'a2', 'a3', 'a4'] },
+      'Team B': { name: 'USA', players: ['b1', 'b2', 'b3', 'b4'] }
+    },
+    sessions: [
+      {
+        id: 'fourballs',
+        name: 'Saturday Fourballs',
+        format: 'fourballs',
+        matches: [
+          { id: 'fb1', teamA: ['a1', 'a2'], teamB: ['b1', 'b2'] },
+          { id: 'fb2', teamA: ['a3', 'a4'], teamB: ['b3', 'b4'] }
+        ]
+      },
+      {
+        id: 'singles',
+        name: 'Sunday Singles',
+        format: 'singles',
+        matches: [
+          { id: 's1', teamA: ['a1'], teamB: ['b1'] },
+          { id: 's2', teamA: ['a2'], teamB: ['b2'] }
+        ]
+      }
+    ],
+    holder: 'Team B'
+  };
+
+  const singles = config.sessions[1];
+
+  describe('validateTeamEvent', () => {
+    it('should accept a valid line-up', () => {
+      expect(validateTeamEvent(config)).toEqual([]);
+    });
+
+    it('should flag wrong side sizes and players in two matches', () => {
+      const session: TeamSession = {
+        id: 'bad',
+        name: 'Foursomes',
+        format: 'foursomes',
+        matches: [
+          { id: 'f1', teamA: ['a1'], teamB: ['b1', 'b2'] },
+          { id: 'f2', teamA: ['a1', 'a2'], teamB: ['b3', 'b4'] }
+        ]
+      };
+      const errors = validateTeamEvent({ ...config, sessions: [session] });
+
+      expect(errors).toContain('Foursomes: match f1 needs 2 player(s) per side for foursomes');
+      expect(errors).toContain('Foursomes: a1 is in more than one match');
+    });
+  });
+
+  describe('scoreTeamMatch', () => {
+    it('should finish a match early once it cannot be caught', () => {
+      // a1 wins the first 10 holes
+      const data: CoreRoundData = {
+        players: ['a1', 'b1'],
+        strokes: { a1: card(3, 10), b1: card(4, 10) },
+        course
+      };
+      const result = scoreTeamMatch(singles.matches[0], singles, data);
+
+      expect(result.state).toBe('complete');
+      expect(result.holesPlayed).toBe(10);
+      expect(result.winner).toBe('Team A');
+      expect(result.status).toBe('Team A wins 10 & 8');
+      expect(result.points).toEqual({ 'Team A': 1, 'Team B': 0 });
+    });
+
+    it('should report in-progress matches with projected points only', () => {
+      const data: CoreRoundData = {
+        players: ['a1', 'b1'],
+        strokes: { a1: { 1: 4, 2: 4, 3: 5 }, b1: { 1: 4, 2: 5, 3: 4, 4: 4 } },
+        course
+      };
+      const result = scoreTeamMatch(singles.matches[0], singles, data);
+
+      expect(result.state).toBe('in-progress');
+      expect(result.holesPlayed).toBe(3);
+      expect(result.status).toBe('All square thru 3');
+      expect(result.points).toEqual({ 'Team A': 0, 'Team B': 0 });
+      expect(result.projectedPoints).toEqual({ 'Team A': 0.5, 'Team B': 0.5 });
+    });
+
+    it('should flag dormie', () => {
+      const a1 = card(4);
+      const b1 = card(4);
+      a1[1] = 3;
+      a1[2] = 3;
+      delete a1[17];
+      delete a1[18];
+
+      const data: CoreRoundData = { players: ['a1', 'b1'], strokes: { a1, b1 }, course };
+      const result = scoreTeamMatch(singles.matches[0], singles, data);
+
+      expect(result.dormie).toBe(true);
+      expect(result.status).toBe('Team A 2 up (dormie) thru 16');
+    });
+
+    it('should use the better ball in fourballs', () => {
+      const session = config.sessions[0];
+      const data: CoreRoundData = {
+        players: ['a1', 'a2', 'b1', 'b2'],
+        strokes: { a1: { 1: 6 }, a2: { 1: 3 }, b1: { 1: 4 }, b2: { 1: 4 } },
+        course
+      };
+      const result = scoreTeamMatch(session.matches[0], session, data);
+
+      expect(result.leader).toBe('Team A');
+      expect(result.margin).toBe(1);
+    });
+
+    it('should apply handicap pops when playing net', () => {
+      const data: CoreRoundData = {
+        players: ['a1', 'b1'],
+        strokes: { a1: { 1: 4 }, b1: { 1: 5 } },
+        handicap: { pops: { a1: {}, b1: { 1: 1 } } },
+        course
+      };
+
+      expect(scoreTeamMatch(singles.matches[0], singles, data, false).leader).toBe('Team A');
+      expect(scoreTeamMatch(singles.matches[0], singles, data, true).leader).toBeNull();
+    });
+  });
+
+  describe('teamEventEngine', () => {
+    it('should aggregate session and event points', () => {
+      const roundData: Record<string, CoreRoundData> = {
+        fourballs: {
+          players: ['a1', 'a2', 'a3', 'a4', 'b1', 'b2', 'b3', 'b4'],
+          strokes: {
+            a1: card(4), a2: card(4), b1: card(5), b2: card(5), // Team A wins fb1
+            a3: card(4), a4: card(4), b3: card(4), b4: card(4)  // fb2 halved
+          },
+          course
+        },
+        singles: {
+          players: ['a1', 'b1'],
+          strokes: { a1: card(5, 4), b1: card(4, 4) }, // s1 in progress, s2 not started
+          course
+        }
+      };
+
+      const board = teamEventEngine(config, roundData);
+
+      expect(board.totalPoints).toBe(4);
+      expect(board.pointsToWin).toBe(2.5);
+      expect(board.sessions[0].points).toEqual({ 'Team A': 1.5, 'Team B': 0.5 });
+      expect(board.points).toEqual({ 'Team A': 1.5, 'Team B': 0.5 });
+      expect(board.projectedPoints).toEqual({ 'Team A': 1.5, 'Team B': 1.5 });
+      expect(board.sessions[1].matches[1].state).toBe('not-started');
+      expect(board.isDecided).toBe(false);
+      expect(board.winner).toBeNull();
+    });
+
+    it('should let the holder retain the cup on a tie', () => {
+      const roundData: Record<string, CoreRoundData> = {
+        fourballs: {
+          players: [],
+          strokes: {
+            a1: card(4), a2: card(4), b1: card(5), b2: card(5),
+            a3: card(4), a4: card(4), b3: card(3), b4: card(3)
+          },
+          course
+        },
+        singles: {
+          players: [],
+          strokes: { a1: card(4), b1: card(4), a2: card(4), b2: card(4) },
+          course
+        }
+      };
+
+      const board = teamEventEngine(config, roundData);
+
+      expect(board.points).toEqual({ 'Team A': 2, 'Team B': 2 });
+      expect(board.winner).toBeNull();
+      expect(board.retainedBy).toBe('Team B');
+      expect(board.isDecided).toBe(true);
+    });
+  });
+
+  it('should round-trip a team event through a Nostr event', () => {
+    const teamEvent: TeamEvent = {
+      eventId: 'club-cup',
+      organizer: 'org',
+      config,
+      sessionRounds: { fourballs: 'round-1', singles: 'round-2' },
+      createdAt: 1_780_000_000_000,
+    };
+    const event = { ...createTeamEventEvent(teamEvent), created_at: teamEvent.createdAt / 1000 };
+
+    expect(parseTeamEventEvent(event)).toEqual(teamEvent);
+    expect(event.tags.filter(t => t[0] === 'p').map(t => t[1])).toEqual(['a1', 'a2', 'a3', 'a4', 'b1', 'b2', 'b3', 'b4']);
+  });
+});
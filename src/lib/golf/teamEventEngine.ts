@@ -0,0 +1,309 @@
+import { type CoreRoundData } from './strokeEngine';
+
+// Ryder Cup style team events: sessions of foursomes, fourballs and singles matches
+
+export type TeamSide = 'Team A' | 'Team B';
+
+export type TeamSessionFormat = 'foursomes' | 'fourballs' | 'singles';
+
+export interface TeamEventTeam {
+  name: string;
+  players: string[];
+  captain?: string;
+}
+
+export interface TeamMatch {
+  id: string;
+  teamA: string[]; // 2 players for foursomes/fourballs, 1 for singles
+  teamB: string[];
+}
+
+export interface TeamSession {
+  id: string;
+  name: string;
+  format: TeamSessionFormat;
+  matches: TeamMatch[];
+}
+
+export interface TeamEventConfig {
+  name: string;
+  teams: Record<TeamSide, TeamEventTeam>;
+  sessions: TeamSession[];
+  holder?: TeamSide; // retains the cup on a tied result
+  useNet?: boolean;
+}
+
+/**
+ * A published team event: its line-ups and the round each session is
+ * played in
+ */
+export interface TeamEvent {
+  eventId: string;
+  organizer: string;
+  config: TeamEventConfig;
+  sessionRounds: Record<string, string>; // session id -> round id
+  createdAt: number;
+}
+
+export type TeamMatchState = 'not-started' | 'in-progress' | 'complete';
+
+export interface TeamMatchResult {
+  matchId: string;
+  sessionId: string;
+  state: TeamMatchState;
+  holesPlayed: number;
+  leader: TeamSide | null;
+  margin: number; // holes up
+  holesRemaining: number;
+  dormie: boolean;
+  winner: TeamSide | null; // null while in progress or when halved
+  points: Record<TeamSide, number>; // awarded points (only once complete)
+  projectedPoints: Record<TeamSide, number>; // points if the match finished as it stands
+  status: string;
+}
+
+export interface TeamSessionScoreboard {
+  sessionId: string;
+  name: string;
+  format: TeamSessionFormat;
+  matches: TeamMatchResult[];
+  points: Record<TeamSide, number>;
+  projectedPoints: Record<TeamSide, number>;
+}
+
+export interface TeamEventScoreboard {
+  name: string;
+  sessions: TeamSessionScoreboard[];
+  points: Record<TeamSide, number>;
+  projectedPoints: Record<TeamSide, number>;
+  totalPoints: number;
+  pointsToWin: number;
+  isDecided: boolean;
+  winner: TeamSide | null;
+  retainedBy: TeamSide | null; // holder keeps the cup on a tie
+}
+
+const HOLES = 18;
+
+function zeroPoints(): Record<TeamSide, number> {
+  return { 'Team A': 0, 'Team B': 0 };
+}
+
+function addPoints(target: Record<TeamSide, number>, source: Record<TeamSide, number>): void {
+  target['Team A'] += source['Team A'];
+  target['Team B'] += source['Team B'];
+}
+
+/**
+ * Validate session line-ups against the format and team rosters
+ */
+export function validateTeamEvent(config: TeamEventConfig): string[] {
+  const errors: string[] = [];
+  const rosterA = new Set(config.teams['Team A'].players);
+  const rosterB = new Set(config.teams['Team B'].players);
+
+  for (const player of rosterA) {
+    if (rosterB.has(player)) errors.push(`Player ${player} is on both teams`);
+  }
+
+  for (const session of config.sessions) {
+    const sideSize = session.format === 'singles' ? 1 : 2;
+    const playing = new Set<string>();
+
+    for (const match of session.matches) {
+      if (match.teamA.length !== sideSize || match.teamB.length !== sideSize) {
+        errors.push(`${session.name}: match ${match.id} needs ${sideSize} player(s) per side for ${session.format}`);
+      }
+
+      for (const player of match.teamA) {
+        if (!rosterA.has(player)) errors.push(`${session.name}: ${player} is not on ${config.teams['Team A'].name}`);
+      }
+      for (const player of match.teamB) {
+        if (!rosterB.has(player)) errors.push(`${session.name}: ${player} is not on ${config.teams['Team B'].name}`);
+      }
+
+      for (const player of [...match.teamA, ...match.teamB]) {
+        if (playing.has(player)) errors.push(`${session.name}: ${player} is in more than one match`);
+        playing.add(player);
+      }
+    }
+  }
+
+  return errors;
+}
+
+/**
+ * Score for one side on one hole, or null if the hole has not been completed.
+ * - Singles: the player's score
+ * - Fourballs: best ball of the pair (either score counts)
+ * - Foursomes: one ball per side, recorded under either partner
+ */
+function sideHoleScore(
+  side: string[],
+  format: TeamSessionFormat,
+  hole: number,
+  data: CoreRoundData,
+  useNet: boolean
+): number | null {
+  const scores: number[] = [];
+
+  for (const player of side) {
+    const gross = data.strokes[player]?.[hole];
+    if (!gross) continue;
+    const pops = useNet ? data.handicap?.pops[player]?.[hole] || 0 : 0;
+    scores.push(gross - pops);
+    if (format === 'foursomes') break; // alternate shot - only one ball is played
+  }
+
+  if (scores.length === 0) return null;
+
+  // Fourballs are live as soon as one partner has holed out
+  return Math.min(...scores);
+}
+
+function formatStatus(leader: TeamSide | null, margin: number, holesRemaining: number, state: TeamMatchState, dormie: boolean): string {
+  if (state === 'not-started') return 'Not started';
+
+  if (state === 'complete') {
+    if (!leader) return 'Halved';
+    if (holesRemaining === 0) return `${leader} wins ${margin} up`;
+    return `${leader} wins ${margin} & ${holesRemaining}`;
+  }
+
+  if (!leader) return `All square thru ${HOLES - holesRemaining}`;
+  return `${leader} ${margin} up${dormie ? ' (dormie)' : ''} thru ${HOLES - holesRemaining}`;
+}
+
+/**
+ * Score a single team match hole by hole. Holes are played in order, so the
+ * match state reflects the run of consecutive completed holes from the 1st.
+ */
+export function scoreTeamMatch(
+  match: TeamMatch,
+  session: TeamSession,
+  data: CoreRoundData,
+  useNet: boolean = false
+): TeamMatchResult {
+  let teamAUp = 0;
+  let holesPlayed = 0;
+
+  for (let hole = 1; hole <= HOLES; hole++) {
+    const scoreA = sideHoleScore(match.teamA, session.format, hole, data, useNet);
+    const scoreB = sideHoleScore(match.teamB, session.format, hole, data, useNet);
+    if (scoreA === null || scoreB === null) break;
+
+    if (scoreA < scoreB) teamAUp++;
+    else if (scoreB < scoreA) teamAUp--;
+    holesPlayed = hole;
+
+    // Match is over once a side is up by more holes than remain
+    if (Math.abs(teamAUp) > HOLES - hole) break;
+  }
+
+  const holesRemaining = HOLES - holesPlayed;
+  const margin = Math.abs(teamAUp);
+  const leader: TeamSide | null = teamAUp > 0 ? 'Team A' : teamAUp < 0 ? 'Team B' : null;
+
+  const state: TeamMatchState = holesPlayed === 0
+    ? 'not-started'
+    : margin > holesRemaining || holesRemaining === 0
+      ? 'complete'
+      : 'in-progress';
+
+  const dormie = state === 'in-progress' && margin > 0 && margin === holesRemaining;
+
+  const projectedPoints = zeroPoints();
+  if (state !== 'not-started') {
+    if (leader) {
+      projectedPoints[leader] = 1;
+    } else {
+      projectedPoints['Team A'] = 0.5;
+      projectedPoints['Team B'] = 0.5;
+    }
+  }
+
+  const points = state === 'complete' ? { ...projectedPoints } : zeroPoints();
+
+  return {
+    matchId: match.id,
+    sessionId: session.id,
+    state,
+    holesPlayed,
+    leader,
+    margin,
+    holesRemaining,
+    dormie,
+    winner: state === 'complete' ? leader : null,
+    points,
+    projectedPoints,
+    status: formatStatus(leader, margin, holesRemaining, state, dormie),
+  };
+}
+
+/**
+ * Build the live scoreboard for a team event.
+ * Each session is scored from its own round data (keyed by session id),
+ * typically assembled from the players' hole-score events.
+ */
+export function teamEventEngine(
+  config: TeamEventConfig,
+  roundData: Record<string, CoreRoundData>
+): TeamEventScoreboard {
+  const sessions: TeamSessionScoreboard[] = [];
+  const points = zeroPoints();
+  const projectedPoints = zeroPoints();
+  let totalPoints = 0;
+
+  for (const session of config.sessions) {
+    const data = roundData[session.id] ?? { players: [], strokes: {}, course: { holes: {} } };
+    const matches = session.matches.map(m => scoreTeamMatch(m, session, data, config.useNet ?? false));
+
+    const sessionPoints = zeroPoints();
+    const sessionProjected = zeroPoints();
+    for (const result of matches) {
+      addPoints(sessionPoints, result.points);
+      addPoints(sessionProjected, result.projectedPoints);
+    }
+
+    addPoints(points, sessionPoints);
+    addPoints(projectedPoints, sessionProjected);
+    totalPoints += session.matches.length;
+
+    sessions.push({
+      sessionId: session.id,
+      name: session.name,
+      format: session.format,
+      matches,
+      points: sessionPoints,
+      projectedPoints: sessionProjected,
+    });
+  }
+
+  // Outright win needs more than half the points; the holder retains with exactly half
+  const pointsToWin = totalPoints / 2 + 0.5;
+  let winner: TeamSide | null = null;
+  let retainedBy: TeamSide | null = null;
+
+  for (const side of ['Team A', 'Team B'] as TeamSide[]) {
+    if (points[side] >= pointsToWin) winner = side;
+  }
+
+  const allComplete = sessions.every(s => s.matches.every(m => m.state === 'complete'));
+  if (!winner && config.holder && points[config.holder] >= totalPoints / 2 && totalPoints > 0) {
+    retainedBy = config.holder;
+  }
+
+  const isDecided = winner !== null || retainedBy !== null || allComplete;
+
+  return {
+    name: config.name,
+    sessions,
+    points,
+    projectedPoints,
+    totalPoints,
+    pointsToWin,
+    isDecided,
+    winner,
+    retainedBy,
+  };
+}
@@ -0,0 +1,110 @@
+import { describe, it, expect } from 'vitest';
+import {
+  calculateRoundMetrics,
+  calculateTrends,
+  averageMetrics,
+  type TrendHole,
+  type TrendRound
+} from './trendsEngine';
+
+describe('Trends Engine', () => {
+  // 18 par-4 holes; `score` strokes on each, with putts, fairways and greens
+  const makeRound = (roundId: string, day: number, score: number, hole: Partial<TrendHole> = {}): TrendRound => ({
+    roundId,
+    date: day * 24 * 60 * 60 * 1000,
+    holes: Array.from({ length: 18 }, () => ({ par: 4, strokes: score, ...hole }))
+  });
+
+  describe('calculateRoundMetrics', () => {
+    it('should calculate scoring and percentage stats', () => {
+      const round: TrendRound = {
+        roundId: 'r1',
+        date: 0,
+        holes: [
+          { par: 4, strokes: 4, putts: 2, fairway: true, gir: true },
+          { par: 4, strokes: 4, putts: 1, fairway: false, gir: false }, // scramble
+          { par: 3, strokes: 4, putts: 2, gir: false }, // failed scramble
+          { par: 5, strokes: 5, putts: 2, fairway: true, gir: true, penalties: 0 }
+        ]
+      };
+
+      const metrics = calculateRoundMetrics(round);
+
+      expect(metrics.scoringAverage).toBe(76.5);
+      expect(metrics.toPar).toBe(4.5);
+      expect(metrics.fairwayPercentage).toBe(0.67);
+      expect(metrics.girPercentage).toBe(0.5);
+      expect(metrics.scramblingPercentage).toBe(0.5);
+      expect(metrics.puttsPerRound).toBe(31.5);
+      expect(metrics.penaltiesPerRound).toBe(0);
+    });
+
+    it('should estimate strokes gained against the benchmark', () => {
+      // Every fairway and green hit, 2 putts each
+      const metrics = calculateRoundMetrics(makeRound('r1', 0, 4, { putts: 2, fairway: true, gir: true }));
+      const sg = metrics.strokesGained!;
+
+      expect(sg.offTheTee).toBe(2.05); // (18 - 11.16) * 0.3
+      expect(sg.approach).toBe(2.97); // (18 - 12.06) * 0.5
+      expect(sg.shortGame).toBe(0);
+      expect(sg.putting).toBe(-6.3); // 29.7 - 36
+      expect(sg.total).toBe(-1.28);
+    });
+
+    it('should leave stats that were not recorded as null', () => {
+      const metrics = calculateRoundMetrics(makeRound('r1', 0, 5));
+
+      expect(metrics.girPercentage).toBeNull();
+      expect(metrics.puttsPerRound).toBeNull();
+      expect(metrics.strokesGained).toBeNull();
+    });
+
+    it('should score to par only over holes with a known par', () => {
+      const round = makeRound('r1', 0, 5);
+      round.holes = round.holes.map((h, i) => (i < 9 ? h : { ...h, par: undefined }));
+      expect(calculateRoundMetrics(round).toPar).toBe(18); // +1 a hole over nine holes
+      expect(calculateRoundMetrics({ ...round, holes: round.holes.map(h => ({ ...h, par: undefined })) }).toPar).toBeNull();
+    });
+  });
+
+  describe('calculateTrends', () => {
+    it('should compute rolling averages in date order', () => {
+      const rounds = [makeRound('r3', 3, 5), makeRound('r1', 1, 4), makeRound('r2', 2, 6)];
+      const trends = calculateTrends(rounds, { window: 2 });
+
+      expect(trends.points.map(p => p.roundId)).toEqual(['r1', 'r2', 'r3']);
+      expect(trends.points.map(p => p.rolling.scoringAverage)).toEqual([72, 90, 99]);
+    });
+
+    it('should compare the latest window with the previous one', () => {
+      const rounds = [
+        makeRound('r1', 1, 5, { putts: 2 }),
+        makeRound('r2', 2, 5, { putts: 2 }),
+        makeRound('r3', 3, 4, { putts: 2 }),
+        makeRound('r4', 4, 4, { putts: 1 })
+      ];
+      const { summary } = calculateTrends(rounds, { window: 2 });
+
+      expect(summary.roundsAnalysed).toBe(4);
+      expect(summary.current?.scoringAverage).toBe(72);
+      expect(summary.previous?.scoringAverage).toBe(90);
+      expect(summary.change.scoringAverage).toBe(-18);
+      expect(summary.change.puttsPerRound).toBe(-9);
+      expect(summary.change.girPercentage).toBeUndefined();
+    });
+
+    it('should not compare without a previous window', () => {
+      const { summary } = calculateTrends([makeRound('r1', 1, 4)], { window: 5 });
+
+      expect(summary.previous).toBeNull();
+      expect(summary.change).toEqual({});
+    });
+  });
+
+  it('should ignore missing stats when averaging', () => {
+    const withStats = calculateRoundMetrics(makeRound('r1', 0, 4, { putts: 2 }));
+    const without = calculateRoundMetrics(makeRound('r2', 1, 4));
+
+    expect(averageMetrics([withStats, without]).puttsPerRound).toBe(36);
+  });
+});
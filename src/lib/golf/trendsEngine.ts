@@ -0,0 +1,234 @@
+// Player performance trends: per-round metrics and rolling averages
+
+export interface TrendHole {
+  par?: number; // undefined when neither the card nor the course records it
+  strokes: number;
+  putts?: number;
+  fairway?: boolean; // undefined on par 3s or when not recorded
+  gir?: boolean;
+  penalties?: number;
+}
+
+export interface TrendRound {
+  roundId: string;
+  date: number;
+  holes: TrendHole[];
+}
+
+export interface RoundMetrics {
+  scoringAverage: number; // normalised to 18 holes
+  toPar: number | null; // over holes with a known par, normalised to 18 holes; null if none
+  girPercentage: number | null;
+  fairwayPercentage: number | null;
+  puttsPerRound: number | null; // normalised to 18 holes
+  scramblingPercentage: number | null; // par or better after missing the green
+  penaltiesPerRound: number | null;
+  strokesGained: StrokesGained | null;
+}
+
+export interface StrokesGained {
+  offTheTee: number;
+  approach: number;
+  shortGame: number;
+  putting: number;
+  total: number;
+}
+
+/**
+ * Benchmark golfer the strokes gained estimates compare against.
+ * Defaults approximate a scratch player over 18 holes.
+ */
+export interface TrendBenchmark {
+  fairwayPercentage: number;
+  girPercentage: number;
+  scramblingPercentage: number;
+  puttsPerHole: number;
+  // Approximate cost in strokes of each miss relative to the benchmark
+  fairwayMissCost: number;
+  girMissCost: number;
+  scrambleMissCost: number;
+}
+
+export const SCRATCH_BENCHMARK: TrendBenchmark = {
+  fairwayPercentage: 0.62,
+  girPercentage: 0.67,
+  scramblingPercentage: 0.55,
+  puttsPerHole: 1.65,
+  fairwayMissCost: 0.3,
+  girMissCost: 0.5,
+  scrambleMissCost: 1,
+};
+
+export interface TrendConfig {
+  window?: number; // rounds per rolling window (default 5)
+  benchmark?: TrendBenchmark;
+}
+
+export interface TrendPoint {
+  roundId: string;
+  date: number;
+  metrics: RoundMetrics;
+  rolling: RoundMetrics; // average over the window ending at this round
+}
+
+export interface TrendSummary {
+  window: number;
+  roundsAnalysed: number;
+  current: RoundMetrics | null; // most recent window
+  previous: RoundMetrics | null; // the window before that
+  change: Partial<Record<keyof Omit<RoundMetrics, 'strokesGained'>, number>>;
+}
+
+export interface PlayerTrends {
+  points: TrendPoint[];
+  summary: TrendSummary;
+}
+
+function round2(value: number): number {
+  return Math.round(value * 100) / 100;
+}
+
+function ratio(hits: number, total: number): number | null {
+  return total > 0 ? round2(hits / total) : null;
+}
+
+/**
+ * Metrics for a single round. Strokes gained is an estimate from round stats
+ * (fairways, greens, scrambling, putts) rather than shot-by-shot data.
+ */
+export function calculateRoundMetrics(round: TrendRound, benchmark: TrendBenchmark = SCRATCH_BENCHMARK): RoundMetrics {
+  const holes = round.holes.filter(h => h.strokes > 0);
+  const scale = holes.length > 0 ? 18 / holes.length : 0;
+
+  const strokes = holes.reduce((sum, h) => sum + h.strokes, 0);
+  const withPar = holes.filter((h): h is TrendHole & { par: number } => h.par !== undefined);
+  const toPar = withPar.reduce((sum, h) => sum + h.strokes - h.par, 0);
+
+  const fairwayHoles = holes.filter(h => h.fairway !== undefined && (h.par === undefined || h.par > 3));
+  const fairwaysHit = fairwayHoles.filter(h => h.fairway).length;
+
+  const girHoles = holes.filter(h => h.gir !== undefined);
+  const girHit = girHoles.filter(h => h.gir).length;
+
+  const missedGreens = girHoles.filter(h => !h.gir && h.par !== undefined);
+  const scrambles = missedGreens.filter(h => h.strokes <= h.par!).length;
+
+  const puttHoles = holes.filter(h => h.putts !== undefined);
+  const putts = puttHoles.reduce((sum, h) => sum + (h.putts ?? 0), 0);
+
+  const penaltyHoles = holes.filter(h => h.penalties !== undefined);
+  const penalties = penaltyHoles.reduce((sum, h) => sum + (h.penalties ?? 0), 0);
+
+  let strokesGained: StrokesGained | null = null;
+  if (fairwayHoles.length > 0 && girHoles.length > 0 && puttHoles.length > 0) {
+    const offTheTee = (fairwaysHit - fairwayHoles.length * benchmark.fairwayPercentage) * benchmark.fairwayMissCost;
+    const approach = (girHit - girHoles.length * benchmark.girPercentage) * benchmark.girMissCost;
+    const shortGame = (scrambles - missedGreens.length * benchmark.scramblingPercentage) * benchmark.scrambleMissCost;
+    const putting = puttHoles.length * benchmark.puttsPerHole - putts;
+
+    strokesGained = {
+      offTheTee: round2(offTheTee),
+      approach: round2(approach),
+      shortGame: round2(shortGame),
+      putting: round2(putting),
+      total: round2(offTheTee + approach + shortGame + putting),
+    };
+  }
+
+  return {
+    scoringAverage: round2(strokes * scale),
+    toPar: withPar.length > 0 ? round2(toPar * (18 / withPar.length)) : null,
+    girPercentage: ratio(girHit, girHoles.length),
+    fairwayPercentage: ratio(fairwaysHit, fairwayHoles.length),
+    puttsPerRound: puttHoles.length > 0 ? round2(putts * (18 / puttHoles.length)) : null,
+    scramblingPercentage: ratio(scrambles, missedGreens.length),
+    penaltiesPerRound: penaltyHoles.length > 0 ? round2(penalties * (18 / penaltyHoles.length)) : null,
+    strokesGained,
+  };
+}
+
+function averageOf(values: (number | null)[]): number | null {
+  const present = values.filter((v): v is number => v !== null);
+  return present.length > 0 ? round2(present.reduce((sum, v) => sum + v, 0) / present.length) : null;
+}
+
+/**
+ * Average a set of round metrics; missing stats are ignored rather than counted as zero
+ */
+export function averageMetrics(metrics: RoundMetrics[]): RoundMetrics {
+  const sg = metrics.map(m => m.strokesGained).filter((s): s is StrokesGained => s !== null);
+
+  return {
+    scoringAverage: averageOf(metrics.map(m => m.scoringAverage)) ?? 0,
+    toPar: averageOf(metrics.map(m => m.toPar)),
+    girPercentage: averageOf(metrics.map(m => m.girPercentage)),
+    fairwayPercentage: averageOf(metrics.map(m => m.fairwayPercentage)),
+    puttsPerRound: averageOf(metrics.map(m => m.puttsPerRound)),
+    scramblingPercentage: averageOf(metrics.map(m => m.scramblingPercentage)),
+    penaltiesPerRound: averageOf(metrics.map(m => m.penaltiesPerRound)),
+    strokesGained: sg.length > 0
+      ? {
+          offTheTee: averageOf(sg.map(s => s.offTheTee))!,
+          approach: averageOf(sg.map(s => s.approach))!,
+          shortGame: averageOf(sg.map(s => s.shortGame))!,
+          putting: averageOf(sg.map(s => s.putting))!,
+          total: averageOf(sg.map(s => s.total))!,
+        }
+      : null,
+  };
+}
+
+const CHANGE_KEYS = [
+  'scoringAverage',
+  'toPar',
+  'girPercentage',
+  'fairwayPercentage',
+  'puttsPerRound',
+  'scramblingPercentage',
+  'penaltiesPerRound',
+] as const;
+
+/**
+ * Rolling trends over a player's rounds (oldest to newest)
+ */
+export function calculateTrends(rounds: TrendRound[], config: TrendConfig = {}): PlayerTrends {
+  const window = Math.max(1, config.window ?? 5);
+  const benchmark = config.benchmark ?? SCRATCH_BENCHMARK;
+
+  const chronological = [...rounds]
+    .filter(r => r.holes.some(h => h.strokes > 0))
+    .sort((a, b) => a.date - b.date);
+  const metrics = chronological.map(r => calculateRoundMetrics(r, benchmark));
+
+  const points: TrendPoint[] = chronological.map((round, i) => ({
+    roundId: round.roundId,
+    date: round.date,
+    metrics: metrics[i],
+    rolling: averageMetrics(metrics.slice(Math.max(0, i - window + 1), i + 1)),
+  }));
+
+  const currentSlice = metrics.slice(-window);
+  const previousSlice = metrics.slice(-window * 2, -window);
+  const current = currentSlice.length > 0 ? averageMetrics(currentSlice) : null;
+  const previous = previousSlice.length > 0 ? averageMetrics(previousSlice) : null;
+
+  const change: TrendSummary['change'] = {};
+  if (current && previous) {
+    for (const key of CHANGE_KEYS) {
+      const now = current[key];
+      const before = previous[key];
+      if (now !== null && before !== null) change[key] = round2(now - before);
+    }
+  }
+
+  return {
+    points,
+    summary: {
+      window,
+      roundsAnalysed: chronological.length,
+      current,
+      previous,
+      change,
+    },
+  };
+}
@@ -1,3 +1,5 @@
+import type { SideCompetition } from './sideCompetitionEngine';
+
 // Golf Game Modes
 export enum GameMode {
   STROKE_PLAY = 'stroke-play',
@@ -28,6 +30,10 @@ export const GOLF_KINDS = {
   PLAYER_SCORE: 36903,    // Per-player scores for a round (addressable, updates in place)
   GOLF_PROFILE: 36904,    // User's handicap, preferences, visibility
   TOURNAMENT: 36905,      // Multi-round competition container
+  DRAW: 36906,            // Tournament draw (tee times and pairings)
+  HANDICAP_PENALTY: 36907, // Committee-applied penalty score
+  GOLF_BAG: 36908,        // Player's clubs and registered carry distances
+  SHOT: 36909,            // Recorded shot (club, carry, positions)
   
   // Legacy kinds (for backward compatibility reading only)
   /** @deprecated Use PLAYER_SCORE instead */
@@ -43,6 +49,33 @@ export const GOLF_KINDS = {
   
   // Optional features
   BADGE_AWARD: 36910,     // Badge achievement awards
+  PRACTICE_SESSION: 36911, // Practice/range session (clubs, balls, launch monitor data)
+  SCORE_ATTESTATION: 36912, // Marker's countersignature (or dispute) of a player's card
+  DISPUTE: 36913,         // Contested score or match result, with evidence
+  RULING: 36914,          // Committee ruling on a dispute or held round
+  SPONSOR_SLOT: 36915,    // Club sponsor banner scheduled on TV displays and scorecards
+  ACE: 36916,             // Hole-in-one claim, naming its witnesses
+  ACE_WITNESS: 36917,     // Witness signature confirming a hole in one
+  SCORING_DELEGATION: 36918, // Player lets a scoring terminal post their scores
+  COURSE_NOTICE: 36919,   // Greenkeeping notice: aeration, closures, maintenance
+  GREEN_REPORT: 36920,    // Daily green speed (stimp) and firmness
+  RENTAL_INVENTORY: 36921, // Club's buggies, trolleys and rental sets for hire
+  RENTAL_RESERVATION: 36922, // Member's reservation of rental equipment
+  LESSON_SLOT: 36923,     // Teaching pro's open lesson slot
+  LESSON_BOOKING: 36924,  // Member's booking of a lesson slot
+  CONDITION_REPORT: 36925, // Player-reported course condition on a hole, with photos
+  COURSE_ALERT: 36926,    // Urgent club alert to everyone on the course (lightning, medical)
+  RATE_CARD: 36927,       // Club's green fee and pricing rules
+  VOUCHER: 36928,         // Gift voucher issued by the club
+  VOUCHER_REDEMPTION: 36929, // Pro shop redemption against a gift voucher
+  PRIVACY: 36930,         // Member's consents and data retention periods
+  SCORER_DEVICE: 36931,   // Organizer's credential letting a tablet score a draw group
+  VIRTUAL_TOURNAMENT: 36932, // Tournament played on any course during a window
+  VIRTUAL_ENTRY: 36933,   // Player's card submitted to a virtual tournament
+  FANTASY_LEAGUE: 36934,  // Fantasy league for the pro majors, with results
+  FANTASY_PICKS: 36935,   // Member's pro picks for one major in a fantasy league
+  PREDICTION: 36936,      // Player's prediction for a round's prediction game
+  TEAM_EVENT: 36937,      // Ryder Cup style team event: line-ups and the round each session is played in
 } as const;
 
 // Player in a round
@@ -118,7 +151,9 @@ export interface RoundMetadata {
   selectedSection?: string; // Selected 9-hole section index
   scorecardImages?: string[]; // Blossom URLs of uploaded scorecard photos
   origin?: 'real' | 'simulator'; // Where the round was played
+  teeTime?: number; // Scheduled start (ms), used for pace of play
   visibility?: 'public' | 'social' | 'private'; // Discoverability
+  sideCompetitions?: SideCompetition[]; // Closest to the pin / longest drive holes
 }
 
 // Badge definition
@@ -134,7 +169,7 @@ export interface BadgeDefinition {
 
 // Badge criteria
 export interface BadgeCriteria {
-  type: 'hole-score' | 'round-score' | 'streak' | 'participation';
+  type: 'hole-score' | 'round-score' | 'streak' | 'participation' | 'prediction';
   conditions: Record<string, unknown>;
 }
 
@@ -0,0 +1,118 @@
+import { describe, it, expect } from 'vitest';
+import {
+  createVirtualEntryEvent,
+  createVirtualTournamentEvent,
+  parseVirtualEntryEvent,
+  parseVirtualTournamentEvent,
+} from './nostrEvents';
+import {
+  entryProblem,
+  netDifferential,
+  shouldCloseVirtual,
+  virtualPhase,
+  virtualStandings,
+  withEvidence,
+  type VirtualEntry,
+  type VirtualTournament,
+} from './virtualTournamentEngine';
+
+const day = 24 * 60 * 60 * 1000;
+const opens = 1_780_000_000_000;
+
+const tournament: VirtualTournament = {
+  tournamentId: 'spring-open',
+  organizer: 'org',
+  name: 'Spring Open',
+  opens,
+  closes: opens + 7 * day,
+  deadline: opens + 8 * day,
+  closed: false,
+  results: [],
+  createdAt: opens - day,
+};
+
+const entry = (player: string, overrides: Partial<VirtualEntry> = {}): VirtualEntry => ({
+  tournamentId: 'spring-open',
+  player,
+  roundId: `${player}-r1`,
+  course: 'Links',
+  courseRating: 72,
+  slope: 113,
+  handicapIndex: 10,
+  gross: 85,
+  playedAt: opens + day,
+  submittedAt: opens + day,
+  ...overrides,
+});
+
+describe('Virtual Tournament Engine', () => {
+  it('should move through the tournament phases and close at the deadline', () => {
+    expect(virtualPhase(tournament, opens - 1)).toBe('upcoming');
+    expect(virtualPhase(tournament, opens + day)).toBe('playing');
+    expect(virtualPhase(tournament, opens + 7.5 * day)).toBe('submitting');
+    expect(virtualPhase(tournament, opens + 8 * day)).toBe('closed');
+    expect(virtualPhase({ ...tournament, closed: true }, opens + day)).toBe('closed');
+
+    expect(shouldCloseVirtual(tournament, opens + 8 * day - 1)).toBe(false);
+    expect(shouldCloseVirtual(tournament, opens + 8 * day)).toBe(true);
+    expect(shouldCloseVirtual({ ...tournament, closed: true }, opens + 9 * day)).toBe(false);
+  });
+
+  it('should compare cards from different courses by net differential', () => {
+    // 85 at 72/113 less 10 = 3; 90 on a harder course (74/130) less 12 = 1.9
+    expect(netDifferential(entry('a'))).toBe(3);
+    expect(netDifferential(entry('b', { gross: 90, courseRating: 74, slope: 130, handicapIndex: 12 }))).toBe(1.9);
+
+    const standings = virtualStandings(tournament, [
+      entry('a'),
+      entry('b', { gross: 90, courseRating: 74, slope: 130, handicapIndex: 12 }),
+    ]);
+
+    expect(standings.map(s => [s.player, s.position, s.differential, s.net])).toEqual([
+      ['b', 1, 13.9, 1.9],
+      ['a', 2, 13, 3],
+    ]);
+  });
+
+  it('should refuse rounds outside the window and cards missing a score or rating', () => {
+    expect(entryProblem(tournament, entry('a', { playedAt: opens - 1 }))).toBe('Round was played outside the tournament window');
+    expect(entryProblem(tournament, entry('a', { slope: 0 }))).toBe('Card is missing a score, course rating or slope');
+    expect(entryProblem(tournament, entry('a'))).toBeNull();
+  });
+
+  it('should take the score, date, ratings and index from the cards and course, not the entry', () => {
+    const claimed = entry('a', { gross: 60, courseRating: 80, slope: 155, handicapIndex: 30 });
+    const evidence = { course: 'Links', gross: 85, playedAt: opens + 2 * day, courseRating: 72, slope: 113, handicapIndex: 10 };
+    expect(withEvidence(claimed, evidence)).toEqual(entry('a', { playedAt: opens + 2 * day }));
+    expect(withEvidence(claimed, { ...evidence, handicapIndex: undefined }).handicapIndex).toBe(0);
+
+    expect(entryProblem(tournament, withEvidence(claimed, undefined))).toBe('Card is missing a score, course rating or slope');
+    expect(entryProblem(tournament, withEvidence(claimed, { ...evidence, slope: undefined }))).toBe('Card is missing a score, course rating or slope');
+  });
+
+  it('should count each player\'s latest valid card and share tied positions', () => {
+    const standings = virtualStandings(tournament, [
+      entry('a', { gross: 80, submittedAt: opens + day }),
+      entry('a', { gross: 84, roundId: 'a-r2', submittedAt: opens + 2 * day }),
+      entry('a', { gross: 70, roundId: 'a-outside', playedAt: opens + 8 * day, submittedAt: opens + 3 * day }),
+      entry('b', { gross: 84 }),
+      entry('c', { gross: 88 }),
+    ]);
+
+    expect(standings.map(s => [s.player, s.roundId, s.position])).toEqual([
+      ['a', 'a-r2', 1],
+      ['b', 'b-r1', 1],
+      ['c', 'c-r1', 3],
+    ]);
+  });
+
+  it('should round-trip a closed tournament and an entry through Nostr events', () => {
+    const closed = { ...tournament, closed: true, results: virtualStandings(tournament, [entry('a')]) };
+    const event = { ...createVirtualTournamentEvent(closed), created_at: closed.createdAt / 1000 };
+    expect(parseVirtualTournamentEvent(event)).toEqual(closed);
+
+    const { submittedAt: _submittedAt, ...card } = entry('a');
+    const submitted = { ...createVirtualEntryEvent(card, 'org'), created_at: (opens + day) / 1000 };
+    expect(parseVirtualEntryEvent(submitted)).toEqual(entry('a'));
+  });
+});
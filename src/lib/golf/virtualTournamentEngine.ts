@@ -0,0 +1,131 @@
+// Virtual tournaments: players play any course during a window and submit
+// one card each. Cards from different courses are compared by net
+// differential, the score differential less the player's handicap index.
+
+import { calculateDifferential } from './handicapCalculator';
+
+export interface VirtualTournament {
+  tournamentId: string;
+  organizer: string;
+  name: string;
+  opens: number; // ms, first day rounds may be played
+  closes: number; // ms, last moment rounds may be played
+  deadline: number; // ms, cards submitted after this don't count
+  closed: boolean; // the organizer has published final results
+  results: VirtualStanding[]; // final results, once closed
+  createdAt: number;
+}
+
+export interface VirtualEntry {
+  tournamentId: string;
+  player: string;
+  roundId: string;
+  course: string;
+  courseRating: number;
+  slope: number;
+  handicapIndex: number; // the player's index going into the round
+  gross: number;
+  playedAt: number; // ms
+  submittedAt: number; // ms
+}
+
+// What a player's card and the course say about the round an entry names
+export interface EntryEvidence {
+  course: string;
+  gross: number; // from the player's PLAYER_SCORE card
+  playedAt: number; // ms, when the card was published
+  courseRating?: number; // the played tee's, from the course event
+  slope?: number;
+  handicapIndex?: number; // from the player's cards before the round; none counts as scratch
+}
+
+export interface VirtualStanding {
+  player: string;
+  roundId: string;
+  course: string;
+  gross: number;
+  differential: number;
+  net: number;
+  position: number; // ties share a position
+}
+
+export type VirtualPhase = 'upcoming' | 'playing' | 'submitting' | 'closed';
+
+const round1 = (n: number) => Math.round(n * 10) / 10;
+
+export function virtualPhase(tournament: VirtualTournament, now: number): VirtualPhase {
+  if (tournament.closed || now >= tournament.deadline) return 'closed';
+  if (now < tournament.opens) return 'upcoming';
+  return now <= tournament.closes ? 'playing' : 'submitting';
+}
+
+/**
+ * An entry with its score, date and ratings taken from the evidence instead
+ * of the entry's own claims. Without a card, or a rated tee, it can't count.
+ */
+export function withEvidence(entry: VirtualEntry, evidence: EntryEvidence | undefined): VirtualEntry {
+  return {
+    ...entry,
+    course: evidence?.course ?? entry.course,
+    gross: evidence?.gross ?? 0,
+    playedAt: evidence?.playedAt ?? entry.playedAt,
+    courseRating: evidence?.courseRating ?? 0,
+    slope: evidence?.slope ?? 0,
+    handicapIndex: evidence?.handicapIndex ?? 0,
+  };
+}
+
+/**
+ * Why an entry doesn't count, or null if it does. The submission deadline
+ * isn't judged here: an entry's own timestamp can be backdated, so the
+ * deadline is the organizer closing the tournament with the entries they
+ * had by then.
+ */
+export function entryProblem(tournament: VirtualTournament, entry: VirtualEntry): string | null {
+  if (entry.tournamentId !== tournament.tournamentId) return 'Card is for another tournament';
+  if (entry.playedAt < tournament.opens || entry.playedAt > tournament.closes) return 'Round was played outside the tournament window';
+  if (!(entry.gross > 0) || !(entry.courseRating > 0) || !(entry.slope > 0)) return 'Card is missing a score, course rating or slope';
+  return null;
+}
+
+export function netDifferential(entry: Pick<VirtualEntry, 'gross' | 'courseRating' | 'slope' | 'handicapIndex'>): number {
+  return round1(calculateDifferential(entry.gross, entry.courseRating, entry.slope) - entry.handicapIndex);
+}
+
+/**
+ * Standings from the entries that count: each player's latest entry, lowest
+ * net differential first.
+ */
+export function virtualStandings(tournament: VirtualTournament, entries: VirtualEntry[]): VirtualStanding[] {
+  const latest = new Map<string, VirtualEntry>();
+  for (const entry of entries) {
+    if (entryProblem(tournament, entry)) continue;
+    const existing = latest.get(entry.player);
+    if (!existing || entry.submittedAt > existing.submittedAt) latest.set(entry.player, entry);
+  }
+
+  const sorted = [...latest.values()]
+    .map(entry => ({
+      player: entry.player,
+      roundId: entry.roundId,
+      course: entry.course,
+      gross: entry.gross,
+      differential: round1(calculateDifferential(entry.gross, entry.courseRating, entry.slope)),
+      net: netDifferential(entry),
+    }))
+    .sort((a, b) => a.net - b.net);
+
+  const standings: VirtualStanding[] = [];
+  sorted.forEach((standing, i) => {
+    const prev = standings[i - 1];
+    standings.push({ ...standing, position: prev && prev.net === standing.net ? prev.position : i + 1 });
+  });
+  return standings;
+}
+
+/**
+ * Whether the organizer's scheduler should close the tournament now
+ */
+export function shouldCloseVirtual(tournament: VirtualTournament, now: number): boolean {
+  return !tournament.closed && now >= tournament.deadline;
+}
@@ -0,0 +1,91 @@
+import { describe, it, expect } from 'vitest';
+import {
+  expiringVouchers,
+  generateVoucherCode,
+  hashVoucherCode,
+  redemptionProblem,
+  voucherBalance,
+  voucherStatus,
+  type Voucher,
+  type VoucherRedemption,
+} from './voucherEngine';
+import {
+  createVoucherEvent,
+  createVoucherRedemptionEvent,
+  parseVoucherEvent,
+  parseVoucherRedemptionEvent,
+} from './nostrEvents';
+
+const DAY = 24 * 60 * 60 * 1000;
+const now = 1_780_000_000_000;
+
+function voucher(overrides: Partial<Voucher> = {}): Voucher {
+  return {
+    voucherId: 'v1',
+    courseId: 'oak-hills',
+    courseAuthor: 'club',
+    kind: 'value',
+    amount: 100,
+    currency: 'GBP',
+    recipient: 'Sam',
+    message: 'Happy birthday',
+    expiresAt: now + 300 * DAY,
+    codeHash: 'abc',
+    voided: false,
+    issuedAt: now - 10 * DAY,
+    createdAt: now - 10 * DAY,
+    ...overrides,
+  };
+}
+
+const redemption = (amount: number, overrides: Partial<VoucherRedemption> = {}): VoucherRedemption => ({
+  redemptionId: `r${amount}`,
+  voucherId: 'v1',
+  courseId: 'oak-hills',
+  courseAuthor: 'club',
+  amount,
+  note: '',
+  createdAt: now - DAY,
+  ...overrides,
+});
+
+describe('Voucher Engine', () => {
+  it('should hash codes the same however they are typed', async () => {
+    const code = generateVoucherCode();
+    expect(code).toMatch(/^[A-Z2-9]{4}-[A-Z2-9]{4}-[A-Z2-9]{4}$/);
+    expect(await hashVoucherCode('k7qm 2xrp-wd')).toBe(await hashVoucherCode('K7QM-2XRP-WD'));
+    expect(await hashVoucherCode('K7QM-2XRP-WD')).toHaveLength(64);
+  });
+
+  it('should track the balance from the club\'s redemptions', () => {
+    const redemptions = [redemption(30), redemption(20), redemption(50, { courseAuthor: 'someone-else' })];
+    expect(voucherBalance(voucher(), redemptions)).toBe(50);
+    expect(voucherStatus(voucher(), [...redemptions, redemption(50, { redemptionId: 'last' })], now)).toBe('used');
+  });
+
+  it('should refuse redemptions that the voucher does not cover', () => {
+    expect(redemptionProblem(voucher(), [redemption(80)], 20, now)).toBeNull();
+    expect(redemptionProblem(voucher(), [redemption(80)], 25, now)).toBe('That is more than the remaining balance');
+    expect(redemptionProblem(voucher({ kind: 'rounds', amount: 2 }), [], 1.5, now)).toBe('Redeem whole rounds');
+    expect(redemptionProblem(voucher({ voided: true }), [], 10, now)).toBe('This voucher has been cancelled');
+    expect(redemptionProblem(voucher({ expiresAt: now - DAY }), [], 10, now)).toMatch(/^This voucher expired/);
+  });
+
+  it('should list active vouchers about to expire', () => {
+    const soon = voucher({ voucherId: 'soon', expiresAt: now + 10 * DAY });
+    const sooner = voucher({ voucherId: 'sooner', expiresAt: now + 2 * DAY });
+    const spent = voucher({ voucherId: 'v1', expiresAt: now + 5 * DAY });
+    const ids = expiringVouchers([voucher({ voucherId: 'later' }), soon, sooner, spent], [redemption(100)], now).map(v => v.voucherId);
+    expect(ids).toEqual(['sooner', 'soon']);
+  });
+
+  it('should round-trip vouchers and redemptions through Nostr events', () => {
+    const original = voucher();
+    const event = { ...createVoucherEvent(original), created_at: original.createdAt / 1000 };
+    expect(parseVoucherEvent(event)).toEqual(original);
+
+    const used = redemption(30);
+    const redemptionEvent = { ...createVoucherRedemptionEvent(used), created_at: used.createdAt / 1000 };
+    expect(parseVoucherRedemptionEvent(redemptionEvent)).toEqual(used);
+  });
+});
@@ -0,0 +1,103 @@
+// Gift vouchers: issued by the club for a value or a number of rounds,
+// redeemed at the pro shop against a secret code
+
+export type VoucherKind = 'value' | 'rounds';
+
+export interface Voucher {
+  voucherId: string;
+  courseId: string;
+  courseAuthor: string;
+  kind: VoucherKind;
+  amount: number; // value in `currency`, or number of rounds
+  currency: string;
+  recipient: string;
+  message: string;
+  expiresAt: number; // ms
+  codeHash: string; // SHA-256 of the code; the code itself is only on the voucher
+  voided: boolean;
+  issuedAt: number; // ms; kept when the voucher is republished to cancel it
+  createdAt: number; // ms
+}
+
+export interface VoucherRedemption {
+  redemptionId: string;
+  voucherId: string;
+  courseId: string;
+  courseAuthor: string;
+  amount: number;
+  note: string;
+  createdAt: number; // ms
+}
+
+export type VoucherStatus = 'active' | 'used' | 'expired' | 'void';
+
+export const VOUCHER_VALIDITY_MONTHS = 12;
+export const VOUCHER_EXPIRY_WARNING_DAYS = 30;
+
+// No 0/O or 1/I, so codes can be read out over the counter
+const CODE_ALPHABET = 'ABCDEFGHJKLMNPQRSTUVWXYZ23456789';
+
+/** A new random voucher code, e.g. "K7QM-2XRP-WD4N" */
+export function generateVoucherCode(length = 12): string {
+  const bytes = crypto.getRandomValues(new Uint8Array(length));
+  const chars = [...bytes].map(b => CODE_ALPHABET[b % CODE_ALPHABET.length]).join('');
+  return chars.match(/.{1,4}/g)!.join('-');
+}
+
+/** SHA-256 of a code, ignoring case, spaces and dashes */
+export async function hashVoucherCode(code: string): Promise<string> {
+  const normalized = code.toUpperCase().replace(/[\s-]/g, '');
+  const digest = await crypto.subtle.digest('SHA-256', new TextEncoder().encode(normalized));
+  return [...new Uint8Array(digest)].map(b => b.toString(16).padStart(2, '0')).join('');
+}
+
+/** Redemptions against a voucher; only the club's count */
+export function voucherRedemptions(voucher: Voucher, redemptions: VoucherRedemption[]): VoucherRedemption[] {
+  return redemptions
+    .filter(r => r.voucherId === voucher.voucherId && r.courseAuthor === voucher.courseAuthor)
+    .sort((a, b) => a.createdAt - b.createdAt);
+}
+
+/** What's left on a voucher */
+export function voucherBalance(voucher: Voucher, redemptions: VoucherRedemption[]): number {
+  const used = voucherRedemptions(voucher, redemptions).reduce((sum, r) => sum + r.amount, 0);
+  return Math.max(0, Math.round((voucher.amount - used) * 100) / 100);
+}
+
+export function voucherStatus(voucher: Voucher, redemptions: VoucherRedemption[], now: number): VoucherStatus {
+  if (voucher.voided) return 'void';
+  if (voucherBalance(voucher, redemptions) <= 0) return 'used';
+  if (voucher.expiresAt <= now) return 'expired';
+  return 'active';
+}
+
+/** Why a redemption can't go ahead, or null if it can */
+export function redemptionProblem(voucher: Voucher, redemptions: VoucherRedemption[], amount: number, now: number): string | null {
+  const status = voucherStatus(voucher, redemptions, now);
+  if (status === 'void') return 'This voucher has been cancelled';
+  if (status === 'used') return 'This voucher has been used';
+  if (status === 'expired') return `This voucher expired on ${new Date(voucher.expiresAt).toLocaleDateString()}`;
+  if (!(amount > 0)) return 'Enter an amount to redeem';
+  if (voucher.kind === 'rounds' && !Number.isInteger(amount)) return 'Redeem whole rounds';
+  if (amount > voucherBalance(voucher, redemptions)) return 'That is more than the remaining balance';
+  return null;
+}
+
+/** Active vouchers that run out within the warning period, soonest first */
+export function expiringVouchers(vouchers: Voucher[], redemptions: VoucherRedemption[], now: number): Voucher[] {
+  const horizon = now + VOUCHER_EXPIRY_WARNING_DAYS * 24 * 60 * 60 * 1000;
+  return vouchers
+    .filter(v => voucherStatus(v, redemptions, now) === 'active' && v.expiresAt <= horizon)
+    .sort((a, b) => a.expiresAt - b.expiresAt);
+}
+
+/** Amount in the voucher's terms, e.g. "50.00 GBP" or "2 rounds" */
+export function formatVoucherAmount(voucher: Pick<Voucher, 'kind' | 'currency'>, amount: number): string {
+  if (voucher.kind === 'rounds') return `${amount} ${amount === 1 ? 'round' : 'rounds'}`;
+  return `${amount.toFixed(2)} ${voucher.currency}`;
+}
+
+/** Link printed as the voucher's QR code. The code stays in the fragment, so it never reaches a server. */
+export function voucherUrl(origin: string, voucher: Pick<Voucher, 'courseId' | 'voucherId'>, code: string): string {
+  return `${origin}/courses/${encodeURIComponent(voucher.courseId)}/vouchers/${voucher.voucherId}#${code}`;
+}
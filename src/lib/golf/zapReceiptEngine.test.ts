@@ -0,0 +1,90 @@
+import { describe, it, expect } from 'vitest';
+import type { NostrEvent } from '@nostrify/nostrify';
+import { bolt11AmountMsats, bolt11AmountSats, bolt11DescriptionHash, lnurlPayUrl, verifyZapReceipt } from './zapReceiptEngine';
+
+const CHARSET = 'qpzry9x8gf2tvdw0s3jn54khce6mua7l';
+
+// An invoice with just a description hash field; the checksum and signature are filler
+function invoice(prefix: string, descriptionHash: string): string {
+  const bytes = descriptionHash.match(/../g)!.map(b => parseInt(b, 16));
+  const words: number[] = [];
+  let acc = 0;
+  let bits = 0;
+  for (const byte of bytes) {
+    acc = (acc << 8) | byte;
+    bits += 8;
+    while (bits >= 5) {
+      bits -= 5;
+      words.push((acc >> bits) & 31);
+    }
+    acc &= (1 << bits) - 1;
+  }
+  if (bits > 0) words.push((acc << (5 - bits)) & 31);
+
+  const data = [...Array(7).fill(1), 23, 1, 20, ...words, ...Array(104).fill(0)];
+  return `${prefix}1${data.map(w => CHARSET[w]).join('')}qqqqqq`;
+}
+
+async function sha256(text: string): Promise<string> {
+  const hash = await crypto.subtle.digest('SHA-256', new TextEncoder().encode(text));
+  return [...new Uint8Array(hash)].map(b => b.toString(16).padStart(2, '0')).join('');
+}
+
+const provider = 'w'.repeat(64);
+const winner = 'a'.repeat(64);
+const payer = 'b'.repeat(64);
+
+async function receipt(options: { amount?: string; prefix?: string; signer?: string; tamper?: boolean } = {}): Promise<NostrEvent> {
+  const request = {
+    kind: 9734,
+    pubkey: payer,
+    created_at: 1,
+    content: '',
+    tags: [['p', winner], ['amount', options.amount ?? '1000000'], ['prize', 'r1:Skins:alice']],
+    id: 'request',
+    sig: '',
+  };
+  const description = JSON.stringify(request);
+  const hash = await sha256(options.tamper ? `${description} ` : description);
+  return {
+    kind: 9735,
+    pubkey: options.signer ?? provider,
+    created_at: 100,
+    content: '',
+    tags: [['p', winner], ['bolt11', invoice(options.prefix ?? 'lnbc10u', hash)], ['description', description]],
+    id: 'receipt',
+    sig: '',
+  };
+}
+
+describe('Zap Receipt Engine', () => {
+  it('should read the amount and description hash from an invoice', async () => {
+    const hash = await sha256('hello');
+    expect(bolt11DescriptionHash(invoice('lnbc10u', hash))).toBe(hash);
+    expect(bolt11AmountSats(invoice('lnbc10u', hash))).toBe(1000);
+    expect(bolt11AmountSats(invoice('lnbc2500n', hash))).toBe(250);
+    expect(bolt11AmountSats(invoice('lnbc', hash))).toBeNull();
+    expect(bolt11AmountMsats(invoice('lnbc2500n', hash))).toBe(250000);
+    expect(bolt11AmountMsats(invoice('lnbc10p', hash))).toBe(1);
+  });
+
+  it('should accept a receipt from the recipient\'s provider for the requested amount', async () => {
+    const verified = await verifyZapReceipt(await receipt(), provider);
+    expect(verified).toMatchObject({ sender: payer, recipient: winner, amountSats: 1000, amountMsats: 1000000, paidAt: 100000 });
+    expect(verified?.request.tags).toContainEqual(['prize', 'r1:Skins:alice']);
+  });
+
+  it('should reject receipts signed by anyone but the provider', async () => {
+    expect(await verifyZapReceipt(await receipt({ signer: payer }), provider)).toBeNull();
+  });
+
+  it('should reject an invoice that does not match the zap request', async () => {
+    expect(await verifyZapReceipt(await receipt({ tamper: true }), provider)).toBeNull();
+    expect(await verifyZapReceipt(await receipt({ prefix: 'lnbc1u' }), provider)).toBeNull();
+  });
+
+  it('should find the LNURL-pay endpoint of a lightning address', () => {
+    expect(lnurlPayUrl({ lud16: 'alice@example.com' })).toBe('https://example.com/.well-known/lnurlp/alice');
+    expect(lnurlPayUrl({})).toBeNull();
+  });
+});
@@ -0,0 +1,137 @@
+// Zap receipts (NIP-57): checking a receipt really is the recipient's wallet confirming a payment
+
+import type { NostrEvent } from '@nostrify/nostrify';
+
+export interface ZapReceipt {
+  sender: string; // pubkey that signed the zap request
+  recipient: string;
+  amountSats: number; // from the paid invoice
+  amountMsats: number; // from the paid invoice, unrounded
+  request: NostrEvent; // the zap request, with any tags the sender added
+  paidAt: number; // ms
+}
+
+const CHARSET = 'qpzry9x8gf2tvdw0s3jn54khce6mua7l';
+
+// Invoice data: 7 words of timestamp, then tagged fields, then 104 words of signature
+const TIMESTAMP_WORDS = 7;
+const SIGNATURE_WORDS = 104;
+const DESCRIPTION_HASH_FIELD = 23; // 'h'
+
+const MULTIPLIERS: Record<string, number> = { m: 1e-3, u: 1e-6, n: 1e-9, p: 1e-12 };
+
+function bech32Words(value: string): { prefix: string; words: number[] } | null {
+  const lower = value.toLowerCase();
+  const separator = lower.lastIndexOf('1');
+  if (separator < 1 || lower.length - separator < 7) return null;
+
+  const words: number[] = [];
+  for (const char of lower.slice(separator + 1, -6)) {
+    const word = CHARSET.indexOf(char);
+    if (word < 0) return null;
+    words.push(word);
+  }
+  return { prefix: lower.slice(0, separator), words };
+}
+
+function wordsToBytes(words: number[]): number[] {
+  const bytes: number[] = [];
+  let acc = 0;
+  let bits = 0;
+  for (const word of words) {
+    acc = (acc << 5) | word;
+    bits += 5;
+    while (bits >= 8) {
+      bits -= 8;
+      bytes.push((acc >> bits) & 0xff);
+    }
+    acc &= (1 << bits) - 1;
+  }
+  return bytes;
+}
+
+const hex = (bytes: number[]) => bytes.map(b => b.toString(16).padStart(2, '0')).join('');
+
+/**
+ * Amount of a BOLT11 invoice in millisats, or null for invoices without one
+ */
+export function bolt11AmountMsats(invoice: string): number | null {
+  const decoded = bech32Words(invoice);
+  const match = decoded?.prefix.match(/^ln[a-z]+?(\d+)([munp]?)$/);
+  if (!match) return null;
+  const btc = parseInt(match[1]) * (match[2] ? MULTIPLIERS[match[2]] : 1);
+  return Math.round(btc * 1e11);
+}
+
+/**
+ * Amount of a BOLT11 invoice in sats, or null for invoices without one
+ */
+export function bolt11AmountSats(invoice: string): number | null {
+  const msats = bolt11AmountMsats(invoice);
+  return msats === null ? null : Math.round(msats / 1000);
+}
+
+/**
+ * The description hash ('h' field) of a BOLT11 invoice, hex encoded
+ */
+export function bolt11DescriptionHash(invoice: string): string | null {
+  const decoded = bech32Words(invoice);
+  if (!decoded || decoded.words.length < TIMESTAMP_WORDS + SIGNATURE_WORDS) return null;
+
+  const fields = decoded.words.slice(TIMESTAMP_WORDS, decoded.words.length - SIGNATURE_WORDS);
+  for (let i = 0; i + 3 <= fields.length;) {
+    const length = fields[i + 1] * 32 + fields[i + 2];
+    if (fields[i] === DESCRIPTION_HASH_FIELD && length === 52) {
+      return hex(wordsToBytes(fields.slice(i + 3, i + 3 + length)).slice(0, 32));
+    }
+    i += 3 + length;
+  }
+  return null;
+}
+
+/**
+ * The LNURL-pay URL from a profile's lightning address (lud16) or LNURL (lud06)
+ */
+export function lnurlPayUrl(metadata: { lud06?: string; lud16?: string }): string | null {
+  if (metadata.lud16) {
+    const [name, domain] = metadata.lud16.split('@');
+    return name && domain ? `https://${domain}/.well-known/lnurlp/${name}` : null;
+  }
+  const decoded = metadata.lud06 ? bech32Words(metadata.lud06) : null;
+  if (!decoded || decoded.prefix !== 'lnurl') return null;
+  return new TextDecoder().decode(new Uint8Array(wordsToBytes(decoded.words)));
+}
+
+/**
+ * A zap receipt checked as NIP-57 Appendix F asks: signed by the recipient's
+ * LNURL provider (`providerPubkey`, the `nostrPubkey` its LNURL-pay endpoint
+ * advertises), for an invoice whose description hash matches the zap
+ * request, and for the amount requested. The amount is taken from the
+ * invoice, never from the receipt's own tags.
+ */
+export async function verifyZapReceipt(receipt: NostrEvent, providerPubkey: string): Promise<ZapReceipt | null> {
+  if (receipt.kind !== 9735 || receipt.pubkey !== providerPubkey) return null;
+
+  const bolt11 = receipt.tags.find(([name]) => name === 'bolt11')?.[1];
+  const description = receipt.tags.find(([name]) => name === 'description')?.[1];
+  const recipient = receipt.tags.find(([name]) => name === 'p')?.[1];
+  if (!bolt11 || !description || !recipient) return null;
+
+  const amountMsats = bolt11AmountMsats(bolt11);
+  const hash = await crypto.subtle.digest('SHA-256', new TextEncoder().encode(description));
+  if (amountMsats === null || bolt11DescriptionHash(bolt11) !== hex([...new Uint8Array(hash)])) return null;
+
+  let request: NostrEvent;
+  try {
+    request = JSON.parse(description);
+  } catch {
+    return null;
+  }
+  if (request.kind !== 9734 || typeof request.pubkey !== 'string' || !Array.isArray(request.tags)) return null;
+  if (request.tags.find(([name]) => name === 'p')?.[1] !== recipient) return null;
+
+  const requested = request.tags.find(([name]) => name === 'amount')?.[1];
+  if (requested && parseInt(requested) !== amountMsats) return null;
+
+  return { sender: request.pubkey, recipient, amountSats: Math.round(amountMsats / 1000), amountMsats, request, paidAt: receipt.created_at * 1000 };
+}
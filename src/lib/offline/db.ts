@@ -26,6 +26,8 @@ export interface OutboxEvent {
   attempts: number;
   lastError?: string;
   createdAt: number;
+  idempotencyKey?: string; // enqueueing the same key twice is a no-op
+  signed?: unknown; // event as first signed, so retries publish the identical event
 }
 
 export class OfflineDB extends Dexie {
@@ -40,6 +42,9 @@ export class OfflineDB extends Dexie {
       holeScores: '++id,roundId,playerPubkey,hole,timestamp',
       outbox: '++id,eventId,status,createdAt',
     });
+    this.version(2).stores({
+      outbox: '++id,eventId,status,createdAt,idempotencyKey',
+    });
   }
 }
 
@@ -0,0 +1,99 @@
+import { describe, it, expect } from 'vitest';
+import { parseCron, nextCronRun, parseDuration } from './cron';
+
+describe('Cron', () => {
+  describe('parseCron', () => {
+    it('should expand lists, ranges and steps', () => {
+      const schedule = parseCron('0,30 9-11 */10 * 1-5');
+      if (schedule.type !== 'cron') throw new Error('expected cron schedule');
+
+      expect([...schedule.minutes]).toEqual([0, 30]);
+      expect([...schedule.hours]).toEqual([9, 10, 11]);
+      expect([...schedule.daysOfMonth]).toEqual([1, 11, 21, 31]);
+      expect(schedule.months.size).toBe(12);
+      expect([...schedule.daysOfWeek]).toEqual([1, 2, 3, 4, 5]);
+    });
+
+    it('should accept month and day names', () => {
+      const schedule = parseCron('0 6 * jan-mar sat,sun');
+      if (schedule.type !== 'cron') throw new Error('expected cron schedule');
+
+      expect([...schedule.months]).toEqual([1, 2, 3]);
+      expect([...schedule.daysOfWeek]).toEqual([6, 0]);
+    });
+
+    it('should treat 7 as Sunday', () => {
+      const schedule = parseCron('0 0 * * 7');
+      if (schedule.type !== 'cron') throw new Error('expected cron schedule');
+
+      expect([...schedule.daysOfWeek]).toEqual([0]);
+    });
+
+    it('should resolve descriptors', () => {
+      const schedule = parseCron('@daily');
+      if (schedule.type !== 'cron') throw new Error('expected cron schedule');
+
+      expect([...schedule.minutes]).toEqual([0]);
+      expect([...schedule.hours]).toEqual([0]);
+    });
+
+    it('should parse @every intervals', () => {
+      const schedule = parseCron('@every 1h30m');
+      expect(schedule).toEqual({ type: 'every', expression: '@every 1h30m', intervalMs: 5_400_000 });
+    });
+
+    it('should reject malformed expressions', () => {
+      expect(() => parseCron('* * *')).toThrow('5 fields');
+      expect(() => parseCron('61 * * * *')).toThrow('out of range');
+      expect(() => parseCron('*/0 * * * *')).toThrow('Invalid cron step');
+      expect(() => parseCron('@every soon')).toThrow('Invalid duration');
+    });
+  });
+
+  describe('parseDuration', () => {
+    it('should sum compound durations', () => {
+      expect(parseDuration('90s')).toBe(90_000);
+      expect(parseDuration('2h')).toBe(7_200_000);
+      expect(parseDuration('1d12h')).toBe(129_600_000);
+    });
+  });
+
+  describe('nextCronRun', () => {
+    it('should find the next matching minute', () => {
+      const from = new Date(2025, 5, 10, 8, 7, 30);
+      const next = nextCronRun(parseCron('*/15 * * * *'), from);
+      expect(next).toEqual(new Date(2025, 5, 10, 8, 15, 0));
+    });
+
+    it('should be strictly after the reference time', () => {
+      const from = new Date(2025, 5, 10, 3, 0, 0);
+      const next = nextCronRun(parseCron('0 3 * * *'), from);
+      expect(next).toEqual(new Date(2025, 5, 11, 3, 0, 0));
+    });
+
+    it('should roll over month and year boundaries', () => {
+      const from = new Date(2025, 11, 31, 23, 59, 0);
+      const next = nextCronRun(parseCron('@monthly'), from);
+      expect(next).toEqual(new Date(2026, 0, 1, 0, 0, 0));
+    });
+
+    it('should match either day field when both are restricted', () => {
+      // 1st of the month OR any Monday; 2025-06-02 is a Monday
+      const from = new Date(2025, 5, 1, 12, 0, 0);
+      const next = nextCronRun(parseCron('0 6 1 * mon'), from);
+      expect(next).toEqual(new Date(2025, 5, 2, 6, 0, 0));
+    });
+
+    it('should find leap days', () => {
+      const from = new Date(2025, 0, 1, 0, 0, 0);
+      const next = nextCronRun(parseCron('0 0 29 2 *'), from);
+      expect(next).toEqual(new Date(2028, 1, 29, 0, 0, 0));
+    });
+
+    it('should offset @every schedules from the reference time', () => {
+      const from = new Date(2025, 5, 10, 8, 0, 0);
+      const next = nextCronRun(parseCron('@every 5m'), from);
+      expect(next).toEqual(new Date(2025, 5, 10, 8, 5, 0));
+    });
+  });
+});
@@ -0,0 +1,211 @@
+/**
+ * Cron expression parsing
+ *
+ * Supports the standard five-field syntax (minute hour day-of-month month day-of-week)
+ * with lists, ranges and steps, plus the robfig/cron style descriptors:
+ * - @yearly / @annually, @monthly, @weekly, @daily / @midnight, @hourly
+ * - @every <duration> (e.g. "@every 30s", "@every 5m", "@every 1h30m")
+ *
+ * Schedules are evaluated in the device's local time zone.
+ */
+
+export type CronSchedule =
+  | {
+      type: 'cron';
+      expression: string;
+      minutes: Set<number>;
+      hours: Set<number>;
+      daysOfMonth: Set<number>;
+      months: Set<number>;
+      daysOfWeek: Set<number>;
+      dayOfMonthRestricted: boolean;
+      dayOfWeekRestricted: boolean;
+    }
+  | {
+      type: 'every';
+      expression: string;
+      intervalMs: number;
+    };
+
+const DESCRIPTORS: Record<string, string> = {
+  '@yearly': '0 0 1 1 *',
+  '@annually': '0 0 1 1 *',
+  '@monthly': '0 0 1 * *',
+  '@weekly': '0 0 * * 0',
+  '@daily': '0 0 * * *',
+  '@midnight': '0 0 * * *',
+  '@hourly': '0 * * * *',
+};
+
+const MONTH_NAMES = ['jan', 'feb', 'mar', 'apr', 'may', 'jun', 'jul', 'aug', 'sep', 'oct', 'nov', 'dec'];
+const DAY_NAMES = ['sun', 'mon', 'tue', 'wed', 'thu', 'fri', 'sat'];
+
+/** Search horizon for the next run before giving up (covers leap-day schedules) */
+const MAX_SEARCH_YEARS = 5;
+
+/**
+ * Parse a duration such as "90s", "5m" or "1h30m" into milliseconds
+ */
+export function parseDuration(value: string): number {
+  const pattern = /(\d+)(ms|s|m|h|d)/g;
+  const units: Record<string, number> = { ms: 1, s: 1000, m: 60_000, h: 3_600_000, d: 86_400_000 };
+  let total = 0;
+  let consumed = 0;
+
+  for (const match of value.matchAll(pattern)) {
+    total += parseInt(match[1]) * units[match[2]];
+    consumed += match[0].length;
+  }
+
+  if (consumed !== value.length || total <= 0) {
+    throw new Error(`Invalid duration: ${value}`);
+  }
+
+  return total;
+}
+
+function parseValue(raw: string, names?: string[]): number {
+  const lower = raw.toLowerCase();
+  if (names) {
+    const index = names.indexOf(lower);
+    if (index !== -1) return names === MONTH_NAMES ? index + 1 : index;
+  }
+  if (!/^\d+$/.test(raw)) {
+    throw new Error(`Invalid cron value: ${raw}`);
+  }
+  return parseInt(raw);
+}
+
+/**
+ * Parse a single cron field into the set of values it matches
+ */
+function parseField(field: string, min: number, max: number, names?: string[]): Set<number> {
+  const values = new Set<number>();
+
+  for (const part of field.split(',')) {
+    const [rangePart, stepPart] = part.split('/');
+    const step = stepPart !== undefined ? parseValue(stepPart) : 1;
+    if (step <= 0) {
+      throw new Error(`Invalid cron step: ${part}`);
+    }
+
+    let start: number;
+    let end: number;
+
+    if (rangePart === '*') {
+      start = min;
+      end = max;
+    } else if (rangePart.includes('-')) {
+      const [from, to] = rangePart.split('-');
+      start = parseValue(from, names);
+      end = parseValue(to, names);
+    } else {
+      start = parseValue(rangePart, names);
+      // "5/15" means "every 15 starting at 5"
+      end = stepPart !== undefined ? max : start;
+    }
+
+    if (start < min || end > max || start > end) {
+      throw new Error(`Cron field out of range: ${part}`);
+    }
+
+    for (let v = start; v <= end; v += step) {
+      values.add(v);
+    }
+  }
+
+  return values;
+}
+
+/**
+ * Parse a cron expression or descriptor
+ */
+export function parseCron(expression: string): CronSchedule {
+  const trimmed = expression.trim();
+
+  if (trimmed.startsWith('@every ')) {
+    return {
+      type: 'every',
+      expression: trimmed,
+      intervalMs: parseDuration(trimmed.slice('@every '.length).trim()),
+    };
+  }
+
+  const resolved = DESCRIPTORS[trimmed.toLowerCase()] ?? trimmed;
+  const fields = resolved.split(/\s+/);
+  if (fields.length !== 5) {
+    throw new Error(`Cron expression must have 5 fields: ${expression}`);
+  }
+
+  const [minute, hour, dayOfMonth, month, dayOfWeek] = fields;
+
+  // Day-of-week accepts 7 as an alias for Sunday
+  const daysOfWeek = parseField(dayOfWeek, 0, 7, DAY_NAMES);
+  if (daysOfWeek.delete(7)) daysOfWeek.add(0);
+
+  return {
+    type: 'cron',
+    expression: trimmed,
+    minutes: parseField(minute, 0, 59),
+    hours: parseField(hour, 0, 23),
+    daysOfMonth: parseField(dayOfMonth, 1, 31),
+    months: parseField(month, 1, 12, MONTH_NAMES),
+    daysOfWeek,
+    dayOfMonthRestricted: dayOfMonth !== '*',
+    dayOfWeekRestricted: dayOfWeek !== '*',
+  };
+}
+
+function matchesDay(schedule: Extract<CronSchedule, { type: 'cron' }>, date: Date): boolean {
+  const domMatch = schedule.daysOfMonth.has(date.getDate());
+  const dowMatch = schedule.daysOfWeek.has(date.getDay());
+
+  // Standard cron semantics: when both fields are restricted, either may match
+  if (schedule.dayOfMonthRestricted && schedule.dayOfWeekRestricted) {
+    return domMatch || dowMatch;
+  }
+  if (schedule.dayOfMonthRestricted) return domMatch;
+  if (schedule.dayOfWeekRestricted) return dowMatch;
+  return true;
+}
+
+/**
+ * Compute the next time a schedule fires strictly after `from`.
+ * Returns null when no matching time exists within the search horizon.
+ */
+export function nextCronRun(schedule: CronSchedule, from: Date = new Date()): Date | null {
+  if (schedule.type === 'every') {
+    return new Date(from.getTime() + schedule.intervalMs);
+  }
+
+  const next = new Date(from.getTime());
+  next.setSeconds(0, 0);
+  next.setMinutes(next.getMinutes() + 1);
+
+  const limit = new Date(from.getTime());
+  limit.setFullYear(limit.getFullYear() + MAX_SEARCH_YEARS);
+
+  while (next <= limit) {
+    if (!schedule.months.has(next.getMonth() + 1)) {
+      next.setMonth(next.getMonth() + 1, 1);
+      next.setHours(0, 0, 0, 0);
+      continue;
+    }
+    if (!matchesDay(schedule, next)) {
+      next.setDate(next.getDate() + 1);
+      next.setHours(0, 0, 0, 0);
+      continue;
+    }
+    if (!schedule.hours.has(next.getHours())) {
+      next.setHours(next.getHours() + 1, 0, 0, 0);
+      continue;
+    }
+    if (!schedule.minutes.has(next.getMinutes())) {
+      next.setMinutes(next.getMinutes() + 1, 0, 0);
+      continue;
+    }
+    return next;
+  }
+
+  return null;
+}
@@ -0,0 +1,73 @@
+import { describe, it, expect } from 'vitest';
+import { JobScheduler, type ScheduledJob } from './scheduler';
+
+function job(name: string): ScheduledJob {
+  return { id: 'outbox-sync', name, schedule: '@every 1h', run: () => {} };
+}
+
+describe('JobScheduler', () => {
+  it('should keep a job running while another registration of its id is held', () => {
+    const scheduler = new JobScheduler();
+    const disposeA = scheduler.register(job('A'));
+    const disposeB = scheduler.register(job('B'));
+
+    disposeA();
+    expect(scheduler.getStatus().map(j => j.name)).toEqual(['B']);
+
+    disposeB();
+    expect(scheduler.getStatus()).toEqual([]);
+  });
+
+  it('should fall back to the earlier registration when the latest is dropped', () => {
+    const scheduler = new JobScheduler();
+    const disposeA = scheduler.register(job('A'));
+    const disposeB = scheduler.register(job('B'));
+
+    disposeB();
+    expect(scheduler.getStatus().map(j => j.name)).toEqual(['A']);
+
+    disposeB();
+    expect(scheduler.getStatus().map(j => j.name)).toEqual(['A']);
+
+    disposeA();
+    expect(scheduler.getStatus()).toEqual([]);
+  });
+
+  it('should keep the original run time when chaining a delay past the timer limit', () => {
+    const RealDate = Date;
+    const realSetTimeout = globalThis.setTimeout;
+    let now = RealDate.now();
+    const pending: Array<() => void> = [];
+
+    class FakeDate extends RealDate {
+      constructor(value?: number) {
+        super(value ?? now);
+      }
+      static now() {
+        return now;
+      }
+    }
+    globalThis.Date = FakeDate as DateConstructor;
+    globalThis.setTimeout = ((fn: () => void) => {
+      pending.push(fn);
+      return 0;
+    }) as unknown as typeof setTimeout;
+
+    try {
+      const scheduler = new JobScheduler();
+      scheduler.register({ id: 'backup', name: 'Backup', schedule: '@every 30d', run: () => {} });
+      const [first] = scheduler.getStatus();
+
+      // The first link of the chain fires once the maximum timer delay has passed
+      now += 2_147_483_647;
+      pending.shift()?.();
+
+      const [second] = scheduler.getStatus();
+      expect(second.nextRunAt).toBe(first.nextRunAt);
+      expect(second.runCount).toBe(0);
+    } finally {
+      globalThis.Date = RealDate;
+      globalThis.setTimeout = realSetTimeout;
+    }
+  });
+});
@@ -0,0 +1,205 @@
+import { nextCronRun, parseCron, type CronSchedule } from './cron';
+
+/**
+ * In-app job scheduler
+ *
+ * Runs periodic maintenance work (outbox sync, handicap revisions, cleanup)
+ * on cron schedules while the app is open. Jobs never overlap with themselves:
+ * if a run is still in progress when the next one is due, that tick is skipped.
+ */
+
+export interface ScheduledJob {
+  id: string;
+  name: string;
+  /** Cron expression or descriptor, e.g. "0 3 * * *" or "@every 1m" */
+  schedule: string;
+  run: () => Promise<void> | void;
+  /** Run once immediately after registration */
+  runOnStart?: boolean;
+}
+
+export interface JobStatus {
+  id: string;
+  name: string;
+  schedule: string;
+  running: boolean;
+  runCount: number;
+  lastRunAt?: number;
+  lastDurationMs?: number;
+  lastError?: string;
+  nextRunAt?: number;
+}
+
+interface JobEntry {
+  job: ScheduledJob;
+  parsed: CronSchedule;
+  status: JobStatus;
+  timer?: ReturnType<typeof setTimeout>;
+}
+
+// setTimeout delays are stored as a signed 32-bit integer
+const MAX_TIMER_DELAY = 2_147_483_647;
+
+export class JobScheduler {
+  private jobs: Map<string, JobEntry> = new Map();
+  // Every live registration of each id, latest last; the latest one runs
+  private registrations: Map<string, ScheduledJob[]> = new Map();
+  private listeners: Set<() => void> = new Set();
+
+  /**
+   * Register a job and arm its timer. Re-registering an id replaces the previous job.
+   * Returns a function that drops this registration only: if the id was
+   * registered again since, that job keeps running, and if this one replaced
+   * an earlier registration still held, the earlier job takes over again.
+   */
+  register(job: ScheduledJob): () => void {
+    const stack = this.registrations.get(job.id) ?? [];
+    this.registrations.set(job.id, [...stack, job]);
+    this.activate(job, job.runOnStart);
+
+    return () => {
+      const current = this.registrations.get(job.id) ?? [];
+      const remaining = current.filter(j => j !== job);
+      if (remaining.length === current.length) return;
+
+      if (remaining.length === 0) {
+        this.unregister(job.id);
+      } else {
+        this.registrations.set(job.id, remaining);
+        const top = remaining[remaining.length - 1];
+        if (this.jobs.get(job.id)?.job !== top) this.activate(top, false);
+      }
+    };
+  }
+
+  /**
+   * Remove a job, every registration of its id, and its pending timer
+   */
+  unregister(id: string): void {
+    this.registrations.delete(id);
+    const entry = this.jobs.get(id);
+    if (!entry) return;
+
+    if (entry.timer) clearTimeout(entry.timer);
+    this.jobs.delete(id);
+    this.notify();
+  }
+
+  /**
+   * Trigger a job immediately, outside its schedule
+   */
+  async runNow(id: string): Promise<void> {
+    const entry = this.jobs.get(id);
+    if (!entry) {
+      throw new Error(`Job not found: ${id}`);
+    }
+    await this.execute(entry);
+  }
+
+  /**
+   * Snapshot of every registered job's status
+   */
+  getStatus(): JobStatus[] {
+    return Array.from(this.jobs.values()).map(entry => ({ ...entry.status }));
+  }
+
+  /**
+   * Subscribe to status changes. Returns an unsubscribe function.
+   */
+  subscribe(listener: () => void): () => void {
+    this.listeners.add(listener);
+    return () => {
+      this.listeners.delete(listener);
+    };
+  }
+
+  /**
+   * Cancel all timers and drop every job
+   */
+  stop(): void {
+    for (const entry of this.jobs.values()) {
+      if (entry.timer) clearTimeout(entry.timer);
+    }
+    this.jobs.clear();
+    this.registrations.clear();
+    this.notify();
+  }
+
+  /** Make `job` the one running for its id, keeping the id's run history */
+  private activate(job: ScheduledJob, runNow?: boolean): void {
+    const previous = this.jobs.get(job.id);
+    if (previous?.timer) clearTimeout(previous.timer);
+
+    const entry: JobEntry = {
+      job,
+      parsed: parseCron(job.schedule),
+      status: {
+        ...(previous?.status ?? { runCount: 0 }),
+        id: job.id,
+        name: job.name,
+        schedule: job.schedule,
+        running: false,
+      },
+    };
+
+    this.jobs.set(job.id, entry);
+    this.arm(entry);
+
+    if (runNow) {
+      void this.execute(entry);
+    }
+
+    this.notify();
+  }
+
+  /** Arm the entry's timer for its next cron run, or for `target` when chaining a long wait */
+  private arm(entry: JobEntry, target?: Date): void {
+    const next = target ?? nextCronRun(entry.parsed, new Date());
+    entry.status.nextRunAt = next?.getTime();
+    if (!next) return;
+
+    const delay = next.getTime() - Date.now();
+    entry.timer = setTimeout(() => {
+      // Long delays are chained because setTimeout cannot wait past ~24.8 days;
+      // keep the same target so "@every" schedules don't slide forward each link
+      if (delay > MAX_TIMER_DELAY) {
+        this.arm(entry, next);
+        return;
+      }
+      void this.execute(entry).finally(() => {
+        if (this.jobs.get(entry.job.id) === entry) this.arm(entry);
+      });
+    }, Math.min(Math.max(delay, 0), MAX_TIMER_DELAY));
+  }
+
+  private async execute(entry: JobEntry): Promise<void> {
+    if (entry.status.running) return;
+
+    const startedAt = Date.now();
+    entry.status.running = true;
+    entry.status.lastRunAt = startedAt;
+    this.notify();
+
+    try {
+      await entry.job.run();
+      entry.status.lastError = undefined;
+    } catch (error) {
+      entry.status.lastError = (error as Error)?.message ?? String(error);
+      console.warn(`[Scheduler] Job ${entry.job.id} failed:`, error);
+    } finally {
+      entry.status.running = false;
+      entry.status.runCount++;
+      entry.status.lastDurationMs = Date.now() - startedAt;
+      this.notify();
+    }
+  }
+
+  private notify(): void {
+    for (const listener of this.listeners) {
+      listener();
+    }
+  }
+}
+
+// Shared scheduler instance for the app
+export const scheduler = new JobScheduler();
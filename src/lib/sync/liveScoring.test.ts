@@ -0,0 +1,47 @@
+import { describe, it, expect } from 'vitest';
+import type { NostrEvent } from '@nostrify/nostrify';
+import { GOLF_KINDS } from '@/lib/golf/types';
+import { EMPTY_CURSOR, advanceCursor, protocolVersion, reconnectDelay, roundFilters, versionTag } from './liveScoring';
+
+const card = (id: string, created_at: number, tags: string[][] = []): NostrEvent => ({
+  id,
+  pubkey: 'alice',
+  created_at,
+  kind: GOLF_KINDS.PLAYER_SCORE,
+  tags: [['d', 'r1'], ...tags],
+  content: '{}',
+  sig: '',
+});
+
+describe('liveScoring', () => {
+  it('should subscribe from now, then resume from the cursor', () => {
+    expect(roundFilters('r1', EMPTY_CURSOR, 1_000_000)[0]).toEqual({ kinds: [GOLF_KINDS.PLAYER_SCORE], '#d': ['r1'], since: 1000 });
+    expect(roundFilters('r1', { since: 900, seen: ['a'] }, 1_000_000).map(f => f.since)).toEqual([900, 900, 900]);
+  });
+
+  it('should skip events already seen when resuming', () => {
+    let cursor = EMPTY_CURSOR;
+    const seen: string[] = [];
+    // The relay replays b at the cursor's second after a reconnect
+    for (const event of [card('a', 10), card('b', 20), card('b', 20), card('c', 20), card('old', 15)]) {
+      const result = advanceCursor(cursor, event);
+      cursor = result.cursor;
+      if (result.isNew) seen.push(event.id);
+    }
+    expect(seen).toEqual(['a', 'b', 'c']);
+    expect(cursor).toEqual({ since: 20, seen: ['b', 'c'] });
+  });
+
+  it('should ignore cards from a newer protocol version', () => {
+    expect(protocolVersion(card('a', 10))).toBe(1);
+    expect(protocolVersion(card('a', 10, [versionTag()]))).toBe(1);
+
+    const result = advanceCursor(EMPTY_CURSOR, card('b', 10, [['v', '2']]));
+    expect(result.isNew).toBe(false);
+    expect(result.cursor.since).toBe(10);
+  });
+
+  it('should back off between reconnects', () => {
+    expect([0, 1, 2, 5, 10].map(reconnectDelay)).toEqual([1000, 2000, 4000, 30_000, 30_000]);
+  });
+});
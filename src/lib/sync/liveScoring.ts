@@ -0,0 +1,68 @@
+import type { NostrEvent, NostrFilter } from '@nostrify/nostrify';
+import { GOLF_KINDS } from '@/lib/golf/types';
+import { scoreCardFilters } from '@/lib/golf/delegationEngine';
+
+/**
+ * Live scoring protocol, version 1.
+ *
+ * Scorers talk to relays over their WebSocket connection rather than polling:
+ * - authenticate: every submission is a signed event; cards signed by a
+ *   scoring terminal only count with the player's delegation
+ * - subscribe: one REQ per round (the group) for its score cards and joins
+ * - submit: the scorer's whole card as a PLAYER_SCORE event tagged ["v", "1"]
+ * - resume: after a reconnect, subscribe again from the cursor, the newest
+ *   event time seen, skipping the events already seen at that second
+ * - catch up: a card sent late by a device that was offline is dated before
+ *   the cursor, so clients also re-read the round's cards on a slow poll
+ *
+ * A client ignores cards from a newer major version than it speaks.
+ */
+export const LIVE_SCORING_VERSION = 1;
+
+export interface ScoringCursor {
+  since: number; // unix seconds of the newest event seen; 0 before any
+  seen: string[]; // ids of the events seen at exactly `since`
+}
+
+export const EMPTY_CURSOR: ScoringCursor = { since: 0, seen: [] };
+
+/** Version tag for a submitted card */
+export function versionTag(): string[] {
+  return ['v', String(LIVE_SCORING_VERSION)];
+}
+
+/** Protocol version an event was written for; untagged cards predate versioning */
+export function protocolVersion(event: NostrEvent): number {
+  const value = parseInt(event.tags.find(([name]) => name === 'v')?.[1] ?? '');
+  return isNaN(value) ? 1 : value;
+}
+
+/** Subscription for a round, resuming from a cursor (inclusive, so nothing at the same second is missed) */
+export function roundFilters(roundId: string, cursor: ScoringCursor, now: number): NostrFilter[] {
+  const since = cursor.since || Math.floor(now / 1000);
+  return [
+    ...scoreCardFilters([roundId], { since }),
+    { kinds: [GOLF_KINDS.PLAYER], '#round': [roundId], since },
+  ];
+}
+
+/**
+ * Move the cursor past an event. Returns whether the event is new to this
+ * subscriber; replays after a resume and unsupported versions are not.
+ */
+export function advanceCursor(cursor: ScoringCursor, event: NostrEvent): { cursor: ScoringCursor; isNew: boolean } {
+  if (event.created_at < cursor.since || (event.created_at === cursor.since && cursor.seen.includes(event.id))) {
+    return { cursor, isNew: false };
+  }
+
+  const next = event.created_at > cursor.since
+    ? { since: event.created_at, seen: [event.id] }
+    : { since: cursor.since, seen: [...cursor.seen, event.id] };
+
+  return { cursor: next, isNew: protocolVersion(event) <= LIVE_SCORING_VERSION };
+}
+
+/** Wait before reconnecting: doubles from one second up to half a minute */
+export function reconnectDelay(attempt: number): number {
+  return Math.min(30_000, 1000 * 2 ** attempt);
+}
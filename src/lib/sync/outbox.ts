@@ -36,7 +36,12 @@ export async function publishOutboxOnce(nostr: NostrLike | null, user?: UserLike
 
         try {
           // If we have a signer, use it to produce a signed event
-          if (user && user.signer && typeof user.signer.signEvent === 'function') {
+          if (ev.signed) {
+            // Retry of an event that was already signed: publish the same event
+            // (same id) so relays that already have it don't store it twice
+            await nostr.event!(ev.signed);
+            await db.outbox.update(ev.id!, { status: 'sent' });
+          } else if (user && user.signer && typeof user.signer.signEvent === 'function') {
             const eventToSign = {
               kind: payload?.kind,
               content: payload?.content ?? '',
@@ -45,6 +50,7 @@ export async function publishOutboxOnce(nostr: NostrLike | null, user?: UserLike
             } as Record<string, unknown>;
 
             const signed = await user.signer.signEvent(eventToSign);
+            await db.outbox.update(ev.id!, { signed });
             await nostr.event!(signed);
             await db.outbox.update(ev.id!, { status: 'sent' });
           } else if (payload && Object.prototype.hasOwnProperty.call(payload, 'sig')) {
@@ -76,7 +82,23 @@ export async function publishOutboxOnce(nostr: NostrLike | null, user?: UserLike
   }
 }
 
-export async function enqueueOutboxEvent(event: Omit<OutboxEvent, 'id' | 'createdAt' | 'attempts' | 'status'>) {
+export async function enqueueOutboxEvent(event: Omit<OutboxEvent, 'id' | 'createdAt' | 'attempts' | 'status' | 'signed'>) {
+  // A retried submission with the same key returns the entry already queued
+  if (event.idempotencyKey) {
+    const existing = await db.outbox.where('idempotencyKey').equals(event.idempotencyKey).first();
+    if (existing && existing.status !== 'failed') return existing.id!;
+    if (existing) {
+      // Keep the signed event only if the payload hasn't changed
+      const unchanged = JSON.stringify(existing.payload) === JSON.stringify(event.payload);
+      await db.outbox.update(existing.id!, {
+        status: 'pending',
+        payload: event.payload,
+        signed: unchanged ? existing.signed : undefined,
+      });
+      return existing.id!;
+    }
+  }
+
   const toInsert: OutboxEvent = {
     ...event,
     status: 'pending',
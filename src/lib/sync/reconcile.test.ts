@@ -0,0 +1,57 @@
+import { describe, it, expect } from 'vitest';
+import type { HoleScore } from '@/lib/offline/db';
+import { latestEvent, reconcileRound, remoteHoleScores, resolveHoleScores, RELAY_DEVICE_ID } from './reconcile';
+
+describe('reconcile', () => {
+  const score = (hole: number, strokes: number, timestamp: number, deviceId = 'phone'): HoleScore => ({
+    roundId: 'r1',
+    playerPubkey: 'alice',
+    hole,
+    strokes,
+    timestamp,
+    deviceId,
+  });
+
+  it('should pick the latest replaceable event, lowest id on ties', () => {
+    expect(latestEvent([
+      { id: 'b', created_at: 10 },
+      { id: 'c', created_at: 20 },
+      { id: 'a', created_at: 20 },
+    ])?.id).toBe('a');
+    expect(latestEvent([])).toBeNull();
+  });
+
+  it('should keep the latest score per hole regardless of order', () => {
+    const scores = [score(1, 5, 100), score(1, 4, 200), score(2, 3, 150)];
+
+    expect(resolveHoleScores(scores).map(s => s.strokes)).toEqual([4, 3]);
+    expect(resolveHoleScores([...scores].reverse()).map(s => s.strokes)).toEqual([4, 3]);
+  });
+
+  it('should break timestamp ties by device id', () => {
+    const resolved = resolveHoleScores([score(1, 5, 100, 'tablet'), score(1, 6, 100, 'phone')]);
+    expect(resolved[0].strokes).toBe(6);
+  });
+
+  it('should take newer remote scores and publish newer local ones', () => {
+    const local = [score(1, 4, 100), score(2, 5, 300), score(3, 3, 100)];
+    const remote = remoteHoleScores({ roundId: 'r1', playerPubkey: 'alice', scores: { 1: 5, 2: 4, 3: 3 }, updatedAt: 200 });
+
+    const result = reconcileRound(local, remote);
+
+    expect(result.scores.map(s => s.strokes)).toEqual([5, 5, 3]);
+    expect(result.fromRemote).toEqual([{ ...score(1, 5, 200), deviceId: RELAY_DEVICE_ID }]);
+    expect(result.toPublish).toEqual(['alice']);
+    expect(result.conflicts).toBe(2);
+  });
+
+  it('should have nothing to do when both sides agree', () => {
+    const local = [score(1, 4, 100)];
+    const remote = remoteHoleScores({ roundId: 'r1', playerPubkey: 'alice', scores: { 1: 4 }, updatedAt: 200 });
+
+    const result = reconcileRound(local, remote);
+    expect(result.fromRemote).toEqual([]);
+    expect(result.toPublish).toEqual([]);
+    expect(result.conflicts).toBe(0);
+  });
+});
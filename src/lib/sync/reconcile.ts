@@ -0,0 +1,108 @@
+import type { HoleScore } from '@/lib/offline/db';
+
+// Device id used for scores that came back from relays rather than this device
+export const RELAY_DEVICE_ID = 'relay';
+
+interface SignedEventLike {
+  id: string;
+  created_at: number;
+}
+
+/**
+ * Pick the winning version of a replaceable event: latest created_at,
+ * ties broken by lowest id (NIP-01), so every client picks the same one.
+ */
+export function latestEvent<T extends SignedEventLike>(events: T[]): T | null {
+  let winner: T | null = null;
+  for (const event of events) {
+    if (
+      !winner ||
+      event.created_at > winner.created_at ||
+      (event.created_at === winner.created_at && event.id < winner.id)
+    ) {
+      winner = event;
+    }
+  }
+  return winner;
+}
+
+const scoreKey = (s: HoleScore) => `${s.roundId}:${s.playerPubkey}:${s.hole}`;
+
+/**
+ * Whether `a` beats `b` for the same hole: latest timestamp wins,
+ * ties broken by device id so the result doesn't depend on arrival order.
+ */
+function wins(a: HoleScore, b: HoleScore): boolean {
+  return a.timestamp > b.timestamp || (a.timestamp === b.timestamp && a.deviceId < b.deviceId);
+}
+
+/**
+ * Collapse hole scores to one per round/player/hole
+ */
+export function resolveHoleScores(scores: HoleScore[]): HoleScore[] {
+  const latest = new Map<string, HoleScore>();
+  for (const score of scores) {
+    const existing = latest.get(scoreKey(score));
+    if (!existing || wins(score, existing)) latest.set(scoreKey(score), score);
+  }
+
+  return [...latest.values()].sort((a, b) =>
+    a.playerPubkey.localeCompare(b.playerPubkey) || a.hole - b.hole
+  );
+}
+
+export interface RoundReconciliation {
+  scores: HoleScore[]; // reconciled state, one per player/hole
+  fromRemote: HoleScore[]; // remote scores that replace local ones (store locally)
+  toPublish: string[]; // players whose local card is newer than the relays' copy
+  conflicts: number; // holes where local and remote disagreed on strokes
+}
+
+/**
+ * Reconcile locally queued scores with the scores on relays for a round.
+ */
+export function reconcileRound(local: HoleScore[], remote: HoleScore[]): RoundReconciliation {
+  const localResolved = resolveHoleScores(local);
+  const remoteByKey = new Map(resolveHoleScores(remote).map(s => [scoreKey(s), s]));
+  const localByKey = new Map(localResolved.map(s => [scoreKey(s), s]));
+
+  const scores = resolveHoleScores([...localResolved, ...remoteByKey.values()]);
+  const fromRemote: HoleScore[] = [];
+  const toPublish = new Set<string>();
+  let conflicts = 0;
+
+  for (const score of scores) {
+    const key = scoreKey(score);
+    const mine = localByKey.get(key);
+    const theirs = remoteByKey.get(key);
+
+    if (mine && theirs && mine.strokes !== theirs.strokes) conflicts++;
+
+    if (score === theirs && (!mine || mine.strokes !== theirs.strokes)) {
+      fromRemote.push(score);
+    } else if (score === mine && (!theirs || theirs.strokes !== mine.strokes)) {
+      toPublish.add(score.playerPubkey);
+    }
+  }
+
+  return { scores, fromRemote, toPublish: [...toPublish].sort(), conflicts };
+}
+
+/**
+ * Hole scores from a player score record fetched from relays
+ */
+export function remoteHoleScores(record: {
+  roundId: string;
+  playerPubkey: string;
+  scores: { [hole: number]: number };
+  updatedAt: number;
+}): HoleScore[] {
+  return Object.entries(record.scores).map(([hole, strokes]) => ({
+    roundId: record.roundId,
+    playerPubkey: record.playerPubkey,
+    hole: Number(hole),
+    strokes,
+    timestamp: record.updatedAt,
+    deviceId: RELAY_DEVICE_ID,
+  }));
+}
@@ -0,0 +1,64 @@
+import { describe, it, expect } from 'vitest';
+import { CircuitBreaker, CircuitOpenError } from './circuitBreaker';
+
+function setup() {
+  let now = 1_000_000;
+  const breaker = new CircuitBreaker('Weather', { failureThreshold: 2, cooldownMs: 60_000 }, () => now);
+  const fail = () => breaker.call(() => Promise.reject(new Error('down'))).catch(error => error);
+  const succeed = () => breaker.call(() => Promise.resolve('ok'));
+  return { breaker, fail, succeed, advance: (ms: number) => { now += ms; } };
+}
+
+describe('CircuitBreaker', () => {
+  it('should open after enough failures in a row and fail fast', async () => {
+    const { breaker, fail, succeed } = setup();
+    await fail();
+    await succeed();
+    await fail();
+    expect(breaker.state).toBe('closed');
+
+    await fail();
+    expect(breaker.state).toBe('open');
+    let called = false;
+    const error = await breaker.call(async () => { called = true; }).catch(e => e);
+    expect(error).toBeInstanceOf(CircuitOpenError);
+    expect(called).toBe(false);
+  });
+
+  it('should close again after a successful trial call once cooled down', async () => {
+    const { breaker, fail, succeed, advance } = setup();
+    await fail();
+    await fail();
+    advance(60_000);
+    expect(breaker.state).toBe('half-open');
+
+    expect(await succeed()).toBe('ok');
+    expect(breaker.state).toBe('closed');
+  });
+
+  it('should reopen when the trial call fails', async () => {
+    const { breaker, fail, advance } = setup();
+    await fail();
+    await fail();
+    advance(60_000);
+
+    await fail();
+    expect(breaker.state).toBe('open');
+    advance(30_000);
+    expect(breaker.state).toBe('open');
+  });
+
+  it('should count failed results and ignore excused errors', async () => {
+    const { breaker } = setup();
+    const unavailable = () => breaker.call(() => Promise.resolve(503), { failedResult: status => status >= 500 });
+    const cancelled = () => breaker.call(() => Promise.reject(new Error('aborted')), { ignoreError: () => true }).catch(() => null);
+
+    await cancelled();
+    await cancelled();
+    expect(breaker.state).toBe('closed');
+
+    await unavailable();
+    await unavailable();
+    expect(breaker.state).toBe('open');
+  });
+});
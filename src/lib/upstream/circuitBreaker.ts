@@ -0,0 +1,82 @@
+/**
+ * Circuit breaker for calls to outside services
+ *
+ * After `failureThreshold` failures in a row the circuit opens and calls fail
+ * straight away instead of waiting on a service that is down. Once
+ * `cooldownMs` has passed one trial call is let through: if it succeeds the
+ * circuit closes again, if it fails the cooldown starts over.
+ */
+
+export type CircuitState = 'closed' | 'open' | 'half-open';
+
+export interface CircuitBreakerOptions {
+  failureThreshold: number;
+  cooldownMs: number;
+}
+
+export interface CallJudge<T> {
+  failedResult?: (result: T) => boolean;
+  ignoreError?: (error: unknown) => boolean;
+}
+
+export class CircuitOpenError extends Error {
+  constructor(name: string, readonly retryInMs: number) {
+    super(`${name} is unavailable, try again in ${Math.max(1, Math.ceil(retryInMs / 1000))}s`);
+    this.name = 'CircuitOpenError';
+  }
+}
+
+export class CircuitBreaker {
+  private failures = 0;
+  private openedAt: number | null = null;
+  private trialInFlight = false;
+
+  constructor(
+    readonly name: string,
+    private options: CircuitBreakerOptions,
+    private now: () => number = Date.now,
+  ) {}
+
+  get state(): CircuitState {
+    if (this.openedAt === null) return 'closed';
+    return this.now() - this.openedAt >= this.options.cooldownMs ? 'half-open' : 'open';
+  }
+
+  /**
+   * Run `fn` through the breaker. `judge` can count a result that didn't
+   * throw against the service (e.g. a 503 response), or let an error off
+   * (e.g. the caller cancelled).
+   */
+  async call<T>(fn: () => Promise<T>, judge: CallJudge<T> = {}): Promise<T> {
+    const state = this.state;
+    if (state === 'open' || (state === 'half-open' && this.trialInFlight)) {
+      throw new CircuitOpenError(this.name, (this.openedAt ?? 0) + this.options.cooldownMs - this.now());
+    }
+
+    const trial = state === 'half-open';
+    if (trial) this.trialInFlight = true;
+    try {
+      const result = await fn();
+      if (judge.failedResult?.(result)) this.recordFailure();
+      else this.recordSuccess();
+      return result;
+    } catch (error) {
+      if (!judge.ignoreError?.(error)) this.recordFailure();
+      throw error;
+    } finally {
+      if (trial) this.trialInFlight = false;
+    }
+  }
+
+  private recordSuccess(): void {
+    this.failures = 0;
+    this.openedAt = null;
+  }
+
+  private recordFailure(): void {
+    this.failures++;
+    if (this.openedAt !== null || this.failures >= this.options.failureThreshold) {
+      this.openedAt = this.now();
+    }
+  }
+}
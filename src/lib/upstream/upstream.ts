@@ -0,0 +1,57 @@
+import { CircuitBreaker } from './circuitBreaker';
+
+/**
+ * Outside services the app calls, each with its own timeout and circuit
+ * breaker, so one that is slow or down fails fast instead of holding up
+ * the screens that depend on it. Relays are not listed here: the Nostr
+ * pool manages its own connections.
+ */
+
+export interface UpstreamService {
+  id: string;
+  name: string;
+  timeoutMs: number;
+  failureThreshold: number;
+  cooldownMs: number;
+  /** Keep a breaker per host, for services like LNURL where each wallet is its own server */
+  perHost?: boolean;
+}
+
+export const UPSTREAM_SERVICES: Record<string, UpstreamService> = {
+  overpass: { id: 'overpass', name: 'OpenStreetMap', timeoutMs: 30000, failureThreshold: 3, cooldownMs: 2 * 60 * 1000 },
+  elevation: { id: 'elevation', name: 'Elevation tiles', timeoutMs: 30000, failureThreshold: 3, cooldownMs: 5 * 60 * 1000 },
+  weather: { id: 'weather', name: 'Open-Meteo', timeoutMs: 10000, failureThreshold: 3, cooldownMs: 60 * 1000 },
+  lightning: { id: 'lightning', name: 'Xweather', timeoutMs: 15000, failureThreshold: 3, cooldownMs: 60 * 1000 },
+  rates: { id: 'rates', name: 'CoinGecko', timeoutMs: 5000, failureThreshold: 3, cooldownMs: 5 * 60 * 1000 },
+  results: { id: 'results', name: 'Tour results', timeoutMs: 15000, failureThreshold: 3, cooldownMs: 5 * 60 * 1000 },
+  lnurl: { id: 'lnurl', name: 'Lightning wallet', timeoutMs: 15000, failureThreshold: 3, cooldownMs: 60 * 1000, perHost: true },
+  blossom: { id: 'blossom', name: 'Blossom server', timeoutMs: 10000, failureThreshold: 3, cooldownMs: 60 * 1000, perHost: true },
+};
+
+const breakers = new Map<string, CircuitBreaker>();
+
+function breakerFor(service: UpstreamService, url: string): CircuitBreaker {
+  const host = service.perHost ? new URL(url).host : '';
+  const key = host ? `${service.id}:${host}` : service.id;
+  let breaker = breakers.get(key);
+  if (!breaker) {
+    breaker = new CircuitBreaker(host ? `${service.name} (${host})` : service.name, service);
+    breakers.set(key, breaker);
+  }
+  return breaker;
+}
+
+/**
+ * `fetch` through a service's circuit breaker, with its timeout added to
+ * any signal the caller passes. Network errors, timeouts and 5xx or 429
+ * responses count against the service; the caller cancelling does not.
+ */
+export function upstreamFetch(service: UpstreamService, url: string, init: RequestInit = {}): Promise<Response> {
+  const timeout = AbortSignal.timeout(service.timeoutMs);
+  const signal = init.signal ? AbortSignal.any([init.signal, timeout]) : timeout;
+
+  return breakerFor(service, url).call(() => fetch(url, { ...init, signal }), {
+    failedResult: response => response.status >= 500 || response.status === 429,
+    ignoreError: () => !!init.signal?.aborted,
+  });
+}
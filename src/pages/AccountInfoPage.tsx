@@ -7,8 +7,16 @@ import { Alert, AlertDescription } from '@/components/ui/alert';
 import { Copy, Download, Eye, EyeOff, Mail, Key, Shield, ExternalLink, Info, Sparkles, X } from 'lucide-react';
 import { useCurrentUser } from '@/hooks/useCurrentUser';
 import RelayStatus from '@/components/RelayStatus';
+import ContentFilterSettings from '@/components/ContentFilterSettings';
+import HandicapCommitteeSettings from '@/components/HandicapCommitteeSettings';
+import { HandicapHistoryCard } from '@/components/golf/HandicapHistoryCard';
+import { PlayerTrendsCard } from '@/components/golf/PlayerTrendsCard';
+import PrivacySettings from '@/components/PrivacySettings';
+import { ScoringTerminalsCard } from '@/components/golf/ScoringTerminalsCard';
+import { ScheduledJobsCard } from '@/components/ScheduledJobsCard';
 import { useToast } from '@/hooks/useToast';
 import { useHandicapCalculation } from '@/hooks/useHandicapCalculation';
+import { useHandicapSettings } from '@/hooks/useHandicapSettings';
 import { HandicapInfoDialog as _HandicapInfoDialog } from '@/components/golf/HandicapInfoDialog';
 import { getEmailUserExportData } from '@/lib/emailAuthService';
 import { EditProfileForm } from '@/components/EditProfileForm';
@@ -20,7 +28,8 @@ export function AccountInfoPage() {
   const { user, isEmailUser, emailUserData } = useCurrentUser();
   const { toast } = useToast();
   const navigate = useNavigate();
-  const { data: _handicapResult } = useHandicapCalculation(user?.pubkey);
+  const { committeePubkeys, requireAttestation } = useHandicapSettings();
+  const { data: _handicapResult } = useHandicapCalculation(user?.pubkey, committeePubkeys, { requireAttestation });
   const [showKeys, setShowKeys] = useState(false);
   const [exportPassword, setExportPassword] = useState('');
   const [showExportForm, setShowExportForm] = useState(false);
@@ -237,6 +246,20 @@ export function AccountInfoPage() {
 
       <RelayStatus />
 
+      <ContentFilterSettings />
+
+      <HandicapCommitteeSettings />
+
+      <HandicapHistoryCard pubkey={user.pubkey} />
+
+      <PlayerTrendsCard pubkey={user.pubkey} />
+
+      <PrivacySettings />
+
+      <ScoringTerminalsCard />
+
+      <ScheduledJobsCard />
+
       {/* Profile Management */}
       <Card>
         <CardHeader>
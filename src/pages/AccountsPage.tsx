@@ -0,0 +1,160 @@
+import React, { useMemo, useState } from 'react';
+import { useParams } from 'react-router-dom';
+import { Download } from 'lucide-react';
+import { Layout } from '@/components/Layout';
+import MobileContainer from '@/components/MobileContainer';
+import { Card, CardContent, CardDescription, CardHeader, CardTitle } from '@/components/ui/card';
+import { Button } from '@/components/ui/button';
+import { Input } from '@/components/ui/input';
+import { Label } from '@/components/ui/label';
+import { Skeleton } from '@/components/ui/skeleton';
+import { useCurrentUser } from '@/hooks/useCurrentUser';
+import { useGolfCourses } from '@/hooks/useGolfCourses';
+import { useClubAccounts } from '@/hooks/useClubAccounts';
+import {
+  LEDGER_ACCOUNTS,
+  accountBalance,
+  downloadLedger,
+  entriesInPeriod,
+  toLedgerCsv,
+  toLedgerJournal,
+} from '@/lib/golf/ledgerEngine';
+
+const dateInput = (date: Date) =>
+  [date.getFullYear(), String(date.getMonth() + 1).padStart(2, '0'), String(date.getDate()).padStart(2, '0')].join('-');
+
+/** Local midnight at the start of a date input's day */
+const startOfDay = (value: string) => {
+  const [y, m, d] = value.split('-').map(Number);
+  return new Date(y, m - 1, d).getTime();
+};
+
+const formatBalance = (balance: Record<string, number>, sign = 1) => {
+  const parts = Object.entries(balance).map(([currency, amount]) => `${(sign * amount).toFixed(currency === 'sats' ? 0 : 2)} ${currency}`);
+  return parts.length ? parts.join(', ') : '—';
+};
+
+export const AccountsPage: React.FC = () => {
+  const { courseId } = useParams<{ courseId: string }>();
+  const { user } = useCurrentUser();
+  const { data: courses = [], isLoading: coursesLoading } = useGolfCourses();
+  const course = courses.find(c => c.id === courseId);
+  const isClub = !!user && user.pubkey === course?.author;
+  const { entries, isLoading } = useClubAccounts(isClub ? course : null);
+
+  const today = new Date();
+  const [from, setFrom] = useState(dateInput(new Date(today.getFullYear(), today.getMonth(), 1)));
+  const [to, setTo] = useState(dateInput(today));
+
+  // The period includes its last day, but nothing that hasn't happened yet
+  const periodStart = startOfDay(from);
+  const periodEnd = Math.min(startOfDay(to) + 24 * 60 * 60 * 1000, Date.now());
+  const period = useMemo(() => entriesInPeriod(entries, periodStart, periodEnd), [entries, periodStart, periodEnd]);
+
+  if (coursesLoading) {
+    return (
+      <Layout>
+        <MobileContainer className="py-4">
+          <Skeleton className="h-32 w-full" />
+        </MobileContainer>
+      </Layout>
+    );
+  }
+
+  if (!course || !isClub) {
+    return (
+      <Layout>
+        <MobileContainer className="py-4">
+          <Card>
+            <CardContent className="py-6 text-sm text-muted-foreground">
+              {course ? `Only the club account can see the accounts for ${course.name}.` : 'Course not found.'}
+            </CardContent>
+          </Card>
+        </MobileContainer>
+      </Layout>
+    );
+  }
+
+  const filename = `${course.id}-${from}-to-${to}`;
+  const summary = Object.values(LEDGER_ACCOUNTS).map(account => ({
+    account,
+    movement: formatBalance(accountBalance(period, account, periodEnd)),
+  }));
+
+  return (
+    <Layout>
+      <MobileContainer className="py-4 space-y-4">
+        <Card>
+          <CardHeader>
+            <CardTitle>{course.name}</CardTitle>
+            <CardDescription>Accounts export for the treasurer</CardDescription>
+          </CardHeader>
+          <CardContent className="space-y-4">
+            <div className="grid grid-cols-2 gap-2">
+              <div className="space-y-1">
+                <Label htmlFor="accounts-from">From</Label>
+                <Input id="accounts-from" type="date" value={from} max={to} onChange={(e) => setFrom(e.target.value)} />
+              </div>
+              <div className="space-y-1">
+                <Label htmlFor="accounts-to">To</Label>
+                <Input id="accounts-to" type="date" value={to} min={from} onChange={(e) => setTo(e.target.value)} />
+              </div>
+            </div>
+            <div className="flex gap-2">
+              <Button
+                variant="outline"
+                className="flex-1"
+                disabled={isLoading || period.length === 0}
+                onClick={() => downloadLedger(toLedgerJournal(period), `${filename}.ledger`, 'text/plain')}
+              >
+                <Download className="h-4 w-4 mr-2" />
+                Ledger
+              </Button>
+              <Button
+                variant="outline"
+                className="flex-1"
+                disabled={isLoading || period.length === 0}
+                onClick={() => downloadLedger(toLedgerCsv(period), `${filename}.csv`, 'text/csv')}
+              >
+                <Download className="h-4 w-4 mr-2" />
+                CSV
+              </Button>
+            </div>
+          </CardContent>
+        </Card>
+
+        <Card>
+          <CardHeader>
+            <CardTitle className="text-lg">Period Summary</CardTitle>
+            <CardDescription>
+              {isLoading ? 'Loading…' : `${period.length} ${period.length === 1 ? 'entry' : 'entries'}. Debits are positive, credits negative.`}
+            </CardDescription>
+          </CardHeader>
+          <CardContent className="space-y-1 text-sm">
+            {isLoading ? (
+              <Skeleton className="h-24 w-full" />
+            ) : (
+              <>
+                {summary.map(({ account, movement }) => (
+                  <div key={account} className="flex justify-between gap-4">
+                    <span>{account}</span>
+                    <span className="text-muted-foreground">{movement}</span>
+                  </div>
+                ))}
+                <div className="flex justify-between gap-4 border-t pt-2 font-medium">
+                  <span>Vouchers outstanding at {to}</span>
+                  <span>{formatBalance(accountBalance(entries, LEDGER_ACCOUNTS.vouchers, periodEnd), -1)}</span>
+                </div>
+              </>
+            )}
+            <p className="pt-2 text-xs text-muted-foreground">
+              Round vouchers have no money value and are left out. Prize payouts are the ones paid from this device.
+            </p>
+          </CardContent>
+        </Card>
+      </MobileContainer>
+    </Layout>
+  );
+};
+
+export default AccountsPage;
@@ -0,0 +1,249 @@
+import React, { useState } from 'react';
+import { useParams } from 'react-router-dom';
+import { nip19 } from 'nostr-tools';
+import { Layout } from '@/components/Layout';
+import MobileContainer from '@/components/MobileContainer';
+import { Card, CardContent, CardDescription, CardHeader, CardTitle } from '@/components/ui/card';
+import { Badge } from '@/components/ui/badge';
+import { Button } from '@/components/ui/button';
+import { Input } from '@/components/ui/input';
+import { Label } from '@/components/ui/label';
+import { Skeleton } from '@/components/ui/skeleton';
+import { Textarea } from '@/components/ui/textarea';
+import { Select, SelectContent, SelectItem, SelectTrigger, SelectValue } from '@/components/ui/select';
+import { useAuthor } from '@/hooks/useAuthor';
+import { useCurrentUser } from '@/hooks/useCurrentUser';
+import { useGolfCourses } from '@/hooks/useGolfCourses';
+import { aceCoordinate, useCourseAces } from '@/hooks/useCourseAces';
+import { useToast } from '@/hooks/useToast';
+import { genUserName } from '@/lib/genUserName';
+import { REQUIRED_WITNESSES, type Ace } from '@/lib/golf/aceEngine';
+import { v4 as uuidv4 } from 'uuid';
+
+function toPubkey(value: string): string | null {
+  const trimmed = value.trim();
+  if (/^[a-f0-9]{64}$/i.test(trimmed)) return trimmed.toLowerCase();
+  try {
+    const decoded = nip19.decode(trimmed);
+    return decoded.type === 'npub' ? decoded.data : null;
+  } catch {
+    return null;
+  }
+}
+
+function AceRow({ ace, signedBy, children }: { ace: Ace; signedBy: string[]; children?: React.ReactNode }) {
+  const author = useAuthor(ace.playerPubkey);
+  const name = author.data?.metadata?.name ?? genUserName(ace.playerPubkey);
+  const shot = [ace.yards ? `${ace.yards} yds` : null, ace.club].filter(Boolean).join(' · ');
+
+  return (
+    <div className="flex items-center justify-between gap-2 rounded border p-3">
+      <div className="min-w-0">
+        <div className="text-sm font-medium truncate">{name} · Hole {ace.hole}</div>
+        <div className="text-xs text-muted-foreground">
+          {new Date(ace.createdAt).toLocaleDateString()}
+          {shot && ` · ${shot}`}
+          {` · ${Math.min(signedBy.length, REQUIRED_WITNESSES)} of ${REQUIRED_WITNESSES} witnesses`}
+        </div>
+        {ace.note && <div className="text-xs mt-1">{ace.note}</div>}
+      </div>
+      <div className="flex items-center gap-2">{children}</div>
+    </div>
+  );
+}
+
+function AnnounceButton({ ace, disabled, onAnnounce }: { ace: Ace; disabled: boolean; onAnnounce: (playerName: string) => void }) {
+  const author = useAuthor(ace.playerPubkey);
+  const name = author.data?.metadata?.name ?? genUserName(ace.playerPubkey);
+  return <Button size="sm" variant="outline" disabled={disabled} onClick={() => onAnnounce(name)}>Announce</Button>;
+}
+
+export const AcesPage: React.FC = () => {
+  const { courseId } = useParams<{ courseId: string }>();
+  const { user } = useCurrentUser();
+  const { toast } = useToast();
+  const { data: courses = [], isLoading: coursesLoading } = useGolfCourses();
+  const course = courses.find(c => c.id === courseId);
+  const { data, isLoading, claimAce, witnessAce, announceAce, isPublishing } = useCourseAces(course);
+
+  const [roundId, setRoundId] = useState('');
+  const [hole, setHole] = useState('');
+  const [yards, setYards] = useState('');
+  const [club, setClub] = useState('');
+  const [witnessOne, setWitnessOne] = useState('');
+  const [witnessTwo, setWitnessTwo] = useState('');
+  const [note, setNote] = useState('');
+
+  const isClub = !!user && user.pubkey === course?.author;
+  const announced = new Set(data?.announced ?? []);
+
+  const handleClaim = async () => {
+    const witnesses = [toPubkey(witnessOne), toPubkey(witnessTwo)];
+    if (!hole || witnesses.some(w => !w)) {
+      toast({ title: 'Enter the hole and both witnesses as npub or hex', variant: 'destructive' });
+      return;
+    }
+    try {
+      await claimAce({
+        roundId: roundId.trim() || uuidv4(),
+        hole: parseInt(hole),
+        yards: parseInt(yards) || undefined,
+        club: club.trim() || undefined,
+        witnesses: witnesses as string[],
+        note: note.trim(),
+      });
+      toast({ title: 'Hole in one recorded', description: 'Your witnesses have been asked to sign it.' });
+      setRoundId('');
+      setHole('');
+      setYards('');
+      setClub('');
+      setNote('');
+    } catch (error) {
+      toast({ title: 'Could not record the ace', description: error instanceof Error ? error.message : undefined, variant: 'destructive' });
+    }
+  };
+
+  const handleWitness = async (ace: Ace) => {
+    try {
+      await witnessAce(ace);
+      toast({ title: 'Signed as witness' });
+    } catch (error) {
+      toast({ title: 'Could not sign', description: error instanceof Error ? error.message : undefined, variant: 'destructive' });
+    }
+  };
+
+  const handleAnnounce = async (ace: Ace, playerName: string) => {
+    try {
+      await announceAce({ ace, playerName });
+      toast({ title: 'Ace announced' });
+    } catch (error) {
+      toast({ title: 'Could not announce', description: error instanceof Error ? error.message : undefined, variant: 'destructive' });
+    }
+  };
+
+  if (coursesLoading) {
+    return (
+      <Layout>
+        <MobileContainer className="py-4">
+          <Skeleton className="h-32 w-full" />
+        </MobileContainer>
+      </Layout>
+    );
+  }
+
+  if (!course) {
+    return (
+      <Layout>
+        <MobileContainer className="py-4">
+          <Card>
+            <CardContent className="py-6 text-sm text-muted-foreground">Course not found.</CardContent>
+          </Card>
+        </MobileContainer>
+      </Layout>
+    );
+  }
+
+  const holes = Object.keys(course.holes || {}).map(Number).sort((a, b) => a - b);
+
+  return (
+    <Layout>
+      <MobileContainer className="py-4 space-y-4">
+        <Card>
+          <CardHeader>
+            <CardTitle>⛳ Hole-in-one Wall of Fame</CardTitle>
+            <CardDescription>{course.name}: aces confirmed by {REQUIRED_WITNESSES} witnesses</CardDescription>
+          </CardHeader>
+          <CardContent className="space-y-2">
+            {isLoading ? (
+              <Skeleton className="h-16 w-full" />
+            ) : !data?.wall.length ? (
+              <p className="text-sm text-muted-foreground">No aces yet. Be the first!</p>
+            ) : (
+              data.wall.map(ace => (
+                <AceRow key={aceCoordinate(ace)} ace={ace} signedBy={ace.signedBy}>
+                  {announced.has(aceCoordinate(ace)) ? (
+                    <Badge variant="secondary">Announced</Badge>
+                  ) : isClub ? (
+                    <AnnounceButton ace={ace} disabled={isPublishing} onAnnounce={name => handleAnnounce(ace, name)} />
+                  ) : null}
+                </AceRow>
+              ))
+            )}
+          </CardContent>
+        </Card>
+
+        {!!data?.pending.length && (
+          <Card>
+            <CardHeader>
+              <CardTitle className="text-lg">Awaiting witnesses</CardTitle>
+            </CardHeader>
+            <CardContent className="space-y-2">
+              {data.pending.map(ace => (
+                <AceRow key={aceCoordinate(ace)} ace={ace} signedBy={ace.signedBy}>
+                  {user && ace.witnesses.includes(user.pubkey) && !ace.signedBy.includes(user.pubkey) && (
+                    <Button size="sm" disabled={isPublishing} onClick={() => handleWitness(ace)}>Sign as witness</Button>
+                  )}
+                </AceRow>
+              ))}
+            </CardContent>
+          </Card>
+        )}
+
+        {user && (
+          <Card>
+            <CardHeader>
+              <CardTitle className="text-lg">Record a hole in one</CardTitle>
+              <CardDescription>Name two playing partners who saw it; it goes on the wall once both sign</CardDescription>
+            </CardHeader>
+            <CardContent className="space-y-3">
+              <div className="grid grid-cols-3 gap-2">
+                <div>
+                  <Label>Hole</Label>
+                  <Select value={hole} onValueChange={setHole}>
+                    <SelectTrigger>
+                      <SelectValue placeholder="Hole" />
+                    </SelectTrigger>
+                    <SelectContent>
+                      {holes.map(h => (
+                        <SelectItem key={h} value={String(h)}>Hole {h}</SelectItem>
+                      ))}
+                    </SelectContent>
+                  </Select>
+                </div>
+                <div>
+                  <Label htmlFor="ace-yards">Yards</Label>
+                  <Input id="ace-yards" type="number" min={0} value={yards} onChange={e => setYards(e.target.value)} />
+                </div>
+                <div>
+                  <Label htmlFor="ace-club">Club</Label>
+                  <Input id="ace-club" placeholder="7 iron" value={club} onChange={e => setClub(e.target.value)} />
+                </div>
+              </div>
+              <div>
+                <Label htmlFor="ace-round">Round id (optional)</Label>
+                <Input id="ace-round" value={roundId} onChange={e => setRoundId(e.target.value)} />
+              </div>
+              <div className="grid grid-cols-2 gap-2">
+                <div>
+                  <Label htmlFor="ace-witness-1">Witness</Label>
+                  <Input id="ace-witness-1" placeholder="npub1..." value={witnessOne} onChange={e => setWitnessOne(e.target.value)} />
+                </div>
+                <div>
+                  <Label htmlFor="ace-witness-2">Witness</Label>
+                  <Input id="ace-witness-2" placeholder="npub1..." value={witnessTwo} onChange={e => setWitnessTwo(e.target.value)} />
+                </div>
+              </div>
+              <div>
+                <Label htmlFor="ace-note">Note</Label>
+                <Textarea id="ace-note" value={note} onChange={e => setNote(e.target.value)} />
+              </div>
+              <Button onClick={handleClaim} disabled={isPublishing}>Record Ace</Button>
+            </CardContent>
+          </Card>
+        )}
+      </MobileContainer>
+    </Layout>
+  );
+};
+
+export default AcesPage;
@@ -1,6 +1,6 @@
-import React, { useState, useEffect } from 'react';
+import React, { useState } from 'react';
 import { BadgeService } from '@/lib/golf/badgeSystem';
-import { BadgeDefinition, BadgeAward } from '@/lib/golf/types';
+import { BadgeDefinition } from '@/lib/golf/types';
 import MobileContainer from '@/components/MobileContainer';
 import { Card, CardContent, CardDescription, CardHeader, CardTitle } from '@/components/ui/card';
 import { Badge as BadgeUI } from '@/components/ui/badge';
@@ -8,11 +8,18 @@ import { Button } from '@/components/ui/button';
 import { Tabs, TabsList, TabsTrigger } from '@/components/ui/tabs';
 import { Trophy, Star, Award, Target } from 'lucide-react';
 import { Layout } from '@/components/Layout';
+import { useCurrentUser } from '@/hooks/useCurrentUser';
+import { useAchievements } from '@/hooks/useAchievements';
+import { useToast } from '@/hooks/useToast';
 
 export const AchievementsPage: React.FC = () => {
   const [badgeService] = useState(() => new BadgeService());
   const [allBadges] = useState<BadgeDefinition[]>(badgeService.getBadgeDefinitions());
-  const [earnedBadges, setEarnedBadges] = useState<BadgeAward[]>([]);
+  const { user } = useCurrentUser();
+  const { toast } = useToast();
+  const { data: earnedBadges = [], publishBadge, isPublishing } = useAchievements(user?.pubkey);
+  const earnedRarity = (rarity: BadgeDefinition['rarity']) =>
+    earnedBadges.filter(a => badgeService.getBadgeDefinition(a.badgeId)?.rarity === rarity).length;
   const [selectedCategory, setSelectedCategory] = useState<string>('all');
 
   const categories = [
@@ -41,45 +48,22 @@ export const AchievementsPage: React.FC = () => {
     legendary: <Star className="h-4 w-4 text-yellow-400" />
   };
 
-  // Mock earned badges - in a real app, this would come from Nostr events
-  useEffect(() => {
-    // Simulate loading earned badges
-    const mockEarnedBadges: BadgeAward[] = [
-      {
-        id: 'earned-1',
-        badgeId: 'first-round',
-        playerId: 'current-user',
-        issuedAt: Date.now() - 86400000, // 1 day ago
-        metadata: {
-          badgeName: 'First Round',
-          description: 'Complete your first golf round',
-          icon: '🎉',
-          rarity: 'common'
-        }
-      },
-      {
-        id: 'earned-2',
-        badgeId: 'hole-in-one',
-        playerId: 'current-user',
-        issuedAt: Date.now() - 172800000, // 2 days ago
-        metadata: {
-          badgeName: 'Hole in One',
-          description: 'Score a hole in one',
-          icon: '🏆',
-          rarity: 'legendary'
-        }
-      }
-    ];
-    setEarnedBadges(mockEarnedBadges);
-  }, []);
-
   const isBadgeEarned = (badgeId: string): boolean => {
     return earnedBadges.some(award => award.badgeId === badgeId);
   };
 
   const getEarnedDate = (badgeId: string): string | null => {
     const award = earnedBadges.find(a => a.badgeId === badgeId);
-    return award ? new Date(award.issuedAt).toLocaleDateString() : null;
+    return award ? new Date(award.earnedAt).toLocaleDateString() : null;
+  };
+
+  const handlePublish = async (badge: BadgeDefinition) => {
+    try {
+      await publishBadge(badge.id);
+      toast({ title: `${badge.name} published`, description: 'Nostr clients that show badges can now display it.' });
+    } catch (error) {
+      toast({ title: 'Could not publish badge', description: error instanceof Error ? error.message : undefined, variant: 'destructive' });
+    }
   };
 
   return (
@@ -114,7 +98,7 @@ export const AchievementsPage: React.FC = () => {
             <Card>
               <CardContent className="p-4 text-center">
                 <div className="text-2xl font-bold text-yellow-600">
-                  {earnedBadges.filter(b => b.metadata.rarity === 'legendary').length}
+                  {earnedRarity('legendary')}
                 </div>
                 <div className="text-sm text-gray-600 dark:text-gray-400">
                   Legendary
@@ -125,7 +109,7 @@ export const AchievementsPage: React.FC = () => {
             <Card>
               <CardContent className="p-4 text-center">
                 <div className="text-2xl font-bold text-amber-600">
-                  {earnedBadges.filter(b => b.metadata.rarity === 'epic').length}
+                  {earnedRarity('epic')}
                 </div>
                 <div className="text-sm text-gray-600 dark:text-gray-400">
                   Epic
@@ -209,8 +193,13 @@ export const AchievementsPage: React.FC = () => {
                         </div>
 
                         {isEarned ? (
-                          <Button variant="outline" size="sm">
-                            Share Achievement
+                          <Button
+                            variant="outline"
+                            size="sm"
+                            disabled={isPublishing || earnedBadges.some(a => a.badgeId === badge.id && a.published)}
+                            onClick={() => handlePublish(badge)}
+                          >
+                            {earnedBadges.some(a => a.badgeId === badge.id && a.published) ? 'Published' : 'Publish Badge'}
                           </Button>
                         ) : (
                           <Button variant="outline" size="sm" disabled>
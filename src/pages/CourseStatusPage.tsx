@@ -0,0 +1,242 @@
+import React, { useState } from 'react';
+import { Link, useParams } from 'react-router-dom';
+import { Layout } from '@/components/Layout';
+import { ConditionReportsCard } from '@/components/golf/ConditionReportsCard';
+import { DaylightCard } from '@/components/golf/DaylightCard';
+import { GreenFeeCard } from '@/components/golf/GreenFeeCard';
+import { GreenReportCard } from '@/components/golf/GreenReportCard';
+import { HoleStatsCard } from '@/components/golf/HoleStatsCard';
+import { RateCardEditor } from '@/components/golf/RateCardEditor';
+import { StrokeIndexCard } from '@/components/golf/StrokeIndexCard';
+import MobileContainer from '@/components/MobileContainer';
+import { Badge } from '@/components/ui/badge';
+import { Button } from '@/components/ui/button';
+import { Card, CardContent, CardDescription, CardHeader, CardTitle } from '@/components/ui/card';
+import { Input } from '@/components/ui/input';
+import { Label } from '@/components/ui/label';
+import { Skeleton } from '@/components/ui/skeleton';
+import { Textarea } from '@/components/ui/textarea';
+import { Select, SelectContent, SelectItem, SelectTrigger, SelectValue } from '@/components/ui/select';
+import { useCurrentUser } from '@/hooks/useCurrentUser';
+import { useCourseNotices } from '@/hooks/useCourseNotices';
+import { useGolfCourses } from '@/hooks/useGolfCourses';
+import { useToast } from '@/hooks/useToast';
+import { NOTICE_TYPE_NAMES, noticesToIcs, type CourseNotice, type CourseStatus, type NoticeType } from '@/lib/golf/maintenanceEngine';
+import { downloadIcs } from '@/lib/golf/icsCalendar';
+import { v4 as uuidv4 } from 'uuid';
+
+const STATUS_LABELS: Record<CourseStatus, string> = {
+  open: 'Open',
+  restricted: 'Open with restrictions',
+  closed: 'Closed',
+};
+
+function formatRange(notice: CourseNotice): string {
+  const from = new Date(notice.startsAt);
+  const to = new Date(notice.endsAt);
+  return `${from.toLocaleString()} – ${to.toLocaleString()}`;
+}
+
+export const CourseStatusPage: React.FC = () => {
+  const { courseId } = useParams<{ courseId: string }>();
+  const { user } = useCurrentUser();
+  const { toast } = useToast();
+  const { data: courses = [], isLoading: coursesLoading } = useGolfCourses();
+  const course = courses.find(c => c.id === courseId);
+  const { notices, upcoming, status, isLoading, postNotice, cancelNotice, isPublishing } = useCourseNotices(course);
+
+  const [type, setType] = useState<NoticeType>('aeration');
+  const [title, setTitle] = useState('');
+  const [details, setDetails] = useState('');
+  const [starts, setStarts] = useState('');
+  const [ends, setEnds] = useState('');
+  const [holes, setHoles] = useState('');
+
+  const isClub = !!user && user.pubkey === course?.author;
+
+  const handlePost = async () => {
+    const startsAt = new Date(starts).getTime();
+    const endsAt = new Date(ends).getTime();
+    if (!title.trim() || isNaN(startsAt) || isNaN(endsAt) || endsAt <= startsAt) {
+      toast({ title: 'Enter a title and a start before the end', variant: 'destructive' });
+      return;
+    }
+    try {
+      await postNotice({
+        noticeId: uuidv4(),
+        type,
+        title: title.trim(),
+        details: details.trim(),
+        startsAt,
+        endsAt,
+        holes: holes.split(/[\s,]+/).map(h => parseInt(h)).filter(h => h > 0),
+        cancelled: false,
+      });
+      toast({ title: 'Notice posted' });
+      setTitle('');
+      setDetails('');
+      setHoles('');
+    } catch (error) {
+      toast({ title: 'Could not post the notice', description: error instanceof Error ? error.message : undefined, variant: 'destructive' });
+    }
+  };
+
+  const handleCancel = async (notice: CourseNotice) => {
+    try {
+      await cancelNotice(notice);
+      toast({ title: 'Notice cancelled' });
+    } catch (error) {
+      toast({ title: 'Could not cancel', description: error instanceof Error ? error.message : undefined, variant: 'destructive' });
+    }
+  };
+
+  const downloadCalendar = () => {
+    if (!course) return;
+    downloadIcs(noticesToIcs(notices, `${course.name} notices`), `${course.id}-notices.ics`);
+  };
+
+  if (coursesLoading) {
+    return (
+      <Layout>
+        <MobileContainer className="py-4">
+          <Skeleton className="h-32 w-full" />
+        </MobileContainer>
+      </Layout>
+    );
+  }
+
+  if (!course) {
+    return (
+      <Layout>
+        <MobileContainer className="py-4">
+          <Card>
+            <CardContent className="py-6 text-sm text-muted-foreground">Course not found.</CardContent>
+          </Card>
+        </MobileContainer>
+      </Layout>
+    );
+  }
+
+  return (
+    <Layout>
+      <MobileContainer className="py-4 space-y-4">
+        <Card>
+          <CardHeader className="flex flex-row items-center justify-between space-y-0">
+            <div>
+              <CardTitle>{course.name}</CardTitle>
+              <CardDescription>
+                Course status ·{' '}
+                <Link to={`/courses/${course.id}/rentals`} className="text-primary hover:underline">Equipment hire</Link>
+                {isClub && (
+                  <>
+                    {' · '}
+                    <Link to={`/courses/${course.id}/occupancy`} className="text-primary hover:underline">Occupancy</Link>
+                    {' · '}
+                    <Link to={`/courses/${course.id}/vouchers`} className="text-primary hover:underline">Vouchers</Link>
+                    {' · '}
+                    <Link to={`/courses/${course.id}/accounts`} className="text-primary hover:underline">Accounts</Link>
+                  </>
+                )}
+              </CardDescription>
+            </div>
+            <Badge variant={status === 'closed' ? 'destructive' : status === 'restricted' ? 'secondary' : 'default'}>
+              {STATUS_LABELS[status]}
+            </Badge>
+          </CardHeader>
+          <CardContent className="space-y-2">
+            {isLoading ? (
+              <Skeleton className="h-16 w-full" />
+            ) : upcoming.length === 0 ? (
+              <p className="text-sm text-muted-foreground">No maintenance or closures scheduled.</p>
+            ) : (
+              upcoming.map(notice => (
+                <div key={notice.noticeId} className="flex items-start justify-between gap-2 rounded border p-3">
+                  <div className="min-w-0">
+                    <div className="text-sm font-medium">
+                      {NOTICE_TYPE_NAMES[notice.type]}: {notice.title}
+                      {notice.holes.length > 0 && ` (holes ${notice.holes.join(', ')})`}
+                    </div>
+                    <div className="text-xs text-muted-foreground">{formatRange(notice)}</div>
+                    {notice.details && <div className="text-xs mt-1">{notice.details}</div>}
+                  </div>
+                  {isClub && (
+                    <Button size="sm" variant="outline" disabled={isPublishing} onClick={() => handleCancel(notice)}>
+                      Cancel
+                    </Button>
+                  )}
+                </div>
+              ))
+            )}
+            <Button variant="outline" className="w-full" onClick={downloadCalendar} disabled={notices.length === 0}>
+              Add to Calendar (.ics)
+            </Button>
+          </CardContent>
+        </Card>
+
+        <GreenReportCard course={course} />
+
+        <DaylightCard course={course} />
+
+        <GreenFeeCard course={course} />
+
+        {isClub && <RateCardEditor course={course} />}
+
+        {isClub && <StrokeIndexCard course={course} />}
+
+        <ConditionReportsCard course={course} />
+
+        <HoleStatsCard course={course} />
+
+        {isClub && (
+          <Card>
+            <CardHeader>
+              <CardTitle className="text-lg">Post a Notice</CardTitle>
+            </CardHeader>
+            <CardContent className="space-y-3">
+              <div className="grid grid-cols-2 gap-3">
+                <div className="space-y-2">
+                  <Label>Type</Label>
+                  <Select value={type} onValueChange={(value) => setType(value as NoticeType)}>
+                    <SelectTrigger>
+                      <SelectValue />
+                    </SelectTrigger>
+                    <SelectContent>
+                      {(Object.keys(NOTICE_TYPE_NAMES) as NoticeType[]).map(t => (
+                        <SelectItem key={t} value={t}>{NOTICE_TYPE_NAMES[t]}</SelectItem>
+                      ))}
+                    </SelectContent>
+                  </Select>
+                </div>
+                <div className="space-y-2">
+                  <Label htmlFor="notice-holes">Holes (blank for all)</Label>
+                  <Input id="notice-holes" value={holes} onChange={(e) => setHoles(e.target.value)} placeholder="3, 4, 5" />
+                </div>
+              </div>
+              <div className="space-y-2">
+                <Label htmlFor="notice-title">Title</Label>
+                <Input id="notice-title" value={title} onChange={(e) => setTitle(e.target.value)} placeholder="Greens hollow-tined" />
+              </div>
+              <div className="grid grid-cols-2 gap-3">
+                <div className="space-y-2">
+                  <Label htmlFor="notice-starts">Starts</Label>
+                  <Input id="notice-starts" type="datetime-local" value={starts} onChange={(e) => setStarts(e.target.value)} />
+                </div>
+                <div className="space-y-2">
+                  <Label htmlFor="notice-ends">Ends</Label>
+                  <Input id="notice-ends" type="datetime-local" value={ends} onChange={(e) => setEnds(e.target.value)} />
+                </div>
+              </div>
+              <div className="space-y-2">
+                <Label htmlFor="notice-details">Details</Label>
+                <Textarea id="notice-details" value={details} onChange={(e) => setDetails(e.target.value)} rows={3} />
+              </div>
+              <Button className="w-full" onClick={handlePost} disabled={isPublishing}>Post Notice</Button>
+            </CardContent>
+          </Card>
+        )}
+      </MobileContainer>
+    </Layout>
+  );
+};
+
+export default CourseStatusPage;
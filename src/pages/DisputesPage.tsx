@@ -0,0 +1,245 @@
+import React, { useState } from 'react';
+import { nip19 } from 'nostr-tools';
+import { Layout } from '@/components/Layout';
+import MobileContainer from '@/components/MobileContainer';
+import { Card, CardContent, CardDescription, CardHeader, CardTitle } from '@/components/ui/card';
+import { Badge } from '@/components/ui/badge';
+import { Button } from '@/components/ui/button';
+import { Input } from '@/components/ui/input';
+import { Label } from '@/components/ui/label';
+import { Textarea } from '@/components/ui/textarea';
+import { Skeleton } from '@/components/ui/skeleton';
+import { Select, SelectContent, SelectItem, SelectTrigger, SelectValue } from '@/components/ui/select';
+import { useCurrentUser } from '@/hooks/useCurrentUser';
+import { useDisputes } from '@/hooks/useDisputes';
+import { useUploadFile } from '@/hooks/useUploadFile';
+import { useToast } from '@/hooks/useToast';
+import {
+  parseCorrections,
+  type DisputeStatus,
+  type DisputeSubject,
+  type DisputeWithStatus,
+  type RulingDecision,
+} from '@/lib/golf/disputeEngine';
+
+const statusStyles: Record<DisputeStatus, { label: string; variant: 'default' | 'secondary' | 'destructive' | 'outline' }> = {
+  open: { label: 'Open', variant: 'default' },
+  dismissed: { label: 'Dismissed', variant: 'outline' },
+  corrected: { label: 'Corrected', variant: 'secondary' },
+  void: { label: 'Void', variant: 'destructive' },
+};
+
+function toPubkey(value: string): string | null {
+  const trimmed = value.trim();
+  if (/^[a-f0-9]{64}$/i.test(trimmed)) return trimmed.toLowerCase();
+  try {
+    const decoded = nip19.decode(trimmed);
+    return decoded.type === 'npub' ? decoded.data : null;
+  } catch {
+    return null;
+  }
+}
+
+function RulingForm({ dispute }: { dispute: DisputeWithStatus }) {
+  const { recordRuling, isPublishing } = useDisputes(undefined);
+  const { toast } = useToast();
+  const [decision, setDecision] = useState<RulingDecision>('dismissed');
+  const [player, setPlayer] = useState(dispute.players[0] ?? '');
+  const [corrections, setCorrections] = useState('');
+  const [explanation, setExplanation] = useState('');
+
+  const handleSubmit = async () => {
+    const parsed = decision === 'corrected' ? parseCorrections(player, corrections) : [];
+    if (parsed === null) {
+      toast({ title: 'Corrections should look like "7:5, 12:4"', variant: 'destructive' });
+      return;
+    }
+    try {
+      await recordRuling({
+        roundId: dispute.roundId,
+        disputeId: dispute.disputeId,
+        players: dispute.players,
+        decision,
+        corrections: parsed,
+        explanation,
+      });
+      toast({ title: 'Ruling recorded' });
+    } catch (error) {
+      toast({ title: 'Could not record ruling', description: error instanceof Error ? error.message : undefined, variant: 'destructive' });
+    }
+  };
+
+  return (
+    <div className="space-y-2 border-t pt-3">
+      <Select value={decision} onValueChange={v => setDecision(v as RulingDecision)}>
+        <SelectTrigger>
+          <SelectValue />
+        </SelectTrigger>
+        <SelectContent>
+          <SelectItem value="dismissed">Dismiss: the card stands</SelectItem>
+          <SelectItem value="corrected">Correct scores</SelectItem>
+          <SelectItem value="void">Void the card</SelectItem>
+        </SelectContent>
+      </Select>
+      {decision === 'corrected' && (
+        <>
+          {dispute.players.length > 1 && (
+            <Select value={player} onValueChange={setPlayer}>
+              <SelectTrigger>
+                <SelectValue />
+              </SelectTrigger>
+              <SelectContent>
+                {dispute.players.map(p => (
+                  <SelectItem key={p} value={p}>{nip19.npubEncode(p).slice(0, 16)}…</SelectItem>
+                ))}
+              </SelectContent>
+            </Select>
+          )}
+          <Input placeholder="hole:strokes, e.g. 7:5, 12:4" value={corrections} onChange={e => setCorrections(e.target.value)} />
+        </>
+      )}
+      <Textarea placeholder="Explanation for the players" value={explanation} onChange={e => setExplanation(e.target.value)} rows={2} />
+      <Button size="sm" onClick={handleSubmit} disabled={isPublishing}>Record ruling</Button>
+    </div>
+  );
+}
+
+function RaiseDisputeForm() {
+  const { raiseDispute, isPublishing } = useDisputes(undefined);
+  const { mutateAsync: uploadFile, isPending: isUploading } = useUploadFile();
+  const { toast } = useToast();
+  const [roundId, setRoundId] = useState('');
+  const [player, setPlayer] = useState('');
+  const [subject, setSubject] = useState<DisputeSubject>('score');
+  const [reason, setReason] = useState('');
+  const [evidence, setEvidence] = useState<string[]>([]);
+
+  const handleUpload = async (file: File | undefined) => {
+    if (!file) return;
+    try {
+      const tags = await uploadFile(file);
+      setEvidence(prev => [...prev, tags[0][1]]);
+    } catch (error) {
+      toast({ title: 'Upload failed', description: error instanceof Error ? error.message : undefined, variant: 'destructive' });
+    }
+  };
+
+  const handleSubmit = async () => {
+    const playerPubkey = toPubkey(player);
+    if (!roundId.trim() || !playerPubkey) {
+      toast({ title: 'Enter the round id, and the player as npub or hex', variant: 'destructive' });
+      return;
+    }
+    try {
+      await raiseDispute({
+        roundId: roundId.trim(),
+        subject,
+        players: [playerPubkey],
+        reason,
+        evidence,
+      });
+      toast({ title: 'Dispute raised', description: 'The player and committee have been notified.' });
+      setReason('');
+      setEvidence([]);
+    } catch (error) {
+      toast({ title: 'Could not raise dispute', description: error instanceof Error ? error.message : undefined, variant: 'destructive' });
+    }
+  };
+
+  return (
+    <Card>
+      <CardHeader>
+        <CardTitle className="text-lg">Raise a dispute</CardTitle>
+        <CardDescription>Contest a score or match result and ask your handicap committee to rule</CardDescription>
+      </CardHeader>
+      <CardContent className="space-y-3">
+        <div className="grid grid-cols-2 gap-2">
+          <div>
+            <Label htmlFor="dispute-round">Round id</Label>
+            <Input id="dispute-round" value={roundId} onChange={e => setRoundId(e.target.value)} />
+          </div>
+          <div>
+            <Label>Subject</Label>
+            <Select value={subject} onValueChange={v => setSubject(v as DisputeSubject)}>
+              <SelectTrigger>
+                <SelectValue />
+              </SelectTrigger>
+              <SelectContent>
+                <SelectItem value="score">Score</SelectItem>
+                <SelectItem value="match">Match result</SelectItem>
+              </SelectContent>
+            </Select>
+          </div>
+        </div>
+        <div>
+          <Label htmlFor="dispute-player">Player</Label>
+          <Input id="dispute-player" placeholder="npub1..." value={player} onChange={e => setPlayer(e.target.value)} />
+        </div>
+        <div>
+          <Label htmlFor="dispute-reason">What happened</Label>
+          <Textarea id="dispute-reason" value={reason} onChange={e => setReason(e.target.value)} rows={3} />
+        </div>
+        <div>
+          <Label htmlFor="dispute-evidence">Evidence</Label>
+          <Input id="dispute-evidence" type="file" disabled={isUploading} onChange={e => handleUpload(e.target.files?.[0])} />
+          {evidence.length > 0 && (
+            <p className="text-xs text-muted-foreground mt-1">{evidence.length} file{evidence.length === 1 ? '' : 's'} attached</p>
+          )}
+        </div>
+        <Button onClick={handleSubmit} disabled={isPublishing || isUploading}>Raise dispute</Button>
+      </CardContent>
+    </Card>
+  );
+}
+
+export const DisputesPage: React.FC = () => {
+  const { user } = useCurrentUser();
+  const { data: disputes = [], isLoading } = useDisputes(user?.pubkey);
+
+  return (
+    <Layout>
+      <MobileContainer className="py-4 space-y-4">
+        <Card>
+          <CardHeader>
+            <CardTitle>Disputes</CardTitle>
+            <CardDescription>Contested scores and match results, and the committee's rulings</CardDescription>
+          </CardHeader>
+          <CardContent className="space-y-4">
+            {!user ? (
+              <p className="text-sm text-muted-foreground">Log in to see your disputes.</p>
+            ) : isLoading ? (
+              <Skeleton className="h-16 w-full" />
+            ) : disputes.length === 0 ? (
+              <p className="text-sm text-muted-foreground">No disputes involve you.</p>
+            ) : (
+              disputes.map(dispute => (
+                <div key={`${dispute.raisedBy}:${dispute.disputeId}`} className="rounded-md border p-3 space-y-2">
+                  <div className="flex items-center justify-between gap-2">
+                    <div className="text-sm font-medium">
+                      {dispute.subject === 'match' ? 'Match result' : 'Score'} · round {dispute.roundId.slice(0, 8)}
+                    </div>
+                    <Badge variant={statusStyles[dispute.status].variant}>{statusStyles[dispute.status].label}</Badge>
+                  </div>
+                  <p className="text-sm">{dispute.reason}</p>
+                  {dispute.evidence.map(url => (
+                    <a key={url} href={url} target="_blank" rel="noreferrer" className="block text-xs text-primary underline truncate">
+                      {url}
+                    </a>
+                  ))}
+                  {dispute.ruling && (
+                    <p className="text-sm text-muted-foreground">Ruling: {dispute.ruling.explanation || dispute.ruling.decision}</p>
+                  )}
+                  {dispute.status === 'open' && dispute.committee.includes(user.pubkey) && <RulingForm dispute={dispute} />}
+                </div>
+              ))
+            )}
+          </CardContent>
+        </Card>
+
+        {user && <RaiseDisputeForm />}
+      </MobileContainer>
+    </Layout>
+  );
+};
+
+export default DisputesPage;
@@ -0,0 +1,273 @@
+import React, { useState } from 'react';
+import { useParams } from 'react-router-dom';
+import { Layout } from '@/components/Layout';
+import MobileContainer from '@/components/MobileContainer';
+import { ZapButton } from '@/components/ZapButton';
+import { PrizePayoutDialog } from '@/components/golf/PrizePayoutDialog';
+import { Badge } from '@/components/ui/badge';
+import { Button } from '@/components/ui/button';
+import { Card, CardContent, CardDescription, CardHeader, CardTitle } from '@/components/ui/card';
+import { Input } from '@/components/ui/input';
+import { Label } from '@/components/ui/label';
+import { Select, SelectContent, SelectItem, SelectTrigger, SelectValue } from '@/components/ui/select';
+import { Skeleton } from '@/components/ui/skeleton';
+import { Textarea } from '@/components/ui/textarea';
+import { useAuthor } from '@/hooks/useAuthor';
+import { useCurrentUser } from '@/hooks/useCurrentUser';
+import { useFantasyLeague } from '@/hooks/useFantasyLeague';
+import { useToast } from '@/hooks/useToast';
+import { genUserName } from '@/lib/genUserName';
+import {
+  DEFAULT_FANTASY_PAYOUT,
+  DEFAULT_PICKS_PER_MAJOR,
+  FANTASY_SOURCES,
+  fantasySource,
+  type FantasyMajor,
+  type FantasyStanding,
+} from '@/lib/golf/fantasyEngine';
+
+function StandingRow({ standing, paid, isMember }: { standing: FantasyStanding; paid: boolean; isMember: boolean }) {
+  const author = useAuthor(standing.member);
+  const name = author.data?.metadata?.name ?? genUserName(standing.member);
+
+  return (
+    <div className={`flex items-center gap-3 rounded border p-3 ${isMember ? 'border-primary' : ''}`}>
+      <span className="w-6 text-center font-bold">{standing.position}</span>
+      <div className="min-w-0 flex-1">
+        <div className="text-sm font-medium truncate">{name}</div>
+        <div className="text-xs text-muted-foreground">{paid ? 'Entry paid' : 'Entry not paid'}</div>
+      </div>
+      <Badge variant="secondary">{standing.points} pts</Badge>
+    </div>
+  );
+}
+
+function MajorCard({ major, league }: { major: FantasyMajor; league: ReturnType<typeof useFantasyLeague> }) {
+  const { toast } = useToast();
+  const current = league.myPicks(major.eventId);
+  const [pros, setPros] = useState(current?.pros.join(', ') ?? '');
+  const [pasted, setPasted] = useState('');
+  const locked = Date.now() >= major.startsAt;
+  const results = league.league?.results[major.eventId];
+  const source = fantasySource(league.league?.source ?? 'manual');
+
+  const run = async (action: () => Promise<unknown>, success: string) => {
+    try {
+      await action();
+      toast({ title: success });
+    } catch (error) {
+      toast({ title: 'Something went wrong', description: error instanceof Error ? error.message : 'Please try again', variant: 'destructive' });
+    }
+  };
+
+  return (
+    <Card>
+      <CardHeader>
+        <div className="flex items-center justify-between gap-2">
+          <CardTitle className="text-lg">{major.name}</CardTitle>
+          <Badge variant={results ? 'default' : 'outline'}>{results ? 'Final' : locked ? 'In play' : 'Picks open'}</Badge>
+        </div>
+        <CardDescription>
+          {locked ? 'Picks locked' : 'Picks lock'} {new Date(major.startsAt).toLocaleString()}
+          {current && ` · your picks: ${current.pros.join(', ')}`}
+        </CardDescription>
+      </CardHeader>
+      <CardContent className="space-y-3">
+        {!locked && (
+          <>
+            <div className="space-y-2">
+              <Label htmlFor={`picks-${major.eventId}`}>Your {league.league?.picksPerMajor} pros, separated by commas</Label>
+              <Input id={`picks-${major.eventId}`} value={pros} onChange={(e) => setPros(e.target.value)} placeholder="Scottie Scheffler, Rory McIlroy" />
+            </div>
+            <Button
+              className="w-full"
+              disabled={league.isPublishing}
+              onClick={() => run(() => league.pick(major.eventId, pros.split(',').map(p => p.trim()).filter(Boolean)), 'Picks saved')}
+            >
+              Save Picks
+            </Button>
+          </>
+        )}
+        {league.isCommissioner && locked && (
+          source.url ? (
+            <Button variant="outline" className="w-full" disabled={league.isPublishing} onClick={() => run(() => league.loadResults(major.eventId), 'Results loaded')}>
+              {results ? 'Reload' : 'Load'} Results from {source.name}
+            </Button>
+          ) : (
+            <>
+              <Textarea value={pasted} onChange={(e) => setPasted(e.target.value)} placeholder={'Pos,Player\n1,Scottie Scheffler\nT2,Rory McIlroy\nCUT,Jon Rahm'} rows={4} />
+              <Button variant="outline" className="w-full" disabled={league.isPublishing || !pasted.trim()} onClick={() => run(() => league.loadResults(major.eventId, pasted), 'Results loaded')}>
+                Save Results
+              </Button>
+            </>
+          )
+        )}
+      </CardContent>
+    </Card>
+  );
+}
+
+export const FantasyLeaguePage: React.FC = () => {
+  const { leagueId } = useParams<{ leagueId: string }>();
+  const { user } = useCurrentUser();
+  const league = useFantasyLeague(leagueId);
+  const { toast } = useToast();
+  const [payoutOpen, setPayoutOpen] = useState(false);
+
+  const [name, setName] = useState('');
+  const [fee, setFee] = useState('1000');
+  const [picksPerMajor, setPicksPerMajor] = useState(String(DEFAULT_PICKS_PER_MAJOR));
+  const [sourceId, setSourceId] = useState(FANTASY_SOURCES[0].id);
+
+  const [majorId, setMajorId] = useState('');
+  const [majorName, setMajorName] = useState('');
+  const [majorStart, setMajorStart] = useState('');
+
+  const handleError = (title: string) => (error: unknown) => {
+    toast({ title, description: error instanceof Error ? error.message : 'Please try again', variant: 'destructive' });
+  };
+
+  const handleCreate = () => {
+    if (!name.trim() || !(Number(fee) >= 0) || !(Number(picksPerMajor) > 0)) {
+      toast({ title: 'Check the details', description: 'Give the league a name, an entry fee and the number of picks.', variant: 'destructive' });
+      return;
+    }
+    league.create({
+      name: name.trim(),
+      entryFeeSats: Number(fee),
+      picksPerMajor: Number(picksPerMajor),
+      payout: DEFAULT_FANTASY_PAYOUT,
+      source: sourceId,
+    }).catch(handleError('Could not create the league'));
+  };
+
+  const handleAddMajor = () => {
+    if (!majorId.trim() || !majorName.trim() || !majorStart) {
+      toast({ title: 'Check the details', description: 'Give the major its id, name and first tee time.', variant: 'destructive' });
+      return;
+    }
+    league.addMajor({ eventId: majorId.trim(), name: majorName.trim(), startsAt: new Date(majorStart).getTime() })
+      .then(() => {
+        setMajorId('');
+        setMajorName('');
+        setMajorStart('');
+      })
+      .catch(handleError('Could not add the major'));
+  };
+
+  const details = league.league;
+  const pot = details ? details.entryFeeSats * league.paid.size : 0;
+
+  return (
+    <Layout>
+      <MobileContainer className="py-4 space-y-4">
+        {league.isLoading ? (
+          <Skeleton className="h-40 w-full" />
+        ) : !details ? (
+          <Card>
+            <CardHeader>
+              <CardTitle>New Fantasy League</CardTitle>
+              <CardDescription>Members pick pros for each major and the entry pot goes to the top of the standings</CardDescription>
+            </CardHeader>
+            <CardContent className="space-y-4">
+              {!user ? (
+                <p className="text-sm text-muted-foreground">Log in to start a fantasy league.</p>
+              ) : (
+                <>
+                  <div className="space-y-2">
+                    <Label htmlFor="fantasy-name">Name</Label>
+                    <Input id="fantasy-name" value={name} onChange={(e) => setName(e.target.value)} placeholder="Club Majors League" />
+                  </div>
+                  <div className="grid grid-cols-2 gap-3">
+                    <div className="space-y-2">
+                      <Label htmlFor="fantasy-fee">Entry (sats)</Label>
+                      <Input id="fantasy-fee" type="number" min={0} value={fee} onChange={(e) => setFee(e.target.value)} />
+                    </div>
+                    <div className="space-y-2">
+                      <Label htmlFor="fantasy-picks">Picks per major</Label>
+                      <Input id="fantasy-picks" type="number" min={1} value={picksPerMajor} onChange={(e) => setPicksPerMajor(e.target.value)} />
+                    </div>
+                  </div>
+                  <div className="space-y-2">
+                    <Label>Results from</Label>
+                    <Select value={sourceId} onValueChange={setSourceId}>
+                      <SelectTrigger>
+                        <SelectValue />
+                      </SelectTrigger>
+                      <SelectContent>
+                        {FANTASY_SOURCES.map(s => <SelectItem key={s.id} value={s.id}>{s.name}</SelectItem>)}
+                      </SelectContent>
+                    </Select>
+                  </div>
+                  <Button className="w-full" onClick={handleCreate} disabled={league.isPublishing}>Start League</Button>
+                </>
+              )}
+            </CardContent>
+          </Card>
+        ) : (
+          <>
+            <Card>
+              <CardHeader>
+                <CardTitle>{details.name}</CardTitle>
+                <CardDescription>
+                  {details.entryFeeSats.toLocaleString()} sats entry · pot {pot.toLocaleString()} sats ·
+                  pays {details.payout.map(p => `${p}%`).join(' / ')}
+                </CardDescription>
+              </CardHeader>
+              <CardContent className="space-y-2">
+                {user && !league.paid.has(user.pubkey) && league.event && details.entryFeeSats > 0 && (
+                  <div className="flex items-center justify-between gap-2 rounded border p-3">
+                    <span className="text-sm">Zap {details.entryFeeSats.toLocaleString()} sats to the league to enter the pot</span>
+                    <ZapButton target={league.event} showCount={false} />
+                  </div>
+                )}
+                {league.standings.length === 0 ? (
+                  <p className="text-sm text-muted-foreground">No picks yet.</p>
+                ) : (
+                  league.standings.map(standing => (
+                    <StandingRow key={standing.member} standing={standing} paid={league.paid.has(standing.member)} isMember={standing.member === user?.pubkey} />
+                  ))
+                )}
+                {league.isCommissioner && league.isFinished && league.prizes.length > 0 && (
+                  <Button className="w-full" onClick={() => setPayoutOpen(true)}>Pay Out Pot</Button>
+                )}
+              </CardContent>
+            </Card>
+
+            {details.majors.map(major => <MajorCard key={major.eventId} major={major} league={league} />)}
+
+            {league.isCommissioner && (
+              <Card>
+                <CardHeader>
+                  <CardTitle className="text-lg">Add a Major</CardTitle>
+                  <CardDescription>Use {fantasySource(details.source).name}'s id for the event so its results can be loaded</CardDescription>
+                </CardHeader>
+                <CardContent className="space-y-3">
+                  <div className="grid grid-cols-2 gap-3">
+                    <div className="space-y-2">
+                      <Label htmlFor="major-id">Event id</Label>
+                      <Input id="major-id" value={majorId} onChange={(e) => setMajorId(e.target.value)} placeholder="401580344" />
+                    </div>
+                    <div className="space-y-2">
+                      <Label htmlFor="major-name">Name</Label>
+                      <Input id="major-name" value={majorName} onChange={(e) => setMajorName(e.target.value)} placeholder="The Masters" />
+                    </div>
+                  </div>
+                  <div className="space-y-2">
+                    <Label htmlFor="major-start">First tee time</Label>
+                    <Input id="major-start" type="datetime-local" value={majorStart} onChange={(e) => setMajorStart(e.target.value)} />
+                  </div>
+                  <Button className="w-full" onClick={handleAddMajor} disabled={league.isPublishing}>Add Major</Button>
+                </CardContent>
+              </Card>
+            )}
+
+            <PrizePayoutDialog open={payoutOpen} onOpenChange={setPayoutOpen} prizes={league.prizes} />
+          </>
+        )}
+      </MobileContainer>
+    </Layout>
+  );
+};
+
+export default FantasyLeaguePage;
@@ -0,0 +1,87 @@
+import React from 'react';
+import { Link } from 'react-router-dom';
+import { nip19 } from 'nostr-tools';
+import type { NostrEvent } from '@nostrify/nostrify';
+import { formatDistanceToNow } from 'date-fns';
+import { Layout } from '@/components/Layout';
+import MobileContainer from '@/components/MobileContainer';
+import { NoteContent } from '@/components/NoteContent';
+import { ModerationMenu } from '@/components/ModerationMenu';
+import { Avatar, AvatarFallback, AvatarImage } from '@/components/ui/avatar';
+import { Button } from '@/components/ui/button';
+import { Card, CardContent, CardDescription, CardHeader, CardTitle } from '@/components/ui/card';
+import { Skeleton } from '@/components/ui/skeleton';
+import { useAuthor } from '@/hooks/useAuthor';
+import { useGolfFeed } from '@/hooks/useGolfFeed';
+import { genUserName } from '@/lib/genUserName';
+
+function FeedNote({ event }: { event: NostrEvent }) {
+  const author = useAuthor(event.pubkey);
+  const metadata = author.data?.metadata;
+  const displayName = metadata?.name ?? genUserName(event.pubkey);
+  const npub = nip19.npubEncode(event.pubkey);
+
+  return (
+    <Card>
+      <CardContent className="p-4 space-y-3">
+        <div className="flex items-start justify-between">
+          <div className="flex items-center space-x-3">
+            <Link to={`/${npub}`}>
+              <Avatar className="h-8 w-8">
+                <AvatarImage src={metadata?.picture} />
+                <AvatarFallback className="text-xs">{displayName.charAt(0)}</AvatarFallback>
+              </Avatar>
+            </Link>
+            <div>
+              <Link to={`/${npub}`} className="font-medium text-sm hover:text-primary transition-colors">
+                {displayName}
+              </Link>
+              <p className="text-xs text-muted-foreground">
+                {formatDistanceToNow(new Date(event.created_at * 1000), { addSuffix: true })}
+              </p>
+            </div>
+          </div>
+          <ModerationMenu event={event} authorName={displayName} />
+        </div>
+        <NoteContent event={event} className="text-sm" />
+      </CardContent>
+    </Card>
+  );
+}
+
+export const FeedPage: React.FC = () => {
+  const { notes, isLoading, fetchNextPage, hasNextPage, isFetchingNextPage } = useGolfFeed();
+
+  return (
+    <Layout>
+      <MobileContainer className="py-4 space-y-4">
+        <Card>
+          <CardHeader>
+            <CardTitle>Golf Feed</CardTitle>
+            <CardDescription>Golf notes from across Nostr</CardDescription>
+          </CardHeader>
+        </Card>
+
+        {isLoading ? (
+          Array.from({ length: 3 }, (_, i) => <Skeleton key={i} className="h-28 w-full" />)
+        ) : notes.length === 0 ? (
+          <Card>
+            <CardContent className="py-6 text-sm text-muted-foreground">
+              No golf notes yet. Post one tagged #golf to get things started.
+            </CardContent>
+          </Card>
+        ) : (
+          notes.map(event => <FeedNote key={event.id} event={event} />)
+        )}
+
+        {hasNextPage && notes.length > 0 && (
+          <Button variant="outline" className="w-full" onClick={() => fetchNextPage()} disabled={isFetchingNextPage}>
+            {isFetchingNextPage ? 'Loading...' : 'Load older'}
+          </Button>
+        )}
+      </MobileContainer>
+    </Layout>
+  );
+};
+
+export default FeedPage;
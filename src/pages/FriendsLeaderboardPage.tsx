@@ -0,0 +1,95 @@
+import React from 'react';
+import { Link, useParams } from 'react-router-dom';
+import { nip19 } from 'nostr-tools';
+import { Layout } from '@/components/Layout';
+import MobileContainer from '@/components/MobileContainer';
+import { Avatar, AvatarFallback, AvatarImage } from '@/components/ui/avatar';
+import { Badge } from '@/components/ui/badge';
+import { Card, CardContent, CardDescription, CardHeader, CardTitle } from '@/components/ui/card';
+import { Skeleton } from '@/components/ui/skeleton';
+import { useAuthor } from '@/hooks/useAuthor';
+import { useFriendsLeaderboard } from '@/hooks/useFriendsLeaderboard';
+import { genUserName } from '@/lib/genUserName';
+import type { FriendStanding } from '@/lib/golf/friendsEngine';
+
+function toPubkey(value: string | undefined): string | null {
+  if (!value) return null;
+  if (/^[a-f0-9]{64}$/i.test(value)) return value.toLowerCase();
+  try {
+    const decoded = nip19.decode(value);
+    return decoded.type === 'npub' ? decoded.data : null;
+  } catch {
+    return null;
+  }
+}
+
+function StandingRow({ standing, isPlayer }: { standing: FriendStanding; isPlayer: boolean }) {
+  const author = useAuthor(standing.playerPubkey);
+  const metadata = author.data?.metadata;
+  const name = metadata?.name ?? genUserName(standing.playerPubkey);
+
+  return (
+    <div className={`flex items-center gap-3 rounded border p-3 ${isPlayer ? 'border-primary' : ''}`}>
+      <span className="w-6 text-center font-bold">{standing.position}</span>
+      <Link to={`/${nip19.npubEncode(standing.playerPubkey)}`}>
+        <Avatar className="h-8 w-8">
+          <AvatarImage src={metadata?.picture} />
+          <AvatarFallback className="text-xs">{name.charAt(0)}</AvatarFallback>
+        </Avatar>
+      </Link>
+      <div className="min-w-0 flex-1">
+        <div className="text-sm font-medium truncate">{name}</div>
+        <div className="text-xs text-muted-foreground">
+          {standing.rounds} {standing.rounds === 1 ? 'round' : 'rounds'} · {standing.holes} holes
+          {standing.best18 !== undefined && ` · best ${standing.best18}`}
+        </div>
+      </div>
+      <Badge variant="secondary">{standing.average.toFixed(1)}</Badge>
+    </div>
+  );
+}
+
+export const FriendsLeaderboardPage: React.FC = () => {
+  const { npub } = useParams<{ npub: string }>();
+  const pubkey = toPubkey(npub);
+  const { data, isLoading } = useFriendsLeaderboard(pubkey ?? undefined);
+  const author = useAuthor(pubkey ?? undefined);
+  const name = pubkey ? author.data?.metadata?.name ?? genUserName(pubkey) : '';
+  const month = new Date().toLocaleDateString(undefined, { month: 'long', year: 'numeric' });
+
+  return (
+    <Layout>
+      <MobileContainer className="py-4 space-y-4">
+        <Card>
+          <CardHeader>
+            <CardTitle>Friends This Month</CardTitle>
+            <CardDescription>
+              {pubkey
+                ? `${name} and the players they follow · ${month} · scoring average per 18 holes`
+                : 'Not a valid player link'}
+            </CardDescription>
+          </CardHeader>
+          {pubkey && (
+            <CardContent className="space-y-2">
+              {isLoading ? (
+                Array.from({ length: 3 }, (_, i) => <Skeleton key={i} className="h-14 w-full" />)
+              ) : !data || data.standings.length === 0 ? (
+                <p className="text-sm text-muted-foreground">
+                  {data?.follows === 0
+                    ? 'This player does not follow anyone yet.'
+                    : 'No one here has played nine holes this month.'}
+                </p>
+              ) : (
+                data.standings.map(standing => (
+                  <StandingRow key={standing.playerPubkey} standing={standing} isPlayer={standing.playerPubkey === pubkey} />
+                ))
+              )}
+            </CardContent>
+          )}
+        </Card>
+      </MobileContainer>
+    </Layout>
+  );
+};
+
+export default FriendsLeaderboardPage;
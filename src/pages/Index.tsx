@@ -1,5 +1,6 @@
 import { useSeoMeta } from '@unhead/react';
 import { Link } from 'react-router-dom';
+import { nip19 } from 'nostr-tools';
 import { Button } from '@/components/ui/button';
 import MobileContainer from '@/components/MobileContainer';
 import { Card, CardContent, CardDescription, CardHeader, CardTitle } from '@/components/ui/card';
@@ -7,7 +8,7 @@ import { LoginArea } from '@/components/auth/LoginArea';
 import { ThemeToggle } from '@/components/ThemeToggle';
 import { useCurrentUser } from '@/hooks/useCurrentUser';
 import { Avatar, AvatarImage, AvatarFallback } from '@/components/ui/avatar';
-import { Users, Zap, Trophy, BarChart3, User, Code } from 'lucide-react';
+import { Users, Zap, Trophy, BarChart3, User, Code, GraduationCap } from 'lucide-react';
 
 // Developer profile info for "Vibed by" section
 const DEVELOPER = {
@@ -124,6 +125,46 @@ const Index = () => {
               <CardDescription>
                 Share achievements and scores with the Nostr network. Build your golf reputation.
               </CardDescription>
+              <div className="mt-4 flex flex-wrap justify-center gap-2">
+                <Link to="/feed">
+                  <Button variant="outline" size="sm">
+                    <Users className="mr-2 h-4 w-4" />
+                    Golf Feed
+                  </Button>
+                </Link>
+                {user && (
+                  <Link to={`/players/${nip19.npubEncode(user.pubkey)}/friends/leaderboard`}>
+                    <Button variant="outline" size="sm">
+                      <Trophy className="mr-2 h-4 w-4" />
+                      Friends Leaderboard
+                    </Button>
+                  </Link>
+                )}
+                {user && (
+                  <Link to="/season">
+                    <Button variant="outline" size="sm">
+                      <Trophy className="mr-2 h-4 w-4" />
+                      Season
+                    </Button>
+                  </Link>
+                )}
+                {user && (
+                  <Link to="/lessons">
+                    <Button variant="outline" size="sm">
+                      <GraduationCap className="mr-2 h-4 w-4" />
+                      Lessons
+                    </Button>
+                  </Link>
+                )}
+                {user && (
+                  <Link to="/practice">
+                    <Button variant="outline" size="sm">
+                      <BarChart3 className="mr-2 h-4 w-4" />
+                      Practice
+                    </Button>
+                  </Link>
+                )}
+              </div>
             </CardContent>
           </Card>
 
@@ -0,0 +1,266 @@
+import React, { useState } from 'react';
+import { Link, useParams } from 'react-router-dom';
+import { nip19 } from 'nostr-tools';
+import type { NostrEvent, NostrMetadata } from '@nostrify/nostrify';
+import { useQueryClient } from '@tanstack/react-query';
+import { Layout } from '@/components/Layout';
+import MobileContainer from '@/components/MobileContainer';
+import { ZapButton } from '@/components/ZapButton';
+import { Button } from '@/components/ui/button';
+import { Card, CardContent, CardDescription, CardHeader, CardTitle } from '@/components/ui/card';
+import { Input } from '@/components/ui/input';
+import { Label } from '@/components/ui/label';
+import { Skeleton } from '@/components/ui/skeleton';
+import { Textarea } from '@/components/ui/textarea';
+import { useAuthor } from '@/hooks/useAuthor';
+import { useCurrentUser } from '@/hooks/useCurrentUser';
+import { useMyLessons, useProLessons } from '@/hooks/useLessons';
+import { useToast } from '@/hooks/useToast';
+import { genUserName } from '@/lib/genUserName';
+import { downloadIcs } from '@/lib/golf/icsCalendar';
+import { lessonsToIcs, type Lesson, type LessonSlot } from '@/lib/golf/lessonEngine';
+
+function toPubkey(value: string | undefined): string | null {
+  if (!value) return null;
+  if (/^[a-f0-9]{64}$/i.test(value)) return value.toLowerCase();
+  try {
+    const decoded = nip19.decode(value);
+    return decoded.type === 'npub' ? decoded.data : null;
+  } catch {
+    return null;
+  }
+}
+
+function formatSlot(slot: LessonSlot): string {
+  const from = new Date(slot.startsAt);
+  const to = new Date(slot.endsAt);
+  return `${from.toLocaleDateString()} ${from.toLocaleTimeString([], { hour: '2-digit', minute: '2-digit' })}–${to.toLocaleTimeString([], { hour: '2-digit', minute: '2-digit' })}`;
+}
+
+function LessonRow({ lesson, viewer, slotEvent, onCancel, disabled }: {
+  lesson: Lesson;
+  viewer: string;
+  slotEvent?: NostrEvent;
+  onCancel: () => void;
+  disabled: boolean;
+}) {
+  const teaching = lesson.slot.pro === viewer;
+  const other = teaching ? lesson.booking.member : lesson.slot.pro;
+  const author = useAuthor(other);
+  const name = author.data?.metadata?.name ?? genUserName(other);
+
+  return (
+    <div className="flex items-start justify-between gap-2 rounded border p-3">
+      <div className="min-w-0">
+        <div className="text-sm font-medium">{teaching ? `Teaching ${name}` : `Lesson with ${name}`}</div>
+        <div className="text-xs text-muted-foreground">
+          {formatSlot(lesson.slot)}
+          {lesson.slot.location && ` · ${lesson.slot.location}`}
+          {lesson.slot.price > 0 && ` · ${lesson.slot.price} ${lesson.slot.currency}`}
+        </div>
+        {lesson.booking.note && <div className="text-xs mt-1">{lesson.booking.note}</div>}
+      </div>
+      <div className="flex items-center gap-1">
+        {!teaching && slotEvent && <ZapButton target={slotEvent} showCount={false} />}
+        <Button size="sm" variant="outline" disabled={disabled} onClick={onCancel}>Cancel</Button>
+      </div>
+    </div>
+  );
+}
+
+export const LessonsPage: React.FC = () => {
+  const { npub } = useParams<{ npub: string }>();
+  const { user } = useCurrentUser();
+  const { toast } = useToast();
+  const queryClient = useQueryClient();
+  const pro = toPubkey(npub) ?? undefined;
+  const proAuthor = useAuthor(pro);
+  const proName = pro ? proAuthor.data?.metadata?.name ?? genUserName(pro) : '';
+  const isOwnPage = !pro || pro === user?.pubkey;
+
+  const mine = useMyLessons();
+  const theirs = useProLessons(isOwnPage ? undefined : pro);
+
+  const [date, setDate] = useState('');
+  const [time, setTime] = useState('');
+  const [minutes, setMinutes] = useState('60');
+  const [price, setPrice] = useState('');
+  const [currency, setCurrency] = useState('USD');
+  const [location, setLocation] = useState('');
+  const [slotNote, setSlotNote] = useState('');
+  const [bookingNote, setBookingNote] = useState('');
+
+  const run = async (action: () => Promise<unknown>, success: string) => {
+    try {
+      await action();
+      toast({ title: success });
+    } catch (error) {
+      toast({ title: 'Something went wrong', description: error instanceof Error ? error.message : undefined, variant: 'destructive' });
+    }
+  };
+
+  const handleOffer = () => {
+    const startsAt = new Date(`${date}T${time}`).getTime();
+    const length = parseInt(minutes);
+    if (isNaN(startsAt) || !(length > 0) || startsAt <= Date.now()) {
+      toast({ title: 'Choose a future date, time and length', variant: 'destructive' });
+      return;
+    }
+    run(() => mine.offerSlot({
+      startsAt,
+      endsAt: startsAt + length * 60 * 1000,
+      price: parseFloat(price) || 0,
+      currency: currency.trim().toUpperCase() || 'USD',
+      location: location.trim(),
+      note: slotNote.trim(),
+    }), 'Lesson slot published');
+  };
+
+  const downloadCalendar = () => {
+    if (!user) return;
+    // Names are already loaded for the rows above
+    const nameOf = (lesson: Lesson) => {
+      const other = lesson.slot.pro === user.pubkey ? lesson.booking.member : lesson.slot.pro;
+      return queryClient.getQueryData<{ metadata?: NostrMetadata }>(['author', other])?.metadata?.name ?? genUserName(other);
+    };
+    downloadIcs(lessonsToIcs(mine.lessons, 'Golf lessons', nameOf), 'lessons.ics');
+  };
+
+  return (
+    <Layout>
+      <MobileContainer className="py-4 space-y-4">
+        {!isOwnPage && (
+          <Card>
+            <CardHeader>
+              <CardTitle>Lessons with {proName}</CardTitle>
+              <CardDescription>Pick an open slot to book it</CardDescription>
+            </CardHeader>
+            <CardContent className="space-y-2">
+              {theirs.isLoading ? (
+                <Skeleton className="h-16 w-full" />
+              ) : theirs.open.length === 0 ? (
+                <p className="text-sm text-muted-foreground">No open lesson slots right now.</p>
+              ) : (
+                <>
+                  <Textarea
+                    value={bookingNote}
+                    onChange={(e) => setBookingNote(e.target.value)}
+                    placeholder="What would you like to work on? (optional)"
+                    rows={2}
+                  />
+                  {theirs.open.map(slot => (
+                    <div key={slot.slotId} className="flex items-center justify-between gap-2 rounded border p-3">
+                      <div className="min-w-0">
+                        <div className="text-sm font-medium">{formatSlot(slot)}</div>
+                        <div className="text-xs text-muted-foreground">
+                          {[slot.location, slot.price > 0 ? `${slot.price} ${slot.currency}` : 'Free', slot.note].filter(Boolean).join(' · ')}
+                        </div>
+                      </div>
+                      <Button
+                        size="sm"
+                        disabled={!user || theirs.isPublishing}
+                        onClick={() => run(() => theirs.book(slot, bookingNote.trim()), 'Lesson booked')}
+                      >
+                        Book
+                      </Button>
+                    </div>
+                  ))}
+                </>
+              )}
+            </CardContent>
+          </Card>
+        )}
+
+        {user && (
+          <Card>
+            <CardHeader>
+              <CardTitle className="text-lg">My Lessons</CardTitle>
+            </CardHeader>
+            <CardContent className="space-y-2">
+              {mine.isLoading ? (
+                <Skeleton className="h-16 w-full" />
+              ) : mine.lessons.length === 0 ? (
+                <p className="text-sm text-muted-foreground">No lessons booked.</p>
+              ) : (
+                mine.lessons.map(lesson => (
+                  <LessonRow
+                    key={`${lesson.slot.pro}:${lesson.slot.slotId}`}
+                    lesson={lesson}
+                    viewer={user.pubkey}
+                    slotEvent={mine.slotEvents[lesson.slot.slotId]}
+                    disabled={mine.isPublishing}
+                    onCancel={() => run(
+                      () => (lesson.slot.pro === user.pubkey ? mine.cancelSlot(lesson.slot) : mine.cancelBooking(lesson.booking)),
+                      'Lesson cancelled',
+                    )}
+                  />
+                ))
+              )}
+              <Button variant="outline" className="w-full" onClick={downloadCalendar} disabled={mine.lessons.length === 0}>
+                Add to Calendar (.ics)
+              </Button>
+            </CardContent>
+          </Card>
+        )}
+
+        {user && isOwnPage && (
+          <Card>
+            <CardHeader>
+              <CardTitle className="text-lg">Teach Lessons</CardTitle>
+              <CardDescription>
+                Students book from{' '}
+                <Link to={`/lessons/${nip19.npubEncode(user.pubkey)}`} className="text-primary hover:underline">your lessons page</Link>
+              </CardDescription>
+            </CardHeader>
+            <CardContent className="space-y-3">
+              {mine.myOpenSlots.map(slot => (
+                <div key={slot.slotId} className="flex items-center justify-between gap-2 text-sm">
+                  <span>{formatSlot(slot)}</span>
+                  <Button size="sm" variant="ghost" disabled={mine.isPublishing} onClick={() => run(() => mine.cancelSlot(slot), 'Slot withdrawn')}>
+                    Withdraw
+                  </Button>
+                </div>
+              ))}
+              <div className="grid grid-cols-3 gap-3">
+                <div className="space-y-2">
+                  <Label htmlFor="lesson-date">Date</Label>
+                  <Input id="lesson-date" type="date" value={date} onChange={(e) => setDate(e.target.value)} />
+                </div>
+                <div className="space-y-2">
+                  <Label htmlFor="lesson-time">Time</Label>
+                  <Input id="lesson-time" type="time" value={time} onChange={(e) => setTime(e.target.value)} />
+                </div>
+                <div className="space-y-2">
+                  <Label htmlFor="lesson-minutes">Minutes</Label>
+                  <Input id="lesson-minutes" type="number" value={minutes} onChange={(e) => setMinutes(e.target.value)} />
+                </div>
+                <div className="space-y-2">
+                  <Label htmlFor="lesson-price">Price</Label>
+                  <Input id="lesson-price" type="number" step="0.01" value={price} onChange={(e) => setPrice(e.target.value)} />
+                </div>
+                <div className="space-y-2">
+                  <Label htmlFor="lesson-currency">Currency</Label>
+                  <Input id="lesson-currency" value={currency} onChange={(e) => setCurrency(e.target.value)} />
+                </div>
+                <div className="space-y-2">
+                  <Label htmlFor="lesson-location">Where</Label>
+                  <Input id="lesson-location" value={location} onChange={(e) => setLocation(e.target.value)} placeholder="Range bay 3" />
+                </div>
+              </div>
+              <Textarea value={slotNote} onChange={(e) => setSlotNote(e.target.value)} placeholder="Notes for students (optional)" rows={2} />
+              <Button className="w-full" onClick={handleOffer} disabled={mine.isPublishing}>Publish Slot</Button>
+            </CardContent>
+          </Card>
+        )}
+
+        {!user && isOwnPage && (
+          <Card>
+            <CardContent className="py-6 text-sm text-muted-foreground">Log in to see and book lessons.</CardContent>
+          </Card>
+        )}
+      </MobileContainer>
+    </Layout>
+  );
+};
+
+export default LessonsPage;
@@ -0,0 +1,147 @@
+import React, { useMemo, useState } from 'react';
+import { Link } from 'react-router-dom';
+import { Layout } from '@/components/Layout';
+import MobileContainer from '@/components/MobileContainer';
+import { Card, CardContent, CardDescription, CardHeader, CardTitle } from '@/components/ui/card';
+import { Badge } from '@/components/ui/badge';
+import { Skeleton } from '@/components/ui/skeleton';
+import { Select, SelectContent, SelectItem, SelectTrigger, SelectValue } from '@/components/ui/select';
+import { Table, TableBody, TableCell, TableHead, TableHeader, TableRow } from '@/components/ui/table';
+import { useGolfCourses } from '@/hooks/useGolfCourses';
+import { useCoursePace } from '@/hooks/useCoursePace';
+import { useCourseNotices } from '@/hooks/useCourseNotices';
+import { useClubActivity } from '@/hooks/useClubActivity';
+import { ClubActivityFeed } from '@/components/golf/ClubActivityFeed';
+import { CourseAlertBroadcast } from '@/components/golf/CourseAlertBroadcast';
+import { LightningWatchCard } from '@/components/golf/LightningWatchCard';
+import { useCurrentUser } from '@/hooks/useCurrentUser';
+import type { PaceStatus } from '@/lib/golf/paceEngine';
+import { closurePeriods } from '@/lib/golf/maintenanceEngine';
+
+const statusStyles: Record<PaceStatus, { label: string; variant: 'default' | 'secondary' | 'destructive' | 'outline' }> = {
+  'not-started': { label: 'Not started', variant: 'outline' },
+  ahead: { label: 'Ahead', variant: 'secondary' },
+  'on-pace': { label: 'On pace', variant: 'secondary' },
+  behind: { label: 'Behind', variant: 'default' },
+  'out-of-position': { label: 'Out of position', variant: 'destructive' },
+  finished: { label: 'Finished', variant: 'outline' },
+};
+
+export const MarshalPage: React.FC = () => {
+  const { data: courses = [] } = useGolfCourses();
+  const { user } = useCurrentUser();
+  const [courseId, setCourseId] = useState<string>('');
+
+  const course = courses.find(c => c.id === courseId);
+  const pars = useMemo(() => {
+    if (!course) return [];
+    return Object.keys(course.holes).map(Number).sort((a, b) => a - b).map(hole => course.holes[hole]);
+  }, [course]);
+
+  const { notices } = useCourseNotices(course);
+  // The pace clock stops while the course is closed, e.g. for lightning
+  const suspensions = useMemo(() => closurePeriods(notices), [notices]);
+  const { data: groups = [], isLoading } = useCoursePace(course?.name, pars, { suspensions });
+  const { data: activity, isLoading: isActivityLoading } = useClubActivity(course?.name, pars, 20);
+  const onCourse = groups.filter(g => g.status !== 'finished' && g.status !== 'not-started');
+
+  return (
+    <Layout>
+      <MobileContainer className="py-4 space-y-4">
+        <Card>
+          <CardHeader>
+            <CardTitle>Marshal</CardTitle>
+            <CardDescription>Groups on the course and their pace of play</CardDescription>
+          </CardHeader>
+          <CardContent>
+            <Select value={courseId} onValueChange={setCourseId}>
+              <SelectTrigger>
+                <SelectValue placeholder="Select a course" />
+              </SelectTrigger>
+              <SelectContent>
+                {courses.map(c => (
+                  <SelectItem key={c.id} value={c.id}>{c.name}</SelectItem>
+                ))}
+              </SelectContent>
+            </Select>
+          </CardContent>
+        </Card>
+
+        {course && (
+          <Card>
+            <CardHeader>
+              <CardTitle className="text-lg">{onCourse.length} groups on the course</CardTitle>
+            </CardHeader>
+            <CardContent>
+              {isLoading ? (
+                <div className="space-y-2">
+                  <Skeleton className="h-8 w-full" />
+                  <Skeleton className="h-8 w-full" />
+                </div>
+              ) : groups.length === 0 ? (
+                <p className="text-sm text-muted-foreground">No active rounds on this course.</p>
+              ) : (
+                <Table>
+                  <TableHeader>
+                    <TableRow>
+                      <TableHead>Tee time</TableHead>
+                      <TableHead>Hole</TableHead>
+                      <TableHead>Pace</TableHead>
+                      <TableHead className="text-right">Status</TableHead>
+                    </TableRow>
+                  </TableHeader>
+                  <TableBody>
+                    {groups.map(group => (
+                      <TableRow key={group.groupId}>
+                        <TableCell>
+                          {new Date(group.teeTime).toLocaleTimeString([], { hour: '2-digit', minute: '2-digit' })}
+                        </TableCell>
+                        <TableCell>{group.currentHole ?? '-'}</TableCell>
+                        <TableCell>
+                          {group.status === 'finished' || group.status === 'not-started'
+                            ? '-'
+                            : group.minutesBehind > 0 ? `+${group.minutesBehind} min` : `${group.minutesBehind} min`}
+                        </TableCell>
+                        <TableCell className="text-right">
+                          <Badge variant={statusStyles[group.status].variant}>{statusStyles[group.status].label}</Badge>
+                        </TableCell>
+                      </TableRow>
+                    ))}
+                  </TableBody>
+                </Table>
+              )}
+            </CardContent>
+          </Card>
+        )}
+
+        {course && user?.pubkey === course.author && (
+          <>
+            <CourseAlertBroadcast course={course} />
+            <LightningWatchCard course={course} />
+          </>
+        )}
+
+        {course && (
+          <Card>
+            <CardHeader className="flex flex-row items-center justify-between space-y-0">
+              <CardTitle className="text-lg">Activity</CardTitle>
+              <div className="flex gap-3">
+                <Link to={`/courses/${course.id}/status`} className="text-sm text-primary hover:underline">
+                  Course status
+                </Link>
+                <Link to={`/courses/${course.id}/aces`} className="text-sm text-primary hover:underline">
+                  Hole-in-one wall
+                </Link>
+              </div>
+            </CardHeader>
+            <CardContent>
+              <ClubActivityFeed items={activity} isLoading={isActivityLoading} />
+            </CardContent>
+          </Card>
+        )}
+      </MobileContainer>
+    </Layout>
+  );
+};
+
+export default MarshalPage;
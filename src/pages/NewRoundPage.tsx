@@ -38,7 +38,16 @@ import { Layout } from '@/components/Layout';
 import MobileContainer from '@/components/MobileContainer';
 import { genUserName } from '@/lib/genUserName';
 import { AddPlayerDialog } from '@/components/AddPlayerDialog';
+import { PrizePayoutDialog } from '@/components/golf/PrizePayoutDialog';
+import { SideCompetitionsEditor } from '@/components/golf/SideCompetitionsEditor';
+import { SideCompetitionsPanel } from '@/components/golf/SideCompetitionsPanel';
+import { PredictionsPanel } from '@/components/golf/PredictionsPanel';
+import { RoundSocialPanel } from '@/components/golf/RoundSocialPanel';
+import { CourseNoticeAlert } from '@/components/golf/CourseNoticeAlert';
+import { CourseAlertBanner } from '@/components/golf/CourseAlertBanner';
+import { prizesFromLedger, type Prize } from '@/lib/golf/payoutEngine';
 import { useNWC } from '@/hooks/useNWCContext';
+import { useLiveScoring } from '@/hooks/useLiveScoring';
 import { LN } from '@getalby/sdk';
 import type { NostrEvent, NostrFilter } from '@nostrify/nostrify';
 import { type GolfCourse } from '@/hooks/useGolfCourses';
@@ -116,6 +125,7 @@ export const NewRoundPage: React.FC = () => {
   const [showSettlementPreview, setShowSettlementPreview] = useState(false);
   type AggregatedInvoice = { recipient: string; amount: number; payers: string[]; memo?: string };
   const [settlementPreview, setSettlementPreview] = useState<AggregatedInvoice[]>([]);
+  const [payoutPrizes, setPayoutPrizes] = useState<Prize[] | null>(null);
   const [isCreatingInvoices, setIsCreatingInvoices] = useState(false);
 
   // Check for roundId in URL — if present, use it instead of generating a new one (join flow)
@@ -282,7 +292,13 @@ export const NewRoundPage: React.FC = () => {
     setShowAutoJoinConfirm(true);
   }, [urlAutoJoin, urlRoundId, user, toast]);
 
-  // Poll for player events so hosts see joiners in near-real-time
+  // Reload the players whenever the live subscription sees someone join
+  const [joins, setJoins] = useState(0);
+  useLiveScoring(round.id, (event) => {
+    if (event.kind === GOLF_KINDS.PLAYER) setJoins(j => j + 1);
+  });
+
+  // Load player events so hosts see joiners as they arrive
   useEffect(() => {
     if (!nostr || !round.id) return;
 
@@ -321,14 +337,12 @@ export const NewRoundPage: React.FC = () => {
     };
 
     fetchPlayers();
-    const id = setInterval(fetchPlayers, 4000);
 
     return () => {
       mounted = false;
       controller.abort();
-      clearInterval(id);
     };
-  }, [nostr, round.id]);
+  }, [nostr, round.id, joins]);
 
   const acceptInvite = async () => {
     if (!user || !round.id) {
@@ -908,6 +922,56 @@ export const NewRoundPage: React.FC = () => {
     }
   };
 
+  // Payments owed in each wagered game, computed with the game-specific engines
+  const computeGameLedgers = (): Record<string, Payable[]> => {
+    const ledgers: Record<string, Payable[]> = {};
+
+    // Convert round players to core data for engines
+    const coreData = convertToRoundData((round.players || []).map(p => ({ playerId: p.playerId, scores: p.scores || [], handicap: p.handicap || 0 })));
+
+    // Build gameConfigs for processRoundWagers (Nassau & Skins)
+    const gameConfigs: { [gameName: string]: import('@/lib/golf/scoringEngine').WagerConfig } = {};
+    selectedGameModeList.forEach(gm => {
+      if (!wagersEnabled && !(selectedGameModes.pinseekrCup && pinseekrWagersEnabled)) return;
+      const key = gm.key;
+      const current = wagerAmounts[key] || '111';
+      const custom = customWagerAmounts[key] || '';
+      const unit = current === 'custom' ? (parseInt(custom) || 0) : parseInt(current);
+
+      if (key === 'nassau') gameConfigs['Nassau'] = { useNet: true, unitSats: unit };
+      if (key === 'skins') gameConfigs['Skins'] = { useNet: true, unitSats: unit, carryCap: 4 };
+    });
+
+    if (Object.keys(gameConfigs).length > 0) {
+      const results = processRoundWagers(round.players || [], gameConfigs);
+      for (const [game, r] of Object.entries(results)) {
+        ledgers[game] = r.ledger;
+      }
+    }
+
+    // Dots game
+    if ((selectedGameModes.dots && wagersEnabled) || (selectedGameModes.pinseekrCup && pinseekrWagersEnabled && wagerAmounts['dots'])) {
+      const dotsCfg = { wagerPerDot: parseInt(customWagerAmounts['dots'] || (wagerAmounts['dots'] === 'custom' ? '0' : (wagerAmounts['dots'] || '100'))) || 100 };
+      const dotsData = convertToDotsData(coreData);
+      const dotsResult = dotsEngine(dotsData, dotsCfg);
+      // transform dotsResult.payments to Payable
+      ledgers['Dots'] = dotsResult.payments.map(p => ({ from: p.from, to: p.to, amount: p.amount, memo: p.reason }));
+    }
+
+    // Snake game
+    if ((selectedGameModes.snake && wagersEnabled) || (selectedGameModes.pinseekrCup && pinseekrWagersEnabled && wagerAmounts['snake'])) {
+      const snakeData = convertToSnakeData(coreData);
+      const snakeResult = snakeEngine(snakeData, { penaltyAmount: parseInt(customWagerAmounts['snake'] || (wagerAmounts['snake'] === 'custom' ? '0' : (wagerAmounts['snake'] || '100'))) || 500 });
+      if (snakeResult.penalty && snakeResult.penalty.loser) {
+        // recipients is array of {playerId, amount}
+        const from = snakeResult.penalty.loser;
+        ledgers['Snake'] = (snakeResult.penalty.recipients || []).map(rec => ({ from, to: rec.playerId, amount: rec.amount, memo: 'Snake penalty' }));
+      }
+    }
+
+    return ledgers;
+  };
+
   // Generate settlement invoices (simple even-split example) and publish settlement event
   const generateAndPublishSettlement = async () => {
     if (!user) {
@@ -953,55 +1017,7 @@ export const NewRoundPage: React.FC = () => {
     }
 
       // Compute detailed payments using game-specific engines
-      const payables: Payable[] = [];
-
-      // Convert round players to core data for engines
-      const coreData = convertToRoundData((round.players || []).map(p => ({ playerId: p.playerId, scores: p.scores || [], handicap: p.handicap || 0 })));
-
-      // Build gameConfigs for processRoundWagers (Nassau & Skins)
-      const gameConfigs: { [gameName: string]: import('@/lib/golf/scoringEngine').WagerConfig } = {};
-      selectedGameModeList.forEach(gm => {
-        if (!wagersEnabled && !(selectedGameModes.pinseekrCup && pinseekrWagersEnabled)) return;
-        const key = gm.key;
-        const current = wagerAmounts[key] || '111';
-        const custom = customWagerAmounts[key] || '';
-        const unit = current === 'custom' ? (parseInt(custom) || 0) : parseInt(current);
-
-        if (key === 'nassau') gameConfigs['Nassau'] = { useNet: true, unitSats: unit };
-        if (key === 'skins') gameConfigs['Skins'] = { useNet: true, unitSats: unit, carryCap: 4 };
-      });
-
-      if (Object.keys(gameConfigs).length > 0) {
-        const results = processRoundWagers(round.players || [], gameConfigs);
-        for (const r of Object.values(results)) {
-          payables.push(...r.ledger);
-        }
-      }
-
-      // Dots game
-      if ((selectedGameModes.dots && wagersEnabled) || (selectedGameModes.pinseekrCup && pinseekrWagersEnabled && wagerAmounts['dots'])) {
-        const dotsCfg = { wagerPerDot: parseInt(customWagerAmounts['dots'] || (wagerAmounts['dots'] === 'custom' ? '0' : (wagerAmounts['dots'] || '100'))) || 100 };
-        const dotsData = convertToDotsData(coreData);
-        const dotsResult = dotsEngine(dotsData, dotsCfg);
-        // transform dotsResult.payments to Payable
-        for (const p of dotsResult.payments) {
-          payables.push({ from: p.from, to: p.to, amount: p.amount, memo: p.reason });
-        }
-      }
-
-      // Snake game
-      if ((selectedGameModes.snake && wagersEnabled) || (selectedGameModes.pinseekrCup && pinseekrWagersEnabled && wagerAmounts['snake'])) {
-        const snakeData = convertToSnakeData(coreData);
-        const snakeResult = snakeEngine(snakeData, { penaltyAmount: parseInt(customWagerAmounts['snake'] || (wagerAmounts['snake'] === 'custom' ? '0' : (wagerAmounts['snake'] || '100'))) || 500 });
-        if (snakeResult.penalty && snakeResult.penalty.loser) {
-          // recipients is array of {playerId, amount}
-          for (const rec of snakeResult.penalty.recipients || []) {
-            const from = snakeResult.penalty.loser;
-            const to = rec.playerId;
-            payables.push({ from, to, amount: rec.amount, memo: 'Snake penalty' });
-          }
-        }
-      }
+      const payables = Object.values(computeGameLedgers()).flat();
 
       // Net the payments
       const netted = netting(payables);
@@ -1152,7 +1168,8 @@ export const NewRoundPage: React.FC = () => {
           courseLocation: round.metadata?.courseLocation || selectedCourse?.location || '',
           teeBox: round.metadata?.teeBox || '',
           weather: round.metadata?.weather || '',
-          notes: round.metadata?.notes || ''
+          notes: round.metadata?.notes || '',
+          sideCompetitions: round.metadata?.sideCompetitions
         }
       };
 
@@ -2856,6 +2873,20 @@ export const NewRoundPage: React.FC = () => {
                           </div>
                         )}
 
+                        <CourseNoticeAlert course={selectedCourse} />
+
+                        {selectedCourse && (
+                          <SideCompetitionsEditor
+                            value={round.metadata?.sideCompetitions ?? []}
+                            onChange={sideCompetitions => setRound(prev => ({
+                              ...prev,
+                              metadata: { ...prev.metadata!, sideCompetitions },
+                            }))}
+                            holes={Object.keys(selectedCourse.holes || {}).map(Number).sort((a, b) => a - b)}
+                            pars={selectedCourse.holes}
+                          />
+                        )}
+
                       </div>
 
                       {/* Game Mode Description Box with Tabs */}
@@ -3580,6 +3611,8 @@ export const NewRoundPage: React.FC = () => {
             </div>
 
             <div className="mt-8">
+              <CourseAlertBanner course={selectedCourse} className="mb-4" />
+
               <ScoreCard
                 round={round as GolfRound}
                 course={selectedCourse}
@@ -3593,6 +3626,16 @@ export const NewRoundPage: React.FC = () => {
                 }}
               />
 
+              <div className="mt-4">
+                <SideCompetitionsPanel round={round} greens={selectedCourse?.greens} />
+              </div>
+
+              <div className="mt-4">
+                <PredictionsPanel round={round} />
+              </div>
+
+              {round.id && <RoundSocialPanel roundId={round.id} className="mt-4" />}
+
               {/* Settlement Actions */}
               {(wagersEnabled || (selectedGameModes.pinseekrCup && pinseekrWagersEnabled)) && (
                 <div className="mt-4 flex gap-3 justify-center">
@@ -3602,6 +3645,14 @@ export const NewRoundPage: React.FC = () => {
                   >
                     Generate Settlement Invoices (NWC)
                   </Button>
+                  <Button
+                    variant="outline"
+                    onClick={() => setPayoutPrizes(
+                      Object.entries(computeGameLedgers()).flatMap(([game, ledger]) => prizesFromLedger(ledger, round.id, game))
+                    )}
+                  >
+                    Pay Prizes from Club Wallet
+                  </Button>
                 </div>
               )}
             </div>
@@ -3618,6 +3669,12 @@ export const NewRoundPage: React.FC = () => {
         existingPlayers={round.players || []}
       />
 
+      <PrizePayoutDialog
+        open={payoutPrizes !== null}
+        onOpenChange={(open) => { if (!open) setPayoutPrizes(null); }}
+        prizes={payoutPrizes ?? []}
+      />
+
       {/* Settlement Preview Dialog */}
       {/* Wolf Settings Dialog */}
       <Dialog open={wolfSettingsOpen} onOpenChange={setWolfSettingsOpen}>
@@ -0,0 +1,133 @@
+import React, { useState } from 'react';
+import { useParams } from 'react-router-dom';
+import { Layout } from '@/components/Layout';
+import MobileContainer from '@/components/MobileContainer';
+import { Card, CardContent, CardDescription, CardHeader, CardTitle } from '@/components/ui/card';
+import { Skeleton } from '@/components/ui/skeleton';
+import { Select, SelectContent, SelectItem, SelectTrigger, SelectValue } from '@/components/ui/select';
+import { useGolfCourses } from '@/hooks/useGolfCourses';
+import { useCourseOccupancy } from '@/hooks/useCourseOccupancy';
+import { DAY_NAMES, offPeakHours, peakHour, type OccupancyCell } from '@/lib/golf/occupancyEngine';
+
+const formatHour = (hour: number) => `${String(hour).padStart(2, '0')}:00`;
+const formatSlot = (cell: OccupancyCell) => `${DAY_NAMES[cell.day]} ${formatHour(cell.hour)}`;
+const percent = (value: number) => `${Math.round(value * 100)}%`;
+
+export const OccupancyPage: React.FC = () => {
+  const { courseId } = useParams<{ courseId: string }>();
+  const { data: courses = [], isLoading: coursesLoading } = useGolfCourses();
+  const course = courses.find(c => c.id === courseId);
+  const [weeks, setWeeks] = useState(12);
+  const { data, isLoading } = useCourseOccupancy(course?.name, weeks);
+
+  if (coursesLoading) {
+    return (
+      <Layout>
+        <MobileContainer className="py-4">
+          <Skeleton className="h-32 w-full" />
+        </MobileContainer>
+      </Layout>
+    );
+  }
+
+  if (!course) {
+    return (
+      <Layout>
+        <MobileContainer className="py-4">
+          <Card>
+            <CardContent className="py-6 text-sm text-muted-foreground">Course not found.</CardContent>
+          </Card>
+        </MobileContainer>
+      </Layout>
+    );
+  }
+
+  const grid = data?.grid ?? [];
+  const peak = peakHour(grid);
+  const quiet = offPeakHours(grid);
+
+  return (
+    <Layout>
+      <MobileContainer className="py-4 space-y-4">
+        <Card>
+          <CardHeader className="flex flex-row items-center justify-between space-y-0">
+            <div>
+              <CardTitle>{course.name}</CardTitle>
+              <CardDescription>Tee sheet occupancy, average per week</CardDescription>
+            </div>
+            <Select value={String(weeks)} onValueChange={(value) => setWeeks(Number(value))}>
+              <SelectTrigger className="w-32">
+                <SelectValue />
+              </SelectTrigger>
+              <SelectContent>
+                <SelectItem value="4">4 weeks</SelectItem>
+                <SelectItem value="12">12 weeks</SelectItem>
+                <SelectItem value="26">26 weeks</SelectItem>
+              </SelectContent>
+            </Select>
+          </CardHeader>
+          <CardContent>
+            {isLoading ? (
+              <Skeleton className="h-48 w-full" />
+            ) : !data || data.rounds === 0 ? (
+              <p className="text-sm text-muted-foreground">No rounds recorded at this course in the last {weeks} weeks.</p>
+            ) : (
+              <div className="overflow-x-auto">
+                <table className="text-xs">
+                  <thead>
+                    <tr>
+                      <th />
+                      {grid[0].map(cell => (
+                        <th key={cell.hour} className="px-1 font-normal text-muted-foreground">{cell.hour}</th>
+                      ))}
+                    </tr>
+                  </thead>
+                  <tbody>
+                    {grid.map((row, day) => (
+                      <tr key={day}>
+                        <th className="pr-2 text-left font-normal text-muted-foreground">{DAY_NAMES[day]}</th>
+                        {row.map(cell => (
+                          <td key={cell.hour} className="p-0.5">
+                            <div
+                              className="h-6 w-6 rounded bg-primary"
+                              style={{ opacity: 0.08 + cell.occupancy * 0.92 }}
+                              title={`${formatSlot(cell)}: ${cell.players.toFixed(1)} players, ${percent(cell.occupancy)} full`}
+                            />
+                          </td>
+                        ))}
+                      </tr>
+                    ))}
+                  </tbody>
+                </table>
+                <p className="mt-2 text-xs text-muted-foreground">
+                  From {data.rounds} rounds. Darker is busier; full means every tee time taken by a four-ball.
+                </p>
+              </div>
+            )}
+          </CardContent>
+        </Card>
+
+        {peak && (
+          <Card>
+            <CardHeader>
+              <CardTitle className="text-lg">Off-Peak Candidates</CardTitle>
+              <CardDescription>
+                Busiest: {formatSlot(peak)} at {percent(peak.occupancy)}. The quietest hours the course is in use:
+              </CardDescription>
+            </CardHeader>
+            <CardContent className="space-y-1 text-sm">
+              {quiet.map(cell => (
+                <div key={`${cell.day}:${cell.hour}`} className="flex justify-between">
+                  <span>{formatSlot(cell)}</span>
+                  <span className="text-muted-foreground">{percent(cell.occupancy)} full</span>
+                </div>
+              ))}
+            </CardContent>
+          </Card>
+        )}
+      </MobileContainer>
+    </Layout>
+  );
+};
+
+export default OccupancyPage;
@@ -0,0 +1,205 @@
+import React, { useState } from 'react';
+import { Layout } from '@/components/Layout';
+import { LaunchMonitorImportCard } from '@/components/golf/LaunchMonitorImportCard';
+import { SensorImportCard } from '@/components/golf/SensorImportCard';
+import { SimulatorImportCard } from '@/components/golf/SimulatorImportCard';
+import MobileContainer from '@/components/MobileContainer';
+import { Badge } from '@/components/ui/badge';
+import { Button } from '@/components/ui/button';
+import { Card, CardContent, CardDescription, CardHeader, CardTitle } from '@/components/ui/card';
+import { Input } from '@/components/ui/input';
+import { Label } from '@/components/ui/label';
+import { Select, SelectContent, SelectItem, SelectTrigger, SelectValue } from '@/components/ui/select';
+import { Skeleton } from '@/components/ui/skeleton';
+import { Textarea } from '@/components/ui/textarea';
+import { useCurrentUser } from '@/hooks/useCurrentUser';
+import { useGolfBag } from '@/hooks/useGolfBag';
+import { usePracticeSessions } from '@/hooks/usePracticeSessions';
+import { useToast } from '@/hooks/useToast';
+import { DEFAULT_BAG } from '@/lib/golf/bagEngine';
+import type { PracticeType } from '@/lib/golf/practiceEngine';
+
+const TYPE_LABELS: Record<PracticeType, string> = {
+  range: 'Range',
+  'short-game': 'Short game',
+  putting: 'Putting',
+  simulator: 'Simulator',
+};
+
+export const PracticePage: React.FC = () => {
+  const { user } = useCurrentUser();
+  const { data: bag } = useGolfBag(user?.pubkey);
+  const { sessions, stats, isLoading, saveSession, isSaving } = usePracticeSessions(user?.pubkey);
+  const { toast } = useToast();
+  const clubs = bag?.clubs ?? DEFAULT_BAG;
+  const clubLabel = (clubId: string) => clubs.find(c => c.id === clubId)?.label ?? clubId;
+
+  const [type, setType] = useState<PracticeType>('range');
+  const [location, setLocation] = useState('');
+  const [minutes, setMinutes] = useState('');
+  const [blocks, setBlocks] = useState<{ clubId: string; balls: string }[]>([{ clubId: '', balls: '' }]);
+  const [notes, setNotes] = useState('');
+
+  const updateBlock = (i: number, change: Partial<{ clubId: string; balls: string }>) =>
+    setBlocks(current => current.map((b, j) => (j === i ? { ...b, ...change } : b)));
+
+  const handleSave = async () => {
+    try {
+      await saveSession({
+        date: Date.now(),
+        type,
+        location: location.trim() || undefined,
+        durationMinutes: Number(minutes) || undefined,
+        blocks: blocks.filter(b => b.clubId || b.balls).map(b => ({ clubId: b.clubId, balls: Number(b.balls) })),
+        notes: notes.trim() || undefined,
+      });
+      toast({ title: 'Practice saved' });
+      setBlocks([{ clubId: '', balls: '' }]);
+      setNotes('');
+    } catch (error) {
+      toast({
+        title: 'Could not save the session',
+        description: error instanceof Error ? error.message : 'Please try again',
+        variant: 'destructive',
+      });
+    }
+  };
+
+  if (!user) {
+    return (
+      <Layout>
+        <MobileContainer className="py-4">
+          <Card>
+            <CardContent className="py-6">
+              <p className="text-sm text-muted-foreground">Log in to track your practice.</p>
+            </CardContent>
+          </Card>
+        </MobileContainer>
+      </Layout>
+    );
+  }
+
+  return (
+    <Layout>
+      <MobileContainer className="py-4 space-y-4">
+        <Card>
+          <CardHeader>
+            <CardTitle>Practice</CardTitle>
+            <CardDescription>
+              {stats.sessions} {stats.sessions === 1 ? 'session' : 'sessions'} · {stats.totalBalls} balls ·
+              {' '}{stats.minutesPractised} minutes. Practice never counts towards your handicap.
+            </CardDescription>
+          </CardHeader>
+          <CardContent className="space-y-2">
+            {isLoading ? (
+              <Skeleton className="h-16 w-full" />
+            ) : stats.byClub.length === 0 ? (
+              <p className="text-sm text-muted-foreground">No practice logged yet.</p>
+            ) : (
+              stats.byClub.map(club => (
+                <div key={club.clubId} className="flex items-center justify-between text-sm">
+                  <span>{clubLabel(club.clubId)}</span>
+                  <span className="text-muted-foreground">
+                    {club.balls} balls
+                    {club.averageCarry !== null && ` · ${club.averageCarry} yd carry`}
+                    {club.carryDeviation !== null && ` ± ${club.carryDeviation}`}
+                  </span>
+                </div>
+              ))
+            )}
+          </CardContent>
+        </Card>
+
+        <Card>
+          <CardHeader>
+            <CardTitle className="text-lg">Log a Session</CardTitle>
+          </CardHeader>
+          <CardContent className="space-y-4">
+            <div className="grid grid-cols-2 gap-3">
+              <div className="space-y-2">
+                <Label>Type</Label>
+                <Select value={type} onValueChange={(value) => setType(value as PracticeType)}>
+                  <SelectTrigger>
+                    <SelectValue />
+                  </SelectTrigger>
+                  <SelectContent>
+                    {(Object.keys(TYPE_LABELS) as PracticeType[]).map(t => (
+                      <SelectItem key={t} value={t}>{TYPE_LABELS[t]}</SelectItem>
+                    ))}
+                  </SelectContent>
+                </Select>
+              </div>
+              <div className="space-y-2">
+                <Label htmlFor="practice-minutes">Minutes</Label>
+                <Input id="practice-minutes" type="number" min={1} value={minutes} onChange={(e) => setMinutes(e.target.value)} />
+              </div>
+            </div>
+            <div className="space-y-2">
+              <Label htmlFor="practice-location">Where</Label>
+              <Input id="practice-location" value={location} onChange={(e) => setLocation(e.target.value)} placeholder="Club range" />
+            </div>
+            {blocks.map((block, i) => (
+              <div key={i} className="grid grid-cols-2 gap-3">
+                <Select value={block.clubId} onValueChange={(clubId) => updateBlock(i, { clubId })}>
+                  <SelectTrigger>
+                    <SelectValue placeholder="Club" />
+                  </SelectTrigger>
+                  <SelectContent>
+                    {clubs.map(club => (
+                      <SelectItem key={club.id} value={club.id}>{club.label}</SelectItem>
+                    ))}
+                  </SelectContent>
+                </Select>
+                <Input
+                  type="number"
+                  min={1}
+                  value={block.balls}
+                  onChange={(e) => updateBlock(i, { balls: e.target.value })}
+                  placeholder="Balls"
+                />
+              </div>
+            ))}
+            <Button variant="outline" size="sm" onClick={() => setBlocks(current => [...current, { clubId: '', balls: '' }])}>
+              Add Club
+            </Button>
+            <div className="space-y-2">
+              <Label htmlFor="practice-notes">Notes</Label>
+              <Textarea id="practice-notes" value={notes} onChange={(e) => setNotes(e.target.value)} />
+            </div>
+            <Button className="w-full" onClick={handleSave} disabled={isSaving}>Save Session</Button>
+          </CardContent>
+        </Card>
+
+        <LaunchMonitorImportCard />
+
+        <SensorImportCard />
+
+        <SimulatorImportCard />
+
+        {sessions.length > 0 && (
+          <Card>
+            <CardHeader>
+              <CardTitle className="text-lg">Recent Sessions</CardTitle>
+            </CardHeader>
+            <CardContent className="space-y-2">
+              {sessions.slice(0, 10).map(session => (
+                <div key={session.sessionId} className="rounded border p-3 space-y-1">
+                  <div className="flex items-center justify-between">
+                    <span className="text-sm font-medium">{new Date(session.date).toLocaleDateString()}</span>
+                    <Badge variant="outline">{TYPE_LABELS[session.type]}</Badge>
+                  </div>
+                  <div className="text-xs text-muted-foreground">
+                    {session.blocks.map(b => `${clubLabel(b.clubId)} × ${b.balls}`).join(', ')}
+                    {session.location && ` · ${session.location}`}
+                  </div>
+                </div>
+              ))}
+            </CardContent>
+          </Card>
+        )}
+      </MobileContainer>
+    </Layout>
+  );
+};
+
+export default PracticePage;
@@ -0,0 +1,108 @@
+import React from 'react';
+import { Download } from 'lucide-react';
+import { Layout } from '@/components/Layout';
+import MobileContainer from '@/components/MobileContainer';
+import { Card, CardContent, CardDescription, CardHeader, CardTitle } from '@/components/ui/card';
+import { Button } from '@/components/ui/button';
+import { Skeleton } from '@/components/ui/skeleton';
+import { usePublicStats } from '@/hooks/usePublicStats';
+import { ROUNDS_PER_PLAYER } from '@/lib/golf/statsEngine';
+
+const formatHour = (hour: number) => `${String(hour).padStart(2, '0')}:00`;
+
+export const PublicStatsPage: React.FC = () => {
+  const { data: stats, isLoading } = usePublicStats();
+
+  const handleDownload = () => {
+    if (!stats) return;
+    const blob = new Blob([JSON.stringify({ ...stats, generatedAt: new Date(stats.generatedAt).toISOString() }, null, 2)], { type: 'application/json' });
+    const url = URL.createObjectURL(blob);
+    const a = document.createElement('a');
+    a.href = url;
+    a.download = `pinseekr-stats-${new Date(stats.generatedAt).toISOString().split('T')[0]}.json`;
+    document.body.appendChild(a);
+    a.click();
+    document.body.removeChild(a);
+    URL.revokeObjectURL(url);
+  };
+
+  const busiest = Math.max(1, ...(stats?.hours ?? []).map(h => h.rounds));
+
+  return (
+    <Layout>
+      <MobileContainer className="py-4 space-y-4">
+        <Card>
+          <CardHeader className="flex flex-row items-center justify-between space-y-0">
+            <div>
+              <CardTitle>Golf Statistics</CardTitle>
+              <CardDescription>Rounds played on Pinseekr over the last year</CardDescription>
+            </div>
+            <Button variant="outline" size="sm" disabled={!stats} onClick={handleDownload}>
+              <Download className="h-4 w-4 mr-2" />
+              JSON
+            </Button>
+          </CardHeader>
+          <CardContent>
+            {isLoading ? (
+              <Skeleton className="h-48 w-full" />
+            ) : !stats || stats.courses.length === 0 ? (
+              <p className="text-sm text-muted-foreground">Not enough rounds yet to publish statistics for any course.</p>
+            ) : (
+              <table className="w-full text-sm">
+                <thead>
+                  <tr className="text-left text-muted-foreground">
+                    <th className="font-normal">Course</th>
+                    <th className="font-normal text-right">Rounds</th>
+                    <th className="font-normal text-right">Avg score</th>
+                    <th className="font-normal text-right">Aces</th>
+                  </tr>
+                </thead>
+                <tbody>
+                  {stats.courses.map(c => (
+                    <tr key={c.course} className="border-t">
+                      <td className="py-1 pr-2">{c.course}</td>
+                      <td className="py-1 text-right">{c.rounds}</td>
+                      <td className="py-1 text-right">{c.averageScore ?? '—'}</td>
+                      <td className="py-1 text-right">{c.aces}</td>
+                    </tr>
+                  ))}
+                </tbody>
+              </table>
+            )}
+          </CardContent>
+        </Card>
+
+        {stats && stats.hours.length > 0 && (
+          <Card>
+            <CardHeader>
+              <CardTitle className="text-lg">Busiest Tee Times</CardTitle>
+              <CardDescription>Rounds by the hour they teed off, in your timezone</CardDescription>
+            </CardHeader>
+            <CardContent className="space-y-1 text-sm">
+              {stats.hours.map(h => (
+                <div key={h.hour} className="flex items-center gap-2">
+                  <span className="w-12 text-muted-foreground">{formatHour(h.hour)}</span>
+                  <div className="h-3 rounded bg-primary" style={{ width: `${(h.rounds / busiest) * 100}%` }} />
+                  <span className="text-xs text-muted-foreground">{h.rounds}</span>
+                </div>
+              ))}
+            </CardContent>
+          </Card>
+        )}
+
+        <Card>
+          <CardContent className="py-4 text-xs text-muted-foreground space-y-1">
+            <p>
+              Courses and hours with fewer than {stats?.k ?? 5} different players are left out. Every figure has random
+              (Laplace) noise added, with a privacy budget of ε = {stats?.epsilon ?? 1} per figure, and no player adds more
+              than {ROUNDS_PER_PLAYER} rounds to any count. Averages use complete 18-hole cards, each player counted once.
+            </p>
+            <p>Private rounds are never included. Rounds are shared on Nostr, so this page works from what your relays return.</p>
+          </CardContent>
+        </Card>
+      </MobileContainer>
+    </Layout>
+  );
+};
+
+export default PublicStatsPage;
@@ -0,0 +1,310 @@
+import React, { useEffect, useState } from 'react';
+import { useParams } from 'react-router-dom';
+import { Layout } from '@/components/Layout';
+import MobileContainer from '@/components/MobileContainer';
+import { Button } from '@/components/ui/button';
+import { Card, CardContent, CardDescription, CardHeader, CardTitle } from '@/components/ui/card';
+import { Input } from '@/components/ui/input';
+import { Label } from '@/components/ui/label';
+import { Skeleton } from '@/components/ui/skeleton';
+import { Select, SelectContent, SelectItem, SelectTrigger, SelectValue } from '@/components/ui/select';
+import { useAuthor } from '@/hooks/useAuthor';
+import { useCurrentUser } from '@/hooks/useCurrentUser';
+import { useGolfCourses } from '@/hooks/useGolfCourses';
+import { useRentals } from '@/hooks/useRentals';
+import { useToast } from '@/hooks/useToast';
+import { genUserName } from '@/lib/genUserName';
+import {
+  DEFAULT_RENTAL_HOURS,
+  RENTAL_ITEM_NAMES,
+  rentalTotal,
+  type RentalItem,
+  type RentalItemType,
+  type RentalReservation,
+} from '@/lib/golf/rentalEngine';
+import { v4 as uuidv4 } from 'uuid';
+
+function toDateInput(date: Date): string {
+  const pad = (n: number) => String(n).padStart(2, '0');
+  return `${date.getFullYear()}-${pad(date.getMonth() + 1)}-${pad(date.getDate())}`;
+}
+
+function formatWindow(reservation: RentalReservation): string {
+  const from = new Date(reservation.startsAt);
+  const to = new Date(reservation.endsAt);
+  return `${from.toLocaleDateString()} ${from.toLocaleTimeString([], { hour: '2-digit', minute: '2-digit' })}–${to.toLocaleTimeString([], { hour: '2-digit', minute: '2-digit' })}`;
+}
+
+function CheckoutRow({ reservation, item, currency }: { reservation: RentalReservation; item?: RentalItem; currency: string }) {
+  const author = useAuthor(reservation.renter);
+  const name = author.data?.metadata?.name ?? genUserName(reservation.renter);
+  return (
+    <div className="flex justify-between text-sm">
+      <span>
+        {new Date(reservation.startsAt).toLocaleTimeString([], { hour: '2-digit', minute: '2-digit' })} · {name} · {reservation.quantity}× {item?.name ?? 'Removed item'}
+      </span>
+      <span className="text-muted-foreground">{((item?.price ?? 0) * reservation.quantity).toFixed(2)} {currency}</span>
+    </div>
+  );
+}
+
+export const RentalsPage: React.FC = () => {
+  const { courseId } = useParams<{ courseId: string }>();
+  const { user } = useCurrentUser();
+  const { toast } = useToast();
+  const { data: courses = [], isLoading: coursesLoading } = useGolfCourses();
+  const course = courses.find(c => c.id === courseId);
+  const { inventory, items, confirmed, mine, isLoading, available, saveInventory, reserve, cancelReservation, isPublishing } = useRentals(course);
+
+  const [date, setDate] = useState(toDateInput(new Date()));
+  const [time, setTime] = useState('09:00');
+  const [hours, setHours] = useState(String(DEFAULT_RENTAL_HOURS));
+  const [quantities, setQuantities] = useState<Record<string, number>>({});
+
+  // Club inventory editor
+  const [draftItems, setDraftItems] = useState<RentalItem[]>([]);
+  const [currency, setCurrency] = useState('USD');
+  const [newType, setNewType] = useState<RentalItemType>('buggy');
+  const [newName, setNewName] = useState('');
+  const [newCount, setNewCount] = useState('');
+  const [newPrice, setNewPrice] = useState('');
+
+  useEffect(() => {
+    setDraftItems(inventory?.items ?? []);
+    setCurrency(inventory?.currency ?? 'USD');
+  }, [inventory]);
+
+  const isClub = !!user && user.pubkey === course?.author;
+  const startsAt = new Date(`${date}T${time}`).getTime();
+  const endsAt = startsAt + (parseFloat(hours) || DEFAULT_RENTAL_HOURS) * 60 * 60 * 1000;
+  const dayStart = new Date(`${date}T00:00`).getTime();
+  const dayEnd = dayStart + 24 * 60 * 60 * 1000;
+  const dayReservations = confirmed
+    .filter(r => r.startsAt < dayEnd && r.endsAt > dayStart)
+    .sort((a, b) => a.startsAt - b.startsAt);
+
+  const handleReserve = async (item: RentalItem) => {
+    if (isNaN(startsAt)) {
+      toast({ title: 'Choose a date and time', variant: 'destructive' });
+      return;
+    }
+    try {
+      await reserve({ itemId: item.id, quantity: quantities[item.id] ?? 1, startsAt, endsAt });
+      toast({ title: `${item.name} reserved` });
+    } catch (error) {
+      toast({ title: 'Could not reserve', description: error instanceof Error ? error.message : undefined, variant: 'destructive' });
+    }
+  };
+
+  const handleCancel = async (reservation: RentalReservation) => {
+    try {
+      await cancelReservation(reservation);
+      toast({ title: 'Reservation cancelled' });
+    } catch (error) {
+      toast({ title: 'Could not cancel', description: error instanceof Error ? error.message : undefined, variant: 'destructive' });
+    }
+  };
+
+  const addItem = () => {
+    const count = parseInt(newCount);
+    if (!newName.trim() || !(count > 0)) {
+      toast({ title: 'Enter a name and how many you have', variant: 'destructive' });
+      return;
+    }
+    setDraftItems([...draftItems, { id: uuidv4(), type: newType, name: newName.trim(), count, price: parseFloat(newPrice) || 0 }]);
+    setNewName('');
+    setNewCount('');
+    setNewPrice('');
+  };
+
+  const handleSaveInventory = async () => {
+    try {
+      await saveInventory({ items: draftItems, currency: currency.trim().toUpperCase() || 'USD' });
+      toast({ title: 'Inventory saved' });
+    } catch (error) {
+      toast({ title: 'Could not save the inventory', description: error instanceof Error ? error.message : undefined, variant: 'destructive' });
+    }
+  };
+
+  if (coursesLoading) {
+    return (
+      <Layout>
+        <MobileContainer className="py-4">
+          <Skeleton className="h-32 w-full" />
+        </MobileContainer>
+      </Layout>
+    );
+  }
+
+  if (!course) {
+    return (
+      <Layout>
+        <MobileContainer className="py-4">
+          <Card>
+            <CardContent className="py-6 text-sm text-muted-foreground">Course not found.</CardContent>
+          </Card>
+        </MobileContainer>
+      </Layout>
+    );
+  }
+
+  const itemFor = (reservation: RentalReservation) => items.find(i => i.id === reservation.itemId);
+  const shopCurrency = inventory?.currency ?? 'USD';
+
+  return (
+    <Layout>
+      <MobileContainer className="py-4 space-y-4">
+        <Card>
+          <CardHeader>
+            <CardTitle>{course.name}</CardTitle>
+            <CardDescription>Buggy, trolley and club hire</CardDescription>
+          </CardHeader>
+          <CardContent className="space-y-3">
+            <div className="grid grid-cols-3 gap-3">
+              <div className="space-y-2">
+                <Label htmlFor="rental-date">Date</Label>
+                <Input id="rental-date" type="date" value={date} onChange={(e) => setDate(e.target.value)} />
+              </div>
+              <div className="space-y-2">
+                <Label htmlFor="rental-time">From</Label>
+                <Input id="rental-time" type="time" value={time} onChange={(e) => setTime(e.target.value)} />
+              </div>
+              <div className="space-y-2">
+                <Label htmlFor="rental-hours">Hours</Label>
+                <Input id="rental-hours" type="number" step="0.5" value={hours} onChange={(e) => setHours(e.target.value)} />
+              </div>
+            </div>
+
+            {isLoading ? (
+              <Skeleton className="h-16 w-full" />
+            ) : items.length === 0 ? (
+              <p className="text-sm text-muted-foreground">This club hasn't listed any equipment for hire.</p>
+            ) : (
+              items.map(item => {
+                const free = isNaN(startsAt) ? 0 : available(item, startsAt, endsAt);
+                return (
+                  <div key={item.id} className="flex items-center justify-between gap-2 rounded border p-3">
+                    <div className="min-w-0">
+                      <div className="text-sm font-medium">{item.name}</div>
+                      <div className="text-xs text-muted-foreground">
+                        {RENTAL_ITEM_NAMES[item.type]} · {item.price.toFixed(2)} {shopCurrency} · {free} of {item.count} free
+                      </div>
+                    </div>
+                    <div className="flex items-center gap-2">
+                      <Input
+                        type="number"
+                        min={1}
+                        max={Math.max(1, free)}
+                        className="w-16"
+                        value={quantities[item.id] ?? 1}
+                        onChange={(e) => setQuantities({ ...quantities, [item.id]: Math.max(1, parseInt(e.target.value) || 1) })}
+                      />
+                      <Button size="sm" disabled={!user || free === 0 || isPublishing} onClick={() => handleReserve(item)}>
+                        Reserve
+                      </Button>
+                    </div>
+                  </div>
+                );
+              })
+            )}
+          </CardContent>
+        </Card>
+
+        {mine.length > 0 && (
+          <Card>
+            <CardHeader>
+              <CardTitle className="text-lg">My Reservations</CardTitle>
+            </CardHeader>
+            <CardContent className="space-y-2">
+              {mine.map(reservation => (
+                <div key={reservation.reservationId} className="flex items-center justify-between gap-2 text-sm">
+                  <span>{reservation.quantity}× {itemFor(reservation)?.name} · {formatWindow(reservation)}</span>
+                  <Button size="sm" variant="outline" disabled={isPublishing} onClick={() => handleCancel(reservation)}>
+                    Cancel
+                  </Button>
+                </div>
+              ))}
+            </CardContent>
+          </Card>
+        )}
+
+        {isClub && (
+          <Card>
+            <CardHeader>
+              <CardTitle className="text-lg">Pro Shop</CardTitle>
+              <CardDescription>Equipment going out on {new Date(dayStart).toLocaleDateString()}</CardDescription>
+            </CardHeader>
+            <CardContent className="space-y-2">
+              {dayReservations.length === 0 ? (
+                <p className="text-sm text-muted-foreground">No reservations for this day.</p>
+              ) : (
+                <>
+                  {dayReservations.map(reservation => (
+                    <CheckoutRow key={`${reservation.renter}:${reservation.reservationId}`} reservation={reservation} item={itemFor(reservation)} currency={shopCurrency} />
+                  ))}
+                  <div className="flex justify-between border-t pt-2 text-sm font-medium">
+                    <span>Total</span>
+                    <span>{rentalTotal(items, dayReservations).toFixed(2)} {shopCurrency}</span>
+                  </div>
+                </>
+              )}
+            </CardContent>
+          </Card>
+        )}
+
+        {isClub && (
+          <Card>
+            <CardHeader>
+              <CardTitle className="text-lg">Inventory</CardTitle>
+            </CardHeader>
+            <CardContent className="space-y-3">
+              {draftItems.map(item => (
+                <div key={item.id} className="flex items-center justify-between text-sm">
+                  <span>{item.count}× {item.name} · {item.price.toFixed(2)}</span>
+                  <Button size="sm" variant="ghost" onClick={() => setDraftItems(draftItems.filter(i => i.id !== item.id))}>
+                    Remove
+                  </Button>
+                </div>
+              ))}
+              <div className="grid grid-cols-2 gap-3">
+                <div className="space-y-2">
+                  <Label>Type</Label>
+                  <Select value={newType} onValueChange={(value) => setNewType(value as RentalItemType)}>
+                    <SelectTrigger>
+                      <SelectValue />
+                    </SelectTrigger>
+                    <SelectContent>
+                      {(Object.keys(RENTAL_ITEM_NAMES) as RentalItemType[]).map(t => (
+                        <SelectItem key={t} value={t}>{RENTAL_ITEM_NAMES[t]}</SelectItem>
+                      ))}
+                    </SelectContent>
+                  </Select>
+                </div>
+                <div className="space-y-2">
+                  <Label htmlFor="rental-name">Name</Label>
+                  <Input id="rental-name" value={newName} onChange={(e) => setNewName(e.target.value)} placeholder="Electric buggy" />
+                </div>
+                <div className="space-y-2">
+                  <Label htmlFor="rental-count">Units</Label>
+                  <Input id="rental-count" type="number" value={newCount} onChange={(e) => setNewCount(e.target.value)} />
+                </div>
+                <div className="space-y-2">
+                  <Label htmlFor="rental-price">Price</Label>
+                  <Input id="rental-price" type="number" step="0.01" value={newPrice} onChange={(e) => setNewPrice(e.target.value)} />
+                </div>
+              </div>
+              <Button variant="outline" className="w-full" onClick={addItem}>Add Item</Button>
+              <div className="space-y-2">
+                <Label htmlFor="rental-currency">Currency</Label>
+                <Input id="rental-currency" value={currency} onChange={(e) => setCurrency(e.target.value)} />
+              </div>
+              <Button className="w-full" onClick={handleSaveInventory} disabled={isPublishing}>Save Inventory</Button>
+            </CardContent>
+          </Card>
+        )}
+      </MobileContainer>
+    </Layout>
+  );
+};
+
+export default RentalsPage;
@@ -3,16 +3,51 @@ import { useLocation, useNavigate } from 'react-router-dom';
 import { Layout } from '@/components/Layout';
 import MobileContainer from '@/components/MobileContainer';
 import { ScoreCard } from '@/components/scoring/ScoreCard';
+import { ScoreAttestationPanel } from '@/components/scoring/ScoreAttestationPanel';
+import { SponsorBanner } from '@/components/golf/SponsorBanner';
+import { RoundSocialPanel } from '@/components/golf/RoundSocialPanel';
+import { useCurrentUser } from '@/hooks/useCurrentUser';
+import { useCaddie } from '@/hooks/useCaddie';
+import { useCourseHandicap } from '@/hooks/useCourseHandicap';
+import { teeRating, useGolfCourses } from '@/hooks/useGolfCourses';
+import { useHoleDetection } from '@/hooks/useHoleDetection';
+import { useOfflineRound } from '@/hooks/useOfflineRound';
+import type { DetectionHole } from '@/lib/golf/holeDetection';
 import type { GolfRound } from '@/lib/golf/types';
 
 export const ScoreEntryPage: React.FC = () => {
   const location = useLocation();
   const navigate = useNavigate();
+  const { user } = useCurrentUser();
+  const { addHoleScore } = useOfflineRound();
 
   // Expect a round to be provided via navigation state { round }
   const state = location.state as { round?: GolfRound } | null;
   const round = state?.round;
 
+  // Follow the player round the course when the course has its greens mapped
+  const { data: courses } = useGolfCourses();
+  const course = courses?.find(c => c.id === round?.courseId || c.name === round?.metadata.courseName);
+  const greens = course?.greens;
+  const detectionHoles = React.useMemo<DetectionHole[]>(
+    () => Object.entries(greens ?? {}).map(([hole, green]) => ({ number: Number(hole), green })),
+    [greens]
+  );
+  const { currentHole: detectedHole, position } = useHoleDetection(detectionHoles, {
+    enabled: round?.status === 'active' && detectionHoles.length > 0,
+  });
+
+  // Club for the shot into the current green, with slope from the elevation tiles
+  const { recommendation } = useCaddie(user?.pubkey, position, detectedHole ? greens?.[detectedHole] ?? null : null);
+
+  // The player's strokes for the tee they're playing, when the tee is rated
+  const rating = course ? teeRating(course, round?.metadata.teeBox) : undefined;
+  const tee = React.useMemo(
+    () => (rating && course ? { ...rating, par: course.totalPar } : undefined),
+    [rating, course]
+  );
+  const { data: courseHandicap } = useCourseHandicap(user?.pubkey, tee);
+
   React.useEffect(() => {
     if (!round) {
       navigate('/round/new');
@@ -24,6 +59,17 @@ export const ScoreEntryPage: React.FC = () => {
   const handleUpdateRound = (updated: GolfRound) => {
     // For now, simply replace history state so user can continue editing
     navigate(location.pathname, { replace: true, state: { round: updated } });
+
+    // Keep the player's own holes on this device and queue their card, so
+    // scores entered without signal are published once back online
+    if (!user) return;
+    const before = round.players.find(p => p.playerId === user.pubkey)?.scores ?? [];
+    const after = updated.players.find(p => p.playerId === user.pubkey)?.scores ?? [];
+    after.forEach((strokes, i) => {
+      if (strokes > 0 && strokes !== before[i]) {
+        addHoleScore(updated.id, user.pubkey, i + 1, strokes).catch(err => console.warn('Could not save hole score offline', err));
+      }
+    });
   };
 
   const handleSaveRound = () => {
@@ -38,13 +84,31 @@ export const ScoreEntryPage: React.FC = () => {
   return (
     <Layout>
       <MobileContainer className="p-4">
+        <SponsorBanner courseId={round.courseId} courseName={round.metadata.courseName} placement="scorecard" className="mb-4" />
+        {courseHandicap && (
+          <p className="mb-4 text-sm text-muted-foreground">
+            Index {courseHandicap.handicapIndex.toFixed(1)} · Course handicap {courseHandicap.courseHandicap} · Playing handicap {courseHandicap.playingHandicap}
+          </p>
+        )}
+        {recommendation && (
+          <p className="mb-4 text-sm">
+            Hole {detectedHole}: {recommendation.distance} yds to the green
+            {recommendation.playsLike !== recommendation.distance && `, plays ${recommendation.playsLike}`}
+            {recommendation.suggestion && ` · ${recommendation.suggestion.club.label}`}
+          </p>
+        )}
         <ScoreCard
           round={round}
           course={null}
           onUpdateRound={handleUpdateRound}
           onSaveRound={handleSaveRound}
           onShareRound={handleShareRound}
+          detectedHole={detectedHole}
         />
+        <div className="mt-4">
+          <ScoreAttestationPanel roundId={round.id} players={round.players} />
+        </div>
+        <RoundSocialPanel roundId={round.id} className="mt-4" />
       </MobileContainer>
     </Layout>
   );
@@ -0,0 +1,136 @@
+import React, { useEffect, useState } from 'react';
+import { useParams } from 'react-router-dom';
+import QRCode from 'qrcode';
+import { Layout } from '@/components/Layout';
+import MobileContainer from '@/components/MobileContainer';
+import { Button } from '@/components/ui/button';
+import { Card, CardContent, CardDescription, CardHeader, CardTitle } from '@/components/ui/card';
+import { Input } from '@/components/ui/input';
+import { Label } from '@/components/ui/label';
+import { Select, SelectContent, SelectItem, SelectTrigger, SelectValue } from '@/components/ui/select';
+import { Skeleton } from '@/components/ui/skeleton';
+import { useScorerDevices } from '@/hooks/useScorerDevices';
+import { useToast } from '@/hooks/useToast';
+import { DEFAULT_DEVICE_HOURS } from '@/lib/golf/deviceEngine';
+
+function QrImage({ value, label }: { value: string; label: string }) {
+  const [src, setSrc] = useState('');
+
+  useEffect(() => {
+    QRCode.toDataURL(value, { width: 256, margin: 2 })
+      .then(setSrc)
+      .catch((err) => console.warn('Could not draw provisioning QR code', err));
+  }, [value]);
+
+  return src ? <img src={src} alt={label} className="mx-auto h-48 w-48" /> : <Skeleton className="mx-auto h-48 w-48" />;
+}
+
+export const ScorerDevicesPage: React.FC = () => {
+  const { tournamentId } = useParams<{ tournamentId: string }>();
+  const { draw, active, isLoading, isOrganizer, register, revoke, isPublishing } = useScorerDevices(tournamentId);
+  const { toast } = useToast();
+  const [group, setGroup] = useState('');
+  const [label, setLabel] = useState('');
+  const [hours, setHours] = useState(String(DEFAULT_DEVICE_HOURS));
+  const [link, setLink] = useState<{ url: string; group: number } | null>(null);
+
+  const handleRegister = async () => {
+    const duration = Number(hours);
+    if (!group || !(duration > 0)) {
+      toast({ title: 'Check the details', description: 'Pick a group and how long the device may score.', variant: 'destructive' });
+      return;
+    }
+    try {
+      const url = await register(Number(group), duration, label.trim() || `Group ${group} tablet`);
+      setLink({ url, group: Number(group) });
+      setLabel('');
+    } catch (error) {
+      toast({
+        title: 'Could not provision the device',
+        description: error instanceof Error ? error.message : 'Please try again',
+        variant: 'destructive',
+      });
+    }
+  };
+
+  return (
+    <Layout>
+      <MobileContainer className="py-4 space-y-4">
+        <Card>
+          <CardHeader>
+            <CardTitle>Scorer Devices</CardTitle>
+            <CardDescription>Tablets that score a group in {tournamentId}, with no member logged in</CardDescription>
+          </CardHeader>
+          <CardContent className="space-y-4">
+            {isLoading ? (
+              <Skeleton className="h-24 w-full" />
+            ) : !draw ? (
+              <p className="text-sm text-muted-foreground">Publish the draw for this tournament before provisioning devices.</p>
+            ) : !isOrganizer ? (
+              <p className="text-sm text-muted-foreground">Only the tournament organizer can provision devices.</p>
+            ) : (
+              <>
+                {active.map(device => (
+                  <div key={device.device} className="flex items-center justify-between gap-2 rounded border p-3">
+                    <div className="min-w-0">
+                      <div className="text-sm font-medium truncate">{device.label}</div>
+                      <div className="text-xs text-muted-foreground">
+                        Group {device.group} · until {new Date(device.until * 1000).toLocaleString()}
+                      </div>
+                    </div>
+                    <Button size="sm" variant="outline" disabled={isPublishing} onClick={() => revoke(device)}>
+                      Revoke
+                    </Button>
+                  </div>
+                ))}
+                <div className="grid grid-cols-2 gap-3">
+                  <div className="space-y-2">
+                    <Label>Group</Label>
+                    <Select value={group} onValueChange={setGroup}>
+                      <SelectTrigger>
+                        <SelectValue placeholder="Choose group" />
+                      </SelectTrigger>
+                      <SelectContent>
+                        {draw.groups.map(g => (
+                          <SelectItem key={g.group} value={String(g.group)}>
+                            Group {g.group} · {new Date(g.teeTime).toLocaleTimeString([], { hour: '2-digit', minute: '2-digit' })}
+                          </SelectItem>
+                        ))}
+                      </SelectContent>
+                    </Select>
+                  </div>
+                  <div className="space-y-2">
+                    <Label htmlFor="device-hours">Hours</Label>
+                    <Input id="device-hours" type="number" min={1} value={hours} onChange={(e) => setHours(e.target.value)} />
+                  </div>
+                </div>
+                <div className="space-y-2">
+                  <Label htmlFor="device-label">Name</Label>
+                  <Input id="device-label" value={label} onChange={(e) => setLabel(e.target.value)} placeholder="Tablet 3" />
+                </div>
+                <Button className="w-full" onClick={handleRegister} disabled={isPublishing}>Provision Device</Button>
+              </>
+            )}
+          </CardContent>
+        </Card>
+
+        {link && (
+          <Card>
+            <CardHeader>
+              <CardTitle className="text-lg">Scan on the Tablet</CardTitle>
+              <CardDescription>
+                The code holds the device's key. It is shown once; revoke the device if it is lost.
+              </CardDescription>
+            </CardHeader>
+            <CardContent className="space-y-3">
+              <QrImage value={link.url} label={`Provision a tablet for group ${link.group}`} />
+              <Button variant="outline" className="w-full" onClick={() => setLink(null)}>Done</Button>
+            </CardContent>
+          </Card>
+        )}
+      </MobileContainer>
+    </Layout>
+  );
+};
+
+export default ScorerDevicesPage;
@@ -0,0 +1,183 @@
+import React, { useMemo, useState } from 'react';
+import { Link } from 'react-router-dom';
+import { nip19 } from 'nostr-tools';
+import { Layout } from '@/components/Layout';
+import MobileContainer from '@/components/MobileContainer';
+import { Avatar, AvatarFallback, AvatarImage } from '@/components/ui/avatar';
+import { Badge } from '@/components/ui/badge';
+import { Card, CardContent, CardDescription, CardHeader, CardTitle } from '@/components/ui/card';
+import { Label } from '@/components/ui/label';
+import { Select, SelectContent, SelectItem, SelectTrigger, SelectValue } from '@/components/ui/select';
+import { Skeleton } from '@/components/ui/skeleton';
+import { useAuthor } from '@/hooks/useAuthor';
+import { useContacts } from '@/hooks/useContacts';
+import { useCurrentUser } from '@/hooks/useCurrentUser';
+import { useGolfCourses } from '@/hooks/useGolfCourses';
+import { useHandicapSettings } from '@/hooks/useHandicapSettings';
+import { useSeasonEclectic } from '@/hooks/useSeasonEclectic';
+import { genUserName } from '@/lib/genUserName';
+import type { SeasonConfig } from '@/lib/golf/seasonEngine';
+
+const ANY_COURSE = 'any';
+
+function PlayerRow({ pubkey, position, detail, value, isPlayer }: {
+  pubkey: string;
+  position: number;
+  detail: string;
+  value: string;
+  isPlayer: boolean;
+}) {
+  const author = useAuthor(pubkey);
+  const metadata = author.data?.metadata;
+  const name = metadata?.name ?? genUserName(pubkey);
+
+  return (
+    <div className={`flex items-center gap-3 rounded border p-3 ${isPlayer ? 'border-primary' : ''}`}>
+      <span className="w-6 text-center font-bold">{position}</span>
+      <Link to={`/${nip19.npubEncode(pubkey)}`}>
+        <Avatar className="h-8 w-8">
+          <AvatarImage src={metadata?.picture} />
+          <AvatarFallback className="text-xs">{name.charAt(0)}</AvatarFallback>
+        </Avatar>
+      </Link>
+      <div className="min-w-0 flex-1">
+        <div className="text-sm font-medium truncate">{name}</div>
+        <div className="text-xs text-muted-foreground">{detail}</div>
+      </div>
+      <Badge variant="secondary">{value}</Badge>
+    </div>
+  );
+}
+
+export const SeasonPage: React.FC = () => {
+  const { user } = useCurrentUser();
+  const { data: contacts = [] } = useContacts();
+  const { data: courses = [] } = useGolfCourses();
+  const { committeePubkeys, requireAttestation } = useHandicapSettings();
+
+  const thisYear = new Date().getFullYear();
+  const [year, setYear] = useState(String(thisYear));
+  const [course, setCourse] = useState(ANY_COURSE);
+
+  const config = useMemo<SeasonConfig>(() => ({
+    name: `${year} Season`,
+    startDate: new Date(Number(year), 0, 1).getTime(),
+    endDate: new Date(Number(year), 11, 31, 23, 59, 59).getTime(),
+    requireAttestation,
+    committee: committeePubkeys,
+    course: course === ANY_COURSE ? undefined : course,
+  }), [year, course, requireAttestation, committeePubkeys]);
+
+  // The player and everyone they follow
+  const players = useMemo(
+    () => (user ? [user.pubkey, ...contacts.map(c => c.pubkey).filter(p => p !== user.pubkey)] : []),
+    [user, contacts]
+  );
+  const { leaderboard, orderOfMerit, isLoading } = useSeasonEclectic(user ? config : undefined, players);
+
+  return (
+    <Layout>
+      <MobileContainer className="py-4 space-y-4">
+        <Card>
+          <CardHeader>
+            <CardTitle>Season</CardTitle>
+            <CardDescription>Eclectic and order of merit for you and the players you follow</CardDescription>
+          </CardHeader>
+          <CardContent>
+            <div className="grid grid-cols-2 gap-3">
+              <div className="space-y-2">
+                <Label>Season</Label>
+                <Select value={year} onValueChange={setYear}>
+                  <SelectTrigger>
+                    <SelectValue />
+                  </SelectTrigger>
+                  <SelectContent>
+                    {[thisYear, thisYear - 1, thisYear - 2].map(y => (
+                      <SelectItem key={y} value={String(y)}>{y}</SelectItem>
+                    ))}
+                  </SelectContent>
+                </Select>
+              </div>
+              <div className="space-y-2">
+                <Label>Course</Label>
+                <Select value={course} onValueChange={setCourse}>
+                  <SelectTrigger>
+                    <SelectValue />
+                  </SelectTrigger>
+                  <SelectContent>
+                    <SelectItem value={ANY_COURSE}>Any course</SelectItem>
+                    {courses.map(c => (
+                      <SelectItem key={c.id} value={c.name}>{c.name}</SelectItem>
+                    ))}
+                  </SelectContent>
+                </Select>
+              </div>
+            </div>
+          </CardContent>
+        </Card>
+
+        {!user ? (
+          <Card>
+            <CardContent className="py-6">
+              <p className="text-sm text-muted-foreground">Log in to see your season.</p>
+            </CardContent>
+          </Card>
+        ) : (
+          <>
+            <Card>
+              <CardHeader>
+                <CardTitle className="text-lg">Eclectic</CardTitle>
+                <CardDescription>Best score on each hole over the season</CardDescription>
+              </CardHeader>
+              <CardContent className="space-y-2">
+                {isLoading ? (
+                  Array.from({ length: 3 }, (_, i) => <Skeleton key={i} className="h-14 w-full" />)
+                ) : leaderboard.length === 0 ? (
+                  <p className="text-sm text-muted-foreground">No cards this season yet.</p>
+                ) : (
+                  leaderboard.map(entry => (
+                    <PlayerRow
+                      key={entry.playerId}
+                      pubkey={entry.playerId}
+                      position={entry.position}
+                      detail={`${entry.holesCovered} holes · ${entry.roundsCounted} ${entry.roundsCounted === 1 ? 'round' : 'rounds'}`}
+                      value={String(entry.total)}
+                      isPlayer={entry.playerId === user.pubkey}
+                    />
+                  ))
+                )}
+              </CardContent>
+            </Card>
+
+            <Card>
+              <CardHeader>
+                <CardTitle className="text-lg">Order of Merit</CardTitle>
+                <CardDescription>Points for each completed round, placed on gross score</CardDescription>
+              </CardHeader>
+              <CardContent className="space-y-2">
+                {isLoading ? (
+                  <Skeleton className="h-14 w-full" />
+                ) : orderOfMerit.length === 0 ? (
+                  <p className="text-sm text-muted-foreground">No completed rounds this season yet.</p>
+                ) : (
+                  orderOfMerit.map(entry => (
+                    <PlayerRow
+                      key={entry.playerId}
+                      pubkey={entry.playerId}
+                      position={entry.position}
+                      detail={`${entry.eventsPlayed} ${entry.eventsPlayed === 1 ? 'event' : 'events'} · ${entry.wins} ${entry.wins === 1 ? 'win' : 'wins'}`}
+                      value={`${entry.points} pts`}
+                      isPlayer={entry.playerId === user.pubkey}
+                    />
+                  ))
+                )}
+              </CardContent>
+            </Card>
+          </>
+        )}
+      </MobileContainer>
+    </Layout>
+  );
+};
+
+export default SeasonPage;
@@ -0,0 +1,170 @@
+import React, { useEffect, useMemo, useState } from 'react';
+import { useParams } from 'react-router-dom';
+import { nip19 } from 'nostr-tools';
+import { Pause, Play, RotateCcw } from 'lucide-react';
+import { Layout } from '@/components/Layout';
+import MobileContainer from '@/components/MobileContainer';
+import { Card, CardContent, CardDescription, CardHeader, CardTitle } from '@/components/ui/card';
+import { Button } from '@/components/ui/button';
+import { Skeleton } from '@/components/ui/skeleton';
+import { Slider } from '@/components/ui/slider';
+import { useAuthor } from '@/hooks/useAuthor';
+import { useShotReplay } from '@/hooks/useShotReplay';
+import { genUserName } from '@/lib/genUserName';
+import type { GeoPoint } from '@/lib/golf/caddieEngine';
+import { replayFrame, type ReplayHole } from '@/lib/golf/replayEngine';
+
+const VIEW = 300;
+const MARGIN = 20;
+const TICK_MS = 50;
+
+function toPubkey(value: string | undefined): string | null {
+  if (!value) return null;
+  if (/^[a-f0-9]{64}$/i.test(value)) return value.toLowerCase();
+  try {
+    const decoded = nip19.decode(value);
+    return decoded.type === 'npub' ? decoded.data : null;
+  } catch {
+    return null;
+  }
+}
+
+/** Fit a hole's shots into the square view, north up */
+function projection(hole: ReplayHole): (p: GeoPoint) => [number, number] {
+  const points = hole.shots.flatMap(s => s.path);
+  const lats = points.map(p => p.lat);
+  const lons = points.map(p => p.lon);
+  const scaleLon = Math.cos((Math.min(...lats) + Math.max(...lats)) / 2 * Math.PI / 180);
+  const width = (Math.max(...lons) - Math.min(...lons)) * scaleLon;
+  const height = Math.max(...lats) - Math.min(...lats);
+  const scale = (VIEW - 2 * MARGIN) / Math.max(width, height, 1e-6);
+
+  return p => [
+    MARGIN + (p.lon - Math.min(...lons)) * scaleLon * scale + (VIEW - 2 * MARGIN - width * scale) / 2,
+    MARGIN + (Math.max(...lats) - p.lat) * scale + (VIEW - 2 * MARGIN - height * scale) / 2,
+  ];
+}
+
+const line = (points: [number, number][]) => points.map(([x, y]) => `${x.toFixed(1)},${y.toFixed(1)}`).join(' ');
+
+export const ShotReplayPage: React.FC = () => {
+  const { roundId, npub } = useParams<{ roundId: string; npub: string }>();
+  const pubkey = toPubkey(npub);
+  const { data, isLoading } = useShotReplay(roundId, pubkey ?? undefined);
+  const author = useAuthor(pubkey ?? undefined);
+  const name = pubkey ? author.data?.metadata?.name ?? genUserName(pubkey) : '';
+
+  const [elapsed, setElapsed] = useState(0);
+  const [playing, setPlaying] = useState(false);
+  const duration = data?.duration ?? 0;
+
+  useEffect(() => {
+    if (!playing) return;
+    const id = setInterval(() => setElapsed(e => Math.min(e + TICK_MS, duration)), TICK_MS);
+    return () => clearInterval(id);
+  }, [playing, duration]);
+
+  useEffect(() => {
+    if (playing && elapsed >= duration) setPlaying(false);
+  }, [playing, elapsed, duration]);
+
+  const frame = data ? replayFrame(data.holes, elapsed) : null;
+  const hole = data?.holes.find(h => h.hole === frame?.hole);
+  const project = useMemo(() => (hole ? projection(hole) : null), [hole]);
+
+  const handlePlay = () => {
+    if (elapsed >= duration) setElapsed(0);
+    setPlaying(p => !p);
+  };
+
+  return (
+    <Layout>
+      <MobileContainer className="py-4 space-y-4">
+        <Card>
+          <CardHeader>
+            <CardTitle>Shot Tracer</CardTitle>
+            <CardDescription>{pubkey ? `${name}'s round, hole by hole` : 'Not a valid player link'}</CardDescription>
+          </CardHeader>
+          {pubkey && (
+            <CardContent className="space-y-4">
+              {isLoading ? (
+                <Skeleton className="aspect-square w-full" />
+              ) : !data || data.holes.length === 0 ? (
+                <p className="text-sm text-muted-foreground">
+                  {data?.shots
+                    ? 'The shots from this round were recorded without positions, so there is nothing to trace.'
+                    : 'No shots were recorded for this round.'}
+                </p>
+              ) : (
+                <>
+                  <svg viewBox={`0 0 ${VIEW} ${VIEW}`} className="w-full rounded bg-green-50 dark:bg-green-950">
+                    {hole && project && hole.shots.map(shot => (
+                      shot.startsAt < elapsed && shot !== frame?.shot && (
+                        <polyline
+                          key={shot.shotId}
+                          points={line(shot.path.map(project))}
+                          fill="none"
+                          className="stroke-muted-foreground"
+                          strokeWidth={1.5}
+                          strokeDasharray="4 3"
+                        />
+                      )
+                    ))}
+                    {frame && project && (
+                      <>
+                        <polyline points={line(frame.trail.map(project))} fill="none" className="stroke-primary" strokeWidth={2.5} />
+                        <circle cx={project(frame.point)[0]} cy={project(frame.point)[1]} r={5} className="fill-white stroke-primary" strokeWidth={2} />
+                      </>
+                    )}
+                  </svg>
+
+                  {frame && (
+                    <div className="flex items-center justify-between text-sm">
+                      <span className="font-medium">
+                        Hole {frame.hole} · shot {hole!.shots.indexOf(frame.shot) + 1}
+                      </span>
+                      <span className="text-muted-foreground">
+                        {frame.shot.clubId} · {Math.round(frame.shot.distance)} yds ·{' '}
+                        {new Date(frame.time).toLocaleTimeString([], { hour: '2-digit', minute: '2-digit' })}
+                      </span>
+                    </div>
+                  )}
+
+                  <div className="flex items-center gap-3">
+                    <Button size="icon" variant="outline" onClick={handlePlay} aria-label={playing ? 'Pause' : 'Play'}>
+                      {playing ? <Pause className="h-4 w-4" /> : <Play className="h-4 w-4" />}
+                    </Button>
+                    <Slider
+                      value={[elapsed]}
+                      max={duration}
+                      step={TICK_MS}
+                      onValueChange={([value]) => setElapsed(value)}
+                    />
+                    <Button size="icon" variant="ghost" onClick={() => { setPlaying(false); setElapsed(0); }} aria-label="Restart">
+                      <RotateCcw className="h-4 w-4" />
+                    </Button>
+                  </div>
+
+                  <div className="flex flex-wrap gap-1">
+                    {data.holes.map(h => (
+                      <Button
+                        key={h.hole}
+                        size="sm"
+                        variant={h.hole === frame?.hole ? 'secondary' : 'outline'}
+                        onClick={() => setElapsed(h.shots[0].startsAt)}
+                      >
+                        {h.hole}
+                      </Button>
+                    ))}
+                  </div>
+                </>
+              )}
+            </CardContent>
+          )}
+        </Card>
+      </MobileContainer>
+    </Layout>
+  );
+};
+
+export default ShotReplayPage;
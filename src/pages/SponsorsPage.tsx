@@ -0,0 +1,212 @@
+import React, { useState } from 'react';
+import { Layout } from '@/components/Layout';
+import MobileContainer from '@/components/MobileContainer';
+import { Card, CardContent, CardDescription, CardHeader, CardTitle } from '@/components/ui/card';
+import { Badge } from '@/components/ui/badge';
+import { Button } from '@/components/ui/button';
+import { Checkbox } from '@/components/ui/checkbox';
+import { Input } from '@/components/ui/input';
+import { Label } from '@/components/ui/label';
+import { Skeleton } from '@/components/ui/skeleton';
+import { Select, SelectContent, SelectItem, SelectTrigger, SelectValue } from '@/components/ui/select';
+import { useCurrentUser } from '@/hooks/useCurrentUser';
+import { useGolfCourses } from '@/hooks/useGolfCourses';
+import { useSponsorImpressions, useSponsorSlots } from '@/hooks/useSponsorSlots';
+import { useUploadFile } from '@/hooks/useUploadFile';
+import { useToast } from '@/hooks/useToast';
+import type { SponsorPlacement, SponsorSlot } from '@/lib/golf/sponsorEngine';
+
+const PLACEMENTS: { value: SponsorPlacement; label: string }[] = [
+  { value: 'tv', label: 'Clubhouse TV' },
+  { value: 'scorecard', label: 'Scorecards' },
+];
+
+const today = () => new Date().toISOString().split('T')[0];
+
+function slotStatus(slot: SponsorSlot, now: number): { label: string; variant: 'secondary' | 'outline' | 'default' } {
+  if (now >= slot.endsAt) return { label: 'Ended', variant: 'outline' };
+  if (now < slot.startsAt) return { label: 'Scheduled', variant: 'secondary' };
+  return { label: 'Running', variant: 'default' };
+}
+
+export const SponsorsPage: React.FC = () => {
+  const { user } = useCurrentUser();
+  const { toast } = useToast();
+  const { data: courses = [] } = useGolfCourses();
+  const ownCourses = courses.filter(c => c.author === user?.pubkey);
+  const [courseId, setCourseId] = useState('');
+  const course = ownCourses.find(c => c.id === courseId);
+
+  const { data: slots = [], isLoading, saveSlot, isSaving } = useSponsorSlots(course);
+  const { impressions } = useSponsorImpressions();
+  const { mutateAsync: uploadFile, isPending: isUploading } = useUploadFile();
+
+  const [sponsor, setSponsor] = useState('');
+  const [link, setLink] = useState('');
+  const [imageUrl, setImageUrl] = useState('');
+  const [placements, setPlacements] = useState<SponsorPlacement[]>(['tv', 'scorecard']);
+  const [starts, setStarts] = useState(today());
+  const [ends, setEnds] = useState('');
+  const [weight, setWeight] = useState(1);
+
+  const handleUpload = async (file: File | undefined) => {
+    if (!file) return;
+    try {
+      const tags = await uploadFile(file);
+      setImageUrl(tags[0][1]);
+    } catch (error) {
+      toast({ title: 'Upload failed', description: error instanceof Error ? error.message : undefined, variant: 'destructive' });
+    }
+  };
+
+  const handleSave = async () => {
+    try {
+      await saveSlot({
+        sponsor,
+        imageUrl,
+        link: link.trim() || undefined,
+        placements,
+        startsAt: new Date(`${starts}T00:00`).getTime(),
+        endsAt: ends ? new Date(`${ends}T23:59:59`).getTime() : NaN,
+        weight,
+      });
+      toast({ title: 'Sponsor slot published' });
+      setSponsor('');
+      setLink('');
+      setImageUrl('');
+    } catch (error) {
+      toast({ title: 'Could not publish sponsor slot', description: error instanceof Error ? error.message : undefined, variant: 'destructive' });
+    }
+  };
+
+  const handleEnd = async (slot: SponsorSlot) => {
+    try {
+      await saveSlot({ ...slot, endsAt: Math.max(Date.now(), slot.startsAt + 1) });
+      toast({ title: `${slot.sponsor} taken down` });
+    } catch (error) {
+      toast({ title: 'Could not end sponsor slot', description: error instanceof Error ? error.message : undefined, variant: 'destructive' });
+    }
+  };
+
+  const now = Date.now();
+
+  return (
+    <Layout>
+      <MobileContainer className="py-4 space-y-4">
+        <Card>
+          <CardHeader>
+            <CardTitle>Sponsors</CardTitle>
+            <CardDescription>Schedule sponsor banners on your clubhouse TV and players' scorecards</CardDescription>
+          </CardHeader>
+          <CardContent>
+            {!user ? (
+              <p className="text-sm text-muted-foreground">Log in to manage sponsors.</p>
+            ) : ownCourses.length === 0 ? (
+              <p className="text-sm text-muted-foreground">Sponsors can be added to courses you published.</p>
+            ) : (
+              <Select value={courseId} onValueChange={setCourseId}>
+                <SelectTrigger>
+                  <SelectValue placeholder="Select a course" />
+                </SelectTrigger>
+                <SelectContent>
+                  {ownCourses.map(c => (
+                    <SelectItem key={c.id} value={c.id}>{c.name}</SelectItem>
+                  ))}
+                </SelectContent>
+              </Select>
+            )}
+          </CardContent>
+        </Card>
+
+        {course && (
+          <Card>
+            <CardHeader>
+              <CardTitle className="text-lg">Sponsor slots</CardTitle>
+              <CardDescription>Impressions are counted on this device</CardDescription>
+            </CardHeader>
+            <CardContent className="space-y-3">
+              {isLoading ? (
+                <Skeleton className="h-16 w-full" />
+              ) : slots.length === 0 ? (
+                <p className="text-sm text-muted-foreground">No sponsors scheduled yet.</p>
+              ) : (
+                slots.map(slot => {
+                  const status = slotStatus(slot, now);
+                  return (
+                    <div key={slot.slotId} className="flex items-center gap-3 rounded-md border p-2">
+                      <img src={slot.imageUrl} alt={slot.sponsor} className="h-10 w-20 object-contain" />
+                      <div className="flex-1 min-w-0">
+                        <div className="text-sm font-medium truncate">{slot.sponsor}</div>
+                        <div className="text-xs text-muted-foreground">
+                          {new Date(slot.startsAt).toLocaleDateString()} – {new Date(slot.endsAt).toLocaleDateString()}
+                          {' · '}{impressions[slot.slotId]?.count ?? 0} impressions
+                        </div>
+                      </div>
+                      <Badge variant={status.variant}>{status.label}</Badge>
+                      {status.label !== 'Ended' && (
+                        <Button size="sm" variant="ghost" onClick={() => handleEnd(slot)} disabled={isSaving}>End</Button>
+                      )}
+                    </div>
+                  );
+                })
+              )}
+            </CardContent>
+          </Card>
+        )}
+
+        {course && (
+          <Card>
+            <CardHeader>
+              <CardTitle className="text-lg">Add a sponsor</CardTitle>
+            </CardHeader>
+            <CardContent className="space-y-3">
+              <div>
+                <Label htmlFor="sponsor-name">Sponsor</Label>
+                <Input id="sponsor-name" value={sponsor} onChange={e => setSponsor(e.target.value)} />
+              </div>
+              <div>
+                <Label htmlFor="sponsor-banner">Banner</Label>
+                <Input id="sponsor-banner" type="file" accept="image/*" disabled={isUploading} onChange={e => handleUpload(e.target.files?.[0])} />
+                {imageUrl && <img src={imageUrl} alt="Banner preview" className="mt-2 max-h-20 object-contain" />}
+              </div>
+              <div>
+                <Label htmlFor="sponsor-link">Link</Label>
+                <Input id="sponsor-link" type="url" placeholder="https://" value={link} onChange={e => setLink(e.target.value)} />
+              </div>
+              <div className="flex gap-4">
+                {PLACEMENTS.map(p => (
+                  <label key={p.value} className="flex items-center gap-2 text-sm">
+                    <Checkbox
+                      checked={placements.includes(p.value)}
+                      onCheckedChange={checked => setPlacements(prev =>
+                        checked ? [...prev, p.value] : prev.filter(v => v !== p.value)
+                      )}
+                    />
+                    {p.label}
+                  </label>
+                ))}
+              </div>
+              <div className="grid grid-cols-3 gap-2">
+                <div>
+                  <Label htmlFor="sponsor-starts">Starts</Label>
+                  <Input id="sponsor-starts" type="date" value={starts} onChange={e => setStarts(e.target.value)} />
+                </div>
+                <div>
+                  <Label htmlFor="sponsor-ends">Ends</Label>
+                  <Input id="sponsor-ends" type="date" value={ends} onChange={e => setEnds(e.target.value)} />
+                </div>
+                <div>
+                  <Label htmlFor="sponsor-weight">Weight</Label>
+                  <Input id="sponsor-weight" type="number" min={1} max={10} value={weight} onChange={e => setWeight(parseInt(e.target.value) || 1)} />
+                </div>
+              </div>
+              <Button onClick={handleSave} disabled={isSaving || isUploading}>Publish</Button>
+            </CardContent>
+          </Card>
+        )}
+      </MobileContainer>
+    </Layout>
+  );
+};
+
+export default SponsorsPage;
@@ -0,0 +1,246 @@
+import React, { useState } from 'react';
+import { useParams } from 'react-router-dom';
+import { nip19 } from 'nostr-tools';
+import { Layout } from '@/components/Layout';
+import MobileContainer from '@/components/MobileContainer';
+import { Badge } from '@/components/ui/badge';
+import { Button } from '@/components/ui/button';
+import { Card, CardContent, CardDescription, CardHeader, CardTitle } from '@/components/ui/card';
+import { Input } from '@/components/ui/input';
+import { Label } from '@/components/ui/label';
+import { Select, SelectContent, SelectItem, SelectTrigger, SelectValue } from '@/components/ui/select';
+import { Skeleton } from '@/components/ui/skeleton';
+import { Textarea } from '@/components/ui/textarea';
+import { useAuthor } from '@/hooks/useAuthor';
+import { useCurrentUser } from '@/hooks/useCurrentUser';
+import { useTeamEvent } from '@/hooks/useTeamEvent';
+import { useTeamEventScoreboard } from '@/hooks/useTeamEventScoreboard';
+import { useToast } from '@/hooks/useToast';
+import { genUserName } from '@/lib/genUserName';
+import type { TeamEventConfig, TeamMatch, TeamSessionFormat, TeamSide } from '@/lib/golf/teamEventEngine';
+
+const FORMAT_LABELS: Record<TeamSessionFormat, string> = {
+  foursomes: 'Foursomes',
+  fourballs: 'Fourballs',
+  singles: 'Singles',
+};
+
+interface SessionDraft {
+  name: string;
+  format: TeamSessionFormat;
+  roundId: string;
+  lineup: string; // one match per line: "<npub> <npub> v <npub> <npub>"
+}
+
+function toPubkey(value: string): string | null {
+  if (/^[a-f0-9]{64}$/i.test(value)) return value.toLowerCase();
+  try {
+    const decoded = nip19.decode(value);
+    return decoded.type === 'npub' ? decoded.data : null;
+  } catch {
+    return null;
+  }
+}
+
+/** Matches from a line-up, one per line with the sides split by "v" */
+function parseLineup(text: string, sessionId: string): TeamMatch[] {
+  return text.split('\n').map(line => line.trim()).filter(Boolean).map((line, i) => {
+    const [a = '', b = ''] = line.split(/\s+vs?\s+/i);
+    const side = (players: string) => players.split(/[\s,&]+/).filter(Boolean).map(p => {
+      const pubkey = toPubkey(p);
+      if (!pubkey) throw new Error(`Not a player: ${p}`);
+      return pubkey;
+    });
+    return { id: `${sessionId}-${i + 1}`, teamA: side(a), teamB: side(b) };
+  });
+}
+
+function PlayerNames({ pubkeys }: { pubkeys: string[] }) {
+  return <>{pubkeys.map((p, i) => <React.Fragment key={p}>{i > 0 && ' & '}<PlayerName pubkey={p} /></React.Fragment>)}</>;
+}
+
+function PlayerName({ pubkey }: { pubkey: string }) {
+  const author = useAuthor(pubkey);
+  return <>{author.data?.metadata?.name ?? genUserName(pubkey)}</>;
+}
+
+export const TeamEventPage: React.FC = () => {
+  const { eventId } = useParams<{ eventId: string }>();
+  const { user } = useCurrentUser();
+  const { data: teamEvent, isLoading, create, isPublishing } = useTeamEvent(eventId);
+  const { data: board } = useTeamEventScoreboard(teamEvent?.config, teamEvent?.sessionRounds ?? {});
+  const { toast } = useToast();
+
+  const [name, setName] = useState('');
+  const [teamA, setTeamA] = useState('');
+  const [teamB, setTeamB] = useState('');
+  const [sessions, setSessions] = useState<SessionDraft[]>([{ name: '', format: 'fourballs', roundId: '', lineup: '' }]);
+
+  const updateSession = (i: number, change: Partial<SessionDraft>) =>
+    setSessions(current => current.map((s, j) => (j === i ? { ...s, ...change } : s)));
+
+  const handleCreate = async () => {
+    try {
+      if (!name.trim() || !teamA.trim() || !teamB.trim()) throw new Error('Name the event and both teams');
+      const drafts = sessions.map((s, i) => ({ ...s, id: `session-${i + 1}` }));
+      const config: TeamEventConfig = {
+        name: name.trim(),
+        teams: {
+          'Team A': { name: teamA.trim(), players: [] },
+          'Team B': { name: teamB.trim(), players: [] },
+        },
+        sessions: drafts.map(s => ({
+          id: s.id,
+          name: s.name.trim() || FORMAT_LABELS[s.format],
+          format: s.format,
+          matches: parseLineup(s.lineup, s.id),
+        })),
+      };
+      // Each team is everyone who plays for it in a session
+      config.teams['Team A'].players = [...new Set(config.sessions.flatMap(s => s.matches.flatMap(m => m.teamA)))];
+      config.teams['Team B'].players = [...new Set(config.sessions.flatMap(s => s.matches.flatMap(m => m.teamB)))];
+
+      await create({
+        config,
+        sessionRounds: Object.fromEntries(drafts.filter(s => s.roundId.trim()).map(s => [s.id, s.roundId.trim()])),
+      });
+      toast({ title: 'Team event published' });
+    } catch (error) {
+      toast({
+        title: 'Could not publish the event',
+        description: error instanceof Error ? error.message : 'Please try again',
+        variant: 'destructive',
+      });
+    }
+  };
+
+  const teamName = (side: TeamSide) => teamEvent?.config.teams[side].name ?? side;
+  const named = (status: string) => status.replace('Team A', teamName('Team A')).replace('Team B', teamName('Team B'));
+
+  return (
+    <Layout>
+      <MobileContainer className="py-4 space-y-4">
+        {isLoading ? (
+          <Skeleton className="h-40 w-full" />
+        ) : !teamEvent ? (
+          <Card>
+            <CardHeader>
+              <CardTitle>New Team Event</CardTitle>
+              <CardDescription>Foursomes, fourballs and singles sessions, each played as its own round</CardDescription>
+            </CardHeader>
+            <CardContent className="space-y-4">
+              {!user ? (
+                <p className="text-sm text-muted-foreground">Log in to organize a team event.</p>
+              ) : (
+                <>
+                  <div className="space-y-2">
+                    <Label htmlFor="team-event-name">Name</Label>
+                    <Input id="team-event-name" value={name} onChange={(e) => setName(e.target.value)} placeholder="Club Cup" />
+                  </div>
+                  <div className="grid grid-cols-2 gap-3">
+                    <div className="space-y-2">
+                      <Label htmlFor="team-a">First team</Label>
+                      <Input id="team-a" value={teamA} onChange={(e) => setTeamA(e.target.value)} placeholder="Captains" />
+                    </div>
+                    <div className="space-y-2">
+                      <Label htmlFor="team-b">Second team</Label>
+                      <Input id="team-b" value={teamB} onChange={(e) => setTeamB(e.target.value)} placeholder="Vice-captains" />
+                    </div>
+                  </div>
+                  {sessions.map((session, i) => (
+                    <div key={i} className="rounded border p-3 space-y-3">
+                      <div className="grid grid-cols-2 gap-3">
+                        <Input value={session.name} onChange={(e) => updateSession(i, { name: e.target.value })} placeholder={`Session ${i + 1}`} />
+                        <Select value={session.format} onValueChange={(format) => updateSession(i, { format: format as TeamSessionFormat })}>
+                          <SelectTrigger>
+                            <SelectValue />
+                          </SelectTrigger>
+                          <SelectContent>
+                            {(Object.keys(FORMAT_LABELS) as TeamSessionFormat[]).map(f => (
+                              <SelectItem key={f} value={f}>{FORMAT_LABELS[f]}</SelectItem>
+                            ))}
+                          </SelectContent>
+                        </Select>
+                      </div>
+                      <Input value={session.roundId} onChange={(e) => updateSession(i, { roundId: e.target.value })} placeholder="Round id the session is played in" />
+                      <Textarea
+                        value={session.lineup}
+                        onChange={(e) => updateSession(i, { lineup: e.target.value })}
+                        placeholder={session.format === 'singles' ? 'npub… v npub…' : 'npub… npub… v npub… npub…'}
+                        rows={3}
+                      />
+                    </div>
+                  ))}
+                  <Button
+                    variant="outline"
+                    size="sm"
+                    onClick={() => setSessions(current => [...current, { name: '', format: 'singles', roundId: '', lineup: '' }])}
+                  >
+                    Add Session
+                  </Button>
+                  <Button className="w-full" onClick={handleCreate} disabled={isPublishing}>Publish Event</Button>
+                </>
+              )}
+            </CardContent>
+          </Card>
+        ) : (
+          <>
+            <Card>
+              <CardHeader>
+                <CardTitle>{teamEvent.config.name}</CardTitle>
+                <CardDescription>
+                  {board
+                    ? `${board.pointsToWin} points to win${board.winner ? ` · ${teamName(board.winner)} win` : board.retainedBy ? ` · ${teamName(board.retainedBy)} retain` : ''}`
+                    : 'Loading scores…'}
+                </CardDescription>
+              </CardHeader>
+              <CardContent>
+                <div className="grid grid-cols-2 gap-3 text-center">
+                  {(['Team A', 'Team B'] as TeamSide[]).map(side => (
+                    <div key={side} className="rounded border p-3">
+                      <div className="text-sm font-medium">{teamName(side)}</div>
+                      <div className="text-3xl font-bold">{board?.points[side] ?? 0}</div>
+                      {board && board.projectedPoints[side] !== board.points[side] && (
+                        <div className="text-xs text-muted-foreground">projected {board.projectedPoints[side]}</div>
+                      )}
+                    </div>
+                  ))}
+                </div>
+              </CardContent>
+            </Card>
+
+            {board?.sessions.map(session => (
+              <Card key={session.sessionId}>
+                <CardHeader>
+                  <div className="flex items-center justify-between gap-2">
+                    <CardTitle className="text-lg">{session.name}</CardTitle>
+                    <Badge variant="outline">{FORMAT_LABELS[session.format]}</Badge>
+                  </div>
+                  <CardDescription>
+                    {teamName('Team A')} {session.points['Team A']} – {session.points['Team B']} {teamName('Team B')}
+                  </CardDescription>
+                </CardHeader>
+                <CardContent className="space-y-2">
+                  {session.matches.map(result => {
+                    const match = teamEvent.config.sessions
+                      .find(s => s.id === session.sessionId)?.matches.find(m => m.id === result.matchId);
+                    return (
+                      <div key={result.matchId} className="rounded border p-3 text-sm">
+                        <div className="font-medium">
+                          <PlayerNames pubkeys={match?.teamA ?? []} /> v <PlayerNames pubkeys={match?.teamB ?? []} />
+                        </div>
+                        <div className="text-xs text-muted-foreground">{named(result.status)}</div>
+                      </div>
+                    );
+                  })}
+                </CardContent>
+              </Card>
+            ))}
+          </>
+        )}
+      </MobileContainer>
+    </Layout>
+  );
+};
+
+export default TeamEventPage;
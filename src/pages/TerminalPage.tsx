@@ -0,0 +1,200 @@
+import React, { useEffect, useState } from 'react';
+import { useNavigate } from 'react-router-dom';
+import { nip19 } from 'nostr-tools';
+import { Layout } from '@/components/Layout';
+import MobileContainer from '@/components/MobileContainer';
+import { Button } from '@/components/ui/button';
+import { Card, CardContent, CardDescription, CardHeader, CardTitle } from '@/components/ui/card';
+import { Input } from '@/components/ui/input';
+import { Label } from '@/components/ui/label';
+import { Select, SelectContent, SelectItem, SelectTrigger, SelectValue } from '@/components/ui/select';
+import { useAuthor } from '@/hooks/useAuthor';
+import { useDeviceCredential, useScorerDevices } from '@/hooks/useScorerDevices';
+import { useScoringTerminal } from '@/hooks/useScoringTerminal';
+import { useTerminalDelegations } from '@/hooks/useScoringDelegations';
+import { useToast } from '@/hooks/useToast';
+import { genUserName } from '@/lib/genUserName';
+import { parseProvisioning } from '@/lib/golf/deviceEngine';
+
+function PlayerOption({ pubkey }: { pubkey: string }) {
+  const author = useAuthor(pubkey);
+  return <SelectItem value={pubkey}>{author.data?.metadata?.name ?? genUserName(pubkey)}</SelectItem>;
+}
+
+export const TerminalPage: React.FC = () => {
+  const terminal = useScoringTerminal();
+  const { data: delegations = [], isLoading: delegationsLoading } = useTerminalDelegations(terminal.pubkey);
+  const { data: credential, isLoading: credentialLoading } = useDeviceCredential(terminal.pubkey);
+  const { draw } = useScorerDevices(credential?.tournamentId);
+  const { toast } = useToast();
+  const navigate = useNavigate();
+  const [player, setPlayer] = useState('');
+  const [roundId, setRoundId] = useState('');
+  const [holes, setHoles] = useState(18);
+  const [scores, setScores] = useState<Record<string, string>>({});
+  const [tournamentId, setTournamentId] = useState('');
+
+  // Opened from an organizer's QR code: take the device key from the link
+  const { provision } = terminal;
+  useEffect(() => {
+    const provisioning = parseProvisioning(window.location.hash);
+    if (!provisioning) return;
+    provision(provisioning.nsec);
+    window.history.replaceState(null, '', window.location.pathname);
+    setRoundId(provisioning.tournamentId);
+    toast({ title: 'Device provisioned', description: `This device now scores group ${provisioning.group}.` });
+  }, [provision, toast]);
+
+  // A provisioned tablet scores the players in its group of the organizer's draw
+  const groupPlayers = credential && draw?.organizer === credential.issuer
+    ? draw.groups.find(g => g.group === credential.group)?.players.map(p => p.id) ?? []
+    : [];
+  const players = [...new Set([...groupPlayers, ...delegations.map(d => d.delegator)])];
+  const isLoading = delegationsLoading || credentialLoading;
+
+  const handleSubmit = async () => {
+    const entered: Record<string, number> = {};
+    for (const [hole, value] of Object.entries(scores)) {
+      const strokes = parseInt(value);
+      if (strokes > 0 && Number(hole) <= holes) entered[hole] = strokes;
+    }
+    if (!player || !roundId.trim() || Object.keys(entered).length === 0) {
+      toast({ title: 'Missing details', description: 'Pick a player, enter the round and at least one score.', variant: 'destructive' });
+      return;
+    }
+
+    try {
+      await terminal.publishScore({ player, roundId: roundId.trim(), scores: entered });
+      toast({ title: 'Score card posted' });
+      setScores({});
+    } catch (error) {
+      toast({
+        title: 'Could not post the card',
+        description: error instanceof Error ? error.message : 'Please try again',
+        variant: 'destructive',
+      });
+    }
+  };
+
+  return (
+    <Layout>
+      <MobileContainer className="py-4 space-y-4">
+        <Card>
+          <CardHeader>
+            <CardTitle>Scoring Terminal</CardTitle>
+            <CardDescription>
+              Post score cards for players who have delegated to this device
+            </CardDescription>
+          </CardHeader>
+          <CardContent className="space-y-3">
+            {terminal.pubkey ? (
+              <>
+                <div className="space-y-1">
+                  <Label>Terminal key</Label>
+                  <code className="text-xs bg-muted p-2 rounded block break-all">{nip19.npubEncode(terminal.pubkey)}</code>
+                  <p className="text-xs text-muted-foreground">
+                    Players add this key under Scoring Terminals on their account page.
+                  </p>
+                </div>
+                {credential && (
+                  <p className="text-sm">
+                    Scoring group {credential.group} of {credential.tournamentId} until{' '}
+                    {new Date(credential.until * 1000).toLocaleTimeString([], { hour: '2-digit', minute: '2-digit' })}
+                    {credential.label && ` · ${credential.label}`}
+                  </p>
+                )}
+                <Button variant="outline" size="sm" onClick={terminal.reset}>Retire this terminal</Button>
+              </>
+            ) : (
+              <Button onClick={terminal.setUp}>Set up this device as a terminal</Button>
+            )}
+          </CardContent>
+        </Card>
+
+        {terminal.pubkey && (
+          <Card>
+            <CardHeader>
+              <CardTitle className="text-lg">Enter Scores</CardTitle>
+            </CardHeader>
+            <CardContent className="space-y-4">
+              {!isLoading && players.length === 0 ? (
+                <p className="text-sm text-muted-foreground">No players have delegated to this terminal yet.</p>
+              ) : (
+                <>
+                  <div className="grid grid-cols-2 gap-3">
+                    <div className="space-y-2">
+                      <Label>Player</Label>
+                      <Select value={player} onValueChange={setPlayer}>
+                        <SelectTrigger>
+                          <SelectValue placeholder="Choose player" />
+                        </SelectTrigger>
+                        <SelectContent>
+                          {players.map(pubkey => <PlayerOption key={pubkey} pubkey={pubkey} />)}
+                        </SelectContent>
+                      </Select>
+                    </div>
+                    <div className="space-y-2">
+                      <Label>Holes</Label>
+                      <Select value={String(holes)} onValueChange={(value) => setHoles(Number(value))}>
+                        <SelectTrigger>
+                          <SelectValue />
+                        </SelectTrigger>
+                        <SelectContent>
+                          <SelectItem value="9">9</SelectItem>
+                          <SelectItem value="18">18</SelectItem>
+                        </SelectContent>
+                      </Select>
+                    </div>
+                  </div>
+                  <div className="space-y-2">
+                    <Label htmlFor="terminal-round">Round</Label>
+                    <Input id="terminal-round" value={roundId} onChange={(e) => setRoundId(e.target.value)} placeholder="Round ID" />
+                  </div>
+                  <div className="grid grid-cols-6 gap-2">
+                    {Array.from({ length: holes }, (_, i) => String(i + 1)).map(hole => (
+                      <div key={hole} className="space-y-1">
+                        <Label htmlFor={`terminal-hole-${hole}`} className="text-xs">{hole}</Label>
+                        <Input
+                          id={`terminal-hole-${hole}`}
+                          type="number"
+                          min={1}
+                          inputMode="numeric"
+                          value={scores[hole] ?? ''}
+                          onChange={(e) => setScores(prev => ({ ...prev, [hole]: e.target.value }))}
+                        />
+                      </div>
+                    ))}
+                  </div>
+                  <Button className="w-full" onClick={handleSubmit} disabled={terminal.isPublishing}>
+                    {terminal.isPublishing ? 'Posting...' : 'Post Score Card'}
+                  </Button>
+                </>
+              )}
+            </CardContent>
+          </Card>
+        )}
+
+        <Card>
+          <CardHeader>
+            <CardTitle className="text-lg">Scorer Devices</CardTitle>
+            <CardDescription>
+              Organizers can provision borrowed tablets to score a group, with no member logged in on them
+            </CardDescription>
+          </CardHeader>
+          <CardContent className="flex gap-2">
+            <Input value={tournamentId} onChange={(e) => setTournamentId(e.target.value)} placeholder="Tournament ID" />
+            <Button
+              variant="outline"
+              disabled={!tournamentId.trim()}
+              onClick={() => navigate(`/tournaments/${encodeURIComponent(tournamentId.trim())}/devices`)}
+            >
+              Manage
+            </Button>
+          </CardContent>
+        </Card>
+      </MobileContainer>
+    </Layout>
+  );
+};
+
+export default TerminalPage;
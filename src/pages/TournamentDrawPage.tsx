@@ -0,0 +1,226 @@
+import React, { useState } from 'react';
+import { useParams } from 'react-router-dom';
+import { Layout } from '@/components/Layout';
+import MobileContainer from '@/components/MobileContainer';
+import { Badge } from '@/components/ui/badge';
+import { Button } from '@/components/ui/button';
+import { Card, CardContent, CardDescription, CardHeader, CardTitle } from '@/components/ui/card';
+import { Input } from '@/components/ui/input';
+import { Label } from '@/components/ui/label';
+import { Select, SelectContent, SelectItem, SelectTrigger, SelectValue } from '@/components/ui/select';
+import { Skeleton } from '@/components/ui/skeleton';
+import { Switch } from '@/components/ui/switch';
+import { useToast } from '@/hooks/useToast';
+import { useTournamentDraw } from '@/hooks/useTournamentDraw';
+import { useTournamentField } from '@/hooks/useTournamentField';
+import type { DrawMethod } from '@/lib/golf/drawEngine';
+
+const METHOD_LABELS: Record<DrawMethod, string> = {
+  'handicap-balanced': 'Balanced by handicap',
+  seeded: 'Seeded (top seeds out last)',
+  random: 'Random',
+};
+
+const formatTime = (time: number) => new Date(time).toLocaleTimeString([], { hour: '2-digit', minute: '2-digit' });
+
+export const TournamentDrawPage: React.FC = () => {
+  const { tournamentId } = useParams<{ tournamentId: string }>();
+  const { data: draw, organizer, isOrganizer, isLoading, publishDraw, shiftTeeTimes, isPublishing } = useTournamentDraw(tournamentId);
+  const { data: field = [], isLoading: isFieldLoading } = useTournamentField(tournamentId, isOrganizer ? organizer : null);
+  const { toast } = useToast();
+
+  const [method, setMethod] = useState<DrawMethod>('handicap-balanced');
+  const [firstTee, setFirstTee] = useState('');
+  const [intervalMinutes, setIntervalMinutes] = useState('10');
+  const [groupSize, setGroupSize] = useState('4');
+  const [twoTees, setTwoTees] = useState(false);
+  const [force, setForce] = useState(false);
+
+  const [delay, setDelay] = useState('');
+  const [fromGroup, setFromGroup] = useState('all');
+  const [forceShift, setForceShift] = useState(false);
+
+  const handlePublish = async () => {
+    const firstTeeTime = new Date(firstTee).getTime();
+    if (field.length === 0 || isNaN(firstTeeTime)) {
+      toast({ title: 'Check the details', description: 'The draw needs players and a first tee time.', variant: 'destructive' });
+      return;
+    }
+    try {
+      const published = await publishDraw({
+        players: field,
+        config: {
+          method,
+          firstTeeTime,
+          intervalMinutes: Number(intervalMinutes) || 10,
+          groupSize: Number(groupSize),
+          startingTees: twoTees ? 2 : 1,
+          randomSeed: method === 'random' ? `${tournamentId}:${firstTeeTime}` : undefined,
+        },
+        force,
+      });
+      toast({ title: 'Draw published', description: `${published.groups.length} groups, first off at ${formatTime(firstTeeTime)}` });
+    } catch (error) {
+      toast({
+        title: 'Could not publish the draw',
+        description: error instanceof Error ? error.message : 'Please try again',
+        variant: 'destructive',
+      });
+    }
+  };
+
+  const handleShift = async () => {
+    const minutes = Number(delay);
+    if (!minutes) {
+      toast({ title: 'Check the details', description: 'Enter how many minutes to move the tee times.', variant: 'destructive' });
+      return;
+    }
+    try {
+      await shiftTeeTimes({
+        minutes,
+        fromGroup: fromGroup === 'all' ? undefined : Number(fromGroup),
+        force: forceShift,
+      });
+      setDelay('');
+      toast({ title: 'Tee times moved', description: 'The players have been notified of their new tee times.' });
+    } catch (error) {
+      toast({
+        title: 'Could not move the tee times',
+        description: error instanceof Error ? error.message : 'Please try again',
+        variant: 'destructive',
+      });
+    }
+  };
+
+  return (
+    <Layout>
+      <MobileContainer className="py-4 space-y-4">
+        <Card>
+          <CardHeader>
+            <CardTitle>Draw</CardTitle>
+            <CardDescription>Tee times and pairings for {tournamentId}</CardDescription>
+          </CardHeader>
+          <CardContent className="space-y-2">
+            {isLoading ? (
+              <Skeleton className="h-24 w-full" />
+            ) : !draw ? (
+              <p className="text-sm text-muted-foreground">The draw has not been published yet.</p>
+            ) : (
+              draw.groups.map(group => (
+                <div key={group.group} className="rounded border p-3 space-y-1">
+                  <div className="flex items-center justify-between">
+                    <span className="text-sm font-medium">Group {group.group} · {formatTime(group.teeTime)}</span>
+                    {group.startingHole === 10 && <Badge variant="outline">10th tee</Badge>}
+                  </div>
+                  <div className="text-xs text-muted-foreground">
+                    {group.players.map(p => `${p.name} (${p.handicap.toFixed(1)})`).join(', ')}
+                  </div>
+                </div>
+              ))
+            )}
+          </CardContent>
+        </Card>
+
+        {isOrganizer && draw && (
+          <Card>
+            <CardHeader>
+              <CardTitle className="text-lg">Move Tee Times</CardTitle>
+              <CardDescription>Delay or bring forward the tee sheet, e.g. for frost</CardDescription>
+            </CardHeader>
+            <CardContent className="space-y-4">
+              <div className="grid grid-cols-2 gap-3">
+                <div className="space-y-2">
+                  <Label htmlFor="shift-minutes">Minutes (negative is earlier)</Label>
+                  <Input id="shift-minutes" type="number" value={delay} onChange={(e) => setDelay(e.target.value)} placeholder="30" />
+                </div>
+                <div className="space-y-2">
+                  <Label>From group</Label>
+                  <Select value={fromGroup} onValueChange={setFromGroup}>
+                    <SelectTrigger>
+                      <SelectValue />
+                    </SelectTrigger>
+                    <SelectContent>
+                      <SelectItem value="all">All groups</SelectItem>
+                      {draw.groups.map(g => (
+                        <SelectItem key={g.group} value={String(g.group)}>Group {g.group} · {formatTime(g.teeTime)}</SelectItem>
+                      ))}
+                    </SelectContent>
+                  </Select>
+                </div>
+              </div>
+              <div className="flex items-center justify-between">
+                <Label htmlFor="shift-force">Move even with crossovers</Label>
+                <Switch id="shift-force" checked={forceShift} onCheckedChange={setForceShift} />
+              </div>
+              <Button className="w-full" onClick={handleShift} disabled={isPublishing}>Move Tee Times</Button>
+            </CardContent>
+          </Card>
+        )}
+
+        {isOrganizer && (
+          <Card>
+            <CardHeader>
+              <CardTitle className="text-lg">{draw ? 'Redo the Draw' : 'Make the Draw'}</CardTitle>
+              <CardDescription>
+                {isFieldLoading
+                  ? 'Loading the field…'
+                  : `${field.length} player${field.length === 1 ? '' : 's'} from the tournament round, with their current index`}
+              </CardDescription>
+            </CardHeader>
+            <CardContent className="space-y-4">
+              <div className="space-y-2">
+                <Label>Method</Label>
+                <Select value={method} onValueChange={(value) => setMethod(value as DrawMethod)}>
+                  <SelectTrigger>
+                    <SelectValue />
+                  </SelectTrigger>
+                  <SelectContent>
+                    {(Object.keys(METHOD_LABELS) as DrawMethod[]).map(m => (
+                      <SelectItem key={m} value={m}>{METHOD_LABELS[m]}</SelectItem>
+                    ))}
+                  </SelectContent>
+                </Select>
+              </div>
+              <div className="space-y-2">
+                <Label htmlFor="draw-first-tee">First tee time</Label>
+                <Input id="draw-first-tee" type="datetime-local" value={firstTee} onChange={(e) => setFirstTee(e.target.value)} />
+              </div>
+              <div className="grid grid-cols-2 gap-3">
+                <div className="space-y-2">
+                  <Label htmlFor="draw-interval">Minutes between groups</Label>
+                  <Input id="draw-interval" type="number" min={5} value={intervalMinutes} onChange={(e) => setIntervalMinutes(e.target.value)} />
+                </div>
+                <div className="space-y-2">
+                  <Label>Group size</Label>
+                  <Select value={groupSize} onValueChange={setGroupSize}>
+                    <SelectTrigger>
+                      <SelectValue />
+                    </SelectTrigger>
+                    <SelectContent>
+                      {['2', '3', '4'].map(size => (
+                        <SelectItem key={size} value={size}>{size}-balls</SelectItem>
+                      ))}
+                    </SelectContent>
+                  </Select>
+                </div>
+              </div>
+              <div className="flex items-center justify-between">
+                <Label htmlFor="draw-two-tees">Two-tee start (1st and 10th)</Label>
+                <Switch id="draw-two-tees" checked={twoTees} onCheckedChange={setTwoTees} />
+              </div>
+              <div className="flex items-center justify-between">
+                <Label htmlFor="draw-force">Publish even with crossovers</Label>
+                <Switch id="draw-force" checked={force} onCheckedChange={setForce} />
+              </div>
+              <Button className="w-full" onClick={handlePublish} disabled={isPublishing || isFieldLoading}>
+                {draw ? 'Republish Draw' : 'Publish Draw'}
+              </Button>
+            </CardContent>
+          </Card>
+        )}
+      </MobileContainer>
+    </Layout>
+  );
+};
+
+export default TournamentDrawPage;
@@ -0,0 +1,144 @@
+import React, { useEffect, useMemo, useState } from 'react';
+import { useParams, useSearchParams } from 'react-router-dom';
+import { Maximize } from 'lucide-react';
+import { Button } from '@/components/ui/button';
+import { Skeleton } from '@/components/ui/skeleton';
+import { ClubActivityFeed } from '@/components/golf/ClubActivityFeed';
+import { useAuthor } from '@/hooks/useAuthor';
+import { useGolfCourses } from '@/hooks/useGolfCourses';
+import { useTournamentLive } from '@/hooks/useTournamentLive';
+import { useSponsorImpressions, useSponsorSlots } from '@/hooks/useSponsorSlots';
+import { genUserName } from '@/lib/genUserName';
+import { formatToPar, parseKioskConfig, type KioskSlide, type LiveStanding } from '@/lib/golf/kioskEngine';
+import { activeSlots, sponsorForRotation } from '@/lib/golf/sponsorEngine';
+
+// Rows that fit on a 1080p display at kiosk sizes
+const LEADERBOARD_ROWS = 10;
+
+const SLIDE_TITLES: Record<KioskSlide, string> = {
+  leaderboard: 'Leaderboard',
+  highlights: 'Highlights',
+  sponsors: 'Our sponsors',
+};
+
+function StandingRow({ standing }: { standing: LiveStanding }) {
+  const author = useAuthor(standing.playerPubkey);
+  const name = author.data?.metadata?.name ?? genUserName(standing.playerPubkey);
+
+  return (
+    <tr className="border-b border-white/10">
+      <td className="py-4 pr-6 text-3xl text-white/60 tabular-nums">{standing.tied ? `T${standing.position}` : standing.position}</td>
+      <td className="py-4 text-4xl font-medium truncate">{name}</td>
+      <td className="py-4 px-6 text-3xl text-right text-white/60 tabular-nums">{standing.thru === 18 ? 'F' : standing.thru}</td>
+      <td className="py-4 text-4xl font-bold text-right tabular-nums">
+        {standing.toPar === null ? standing.gross : formatToPar(standing.toPar)}
+      </td>
+    </tr>
+  );
+}
+
+/**
+ * Full-screen clubhouse display for a tournament, rotating between slides.
+ * Configured from the URL: /tv/<tournament>?slides=leaderboard,highlights&rotate=20&course=<name>
+ */
+export const TvPage: React.FC = () => {
+  const { tournamentId } = useParams<{ tournamentId: string }>();
+  const [searchParams] = useSearchParams();
+  const config = useMemo(() => parseKioskConfig(searchParams), [searchParams]);
+
+  const { data: courses = [] } = useGolfCourses();
+  const course = courses.find(c => c.name === config.course || c.id === config.course);
+  const pars = useMemo(() => {
+    if (!course) return [];
+    return Object.keys(course.holes).map(Number).sort((a, b) => a - b).map(hole => course.holes[hole]);
+  }, [course]);
+
+  const { data, isLoading } = useTournamentLive(tournamentId, pars);
+  const [slideIndex, setSlideIndex] = useState(0);
+  const [isFullscreen, setIsFullscreen] = useState(!!document.fullscreenElement);
+
+  useEffect(() => {
+    const onChange = () => setIsFullscreen(!!document.fullscreenElement);
+    document.addEventListener('fullscreenchange', onChange);
+    return () => document.removeEventListener('fullscreenchange', onChange);
+  }, []);
+
+  useEffect(() => {
+    const timer = setInterval(() => setSlideIndex(i => i + 1), config.rotateSeconds * 1000);
+    return () => clearInterval(timer);
+  }, [config.rotateSeconds]);
+
+  // The sponsors slide only rotates in while the club has banners running
+  const { data: sponsorSlots = [] } = useSponsorSlots(course);
+  const { recordImpression } = useSponsorImpressions();
+  const tvSponsors = activeSlots(sponsorSlots, 'tv', Date.now());
+  const slides = config.slides.filter(s => s !== 'sponsors' || tvSponsors.length > 0);
+  const slide = slides.length > 0 ? slides[slideIndex % slides.length] : 'leaderboard';
+  const sponsor = slide === 'sponsors' ? sponsorForRotation(tvSponsors, Math.floor(slideIndex / slides.length)) : null;
+  const sponsorId = sponsor?.slotId;
+
+  useEffect(() => {
+    if (sponsorId) recordImpression(sponsorId);
+  }, [sponsorId, slideIndex, recordImpression]);
+
+  return (
+    <div className="min-h-screen bg-neutral-950 text-white p-12 flex flex-col">
+      <header className="flex items-center justify-between mb-10">
+        <h1 className="text-5xl font-bold">{SLIDE_TITLES[slide]}</h1>
+        <div className="flex items-center gap-6">
+          {course && <span className="text-3xl text-white/60">{course.name}</span>}
+          {!isFullscreen && (
+            <Button variant="ghost" size="icon" onClick={() => document.documentElement.requestFullscreen()} title="Full screen">
+              <Maximize className="h-6 w-6" />
+            </Button>
+          )}
+        </div>
+      </header>
+
+      <main className="flex-1">
+        {isLoading ? (
+          <div className="space-y-6">
+            <Skeleton className="h-14 w-full bg-white/10" />
+            <Skeleton className="h-14 w-full bg-white/10" />
+            <Skeleton className="h-14 w-full bg-white/10" />
+          </div>
+        ) : slide === 'leaderboard' ? (
+          data && data.standings.length > 0 ? (
+            <table className="w-full table-fixed">
+              <colgroup>
+                <col className="w-28" />
+                <col />
+                <col className="w-36" />
+                <col className="w-32" />
+              </colgroup>
+              <thead>
+                <tr className="text-2xl text-white/50 text-left">
+                  <th className="pb-4 font-normal">Pos</th>
+                  <th className="pb-4 font-normal">Player</th>
+                  <th className="pb-4 px-6 font-normal text-right">Thru</th>
+                  <th className="pb-4 font-normal text-right">Score</th>
+                </tr>
+              </thead>
+              <tbody>
+                {data.standings.slice(0, LEADERBOARD_ROWS).map(standing => (
+                  <StandingRow key={standing.playerPubkey} standing={standing} />
+                ))}
+              </tbody>
+            </table>
+          ) : (
+            <p className="text-3xl text-white/60">No scores posted yet.</p>
+          )
+        ) : slide === 'sponsors' && sponsor ? (
+          <div className="h-full flex flex-col items-center justify-center gap-8">
+            <img src={sponsor.imageUrl} alt={sponsor.sponsor} className="max-h-[60vh] max-w-full object-contain" />
+            <p className="text-4xl font-medium">{sponsor.sponsor}</p>
+          </div>
+        ) : (
+          <ClubActivityFeed items={data?.highlights.slice(0, 8)} large />
+        )}
+      </main>
+    </div>
+  );
+};
+
+export default TvPage;
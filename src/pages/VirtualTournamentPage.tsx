@@ -0,0 +1,217 @@
+import React, { useState } from 'react';
+import { Link, useParams } from 'react-router-dom';
+import { nip19 } from 'nostr-tools';
+import { Layout } from '@/components/Layout';
+import MobileContainer from '@/components/MobileContainer';
+import { Avatar, AvatarFallback, AvatarImage } from '@/components/ui/avatar';
+import { Badge } from '@/components/ui/badge';
+import { Button } from '@/components/ui/button';
+import { Card, CardContent, CardDescription, CardHeader, CardTitle } from '@/components/ui/card';
+import { Input } from '@/components/ui/input';
+import { Label } from '@/components/ui/label';
+import { Select, SelectContent, SelectItem, SelectTrigger, SelectValue } from '@/components/ui/select';
+import { Skeleton } from '@/components/ui/skeleton';
+import { useAuthor } from '@/hooks/useAuthor';
+import { useCurrentUser } from '@/hooks/useCurrentUser';
+import { useToast } from '@/hooks/useToast';
+import { useRoundsInWindow, useVirtualTournament } from '@/hooks/useVirtualTournament';
+import { genUserName } from '@/lib/genUserName';
+import type { VirtualPhase, VirtualStanding } from '@/lib/golf/virtualTournamentEngine';
+
+const DAY_MS = 24 * 60 * 60 * 1000;
+
+const PHASE_LABELS: Record<VirtualPhase, string> = {
+  upcoming: 'Not open yet',
+  playing: 'Open for play',
+  submitting: 'Submissions closing',
+  closed: 'Final',
+};
+
+function StandingRow({ standing, isPlayer }: { standing: VirtualStanding; isPlayer: boolean }) {
+  const author = useAuthor(standing.player);
+  const metadata = author.data?.metadata;
+  const name = metadata?.name ?? genUserName(standing.player);
+
+  return (
+    <div className={`flex items-center gap-3 rounded border p-3 ${isPlayer ? 'border-primary' : ''}`}>
+      <span className="w-6 text-center font-bold">{standing.position}</span>
+      <Link to={`/${nip19.npubEncode(standing.player)}`}>
+        <Avatar className="h-8 w-8">
+          <AvatarImage src={metadata?.picture} />
+          <AvatarFallback className="text-xs">{name.charAt(0)}</AvatarFallback>
+        </Avatar>
+      </Link>
+      <div className="min-w-0 flex-1">
+        <div className="text-sm font-medium truncate">{name}</div>
+        <div className="text-xs text-muted-foreground truncate">
+          {standing.gross} at {standing.course} · differential {standing.differential.toFixed(1)}
+        </div>
+      </div>
+      <Badge variant="secondary">{standing.net > 0 ? '+' : ''}{standing.net.toFixed(1)}</Badge>
+    </div>
+  );
+}
+
+export const VirtualTournamentPage: React.FC = () => {
+  const { tournamentId } = useParams<{ tournamentId: string }>();
+  const { user } = useCurrentUser();
+  const { tournament, phase, standings, myEntry, isLoading, create, submit, isPublishing } = useVirtualTournament(tournamentId);
+  const { data: rounds = [] } = useRoundsInWindow(tournament);
+  const { toast } = useToast();
+
+  const [name, setName] = useState('');
+  const [opens, setOpens] = useState('');
+  const [closes, setCloses] = useState('');
+  const [deadlineDays, setDeadlineDays] = useState('1');
+
+  const [roundId, setRoundId] = useState('');
+
+  const handleCreate = async () => {
+    if (!name.trim() || !opens || !closes) {
+      toast({ title: 'Check the details', description: 'Give the tournament a name and its playing window.', variant: 'destructive' });
+      return;
+    }
+    try {
+      const closesAt = new Date(`${closes}T23:59:59`).getTime();
+      await create({
+        name: name.trim(),
+        opens: new Date(`${opens}T00:00:00`).getTime(),
+        closes: closesAt,
+        deadline: closesAt + Number(deadlineDays) * DAY_MS,
+      });
+    } catch (error) {
+      toast({
+        title: 'Could not create the tournament',
+        description: error instanceof Error ? error.message : 'Please try again',
+        variant: 'destructive',
+      });
+    }
+  };
+
+  const handleSubmit = async () => {
+    const round = rounds.find(r => r.roundId === roundId);
+    if (!round) {
+      toast({ title: 'Pick a round', description: 'Choose the round you want to submit.', variant: 'destructive' });
+      return;
+    }
+    if (!round.courseRating || !round.slope) {
+      toast({ title: 'No course rating', description: 'The course has no rating and slope for the tee you played.', variant: 'destructive' });
+      return;
+    }
+    try {
+      await submit(round);
+      toast({ title: 'Card submitted', description: `${round.gross} at ${round.course}` });
+    } catch (error) {
+      toast({
+        title: 'Could not submit the card',
+        description: error instanceof Error ? error.message : 'Please try again',
+        variant: 'destructive',
+      });
+    }
+  };
+
+  const canSubmit = !!user && (phase === 'playing' || phase === 'submitting');
+
+  return (
+    <Layout>
+      <MobileContainer className="py-4 space-y-4">
+        {isLoading ? (
+          <Skeleton className="h-40 w-full" />
+        ) : !tournament ? (
+          <Card>
+            <CardHeader>
+              <CardTitle>New Virtual Tournament</CardTitle>
+              <CardDescription>Players play any course during the window and submit one card, compared by net differential</CardDescription>
+            </CardHeader>
+            <CardContent className="space-y-4">
+              {!user ? (
+                <p className="text-sm text-muted-foreground">Log in to organize a virtual tournament.</p>
+              ) : (
+                <>
+                  <div className="space-y-2">
+                    <Label htmlFor="virtual-name">Name</Label>
+                    <Input id="virtual-name" value={name} onChange={(e) => setName(e.target.value)} placeholder="Spring Open" />
+                  </div>
+                  <div className="grid grid-cols-2 gap-3">
+                    <div className="space-y-2">
+                      <Label htmlFor="virtual-opens">First day</Label>
+                      <Input id="virtual-opens" type="date" value={opens} onChange={(e) => setOpens(e.target.value)} />
+                    </div>
+                    <div className="space-y-2">
+                      <Label htmlFor="virtual-closes">Last day</Label>
+                      <Input id="virtual-closes" type="date" value={closes} onChange={(e) => setCloses(e.target.value)} />
+                    </div>
+                  </div>
+                  <div className="space-y-2">
+                    <Label htmlFor="virtual-deadline">Days to submit after the last day</Label>
+                    <Input id="virtual-deadline" type="number" min={0} value={deadlineDays} onChange={(e) => setDeadlineDays(e.target.value)} />
+                  </div>
+                  <Button className="w-full" onClick={handleCreate} disabled={isPublishing}>Create Tournament</Button>
+                </>
+              )}
+            </CardContent>
+          </Card>
+        ) : (
+          <>
+            <Card>
+              <CardHeader>
+                <div className="flex items-center justify-between gap-2">
+                  <CardTitle>{tournament.name}</CardTitle>
+                  {phase && <Badge variant={phase === 'closed' ? 'default' : 'outline'}>{PHASE_LABELS[phase]}</Badge>}
+                </div>
+                <CardDescription>
+                  Play {new Date(tournament.opens).toLocaleDateString()} – {new Date(tournament.closes).toLocaleDateString()} ·
+                  submit by {new Date(tournament.deadline).toLocaleString()}
+                </CardDescription>
+              </CardHeader>
+              <CardContent className="space-y-2">
+                {standings.length === 0 ? (
+                  <p className="text-sm text-muted-foreground">No cards yet.</p>
+                ) : (
+                  standings.map(standing => (
+                    <StandingRow key={standing.player} standing={standing} isPlayer={standing.player === user?.pubkey} />
+                  ))
+                )}
+              </CardContent>
+            </Card>
+
+            {canSubmit && (
+              <Card>
+                <CardHeader>
+                  <CardTitle className="text-lg">Submit Your Card</CardTitle>
+                  <CardDescription>
+                    {myEntry
+                      ? `Submitted ${myEntry.gross} at ${myEntry.course}. A new card replaces it.`
+                      : 'Normalized with your handicap index going into the round, from your earlier cards'}
+                  </CardDescription>
+                </CardHeader>
+                <CardContent className="space-y-4">
+                  <div className="space-y-2">
+                    <Label>Round</Label>
+                    <Select value={roundId} onValueChange={setRoundId}>
+                      <SelectTrigger>
+                        <SelectValue placeholder={rounds.length > 0 ? 'Choose round' : 'No rounds in the window'} />
+                      </SelectTrigger>
+                      <SelectContent>
+                        {rounds.map(round => (
+                          <SelectItem key={round.roundId} value={round.roundId}>
+                            {round.gross} at {round.course} · {new Date(round.playedAt).toLocaleDateString()}
+                            {round.courseRating && round.slope ? ` · ${round.courseRating}/${round.slope}` : ' · no rating'}
+                            {` · index ${round.handicapIndex ?? 0}`}
+                          </SelectItem>
+                        ))}
+                      </SelectContent>
+                    </Select>
+                  </div>
+                  <Button className="w-full" onClick={handleSubmit} disabled={isPublishing || !roundId}>Submit Card</Button>
+                </CardContent>
+              </Card>
+            )}
+          </>
+        )}
+      </MobileContainer>
+    </Layout>
+  );
+};
+
+export default VirtualTournamentPage;
@@ -0,0 +1,397 @@
+import React, { useEffect, useState } from 'react';
+import { Link, useNavigate, useParams } from 'react-router-dom';
+import QRCode from 'qrcode';
+import { Layout } from '@/components/Layout';
+import MobileContainer from '@/components/MobileContainer';
+import { Card, CardContent, CardDescription, CardHeader, CardTitle } from '@/components/ui/card';
+import { Badge } from '@/components/ui/badge';
+import { Button } from '@/components/ui/button';
+import { Input } from '@/components/ui/input';
+import { Label } from '@/components/ui/label';
+import { Skeleton } from '@/components/ui/skeleton';
+import { Textarea } from '@/components/ui/textarea';
+import { Select, SelectContent, SelectItem, SelectTrigger, SelectValue } from '@/components/ui/select';
+import { useCurrentUser } from '@/hooks/useCurrentUser';
+import { useGolfCourses } from '@/hooks/useGolfCourses';
+import { useToast } from '@/hooks/useToast';
+import { useVouchers } from '@/hooks/useVouchers';
+import {
+  VOUCHER_VALIDITY_MONTHS,
+  expiringVouchers,
+  formatVoucherAmount,
+  hashVoucherCode,
+  voucherBalance,
+  voucherRedemptions,
+  voucherStatus,
+  voucherUrl,
+  type Voucher,
+  type VoucherKind,
+  type VoucherStatus,
+} from '@/lib/golf/voucherEngine';
+
+const STATUS_BADGES: Record<VoucherStatus, { label: string; variant: 'default' | 'secondary' | 'destructive' | 'outline' }> = {
+  active: { label: 'Active', variant: 'default' },
+  used: { label: 'Used', variant: 'secondary' },
+  expired: { label: 'Expired', variant: 'outline' },
+  void: { label: 'Cancelled', variant: 'destructive' },
+};
+
+/** Date input value a year (or so) from now */
+function defaultExpiry(): string {
+  const date = new Date();
+  date.setMonth(date.getMonth() + VOUCHER_VALIDITY_MONTHS);
+  return date.toISOString().split('T')[0];
+}
+
+function QrImage({ value, label }: { value: string; label: string }) {
+  const [src, setSrc] = useState('');
+
+  useEffect(() => {
+    QRCode.toDataURL(value, { width: 256, margin: 2 })
+      .then(setSrc)
+      .catch((err) => console.warn('Could not draw voucher QR code', err));
+  }, [value]);
+
+  return src ? <img src={src} alt={label} className="mx-auto h-48 w-48" /> : <Skeleton className="mx-auto h-48 w-48" />;
+}
+
+export const VouchersPage: React.FC = () => {
+  const { courseId, voucherId } = useParams<{ courseId: string; voucherId?: string }>();
+  const navigate = useNavigate();
+  const { user } = useCurrentUser();
+  const { toast } = useToast();
+  const { data: courses = [], isLoading: coursesLoading } = useGolfCourses();
+  const course = courses.find(c => c.id === courseId);
+  const { vouchers, redemptions, isLoading, issueVoucher, redeemVoucher, cancelVoucher, isPublishing } = useVouchers(course);
+
+  const [kind, setKind] = useState<VoucherKind>('value');
+  const [amount, setAmount] = useState('');
+  const [currency, setCurrency] = useState('USD');
+  const [recipient, setRecipient] = useState('');
+  const [message, setMessage] = useState('');
+  const [expires, setExpires] = useState(defaultExpiry);
+  const [issued, setIssued] = useState<{ voucher: Omit<Voucher, 'createdAt'>; code: string } | null>(null);
+  const [lookupCode, setLookupCode] = useState('');
+
+  // The code travels in the link's fragment
+  const [code, setCode] = useState(() => decodeURIComponent(window.location.hash.slice(1)));
+  const [redeemAmount, setRedeemAmount] = useState('');
+  const [note, setNote] = useState('');
+
+  const isClub = !!user && user.pubkey === course?.author;
+  const now = Date.now();
+
+  const handleIssue = async () => {
+    const value = Number(amount);
+    if (!(value > 0) || (kind === 'rounds' && !Number.isInteger(value))) {
+      toast({ title: kind === 'rounds' ? 'Enter a number of rounds' : 'Enter the voucher value', variant: 'destructive' });
+      return;
+    }
+    try {
+      const result = await issueVoucher({
+        kind,
+        amount: value,
+        currency: currency.trim() || 'USD',
+        recipient: recipient.trim(),
+        message: message.trim(),
+        expiresAt: new Date(`${expires}T23:59:59`).getTime(),
+      });
+      setIssued(result);
+      setAmount('');
+      setRecipient('');
+      setMessage('');
+    } catch (error) {
+      toast({ title: 'Could not issue the voucher', description: error instanceof Error ? error.message : undefined, variant: 'destructive' });
+    }
+  };
+
+  const handleLookup = async () => {
+    const hash = await hashVoucherCode(lookupCode);
+    const match = vouchers.find(v => v.codeHash === hash);
+    if (!match) {
+      toast({ title: 'No voucher with that code', variant: 'destructive' });
+      return;
+    }
+    setCode(lookupCode.trim());
+    navigate(`/courses/${course!.id}/vouchers/${match.voucherId}`);
+  };
+
+  const handleRedeem = async (voucher: Voucher) => {
+    try {
+      await redeemVoucher({ voucher, code, amount: voucher.kind === 'rounds' && !redeemAmount ? 1 : Number(redeemAmount), note: note.trim() });
+      toast({ title: 'Voucher redeemed' });
+      setRedeemAmount('');
+      setNote('');
+    } catch (error) {
+      toast({ title: 'Could not redeem the voucher', description: error instanceof Error ? error.message : undefined, variant: 'destructive' });
+    }
+  };
+
+  const handleCancel = async (voucher: Voucher) => {
+    try {
+      await cancelVoucher(voucher);
+      toast({ title: 'Voucher cancelled' });
+    } catch (error) {
+      toast({ title: 'Could not cancel the voucher', description: error instanceof Error ? error.message : undefined, variant: 'destructive' });
+    }
+  };
+
+  if (coursesLoading) {
+    return (
+      <Layout>
+        <MobileContainer className="py-4">
+          <Skeleton className="h-32 w-full" />
+        </MobileContainer>
+      </Layout>
+    );
+  }
+
+  if (!course) {
+    return (
+      <Layout>
+        <MobileContainer className="py-4">
+          <Card>
+            <CardContent className="py-6 text-sm text-muted-foreground">Course not found.</CardContent>
+          </Card>
+        </MobileContainer>
+      </Layout>
+    );
+  }
+
+  if (voucherId) {
+    const voucher = vouchers.find(v => v.voucherId === voucherId);
+    if (!voucher) {
+      return (
+        <Layout>
+          <MobileContainer className="py-4">
+            {isLoading ? (
+              <Skeleton className="h-32 w-full" />
+            ) : (
+              <Card>
+                <CardContent className="py-6 text-sm text-muted-foreground">Voucher not found.</CardContent>
+              </Card>
+            )}
+          </MobileContainer>
+        </Layout>
+      );
+    }
+
+    const status = voucherStatus(voucher, redemptions, now);
+    const history = voucherRedemptions(voucher, redemptions);
+
+    return (
+      <Layout>
+        <MobileContainer className="py-4 space-y-4">
+          <Card>
+            <CardHeader className="flex flex-row items-center justify-between space-y-0">
+              <div>
+                <CardTitle>{course.name} Gift Voucher</CardTitle>
+                <CardDescription>{voucher.recipient && `For ${voucher.recipient}`}</CardDescription>
+              </div>
+              <Badge variant={STATUS_BADGES[status].variant}>{STATUS_BADGES[status].label}</Badge>
+            </CardHeader>
+            <CardContent className="space-y-3">
+              {voucher.message && <p className="text-sm italic">{voucher.message}</p>}
+              <div className="text-2xl font-bold">{formatVoucherAmount(voucher, voucherBalance(voucher, redemptions))}</div>
+              <div className="text-sm text-muted-foreground">
+                of {formatVoucherAmount(voucher, voucher.amount)} · valid until {new Date(voucher.expiresAt).toLocaleDateString()}
+              </div>
+              {code && status === 'active' && (
+                <>
+                  <QrImage value={voucherUrl(window.location.origin, voucher, code)} label="Voucher QR code" />
+                  <p className="text-center font-mono text-sm">{code}</p>
+                  <p className="text-center text-xs text-muted-foreground">Show this at the pro shop. Keep the code private.</p>
+                </>
+              )}
+            </CardContent>
+          </Card>
+
+          {isClub && (
+            <Card>
+              <CardHeader>
+                <CardTitle className="text-lg">Redeem</CardTitle>
+              </CardHeader>
+              <CardContent className="space-y-3">
+                <div className="grid grid-cols-2 gap-3">
+                  <div className="space-y-2">
+                    <Label htmlFor="voucher-code">Code</Label>
+                    <Input id="voucher-code" value={code} onChange={(e) => setCode(e.target.value)} className="font-mono" />
+                  </div>
+                  <div className="space-y-2">
+                    <Label htmlFor="redeem-amount">{voucher.kind === 'rounds' ? 'Rounds' : `Amount (${voucher.currency})`}</Label>
+                    <Input
+                      id="redeem-amount"
+                      type="number"
+                      step={voucher.kind === 'rounds' ? 1 : 0.01}
+                      value={redeemAmount}
+                      onChange={(e) => setRedeemAmount(e.target.value)}
+                      placeholder={voucher.kind === 'rounds' ? '1' : undefined}
+                    />
+                  </div>
+                </div>
+                <Input value={note} onChange={(e) => setNote(e.target.value)} placeholder="Note (optional)" />
+                <Button className="w-full" onClick={() => handleRedeem(voucher)} disabled={isPublishing || status !== 'active'}>
+                  Redeem
+                </Button>
+                {history.map(r => (
+                  <div key={r.redemptionId} className="flex justify-between text-sm">
+                    <span>{new Date(r.createdAt).toLocaleDateString()}{r.note && ` · ${r.note}`}</span>
+                    <span>{formatVoucherAmount(voucher, r.amount)}</span>
+                  </div>
+                ))}
+                {status === 'active' && (
+                  <Button variant="outline" className="w-full" onClick={() => handleCancel(voucher)} disabled={isPublishing}>
+                    Cancel Voucher
+                  </Button>
+                )}
+              </CardContent>
+            </Card>
+          )}
+        </MobileContainer>
+      </Layout>
+    );
+  }
+
+  if (!isClub) {
+    return (
+      <Layout>
+        <MobileContainer className="py-4">
+          <Card>
+            <CardContent className="py-6 text-sm text-muted-foreground">
+              Gift vouchers for {course.name} are issued by the club. Open the link on your voucher to see its balance.
+            </CardContent>
+          </Card>
+        </MobileContainer>
+      </Layout>
+    );
+  }
+
+  const expiring = expiringVouchers(vouchers, redemptions, now);
+
+  return (
+    <Layout>
+      <MobileContainer className="py-4 space-y-4">
+        <Card>
+          <CardHeader>
+            <CardTitle>Gift Vouchers</CardTitle>
+            <CardDescription>{course.name}</CardDescription>
+          </CardHeader>
+          <CardContent className="space-y-3">
+            <div className="flex gap-2">
+              <Input value={lookupCode} onChange={(e) => setLookupCode(e.target.value)} placeholder="Voucher code" className="font-mono" />
+              <Button variant="outline" onClick={handleLookup} disabled={!lookupCode.trim()}>Find</Button>
+            </div>
+          </CardContent>
+        </Card>
+
+        {issued && (
+          <Card>
+            <CardHeader>
+              <CardTitle className="text-lg">Voucher Issued</CardTitle>
+              <CardDescription>
+                Print or send this now. The code is not stored anywhere else and can't be shown again.
+              </CardDescription>
+            </CardHeader>
+            <CardContent className="space-y-2 text-center">
+              <QrImage value={voucherUrl(window.location.origin, issued.voucher, issued.code)} label="Voucher QR code" />
+              <p className="font-mono">{issued.code}</p>
+              <p className="text-sm">{formatVoucherAmount(issued.voucher, issued.voucher.amount)}{issued.voucher.recipient && ` for ${issued.voucher.recipient}`}</p>
+              <p className="break-all text-xs text-muted-foreground">{voucherUrl(window.location.origin, issued.voucher, issued.code)}</p>
+            </CardContent>
+          </Card>
+        )}
+
+        <Card>
+          <CardHeader>
+            <CardTitle className="text-lg">Issue a Voucher</CardTitle>
+          </CardHeader>
+          <CardContent className="space-y-3">
+            <div className="grid grid-cols-2 gap-3">
+              <div className="space-y-2">
+                <Label>Type</Label>
+                <Select value={kind} onValueChange={(value) => setKind(value as VoucherKind)}>
+                  <SelectTrigger>
+                    <SelectValue />
+                  </SelectTrigger>
+                  <SelectContent>
+                    <SelectItem value="value">Value</SelectItem>
+                    <SelectItem value="rounds">Rounds</SelectItem>
+                  </SelectContent>
+                </Select>
+              </div>
+              <div className="space-y-2">
+                <Label htmlFor="voucher-amount">{kind === 'rounds' ? 'Rounds' : 'Value'}</Label>
+                <Input id="voucher-amount" type="number" step={kind === 'rounds' ? 1 : 0.01} value={amount} onChange={(e) => setAmount(e.target.value)} />
+              </div>
+              {kind === 'value' && (
+                <div className="space-y-2">
+                  <Label htmlFor="voucher-currency">Currency</Label>
+                  <Input id="voucher-currency" value={currency} onChange={(e) => setCurrency(e.target.value)} />
+                </div>
+              )}
+              <div className="space-y-2">
+                <Label htmlFor="voucher-expires">Valid until</Label>
+                <Input id="voucher-expires" type="date" value={expires} onChange={(e) => setExpires(e.target.value)} />
+              </div>
+            </div>
+            <Input value={recipient} onChange={(e) => setRecipient(e.target.value)} placeholder="Recipient's name" />
+            <Textarea value={message} onChange={(e) => setMessage(e.target.value)} placeholder="Message (optional)" rows={2} />
+            <Button className="w-full" onClick={handleIssue} disabled={isPublishing}>Issue Voucher</Button>
+          </CardContent>
+        </Card>
+
+        {expiring.length > 0 && (
+          <Card>
+            <CardHeader>
+              <CardTitle className="text-lg">Expiring Soon</CardTitle>
+            </CardHeader>
+            <CardContent className="space-y-1 text-sm">
+              {expiring.map(v => (
+                <div key={v.voucherId} className="flex justify-between">
+                  <Link to={`/courses/${course.id}/vouchers/${v.voucherId}`} className="text-primary hover:underline">
+                    {v.recipient || 'Voucher'} · {formatVoucherAmount(v, voucherBalance(v, redemptions))}
+                  </Link>
+                  <span className="text-muted-foreground">{new Date(v.expiresAt).toLocaleDateString()}</span>
+                </div>
+              ))}
+            </CardContent>
+          </Card>
+        )}
+
+        <Card>
+          <CardHeader>
+            <CardTitle className="text-lg">All Vouchers</CardTitle>
+          </CardHeader>
+          <CardContent className="space-y-2">
+            {isLoading ? (
+              <Skeleton className="h-16 w-full" />
+            ) : vouchers.length === 0 ? (
+              <p className="text-sm text-muted-foreground">No vouchers issued yet.</p>
+            ) : (
+              vouchers.map(v => {
+                const status = voucherStatus(v, redemptions, now);
+                return (
+                  <Link
+                    key={v.voucherId}
+                    to={`/courses/${course.id}/vouchers/${v.voucherId}`}
+                    className="flex items-center justify-between gap-2 rounded border p-3 hover:bg-muted"
+                  >
+                    <div className="min-w-0">
+                      <div className="text-sm font-medium truncate">{v.recipient || 'Voucher'}</div>
+                      <div className="text-xs text-muted-foreground">
+                        {formatVoucherAmount(v, voucherBalance(v, redemptions))} left of {formatVoucherAmount(v, v.amount)}
+                      </div>
+                    </div>
+                    <Badge variant={STATUS_BADGES[status].variant}>{STATUS_BADGES[status].label}</Badge>
+                  </Link>
+                );
+              })
+            )}
+          </CardContent>
+        </Card>
+      </MobileContainer>
+    </Layout>
+  );
+};
+
+export default VouchersPage;